@@ -176,6 +176,35 @@ load _helpers
   [ "${actual}" = "1" ]
 }
 
+#--------------------------------------------------------------------
+# apiGateway
+
+@test "connectInject/ClusterRole: no gateway.networking.k8s.io access by default" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/connect-inject-clusterrole.yaml  \
+      --set 'connectInject.enabled=true' \
+      . | tee /dev/stderr |
+      yq -r '.rules | map(select(.apiGroups[0] == "gateway.networking.k8s.io")) | length' | tee /dev/stderr)
+  [ "${actual}" = "0" ]
+}
+
+@test "connectInject/ClusterRole: allows gateway.networking.k8s.io and deployments access with connectInject.apiGateway.enabled=true" {
+  cd `chart_dir`
+  local rules=$(helm template \
+      -s templates/connect-inject-clusterrole.yaml  \
+      --set 'connectInject.enabled=true' \
+      --set 'connectInject.apiGateway.enabled=true' \
+      . | tee /dev/stderr |
+      yq -r '.rules' | tee /dev/stderr)
+
+  local actual=$(echo $rules | yq -r 'map(select(.apiGroups[0] == "gateway.networking.k8s.io")) | length' | tee /dev/stderr)
+  [ "${actual}" = "2" ]
+
+  local actual=$(echo $rules | yq -r 'map(select(.apiGroups[0] == "apps" and .resources[0] == "deployments")) | .[0].verbs | index("create")' | tee /dev/stderr)
+  [ "${actual}" != null ]
+}
+
 #--------------------------------------------------------------------
 # vault
 
@@ -1419,6 +1419,44 @@ load _helpers
   [[ "$output" =~ "setting global.peering.enabled to true requires connectInject.enabled to be true" ]]
 }
 
+#--------------------------------------------------------------------
+# apiGateway
+
+@test "connectInject/Deployment: -enable-gateway-api is not set by default" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/connect-inject-deployment.yaml  \
+      --set 'connectInject.enabled=true' \
+      . | tee /dev/stderr |
+      yq '.spec.template.spec.containers[0].command | any(contains("-enable-gateway-api=true"))' | tee /dev/stderr)
+
+  [ "${actual}" = "false" ]
+}
+
+@test "connectInject/Deployment: Gateway API flags are set when connectInject.apiGateway.enabled is true" {
+  cd `chart_dir`
+  local cmd=$(helm template \
+      -s templates/connect-inject-deployment.yaml  \
+      --set 'connectInject.enabled=true' \
+      --set 'connectInject.apiGateway.enabled=true' \
+      --set 'connectInject.apiGateway.managedGatewayClass.serviceType=NodePort' \
+      --set 'connectInject.apiGateway.managedGatewayClass.replicas=3' \
+      . | tee /dev/stderr |
+      yq '.spec.template.spec.containers[0].command' | tee /dev/stderr)
+
+  local actual=$(echo "$cmd" |
+    yq 'any(contains("-enable-gateway-api=true"))' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+
+  local actual=$(echo "$cmd" |
+    yq 'any(contains("-gateway-api-service-type=NodePort"))' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+
+  local actual=$(echo "$cmd" |
+    yq 'any(contains("-gateway-api-replicas=3"))' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+}
+
 @test "connectInject/Deployment: fails if peering is enabled but tls is not" {
   cd `chart_dir`
   run helm template \
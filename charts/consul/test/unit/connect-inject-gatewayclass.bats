@@ -0,0 +1,38 @@
+#!/usr/bin/env bats
+
+load _helpers
+
+@test "connectInject/GatewayClass: disabled by default" {
+  cd `chart_dir`
+  assert_empty helm template \
+      -s templates/connect-inject-gatewayclass.yaml  \
+      .
+}
+
+@test "connectInject/GatewayClass: enabled with connectInject.apiGateway.enabled=true" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/connect-inject-gatewayclass.yaml  \
+      --set 'connectInject.apiGateway.enabled=true' \
+      . | tee /dev/stderr |
+      yq -r '.spec.controllerName' | tee /dev/stderr)
+  [ "${actual}" = "consul.hashicorp.com/gateway-controller" ]
+}
+
+@test "connectInject/GatewayClass: disabled with connectInject.apiGateway.managedGatewayClass.enabled=false" {
+  cd `chart_dir`
+  assert_empty helm template \
+      -s templates/connect-inject-gatewayclass.yaml  \
+      --set 'connectInject.apiGateway.enabled=true' \
+      --set 'connectInject.apiGateway.managedGatewayClass.enabled=false' \
+      .
+}
+
+@test "connectInject/GatewayClass: disabled with connectInject.enabled=false" {
+  cd `chart_dir`
+  assert_empty helm template \
+      -s templates/connect-inject-gatewayclass.yaml  \
+      --set 'connectInject.enabled=false' \
+      --set 'connectInject.apiGateway.enabled=true' \
+      .
+}
@@ -20,3 +20,66 @@ load _helpers
       yq 'length > 0' | tee /dev/stderr)
   [ "${actual}" = "true" ]
 }
+
+@test "controller/MutatingWebhookConfiguration: no consulkvs webhook by default" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/controller-mutatingwebhookconfiguration.yaml  \
+      --set 'controller.enabled=true' \
+      . | tee /dev/stderr |
+      yq '.webhooks | map(select(.name == "mutate-consulkvs.consul.hashicorp.com")) | length' | tee /dev/stderr)
+  [ "${actual}" = "0" ]
+}
+
+@test "controller/MutatingWebhookConfiguration: consulkvs webhook with controller.consulKV.enabled=true" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/controller-mutatingwebhookconfiguration.yaml  \
+      --set 'controller.enabled=true' \
+      --set 'controller.consulKV.enabled=true' \
+      . | tee /dev/stderr |
+      yq '.webhooks | map(select(.name == "mutate-consulkvs.consul.hashicorp.com")) | length' | tee /dev/stderr)
+  [ "${actual}" = "1" ]
+}
+
+@test "controller/MutatingWebhookConfiguration: no registrations webhook by default" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/controller-mutatingwebhookconfiguration.yaml  \
+      --set 'controller.enabled=true' \
+      . | tee /dev/stderr |
+      yq '.webhooks | map(select(.name == "mutate-registrations.consul.hashicorp.com")) | length' | tee /dev/stderr)
+  [ "${actual}" = "0" ]
+}
+
+@test "controller/MutatingWebhookConfiguration: registrations webhook with controller.registrations.enabled=true" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/controller-mutatingwebhookconfiguration.yaml  \
+      --set 'controller.enabled=true' \
+      --set 'controller.registrations.enabled=true' \
+      . | tee /dev/stderr |
+      yq '.webhooks | map(select(.name == "mutate-registrations.consul.hashicorp.com")) | length' | tee /dev/stderr)
+  [ "${actual}" = "1" ]
+}
+
+@test "controller/MutatingWebhookConfiguration: no externalservices webhook by default" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/controller-mutatingwebhookconfiguration.yaml  \
+      --set 'controller.enabled=true' \
+      . | tee /dev/stderr |
+      yq '.webhooks | map(select(.name == "mutate-externalservices.consul.hashicorp.com")) | length' | tee /dev/stderr)
+  [ "${actual}" = "0" ]
+}
+
+@test "controller/MutatingWebhookConfiguration: externalservices webhook with controller.externalServices.enabled=true" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/controller-mutatingwebhookconfiguration.yaml  \
+      --set 'controller.enabled=true' \
+      --set 'controller.externalServices.enabled=true' \
+      . | tee /dev/stderr |
+      yq '.webhooks | map(select(.name == "mutate-externalservices.consul.hashicorp.com")) | length' | tee /dev/stderr)
+  [ "${actual}" = "1" ]
+}
@@ -24,3 +24,20 @@ load _helpers
       yq -s 'length > 0' | tee /dev/stderr)
   [ "${actual}" = "true" ]
 }
+
+@test "proxyDefaults/CustomResourceDefinition: uses the controller's conversion webhook" {
+  cd `chart_dir`
+  local object=$(helm template \
+      -s templates/crd-proxydefaults.yaml  \
+      . | tee /dev/stderr |
+      yq -s '.[1].spec.conversion' | tee /dev/stderr)
+
+  local actual=$(echo $object | yq -r '.strategy' | tee /dev/stderr)
+  [ "${actual}" = "Webhook" ]
+
+  local actual=$(echo $object | yq -r '.webhook.clientConfig.service.name' | tee /dev/stderr)
+  [ "${actual}" = "release-name-consul-controller-webhook" ]
+
+  local actual=$(echo $object | yq -r '.webhook.clientConfig.service.path' | tee /dev/stderr)
+  [ "${actual}" = "/convert" ]
+}
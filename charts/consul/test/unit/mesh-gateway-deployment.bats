@@ -1004,6 +1004,29 @@ key2: value2' \
     [ "${actual}" = "443" ]
 }
 
+@test "meshGateway/Deployment: wanAddress.resolveHostnames is not set by default" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/mesh-gateway-deployment.yaml  \
+      --set 'meshGateway.enabled=true' \
+      --set 'connectInject.enabled=true' \
+      . | tee /dev/stderr |
+      yq -r '.spec.template.metadata.annotations["consul.hashicorp.com/gateway-wan-address-resolve"]' | tee /dev/stderr)
+  [ "${actual}" = "null" ]
+}
+
+@test "meshGateway/Deployment: wanAddress.resolveHostnames can be enabled" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/mesh-gateway-deployment.yaml  \
+      --set 'meshGateway.enabled=true' \
+      --set 'connectInject.enabled=true' \
+      --set 'meshGateway.wanAddress.resolveHostnames=true' \
+      . | tee /dev/stderr |
+      yq -r '.spec.template.metadata.annotations["consul.hashicorp.com/gateway-wan-address-resolve"]' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+}
+
 @test "meshGateway/Deployment: mesh-gateway-init init container wanAddress.source=Service, type=LoadBalancer" {
   cd `chart_dir`
   local annotations=$(helm template \
@@ -0,0 +1,116 @@
+// Package acls handles creating and updating Consul ACL resources. It is
+// shared by server-acl-init and the controllers for the ACL custom resources.
+package acls
+
+import (
+	"fmt"
+	"strings"
+
+	capi "github.com/hashicorp/consul/api"
+)
+
+// PolicyUpdateCheck is called by CreateOrUpdatePolicy with the existing
+// policy when a policy with the same name already exists. It returns true if
+// the existing policy should be updated, or an error if it must not be
+// touched.
+type PolicyUpdateCheck func(existing *capi.ACLPolicy) (bool, error)
+
+// RoleUpdateCheck is the equivalent of PolicyUpdateCheck for roles.
+type RoleUpdateCheck func(existing *capi.ACLRole) (bool, error)
+
+// CreateOrUpdatePolicy creates policy in Consul. If a policy with the same
+// name already exists, check decides whether it is updated. A nil check
+// always updates. The returned policy is the policy as stored in Consul, or
+// nil if the existing policy was left alone.
+func CreateOrUpdatePolicy(client *capi.Client, policy capi.ACLPolicy, check PolicyUpdateCheck, opts *capi.WriteOptions) (*capi.ACLPolicy, error) {
+	// Attempt to create the ACL policy.
+	created, _, err := client.ACL().PolicyCreate(&policy, opts)
+	if !IsPolicyExistsErr(err, policy.Name) {
+		return created, err
+	}
+
+	// The policy ID is required in any PolicyUpdate call, so first we need to
+	// get the existing policy to extract its ID.
+	existing, _, err := client.ACL().PolicyReadByName(policy.Name, queryOptions(policy.Namespace, policy.Partition, opts))
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("policy %q already exists but could not be read", policy.Name)
+	}
+	if check != nil {
+		update, err := check(existing)
+		if err != nil || !update {
+			return nil, err
+		}
+	}
+
+	policy.ID = existing.ID
+	updated, _, err := client.ACL().PolicyUpdate(&policy, opts)
+	return updated, err
+}
+
+// CreateOrUpdateRole creates role in Consul. If a role with the same name
+// already exists, check decides whether it is updated. A nil check always
+// updates. The returned role is the role as stored in Consul, or nil if the
+// existing role was left alone.
+func CreateOrUpdateRole(client *capi.Client, role capi.ACLRole, check RoleUpdateCheck, opts *capi.WriteOptions) (*capi.ACLRole, error) {
+	existing, _, err := client.ACL().RoleReadByName(role.Name, queryOptions(role.Namespace, role.Partition, opts))
+	if err != nil {
+		return nil, fmt.Errorf("reading role %q: %w", role.Name, err)
+	}
+	if existing != nil {
+		if check != nil {
+			update, err := check(existing)
+			if err != nil || !update {
+				return nil, err
+			}
+		}
+		role.ID = existing.ID
+		updated, _, err := client.ACL().RoleUpdate(&role, opts)
+		if err != nil {
+			return nil, fmt.Errorf("updating role %q: %w", role.Name, err)
+		}
+		return updated, nil
+	}
+	created, _, err := client.ACL().RoleCreate(&role, opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating role %q: %w", role.Name, err)
+	}
+	return created, nil
+}
+
+// IsPolicyExistsErr returns true if err is due to trying to call the
+// policy create API when the policy already exists.
+func IsPolicyExistsErr(err error, policyName string) bool {
+	return err != nil &&
+		strings.Contains(err.Error(), "Unexpected response code: 500") &&
+		strings.Contains(err.Error(), fmt.Sprintf("Invalid Policy: A Policy with Name %q already exists", policyName))
+}
+
+// IsACLNotFoundErr returns true if err is due to reading an ACL policy that
+// doesn't exist. Consul returns a 403 rather than a 404 in this case.
+func IsACLNotFoundErr(err error) bool {
+	return err != nil &&
+		strings.Contains(err.Error(), "Unexpected response code: 403") &&
+		strings.Contains(err.Error(), "ACL not found")
+}
+
+// queryOptions returns query options that target the namespace and
+// partition of a resource. Namespaces and partitions set on the resource take
+// precedence over those set in opts, as they do for writes.
+func queryOptions(namespace, partition string, opts *capi.WriteOptions) *capi.QueryOptions {
+	q := &capi.QueryOptions{
+		Namespace: namespace,
+		Partition: partition,
+	}
+	if opts != nil {
+		if q.Namespace == "" {
+			q.Namespace = opts.Namespace
+		}
+		if q.Partition == "" {
+			q.Partition = opts.Partition
+		}
+	}
+	return q
+}
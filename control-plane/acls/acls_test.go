@@ -0,0 +1,156 @@
+package acls
+
+import (
+	"errors"
+	"testing"
+
+	capi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOrUpdatePolicy(t *testing.T) {
+	cases := map[string]struct {
+		existing    *capi.ACLPolicy
+		check       PolicyUpdateCheck
+		expRules    string
+		expReturned bool
+		expErr      string
+	}{
+		"creates policy": {
+			expRules:    `service "web" { policy = "write" }`,
+			expReturned: true,
+		},
+		"updates existing policy with nil check": {
+			existing:    &capi.ACLPolicy{Name: "policy", Rules: `service "web" { policy = "read" }`},
+			expRules:    `service "web" { policy = "write" }`,
+			expReturned: true,
+		},
+		"updates existing policy when check passes": {
+			existing:    &capi.ACLPolicy{Name: "policy", Rules: `service "web" { policy = "read" }`},
+			check:       func(*capi.ACLPolicy) (bool, error) { return true, nil },
+			expRules:    `service "web" { policy = "write" }`,
+			expReturned: true,
+		},
+		"skips update when check returns false": {
+			existing: &capi.ACLPolicy{Name: "policy", Rules: `service "web" { policy = "read" }`},
+			check:    func(*capi.ACLPolicy) (bool, error) { return false, nil },
+			expRules: `service "web" { policy = "read" }`,
+		},
+		"returns error from check": {
+			existing: &capi.ACLPolicy{Name: "policy", Rules: `service "web" { policy = "read" }`},
+			check:    func(*capi.ACLPolicy) (bool, error) { return false, errors.New("not ours") },
+			expRules: `service "web" { policy = "read" }`,
+			expErr:   "not ours",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := testClient(t)
+			if c.existing != nil {
+				_, _, err := client.ACL().PolicyCreate(c.existing, nil)
+				require.NoError(t, err)
+			}
+
+			returned, err := CreateOrUpdatePolicy(client, capi.ACLPolicy{
+				Name:  "policy",
+				Rules: `service "web" { policy = "write" }`,
+			}, c.check, nil)
+			if c.expErr != "" {
+				require.EqualError(t, err, c.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, c.expReturned, returned != nil)
+
+			policy, _, err := client.ACL().PolicyReadByName("policy", nil)
+			require.NoError(t, err)
+			require.Equal(t, c.expRules, policy.Rules)
+			if returned != nil {
+				require.Equal(t, policy.ID, returned.ID)
+			}
+		})
+	}
+}
+
+func TestCreateOrUpdateRole(t *testing.T) {
+	client := testClient(t)
+	for _, name := range []string{"policy-1", "policy-2"} {
+		_, _, err := client.ACL().PolicyCreate(&capi.ACLPolicy{Name: name}, nil)
+		require.NoError(t, err)
+	}
+
+	// Create the role.
+	created, err := CreateOrUpdateRole(client, capi.ACLRole{
+		Name:     "role",
+		Policies: []*capi.ACLRolePolicyLink{{Name: "policy-1"}},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+
+	// Updating it keeps the ID and replaces the policies.
+	updated, err := CreateOrUpdateRole(client, capi.ACLRole{
+		Name:     "role",
+		Policies: []*capi.ACLRolePolicyLink{{Name: "policy-2"}},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, updated.ID)
+
+	role, _, err := client.ACL().RoleRead(created.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, role.Policies, 1)
+	require.Equal(t, "policy-2", role.Policies[0].Name)
+
+	// A check that rejects the update leaves the role alone.
+	skipped, err := CreateOrUpdateRole(client, capi.ACLRole{
+		Name:     "role",
+		Policies: []*capi.ACLRolePolicyLink{{Name: "policy-1"}},
+	}, func(*capi.ACLRole) (bool, error) { return false, nil }, nil)
+	require.NoError(t, err)
+	require.Nil(t, skipped)
+
+	role, _, err = client.ACL().RoleRead(created.ID, nil)
+	require.NoError(t, err)
+	require.Equal(t, "policy-2", role.Policies[0].Name)
+}
+
+func TestIsPolicyExistsErr(t *testing.T) {
+	require.False(t, IsPolicyExistsErr(nil, "policy"))
+	require.False(t, IsPolicyExistsErr(errors.New("Unexpected response code: 500 (other error)"), "policy"))
+	require.True(t, IsPolicyExistsErr(errors.New(`Unexpected response code: 500 (Invalid Policy: A Policy with Name "policy" already exists)`), "policy"))
+}
+
+func TestIsACLNotFoundErr(t *testing.T) {
+	require.False(t, IsACLNotFoundErr(nil))
+	require.False(t, IsACLNotFoundErr(errors.New("Unexpected response code: 403 (Permission denied)")))
+	require.True(t, IsACLNotFoundErr(errors.New("Unexpected response code: 403 (ACL not found)")))
+}
+
+func testClient(t *testing.T) *capi.Client {
+	t.Helper()
+	bootToken := "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"
+	server, err := testutil.NewTestServerConfigT(t, func(c *testutil.TestServerConfig) {
+		c.ACL.Enabled = true
+		c.ACL.Tokens.InitialManagement = bootToken
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = server.Stop()
+	})
+	server.WaitForLeader(t)
+
+	client, err := capi.NewClient(&capi.Config{
+		Address: server.HTTPAddr,
+		Token:   bootToken,
+	})
+	require.NoError(t, err)
+
+	// The bootstrap token is only usable once the leader has initialized ACLs.
+	retry.Run(t, func(r *retry.R) {
+		_, _, err := client.ACL().TokenReadSelf(nil)
+		require.NoError(r, err)
+	})
+	return client
+}
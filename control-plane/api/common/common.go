@@ -8,9 +8,30 @@ const (
 	ServiceRouter      string = "servicerouter"
 	ServiceSplitter    string = "servicesplitter"
 	ServiceIntentions  string = "serviceintentions"
+	ServiceIntention   string = "serviceintention"
 	ExportedServices   string = "exportedservices"
 	IngressGateway     string = "ingressgateway"
 	TerminatingGateway string = "terminatinggateway"
+	APIGateway         string = "apigateway"
+	HTTPRoute          string = "httproute"
+	TCPRoute           string = "tcproute"
+	InlineCertificate  string = "inlinecertificate"
+	JWTProvider        string = "jwtprovider"
+	SamenessGroup      string = "samenessgroup"
+
+	// Resources that are synced to Consul but aren't config entries.
+	ConsulNamespace string = "consulnamespace"
+	ConsulPartition string = "consulpartition"
+	ACLPolicy       string = "aclpolicy"
+	ACLRole         string = "aclrole"
+	ACLBindingRule  string = "aclbindingrule"
+	ConsulKV        string = "consulkv"
+	PreparedQuery   string = "preparedquery"
+	ExternalService string = "externalservice"
+	Registration    string = "registration"
+
+	// Resources that configure other resources rather than Consul.
+	TrafficShift string = "trafficshift"
 
 	Global                 string = "global"
 	Mesh                   string = "mesh"
@@ -20,7 +41,22 @@ const (
 
 	SourceKey        string = "external-source"
 	DatacenterKey    string = "consul.hashicorp.com/source-datacenter"
+	ClusterIDKey     string = "consul.hashicorp.com/source-cluster-id"
+	ResourceKey      string = "consul.hashicorp.com/source-resource"
 	MigrateEntryKey  string = "consul.hashicorp.com/migrate-entry"
 	MigrateEntryTrue string = "true"
 	SourceValue      string = "kubernetes"
+
+	// SourceKindKey is set on config entries that are aggregated from
+	// several resources of the given kind rather than managed by one.
+	SourceKindKey string = "consul.hashicorp.com/source-kind"
+
+	// ReconcileKey is the annotation that controls how changes to a config
+	// entry resource are synced to Consul. If it is ReconcilePaused, changes
+	// are not written to Consul. If it is ReconcileDryRun, the change that
+	// would be made is recorded in the resource's status instead. Deleting
+	// the resource still deletes the config entry.
+	ReconcileKey    string = "consul.hashicorp.com/reconcile"
+	ReconcilePaused string = "paused"
+	ReconcileDryRun string = "dry-run"
 )
@@ -46,6 +46,40 @@ type ConfigEntryResource interface {
 	SyncedCondition() (status corev1.ConditionStatus, reason, message string)
 	// SyncedConditionStatus returns the status of the synced condition.
 	SyncedConditionStatus() corev1.ConditionStatus
+	// SetInSyncCondition updates the condition that reports whether the config
+	// entry in Consul still matches the resource.
+	SetInSyncCondition(status corev1.ConditionStatus, reason, message string)
+	// InSyncCondition gets the in sync condition.
+	InSyncCondition() (status corev1.ConditionStatus, reason, message string)
+	// SetOwnershipConflictCondition updates the condition that reports
+	// whether the config entry in Consul is owned by another cluster or
+	// resource.
+	SetOwnershipConflictCondition(status corev1.ConditionStatus, reason, message string)
+	// OwnershipConflictCondition gets the ownership conflict condition.
+	OwnershipConflictCondition() (status corev1.ConditionStatus, reason, message string)
+	// SetReconcileSuspendedCondition updates the condition that reports
+	// whether changes to the resource are not written to Consul because of
+	// its reconcile annotation.
+	SetReconcileSuspendedCondition(status corev1.ConditionStatus, reason, message string)
+	// ReconcileSuspendedCondition gets the reconcile suspended condition.
+	ReconcileSuspendedCondition() (status corev1.ConditionStatus, reason, message string)
+	// SetDryRun records the config entry that would be written to Consul
+	// and the fields that would change.
+	SetDryRun(generation int64, operation, configEntry string, diff []string)
+	// ClearDryRun removes the change recorded by SetDryRun.
+	ClearDryRun()
+	// SetSyncedGeneration records the generation of the resource that was
+	// last synced with Consul.
+	SetSyncedGeneration(generation int64)
+	// GetSyncedGeneration returns the generation of the resource that was
+	// last synced with Consul.
+	GetSyncedGeneration() int64
+	// SetSyncedModifyIndex records the modify index of the config entry in
+	// Consul when the resource was last synced with it.
+	SetSyncedModifyIndex(index uint64)
+	// GetSyncedModifyIndex returns the modify index of the config entry in
+	// Consul when the resource was last synced with it.
+	GetSyncedModifyIndex() uint64
 	// ToConsul converts the resource to the corresponding Consul API definition.
 	// Its return type is the generic ConfigEntry but a specific config entry
 	// type should be constructed e.g. ServiceConfigEntry.
@@ -69,6 +103,20 @@ type ConfigEntryResource interface {
 	metav1.Object
 }
 
+// DiscoveryChainResource is implemented by config entry resources that are
+// part of a service's discovery chain, i.e. routers, splitters and resolvers.
+type DiscoveryChainResource interface {
+	ConfigEntryResource
+	// SetDiscoveryChain updates the discovery chain compiled by Consul for
+	// the service.
+	SetDiscoveryChain(chain *api.CompiledDiscoveryChain)
+	// SetDiscoveryChainCondition updates the condition that reports whether
+	// Consul could compile the discovery chain.
+	SetDiscoveryChainCondition(status corev1.ConditionStatus, reason, message string)
+	// DiscoveryChainCondition gets the discovery chain condition.
+	DiscoveryChainCondition() (status corev1.ConditionStatus, reason, message string)
+}
+
 // ConsulMeta contains metadata which represents installation specific
 // information about Consul.
 type ConsulMeta struct {
@@ -96,4 +144,22 @@ type ConsulMeta struct {
 	// service in the k8s `staging` namespace will be registered into the
 	// `k8s-staging` Consul namespace.
 	Prefix string
+
+	// CrossResourceValidation controls whether webhooks check config entries
+	// against the other resources they reference, e.g. that the subsets used
+	// by a ServiceSplitter are defined by a ServiceResolver. It is one of
+	// CrossResourceValidationDisabled, CrossResourceValidationWarn or
+	// CrossResourceValidationDeny.
+	CrossResourceValidation string
 }
+
+const (
+	// CrossResourceValidationDisabled turns off cross-resource validation.
+	CrossResourceValidationDisabled = "disabled"
+	// CrossResourceValidationWarn admits resources that fail cross-resource
+	// validation and returns the failures as warnings.
+	CrossResourceValidationWarn = "warn"
+	// CrossResourceValidationDeny rejects resources that fail cross-resource
+	// validation.
+	CrossResourceValidationDeny = "deny"
+)
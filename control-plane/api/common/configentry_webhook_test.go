@@ -318,6 +318,42 @@ func (in *mockConfigEntry) SyncedConditionStatus() corev1.ConditionStatus {
 	return corev1.ConditionTrue
 }
 
+func (in *mockConfigEntry) SetInSyncCondition(_ corev1.ConditionStatus, _ string, _ string) {}
+
+func (in *mockConfigEntry) InSyncCondition() (status corev1.ConditionStatus, reason string, message string) {
+	return corev1.ConditionTrue, "", ""
+}
+
+func (in *mockConfigEntry) SetOwnershipConflictCondition(_ corev1.ConditionStatus, _ string, _ string) {
+}
+
+func (in *mockConfigEntry) OwnershipConflictCondition() (status corev1.ConditionStatus, reason string, message string) {
+	return corev1.ConditionFalse, "", ""
+}
+
+func (in *mockConfigEntry) SetReconcileSuspendedCondition(_ corev1.ConditionStatus, _ string, _ string) {
+}
+
+func (in *mockConfigEntry) ReconcileSuspendedCondition() (status corev1.ConditionStatus, reason string, message string) {
+	return corev1.ConditionFalse, "", ""
+}
+
+func (in *mockConfigEntry) SetDryRun(_ int64, _ string, _ string, _ []string) {}
+
+func (in *mockConfigEntry) ClearDryRun() {}
+
+func (in *mockConfigEntry) SetSyncedGeneration(_ int64) {}
+
+func (in *mockConfigEntry) GetSyncedGeneration() int64 {
+	return 0
+}
+
+func (in *mockConfigEntry) SetSyncedModifyIndex(_ uint64) {}
+
+func (in *mockConfigEntry) GetSyncedModifyIndex() uint64 {
+	return 0
+}
+
 func (in *mockConfigEntry) ToConsul(string) capi.ConfigEntry {
 	return &capi.ServiceConfigEntry{}
 }
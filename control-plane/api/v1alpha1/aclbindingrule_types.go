@@ -0,0 +1,133 @@
+package v1alpha1
+
+import (
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const ACLBindingRuleKubeKind = "aclbindingrule"
+
+func init() {
+	SchemeBuilder.Register(&ACLBindingRule{}, &ACLBindingRuleList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ACLBindingRule is the Schema for the aclbindingrules API. Binding rules
+// have no name in Consul so they are tracked by the ID in the status.
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the binding rule in Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="acl-binding-rule"
+type ACLBindingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ACLBindingRuleSpec `json:"spec,omitempty"`
+	Status ACLStatus          `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ACLBindingRuleList contains a list of ACLBindingRule.
+type ACLBindingRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ACLBindingRule `json:"items"`
+}
+
+// ACLBindingRuleSpec defines the desired state of ACLBindingRule.
+type ACLBindingRuleSpec struct {
+	// Description is a human-readable description of the binding rule.
+	Description string `json:"description,omitempty"`
+	// AuthMethod is the name of the auth method the binding rule applies to.
+	AuthMethod string `json:"authMethod,omitempty"`
+	// Selector is an expression that matches against the verified identity
+	// attributes returned from the auth method during login.
+	Selector string `json:"selector,omitempty"`
+	// BindType determines what the BindName refers to. One of "service",
+	// "node" or "role".
+	BindType string `json:"bindType,omitempty"`
+	// BindName is the name to bind to the token. It may contain
+	// ${var} references to the identity attributes.
+	BindName string `json:"bindName,omitempty"`
+}
+
+func (in *ACLBindingRule) KubeKind() string {
+	return ACLBindingRuleKubeKind
+}
+
+func (in *ACLBindingRule) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *ACLBindingRule) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *ACLBindingRule) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *ACLBindingRule) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+// ToConsul converts the resource to a Consul ACL binding rule in the given
+// Consul namespace.
+func (in *ACLBindingRule) ToConsul(namespace string) capi.ACLBindingRule {
+	return capi.ACLBindingRule{
+		ID:          in.Status.ID,
+		Description: in.Spec.Description,
+		AuthMethod:  in.Spec.AuthMethod,
+		Selector:    in.Spec.Selector,
+		BindType:    capi.BindingRuleBindType(in.Spec.BindType),
+		BindName:    in.Spec.BindName,
+		Namespace:   namespace,
+	}
+}
+
+// MatchesConsul returns true if the binding rule in Consul has the same
+// fields as this resource.
+func (in *ACLBindingRule) MatchesConsul(candidate *capi.ACLBindingRule) bool {
+	return candidate != nil &&
+		candidate.Description == in.Spec.Description &&
+		candidate.AuthMethod == in.Spec.AuthMethod &&
+		candidate.Selector == in.Spec.Selector &&
+		string(candidate.BindType) == in.Spec.BindType &&
+		candidate.BindName == in.Spec.BindName
+}
+
+func (in *ACLBindingRule) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+	bindTypes := []string{string(capi.BindingRuleBindTypeService), "node", string(capi.BindingRuleBindTypeRole)}
+
+	if in.Spec.AuthMethod == "" {
+		errs = append(errs, field.Required(path.Child("authMethod"), "authMethod must be set"))
+	}
+	if !sliceContains(bindTypes, in.Spec.BindType) {
+		errs = append(errs, field.Invalid(path.Child("bindType"), in.Spec.BindType, notInSliceMessage(bindTypes)))
+	}
+	if in.Spec.BindName == "" {
+		errs = append(errs, field.Required(path.Child("bindName"), "bindName must be set"))
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ACLBindingRuleKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
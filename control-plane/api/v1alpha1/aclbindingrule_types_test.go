@@ -0,0 +1,119 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestACLBindingRule_ToConsul(t *testing.T) {
+	bindingRule := ACLBindingRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "rule",
+		},
+		Spec: ACLBindingRuleSpec{
+			Description: "description",
+			AuthMethod:  "k8s",
+			Selector:    "serviceaccount.name!=default",
+			BindType:    "service",
+			BindName:    "${serviceaccount.name}",
+		},
+		Status: ACLStatus{ID: "id"},
+	}
+	require.Equal(t, capi.ACLBindingRule{
+		ID:          "id",
+		Description: "description",
+		AuthMethod:  "k8s",
+		Selector:    "serviceaccount.name!=default",
+		BindType:    capi.BindingRuleBindTypeService,
+		BindName:    "${serviceaccount.name}",
+		Namespace:   "ns",
+	}, bindingRule.ToConsul("ns"))
+}
+
+func TestACLBindingRule_MatchesConsul(t *testing.T) {
+	bindingRule := ACLBindingRule{
+		Spec: ACLBindingRuleSpec{
+			AuthMethod: "k8s",
+			BindType:   "role",
+			BindName:   "role",
+		},
+	}
+	require.False(t, bindingRule.MatchesConsul(nil))
+	require.True(t, bindingRule.MatchesConsul(&capi.ACLBindingRule{
+		ID:         "id",
+		AuthMethod: "k8s",
+		BindType:   capi.BindingRuleBindTypeRole,
+		BindName:   "role",
+	}))
+	require.False(t, bindingRule.MatchesConsul(&capi.ACLBindingRule{
+		AuthMethod: "k8s",
+		BindType:   capi.BindingRuleBindTypeService,
+		BindName:   "role",
+	}))
+}
+
+func TestACLBindingRule_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec            ACLBindingRuleSpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: ACLBindingRuleSpec{
+				AuthMethod: "k8s",
+				BindType:   "node",
+				BindName:   "${serviceaccount.name}",
+			},
+		},
+		"missing fields": {
+			spec: ACLBindingRuleSpec{},
+			expectedErrMsgs: []string{
+				`spec.authMethod: Required value: authMethod must be set`,
+				`spec.bindType: Invalid value: "": must be one of "service", "node", "role"`,
+				`spec.bindName: Required value: bindName must be set`,
+			},
+		},
+		"invalid bind type": {
+			spec: ACLBindingRuleSpec{
+				AuthMethod: "k8s",
+				BindType:   "policy",
+				BindName:   "name",
+			},
+			expectedErrMsgs: []string{
+				`spec.bindType: Invalid value: "policy": must be one of "service", "node", "role"`,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			bindingRule := &ACLBindingRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "rule"},
+				Spec:       c.spec,
+			}
+			err := bindingRule.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACLBindingRule_SetSyncedCondition(t *testing.T) {
+	bindingRule := &ACLBindingRule{}
+	bindingRule.SetSyncedCondition(corev1.ConditionTrue, "", "")
+	require.Equal(t, corev1.ConditionTrue, bindingRule.SyncedConditionStatus())
+}
+
+func TestACLBindingRule_KubeKind(t *testing.T) {
+	require.Equal(t, "aclbindingrule", (&ACLBindingRule{}).KubeKind())
+}
@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type ACLBindingRuleWebhook struct {
+	client.Client
+	Logger logr.Logger
+
+	// ConsulMeta contains metadata specific to the Consul installation.
+	ConsulMeta common.ConsulMeta
+
+	decoder *admission.Decoder
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is
+// it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-aclbindingrule,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=aclbindingrules,versions=v1alpha1,name=mutate-aclbindingrules.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *ACLBindingRuleWebhook) Handle(_ context.Context, req admission.Request) admission.Response {
+	var bindingRule ACLBindingRule
+	err := v.decoder.Decode(req, &bindingRule)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	v.Logger.Info("validate", "operation", req.Operation, "name", bindingRule.KubernetesName())
+	if err := bindingRule.Validate(v.ConsulMeta); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	return admission.Allowed(fmt.Sprintf("valid %s request", bindingRule.KubeKind()))
+}
+
+func (v *ACLBindingRuleWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
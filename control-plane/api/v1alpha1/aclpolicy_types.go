@@ -0,0 +1,293 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const ACLPolicyKubeKind = "aclpolicy"
+
+func init() {
+	SchemeBuilder.Register(&ACLPolicy{}, &ACLPolicyList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ACLPolicy is the Schema for the aclpolicies API. The name of the resource
+// is the name of the ACL policy in Consul.
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the policy in Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="acl-policy"
+type ACLPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ACLPolicySpec `json:"spec,omitempty"`
+	Status ACLStatus     `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ACLPolicyList contains a list of ACLPolicy.
+type ACLPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ACLPolicy `json:"items"`
+}
+
+// ACLPolicySpec defines the desired state of ACLPolicy.
+type ACLPolicySpec struct {
+	// Description is a human-readable description of the policy.
+	Description string `json:"description,omitempty"`
+	// Rules is the ACL rule set of the policy in HCL or JSON.
+	Rules string `json:"rules,omitempty"`
+	// Datacenters restricts the policy to the given datacenters. If empty,
+	// the policy is valid in all datacenters.
+	Datacenters []string `json:"datacenters,omitempty"`
+}
+
+// ACLStatus defines the observed state of the ACL resources.
+type ACLStatus struct {
+	Status `json:",inline"`
+	// ID is the ID of the resource in Consul. It is used to check that the
+	// resource in Consul was created by this custom resource.
+	ID string `json:"id,omitempty"`
+}
+
+func (in *ACLPolicy) KubeKind() string {
+	return ACLPolicyKubeKind
+}
+
+func (in *ACLPolicy) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// ConsulName returns the name of the policy in Consul.
+func (in *ACLPolicy) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *ACLPolicy) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *ACLPolicy) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *ACLPolicy) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+// ToConsul converts the resource to a Consul ACL policy in the given Consul
+// namespace. The ID is left empty so the policy can be created.
+func (in *ACLPolicy) ToConsul(namespace string) capi.ACLPolicy {
+	return capi.ACLPolicy{
+		Name:        in.ConsulName(),
+		Description: in.Spec.Description,
+		Rules:       in.Spec.Rules,
+		Datacenters: in.Spec.Datacenters,
+		Namespace:   namespace,
+	}
+}
+
+// MatchesConsul returns true if the policy in Consul has the same
+// description, rules and datacenters as this resource.
+func (in *ACLPolicy) MatchesConsul(candidate *capi.ACLPolicy) bool {
+	return candidate != nil &&
+		candidate.Name == in.ConsulName() &&
+		candidate.Description == in.Spec.Description &&
+		candidate.Rules == in.Spec.Rules &&
+		stringSlicesEqual(candidate.Datacenters, in.Spec.Datacenters)
+}
+
+func (in *ACLPolicy) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if err := validateRules(in.Spec.Rules); err != nil {
+		errs = append(errs, field.Invalid(path.Child("rules"), in.Spec.Rules, err.Error()))
+	}
+	for i, dc := range in.Spec.Datacenters {
+		if dc == "" {
+			errs = append(errs, field.Required(path.Child("datacenters").Index(i), "datacenter cannot be empty"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ACLPolicyKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+var (
+	// aclNamedResources are the ACL rule resources that take a name or
+	// prefix, e.g. service "web" { policy = "read" }.
+	aclNamedResources = []string{
+		"agent", "agent_prefix",
+		"event", "event_prefix",
+		"identity", "identity_prefix",
+		"key", "key_prefix",
+		"node", "node_prefix",
+		"query", "query_prefix",
+		"service", "service_prefix",
+		"session", "session_prefix",
+	}
+	// aclScopeResources are the ACL rule resources that contain rules for
+	// the resources inside them, e.g. namespace "ns" { service "web" {} }.
+	aclScopeResources = []string{
+		"namespace", "namespace_prefix",
+		"partition", "partition_prefix",
+	}
+	// aclPlainResources are the ACL rule resources that are set directly,
+	// e.g. operator = "read".
+	aclPlainResources = []string{"acl", "keyring", "mesh", "operator", "peering"}
+	// aclIntentionResources are the named resources whose rules may also
+	// set the access to their intentions.
+	aclIntentionResources = []string{"identity", "identity_prefix", "service", "service_prefix"}
+	aclAccessLevels       = []string{"read", "write", "list", "deny"}
+)
+
+// validateRules checks that rules can be parsed as an ACL rule set. It
+// catches syntax errors and unknown resources and access levels, but it does
+// not check that the rules are valid for the Consul version and edition
+// they're written to.
+func validateRules(rules string) error {
+	if strings.TrimSpace(rules) == "" {
+		return nil
+	}
+	file, err := hcl.Parse(rules)
+	if err != nil {
+		return fmt.Errorf("failed to parse rules: %s", err)
+	}
+	list, ok := file.Node.(*ast.ObjectList)
+	if !ok {
+		return fmt.Errorf("failed to parse rules: unexpected root node")
+	}
+	return validateRuleList(list, false)
+}
+
+// validateRuleList validates the rules in list. inScope is true for the rules
+// nested in a namespace or partition rule, which may also set a policy for
+// the namespace or partition itself.
+func validateRuleList(list *ast.ObjectList, inScope bool) error {
+	for _, item := range list.Items {
+		resource := objectKey(item, 0)
+		switch {
+		case sliceContains(aclPlainResources, resource), inScope && resource == "policy":
+			if err := validateAccessLevel(resource, item.Val); err != nil {
+				return err
+			}
+		case sliceContains(aclNamedResources, resource), sliceContains(aclScopeResources, resource):
+			rules, err := namedRules(resource, item)
+			if err != nil {
+				return err
+			}
+			for name, body := range rules {
+				if err := validateNamedRule(resource, name, body); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unknown resource %q", resource)
+		}
+	}
+	return nil
+}
+
+// namedRules returns the rule bodies of a named resource keyed by name.
+// HCL rules are written as service "web" {} whereas JSON rules are written
+// as "service": {"web": {}} so both forms are handled.
+func namedRules(resource string, item *ast.ObjectItem) (map[string]*ast.ObjectList, error) {
+	body, ok := item.Val.(*ast.ObjectType)
+	if !ok {
+		return nil, fmt.Errorf("%s rule must be a block", resource)
+	}
+	if len(item.Keys) > 1 {
+		return map[string]*ast.ObjectList{objectKey(item, 1): body.List}, nil
+	}
+	rules := make(map[string]*ast.ObjectList)
+	for _, inner := range body.List.Items {
+		innerBody, ok := inner.Val.(*ast.ObjectType)
+		if !ok {
+			return nil, fmt.Errorf("%s rule must be a block", resource)
+		}
+		rules[objectKey(inner, 0)] = innerBody.List
+	}
+	return rules, nil
+}
+
+func validateNamedRule(resource, name string, body *ast.ObjectList) error {
+	if sliceContains(aclScopeResources, resource) {
+		if err := validateRuleList(body, true); err != nil {
+			return fmt.Errorf("%s %q: %s", resource, name, err)
+		}
+		return nil
+	}
+	for _, item := range body.Items {
+		field := objectKey(item, 0)
+		switch {
+		case field == "policy", field == "intentions" && sliceContains(aclIntentionResources, resource):
+			if err := validateAccessLevel(field, item.Val); err != nil {
+				return fmt.Errorf("%s %q: %s", resource, name, err)
+			}
+		default:
+			return fmt.Errorf("%s %q: unknown field %q", resource, name, field)
+		}
+	}
+	return nil
+}
+
+func validateAccessLevel(field string, val ast.Node) error {
+	lit, ok := val.(*ast.LiteralType)
+	if !ok {
+		return fmt.Errorf("%s must be a string", field)
+	}
+	level, ok := lit.Token.Value().(string)
+	if !ok || !sliceContains(aclAccessLevels, level) {
+		return fmt.Errorf("invalid access level %s for %s: %s", lit.Token.Text, field, notInSliceMessage(aclAccessLevels))
+	}
+	return nil
+}
+
+// objectKey returns the i'th key of item without quotes.
+func objectKey(item *ast.ObjectItem, i int) string {
+	if i >= len(item.Keys) {
+		return ""
+	}
+	if s, ok := item.Keys[i].Token.Value().(string); ok {
+		return s
+	}
+	return item.Keys[i].Token.Text
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,210 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestACLPolicy_ToConsul(t *testing.T) {
+	policy := ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web",
+		},
+		Spec: ACLPolicySpec{
+			Description: "description",
+			Rules:       `service "web" { policy = "write" }`,
+			Datacenters: []string{"dc1"},
+		},
+		Status: ACLStatus{ID: "id"},
+	}
+	require.Equal(t, capi.ACLPolicy{
+		Name:        "web",
+		Description: "description",
+		Rules:       `service "web" { policy = "write" }`,
+		Datacenters: []string{"dc1"},
+		Namespace:   "ns",
+	}, policy.ToConsul("ns"))
+}
+
+func TestACLPolicy_MatchesConsul(t *testing.T) {
+	policy := ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web",
+		},
+		Spec: ACLPolicySpec{
+			Description: "description",
+			Rules:       `service "web" { policy = "write" }`,
+			Datacenters: []string{"dc1"},
+		},
+	}
+
+	cases := map[string]struct {
+		Theirs  *capi.ACLPolicy
+		Matches bool
+	}{
+		"nil": {
+			Theirs:  nil,
+			Matches: false,
+		},
+		"matches ignoring ID and indexes": {
+			Theirs: &capi.ACLPolicy{
+				ID:          "id",
+				Name:        "web",
+				Description: "description",
+				Rules:       `service "web" { policy = "write" }`,
+				Datacenters: []string{"dc1"},
+				CreateIndex: 1,
+				ModifyIndex: 2,
+			},
+			Matches: true,
+		},
+		"rules differ": {
+			Theirs: &capi.ACLPolicy{
+				Name:        "web",
+				Description: "description",
+				Rules:       `service "web" { policy = "read" }`,
+				Datacenters: []string{"dc1"},
+			},
+			Matches: false,
+		},
+		"datacenters differ": {
+			Theirs: &capi.ACLPolicy{
+				Name:        "web",
+				Description: "description",
+				Rules:       `service "web" { policy = "write" }`,
+			},
+			Matches: false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.Matches, policy.MatchesConsul(c.Theirs))
+		})
+	}
+}
+
+func TestACLPolicy_Validate(t *testing.T) {
+	cases := map[string]struct {
+		rules          string
+		datacenters    []string
+		expectedErrMsg string
+	}{
+		"empty rules": {
+			rules: "",
+		},
+		"valid HCL": {
+			rules: `
+acl = "read"
+operator = "write"
+service_prefix "" {
+  policy = "read"
+  intentions = "read"
+}
+service "web" {
+  policy = "write"
+}
+key_prefix "app/" {
+  policy = "list"
+}
+namespace "team-a" {
+  policy = "write"
+  service_prefix "" {
+    policy = "read"
+  }
+}
+partition_prefix "" {
+  namespace_prefix "" {
+    node_prefix "" {
+      policy = "read"
+    }
+  }
+}`,
+		},
+		"valid JSON": {
+			rules: `{"service": {"web": {"policy": "write"}}, "node_prefix": {"": {"policy": "read"}}, "mesh": "read"}`,
+		},
+		"syntax error": {
+			rules:          `service "web" { policy = "write"`,
+			expectedErrMsg: `spec.rules: Invalid value: "service \"web\" { policy = \"write\"": failed to parse rules:`,
+		},
+		"unknown resource": {
+			rules:          `services "web" { policy = "write" }`,
+			expectedErrMsg: `unknown resource "services"`,
+		},
+		"unknown access level": {
+			rules:          `service "web" { policy = "admin" }`,
+			expectedErrMsg: `service "web": invalid access level "admin" for policy: must be one of "read", "write", "list", "deny"`,
+		},
+		"identity rules": {
+			rules: `
+identity_prefix "" {
+  policy = "read"
+}
+identity "web" {
+  policy     = "write"
+  intentions = "read"
+}`,
+		},
+		"unknown field": {
+			rules:          `node "web" { intentions = "read" }`,
+			expectedErrMsg: `node "web": unknown field "intentions"`,
+		},
+		"invalid nested rule": {
+			rules:          `namespace "ns" { service "web" { policy = "rw" } }`,
+			expectedErrMsg: `namespace "ns": service "web": invalid access level "rw" for policy`,
+		},
+		"plain resource with block": {
+			rules:          `operator { policy = "read" }`,
+			expectedErrMsg: `operator must be a string`,
+		},
+		"empty datacenter": {
+			datacenters:    []string{""},
+			expectedErrMsg: `spec.datacenters[0]: Required value: datacenter cannot be empty`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			policy := &ACLPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "web",
+				},
+				Spec: ACLPolicySpec{
+					Rules:       c.rules,
+					Datacenters: c.datacenters,
+				},
+			}
+			err := policy.Validate(common.ConsulMeta{})
+			if c.expectedErrMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACLPolicy_SetSyncedCondition(t *testing.T) {
+	policy := &ACLPolicy{}
+	policy.SetSyncedCondition(corev1.ConditionTrue, "reason", "message")
+
+	require.Equal(t, corev1.ConditionTrue, policy.Status.Conditions[0].Status)
+	require.Equal(t, "reason", policy.Status.Conditions[0].Reason)
+	require.Equal(t, "message", policy.Status.Conditions[0].Message)
+	require.Equal(t, corev1.ConditionTrue, policy.SyncedConditionStatus())
+}
+
+func TestACLPolicy_SyncedConditionStatusWhenStatusNil(t *testing.T) {
+	require.Equal(t, corev1.ConditionUnknown, (&ACLPolicy{}).SyncedConditionStatus())
+}
+
+func TestACLPolicy_KubeKind(t *testing.T) {
+	require.Equal(t, "aclpolicy", (&ACLPolicy{}).KubeKind())
+}
@@ -0,0 +1,193 @@
+package v1alpha1
+
+import (
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const ACLRoleKubeKind = "aclrole"
+
+func init() {
+	SchemeBuilder.Register(&ACLRole{}, &ACLRoleList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ACLRole is the Schema for the aclroles API. The name of the resource is
+// the name of the ACL role in Consul.
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the role in Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="acl-role"
+type ACLRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ACLRoleSpec `json:"spec,omitempty"`
+	Status ACLStatus   `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ACLRoleList contains a list of ACLRole.
+type ACLRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ACLRole `json:"items"`
+}
+
+// ACLRoleSpec defines the desired state of ACLRole.
+type ACLRoleSpec struct {
+	// Description is a human-readable description of the role.
+	Description string `json:"description,omitempty"`
+	// Policies is the list of names of the ACL policies linked to the role.
+	// The policies must be in the same Consul namespace as the role.
+	Policies []string `json:"policies,omitempty"`
+	// ServiceIdentities is the list of service identities linked to the role.
+	ServiceIdentities []ACLServiceIdentity `json:"serviceIdentities,omitempty"`
+	// NodeIdentities is the list of node identities linked to the role.
+	NodeIdentities []ACLNodeIdentity `json:"nodeIdentities,omitempty"`
+}
+
+// ACLServiceIdentity grants the permissions of a service to a role.
+type ACLServiceIdentity struct {
+	// ServiceName is the name of the service.
+	ServiceName string `json:"serviceName,omitempty"`
+	// Datacenters restricts the identity to the given datacenters. If empty,
+	// the identity is valid in all datacenters.
+	Datacenters []string `json:"datacenters,omitempty"`
+}
+
+// ACLNodeIdentity grants the permissions of a node to a role.
+type ACLNodeIdentity struct {
+	// NodeName is the name of the node.
+	NodeName string `json:"nodeName,omitempty"`
+	// Datacenter is the datacenter the identity is valid in.
+	Datacenter string `json:"datacenter,omitempty"`
+}
+
+func (in *ACLRole) KubeKind() string {
+	return ACLRoleKubeKind
+}
+
+func (in *ACLRole) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// ConsulName returns the name of the role in Consul.
+func (in *ACLRole) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *ACLRole) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *ACLRole) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *ACLRole) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+// ToConsul converts the resource to a Consul ACL role in the given Consul
+// namespace. The ID is left empty so the role can be created.
+func (in *ACLRole) ToConsul(namespace string) capi.ACLRole {
+	role := capi.ACLRole{
+		Name:        in.ConsulName(),
+		Description: in.Spec.Description,
+		Namespace:   namespace,
+	}
+	for _, policy := range in.Spec.Policies {
+		role.Policies = append(role.Policies, &capi.ACLRolePolicyLink{Name: policy})
+	}
+	for _, identity := range in.Spec.ServiceIdentities {
+		role.ServiceIdentities = append(role.ServiceIdentities, &capi.ACLServiceIdentity{
+			ServiceName: identity.ServiceName,
+			Datacenters: identity.Datacenters,
+		})
+	}
+	for _, identity := range in.Spec.NodeIdentities {
+		role.NodeIdentities = append(role.NodeIdentities, &capi.ACLNodeIdentity{
+			NodeName:   identity.NodeName,
+			Datacenter: identity.Datacenter,
+		})
+	}
+	return role
+}
+
+// MatchesConsul returns true if the role in Consul has the same
+// description, policies and identities as this resource. Policies are
+// compared by name since Consul fills in their IDs.
+func (in *ACLRole) MatchesConsul(candidate *capi.ACLRole) bool {
+	if candidate == nil ||
+		candidate.Name != in.ConsulName() ||
+		candidate.Description != in.Spec.Description ||
+		len(candidate.Policies) != len(in.Spec.Policies) ||
+		len(candidate.ServiceIdentities) != len(in.Spec.ServiceIdentities) ||
+		len(candidate.NodeIdentities) != len(in.Spec.NodeIdentities) {
+		return false
+	}
+	for i, policy := range in.Spec.Policies {
+		if candidate.Policies[i].Name != policy {
+			return false
+		}
+	}
+	for i, identity := range in.Spec.ServiceIdentities {
+		theirs := candidate.ServiceIdentities[i]
+		if theirs.ServiceName != identity.ServiceName || !stringSlicesEqual(theirs.Datacenters, identity.Datacenters) {
+			return false
+		}
+	}
+	for i, identity := range in.Spec.NodeIdentities {
+		theirs := candidate.NodeIdentities[i]
+		if theirs.NodeName != identity.NodeName || theirs.Datacenter != identity.Datacenter {
+			return false
+		}
+	}
+	return true
+}
+
+func (in *ACLRole) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if len(in.Spec.Policies) == 0 && len(in.Spec.ServiceIdentities) == 0 && len(in.Spec.NodeIdentities) == 0 {
+		errs = append(errs, field.Required(path,
+			"at least one of policies, serviceIdentities or nodeIdentities must be set"))
+	}
+	errs = append(errs, validateACLLinkNames(path.Child("policies"), in.Spec.Policies)...)
+	for i, identity := range in.Spec.ServiceIdentities {
+		if identity.ServiceName == "" {
+			errs = append(errs, field.Required(path.Child("serviceIdentities").Index(i).Child("serviceName"), "serviceName must be set"))
+		}
+	}
+	for i, identity := range in.Spec.NodeIdentities {
+		identityPath := path.Child("nodeIdentities").Index(i)
+		if identity.NodeName == "" {
+			errs = append(errs, field.Required(identityPath.Child("nodeName"), "nodeName must be set"))
+		}
+		if identity.Datacenter == "" {
+			errs = append(errs, field.Required(identityPath.Child("datacenter"), "datacenter must be set"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ACLRoleKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
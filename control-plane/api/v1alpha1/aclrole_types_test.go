@@ -0,0 +1,177 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestACLRole_ToConsul(t *testing.T) {
+	role := ACLRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "role",
+		},
+		Spec: ACLRoleSpec{
+			Description: "description",
+			Policies:    []string{"policy"},
+			ServiceIdentities: []ACLServiceIdentity{
+				{ServiceName: "web", Datacenters: []string{"dc1"}},
+			},
+			NodeIdentities: []ACLNodeIdentity{
+				{NodeName: "node", Datacenter: "dc1"},
+			},
+		},
+		Status: ACLStatus{ID: "id"},
+	}
+	require.Equal(t, capi.ACLRole{
+		Name:        "role",
+		Description: "description",
+		Namespace:   "ns",
+		Policies:    []*capi.ACLRolePolicyLink{{Name: "policy"}},
+		ServiceIdentities: []*capi.ACLServiceIdentity{
+			{ServiceName: "web", Datacenters: []string{"dc1"}},
+		},
+		NodeIdentities: []*capi.ACLNodeIdentity{
+			{NodeName: "node", Datacenter: "dc1"},
+		},
+	}, role.ToConsul("ns"))
+}
+
+func TestACLRole_MatchesConsul(t *testing.T) {
+	role := ACLRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "role",
+		},
+		Spec: ACLRoleSpec{
+			Description: "description",
+			Policies:    []string{"policy"},
+			ServiceIdentities: []ACLServiceIdentity{
+				{ServiceName: "web"},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		Theirs  *capi.ACLRole
+		Matches bool
+	}{
+		"nil": {
+			Theirs:  nil,
+			Matches: false,
+		},
+		"matches ignoring policy IDs": {
+			Theirs: &capi.ACLRole{
+				ID:                "id",
+				Name:              "role",
+				Description:       "description",
+				Policies:          []*capi.ACLRolePolicyLink{{ID: "policy-id", Name: "policy"}},
+				ServiceIdentities: []*capi.ACLServiceIdentity{{ServiceName: "web"}},
+			},
+			Matches: true,
+		},
+		"policies differ": {
+			Theirs: &capi.ACLRole{
+				Name:              "role",
+				Description:       "description",
+				Policies:          []*capi.ACLRolePolicyLink{{Name: "other"}},
+				ServiceIdentities: []*capi.ACLServiceIdentity{{ServiceName: "web"}},
+			},
+			Matches: false,
+		},
+		"identities differ": {
+			Theirs: &capi.ACLRole{
+				Name:              "role",
+				Description:       "description",
+				Policies:          []*capi.ACLRolePolicyLink{{Name: "policy"}},
+				ServiceIdentities: []*capi.ACLServiceIdentity{{ServiceName: "web", Datacenters: []string{"dc2"}}},
+			},
+			Matches: false,
+		},
+		"node identity added": {
+			Theirs: &capi.ACLRole{
+				Name:              "role",
+				Description:       "description",
+				Policies:          []*capi.ACLRolePolicyLink{{Name: "policy"}},
+				ServiceIdentities: []*capi.ACLServiceIdentity{{ServiceName: "web"}},
+				NodeIdentities:    []*capi.ACLNodeIdentity{{NodeName: "node", Datacenter: "dc1"}},
+			},
+			Matches: false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.Matches, role.MatchesConsul(c.Theirs))
+		})
+	}
+}
+
+func TestACLRole_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec            ACLRoleSpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: ACLRoleSpec{
+				Policies:          []string{"policy"},
+				ServiceIdentities: []ACLServiceIdentity{{ServiceName: "web"}},
+				NodeIdentities:    []ACLNodeIdentity{{NodeName: "node", Datacenter: "dc1"}},
+			},
+		},
+		"empty": {
+			spec: ACLRoleSpec{},
+			expectedErrMsgs: []string{
+				`spec: Required value: at least one of policies, serviceIdentities or nodeIdentities must be set`,
+			},
+		},
+		"invalid fields": {
+			spec: ACLRoleSpec{
+				Policies:          []string{"policy", "policy"},
+				ServiceIdentities: []ACLServiceIdentity{{}},
+				NodeIdentities:    []ACLNodeIdentity{{}},
+			},
+			expectedErrMsgs: []string{
+				`spec.policies[1]: Duplicate value: "policy"`,
+				`spec.serviceIdentities[0].serviceName: Required value: serviceName must be set`,
+				`spec.nodeIdentities[0].nodeName: Required value: nodeName must be set`,
+				`spec.nodeIdentities[0].datacenter: Required value: datacenter must be set`,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			role := &ACLRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "role"},
+				Spec:       c.spec,
+			}
+			err := role.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACLRole_SetSyncedCondition(t *testing.T) {
+	role := &ACLRole{}
+	role.SetSyncedCondition(corev1.ConditionFalse, "reason", "message")
+
+	require.Equal(t, corev1.ConditionFalse, role.Status.Conditions[0].Status)
+	require.Equal(t, "reason", role.Status.Conditions[0].Reason)
+	require.Equal(t, "message", role.Status.Conditions[0].Message)
+	require.Equal(t, corev1.ConditionFalse, role.SyncedConditionStatus())
+}
+
+func TestACLRole_KubeKind(t *testing.T) {
+	require.Equal(t, "aclrole", (&ACLRole{}).KubeKind())
+}
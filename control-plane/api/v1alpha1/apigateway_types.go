@@ -0,0 +1,266 @@
+package v1alpha1
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	apiGatewayKubeKind = "apigateway"
+)
+
+func init() {
+	SchemeBuilder.Register(&APIGateway{}, &APIGatewayList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// APIGateway is the Schema for the apigateways API
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="api-gateway"
+type APIGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIGatewaySpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APIGatewayList contains a list of APIGateway.
+type APIGatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIGateway `json:"items"`
+}
+
+// APIGatewaySpec defines the desired state of APIGateway.
+type APIGatewaySpec struct {
+	// Listeners is the set of listeners the API gateway binds to. HTTPRoute
+	// and TCPRoute resources are attached to these listeners.
+	Listeners []APIGatewayListener `json:"listeners,omitempty"`
+}
+
+// APIGatewayListener is a single listener of an API gateway.
+type APIGatewayListener struct {
+	// Name is the name of the listener. It must be unique within the gateway
+	// and is used by routes to bind to a specific listener.
+	Name string `json:"name,omitempty"`
+	// Hostname is the host name the listener is bound to. If unset, the
+	// listener accepts requests for all host names.
+	Hostname string `json:"hostname,omitempty"`
+	// Port is the port the listener binds to.
+	Port int `json:"port,omitempty"`
+	// Protocol is the protocol of the listener. One of "http" or "tcp".
+	Protocol string `json:"protocol,omitempty"`
+	// TLS is the TLS configuration of the listener.
+	TLS APIGatewayTLSConfiguration `json:"tls,omitempty"`
+}
+
+// APIGatewayTLSConfiguration is the TLS configuration of an API gateway listener.
+type APIGatewayTLSConfiguration struct {
+	// Certificates is the list of InlineCertificate resources the listener
+	// uses for TLS termination.
+	Certificates []ResourceReference `json:"certificates,omitempty"`
+	// MinVersion is the minimum TLS version supported by the listener.
+	// One of `TLS_AUTO`, `TLSv1_0`, `TLSv1_1`, `TLSv1_2`, or `TLSv1_3`.
+	MinVersion string `json:"minVersion,omitempty"`
+	// MaxVersion is the maximum TLS version supported by the listener.
+	// One of `TLS_AUTO`, `TLSv1_0`, `TLSv1_1`, `TLSv1_2`, or `TLSv1_3`.
+	MaxVersion string `json:"maxVersion,omitempty"`
+	// CipherSuites restricts the cipher suites supported by the listener.
+	// Only applicable to connections negotiated via TLS 1.2 or earlier.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+func (in *APIGateway) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *APIGateway) AddFinalizer(name string) {
+	in.ObjectMeta.Finalizers = append(in.Finalizers(), name)
+}
+
+func (in *APIGateway) RemoveFinalizer(name string) {
+	var newFinalizers []string
+	for _, oldF := range in.Finalizers() {
+		if oldF != name {
+			newFinalizers = append(newFinalizers, oldF)
+		}
+	}
+	in.ObjectMeta.Finalizers = newFinalizers
+}
+
+func (in *APIGateway) Finalizers() []string {
+	return in.ObjectMeta.Finalizers
+}
+
+func (in *APIGateway) ConsulKind() string {
+	return capi.APIGateway
+}
+
+func (in *APIGateway) ConsulGlobalResource() bool {
+	return false
+}
+
+func (in *APIGateway) ConsulMirroringNS() string {
+	return in.Namespace
+}
+
+func (in *APIGateway) KubeKind() string {
+	return apiGatewayKubeKind
+}
+
+func (in *APIGateway) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *APIGateway) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *APIGateway) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *APIGateway) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *APIGateway) SyncedCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+func (in *APIGateway) SyncedConditionStatus() corev1.ConditionStatus {
+	condition := in.Status.GetCondition(ConditionSynced)
+	if condition == nil {
+		return corev1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+func (in *APIGateway) ToConsul(datacenter string) capi.ConfigEntry {
+	var listeners []capi.APIGatewayListener
+	for _, l := range in.Spec.Listeners {
+		listeners = append(listeners, l.toConsul())
+	}
+	return &capi.APIGatewayConfigEntry{
+		Kind:      in.ConsulKind(),
+		Name:      in.ConsulName(),
+		Listeners: listeners,
+		Meta:      meta(datacenter),
+	}
+}
+
+func (in *APIGateway) MatchesConsul(candidate capi.ConfigEntry) bool {
+	configEntry, ok := candidate.(*capi.APIGatewayConfigEntry)
+	if !ok {
+		return false
+	}
+	// No datacenter is passed to ToConsul as we ignore the Meta field when checking for equality.
+	// Status is computed by Consul so it is ignored too.
+	return cmp.Equal(in.ToConsul(""), configEntry, cmpopts.IgnoreFields(capi.APIGatewayConfigEntry{}, "Partition", "Namespace", "Meta", "Status", "ModifyIndex", "CreateIndex"), cmpopts.IgnoreUnexported(), cmpopts.EquateEmpty())
+}
+
+func (in *APIGateway) Validate(consulMeta common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	names := make(map[string]bool)
+	for i, l := range in.Spec.Listeners {
+		listenerPath := path.Child("listeners").Index(i)
+		if names[l.Name] {
+			errs = append(errs, field.Duplicate(listenerPath.Child("name"), l.Name))
+		}
+		names[l.Name] = true
+		errs = append(errs, l.validate(listenerPath, consulMeta)...)
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: apiGatewayKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+// DefaultNamespaceFields sets the namespace field on the listener certificates to their default values if namespaces are enabled.
+func (in *APIGateway) DefaultNamespaceFields(consulMeta common.ConsulMeta) {
+	// If namespaces are enabled we want to set the namespace fields to their
+	// defaults. If namespaces are not enabled (i.e. OSS) we don't set the
+	// namespace fields because this would cause errors
+	// making API calls (because namespace fields can't be set in OSS).
+	if consulMeta.NamespacesEnabled {
+		// Default to the current namespace (i.e. the namespace of the config entry).
+		namespace := namespaces.ConsulNamespace(in.Namespace, consulMeta.NamespacesEnabled, consulMeta.DestinationNamespace, consulMeta.Mirroring, consulMeta.Prefix)
+		for i, listener := range in.Spec.Listeners {
+			for j, cert := range listener.TLS.Certificates {
+				if cert.Namespace == "" {
+					in.Spec.Listeners[i].TLS.Certificates[j].Namespace = namespace
+				}
+			}
+		}
+	}
+}
+
+func (in APIGatewayListener) toConsul() capi.APIGatewayListener {
+	var certs []capi.ResourceReference
+	for _, c := range in.TLS.Certificates {
+		certs = append(certs, c.toConsul(capi.InlineCertificate))
+	}
+	return capi.APIGatewayListener{
+		Name:     in.Name,
+		Hostname: in.Hostname,
+		Port:     in.Port,
+		Protocol: in.Protocol,
+		TLS: capi.APIGatewayTLSConfiguration{
+			Certificates: certs,
+			MinVersion:   in.TLS.MinVersion,
+			MaxVersion:   in.TLS.MaxVersion,
+			CipherSuites: in.TLS.CipherSuites,
+		},
+	}
+}
+
+func (in APIGatewayListener) validate(path *field.Path, consulMeta common.ConsulMeta) field.ErrorList {
+	var errs field.ErrorList
+	if in.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "name must be set"))
+	}
+	if in.Port < 1 || in.Port > 65535 {
+		errs = append(errs, field.Invalid(path.Child("port"), in.Port, "must be between 1 and 65535"))
+	}
+	protocols := []string{"http", "tcp"}
+	if !sliceContains(protocols, in.Protocol) {
+		errs = append(errs, field.Invalid(path.Child("protocol"), in.Protocol, notInSliceMessage(protocols)))
+	}
+
+	tlsPath := path.Child("tls")
+	versions := []string{"TLS_AUTO", "TLSv1_0", "TLSv1_1", "TLSv1_2", "TLSv1_3", ""}
+	if !sliceContains(versions, in.TLS.MinVersion) {
+		errs = append(errs, field.Invalid(tlsPath.Child("minVersion"), in.TLS.MinVersion, notInSliceMessage(versions)))
+	}
+	if !sliceContains(versions, in.TLS.MaxVersion) {
+		errs = append(errs, field.Invalid(tlsPath.Child("maxVersion"), in.TLS.MaxVersion, notInSliceMessage(versions)))
+	}
+	for i, cert := range in.TLS.Certificates {
+		errs = append(errs, cert.validate(tlsPath.Child("certificates").Index(i), capi.InlineCertificate, consulMeta)...)
+	}
+	return errs
+}
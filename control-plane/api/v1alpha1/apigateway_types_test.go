@@ -0,0 +1,255 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAPIGateway_MatchesConsul(t *testing.T) {
+	cases := map[string]struct {
+		Ours    APIGateway
+		Theirs  capi.ConfigEntry
+		Matches bool
+	}{
+		"empty fields matches": {
+			Ours: APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+			},
+			Theirs: &capi.APIGatewayConfigEntry{
+				Kind:        capi.APIGateway,
+				Name:        "name",
+				Namespace:   "foobar",
+				CreateIndex: 1,
+				ModifyIndex: 2,
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: "datacenter",
+				},
+			},
+			Matches: true,
+		},
+		"all fields set matches, ignoring status": {
+			Ours: APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+				Spec: APIGatewaySpec{
+					Listeners: []APIGatewayListener{
+						{
+							Name:     "https",
+							Hostname: "*.example.com",
+							Port:     443,
+							Protocol: "http",
+							TLS: APIGatewayTLSConfiguration{
+								Certificates: []ResourceReference{{Name: "cert"}},
+								MinVersion:   "TLSv1_2",
+								MaxVersion:   "TLSv1_3",
+								CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+							},
+						},
+					},
+				},
+			},
+			Theirs: &capi.APIGatewayConfigEntry{
+				Kind: capi.APIGateway,
+				Name: "name",
+				Listeners: []capi.APIGatewayListener{
+					{
+						Name:     "https",
+						Hostname: "*.example.com",
+						Port:     443,
+						Protocol: "http",
+						TLS: capi.APIGatewayTLSConfiguration{
+							Certificates: []capi.ResourceReference{{Kind: capi.InlineCertificate, Name: "cert"}},
+							MinVersion:   "TLSv1_2",
+							MaxVersion:   "TLSv1_3",
+							CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+						},
+					},
+				},
+				Status: capi.ConfigEntryStatus{
+					Conditions: []capi.Condition{{Type: "Accepted", Status: "True"}},
+				},
+			},
+			Matches: true,
+		},
+		"mismatched listeners do not match": {
+			Ours: APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+				Spec: APIGatewaySpec{
+					Listeners: []APIGatewayListener{{Name: "http", Port: 80, Protocol: "http"}},
+				},
+			},
+			Theirs: &capi.APIGatewayConfigEntry{
+				Kind:      capi.APIGateway,
+				Name:      "name",
+				Listeners: []capi.APIGatewayListener{{Name: "http", Port: 8080, Protocol: "http"}},
+			},
+			Matches: false,
+		},
+		"different types does not match": {
+			Ours: APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+			},
+			Theirs: &capi.TerminatingGatewayConfigEntry{
+				Kind: capi.TerminatingGateway,
+				Name: "name",
+			},
+			Matches: false,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.Matches, c.Ours.MatchesConsul(c.Theirs))
+		})
+	}
+}
+
+func TestAPIGateway_Validate(t *testing.T) {
+	cases := map[string]struct {
+		input           *APIGateway
+		namespaces      bool
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			input: &APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+				Spec: APIGatewaySpec{
+					Listeners: []APIGatewayListener{
+						{Name: "http", Port: 80, Protocol: "http"},
+						{
+							Name:     "tcp",
+							Port:     9000,
+							Protocol: "tcp",
+							TLS: APIGatewayTLSConfiguration{
+								Certificates: []ResourceReference{{Kind: capi.InlineCertificate, Name: "cert", Namespace: "ns"}},
+								MinVersion:   "TLSv1_2",
+							},
+						},
+					},
+				},
+			},
+			namespaces: true,
+		},
+		"invalid listeners": {
+			input: &APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+				Spec: APIGatewaySpec{
+					Listeners: []APIGatewayListener{
+						{Name: "http", Port: 80, Protocol: "http"},
+						{Name: "http", Port: 0, Protocol: "grpc"},
+						{
+							Port:     443,
+							Protocol: "http",
+							TLS: APIGatewayTLSConfiguration{
+								Certificates: []ResourceReference{{Kind: capi.APIGateway, Namespace: "ns"}},
+								MinVersion:   "TLSv1_9",
+								MaxVersion:   "foo",
+							},
+						},
+					},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.listeners[1].name: Duplicate value: "http"`,
+				`spec.listeners[1].port: Invalid value: 0: must be between 1 and 65535`,
+				`spec.listeners[1].protocol: Invalid value: "grpc": must be one of "http", "tcp"`,
+				`spec.listeners[2].name: Required value: name must be set`,
+				`spec.listeners[2].tls.minVersion: Invalid value: "TLSv1_9": must be one of "TLS_AUTO", "TLSv1_0", "TLSv1_1", "TLSv1_2", "TLSv1_3", ""`,
+				`spec.listeners[2].tls.maxVersion: Invalid value: "foo": must be one of "TLS_AUTO", "TLSv1_0", "TLSv1_1", "TLSv1_2", "TLSv1_3", ""`,
+				`spec.listeners[2].tls.certificates[0].kind: Invalid value: "api-gateway": must be one of "inline-certificate"`,
+				`spec.listeners[2].tls.certificates[0].name: Required value: name must be set`,
+				`spec.listeners[2].tls.certificates[0].namespace: Invalid value: "ns": Consul Enterprise namespaces must be enabled to set namespace`,
+			},
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.input.Validate(common.ConsulMeta{NamespacesEnabled: testCase.namespaces})
+			if len(testCase.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range testCase.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAPIGateway_DefaultNamespaceFields(t *testing.T) {
+	gateway := &APIGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "k8s"},
+		Spec: APIGatewaySpec{
+			Listeners: []APIGatewayListener{
+				{
+					Name: "https",
+					TLS: APIGatewayTLSConfiguration{
+						Certificates: []ResourceReference{{Name: "cert"}, {Name: "other", Namespace: "other"}},
+					},
+				},
+			},
+		},
+	}
+
+	gateway.DefaultNamespaceFields(common.ConsulMeta{})
+	require.Empty(t, gateway.Spec.Listeners[0].TLS.Certificates[0].Namespace)
+
+	gateway.DefaultNamespaceFields(common.ConsulMeta{NamespacesEnabled: true, Mirroring: true, Prefix: "prefix-"})
+	require.Equal(t, "prefix-k8s", gateway.Spec.Listeners[0].TLS.Certificates[0].Namespace)
+	require.Equal(t, "other", gateway.Spec.Listeners[0].TLS.Certificates[1].Namespace)
+}
+
+func TestAPIGateway_ToConsul(t *testing.T) {
+	gateway := &APIGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		Spec: APIGatewaySpec{
+			Listeners: []APIGatewayListener{
+				{
+					Name:     "https",
+					Port:     443,
+					Protocol: "http",
+					TLS: APIGatewayTLSConfiguration{
+						Certificates: []ResourceReference{{Name: "cert", SectionName: "section"}},
+					},
+				},
+			},
+		},
+	}
+	require.Equal(t, &capi.APIGatewayConfigEntry{
+		Kind: capi.APIGateway,
+		Name: "name",
+		Listeners: []capi.APIGatewayListener{
+			{
+				Name:     "https",
+				Port:     443,
+				Protocol: "http",
+				TLS: capi.APIGatewayTLSConfiguration{
+					Certificates: []capi.ResourceReference{{Kind: capi.InlineCertificate, Name: "cert", SectionName: "section"}},
+				},
+			},
+		},
+		Meta: map[string]string{
+			common.SourceKey:     common.SourceValue,
+			common.DatacenterKey: "datacenter",
+		},
+	}, gateway.ToConsul("datacenter"))
+}
+
+func TestAPIGateway_ObjectMeta(t *testing.T) {
+	meta := metav1.ObjectMeta{
+		Name:      "name",
+		Namespace: "namespace",
+	}
+	gateway := &APIGateway{
+		ObjectMeta: meta,
+	}
+	require.Equal(t, meta, gateway.GetObjectMeta())
+	require.Equal(t, apiGatewayKubeKind, gateway.KubeKind())
+	require.Equal(t, capi.APIGateway, gateway.ConsulKind())
+	require.Equal(t, "namespace", gateway.ConsulMirroringNS())
+	require.False(t, gateway.ConsulGlobalResource())
+}
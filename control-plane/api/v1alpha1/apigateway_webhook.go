@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type APIGatewayWebhook struct {
+	Logger logr.Logger
+
+	// ConsulMeta contains metadata specific to the Consul installation.
+	ConsulMeta common.ConsulMeta
+
+	decoder *admission.Decoder
+	client.Client
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-apigateway,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=apigateways,versions=v1alpha1,name=mutate-apigateway.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *APIGatewayWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var resource APIGateway
+	err := v.decoder.Decode(req, &resource)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	resp := common.ValidateConfigEntry(ctx, req, v.Logger, v, &resource, v.ConsulMeta)
+	if !resp.Allowed {
+		return resp
+	}
+
+	// Check that the certificates used by the listeners exist.
+	var certs []ResourceReference
+	var paths []*field.Path
+	for i, l := range resource.Spec.Listeners {
+		for j, cert := range l.TLS.Certificates {
+			certs = append(certs, cert)
+			paths = append(paths, field.NewPath("spec").Child("listeners").Index(i).Child("tls").Child("certificates").Index(j))
+		}
+	}
+	errs, err := validateCertificateReferences(ctx, v.Client, v.ConsulMeta, req.Namespace, certs, paths)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: apiGatewayKubeKind},
+			resource.KubernetesName(), errs))
+	}
+	return resp
+}
+
+func (v *APIGatewayWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
+	var resourceList APIGatewayList
+	if err := v.Client.List(ctx, &resourceList); err != nil {
+		return nil, err
+	}
+	var entries []common.ConfigEntryResource
+	for _, item := range resourceList.Items {
+		entries = append(entries, common.ConfigEntryResource(&item))
+	}
+	return entries, nil
+}
+
+func (v *APIGatewayWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
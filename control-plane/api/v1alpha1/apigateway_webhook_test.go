@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestHandle_APIGateway_References(t *testing.T) {
+	inlineCert := &InlineCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "default"},
+	}
+
+	cases := map[string]struct {
+		existingResources []runtime.Object
+		certificates      []ResourceReference
+		consulMeta        common.ConsulMeta
+		expAllow          bool
+		expErrMessage     string
+	}{
+		"no certificates": {
+			expAllow: true,
+		},
+		"certificate exists": {
+			existingResources: []runtime.Object{inlineCert},
+			certificates:      []ResourceReference{{Name: "cert"}},
+			expAllow:          true,
+		},
+		"certificate does not exist": {
+			existingResources: []runtime.Object{inlineCert},
+			certificates:      []ResourceReference{{Name: "cert"}, {Name: "missing"}},
+			expAllow:          false,
+			expErrMessage:     `apigateway.consul.hashicorp.com "gateway" is invalid: spec.listeners[0].tls.certificates[1].name: Not found: "missing"`,
+		},
+		"certificate in a different mirrored namespace": {
+			existingResources: []runtime.Object{inlineCert},
+			certificates:      []ResourceReference{{Name: "cert", Namespace: "other"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true},
+			expAllow:          false,
+			expErrMessage:     `apigateway.consul.hashicorp.com "gateway" is invalid: spec.listeners[0].tls.certificates[0].name: Not found: "cert"`,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			gateway := &APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+				Spec: APIGatewaySpec{
+					Listeners: []APIGatewayListener{
+						{
+							Name:     "https",
+							Port:     443,
+							Protocol: "http",
+							TLS:      APIGatewayTLSConfiguration{Certificates: c.certificates},
+						},
+					},
+				},
+			}
+			marshalledRequestObject, err := json.Marshal(gateway)
+			require.NoError(t, err)
+			s := runtime.NewScheme()
+			s.AddKnownTypes(GroupVersion, &APIGateway{}, &APIGatewayList{}, &InlineCertificate{}, &InlineCertificateList{})
+			client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
+			decoder, err := admission.NewDecoder(s)
+			require.NoError(t, err)
+
+			validator := &APIGatewayWebhook{
+				Client:     client,
+				Logger:     logrtest.TestLogger{T: t},
+				decoder:    decoder,
+				ConsulMeta: c.consulMeta,
+			}
+			response := validator.Handle(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      gateway.KubernetesName(),
+					Namespace: gateway.Namespace,
+					Operation: admissionv1.Create,
+					Object: runtime.RawExtension{
+						Raw: marshalledRequestObject,
+					},
+				},
+			})
+
+			require.Equal(t, c.expAllow, response.Allowed)
+			if c.expErrMessage != "" {
+				require.Equal(t, c.expErrMessage, response.AdmissionResponse.Result.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const ConsulKVKubeKind = "consulkv"
+
+func init() {
+	SchemeBuilder.Register(&ConsulKV{}, &ConsulKVList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ConsulKV is the Schema for the consulkvs API. It writes key/value pairs
+// under a prefix in the Consul KV store.
+// +kubebuilder:printcolumn:name="Prefix",type="string",JSONPath=".spec.prefix",description="The Consul KV prefix the keys are written under"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="consul-kv"
+type ConsulKV struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConsulKVSpec   `json:"spec,omitempty"`
+	Status ConsulKVStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ConsulKVList contains a list of ConsulKV.
+type ConsulKVList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsulKV `json:"items"`
+}
+
+// ConsulKVSpec defines the desired state of ConsulKV.
+type ConsulKVSpec struct {
+	// Prefix is the path in the Consul KV store that all keys are written
+	// under, e.g. "config/web".
+	Prefix string `json:"prefix"`
+	// DataFrom writes every key of a ConfigMap or Secret in the namespace of
+	// this resource. Sources later in the list take precedence over earlier
+	// ones when they contain the same key.
+	DataFrom []ConsulKVDataSource `json:"dataFrom,omitempty"`
+	// Entries are individual keys to write. Entries take precedence over keys
+	// of the same name from DataFrom.
+	Entries []ConsulKVEntry `json:"entries,omitempty"`
+}
+
+// ConsulKVEntry is a single key to write.
+type ConsulKVEntry struct {
+	// Key is the key relative to the prefix.
+	Key string `json:"key"`
+	// Value is the value of the key. Cannot be used with ValueFrom.
+	Value string `json:"value,omitempty"`
+	// ValueFrom reads the value of the key from a ConfigMap or Secret in the
+	// namespace of this resource. Cannot be used with Value.
+	ValueFrom *ConsulKVValueSource `json:"valueFrom,omitempty"`
+}
+
+// ConsulKVValueSource is the source of a single value. Exactly one of
+// ConfigMapKeyRef or SecretKeyRef must be set.
+type ConsulKVValueSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap.
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret.
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// ConsulKVDataSource is a ConfigMap or Secret whose keys are all written.
+// Exactly one of ConfigMapRef or SecretRef must be set.
+type ConsulKVDataSource struct {
+	// Prefix is prepended to each key of the source, relative to the prefix
+	// of the resource.
+	Prefix string `json:"prefix,omitempty"`
+	// ConfigMapRef is the ConfigMap to read keys from.
+	ConfigMapRef *ConsulKVSourceReference `json:"configMapRef,omitempty"`
+	// SecretRef is the Secret to read keys from.
+	SecretRef *ConsulKVSourceReference `json:"secretRef,omitempty"`
+}
+
+// ConsulKVSourceReference references a ConfigMap or Secret.
+type ConsulKVSourceReference struct {
+	// Name is the name of the ConfigMap or Secret.
+	Name string `json:"name"`
+	// Optional specifies whether the ConfigMap or Secret must exist.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// ConsulKVStatus defines the observed state of ConsulKV.
+type ConsulKVStatus struct {
+	Status `json:",inline"`
+	// Keys are the Consul keys that were created by this resource. Only these
+	// keys are updated or deleted by the controller.
+	Keys []ConsulKVKeyStatus `json:"keys,omitempty"`
+}
+
+// ConsulKVKeyStatus records a key written by the controller.
+type ConsulKVKeyStatus struct {
+	// Key is the full path of the key in Consul.
+	Key string `json:"key"`
+	// ModifyIndex is the Consul modify index of the last write made by the
+	// controller. If the key's index in Consul differs then the key has been
+	// modified outside of this resource.
+	ModifyIndex uint64 `json:"modifyIndex"`
+}
+
+func (in *ConsulKV) KubeKind() string {
+	return ConsulKVKubeKind
+}
+
+func (in *ConsulKV) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// ConsulKey returns the full path in Consul of a key relative to the prefix.
+func (in *ConsulKV) ConsulKey(key string) string {
+	return strings.TrimSuffix(in.Spec.Prefix, "/") + "/" + key
+}
+
+// OwnedKeys returns the keys created by this resource mapped to the modify
+// index of the controller's last write.
+func (in *ConsulKV) OwnedKeys() map[string]uint64 {
+	owned := make(map[string]uint64, len(in.Status.Keys))
+	for _, k := range in.Status.Keys {
+		owned[k.Key] = k.ModifyIndex
+	}
+	return owned
+}
+
+// SetOwnedKeys records the keys created by this resource.
+func (in *ConsulKV) SetOwnedKeys(owned map[string]uint64) {
+	in.Status.Keys = nil
+	for key, index := range owned {
+		in.Status.Keys = append(in.Status.Keys, ConsulKVKeyStatus{Key: key, ModifyIndex: index})
+	}
+	sort.Slice(in.Status.Keys, func(i, j int) bool {
+		return in.Status.Keys[i].Key < in.Status.Keys[j].Key
+	})
+}
+
+func (in *ConsulKV) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *ConsulKV) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *ConsulKV) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+func (in *ConsulKV) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	prefix := strings.TrimSuffix(in.Spec.Prefix, "/")
+	if prefix == "" {
+		errs = append(errs, field.Required(path.Child("prefix"), "prefix must be set"))
+	} else if strings.HasPrefix(prefix, "/") {
+		errs = append(errs, field.Invalid(path.Child("prefix"), in.Spec.Prefix, "prefix cannot start with a '/'"))
+	}
+
+	for i, source := range in.Spec.DataFrom {
+		sourcePath := path.Child("dataFrom").Index(i)
+		if (source.ConfigMapRef == nil) == (source.SecretRef == nil) {
+			asJSON, _ := json.Marshal(source)
+			errs = append(errs, field.Invalid(sourcePath, string(asJSON), "exactly one of configMapRef or secretRef must be set"))
+		}
+		if source.ConfigMapRef != nil && source.ConfigMapRef.Name == "" {
+			errs = append(errs, field.Required(sourcePath.Child("configMapRef").Child("name"), "name must be set"))
+		}
+		if source.SecretRef != nil && source.SecretRef.Name == "" {
+			errs = append(errs, field.Required(sourcePath.Child("secretRef").Child("name"), "name must be set"))
+		}
+		if strings.HasPrefix(source.Prefix, "/") {
+			errs = append(errs, field.Invalid(sourcePath.Child("prefix"), source.Prefix, "prefix cannot start with a '/'"))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, entry := range in.Spec.Entries {
+		entryPath := path.Child("entries").Index(i)
+		switch {
+		case entry.Key == "":
+			errs = append(errs, field.Required(entryPath.Child("key"), "key must be set"))
+		case strings.HasPrefix(entry.Key, "/"):
+			errs = append(errs, field.Invalid(entryPath.Child("key"), entry.Key, "key cannot start with a '/'"))
+		case seen[entry.Key]:
+			errs = append(errs, field.Duplicate(entryPath.Child("key"), entry.Key))
+		}
+		seen[entry.Key] = true
+
+		if entry.ValueFrom == nil {
+			continue
+		}
+		if entry.Value != "" {
+			errs = append(errs, field.Invalid(entryPath.Child("value"), entry.Value, "value and valueFrom are mutually exclusive"))
+		}
+		if (entry.ValueFrom.ConfigMapKeyRef == nil) == (entry.ValueFrom.SecretKeyRef == nil) {
+			asJSON, _ := json.Marshal(entry.ValueFrom)
+			errs = append(errs, field.Invalid(entryPath.Child("valueFrom"), string(asJSON),
+				"exactly one of configMapKeyRef or secretKeyRef must be set"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ConsulKVKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
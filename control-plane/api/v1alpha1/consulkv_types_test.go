@@ -0,0 +1,173 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConsulKV_ConsulKey(t *testing.T) {
+	cases := map[string]struct {
+		prefix string
+		key    string
+		exp    string
+	}{
+		"prefix without trailing slash": {
+			prefix: "config/web",
+			key:    "port",
+			exp:    "config/web/port",
+		},
+		"prefix with trailing slash": {
+			prefix: "config/web/",
+			key:    "port",
+			exp:    "config/web/port",
+		},
+		"nested key": {
+			prefix: "config",
+			key:    "web/port",
+			exp:    "config/web/port",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			kv := &ConsulKV{Spec: ConsulKVSpec{Prefix: c.prefix}}
+			require.Equal(t, c.exp, kv.ConsulKey(c.key))
+		})
+	}
+}
+
+func TestConsulKV_OwnedKeys(t *testing.T) {
+	kv := &ConsulKV{}
+	require.Empty(t, kv.OwnedKeys())
+
+	kv.SetOwnedKeys(map[string]uint64{"b": 2, "a": 1})
+	require.Equal(t, []ConsulKVKeyStatus{
+		{Key: "a", ModifyIndex: 1},
+		{Key: "b", ModifyIndex: 2},
+	}, kv.Status.Keys)
+	require.Equal(t, map[string]uint64{"a": 1, "b": 2}, kv.OwnedKeys())
+
+	kv.SetOwnedKeys(nil)
+	require.Nil(t, kv.Status.Keys)
+}
+
+func TestConsulKV_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec            ConsulKVSpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: ConsulKVSpec{
+				Prefix: "config/web",
+				DataFrom: []ConsulKVDataSource{
+					{ConfigMapRef: &ConsulKVSourceReference{Name: "config"}},
+					{Prefix: "secrets/", SecretRef: &ConsulKVSourceReference{Name: "secret", Optional: true}},
+				},
+				Entries: []ConsulKVEntry{
+					{Key: "port", Value: "8080"},
+					{Key: "empty"},
+					{
+						Key: "password",
+						ValueFrom: &ConsulKVValueSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "secret"},
+								Key:                  "password",
+							},
+						},
+					},
+				},
+			},
+		},
+		"missing prefix": {
+			spec: ConsulKVSpec{Prefix: "/"},
+			expectedErrMsgs: []string{
+				`spec.prefix: Required value: prefix must be set`,
+			},
+		},
+		"absolute prefix": {
+			spec: ConsulKVSpec{Prefix: "/config"},
+			expectedErrMsgs: []string{
+				`spec.prefix: Invalid value: "/config": prefix cannot start with a '/'`,
+			},
+		},
+		"invalid data sources": {
+			spec: ConsulKVSpec{
+				Prefix: "config",
+				DataFrom: []ConsulKVDataSource{
+					{},
+					{
+						ConfigMapRef: &ConsulKVSourceReference{Name: "config"},
+						SecretRef:    &ConsulKVSourceReference{Name: "secret"},
+					},
+					{Prefix: "/abs", ConfigMapRef: &ConsulKVSourceReference{}},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.dataFrom[0]: Invalid value: "{}": exactly one of configMapRef or secretRef must be set`,
+				`spec.dataFrom[1]: Invalid value: "{\"configMapRef\":{\"name\":\"config\"},\"secretRef\":{\"name\":\"secret\"}}": exactly one of configMapRef or secretRef must be set`,
+				`spec.dataFrom[2].configMapRef.name: Required value: name must be set`,
+				`spec.dataFrom[2].prefix: Invalid value: "/abs": prefix cannot start with a '/'`,
+			},
+		},
+		"invalid entries": {
+			spec: ConsulKVSpec{
+				Prefix: "config",
+				Entries: []ConsulKVEntry{
+					{Value: "foo"},
+					{Key: "/abs"},
+					{Key: "dup"},
+					{Key: "dup"},
+					{Key: "both", Value: "foo", ValueFrom: &ConsulKVValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+							Key:                  "key",
+						},
+					}},
+					{Key: "neither", ValueFrom: &ConsulKVValueSource{}},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.entries[0].key: Required value: key must be set`,
+				`spec.entries[1].key: Invalid value: "/abs": key cannot start with a '/'`,
+				`spec.entries[3].key: Duplicate value: "dup"`,
+				`spec.entries[4].value: Invalid value: "foo": value and valueFrom are mutually exclusive`,
+				`spec.entries[5].valueFrom: Invalid value: "{}": exactly one of configMapKeyRef or secretKeyRef must be set`,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			kv := &ConsulKV{
+				ObjectMeta: metav1.ObjectMeta{Name: "kv"},
+				Spec:       c.spec,
+			}
+			err := kv.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsulKV_SetSyncedCondition(t *testing.T) {
+	kv := &ConsulKV{}
+	kv.SetSyncedCondition(corev1.ConditionFalse, "reason", "message")
+
+	require.Equal(t, corev1.ConditionFalse, kv.Status.Conditions[0].Status)
+	require.Equal(t, "reason", kv.Status.Conditions[0].Reason)
+	require.Equal(t, "message", kv.Status.Conditions[0].Message)
+	require.Equal(t, corev1.ConditionFalse, kv.SyncedConditionStatus())
+}
+
+func TestConsulKV_KubeKind(t *testing.T) {
+	require.Equal(t, "consulkv", (&ConsulKV{}).KubeKind())
+}
@@ -0,0 +1,271 @@
+package v1alpha1
+
+import (
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	ConsulNamespaceKubeKind = "consulnamespace"
+
+	// DeletionPolicyDelete deletes the resource from Consul when the
+	// custom resource is deleted.
+	DeletionPolicyDelete = "delete"
+	// DeletionPolicyRetain leaves the resource in Consul when the custom
+	// resource is deleted.
+	DeletionPolicyRetain = "retain"
+)
+
+func init() {
+	SchemeBuilder.Register(&ConsulNamespace{}, &ConsulNamespaceList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ConsulNamespace is the Schema for the consulnamespaces API. The name of the
+// resource is the name of the Consul namespace. Consul namespaces are a
+// Consul Enterprise feature.
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:scope=Cluster,shortName="consul-namespace"
+type ConsulNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConsulNamespaceSpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ConsulNamespaceList contains a list of ConsulNamespace.
+type ConsulNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsulNamespace `json:"items"`
+}
+
+// ConsulNamespaceSpec defines the desired state of ConsulNamespace.
+type ConsulNamespaceSpec struct {
+	// Description is a human-readable description of the namespace.
+	Description string `json:"description,omitempty"`
+	// Meta is arbitrary key/value metadata to set on the namespace.
+	Meta map[string]string `json:"meta,omitempty"`
+	// ACLs is the ACL configuration for the namespace.
+	ACLs *NamespaceACLConfig `json:"acls,omitempty"`
+	// DeletionPolicy determines what happens to the namespace in Consul when
+	// this resource is deleted. One of "delete" or "retain". Defaults to
+	// "delete". Namespaces that existed before this resource took them over,
+	// and the default namespace, are never deleted.
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// NamespaceACLConfig is the ACL configuration for a Consul namespace.
+type NamespaceACLConfig struct {
+	// PolicyDefaults is the list of names of ACL policies that are used as
+	// the parent authorizer for all tokens in the namespace.
+	PolicyDefaults []string `json:"policyDefaults,omitempty"`
+	// RoleDefaults is the list of names of ACL roles that are used as
+	// the parent authorizer for all tokens in the namespace.
+	RoleDefaults []string `json:"roleDefaults,omitempty"`
+}
+
+func (in *ConsulNamespace) KubeKind() string {
+	return ConsulNamespaceKubeKind
+}
+
+func (in *ConsulNamespace) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// ConsulName returns the name of the namespace in Consul.
+func (in *ConsulNamespace) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+// ShouldDelete returns true if the namespace should be deleted from Consul
+// when this resource is deleted.
+func (in *ConsulNamespace) ShouldDelete() bool {
+	return in.ConsulName() != common.DefaultConsulNamespace && in.Spec.DeletionPolicy != DeletionPolicyRetain
+}
+
+// CreatedMarker is the value of common.ResourceKey in the meta of the
+// namespace if this resource created it.
+func (in *ConsulNamespace) CreatedMarker() string {
+	return ConsulNamespaceKubeKind + "/" + in.ConsulName()
+}
+
+// CreatedInConsul returns true if this resource created the namespace in
+// Consul rather than taking over an existing one.
+func (in *ConsulNamespace) CreatedInConsul(candidate *capi.Namespace) bool {
+	return candidate != nil && candidate.Meta[common.ResourceKey] == in.CreatedMarker()
+}
+
+func (in *ConsulNamespace) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *ConsulNamespace) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *ConsulNamespace) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+// ToConsul converts the resource to a Consul namespace in the given partition.
+func (in *ConsulNamespace) ToConsul(partition string) *capi.Namespace {
+	ns := &capi.Namespace{
+		Name:        in.ConsulName(),
+		Description: in.Spec.Description,
+		Meta:        map[string]string{},
+		Partition:   partition,
+	}
+	for k, v := range in.Spec.Meta {
+		ns.Meta[k] = v
+	}
+	ns.Meta[common.SourceKey] = common.SourceValue
+
+	if in.Spec.ACLs != nil {
+		ns.ACLs = &capi.NamespaceACLConfig{
+			PolicyDefaults: aclLinks(in.Spec.ACLs.PolicyDefaults),
+			RoleDefaults:   aclLinks(in.Spec.ACLs.RoleDefaults),
+		}
+	}
+	return ns
+}
+
+// MatchesConsul returns true if the namespace in Consul has the same
+// description, metadata and ACL defaults as this resource. The marker of the
+// resource that created the namespace is ignored.
+func (in *ConsulNamespace) MatchesConsul(candidate *capi.Namespace) bool {
+	if candidate == nil {
+		return false
+	}
+	desired := in.ToConsul(candidate.Partition)
+	candidateMeta := make(map[string]string, len(candidate.Meta))
+	for k, v := range candidate.Meta {
+		if k != common.ResourceKey {
+			candidateMeta[k] = v
+		}
+	}
+	if desired.Description != candidate.Description || !stringMapsEqual(desired.Meta, candidateMeta) {
+		return false
+	}
+
+	var wantPolicies, wantRoles, gotPolicies, gotRoles []capi.ACLLink
+	if desired.ACLs != nil {
+		wantPolicies, wantRoles = desired.ACLs.PolicyDefaults, desired.ACLs.RoleDefaults
+	}
+	if candidate.ACLs != nil {
+		gotPolicies, gotRoles = candidate.ACLs.PolicyDefaults, candidate.ACLs.RoleDefaults
+	}
+	return aclLinkNamesEqual(wantPolicies, gotPolicies) && aclLinkNamesEqual(wantRoles, gotRoles)
+}
+
+func (in *ConsulNamespace) Validate(consulMeta common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if !consulMeta.NamespacesEnabled {
+		errs = append(errs, field.Invalid(field.NewPath("metadata").Child("name"), in.ConsulName(),
+			"Consul Enterprise namespaces must be enabled to create ConsulNamespace resources"))
+	}
+	if in.ConsulName() == common.WildcardNamespace {
+		errs = append(errs, field.Invalid(field.NewPath("metadata").Child("name"), in.ConsulName(),
+			"namespace name cannot be the wildcard namespace"))
+	}
+	errs = append(errs, validateDeletionPolicy(path.Child("deletionPolicy"), in.Spec.DeletionPolicy)...)
+	errs = append(errs, validateNamespaceMeta(path.Child("meta"), in.Spec.Meta)...)
+	if in.Spec.ACLs != nil {
+		errs = append(errs, validateACLLinkNames(path.Child("acls").Child("policyDefaults"), in.Spec.ACLs.PolicyDefaults)...)
+		errs = append(errs, validateACLLinkNames(path.Child("acls").Child("roleDefaults"), in.Spec.ACLs.RoleDefaults)...)
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ConsulNamespaceKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+// validateNamespaceMeta rejects the meta key that records the resource that
+// created a namespace so that resources can't claim namespaces they didn't
+// create.
+func validateNamespaceMeta(path *field.Path, meta map[string]string) field.ErrorList {
+	if _, ok := meta[common.ResourceKey]; ok {
+		return field.ErrorList{field.Invalid(path.Key(common.ResourceKey), meta[common.ResourceKey],
+			"meta key is reserved")}
+	}
+	return nil
+}
+
+func validateDeletionPolicy(path *field.Path, policy string) field.ErrorList {
+	switch policy {
+	case "", DeletionPolicyDelete, DeletionPolicyRetain:
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, policy,
+		notInSliceMessage([]string{DeletionPolicyDelete, DeletionPolicyRetain}))}
+}
+
+func validateACLLinkNames(path *field.Path, names []string) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]bool)
+	for i, name := range names {
+		if name == "" {
+			errs = append(errs, field.Required(path.Index(i), "name cannot be empty"))
+			continue
+		}
+		if seen[name] {
+			errs = append(errs, field.Duplicate(path.Index(i), name))
+		}
+		seen[name] = true
+	}
+	return errs
+}
+
+func aclLinks(names []string) []capi.ACLLink {
+	var links []capi.ACLLink
+	for _, name := range names {
+		links = append(links, capi.ACLLink{Name: name})
+	}
+	return links
+}
+
+// aclLinkNamesEqual compares ACL links by name since Consul fills in the IDs.
+func aclLinkNamesEqual(a, b []capi.ACLLink) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+func stringMapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
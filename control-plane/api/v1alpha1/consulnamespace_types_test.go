@@ -0,0 +1,356 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConsulNamespace_ToConsul(t *testing.T) {
+	cases := map[string]struct {
+		Ours      ConsulNamespace
+		Partition string
+		Exp       *capi.Namespace
+	}{
+		"empty fields": {
+			Ours: ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+			Exp: &capi.Namespace{
+				Name: "ns",
+				Meta: map[string]string{
+					common.SourceKey: common.SourceValue,
+				},
+			},
+		},
+		"every field set": {
+			Ours: ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+				Spec: ConsulNamespaceSpec{
+					Description: "description",
+					Meta: map[string]string{
+						"team": "platform",
+					},
+					ACLs: &NamespaceACLConfig{
+						PolicyDefaults: []string{"policy"},
+						RoleDefaults:   []string{"role"},
+					},
+				},
+			},
+			Partition: "part",
+			Exp: &capi.Namespace{
+				Name:        "ns",
+				Description: "description",
+				Partition:   "part",
+				Meta: map[string]string{
+					"team":           "platform",
+					common.SourceKey: common.SourceValue,
+				},
+				ACLs: &capi.NamespaceACLConfig{
+					PolicyDefaults: []capi.ACLLink{{Name: "policy"}},
+					RoleDefaults:   []capi.ACLLink{{Name: "role"}},
+				},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.Exp, c.Ours.ToConsul(c.Partition))
+		})
+	}
+}
+
+func TestConsulNamespace_MatchesConsul(t *testing.T) {
+	ours := ConsulNamespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ns",
+		},
+		Spec: ConsulNamespaceSpec{
+			Description: "description",
+			Meta: map[string]string{
+				"team": "platform",
+			},
+			ACLs: &NamespaceACLConfig{
+				PolicyDefaults: []string{"policy"},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		Theirs  *capi.Namespace
+		Matches bool
+	}{
+		"nil": {
+			Theirs:  nil,
+			Matches: false,
+		},
+		"all fields match ignoring IDs and indexes": {
+			Theirs: &capi.Namespace{
+				Name:        "ns",
+				Description: "description",
+				Meta: map[string]string{
+					"team":           "platform",
+					common.SourceKey: common.SourceValue,
+				},
+				ACLs: &capi.NamespaceACLConfig{
+					PolicyDefaults: []capi.ACLLink{{ID: "id", Name: "policy"}},
+				},
+				CreateIndex: 1,
+				ModifyIndex: 2,
+			},
+			Matches: true,
+		},
+		"created marker is ignored": {
+			Theirs: &capi.Namespace{
+				Name:        "ns",
+				Description: "description",
+				Meta: map[string]string{
+					"team":             "platform",
+					common.SourceKey:   common.SourceValue,
+					common.ResourceKey: "consulnamespace/ns",
+				},
+				ACLs: &capi.NamespaceACLConfig{
+					PolicyDefaults: []capi.ACLLink{{Name: "policy"}},
+				},
+			},
+			Matches: true,
+		},
+		"description differs": {
+			Theirs: &capi.Namespace{
+				Name:        "ns",
+				Description: "other",
+				Meta: map[string]string{
+					"team":           "platform",
+					common.SourceKey: common.SourceValue,
+				},
+				ACLs: &capi.NamespaceACLConfig{
+					PolicyDefaults: []capi.ACLLink{{Name: "policy"}},
+				},
+			},
+			Matches: false,
+		},
+		"meta differs": {
+			Theirs: &capi.Namespace{
+				Name:        "ns",
+				Description: "description",
+				Meta: map[string]string{
+					"team": "platform",
+				},
+				ACLs: &capi.NamespaceACLConfig{
+					PolicyDefaults: []capi.ACLLink{{Name: "policy"}},
+				},
+			},
+			Matches: false,
+		},
+		"acls differ": {
+			Theirs: &capi.Namespace{
+				Name:        "ns",
+				Description: "description",
+				Meta: map[string]string{
+					"team":           "platform",
+					common.SourceKey: common.SourceValue,
+				},
+			},
+			Matches: false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.Matches, ours.MatchesConsul(c.Theirs))
+		})
+	}
+}
+
+func TestConsulNamespace_ShouldDelete(t *testing.T) {
+	cases := map[string]struct {
+		Name           string
+		DeletionPolicy string
+		Exp            bool
+	}{
+		"default policy": {
+			Name: "ns",
+			Exp:  true,
+		},
+		"delete": {
+			Name:           "ns",
+			DeletionPolicy: DeletionPolicyDelete,
+			Exp:            true,
+		},
+		"retain": {
+			Name:           "ns",
+			DeletionPolicy: DeletionPolicyRetain,
+			Exp:            false,
+		},
+		"default namespace is never deleted": {
+			Name:           common.DefaultConsulNamespace,
+			DeletionPolicy: DeletionPolicyDelete,
+			Exp:            false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ns := ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: c.Name},
+				Spec:       ConsulNamespaceSpec{DeletionPolicy: c.DeletionPolicy},
+			}
+			require.Equal(t, c.Exp, ns.ShouldDelete())
+		})
+	}
+}
+
+func TestConsulNamespace_Validate(t *testing.T) {
+	cases := map[string]struct {
+		input             *ConsulNamespace
+		namespacesEnabled bool
+		expectedErrMsgs   []string
+	}{
+		"valid": {
+			input: &ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+				Spec: ConsulNamespaceSpec{
+					DeletionPolicy: DeletionPolicyRetain,
+					ACLs: &NamespaceACLConfig{
+						PolicyDefaults: []string{"policy"},
+						RoleDefaults:   []string{"role"},
+					},
+				},
+			},
+			namespacesEnabled: true,
+		},
+		"namespaces disabled": {
+			input: &ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+			namespacesEnabled: false,
+			expectedErrMsgs: []string{
+				`consulnamespace.consul.hashicorp.com "ns" is invalid: metadata.name: Invalid value: "ns": Consul Enterprise namespaces must be enabled to create ConsulNamespace resources`,
+			},
+		},
+		"wildcard namespace": {
+			input: &ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "*",
+				},
+			},
+			namespacesEnabled: true,
+			expectedErrMsgs: []string{
+				`metadata.name: Invalid value: "*": namespace name cannot be the wildcard namespace`,
+			},
+		},
+		"invalid deletion policy": {
+			input: &ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+				Spec: ConsulNamespaceSpec{
+					DeletionPolicy: "orphan",
+				},
+			},
+			namespacesEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.deletionPolicy: Invalid value: "orphan": must be one of "delete", "retain"`,
+			},
+		},
+		"invalid acl defaults": {
+			input: &ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+				Spec: ConsulNamespaceSpec{
+					ACLs: &NamespaceACLConfig{
+						PolicyDefaults: []string{"policy", "policy"},
+						RoleDefaults:   []string{""},
+					},
+				},
+			},
+			namespacesEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.acls.policyDefaults[1]: Duplicate value: "policy"`,
+				`spec.acls.roleDefaults[0]: Required value: name cannot be empty`,
+			},
+		},
+		"reserved meta key": {
+			input: &ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+				Spec: ConsulNamespaceSpec{
+					Meta: map[string]string{common.ResourceKey: "consulnamespace/ns"},
+				},
+			},
+			namespacesEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.meta[consul.hashicorp.com/source-resource]: Invalid value: "consulnamespace/ns": meta key is reserved`,
+			},
+		},
+	}
+
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.input.Validate(common.ConsulMeta{NamespacesEnabled: testCase.namespacesEnabled})
+			if len(testCase.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range testCase.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsulNamespace_CreatedInConsul(t *testing.T) {
+	ns := &ConsulNamespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+	require.False(t, ns.CreatedInConsul(nil))
+	require.False(t, ns.CreatedInConsul(&capi.Namespace{Name: "ns", Meta: map[string]string{common.SourceKey: common.SourceValue}}))
+	require.False(t, ns.CreatedInConsul(&capi.Namespace{Name: "ns", Meta: map[string]string{common.ResourceKey: "consulpartition/ns"}}))
+	require.True(t, ns.CreatedInConsul(&capi.Namespace{Name: "ns", Meta: map[string]string{common.ResourceKey: "consulnamespace/ns"}}))
+}
+
+func TestConsulNamespace_SetSyncedCondition(t *testing.T) {
+	ns := &ConsulNamespace{}
+	ns.SetSyncedCondition(corev1.ConditionTrue, "reason", "message")
+
+	require.Equal(t, corev1.ConditionTrue, ns.Status.Conditions[0].Status)
+	require.Equal(t, "reason", ns.Status.Conditions[0].Reason)
+	require.Equal(t, "message", ns.Status.Conditions[0].Message)
+	now := metav1.Now()
+	require.True(t, ns.Status.Conditions[0].LastTransitionTime.Before(&now))
+}
+
+func TestConsulNamespace_SetLastSyncedTime(t *testing.T) {
+	ns := &ConsulNamespace{}
+	syncedTime := metav1.NewTime(time.Now())
+	ns.SetLastSyncedTime(&syncedTime)
+
+	require.Equal(t, &syncedTime, ns.Status.LastSyncedTime)
+}
+
+func TestConsulNamespace_SyncedConditionStatusWhenStatusNil(t *testing.T) {
+	require.Equal(t, corev1.ConditionUnknown, (&ConsulNamespace{}).SyncedConditionStatus())
+}
+
+func TestConsulNamespace_KubeKind(t *testing.T) {
+	require.Equal(t, "consulnamespace", (&ConsulNamespace{}).KubeKind())
+}
+
+func TestConsulNamespace_ConsulName(t *testing.T) {
+	require.Equal(t, "foo", (&ConsulNamespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}).ConsulName())
+}
@@ -0,0 +1,180 @@
+package v1alpha1
+
+import (
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const ConsulPartitionKubeKind = "consulpartition"
+
+func init() {
+	SchemeBuilder.Register(&ConsulPartition{}, &ConsulPartitionList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ConsulPartition is the Schema for the consulpartitions API. The name of the
+// resource is the name of the Consul admin partition. Admin partitions are a
+// Consul Enterprise feature.
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:scope=Cluster,shortName="consul-partition"
+type ConsulPartition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConsulPartitionSpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ConsulPartitionList contains a list of ConsulPartition.
+type ConsulPartitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsulPartition `json:"items"`
+}
+
+// ConsulPartitionSpec defines the desired state of ConsulPartition.
+type ConsulPartitionSpec struct {
+	// Description is a human-readable description of the partition.
+	Description string `json:"description,omitempty"`
+	// DefaultNamespace configures the default namespace of the partition.
+	// Consul partitions don't support metadata or ACL defaults directly so
+	// they are set on the partition's default namespace instead.
+	DefaultNamespace *PartitionDefaultNamespace `json:"defaultNamespace,omitempty"`
+	// DeletionPolicy determines what happens to the partition in Consul when
+	// this resource is deleted. One of "delete" or "retain". Defaults to
+	// "delete". Partitions that existed before this resource took them over
+	// are never deleted.
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// PartitionDefaultNamespace configures the default namespace of a partition.
+type PartitionDefaultNamespace struct {
+	// Meta is arbitrary key/value metadata to set on the default namespace.
+	Meta map[string]string `json:"meta,omitempty"`
+	// ACLs is the ACL configuration for the default namespace.
+	ACLs *NamespaceACLConfig `json:"acls,omitempty"`
+}
+
+func (in *ConsulPartition) KubeKind() string {
+	return ConsulPartitionKubeKind
+}
+
+func (in *ConsulPartition) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// ConsulName returns the name of the partition in Consul.
+func (in *ConsulPartition) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+// ShouldDelete returns true if the partition should be deleted from Consul
+// when this resource is deleted.
+func (in *ConsulPartition) ShouldDelete() bool {
+	return in.Spec.DeletionPolicy != DeletionPolicyRetain
+}
+
+// CreatedMarker is the value of common.ResourceKey in the meta of the default
+// namespace of the partition if this resource created it. Partitions have no
+// meta of their own.
+func (in *ConsulPartition) CreatedMarker() string {
+	return ConsulPartitionKubeKind + "/" + in.ConsulName()
+}
+
+// CreatedInConsul returns true if this resource created the partition in
+// Consul rather than taking over an existing one, given the default namespace
+// of the partition.
+func (in *ConsulPartition) CreatedInConsul(defaultNS *capi.Namespace) bool {
+	return defaultNS != nil && defaultNS.Meta[common.ResourceKey] == in.CreatedMarker()
+}
+
+func (in *ConsulPartition) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *ConsulPartition) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *ConsulPartition) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+// ToConsul converts the resource to a Consul partition.
+func (in *ConsulPartition) ToConsul() *capi.Partition {
+	return &capi.Partition{
+		Name:        in.ConsulName(),
+		Description: in.Spec.Description,
+	}
+}
+
+// DefaultNamespaceToConsul returns the default namespace of the partition
+// or nil if it isn't configured.
+func (in *ConsulPartition) DefaultNamespaceToConsul() *ConsulNamespace {
+	if in.Spec.DefaultNamespace == nil {
+		return nil
+	}
+	return &ConsulNamespace{
+		ObjectMeta: metav1.ObjectMeta{Name: common.DefaultConsulNamespace},
+		Spec: ConsulNamespaceSpec{
+			Description: "Default namespace of partition " + in.ConsulName(),
+			Meta:        in.Spec.DefaultNamespace.Meta,
+			ACLs:        in.Spec.DefaultNamespace.ACLs,
+		},
+	}
+}
+
+// MatchesConsul returns true if the partition in Consul has the same
+// description as this resource.
+func (in *ConsulPartition) MatchesConsul(candidate *capi.Partition) bool {
+	return candidate != nil && candidate.Description == in.Spec.Description
+}
+
+func (in *ConsulPartition) Validate(consulMeta common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+	namePath := field.NewPath("metadata").Child("name")
+
+	if !consulMeta.PartitionsEnabled {
+		errs = append(errs, field.Invalid(namePath, in.ConsulName(),
+			"Consul Enterprise admin partitions must be enabled to create ConsulPartition resources"))
+	} else if consulMeta.Partition != common.DefaultConsulPartition {
+		errs = append(errs, field.Invalid(namePath, in.ConsulName(),
+			"partitions can only be managed from a cluster in the default partition"))
+	}
+	if in.ConsulName() == common.DefaultConsulPartition {
+		errs = append(errs, field.Invalid(namePath, in.ConsulName(),
+			"the default partition cannot be managed"))
+	}
+	errs = append(errs, validateDeletionPolicy(path.Child("deletionPolicy"), in.Spec.DeletionPolicy)...)
+	if in.Spec.DefaultNamespace != nil {
+		errs = append(errs, validateNamespaceMeta(path.Child("defaultNamespace").Child("meta"), in.Spec.DefaultNamespace.Meta)...)
+	}
+	if in.Spec.DefaultNamespace != nil && in.Spec.DefaultNamespace.ACLs != nil {
+		aclsPath := path.Child("defaultNamespace").Child("acls")
+		errs = append(errs, validateACLLinkNames(aclsPath.Child("policyDefaults"), in.Spec.DefaultNamespace.ACLs.PolicyDefaults)...)
+		errs = append(errs, validateACLLinkNames(aclsPath.Child("roleDefaults"), in.Spec.DefaultNamespace.ACLs.RoleDefaults)...)
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ConsulPartitionKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
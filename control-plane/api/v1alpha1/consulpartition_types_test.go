@@ -0,0 +1,186 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConsulPartition_ToConsul(t *testing.T) {
+	partition := ConsulPartition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "part",
+		},
+		Spec: ConsulPartitionSpec{
+			Description: "description",
+		},
+	}
+	require.Equal(t, &capi.Partition{Name: "part", Description: "description"}, partition.ToConsul())
+}
+
+func TestConsulPartition_MatchesConsul(t *testing.T) {
+	partition := ConsulPartition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "part",
+		},
+		Spec: ConsulPartitionSpec{
+			Description: "description",
+		},
+	}
+	require.False(t, partition.MatchesConsul(nil))
+	require.False(t, partition.MatchesConsul(&capi.Partition{Name: "part", Description: "other"}))
+	require.True(t, partition.MatchesConsul(&capi.Partition{Name: "part", Description: "description", CreateIndex: 1, ModifyIndex: 2}))
+}
+
+func TestConsulPartition_DefaultNamespaceToConsul(t *testing.T) {
+	partition := ConsulPartition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "part",
+		},
+	}
+	require.Nil(t, partition.DefaultNamespaceToConsul())
+
+	partition.Spec.DefaultNamespace = &PartitionDefaultNamespace{
+		Meta: map[string]string{"team": "platform"},
+		ACLs: &NamespaceACLConfig{
+			PolicyDefaults: []string{"policy"},
+		},
+	}
+	ns := partition.DefaultNamespaceToConsul()
+	require.NotNil(t, ns)
+	require.Equal(t, &capi.Namespace{
+		Name:        common.DefaultConsulNamespace,
+		Description: "Default namespace of partition part",
+		Partition:   "part",
+		Meta: map[string]string{
+			"team":           "platform",
+			common.SourceKey: common.SourceValue,
+		},
+		ACLs: &capi.NamespaceACLConfig{
+			PolicyDefaults: []capi.ACLLink{{Name: "policy"}},
+		},
+	}, ns.ToConsul("part"))
+	require.False(t, ns.ShouldDelete())
+}
+
+func TestConsulPartition_Validate(t *testing.T) {
+	cases := map[string]struct {
+		input           *ConsulPartition
+		consulMeta      common.ConsulMeta
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			input: &ConsulPartition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "part",
+				},
+				Spec: ConsulPartitionSpec{
+					DeletionPolicy: DeletionPolicyDelete,
+					DefaultNamespace: &PartitionDefaultNamespace{
+						ACLs: &NamespaceACLConfig{
+							PolicyDefaults: []string{"policy"},
+						},
+					},
+				},
+			},
+			consulMeta: common.ConsulMeta{PartitionsEnabled: true, Partition: common.DefaultConsulPartition},
+		},
+		"partitions disabled": {
+			input: &ConsulPartition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "part",
+				},
+			},
+			consulMeta: common.ConsulMeta{},
+			expectedErrMsgs: []string{
+				`consulpartition.consul.hashicorp.com "part" is invalid: metadata.name: Invalid value: "part": Consul Enterprise admin partitions must be enabled to create ConsulPartition resources`,
+			},
+		},
+		"not in default partition": {
+			input: &ConsulPartition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "part",
+				},
+			},
+			consulMeta: common.ConsulMeta{PartitionsEnabled: true, Partition: "other"},
+			expectedErrMsgs: []string{
+				`metadata.name: Invalid value: "part": partitions can only be managed from a cluster in the default partition`,
+			},
+		},
+		"default partition": {
+			input: &ConsulPartition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: common.DefaultConsulPartition,
+				},
+			},
+			consulMeta: common.ConsulMeta{PartitionsEnabled: true, Partition: common.DefaultConsulPartition},
+			expectedErrMsgs: []string{
+				`metadata.name: Invalid value: "default": the default partition cannot be managed`,
+			},
+		},
+		"invalid deletion policy and acl defaults": {
+			input: &ConsulPartition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "part",
+				},
+				Spec: ConsulPartitionSpec{
+					DeletionPolicy: "orphan",
+					DefaultNamespace: &PartitionDefaultNamespace{
+						ACLs: &NamespaceACLConfig{
+							RoleDefaults: []string{"role", "role"},
+						},
+					},
+				},
+			},
+			consulMeta: common.ConsulMeta{PartitionsEnabled: true, Partition: common.DefaultConsulPartition},
+			expectedErrMsgs: []string{
+				`spec.deletionPolicy: Invalid value: "orphan": must be one of "delete", "retain"`,
+				`spec.defaultNamespace.acls.roleDefaults[1]: Duplicate value: "role"`,
+			},
+		},
+	}
+
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.input.Validate(testCase.consulMeta)
+			if len(testCase.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range testCase.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsulPartition_SetSyncedCondition(t *testing.T) {
+	partition := &ConsulPartition{}
+	partition.SetSyncedCondition(corev1.ConditionFalse, "reason", "message")
+
+	require.Equal(t, corev1.ConditionFalse, partition.Status.Conditions[0].Status)
+	require.Equal(t, "reason", partition.Status.Conditions[0].Reason)
+	require.Equal(t, "message", partition.Status.Conditions[0].Message)
+}
+
+func TestConsulPartition_SetLastSyncedTime(t *testing.T) {
+	partition := &ConsulPartition{}
+	syncedTime := metav1.NewTime(time.Now())
+	partition.SetLastSyncedTime(&syncedTime)
+
+	require.Equal(t, &syncedTime, partition.Status.LastSyncedTime)
+}
+
+func TestConsulPartition_SyncedConditionStatusWhenStatusNil(t *testing.T) {
+	require.Equal(t, corev1.ConditionUnknown, (&ConsulPartition{}).SyncedConditionStatus())
+}
+
+func TestConsulPartition_KubeKind(t *testing.T) {
+	require.Equal(t, "consulpartition", (&ConsulPartition{}).KubeKind())
+}
@@ -0,0 +1,232 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// This file contains the optional checks that validate config entries against
+// the other config entry resources in this cluster, e.g. that a ServiceRouter
+// only targets services with an L7 protocol. Like the API gateway reference
+// checks, references to other admin partitions are not checked. Config entries
+// written to Consul directly are not taken into account either, so depending
+// on ConsulMeta.CrossResourceValidation the failures are either returned as
+// warnings or used to reject the resource.
+
+// l7Protocols are the service protocols that support L7 features such as
+// routing, splitting and L7 intentions.
+var l7Protocols = []string{"http", "http2", "grpc"}
+
+const l7ProtocolList = `"http", "http2", "grpc"`
+
+// crossResourceValidationEnabled returns true if the webhooks should check
+// resources against the resources they reference.
+func crossResourceValidationEnabled(consulMeta common.ConsulMeta) bool {
+	return consulMeta.CrossResourceValidation == common.CrossResourceValidationWarn ||
+		consulMeta.CrossResourceValidation == common.CrossResourceValidationDeny
+}
+
+// crossResourceResponse returns resp with the cross-resource validation errors
+// added as warnings, or an error response if resources that fail validation
+// are rejected.
+func crossResourceResponse(consulMeta common.ConsulMeta, resp admission.Response, kind, name string, errs field.ErrorList) admission.Response {
+	if len(errs) == 0 {
+		return resp
+	}
+	switch consulMeta.CrossResourceValidation {
+	case common.CrossResourceValidationDeny:
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: kind}, name, errs))
+	case common.CrossResourceValidationWarn:
+		var warnings []string
+		for _, err := range errs {
+			warnings = append(warnings, err.Error())
+		}
+		return resp.WithWarnings(warnings...)
+	}
+	return resp
+}
+
+// serviceProtocols looks up the protocol of services from the ServiceDefaults
+// and ProxyDefaults resources in this cluster.
+type serviceProtocols struct {
+	consulMeta      common.ConsulMeta
+	serviceDefaults []ServiceDefaults
+	// defaultProtocol is the protocol of services without a protocol set in
+	// their ServiceDefaults.
+	defaultProtocol string
+}
+
+func newServiceProtocols(ctx context.Context, c client.Client, consulMeta common.ConsulMeta) (*serviceProtocols, error) {
+	var serviceDefaults ServiceDefaultsList
+	if err := c.List(ctx, &serviceDefaults); err != nil {
+		return nil, err
+	}
+	var proxyDefaults ProxyDefaultsList
+	if err := c.List(ctx, &proxyDefaults); err != nil {
+		return nil, err
+	}
+
+	protocols := &serviceProtocols{
+		consulMeta:      consulMeta,
+		serviceDefaults: serviceDefaults.Items,
+		defaultProtocol: "tcp",
+	}
+	for _, item := range proxyDefaults.Items {
+		if item.KubernetesName() != common.Global {
+			continue
+		}
+		if protocol, ok := item.convertConfig()["protocol"].(string); ok && protocol != "" {
+			protocols.defaultProtocol = protocol
+		}
+	}
+	return protocols, nil
+}
+
+// protocol returns the protocol of the service with the given name in the
+// given Consul namespace.
+func (p *serviceProtocols) protocol(name, namespace string) string {
+	for _, item := range p.serviceDefaults {
+		if item.ConsulName() == name && consulNamespace(p.consulMeta, item.Namespace) == namespace {
+			if item.Spec.Protocol != "" {
+				return item.Spec.Protocol
+			}
+			break
+		}
+	}
+	return p.defaultProtocol
+}
+
+// validateSplitSubsets checks that the subsets used by the splits are defined
+// by the ServiceResolver of the split's service.
+func validateSplitSubsets(ctx context.Context, c client.Client, consulMeta common.ConsulMeta, splitter *ServiceSplitter) (field.ErrorList, error) {
+	var resolvers ServiceResolverList
+	if err := c.List(ctx, &resolvers); err != nil {
+		return nil, err
+	}
+
+	var errs field.ErrorList
+	path := field.NewPath("spec").Child("splits")
+	for i, split := range splitter.Spec.Splits {
+		if split.ServiceSubset == "" || isOtherPartition(consulMeta, split.Partition) {
+			continue
+		}
+		service := split.Service
+		if service == "" {
+			service = splitter.ConsulName()
+		}
+		namespace := referencedNamespace(consulMeta, splitter.Namespace, split.Namespace)
+
+		found := false
+		for _, resolver := range resolvers.Items {
+			if resolver.ConsulName() == service && consulNamespace(consulMeta, resolver.Namespace) == namespace {
+				_, found = resolver.Spec.Subsets[split.ServiceSubset]
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, field.Invalid(path.Index(i).Child("serviceSubset"), split.ServiceSubset,
+				fmt.Sprintf("subset is not defined by a ServiceResolver for service %q", service)))
+		}
+	}
+	return errs, nil
+}
+
+// validateRouterProtocols checks that the router's service and the services it
+// routes to have an L7 protocol.
+func validateRouterProtocols(ctx context.Context, c client.Client, consulMeta common.ConsulMeta, router *ServiceRouter) (field.ErrorList, error) {
+	protocols, err := newServiceProtocols(ctx, c, consulMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs field.ErrorList
+	namespace := consulNamespace(consulMeta, router.Namespace)
+	if protocol := protocols.protocol(router.ConsulName(), namespace); !sliceContains(l7Protocols, protocol) {
+		errs = append(errs, field.Invalid(field.NewPath("metadata").Child("name"), router.ConsulName(),
+			fmt.Sprintf("service has protocol %q but routers require one of %s", protocol, l7ProtocolList)))
+	}
+	path := field.NewPath("spec").Child("routes")
+	for i, route := range router.Spec.Routes {
+		if route.Destination == nil || route.Destination.Service == "" || isOtherPartition(consulMeta, route.Destination.Partition) {
+			continue
+		}
+		destNamespace := referencedNamespace(consulMeta, router.Namespace, route.Destination.Namespace)
+		if protocol := protocols.protocol(route.Destination.Service, destNamespace); !sliceContains(l7Protocols, protocol) {
+			errs = append(errs, field.Invalid(path.Index(i).Child("destination", "service"), route.Destination.Service,
+				fmt.Sprintf("service has protocol %q but routers require one of %s", protocol, l7ProtocolList)))
+		}
+	}
+	return errs, nil
+}
+
+// validateIntentionPermissionProtocol checks that the destination has an L7
+// protocol if any of the sources set L7 permissions.
+func validateIntentionPermissionProtocol(ctx context.Context, c client.Client, consulMeta common.ConsulMeta, kubeNS string, destination IntentionDestination, sources []*SourceIntention, paths []*field.Path) (field.ErrorList, error) {
+	if destination.Name == wildcardServiceName {
+		return nil, nil
+	}
+	var withPermissions []int
+	for i, source := range sources {
+		if len(source.Permissions) > 0 {
+			withPermissions = append(withPermissions, i)
+		}
+	}
+	if len(withPermissions) == 0 {
+		return nil, nil
+	}
+
+	protocols, err := newServiceProtocols(ctx, c, consulMeta)
+	if err != nil {
+		return nil, err
+	}
+	protocol := protocols.protocol(destination.Name, referencedNamespace(consulMeta, kubeNS, destination.Namespace))
+	if sliceContains(l7Protocols, protocol) {
+		return nil, nil
+	}
+	var errs field.ErrorList
+	for _, i := range withPermissions {
+		errs = append(errs, field.Forbidden(paths[i].Child("permissions"),
+			fmt.Sprintf("destination service %q has protocol %q but L7 permissions require one of %s", destination.Name, protocol, l7ProtocolList)))
+	}
+	return errs, nil
+}
+
+// validateIngressListenerProtocols checks that the protocol of each listener
+// matches the protocol of its services. Services of HTTP listeners can have
+// any L7 protocol.
+func validateIngressListenerProtocols(ctx context.Context, c client.Client, consulMeta common.ConsulMeta, gateway *IngressGateway) (field.ErrorList, error) {
+	protocols, err := newServiceProtocols(ctx, c, consulMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs field.ErrorList
+	path := field.NewPath("spec").Child("listeners")
+	for i, listener := range gateway.Spec.Listeners {
+		listenerProtocol := listener.Protocol
+		if listenerProtocol == "" {
+			listenerProtocol = "tcp"
+		}
+		for j, svc := range listener.Services {
+			if svc.Name == wildcardServiceName || isOtherPartition(consulMeta, svc.Partition) {
+				continue
+			}
+			protocol := protocols.protocol(svc.Name, referencedNamespace(consulMeta, gateway.Namespace, svc.Namespace))
+			if protocol == listenerProtocol || (listenerProtocol == "http" && sliceContains(l7Protocols, protocol)) {
+				continue
+			}
+			errs = append(errs, field.Invalid(path.Index(i).Child("services").Index(j).Child("name"), svc.Name,
+				fmt.Sprintf("service has protocol %q but the listener has protocol %q", protocol, listenerProtocol)))
+		}
+	}
+	return errs, nil
+}
@@ -0,0 +1,207 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestHandle_CrossResourceValidation(t *testing.T) {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+	httpDefaults := func(name string) *ServiceDefaults {
+		return &ServiceDefaults{ObjectMeta: meta(name), Spec: ServiceDefaultsSpec{Protocol: "http"}}
+	}
+	resolver := &ServiceResolver{
+		ObjectMeta: meta("web"),
+		Spec:       ServiceResolverSpec{Subsets: ServiceResolverSubsetMap{"v1": {Filter: "Service.Meta.version == v1"}}},
+	}
+	splitter := func(subset string) *ServiceSplitter {
+		return &ServiceSplitter{
+			ObjectMeta: meta("web"),
+			Spec:       ServiceSplitterSpec{Splits: ServiceSplits{{Weight: 100, ServiceSubset: subset}}},
+		}
+	}
+	router := &ServiceRouter{
+		ObjectMeta: meta("web"),
+		Spec: ServiceRouterSpec{Routes: []ServiceRoute{{
+			Match:       &ServiceRouteMatch{HTTP: &ServiceRouteHTTPMatch{PathPrefix: "/api"}},
+			Destination: &ServiceRouteDestination{Service: "api"},
+		}}},
+	}
+	permissions := IntentionPermissions{{Action: "allow", HTTP: &IntentionHTTPPermission{PathPrefix: "/api"}}}
+	intentions := &ServiceIntentions{
+		ObjectMeta: meta("web"),
+		Spec: ServiceIntentionsSpec{
+			Destination: IntentionDestination{Name: "web"},
+			Sources:     SourceIntentions{{Name: "api", Permissions: permissions}},
+		},
+	}
+	intention := &ServiceIntention{
+		ObjectMeta: meta("api-to-web"),
+		Spec: ServiceIntentionSpec{
+			Destination: IntentionDestination{Name: "web"},
+			Source:      SourceIntention{Name: "api", Permissions: permissions},
+		},
+	}
+	ingress := &IngressGateway{
+		ObjectMeta: meta("ingress"),
+		Spec: IngressGatewaySpec{Listeners: []IngressListener{{
+			Port:     8080,
+			Protocol: "http",
+			Services: []IngressService{{Name: "web"}},
+		}}},
+	}
+	globalHTTP := &ProxyDefaults{
+		ObjectMeta: meta(common.Global),
+		Spec:       ProxyDefaultsSpec{Config: json.RawMessage(`{"protocol": "http"}`)},
+	}
+
+	cases := map[string]struct {
+		existingResources []runtime.Object
+		resource          client.Object
+		mode              string
+		expAllow          bool
+		expErrMessage     string
+		expWarnings       []string
+	}{
+		"disabled": {
+			resource: splitter("v2"),
+			mode:     common.CrossResourceValidationDisabled,
+			expAllow: true,
+		},
+		"splitter subset defined": {
+			existingResources: []runtime.Object{resolver},
+			resource:          splitter("v1"),
+			mode:              common.CrossResourceValidationDeny,
+			expAllow:          true,
+		},
+		"splitter subset not defined warns": {
+			existingResources: []runtime.Object{resolver},
+			resource:          splitter("v2"),
+			mode:              common.CrossResourceValidationWarn,
+			expAllow:          true,
+			expWarnings:       []string{`spec.splits[0].serviceSubset: Invalid value: "v2": subset is not defined by a ServiceResolver for service "web"`},
+		},
+		"splitter subset not defined is denied": {
+			resource:      splitter("v1"),
+			mode:          common.CrossResourceValidationDeny,
+			expAllow:      false,
+			expErrMessage: `servicesplitter.consul.hashicorp.com "web" is invalid: spec.splits[0].serviceSubset: Invalid value: "v1": subset is not defined by a ServiceResolver for service "web"`,
+		},
+		"router services are http": {
+			existingResources: []runtime.Object{httpDefaults("web"), httpDefaults("api")},
+			resource:          router,
+			mode:              common.CrossResourceValidationDeny,
+			expAllow:          true,
+		},
+		"router services are http by proxy defaults": {
+			existingResources: []runtime.Object{globalHTTP},
+			resource:          router,
+			mode:              common.CrossResourceValidationDeny,
+			expAllow:          true,
+		},
+		"router destination is tcp": {
+			existingResources: []runtime.Object{httpDefaults("web")},
+			resource:          router,
+			mode:              common.CrossResourceValidationDeny,
+			expAllow:          false,
+			expErrMessage:     `servicerouter.consul.hashicorp.com "web" is invalid: spec.routes[0].destination.service: Invalid value: "api": service has protocol "tcp" but routers require one of "http", "http2", "grpc"`,
+		},
+		"router services are tcp warns": {
+			resource: router,
+			mode:     common.CrossResourceValidationWarn,
+			expAllow: true,
+			expWarnings: []string{
+				`metadata.name: Invalid value: "web": service has protocol "tcp" but routers require one of "http", "http2", "grpc"`,
+				`spec.routes[0].destination.service: Invalid value: "api": service has protocol "tcp" but routers require one of "http", "http2", "grpc"`,
+			},
+		},
+		"intentions with permissions to http destination": {
+			existingResources: []runtime.Object{httpDefaults("web")},
+			resource:          intentions,
+			mode:              common.CrossResourceValidationDeny,
+			expAllow:          true,
+		},
+		"intentions with permissions to tcp destination": {
+			resource:      intentions,
+			mode:          common.CrossResourceValidationDeny,
+			expAllow:      false,
+			expErrMessage: `serviceintentions.consul.hashicorp.com "web" is invalid: spec.sources[0].permissions: Forbidden: destination service "web" has protocol "tcp" but L7 permissions require one of "http", "http2", "grpc"`,
+		},
+		"intention with permissions to tcp destination warns": {
+			resource:    intention,
+			mode:        common.CrossResourceValidationWarn,
+			expAllow:    true,
+			expWarnings: []string{`spec.source.permissions: Forbidden: destination service "web" has protocol "tcp" but L7 permissions require one of "http", "http2", "grpc"`},
+		},
+		"ingress listener matches service": {
+			existingResources: []runtime.Object{httpDefaults("web")},
+			resource:          ingress,
+			mode:              common.CrossResourceValidationDeny,
+			expAllow:          true,
+		},
+		"ingress listener does not match service": {
+			resource:      ingress,
+			mode:          common.CrossResourceValidationDeny,
+			expAllow:      false,
+			expErrMessage: `ingressgateway.consul.hashicorp.com "ingress" is invalid: spec.listeners[0].services[0].name: Invalid value: "web": service has protocol "tcp" but the listener has protocol "http"`,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			marshalledRequestObject, err := json.Marshal(c.resource)
+			require.NoError(t, err)
+			s := runtime.NewScheme()
+			require.NoError(t, AddToScheme(s))
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
+			decoder, err := admission.NewDecoder(s)
+			require.NoError(t, err)
+
+			consulMeta := common.ConsulMeta{CrossResourceValidation: c.mode}
+			logger := logrtest.TestLogger{T: t}
+			var handler admission.Handler
+			switch c.resource.(type) {
+			case *ServiceSplitter:
+				handler = &ServiceSplitterWebhook{Client: fakeClient, Logger: logger, decoder: decoder, ConsulMeta: consulMeta}
+			case *ServiceRouter:
+				handler = &ServiceRouterWebhook{Client: fakeClient, Logger: logger, decoder: decoder, ConsulMeta: consulMeta}
+			case *ServiceIntentions:
+				handler = &ServiceIntentionsWebhook{Client: fakeClient, Logger: logger, decoder: decoder, ConsulMeta: consulMeta}
+			case *ServiceIntention:
+				handler = &ServiceIntentionWebhook{Client: fakeClient, Logger: logger, decoder: decoder, ConsulMeta: consulMeta}
+			case *IngressGateway:
+				handler = &IngressGatewayWebhook{Client: fakeClient, Logger: logger, decoder: decoder, ConsulMeta: consulMeta}
+			}
+
+			response := handler.Handle(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      c.resource.GetName(),
+					Namespace: c.resource.GetNamespace(),
+					Operation: admissionv1.Create,
+					Object: runtime.RawExtension{
+						Raw: marshalledRequestObject,
+					},
+				},
+			})
+
+			require.Equal(t, c.expAllow, response.Allowed)
+			if c.expErrMessage != "" {
+				require.Equal(t, c.expErrMessage, response.AdmissionResponse.Result.Message)
+			}
+			require.Equal(t, c.expWarnings, response.Warnings)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package v1alpha1
+
+import (
+	"sort"
+
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DiscoveryChainStatus is the status of resources that are part of a
+// service's discovery chain, i.e. service routers, splitters and resolvers.
+// +k8s:deepcopy-gen=true
+// +k8s:openapi-gen=true
+type DiscoveryChainStatus struct {
+	Status `json:",inline"`
+
+	// DiscoveryChain is the discovery chain Consul compiled for the service
+	// the last time the resource was synced. It is kept when a change to the
+	// resource is rejected by Consul since the previous chain is still in use.
+	// +optional
+	DiscoveryChain *CompiledDiscoveryChain `json:"discoveryChain,omitempty"`
+}
+
+// CompiledDiscoveryChain is the discovery chain Consul compiled from the
+// router, splitter and resolver config entries of a service.
+type CompiledDiscoveryChain struct {
+	// Protocol is the protocol shared by everything in the chain.
+	Protocol string `json:"protocol,omitempty"`
+	// StartNode is the name of the first node in the chain.
+	StartNode string `json:"startNode,omitempty"`
+	// Nodes are the routers, splitters and resolvers in the chain.
+	Nodes []DiscoveryChainNode `json:"nodes,omitempty"`
+	// Targets are the services and subsets traffic can be sent to.
+	Targets []DiscoveryChainTarget `json:"targets,omitempty"`
+}
+
+// DiscoveryChainNode is a router, splitter or resolver in a discovery chain.
+type DiscoveryChainNode struct {
+	// Name of the node.
+	Name string `json:"name"`
+	// Type of the node, one of router, splitter or resolver.
+	Type string `json:"type"`
+	// Routes are the names of the nodes each route of a router leads to, in
+	// the order the routes are matched.
+	Routes []string `json:"routes,omitempty"`
+	// Splits are the splits of a splitter.
+	Splits []DiscoveryChainSplit `json:"splits,omitempty"`
+	// Target is the ID of the target a resolver resolves to.
+	Target string `json:"target,omitempty"`
+	// Failover are the IDs of the targets a resolver fails over to, in order.
+	Failover []string `json:"failover,omitempty"`
+}
+
+// DiscoveryChainSplit is a split of a splitter in a discovery chain.
+type DiscoveryChainSplit struct {
+	// Weight is the percentage of traffic sent to the next node.
+	Weight float32 `json:"weight"`
+	// NextNode is the name of the node the traffic is sent to.
+	NextNode string `json:"nextNode"`
+}
+
+// DiscoveryChainTarget is a service or service subset in a discovery chain.
+type DiscoveryChainTarget struct {
+	// ID of the target.
+	ID string `json:"id"`
+	// Service is the name of the service.
+	Service string `json:"service"`
+	// ServiceSubset is the name of the subset of the service, if any.
+	ServiceSubset string `json:"serviceSubset,omitempty"`
+	// Filter is the filter that selects the instances of the subset.
+	Filter string `json:"filter,omitempty"`
+	// Namespace is the Consul namespace of the service.
+	Namespace string `json:"namespace,omitempty"`
+	// Datacenter is the datacenter of the service.
+	Datacenter string `json:"datacenter,omitempty"`
+}
+
+// SetDiscoveryChain updates the compiled discovery chain.
+func (s *DiscoveryChainStatus) SetDiscoveryChain(chain *capi.CompiledDiscoveryChain) {
+	s.DiscoveryChain = compiledDiscoveryChain(chain)
+}
+
+// SetDiscoveryChainCondition updates the DiscoveryChainCompiled condition.
+func (s *DiscoveryChainStatus) SetDiscoveryChainCondition(status corev1.ConditionStatus, reason, message string) {
+	// The chain is compiled on every sync so only record a transition if the
+	// condition changed.
+	if cond := s.GetCondition(ConditionDiscoveryChainCompiled); cond != nil &&
+		cond.Status == status && cond.Reason == reason && cond.Message == message {
+		return
+	}
+	s.setCondition(ConditionDiscoveryChainCompiled, status, reason, message)
+}
+
+// DiscoveryChainCondition gets the DiscoveryChainCompiled condition.
+func (s *DiscoveryChainStatus) DiscoveryChainCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := s.GetCondition(ConditionDiscoveryChainCompiled)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+// compiledDiscoveryChain converts the discovery chain returned by Consul to
+// its status representation. Nodes and targets are sorted so that
+// the status only changes when the chain does.
+func compiledDiscoveryChain(chain *capi.CompiledDiscoveryChain) *CompiledDiscoveryChain {
+	if chain == nil {
+		return nil
+	}
+	out := &CompiledDiscoveryChain{
+		Protocol:  chain.Protocol,
+		StartNode: chain.StartNode,
+	}
+	// Routers and splitters can have the same name so nodes are sorted by
+	// their key in the chain, which includes the node type.
+	keys := make([]string, 0, len(chain.Nodes))
+	for key := range chain.Nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		node := chain.Nodes[key]
+		n := DiscoveryChainNode{
+			Name: node.Name,
+			Type: node.Type,
+		}
+		for _, route := range node.Routes {
+			n.Routes = append(n.Routes, route.NextNode)
+		}
+		for _, split := range node.Splits {
+			n.Splits = append(n.Splits, DiscoveryChainSplit{
+				Weight:   split.Weight,
+				NextNode: split.NextNode,
+			})
+		}
+		if node.Resolver != nil {
+			n.Target = node.Resolver.Target
+			if node.Resolver.Failover != nil {
+				n.Failover = node.Resolver.Failover.Targets
+			}
+		}
+		out.Nodes = append(out.Nodes, n)
+	}
+	for _, target := range chain.Targets {
+		out.Targets = append(out.Targets, DiscoveryChainTarget{
+			ID:            target.ID,
+			Service:       target.Service,
+			ServiceSubset: target.ServiceSubset,
+			Filter:        target.Subset.Filter,
+			Namespace:     target.Namespace,
+			Datacenter:    target.Datacenter,
+		})
+	}
+	sort.Slice(out.Targets, func(i, j int) bool {
+		return out.Targets[i].ID < out.Targets[j].ID
+	})
+	return out
+}
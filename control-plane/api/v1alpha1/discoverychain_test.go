@@ -0,0 +1,139 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDiscoveryChainStatus_SetDiscoveryChain(t *testing.T) {
+	chain := &capi.CompiledDiscoveryChain{
+		ServiceName: "foo",
+		Protocol:    "http",
+		StartNode:   "router:foo.default.default",
+		Nodes: map[string]*capi.DiscoveryGraphNode{
+			"router:foo.default.default": {
+				Type: capi.DiscoveryGraphNodeTypeRouter,
+				Name: "foo.default.default",
+				Routes: []*capi.DiscoveryRoute{
+					{NextNode: "splitter:foo.default.default"},
+					{NextNode: "resolver:foo.default.default.dc1"},
+				},
+			},
+			"splitter:foo.default.default": {
+				Type: capi.DiscoveryGraphNodeTypeSplitter,
+				Name: "foo.default.default",
+				Splits: []*capi.DiscoverySplit{
+					{Weight: 90, NextNode: "resolver:foo.default.default.dc1"},
+					{Weight: 10, NextNode: "resolver:v2.foo.default.default.dc1"},
+				},
+			},
+			"resolver:foo.default.default.dc1": {
+				Type: capi.DiscoveryGraphNodeTypeResolver,
+				Name: "foo.default.default.dc1",
+				Resolver: &capi.DiscoveryResolver{
+					Target: "foo.default.default.dc1",
+					Failover: &capi.DiscoveryFailover{
+						Targets: []string{"foo.default.default.dc2"},
+					},
+				},
+			},
+			"resolver:v2.foo.default.default.dc1": {
+				Type: capi.DiscoveryGraphNodeTypeResolver,
+				Name: "v2.foo.default.default.dc1",
+				Resolver: &capi.DiscoveryResolver{
+					Target: "v2.foo.default.default.dc1",
+				},
+			},
+		},
+		Targets: map[string]*capi.DiscoveryTarget{
+			"v2.foo.default.default.dc1": {
+				ID:            "v2.foo.default.default.dc1",
+				Service:       "foo",
+				ServiceSubset: "v2",
+				Namespace:     "default",
+				Datacenter:    "dc1",
+				Subset:        capi.ServiceResolverSubset{Filter: "Service.Meta.version == v2"},
+			},
+			"foo.default.default.dc1": {
+				ID:         "foo.default.default.dc1",
+				Service:    "foo",
+				Namespace:  "default",
+				Datacenter: "dc1",
+			},
+			"foo.default.default.dc2": {
+				ID:         "foo.default.default.dc2",
+				Service:    "foo",
+				Namespace:  "default",
+				Datacenter: "dc2",
+			},
+		},
+	}
+
+	router := &ServiceRouter{}
+	router.SetDiscoveryChain(chain)
+	require.Equal(t, &CompiledDiscoveryChain{
+		Protocol:  "http",
+		StartNode: "router:foo.default.default",
+		Nodes: []DiscoveryChainNode{
+			{
+				Name:     "foo.default.default.dc1",
+				Type:     "resolver",
+				Target:   "foo.default.default.dc1",
+				Failover: []string{"foo.default.default.dc2"},
+			},
+			{
+				Name:   "v2.foo.default.default.dc1",
+				Type:   "resolver",
+				Target: "v2.foo.default.default.dc1",
+			},
+			{
+				Name: "foo.default.default",
+				Type: "router",
+				Routes: []string{
+					"splitter:foo.default.default",
+					"resolver:foo.default.default.dc1",
+				},
+			},
+			{
+				Name: "foo.default.default",
+				Type: "splitter",
+				Splits: []DiscoveryChainSplit{
+					{Weight: 90, NextNode: "resolver:foo.default.default.dc1"},
+					{Weight: 10, NextNode: "resolver:v2.foo.default.default.dc1"},
+				},
+			},
+		},
+		Targets: []DiscoveryChainTarget{
+			{ID: "foo.default.default.dc1", Service: "foo", Namespace: "default", Datacenter: "dc1"},
+			{ID: "foo.default.default.dc2", Service: "foo", Namespace: "default", Datacenter: "dc2"},
+			{ID: "v2.foo.default.default.dc1", Service: "foo", ServiceSubset: "v2", Filter: "Service.Meta.version == v2", Namespace: "default", Datacenter: "dc1"},
+		},
+	}, router.DiscoveryChain)
+
+	router.SetDiscoveryChain(nil)
+	require.Nil(t, router.DiscoveryChain)
+}
+
+func TestDiscoveryChainStatus_SetDiscoveryChainCondition(t *testing.T) {
+	resolver := &ServiceResolver{}
+	status, reason, message := resolver.DiscoveryChainCondition()
+	require.Equal(t, corev1.ConditionUnknown, status)
+	require.Empty(t, reason)
+	require.Empty(t, message)
+
+	resolver.SetSyncedCondition(corev1.ConditionTrue, "", "")
+	resolver.SetDiscoveryChainCondition(corev1.ConditionFalse, "SubsetNotFound", "no subset")
+	cond := *resolver.GetCondition(ConditionDiscoveryChainCompiled)
+
+	// Setting the same condition again keeps the transition time.
+	resolver.SetDiscoveryChainCondition(corev1.ConditionFalse, "SubsetNotFound", "no subset")
+	require.Equal(t, cond, *resolver.GetCondition(ConditionDiscoveryChainCompiled))
+	require.Equal(t, corev1.ConditionTrue, resolver.SyncedConditionStatus())
+
+	resolver.SetDiscoveryChainCondition(corev1.ConditionTrue, "", "")
+	status, _, _ = resolver.DiscoveryChainCondition()
+	require.Equal(t, corev1.ConditionTrue, status)
+}
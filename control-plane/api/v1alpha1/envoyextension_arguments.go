@@ -0,0 +1,242 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"sort"
+
+	capi "github.com/hashicorp/consul/api"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// This file contains the argument validation for the built-in Envoy
+// extensions. Arguments are decoded with encoding/json, so keys are matched
+// case-insensitively just as Consul does, e.g. "script" and "Script" are both
+// accepted. Only the fields needed to validate the arguments are declared;
+// any others are passed through to Consul untouched.
+
+// envoyExtensionArguments maps the name of each built-in extension to the
+// function that validates its arguments.
+var envoyExtensionArguments = map[string]func(json.RawMessage, *field.Path) field.ErrorList{
+	capi.BuiltinAWSLambdaExtension:         validateAWSLambdaArguments,
+	capi.BuiltinExtAuthzExtension:          validateExtAuthzArguments,
+	capi.BuiltinLuaExtension:               validateLuaArguments,
+	capi.BuiltinOTELAccessLoggingExtension: validateOTELAccessLoggingArguments,
+	capi.BuiltinPropertyOverrideExtension:  validatePropertyOverrideArguments,
+	capi.BuiltinWasmExtension:              validateWasmArguments,
+}
+
+func envoyExtensionNames() []string {
+	var names []string
+	for name := range envoyExtensionArguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type awsLambdaArguments struct {
+	ARN            string
+	InvocationMode string
+}
+
+func validateAWSLambdaArguments(raw json.RawMessage, path *field.Path) field.ErrorList {
+	var args awsLambdaArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return field.ErrorList{field.Invalid(path, string(raw), err.Error())}
+	}
+	var errs field.ErrorList
+	if args.ARN == "" {
+		errs = append(errs, field.Required(path.Child("ARN"), "ARN must be set"))
+	}
+	modes := []string{"synchronous", "asynchronous"}
+	if args.InvocationMode != "" && !sliceContains(modes, args.InvocationMode) {
+		errs = append(errs, field.Invalid(path.Child("InvocationMode"), args.InvocationMode, notInSliceMessage(modes)))
+	}
+	return errs
+}
+
+type luaArguments struct {
+	ProxyType string
+	Listener  string
+	Script    string
+}
+
+func validateLuaArguments(raw json.RawMessage, path *field.Path) field.ErrorList {
+	var args luaArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return field.ErrorList{field.Invalid(path, string(raw), err.Error())}
+	}
+	var errs field.ErrorList
+	errs = append(errs, validateExtensionProxyType(args.ProxyType, path)...)
+	errs = append(errs, validateTrafficDirection(args.Listener, path.Child("Listener"))...)
+	if args.Script == "" {
+		errs = append(errs, field.Required(path.Child("Script"), "Script must be set"))
+	}
+	return errs
+}
+
+// extensionTarget is the upstream service or URI an extension sends data to.
+type extensionTarget struct {
+	Service *struct {
+		Name string
+	}
+	URI string
+}
+
+type extensionService struct {
+	Target *extensionTarget
+}
+
+type extAuthzArguments struct {
+	ProxyType string
+	Config    *struct {
+		GrpcService *extensionService
+		HttpService *extensionService
+	}
+}
+
+func validateExtAuthzArguments(raw json.RawMessage, path *field.Path) field.ErrorList {
+	var args extAuthzArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return field.ErrorList{field.Invalid(path, string(raw), err.Error())}
+	}
+	var errs field.ErrorList
+	errs = append(errs, validateExtensionProxyType(args.ProxyType, path)...)
+	configPath := path.Child("Config")
+	if args.Config == nil {
+		return append(errs, field.Required(configPath, "Config must be set"))
+	}
+	switch {
+	case args.Config.GrpcService != nil && args.Config.HttpService != nil,
+		args.Config.GrpcService == nil && args.Config.HttpService == nil:
+		errs = append(errs, field.Invalid(configPath, "", "exactly one of GrpcService or HttpService must be set"))
+	case args.Config.GrpcService != nil:
+		errs = append(errs, args.Config.GrpcService.validate(configPath.Child("GrpcService"))...)
+	default:
+		errs = append(errs, args.Config.HttpService.validate(configPath.Child("HttpService"))...)
+	}
+	return errs
+}
+
+type otelAccessLoggingArguments struct {
+	ProxyType    string
+	ListenerType string
+	Config       *struct {
+		GrpcService *extensionService
+	}
+}
+
+func validateOTELAccessLoggingArguments(raw json.RawMessage, path *field.Path) field.ErrorList {
+	var args otelAccessLoggingArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return field.ErrorList{field.Invalid(path, string(raw), err.Error())}
+	}
+	var errs field.ErrorList
+	errs = append(errs, validateExtensionProxyType(args.ProxyType, path)...)
+	errs = append(errs, validateTrafficDirection(args.ListenerType, path.Child("ListenerType"))...)
+	configPath := path.Child("Config")
+	if args.Config == nil || args.Config.GrpcService == nil {
+		return append(errs, field.Required(configPath.Child("GrpcService"), "GrpcService must be set"))
+	}
+	return append(errs, args.Config.GrpcService.validate(configPath.Child("GrpcService"))...)
+}
+
+type propertyOverrideArguments struct {
+	ProxyType string
+	Patches   []struct {
+		ResourceFilter struct {
+			ResourceType     string
+			TrafficDirection string
+		}
+		Op    string
+		Path  string
+		Value interface{}
+	}
+}
+
+func validatePropertyOverrideArguments(raw json.RawMessage, path *field.Path) field.ErrorList {
+	var args propertyOverrideArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return field.ErrorList{field.Invalid(path, string(raw), err.Error())}
+	}
+	var errs field.ErrorList
+	errs = append(errs, validateExtensionProxyType(args.ProxyType, path)...)
+	if len(args.Patches) == 0 {
+		return append(errs, field.Required(path.Child("Patches"), "at least one patch must be set"))
+	}
+	resourceTypes := []string{"cluster", "cluster-load-assignment", "listener", "route"}
+	ops := []string{"add", "remove"}
+	for i, patch := range args.Patches {
+		patchPath := path.Child("Patches").Index(i)
+		filter := patch.ResourceFilter
+		if !sliceContains(resourceTypes, filter.ResourceType) {
+			errs = append(errs, field.Invalid(patchPath.Child("ResourceFilter", "ResourceType"), filter.ResourceType, notInSliceMessage(resourceTypes)))
+		}
+		errs = append(errs, validateTrafficDirection(filter.TrafficDirection, patchPath.Child("ResourceFilter", "TrafficDirection"))...)
+		if !sliceContains(ops, patch.Op) {
+			errs = append(errs, field.Invalid(patchPath.Child("Op"), patch.Op, notInSliceMessage(ops)))
+		}
+		if patch.Path == "" {
+			errs = append(errs, field.Required(patchPath.Child("Path"), "Path must be set"))
+		} else if invalidPathPrefix(patch.Path) {
+			errs = append(errs, field.Invalid(patchPath.Child("Path"), patch.Path, "must begin with a '/'"))
+		}
+		if patch.Op == "add" && patch.Value == nil {
+			errs = append(errs, field.Required(patchPath.Child("Value"), "Value must be set when Op is add"))
+		}
+		if patch.Op == "remove" && patch.Value != nil {
+			errs = append(errs, field.Invalid(patchPath.Child("Value"), patch.Value, "Value must not be set when Op is remove"))
+		}
+	}
+	return errs
+}
+
+type wasmArguments struct {
+	Protocol     string
+	ListenerType string
+	ProxyType    string
+}
+
+func validateWasmArguments(raw json.RawMessage, path *field.Path) field.ErrorList {
+	var args wasmArguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return field.ErrorList{field.Invalid(path, string(raw), err.Error())}
+	}
+	var errs field.ErrorList
+	errs = append(errs, validateExtensionProxyType(args.ProxyType, path)...)
+	errs = append(errs, validateTrafficDirection(args.ListenerType, path.Child("ListenerType"))...)
+	protocols := []string{"http", "tcp"}
+	if args.Protocol != "" && !sliceContains(protocols, args.Protocol) {
+		errs = append(errs, field.Invalid(path.Child("Protocol"), args.Protocol, notInSliceMessage(protocols)))
+	}
+	return errs
+}
+
+func (in *extensionService) validate(path *field.Path) field.ErrorList {
+	targetPath := path.Child("Target")
+	if in.Target == nil {
+		return field.ErrorList{field.Required(targetPath, "Target must be set")}
+	}
+	hasService := in.Target.Service != nil && in.Target.Service.Name != ""
+	if hasService == (in.Target.URI != "") {
+		return field.ErrorList{field.Invalid(targetPath, "", "exactly one of Service.Name or URI must be set")}
+	}
+	return nil
+}
+
+// validateExtensionProxyType checks the ProxyType argument shared by the
+// built-in extensions. It defaults to "connect-proxy", the only type supported.
+func validateExtensionProxyType(proxyType string, path *field.Path) field.ErrorList {
+	if proxyType != "" && proxyType != string(capi.ServiceKindConnectProxy) {
+		return field.ErrorList{field.Invalid(path.Child("ProxyType"), proxyType, notInSliceMessage([]string{string(capi.ServiceKindConnectProxy)}))}
+	}
+	return nil
+}
+
+func validateTrafficDirection(direction string, path *field.Path) field.ErrorList {
+	directions := []string{"inbound", "outbound"}
+	if !sliceContains(directions, direction) {
+		return field.ErrorList{field.Invalid(path, direction, notInSliceMessage(directions))}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestEnvoyExtension_Validate(t *testing.T) {
+	cases := map[string]struct {
+		name            string
+		arguments       string
+		expectedErrMsgs []string
+	}{
+		"aws lambda: valid": {
+			name:      "builtin/aws/lambda",
+			arguments: `{"ARN": "arn:aws:lambda:us-east-1:111111111111:function:lambda", "InvocationMode": "asynchronous"}`,
+		},
+		"aws lambda: invalid": {
+			name:      "builtin/aws/lambda",
+			arguments: `{"InvocationMode": "eventually"}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.ARN: Required value: ARN must be set`,
+				`envoyExtensions[0].arguments.InvocationMode: Invalid value: "eventually": must be one of "synchronous", "asynchronous"`,
+			},
+		},
+		"lua: valid with lower case keys": {
+			name:      "builtin/lua",
+			arguments: `{"proxyType": "connect-proxy", "listener": "outbound", "script": "function envoy_on_response(handle) end"}`,
+		},
+		"lua: invalid": {
+			name:      "builtin/lua",
+			arguments: `{"ProxyType": "mesh-gateway"}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.ProxyType: Invalid value: "mesh-gateway": must be one of "connect-proxy"`,
+				`envoyExtensions[0].arguments.Listener: Invalid value: "": must be one of "inbound", "outbound"`,
+				`envoyExtensions[0].arguments.Script: Required value: Script must be set`,
+			},
+		},
+		"ext-authz: valid http service": {
+			name:      "builtin/ext-authz",
+			arguments: `{"Config": {"HttpService": {"Target": {"URI": "127.0.0.1:9191"}}}}`,
+		},
+		"ext-authz: missing config": {
+			name:      "builtin/ext-authz",
+			arguments: `{}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.Config: Required value: Config must be set`,
+			},
+		},
+		"ext-authz: both services": {
+			name:      "builtin/ext-authz",
+			arguments: `{"Config": {"GrpcService": {"Target": {"URI": "127.0.0.1:9191"}}, "HttpService": {"Target": {"URI": "127.0.0.1:9191"}}}}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.Config: Invalid value: "": exactly one of GrpcService or HttpService must be set`,
+			},
+		},
+		"ext-authz: service and URI target": {
+			name:      "builtin/ext-authz",
+			arguments: `{"Config": {"GrpcService": {"Target": {"Service": {"Name": "authz"}, "URI": "127.0.0.1:9191"}}}}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.Config.GrpcService.Target: Invalid value: "": exactly one of Service.Name or URI must be set`,
+			},
+		},
+		"otel access logging: valid": {
+			name:      "builtin/otel-access-logging",
+			arguments: `{"ListenerType": "outbound", "Config": {"LogName": "access", "GrpcService": {"Target": {"Service": {"Name": "otel-collector"}}}}}`,
+		},
+		"otel access logging: invalid": {
+			name:      "builtin/otel-access-logging",
+			arguments: `{"Config": {"LogName": "access"}}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.ListenerType: Invalid value: "": must be one of "inbound", "outbound"`,
+				`envoyExtensions[0].arguments.Config.GrpcService: Required value: GrpcService must be set`,
+			},
+		},
+		"property override: valid": {
+			name: "builtin/property-override",
+			arguments: `{"Patches": [
+				{"ResourceFilter": {"ResourceType": "cluster", "TrafficDirection": "outbound"}, "Op": "add", "Path": "/respect_dns_ttl", "Value": true},
+				{"ResourceFilter": {"ResourceType": "listener", "TrafficDirection": "inbound"}, "Op": "remove", "Path": "/per_connection_buffer_limit_bytes"}
+			]}`,
+		},
+		"property override: no patches": {
+			name:      "builtin/property-override",
+			arguments: `{"Patches": []}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.Patches: Required value: at least one patch must be set`,
+			},
+		},
+		"property override: invalid patches": {
+			name: "builtin/property-override",
+			arguments: `{"Patches": [
+				{"ResourceFilter": {"ResourceType": "secret", "TrafficDirection": "inbound"}, "Op": "replace", "Path": "respect_dns_ttl"},
+				{"ResourceFilter": {"ResourceType": "cluster", "TrafficDirection": "outbound"}, "Op": "add", "Path": "/respect_dns_ttl"},
+				{"ResourceFilter": {"ResourceType": "cluster", "TrafficDirection": "outbound"}, "Op": "remove", "Path": "/respect_dns_ttl", "Value": true}
+			]}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.Patches[0].ResourceFilter.ResourceType: Invalid value: "secret": must be one of "cluster", "cluster-load-assignment", "listener", "route"`,
+				`envoyExtensions[0].arguments.Patches[0].Op: Invalid value: "replace": must be one of "add", "remove"`,
+				`envoyExtensions[0].arguments.Patches[0].Path: Invalid value: "respect_dns_ttl": must begin with a '/'`,
+				`envoyExtensions[0].arguments.Patches[1].Value: Required value: Value must be set when Op is add`,
+				`envoyExtensions[0].arguments.Patches[2].Value: Invalid value: true: Value must not be set when Op is remove`,
+			},
+		},
+		"wasm: valid": {
+			name:      "builtin/wasm",
+			arguments: `{"Protocol": "tcp", "ListenerType": "inbound", "PluginConfig": {"VmConfig": {"Code": {"Local": {"Filename": "plugin.wasm"}}}}}`,
+		},
+		"wasm: invalid": {
+			name:      "builtin/wasm",
+			arguments: `{"Protocol": "udp", "ListenerType": "inbound"}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments.Protocol: Invalid value: "udp": must be one of "http", "tcp"`,
+			},
+		},
+		"arguments are not a map": {
+			name:      "builtin/lua",
+			arguments: `"script"`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments: Invalid value: "\"script\"": must be valid map value: json: cannot unmarshal string into Go value of type map[string]interface {}`,
+			},
+		},
+		"arguments of the wrong type": {
+			name:      "builtin/lua",
+			arguments: `{"Listener": "inbound", "Script": 1}`,
+			expectedErrMsgs: []string{
+				`envoyExtensions[0].arguments: Invalid value: "{\"Listener\": \"inbound\", \"Script\": 1}": json: cannot unmarshal number into Go struct field luaArguments.Script of type string`,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			extensions := EnvoyExtensions{{Name: c.name, Arguments: json.RawMessage(c.arguments)}}
+			errs := extensions.validate(field.NewPath("envoyExtensions"))
+			if len(c.expectedErrMsgs) == 0 {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, len(c.expectedErrMsgs))
+			for _, s := range c.expectedErrMsgs {
+				require.Contains(t, errs.ToAggregate().Error(), s)
+			}
+		})
+	}
+}
@@ -70,6 +70,8 @@ type ServiceConsumer struct {
 	Partition string `json:"partition,omitempty"`
 	// [Experimental] Peer is the name of the peer to export the service to.
 	Peer string `json:"peer,omitempty"`
+	// SamenessGroup is the name of the sameness group to export the service to.
+	SamenessGroup string `json:"samenessGroup,omitempty"`
 }
 
 func (in *ExportedServices) GetObjectMeta() metav1.ObjectMeta {
@@ -119,15 +121,7 @@ func (in *ExportedServices) KubernetesName() string {
 }
 
 func (in *ExportedServices) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *ExportedServices) SetLastSyncedTime(time *metav1.Time) {
@@ -166,8 +160,9 @@ func (in *ExportedService) toConsul() capi.ExportedService {
 	var consumers []capi.ServiceConsumer
 	for _, consumer := range in.Consumers {
 		consumers = append(consumers, capi.ServiceConsumer{
-			Partition: consumer.Partition,
-			Peer:      consumer.Peer,
+			Partition:     consumer.Partition,
+			Peer:          consumer.Peer,
+			SamenessGroup: consumer.SamenessGroup,
 		})
 	}
 	return capi.ExportedService{
@@ -230,14 +225,35 @@ func (in *ServiceConsumer) validate(path *field.Path, consulMeta common.ConsulMe
 	if in.Partition != "" && in.Peer != "" {
 		return field.Invalid(path, *in, "both partition and peer cannot be specified.")
 	}
-	if in.Partition == "" && in.Peer == "" {
-		return field.Invalid(path, *in, "either partition or peer must be specified.")
+	if in.SamenessGroup != "" && (in.Partition != "" || in.Peer != "") {
+		return field.Invalid(path, *in, "samenessGroup cannot be specified with partition or peer.")
+	}
+	if in.Partition == "" && in.Peer == "" && in.SamenessGroup == "" {
+		return field.Invalid(path, *in, "one of partition, peer or samenessGroup must be specified.")
 	}
 	if !consulMeta.PartitionsEnabled && in.Partition != "" {
 		return field.Invalid(path.Child("partitions"), in.Partition, "Consul Admin Partitions need to be enabled to specify partition.")
 	}
+	if !consulMeta.PartitionsEnabled && in.SamenessGroup != "" {
+		return field.Invalid(path.Child("samenessGroup"), in.SamenessGroup, "Consul Admin Partitions need to be enabled to specify samenessGroup.")
+	}
 	return nil
 }
 
+// samenessGroupRefs returns every SamenessGroup referenced by the consumers of
+// the exported services.
+func (in *ExportedServices) samenessGroupRefs() []samenessGroupRef {
+	var refs []samenessGroupRef
+	for i, service := range in.Spec.Services {
+		for j, consumer := range service.Consumers {
+			if consumer.SamenessGroup != "" {
+				path := field.NewPath("spec").Child("services").Index(i).Child("consumers").Index(j).Child("samenessGroup")
+				refs = append(refs, samenessGroupRef{name: consumer.SamenessGroup, path: path})
+			}
+		}
+	}
+	return refs
+}
+
 func (in *ExportedServices) DefaultNamespaceFields(_ common.ConsulMeta) {
 }
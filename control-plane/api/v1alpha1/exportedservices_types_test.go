@@ -195,6 +195,9 @@ func TestExportedServices_ToConsul(t *testing.T) {
 								{
 									Peer: "third-peer",
 								},
+								{
+									SamenessGroup: "group",
+								},
 							},
 						},
 					},
@@ -231,6 +234,9 @@ func TestExportedServices_ToConsul(t *testing.T) {
 							{
 								Peer: "third-peer",
 							},
+							{
+								SamenessGroup: "group",
+							},
 						},
 					},
 				},
@@ -328,7 +334,7 @@ func TestExportedServices_Validate(t *testing.T) {
 			namespaceEnabled:  true,
 			partitionsEnabled: true,
 			expectedErrMsgs: []string{
-				`spec.services[0].consumers[0]: Invalid value: v1alpha1.ServiceConsumer{Partition:"second", Peer:"second-peer"}: both partition and peer cannot be specified.`,
+				`spec.services[0].consumers[0]: Invalid value: v1alpha1.ServiceConsumer{Partition:"second", Peer:"second-peer", SamenessGroup:""}: both partition and peer cannot be specified.`,
 			},
 		},
 		"neither partition nor peer name specified": {
@@ -351,7 +357,51 @@ func TestExportedServices_Validate(t *testing.T) {
 			namespaceEnabled:  true,
 			partitionsEnabled: true,
 			expectedErrMsgs: []string{
-				`spec.services[0].consumers[0]: Invalid value: v1alpha1.ServiceConsumer{Partition:"", Peer:""}: either partition or peer must be specified.`,
+				`spec.services[0].consumers[0]: Invalid value: v1alpha1.ServiceConsumer{Partition:"", Peer:"", SamenessGroup:""}: one of partition, peer or samenessGroup must be specified.`,
+			},
+		},
+		"sameness group and peer specified": {
+			input: &ExportedServices{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: common.DefaultConsulPartition,
+				},
+				Spec: ExportedServicesSpec{
+					Services: []ExportedService{
+						{
+							Name:      "service-frontend",
+							Namespace: "frontend",
+							Consumers: []ServiceConsumer{
+								{SamenessGroup: "group", Peer: "second-peer"},
+							},
+						},
+					},
+				},
+			},
+			namespaceEnabled:  true,
+			partitionsEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.services[0].consumers[0]: Invalid value: v1alpha1.ServiceConsumer{Partition:"", Peer:"second-peer", SamenessGroup:"group"}: samenessGroup cannot be specified with partition or peer.`,
+			},
+		},
+		"sameness group provided when partitions are disabled": {
+			input: &ExportedServices{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: common.DefaultConsulPartition,
+				},
+				Spec: ExportedServicesSpec{
+					Services: []ExportedService{
+						{
+							Name: "service-frontend",
+							Consumers: []ServiceConsumer{
+								{SamenessGroup: "group"},
+							},
+						},
+					},
+				},
+			},
+			partitionsEnabled: false,
+			expectedErrMsgs: []string{
+				`spec.services[0].consumers[0].samenessGroup: Invalid value: "group": Consul Admin Partitions need to be enabled to specify samenessGroup.`,
 			},
 		},
 		"partition provided when partitions are disabled": {
@@ -428,8 +478,8 @@ func TestExportedServices_Validate(t *testing.T) {
 			namespaceEnabled:  true,
 			partitionsEnabled: true,
 			expectedErrMsgs: []string{
-				`spec.services[0].consumers[0]: Invalid value: v1alpha1.ServiceConsumer{Partition:"second", Peer:"second-peer"}: both partition and peer cannot be specified.`,
-				`spec.services[0].consumers[1]: Invalid value: v1alpha1.ServiceConsumer{Partition:"", Peer:""}: either partition or peer must be specified.`,
+				`spec.services[0].consumers[0]: Invalid value: v1alpha1.ServiceConsumer{Partition:"second", Peer:"second-peer", SamenessGroup:""}: both partition and peer cannot be specified.`,
+				`spec.services[0].consumers[1]: Invalid value: v1alpha1.ServiceConsumer{Partition:"", Peer:"", SamenessGroup:""}: one of partition, peer or samenessGroup must be specified.`,
 			},
 		},
 	}
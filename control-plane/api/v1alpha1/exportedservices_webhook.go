@@ -8,6 +8,8 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/consul-k8s/control-plane/api/common"
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -56,6 +58,17 @@ func (v *ExportedServicesWebhook) Handle(ctx context.Context, req admission.Requ
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	// Consumers must reference existing SamenessGroup resources.
+	errs, err := missingSamenessGroups(ctx, v.Client, exports.samenessGroupRefs())
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ExportedServicesKubeKind},
+			exports.KubernetesName(), errs))
+	}
+
 	return admission.Allowed(fmt.Sprintf("valid %s request", exports.KubeKind()))
 }
 
@@ -163,6 +163,54 @@ func TestValidateExportedServices(t *testing.T) {
 			expAllow:      false,
 			expErrMessage: "exportedservices.consul.hashicorp.com \"other\" is invalid: spec.services[0]: Invalid value: []v1alpha1.ServiceConsumer(nil): service must have at least 1 consumer.",
 		},
+		"sameness group consumer": {
+			existingResources: []runtime.Object{&SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "group",
+					Namespace: otherNS,
+				},
+			}},
+			newResource: &ExportedServices{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: otherPartition,
+				},
+				Spec: ExportedServicesSpec{
+					Services: []ExportedService{
+						{
+							Name:      "service",
+							Consumers: []ServiceConsumer{{SamenessGroup: "group"}},
+						},
+					},
+				},
+			},
+			consulMeta: common.ConsulMeta{
+				PartitionsEnabled: true,
+				Partition:         otherPartition,
+			},
+			expAllow: true,
+		},
+		"sameness group consumer does not exist": {
+			existingResources: []runtime.Object{},
+			newResource: &ExportedServices{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: otherPartition,
+				},
+				Spec: ExportedServicesSpec{
+					Services: []ExportedService{
+						{
+							Name:      "service",
+							Consumers: []ServiceConsumer{{Partition: "default"}, {SamenessGroup: "group"}},
+						},
+					},
+				},
+			},
+			consulMeta: common.ConsulMeta{
+				PartitionsEnabled: true,
+				Partition:         otherPartition,
+			},
+			expAllow:      false,
+			expErrMessage: "exportedservices.consul.hashicorp.com \"other\" is invalid: spec.services[0].consumers[1].samenessGroup: Not found: \"group\"",
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -170,7 +218,7 @@ func TestValidateExportedServices(t *testing.T) {
 			marshalledRequestObject, err := json.Marshal(c.newResource)
 			require.NoError(t, err)
 			s := runtime.NewScheme()
-			s.AddKnownTypes(GroupVersion, &ExportedServices{}, &ExportedServicesList{})
+			s.AddKnownTypes(GroupVersion, &ExportedServices{}, &ExportedServicesList{}, &SamenessGroup{}, &SamenessGroupList{})
 			client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
 			decoder, err := admission.NewDecoder(s)
 			require.NoError(t, err)
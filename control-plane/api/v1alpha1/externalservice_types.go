@@ -0,0 +1,161 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const ExternalServiceKubeKind = "externalservice"
+
+// externalServiceProtocols are the protocols an external service can have.
+var externalServiceProtocols = []string{"tcp", "http", "http2", "grpc"}
+
+func init() {
+	SchemeBuilder.Register(&ExternalService{}, &ExternalServiceList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ExternalService is the Schema for the externalservices API. It declares a
+// service outside of the mesh that is reached through a terminating gateway.
+// The service is registered in the Consul catalog, or as a ServiceDefaults
+// destination, and linked to the gateway.
+// +kubebuilder:printcolumn:name="Gateway",type="string",JSONPath=".spec.terminatingGateway",description="The terminating gateway the service is linked to"
+// +kubebuilder:printcolumn:name="Address",type="string",JSONPath=".spec.address",description="The address of the service"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="external-service"
+type ExternalService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExternalServiceSpec   `json:"spec,omitempty"`
+	Status ExternalServiceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ExternalServiceList contains a list of ExternalService.
+type ExternalServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExternalService `json:"items"`
+}
+
+// ExternalServiceSpec defines the desired state of ExternalService. The name
+// of the resource is the name of the service in Consul.
+type ExternalServiceSpec struct {
+	// TerminatingGateway is the name of the TerminatingGateway resource in
+	// the namespace of this resource that the service is linked to.
+	TerminatingGateway string `json:"terminatingGateway"`
+	// Address is the hostname or IP address of the service.
+	Address string `json:"address"`
+	// Port is the port of the service.
+	Port int32 `json:"port"`
+	// Protocol is the protocol of the service: tcp, http, http2 or grpc. If
+	// it is set, or Destination is true, a ServiceDefaults resource with the
+	// same name is created for the service.
+	Protocol string `json:"protocol,omitempty"`
+	// Destination configures the service as a ServiceDefaults destination
+	// that is dialed by its address through transparent proxies instead of
+	// registering it in the Consul catalog.
+	Destination bool `json:"destination,omitempty"`
+	// CAFile is the optional path to a CA certificate on the gateway to use
+	// for TLS connections to the service.
+	CAFile string `json:"caFile,omitempty"`
+	// CertFile is the optional path to a client certificate on the gateway to
+	// use for TLS connections to the service.
+	CertFile string `json:"certFile,omitempty"`
+	// KeyFile is the optional path to the private key of CertFile.
+	KeyFile string `json:"keyFile,omitempty"`
+	// SNI is the optional name to use during the TLS handshake with the
+	// service.
+	SNI string `json:"sni,omitempty"`
+}
+
+// ExternalServiceStatus defines the observed state of ExternalService.
+type ExternalServiceStatus struct {
+	Status `json:",inline"`
+	// TerminatingGateway is the TerminatingGateway resource the service is
+	// currently linked to.
+	TerminatingGateway string `json:"terminatingGateway,omitempty"`
+}
+
+func (in *ExternalService) KubeKind() string {
+	return ExternalServiceKubeKind
+}
+
+func (in *ExternalService) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// ConsulName returns the name of the service in Consul.
+func (in *ExternalService) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+// LinkedService returns the entry of the service in the terminating gateway
+// for the given Consul namespace.
+func (in *ExternalService) LinkedService(consulNS string) LinkedService {
+	return LinkedService{
+		Namespace: consulNS,
+		Name:      in.ConsulName(),
+		CAFile:    in.Spec.CAFile,
+		CertFile:  in.Spec.CertFile,
+		KeyFile:   in.Spec.KeyFile,
+		SNI:       in.Spec.SNI,
+	}
+}
+
+func (in *ExternalService) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *ExternalService) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *ExternalService) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+func (in *ExternalService) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if in.Spec.TerminatingGateway == "" {
+		errs = append(errs, field.Required(path.Child("terminatingGateway"), "terminatingGateway must be set"))
+	}
+	if in.Spec.Address == "" {
+		errs = append(errs, field.Required(path.Child("address"), "address must be set"))
+	}
+	if in.Spec.Port < 1 || in.Spec.Port > 65535 {
+		errs = append(errs, field.Invalid(path.Child("port"), in.Spec.Port, "port must be between 1 and 65535"))
+	}
+	if in.Spec.Protocol != "" && !sliceContains(externalServiceProtocols, in.Spec.Protocol) {
+		errs = append(errs, field.Invalid(path.Child("protocol"), in.Spec.Protocol, notInSliceMessage(externalServiceProtocols)))
+	}
+	if (in.Spec.CertFile == "") != (in.Spec.KeyFile == "") {
+		errs = append(errs, field.Invalid(path.Child("certFile"), in.Spec.CertFile,
+			fmt.Sprintf("certFile and keyFile must both be set or both be empty, keyFile is %q", in.Spec.KeyFile)))
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ExternalServiceKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
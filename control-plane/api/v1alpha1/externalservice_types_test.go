@@ -0,0 +1,121 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExternalService_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec            ExternalServiceSpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: ExternalServiceSpec{
+				TerminatingGateway: "terminating-gateway",
+				Address:            "api.example.com",
+				Port:               443,
+				Protocol:           "http",
+				CAFile:             "/etc/ssl/ca.pem",
+				CertFile:           "/etc/ssl/cert.pem",
+				KeyFile:            "/etc/ssl/key.pem",
+				SNI:                "api.example.com",
+			},
+		},
+		"valid destination": {
+			spec: ExternalServiceSpec{
+				TerminatingGateway: "terminating-gateway",
+				Address:            "10.0.0.1",
+				Port:               5432,
+				Destination:        true,
+			},
+		},
+		"missing fields": {
+			spec: ExternalServiceSpec{},
+			expectedErrMsgs: []string{
+				`spec.terminatingGateway: Required value: terminatingGateway must be set`,
+				`spec.address: Required value: address must be set`,
+				`spec.port: Invalid value: 0: port must be between 1 and 65535`,
+			},
+		},
+		"invalid protocol and port": {
+			spec: ExternalServiceSpec{
+				TerminatingGateway: "terminating-gateway",
+				Address:            "api.example.com",
+				Port:               65536,
+				Protocol:           "udp",
+			},
+			expectedErrMsgs: []string{
+				`spec.port: Invalid value: 65536: port must be between 1 and 65535`,
+				`spec.protocol: Invalid value: "udp": must be one of "tcp", "http", "http2", "grpc"`,
+			},
+		},
+		"cert without key": {
+			spec: ExternalServiceSpec{
+				TerminatingGateway: "terminating-gateway",
+				Address:            "api.example.com",
+				Port:               443,
+				CertFile:           "/etc/ssl/cert.pem",
+			},
+			expectedErrMsgs: []string{
+				`spec.certFile: Invalid value: "/etc/ssl/cert.pem": certFile and keyFile must both be set or both be empty, keyFile is ""`,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			svc := &ExternalService{
+				ObjectMeta: metav1.ObjectMeta{Name: "api"},
+				Spec:       c.spec,
+			}
+			err := svc.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExternalService_LinkedService(t *testing.T) {
+	svc := &ExternalService{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: ExternalServiceSpec{
+			CAFile:   "/etc/ssl/ca.pem",
+			CertFile: "/etc/ssl/cert.pem",
+			KeyFile:  "/etc/ssl/key.pem",
+			SNI:      "api.example.com",
+		},
+	}
+	require.Equal(t, LinkedService{
+		Namespace: "ns",
+		Name:      "api",
+		CAFile:    "/etc/ssl/ca.pem",
+		CertFile:  "/etc/ssl/cert.pem",
+		KeyFile:   "/etc/ssl/key.pem",
+		SNI:       "api.example.com",
+	}, svc.LinkedService("ns"))
+}
+
+func TestExternalService_SetSyncedCondition(t *testing.T) {
+	svc := &ExternalService{}
+	svc.SetSyncedCondition(corev1.ConditionTrue, "reason", "message")
+
+	require.Equal(t, corev1.ConditionTrue, svc.Status.Conditions[0].Status)
+	require.Equal(t, "reason", svc.Status.Conditions[0].Reason)
+	require.Equal(t, "message", svc.Status.Conditions[0].Message)
+	require.Equal(t, corev1.ConditionTrue, svc.SyncedConditionStatus())
+}
+
+func TestExternalService_KubeKind(t *testing.T) {
+	require.Equal(t, "externalservice", (&ExternalService{}).KubeKind())
+}
@@ -0,0 +1,167 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// This file contains the cross-reference checks shared by the API gateway
+// webhooks. References are resolved against the resources in this cluster,
+// so references to other admin partitions are not checked. Service references
+// are only checked as part of cross-resource validation.
+
+// serviceReference is a reference to a Consul service from a route.
+type serviceReference struct {
+	Name      string
+	Namespace string
+	Partition string
+}
+
+// validateParentReferences checks that each parent is an APIGateway resource
+// with a listener of the given protocol. If a parent sets sectionName, the
+// listener with that name must exist and have the given protocol.
+func validateParentReferences(ctx context.Context, c client.Client, consulMeta common.ConsulMeta, kubeNS string, parents []ResourceReference, protocol string, path *field.Path) (field.ErrorList, error) {
+	var gateways APIGatewayList
+	if err := c.List(ctx, &gateways); err != nil {
+		return nil, err
+	}
+
+	var errs field.ErrorList
+	for i, parent := range parents {
+		if isOtherPartition(consulMeta, parent.Partition) {
+			continue
+		}
+		parentPath := path.Index(i)
+		namespace := referencedNamespace(consulMeta, kubeNS, parent.Namespace)
+
+		var gateway *APIGateway
+		for j, gw := range gateways.Items {
+			if gw.ConsulName() == parent.Name && consulNamespace(consulMeta, gw.Namespace) == namespace {
+				gateway = &gateways.Items[j]
+				break
+			}
+		}
+		if gateway == nil {
+			errs = append(errs, field.NotFound(parentPath.Child("name"), parent.Name))
+			continue
+		}
+
+		if parent.SectionName != "" {
+			var listener *APIGatewayListener
+			for j, l := range gateway.Spec.Listeners {
+				if l.Name == parent.SectionName {
+					listener = &gateway.Spec.Listeners[j]
+					break
+				}
+			}
+			if listener == nil {
+				errs = append(errs, field.NotFound(parentPath.Child("sectionName"), parent.SectionName))
+			} else if listener.Protocol != protocol {
+				errs = append(errs, field.Invalid(parentPath.Child("sectionName"), parent.SectionName,
+					fmt.Sprintf("listener has protocol %q but must be %q", listener.Protocol, protocol)))
+			}
+			continue
+		}
+
+		found := false
+		for _, l := range gateway.Spec.Listeners {
+			if l.Protocol == protocol {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, field.Invalid(parentPath.Child("name"), parent.Name,
+				fmt.Sprintf("gateway has no listeners with protocol %q", protocol)))
+		}
+	}
+	return errs, nil
+}
+
+// validateServiceReferences checks that there is a Kubernetes service for each
+// referenced service in the Consul namespace that only kubeNS is mirrored to.
+// Other references aren't checked since the service may be in another
+// Kubernetes namespace or only be registered in Consul.
+func validateServiceReferences(ctx context.Context, c client.Client, consulMeta common.ConsulMeta, kubeNS string, services []serviceReference, paths []*field.Path) (field.ErrorList, error) {
+	if !consulMeta.NamespacesEnabled || !consulMeta.Mirroring {
+		return nil, nil
+	}
+
+	var errs field.ErrorList
+	for i, svc := range services {
+		if isOtherPartition(consulMeta, svc.Partition) {
+			continue
+		}
+		if referencedNamespace(consulMeta, kubeNS, svc.Namespace) != consulNamespace(consulMeta, kubeNS) {
+			continue
+		}
+		var kubeSvc corev1.Service
+		err := c.Get(ctx, types.NamespacedName{Name: svc.Name, Namespace: kubeNS}, &kubeSvc)
+		if k8serrors.IsNotFound(err) {
+			errs = append(errs, field.NotFound(paths[i].Child("name"), svc.Name))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return errs, nil
+}
+
+// validateCertificateReferences checks that each certificate is an
+// InlineCertificate resource.
+func validateCertificateReferences(ctx context.Context, c client.Client, consulMeta common.ConsulMeta, kubeNS string, certificates []ResourceReference, paths []*field.Path) (field.ErrorList, error) {
+	if len(certificates) == 0 {
+		return nil, nil
+	}
+	var inlineCerts InlineCertificateList
+	if err := c.List(ctx, &inlineCerts); err != nil {
+		return nil, err
+	}
+
+	var errs field.ErrorList
+	for i, cert := range certificates {
+		if isOtherPartition(consulMeta, cert.Partition) {
+			continue
+		}
+		namespace := referencedNamespace(consulMeta, kubeNS, cert.Namespace)
+		found := false
+		for _, inlineCert := range inlineCerts.Items {
+			if inlineCert.ConsulName() == cert.Name && consulNamespace(consulMeta, inlineCert.Namespace) == namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, field.NotFound(paths[i].Child("name"), cert.Name))
+		}
+	}
+	return errs, nil
+}
+
+// consulNamespace returns the Consul namespace that resources in kubeNS are
+// synced to.
+func consulNamespace(consulMeta common.ConsulMeta, kubeNS string) string {
+	return namespaces.ConsulNamespace(kubeNS, consulMeta.NamespacesEnabled, consulMeta.DestinationNamespace, consulMeta.Mirroring, consulMeta.Prefix)
+}
+
+// referencedNamespace returns the Consul namespace of a reference with
+// namespace refNS made from a resource in kubeNS.
+func referencedNamespace(consulMeta common.ConsulMeta, kubeNS, refNS string) string {
+	if refNS != "" && consulMeta.NamespacesEnabled {
+		return refNS
+	}
+	return consulNamespace(consulMeta, kubeNS)
+}
+
+// isOtherPartition returns true if partition is a different admin partition
+// than the one this cluster syncs to.
+func isOtherPartition(consulMeta common.ConsulMeta, partition string) bool {
+	return consulMeta.PartitionsEnabled && partition != "" && partition != consulMeta.Partition
+}
@@ -0,0 +1,467 @@
+package v1alpha1
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	httpRouteKubeKind = "httproute"
+)
+
+func init() {
+	SchemeBuilder.Register(&HTTPRoute{}, &HTTPRouteList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// HTTPRoute is the Schema for the httproutes API
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="http-route"
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HTTPRouteSpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HTTPRouteList contains a list of HTTPRoute.
+type HTTPRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HTTPRoute `json:"items"`
+}
+
+// HTTPRouteSpec defines the desired state of HTTPRoute.
+type HTTPRouteSpec struct {
+	// Parents is the list of APIGateway resources the route binds to. The
+	// sectionName of a parent is the name of a listener on the gateway.
+	Parents []ResourceReference `json:"parents,omitempty"`
+	// Rules is the list of routing rules used to build the gateway's routing table.
+	Rules []HTTPRouteRule `json:"rules,omitempty"`
+	// Hostnames is the list of host names the route responds to.
+	Hostnames []string `json:"hostnames,omitempty"`
+}
+
+// HTTPRouteRule routes requests that match any of Matches to Services.
+type HTTPRouteRule struct {
+	// Filters modify requests matching this rule before they are routed.
+	Filters HTTPFilters `json:"filters,omitempty"`
+	// Matches is the list of criteria a request is matched against. If empty,
+	// all requests match.
+	Matches []HTTPMatch `json:"matches,omitempty"`
+	// Services is the list of services requests are routed to.
+	Services []HTTPService `json:"services,omitempty"`
+}
+
+// HTTPMatch is the criteria used to match a request.
+type HTTPMatch struct {
+	// Headers matches on request headers.
+	Headers []HTTPHeaderMatch `json:"headers,omitempty"`
+	// Method matches on the request's HTTP method, e.g. "GET".
+	Method string `json:"method,omitempty"`
+	// Path matches on the request's path.
+	Path HTTPPathMatch `json:"path,omitempty"`
+	// Query matches on the request's query parameters.
+	Query []HTTPQueryMatch `json:"query,omitempty"`
+}
+
+// HTTPHeaderMatch matches a request header.
+type HTTPHeaderMatch struct {
+	// Match is the type of match. One of "exact", "prefix", "present",
+	// "regex" or "suffix".
+	Match string `json:"match,omitempty"`
+	// Name is the name of the header.
+	Name string `json:"name,omitempty"`
+	// Value is the value matched against.
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPPathMatch matches the request path.
+type HTTPPathMatch struct {
+	// Match is the type of match. One of "exact", "prefix" or "regex".
+	Match string `json:"match,omitempty"`
+	// Value is the path matched against.
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPQueryMatch matches a request query parameter.
+type HTTPQueryMatch struct {
+	// Match is the type of match. One of "exact", "present" or "regex".
+	Match string `json:"match,omitempty"`
+	// Name is the name of the query parameter.
+	Name string `json:"name,omitempty"`
+	// Value is the value matched against.
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPFilters modify a request before it is routed.
+type HTTPFilters struct {
+	// Headers modifies the request headers.
+	Headers []HTTPHeaderFilter `json:"headers,omitempty"`
+	// URLRewrite rewrites the request URL.
+	URLRewrite *URLRewrite `json:"urlRewrite,omitempty"`
+}
+
+// HTTPHeaderFilter modifies request headers.
+type HTTPHeaderFilter struct {
+	// Add is a set of name -> value pairs appended to the request headers.
+	Add map[string]string `json:"add,omitempty"`
+	// Remove is the set of header names removed from the request.
+	Remove []string `json:"remove,omitempty"`
+	// Set is a set of name -> value pairs added to the request, overwriting
+	// existing headers of the same name.
+	Set map[string]string `json:"set,omitempty"`
+}
+
+// URLRewrite rewrites the request URL.
+type URLRewrite struct {
+	// Path replaces the matched path prefix of the request.
+	Path string `json:"path,omitempty"`
+}
+
+// HTTPService is a service requests are routed to.
+type HTTPService struct {
+	// Name is the name of the service.
+	Name string `json:"name,omitempty"`
+	// Weight is the proportion of traffic sent to this service relative to the
+	// other services of the rule.
+	Weight int `json:"weight,omitempty"`
+	// Filters modify requests before they are sent to this service.
+	Filters HTTPFilters `json:"filters,omitempty"`
+	// Namespace is the namespace the service is registered in.
+	// Namespacing is a Consul Enterprise feature.
+	Namespace string `json:"namespace,omitempty"`
+	// Partition is the admin partition the service is registered in.
+	// Partitioning is a Consul Enterprise feature.
+	Partition string `json:"partition,omitempty"`
+}
+
+func (in *HTTPRoute) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *HTTPRoute) AddFinalizer(name string) {
+	in.ObjectMeta.Finalizers = append(in.Finalizers(), name)
+}
+
+func (in *HTTPRoute) RemoveFinalizer(name string) {
+	var newFinalizers []string
+	for _, oldF := range in.Finalizers() {
+		if oldF != name {
+			newFinalizers = append(newFinalizers, oldF)
+		}
+	}
+	in.ObjectMeta.Finalizers = newFinalizers
+}
+
+func (in *HTTPRoute) Finalizers() []string {
+	return in.ObjectMeta.Finalizers
+}
+
+func (in *HTTPRoute) ConsulKind() string {
+	return capi.HTTPRoute
+}
+
+func (in *HTTPRoute) ConsulGlobalResource() bool {
+	return false
+}
+
+func (in *HTTPRoute) ConsulMirroringNS() string {
+	return in.Namespace
+}
+
+func (in *HTTPRoute) KubeKind() string {
+	return httpRouteKubeKind
+}
+
+func (in *HTTPRoute) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *HTTPRoute) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *HTTPRoute) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *HTTPRoute) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *HTTPRoute) SyncedCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+func (in *HTTPRoute) SyncedConditionStatus() corev1.ConditionStatus {
+	condition := in.Status.GetCondition(ConditionSynced)
+	if condition == nil {
+		return corev1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+func (in *HTTPRoute) ToConsul(datacenter string) capi.ConfigEntry {
+	var parents []capi.ResourceReference
+	for _, p := range in.Spec.Parents {
+		parents = append(parents, p.toConsul(capi.APIGateway))
+	}
+	var rules []capi.HTTPRouteRule
+	for _, r := range in.Spec.Rules {
+		rules = append(rules, r.toConsul())
+	}
+	return &capi.HTTPRouteConfigEntry{
+		Kind:      in.ConsulKind(),
+		Name:      in.ConsulName(),
+		Parents:   parents,
+		Rules:     rules,
+		Hostnames: in.Spec.Hostnames,
+		Meta:      meta(datacenter),
+	}
+}
+
+func (in *HTTPRoute) MatchesConsul(candidate capi.ConfigEntry) bool {
+	configEntry, ok := candidate.(*capi.HTTPRouteConfigEntry)
+	if !ok {
+		return false
+	}
+	// No datacenter is passed to ToConsul as we ignore the Meta field when checking for equality.
+	// Status is computed by Consul so it is ignored too.
+	return cmp.Equal(in.ToConsul(""), configEntry, cmpopts.IgnoreFields(capi.HTTPRouteConfigEntry{}, "Partition", "Namespace", "Meta", "Status", "ModifyIndex", "CreateIndex"), cmpopts.IgnoreUnexported(), cmpopts.EquateEmpty())
+}
+
+func (in *HTTPRoute) Validate(consulMeta common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if len(in.Spec.Parents) == 0 {
+		errs = append(errs, field.Required(path.Child("parents"), "at least one parent must be set"))
+	}
+	for i, p := range in.Spec.Parents {
+		errs = append(errs, p.validate(path.Child("parents").Index(i), capi.APIGateway, consulMeta)...)
+	}
+	for i, r := range in.Spec.Rules {
+		errs = append(errs, r.validate(path.Child("rules").Index(i), consulMeta)...)
+	}
+	for i, h := range in.Spec.Hostnames {
+		if h == "" {
+			errs = append(errs, field.Required(path.Child("hostnames").Index(i), "hostname cannot be empty"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: httpRouteKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+// DefaultNamespaceFields sets the namespace field on spec.parents and the rule services to their default values if namespaces are enabled.
+func (in *HTTPRoute) DefaultNamespaceFields(consulMeta common.ConsulMeta) {
+	// If namespaces are enabled we want to set the namespace fields to their
+	// defaults. If namespaces are not enabled (i.e. OSS) we don't set the
+	// namespace fields because this would cause errors
+	// making API calls (because namespace fields can't be set in OSS).
+	if consulMeta.NamespacesEnabled {
+		// Default to the current namespace (i.e. the namespace of the config entry).
+		namespace := namespaces.ConsulNamespace(in.Namespace, consulMeta.NamespacesEnabled, consulMeta.DestinationNamespace, consulMeta.Mirroring, consulMeta.Prefix)
+		for i, parent := range in.Spec.Parents {
+			if parent.Namespace == "" {
+				in.Spec.Parents[i].Namespace = namespace
+			}
+		}
+		for i, rule := range in.Spec.Rules {
+			for j, service := range rule.Services {
+				if service.Namespace == "" {
+					in.Spec.Rules[i].Services[j].Namespace = namespace
+				}
+			}
+		}
+	}
+}
+
+func (in HTTPRouteRule) toConsul() capi.HTTPRouteRule {
+	var matches []capi.HTTPMatch
+	for _, m := range in.Matches {
+		matches = append(matches, m.toConsul())
+	}
+	var services []capi.HTTPService
+	for _, s := range in.Services {
+		services = append(services, capi.HTTPService{
+			Name:      s.Name,
+			Weight:    s.Weight,
+			Filters:   s.Filters.toConsul(),
+			Namespace: s.Namespace,
+			Partition: s.Partition,
+		})
+	}
+	return capi.HTTPRouteRule{
+		Filters:  in.Filters.toConsul(),
+		Matches:  matches,
+		Services: services,
+	}
+}
+
+func (in HTTPRouteRule) validate(path *field.Path, consulMeta common.ConsulMeta) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, in.Filters.validate(path.Child("filters"))...)
+	for i, m := range in.Matches {
+		errs = append(errs, m.validate(path.Child("matches").Index(i))...)
+	}
+	if len(in.Services) == 0 {
+		errs = append(errs, field.Required(path.Child("services"), "at least one service must be set"))
+	}
+	for i, s := range in.Services {
+		svcPath := path.Child("services").Index(i)
+		if s.Name == "" {
+			errs = append(errs, field.Required(svcPath.Child("name"), "name must be set"))
+		}
+		if s.Weight < 0 {
+			errs = append(errs, field.Invalid(svcPath.Child("weight"), s.Weight, "must be greater than or equal to 0"))
+		}
+		if s.Namespace != "" && !consulMeta.NamespacesEnabled {
+			errs = append(errs, field.Invalid(svcPath.Child("namespace"), s.Namespace,
+				"Consul Enterprise namespaces must be enabled to set namespace"))
+		}
+		if s.Partition != "" && !consulMeta.PartitionsEnabled {
+			errs = append(errs, field.Invalid(svcPath.Child("partition"), s.Partition,
+				"Consul Enterprise admin-partitions must be enabled to set partition"))
+		}
+		errs = append(errs, s.Filters.validate(svcPath.Child("filters"))...)
+	}
+	return errs
+}
+
+func (in HTTPMatch) toConsul() capi.HTTPMatch {
+	var headers []capi.HTTPHeaderMatch
+	for _, h := range in.Headers {
+		headers = append(headers, capi.HTTPHeaderMatch{
+			Match: capi.HTTPHeaderMatchType(h.Match),
+			Name:  h.Name,
+			Value: h.Value,
+		})
+	}
+	var query []capi.HTTPQueryMatch
+	for _, q := range in.Query {
+		query = append(query, capi.HTTPQueryMatch{
+			Match: capi.HTTPQueryMatchType(q.Match),
+			Name:  q.Name,
+			Value: q.Value,
+		})
+	}
+	return capi.HTTPMatch{
+		Headers: headers,
+		Method:  capi.HTTPMatchMethod(in.Method),
+		Path: capi.HTTPPathMatch{
+			Match: capi.HTTPPathMatchType(in.Path.Match),
+			Value: in.Path.Value,
+		},
+		Query: query,
+	}
+}
+
+func (in HTTPMatch) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	methods := []string{"", "CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT", "TRACE"}
+	if !sliceContains(methods, in.Method) {
+		errs = append(errs, field.Invalid(path.Child("method"), in.Method, notInSliceMessage(methods)))
+	}
+
+	headerMatches := []string{"exact", "prefix", "present", "regex", "suffix"}
+	for i, h := range in.Headers {
+		headerPath := path.Child("headers").Index(i)
+		if !sliceContains(headerMatches, h.Match) {
+			errs = append(errs, field.Invalid(headerPath.Child("match"), h.Match, notInSliceMessage(headerMatches)))
+		}
+		if h.Name == "" {
+			errs = append(errs, field.Required(headerPath.Child("name"), "name must be set"))
+		}
+		errs = append(errs, validateRegex(headerPath.Child("value"), h.Match, h.Value)...)
+	}
+
+	pathMatches := []string{"", "exact", "prefix", "regex"}
+	if !sliceContains(pathMatches, in.Path.Match) {
+		errs = append(errs, field.Invalid(path.Child("path").Child("match"), in.Path.Match, notInSliceMessage(pathMatches)))
+	}
+	if (in.Path.Match == "exact" || in.Path.Match == "prefix") && !strings.HasPrefix(in.Path.Value, "/") {
+		errs = append(errs, field.Invalid(path.Child("path").Child("value"), in.Path.Value,
+			"must begin with a '/' if match is \"exact\" or \"prefix\""))
+	}
+	errs = append(errs, validateRegex(path.Child("path").Child("value"), in.Path.Match, in.Path.Value)...)
+
+	queryMatches := []string{"exact", "present", "regex"}
+	for i, q := range in.Query {
+		queryPath := path.Child("query").Index(i)
+		if !sliceContains(queryMatches, q.Match) {
+			errs = append(errs, field.Invalid(queryPath.Child("match"), q.Match, notInSliceMessage(queryMatches)))
+		}
+		if q.Name == "" {
+			errs = append(errs, field.Required(queryPath.Child("name"), "name must be set"))
+		}
+		errs = append(errs, validateRegex(queryPath.Child("value"), q.Match, q.Value)...)
+	}
+	return errs
+}
+
+func (in HTTPFilters) toConsul() capi.HTTPFilters {
+	var headers []capi.HTTPHeaderFilter
+	for _, h := range in.Headers {
+		headers = append(headers, capi.HTTPHeaderFilter{
+			Add:    h.Add,
+			Remove: h.Remove,
+			Set:    h.Set,
+		})
+	}
+	var rewrite *capi.URLRewrite
+	if in.URLRewrite != nil {
+		rewrite = &capi.URLRewrite{Path: in.URLRewrite.Path}
+	}
+	return capi.HTTPFilters{
+		Headers:    headers,
+		URLRewrite: rewrite,
+	}
+}
+
+func (in HTTPFilters) validate(path *field.Path) field.ErrorList {
+	if in.URLRewrite != nil && !strings.HasPrefix(in.URLRewrite.Path, "/") {
+		return field.ErrorList{field.Invalid(path.Child("urlRewrite").Child("path"), in.URLRewrite.Path, "must begin with a '/'")}
+	}
+	return nil
+}
+
+// validateRegex returns an error if match is "regex" and value isn't a valid
+// regular expression.
+func validateRegex(path *field.Path, match, value string) field.ErrorList {
+	if match != "regex" {
+		return nil
+	}
+	if _, err := regexp.Compile(value); err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	return nil
+}
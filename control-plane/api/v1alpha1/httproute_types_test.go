@@ -0,0 +1,258 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHTTPRoute_ToConsul(t *testing.T) {
+	route := &HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		Spec: HTTPRouteSpec{
+			Parents: []ResourceReference{{Name: "gateway", SectionName: "http"}},
+			Rules: []HTTPRouteRule{
+				{
+					Filters: HTTPFilters{
+						Headers:    []HTTPHeaderFilter{{Add: map[string]string{"x-foo": "bar"}, Remove: []string{"x-baz"}}},
+						URLRewrite: &URLRewrite{Path: "/v2"},
+					},
+					Matches: []HTTPMatch{
+						{
+							Headers: []HTTPHeaderMatch{{Match: "exact", Name: "x-version", Value: "2"}},
+							Method:  "GET",
+							Path:    HTTPPathMatch{Match: "prefix", Value: "/api"},
+							Query:   []HTTPQueryMatch{{Match: "present", Name: "debug"}},
+						},
+					},
+					Services: []HTTPService{
+						{
+							Name:    "web",
+							Weight:  90,
+							Filters: HTTPFilters{Headers: []HTTPHeaderFilter{{Set: map[string]string{"x-web": "true"}}}},
+						},
+						{Name: "web-canary", Weight: 10},
+					},
+				},
+			},
+			Hostnames: []string{"example.com"},
+		},
+	}
+
+	require.Equal(t, &capi.HTTPRouteConfigEntry{
+		Kind:    capi.HTTPRoute,
+		Name:    "name",
+		Parents: []capi.ResourceReference{{Kind: capi.APIGateway, Name: "gateway", SectionName: "http"}},
+		Rules: []capi.HTTPRouteRule{
+			{
+				Filters: capi.HTTPFilters{
+					Headers:    []capi.HTTPHeaderFilter{{Add: map[string]string{"x-foo": "bar"}, Remove: []string{"x-baz"}}},
+					URLRewrite: &capi.URLRewrite{Path: "/v2"},
+				},
+				Matches: []capi.HTTPMatch{
+					{
+						Headers: []capi.HTTPHeaderMatch{{Match: capi.HTTPHeaderMatchExact, Name: "x-version", Value: "2"}},
+						Method:  capi.HTTPMatchMethodGet,
+						Path:    capi.HTTPPathMatch{Match: capi.HTTPPathMatchPrefix, Value: "/api"},
+						Query:   []capi.HTTPQueryMatch{{Match: capi.HTTPQueryMatchPresent, Name: "debug"}},
+					},
+				},
+				Services: []capi.HTTPService{
+					{
+						Name:    "web",
+						Weight:  90,
+						Filters: capi.HTTPFilters{Headers: []capi.HTTPHeaderFilter{{Set: map[string]string{"x-web": "true"}}}},
+					},
+					{Name: "web-canary", Weight: 10},
+				},
+			},
+		},
+		Hostnames: []string{"example.com"},
+		Meta: map[string]string{
+			common.SourceKey:     common.SourceValue,
+			common.DatacenterKey: "datacenter",
+		},
+	}, route.ToConsul("datacenter"))
+}
+
+func TestHTTPRoute_MatchesConsul(t *testing.T) {
+	route := &HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		Spec: HTTPRouteSpec{
+			Parents: []ResourceReference{{Name: "gateway"}},
+			Rules:   []HTTPRouteRule{{Services: []HTTPService{{Name: "web"}}}},
+		},
+	}
+
+	cases := map[string]struct {
+		theirs  capi.ConfigEntry
+		matches bool
+	}{
+		"matches ignoring status and meta": {
+			theirs: &capi.HTTPRouteConfigEntry{
+				Kind:    capi.HTTPRoute,
+				Name:    "name",
+				Parents: []capi.ResourceReference{{Kind: capi.APIGateway, Name: "gateway"}},
+				Rules: []capi.HTTPRouteRule{{
+					Matches:  []capi.HTTPMatch{},
+					Services: []capi.HTTPService{{Name: "web"}},
+				}},
+				Meta:        map[string]string{common.SourceKey: common.SourceValue},
+				CreateIndex: 1,
+				ModifyIndex: 2,
+				Status: capi.ConfigEntryStatus{
+					Conditions: []capi.Condition{{Type: "Bound", Status: "True"}},
+				},
+			},
+			matches: true,
+		},
+		"different rules do not match": {
+			theirs: &capi.HTTPRouteConfigEntry{
+				Kind:    capi.HTTPRoute,
+				Name:    "name",
+				Parents: []capi.ResourceReference{{Kind: capi.APIGateway, Name: "gateway"}},
+				Rules:   []capi.HTTPRouteRule{{Services: []capi.HTTPService{{Name: "other"}}}},
+			},
+			matches: false,
+		},
+		"different types do not match": {
+			theirs: &capi.TCPRouteConfigEntry{
+				Kind: capi.TCPRoute,
+				Name: "name",
+			},
+			matches: false,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.matches, route.MatchesConsul(c.theirs))
+		})
+	}
+}
+
+func TestHTTPRoute_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec            HTTPRouteSpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: HTTPRouteSpec{
+				Parents: []ResourceReference{{Kind: capi.APIGateway, Name: "gateway"}},
+				Rules: []HTTPRouteRule{{
+					Matches: []HTTPMatch{
+						{Path: HTTPPathMatch{Match: "regex", Value: "^/v[0-9]+/.*"}},
+						{Headers: []HTTPHeaderMatch{{Match: "present", Name: "x-debug"}}, Method: "POST"},
+					},
+					Services: []HTTPService{{Name: "web"}},
+				}},
+			},
+		},
+		"missing parents and services": {
+			spec: HTTPRouteSpec{
+				Rules: []HTTPRouteRule{{}},
+			},
+			expectedErrMsgs: []string{
+				`spec.parents: Required value: at least one parent must be set`,
+				`spec.rules[0].services: Required value: at least one service must be set`,
+			},
+		},
+		"invalid parent": {
+			spec: HTTPRouteSpec{
+				Parents: []ResourceReference{{Kind: capi.TCPRoute, Partition: "part"}},
+				Rules:   []HTTPRouteRule{{Services: []HTTPService{{Name: "web"}}}},
+			},
+			expectedErrMsgs: []string{
+				`spec.parents[0].kind: Invalid value: "tcp-route": must be one of "api-gateway"`,
+				`spec.parents[0].name: Required value: name must be set`,
+				`spec.parents[0].partition: Invalid value: "part": Consul Enterprise admin-partitions must be enabled to set partition`,
+			},
+		},
+		"invalid matches": {
+			spec: HTTPRouteSpec{
+				Parents: []ResourceReference{{Name: "gateway"}},
+				Rules: []HTTPRouteRule{{
+					Matches: []HTTPMatch{
+						{
+							Headers: []HTTPHeaderMatch{{Match: "contains"}, {Match: "regex", Name: "x-foo", Value: "(foo"}},
+							Method:  "get",
+							Path:    HTTPPathMatch{Match: "exact", Value: "api"},
+							Query:   []HTTPQueryMatch{{Match: "prefix"}},
+						},
+						{Path: HTTPPathMatch{Match: "suffix"}},
+					},
+					Services: []HTTPService{{Name: "web"}},
+				}},
+			},
+			expectedErrMsgs: []string{
+				`spec.rules[0].matches[0].method: Invalid value: "get": must be one of "", "CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT", "TRACE"`,
+				`spec.rules[0].matches[0].headers[0].match: Invalid value: "contains": must be one of "exact", "prefix", "present", "regex", "suffix"`,
+				`spec.rules[0].matches[0].headers[0].name: Required value: name must be set`,
+				`spec.rules[0].matches[0].headers[1].value: Invalid value: "(foo": error parsing regexp: missing closing ): ` + "`(foo`",
+				`spec.rules[0].matches[0].path.value: Invalid value: "api": must begin with a '/' if match is "exact" or "prefix"`,
+				`spec.rules[0].matches[0].query[0].match: Invalid value: "prefix": must be one of "exact", "present", "regex"`,
+				`spec.rules[0].matches[0].query[0].name: Required value: name must be set`,
+				`spec.rules[0].matches[1].path.match: Invalid value: "suffix": must be one of "", "exact", "prefix", "regex"`,
+			},
+		},
+		"invalid services and filters": {
+			spec: HTTPRouteSpec{
+				Parents: []ResourceReference{{Name: "gateway"}},
+				Rules: []HTTPRouteRule{{
+					Filters: HTTPFilters{URLRewrite: &URLRewrite{Path: "v2"}},
+					Services: []HTTPService{
+						{Weight: -1, Namespace: "ns"},
+						{Name: "web", Filters: HTTPFilters{URLRewrite: &URLRewrite{}}},
+					},
+				}},
+				Hostnames: []string{""},
+			},
+			expectedErrMsgs: []string{
+				`spec.rules[0].filters.urlRewrite.path: Invalid value: "v2": must begin with a '/'`,
+				`spec.rules[0].services[0].name: Required value: name must be set`,
+				`spec.rules[0].services[0].weight: Invalid value: -1: must be greater than or equal to 0`,
+				`spec.rules[0].services[0].namespace: Invalid value: "ns": Consul Enterprise namespaces must be enabled to set namespace`,
+				`spec.rules[0].services[1].filters.urlRewrite.path: Invalid value: "": must begin with a '/'`,
+				`spec.hostnames[0]: Required value: hostname cannot be empty`,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			route := &HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+				Spec:       c.spec,
+			}
+			err := route.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPRoute_DefaultNamespaceFields(t *testing.T) {
+	route := &HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "k8s"},
+		Spec: HTTPRouteSpec{
+			Parents: []ResourceReference{{Name: "gateway"}},
+			Rules:   []HTTPRouteRule{{Services: []HTTPService{{Name: "web"}, {Name: "other", Namespace: "other"}}}},
+		},
+	}
+
+	route.DefaultNamespaceFields(common.ConsulMeta{})
+	require.Empty(t, route.Spec.Parents[0].Namespace)
+	require.Empty(t, route.Spec.Rules[0].Services[0].Namespace)
+
+	route.DefaultNamespaceFields(common.ConsulMeta{NamespacesEnabled: true, DestinationNamespace: "dest"})
+	require.Equal(t, "dest", route.Spec.Parents[0].Namespace)
+	require.Equal(t, "dest", route.Spec.Rules[0].Services[0].Namespace)
+	require.Equal(t, "other", route.Spec.Rules[0].Services[1].Namespace)
+}
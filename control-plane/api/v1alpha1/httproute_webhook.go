@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type HTTPRouteWebhook struct {
+	Logger logr.Logger
+
+	// ConsulMeta contains metadata specific to the Consul installation.
+	ConsulMeta common.ConsulMeta
+
+	decoder *admission.Decoder
+	client.Client
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-httproute,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=httproutes,versions=v1alpha1,name=mutate-httproute.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *HTTPRouteWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var resource HTTPRoute
+	err := v.decoder.Decode(req, &resource)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	resp := common.ValidateConfigEntry(ctx, req, v.Logger, v, &resource, v.ConsulMeta)
+	if !resp.Allowed {
+		return resp
+	}
+
+	// Check that the gateways referenced by the route exist.
+	path := field.NewPath("spec")
+	errs, err := validateParentReferences(ctx, v.Client, v.ConsulMeta, req.Namespace, resource.Spec.Parents, "http", path.Child("parents"))
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: httpRouteKubeKind},
+			resource.KubernetesName(), errs))
+	}
+
+	// Services may be registered in Consul only, so missing services are
+	// reported through cross-resource validation.
+	if !crossResourceValidationEnabled(v.ConsulMeta) {
+		return resp
+	}
+	var services []serviceReference
+	var paths []*field.Path
+	for i, rule := range resource.Spec.Rules {
+		for j, svc := range rule.Services {
+			services = append(services, serviceReference{Name: svc.Name, Namespace: svc.Namespace, Partition: svc.Partition})
+			paths = append(paths, path.Child("rules").Index(i).Child("services").Index(j))
+		}
+	}
+	serviceErrs, err := validateServiceReferences(ctx, v.Client, v.ConsulMeta, req.Namespace, services, paths)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return crossResourceResponse(v.ConsulMeta, resp, httpRouteKubeKind, resource.KubernetesName(), serviceErrs)
+}
+
+func (v *HTTPRouteWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
+	var resourceList HTTPRouteList
+	if err := v.Client.List(ctx, &resourceList); err != nil {
+		return nil, err
+	}
+	var entries []common.ConfigEntryResource
+	for _, item := range resourceList.Items {
+		entries = append(entries, common.ConfigEntryResource(&item))
+	}
+	return entries, nil
+}
+
+func (v *HTTPRouteWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
@@ -0,0 +1,197 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestHandle_HTTPRoute_References(t *testing.T) {
+	gateway := &APIGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+		Spec: APIGatewaySpec{
+			Listeners: []APIGatewayListener{
+				{Name: "http", Port: 80, Protocol: "http"},
+				{Name: "tcp", Port: 9000, Protocol: "tcp"},
+			},
+		},
+	}
+	tcpGateway := &APIGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "tcp-gateway", Namespace: "default"},
+		Spec: APIGatewaySpec{
+			Listeners: []APIGatewayListener{{Name: "tcp", Port: 9000, Protocol: "tcp"}},
+		},
+	}
+	webService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	otherNSService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "other"}}
+
+	cases := map[string]struct {
+		existingResources []runtime.Object
+		parents           []ResourceReference
+		services          []HTTPService
+		consulMeta        common.ConsulMeta
+		expAllow          bool
+		expErrMessage     string
+		expWarnings       []string
+	}{
+		"gateway and service exist": {
+			existingResources: []runtime.Object{gateway, webService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "web"}},
+			expAllow:          true,
+		},
+		"gateway listener exists": {
+			existingResources: []runtime.Object{gateway, webService},
+			parents:           []ResourceReference{{Name: "gateway", SectionName: "http"}},
+			services:          []HTTPService{{Name: "web"}},
+			expAllow:          true,
+		},
+		"service in other kube namespace without namespaces": {
+			existingResources: []runtime.Object{gateway, otherNSService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "api"}},
+			expAllow:          true,
+		},
+		"service in mirrored namespace": {
+			existingResources: []runtime.Object{gateway, otherNSService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "api", Namespace: "other"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true},
+			expAllow:          true,
+		},
+		"service in another partition is not checked": {
+			existingResources: []runtime.Object{gateway},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "remote", Partition: "other"}},
+			consulMeta:        common.ConsulMeta{PartitionsEnabled: true, Partition: "default"},
+			expAllow:          true,
+		},
+		"gateway does not exist": {
+			existingResources: []runtime.Object{webService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "web"}},
+			expAllow:          false,
+			expErrMessage:     `httproute.consul.hashicorp.com "route" is invalid: spec.parents[0].name: Not found: "gateway"`,
+		},
+		"gateway listener does not exist": {
+			existingResources: []runtime.Object{gateway, webService},
+			parents:           []ResourceReference{{Name: "gateway", SectionName: "https"}},
+			services:          []HTTPService{{Name: "web"}},
+			expAllow:          false,
+			expErrMessage:     `httproute.consul.hashicorp.com "route" is invalid: spec.parents[0].sectionName: Not found: "https"`,
+		},
+		"gateway listener has wrong protocol": {
+			existingResources: []runtime.Object{gateway, webService},
+			parents:           []ResourceReference{{Name: "gateway", SectionName: "tcp"}},
+			services:          []HTTPService{{Name: "web"}},
+			expAllow:          false,
+			expErrMessage:     `httproute.consul.hashicorp.com "route" is invalid: spec.parents[0].sectionName: Invalid value: "tcp": listener has protocol "tcp" but must be "http"`,
+		},
+		"gateway has no http listeners": {
+			existingResources: []runtime.Object{tcpGateway, webService},
+			parents:           []ResourceReference{{Name: "tcp-gateway"}},
+			services:          []HTTPService{{Name: "web"}},
+			expAllow:          false,
+			expErrMessage:     `httproute.consul.hashicorp.com "route" is invalid: spec.parents[0].name: Invalid value: "tcp-gateway": gateway has no listeners with protocol "http"`,
+		},
+		"gateway in a different mirrored namespace": {
+			existingResources: []runtime.Object{gateway, otherNSService},
+			parents:           []ResourceReference{{Name: "gateway", Namespace: "other"}},
+			services:          []HTTPService{{Name: "api", Namespace: "other"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true},
+			expAllow:          false,
+			expErrMessage:     `httproute.consul.hashicorp.com "route" is invalid: spec.parents[0].name: Not found: "gateway"`,
+		},
+		"service does not exist without cross-resource validation": {
+			existingResources: []runtime.Object{gateway, webService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "web"}, {Name: "missing"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true},
+			expAllow:          true,
+		},
+		"service does not exist without mirroring": {
+			existingResources: []runtime.Object{gateway, webService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "web"}, {Name: "missing"}},
+			consulMeta:        common.ConsulMeta{CrossResourceValidation: common.CrossResourceValidationDeny},
+			expAllow:          true,
+		},
+		"service does not exist with cross-resource validation warnings": {
+			existingResources: []runtime.Object{gateway, webService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "web"}, {Name: "missing"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true, CrossResourceValidation: common.CrossResourceValidationWarn},
+			expAllow:          true,
+			expWarnings:       []string{`spec.rules[0].services[1].name: Not found: "missing"`},
+		},
+		"service does not exist": {
+			existingResources: []runtime.Object{gateway, webService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "web"}, {Name: "missing"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true, CrossResourceValidation: common.CrossResourceValidationDeny},
+			expAllow:          false,
+			expErrMessage:     `httproute.consul.hashicorp.com "route" is invalid: spec.rules[0].services[1].name: Not found: "missing"`,
+		},
+		"service in a different mirrored namespace": {
+			existingResources: []runtime.Object{gateway, otherNSService},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			services:          []HTTPService{{Name: "api"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true, CrossResourceValidation: common.CrossResourceValidationDeny},
+			expAllow:          false,
+			expErrMessage:     `httproute.consul.hashicorp.com "route" is invalid: spec.rules[0].services[0].name: Not found: "api"`,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			route := &HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+				Spec: HTTPRouteSpec{
+					Parents: c.parents,
+					Rules:   []HTTPRouteRule{{Services: c.services}},
+				},
+			}
+			marshalledRequestObject, err := json.Marshal(route)
+			require.NoError(t, err)
+			s := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(s))
+			s.AddKnownTypes(GroupVersion, &HTTPRoute{}, &HTTPRouteList{}, &APIGateway{}, &APIGatewayList{})
+			client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
+			decoder, err := admission.NewDecoder(s)
+			require.NoError(t, err)
+
+			validator := &HTTPRouteWebhook{
+				Client:     client,
+				Logger:     logrtest.TestLogger{T: t},
+				decoder:    decoder,
+				ConsulMeta: c.consulMeta,
+			}
+			response := validator.Handle(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      route.KubernetesName(),
+					Namespace: route.Namespace,
+					Operation: admissionv1.Create,
+					Object: runtime.RawExtension{
+						Raw: marshalledRequestObject,
+					},
+				},
+			})
+
+			require.Equal(t, c.expAllow, response.Allowed)
+			if c.expErrMessage != "" {
+				require.Equal(t, c.expErrMessage, response.AdmissionResponse.Result.Message)
+			}
+			require.Equal(t, c.expWarnings, response.Warnings)
+		})
+	}
+}
@@ -13,12 +13,17 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const (
 	ingressGatewayKubeKind = "ingressgateway"
 	wildcardServiceName    = "*"
+
+	// IngressGatewayReadyPort is the port the pods of ingress gateways
+	// deployed by the controller report their readiness on.
+	IngressGatewayReadyPort = 21000
 )
 
 func init() {
@@ -57,6 +62,64 @@ type IngressGatewaySpec struct {
 	// Listeners declares what ports the ingress gateway should listen on, and
 	// what services to associated to those ports.
 	Listeners []IngressListener `json:"listeners,omitempty"`
+	// Deployment configures the pods that run the gateway. If set, and the
+	// controller is run with -enable-ingress-gateway-deployments, the
+	// controller manages a Deployment, Service, PodDisruptionBudget and
+	// HorizontalPodAutoscaler for the gateway. The Service exposes the ports
+	// of the listeners. Requires Kubernetes 1.21 or later. This field is not
+	// synced to Consul.
+	Deployment *IngressGatewayDeployment `json:"deployment,omitempty"`
+}
+
+// IngressGatewayDeployment configures the Kubernetes resources that run an
+// ingress gateway.
+type IngressGatewayDeployment struct {
+	// Replicas is the number of gateway pods. It is ignored if autoscaling is
+	// set. Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// ServiceType is the type of the Service that exposes the listeners of the
+	// gateway. One of ClusterIP, NodePort or LoadBalancer. Defaults to
+	// LoadBalancer.
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+	// ServiceAnnotations are added to the Service, e.g. to configure the load
+	// balancer of a cloud provider.
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+	// Resources are the resource requests and limits of the gateway container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector restricts the nodes the gateway pods are scheduled on.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations of the gateway pods.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// PodDisruptionBudget creates a PodDisruptionBudget for the gateway pods
+	// if set.
+	PodDisruptionBudget *IngressGatewayPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+	// Autoscaling creates a HorizontalPodAutoscaler that scales the gateway
+	// pods on their CPU usage if set.
+	Autoscaling *IngressGatewayAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// IngressGatewayPodDisruptionBudget configures the PodDisruptionBudget of an
+// ingress gateway. Exactly one of MinAvailable and MaxUnavailable must be set.
+type IngressGatewayPodDisruptionBudget struct {
+	// MinAvailable is the number or percentage of gateway pods that must
+	// remain available during a voluntary disruption.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percentage of gateway pods that can be
+	// unavailable during a voluntary disruption.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// IngressGatewayAutoscaling configures the HorizontalPodAutoscaler of an
+// ingress gateway.
+type IngressGatewayAutoscaling struct {
+	// MinReplicas is the lower limit of gateway pods. Defaults to 1.
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit of gateway pods.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilizationPercentage is the average CPU usage of the gateway
+	// pods, as a percentage of their requested CPU, the autoscaler aims for.
+	// Defaults to 80.
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
 }
 
 type GatewayTLSConfig struct {
@@ -193,15 +256,7 @@ func (in *IngressGateway) KubernetesName() string {
 }
 
 func (in *IngressGateway) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *IngressGateway) SetLastSyncedTime(time *metav1.Time) {
@@ -257,6 +312,16 @@ func (in *IngressGateway) Validate(consulMeta common.ConsulMeta) error {
 		errs = append(errs, v.validate(path.Child("listeners").Index(i), consulMeta)...)
 	}
 
+	if in.Spec.Deployment != nil {
+		errs = append(errs, in.Spec.Deployment.validate(path.Child("deployment"))...)
+		for i, v := range in.Spec.Listeners {
+			if v.Port == IngressGatewayReadyPort {
+				errs = append(errs, field.Invalid(path.Child("listeners").Index(i).Child("port"), v.Port,
+					fmt.Sprintf("port %d is used by the readiness probe of the gateway pods", IngressGatewayReadyPort)))
+			}
+		}
+	}
+
 	if len(errs) > 0 {
 		return apierrors.NewInvalid(
 			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ingressGatewayKubeKind},
@@ -409,3 +474,40 @@ func (in IngressListener) validate(path *field.Path, consulMeta common.ConsulMet
 	}
 	return errs
 }
+
+func (in *IngressGatewayDeployment) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if in.Replicas != nil && *in.Replicas < 0 {
+		errs = append(errs, field.Invalid(path.Child("replicas"), *in.Replicas, "replicas must not be negative"))
+	}
+	serviceTypes := []string{string(corev1.ServiceTypeClusterIP), string(corev1.ServiceTypeNodePort), string(corev1.ServiceTypeLoadBalancer), ""}
+	if !sliceContains(serviceTypes, string(in.ServiceType)) {
+		errs = append(errs, field.Invalid(path.Child("serviceType"), in.ServiceType, notInSliceMessage(serviceTypes)))
+	}
+	if pdb := in.PodDisruptionBudget; pdb != nil {
+		if pdb.MinAvailable == nil && pdb.MaxUnavailable == nil {
+			errs = append(errs, field.Required(path.Child("podDisruptionBudget"), "one of minAvailable or maxUnavailable must be set"))
+		} else if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+			errs = append(errs, field.Forbidden(path.Child("podDisruptionBudget", "maxUnavailable"), "maxUnavailable cannot be set with minAvailable"))
+		}
+	}
+	if as := in.Autoscaling; as != nil {
+		asPath := path.Child("autoscaling")
+		minReplicas := int32(1)
+		if as.MinReplicas != nil {
+			minReplicas = *as.MinReplicas
+			if minReplicas < 1 {
+				errs = append(errs, field.Invalid(asPath.Child("minReplicas"), minReplicas, "minReplicas must be at least 1"))
+			}
+		}
+		if as.MaxReplicas < minReplicas {
+			errs = append(errs, field.Invalid(asPath.Child("maxReplicas"), as.MaxReplicas,
+				fmt.Sprintf("maxReplicas must be at least minReplicas (%d)", minReplicas)))
+		}
+		if t := as.TargetCPUUtilizationPercentage; t != nil && *t < 1 {
+			errs = append(errs, field.Invalid(asPath.Child("targetCPUUtilizationPercentage"), *t,
+				"targetCPUUtilizationPercentage must be at least 1"))
+		}
+	}
+	return errs
+}
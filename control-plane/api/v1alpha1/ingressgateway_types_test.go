@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 )
 
 func TestIngressGateway_MatchesConsul(t *testing.T) {
@@ -809,6 +811,68 @@ func TestIngressGateway_Validate(t *testing.T) {
 				`spec.listeners[0].services[0].name: Invalid value: "*": if name is "*", protocol must be "http" but was "invalid"`,
 			},
 		},
+		"deployment valid": {
+			input: &IngressGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: IngressGatewaySpec{
+					Listeners: []IngressListener{{Port: 8080, Protocol: "tcp"}},
+					Deployment: &IngressGatewayDeployment{
+						Replicas:            pointer.Int32(2),
+						ServiceType:         corev1.ServiceTypeNodePort,
+						PodDisruptionBudget: &IngressGatewayPodDisruptionBudget{MinAvailable: intstrPtr(intstr.FromString("50%"))},
+						Autoscaling:         &IngressGatewayAutoscaling{MinReplicas: pointer.Int32(2), MaxReplicas: 4},
+					},
+				},
+			},
+		},
+		"deployment invalid": {
+			input: &IngressGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: IngressGatewaySpec{
+					Listeners: []IngressListener{{Port: 21000, Protocol: "tcp"}},
+					Deployment: &IngressGatewayDeployment{
+						Replicas:            pointer.Int32(-1),
+						ServiceType:         "ExternalName",
+						PodDisruptionBudget: &IngressGatewayPodDisruptionBudget{},
+						Autoscaling: &IngressGatewayAutoscaling{
+							MinReplicas:                    pointer.Int32(3),
+							MaxReplicas:                    2,
+							TargetCPUUtilizationPercentage: pointer.Int32(0),
+						},
+					},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.deployment.replicas: Invalid value: -1: replicas must not be negative`,
+				`spec.deployment.serviceType: Invalid value: "ExternalName": must be one of "ClusterIP", "NodePort", "LoadBalancer", ""`,
+				`spec.deployment.podDisruptionBudget: Required value: one of minAvailable or maxUnavailable must be set`,
+				`spec.deployment.autoscaling.maxReplicas: Invalid value: 2: maxReplicas must be at least minReplicas (3)`,
+				`spec.deployment.autoscaling.targetCPUUtilizationPercentage: Invalid value: 0: targetCPUUtilizationPercentage must be at least 1`,
+				`spec.listeners[0].port: Invalid value: 21000: port 21000 is used by the readiness probe of the gateway pods`,
+			},
+		},
+		"deployment pod disruption budget with both fields": {
+			input: &IngressGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: IngressGatewaySpec{
+					Deployment: &IngressGatewayDeployment{
+						PodDisruptionBudget: &IngressGatewayPodDisruptionBudget{
+							MinAvailable:   intstrPtr(intstr.FromInt(1)),
+							MaxUnavailable: intstrPtr(intstr.FromInt(1)),
+						},
+					},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.deployment.podDisruptionBudget.maxUnavailable: Forbidden: maxUnavailable cannot be set with minAvailable`,
+			},
+		},
 	}
 
 	for name, testCase := range cases {
@@ -1029,3 +1093,7 @@ func TestIngressGateway_ObjectMeta(t *testing.T) {
 	}
 	require.Equal(t, meta, ingressGateway.GetObjectMeta())
 }
+
+func intstrPtr(v intstr.IntOrString) *intstr.IntOrString {
+	return &v
+}
@@ -37,7 +37,17 @@ func (v *IngressGatewayWebhook) Handle(ctx context.Context, req admission.Reques
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
-	return common.ValidateConfigEntry(ctx, req, v.Logger, v, &resource, v.ConsulMeta)
+	resp := common.ValidateConfigEntry(ctx, req, v.Logger, v, &resource, v.ConsulMeta)
+	if !resp.Allowed || !crossResourceValidationEnabled(v.ConsulMeta) {
+		return resp
+	}
+
+	// Check that the listener protocols match the protocols of their services.
+	errs, err := validateIngressListenerProtocols(ctx, v.Client, v.ConsulMeta, &resource)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return crossResourceResponse(v.ConsulMeta, resp, ingressGatewayKubeKind, resource.KubernetesName(), errs)
 }
 
 func (v *IngressGatewayWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
@@ -0,0 +1,176 @@
+package v1alpha1
+
+import (
+	"crypto/tls"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	inlineCertificateKubeKind = "inlinecertificate"
+)
+
+func init() {
+	SchemeBuilder.Register(&InlineCertificate{}, &InlineCertificateList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// InlineCertificate is the Schema for the inlinecertificates API
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="inline-certificate"
+type InlineCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InlineCertificateSpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InlineCertificateList contains a list of InlineCertificate.
+type InlineCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InlineCertificate `json:"items"`
+}
+
+// InlineCertificateSpec defines the desired state of InlineCertificate.
+type InlineCertificateSpec struct {
+	// Certificate is the PEM encoded public certificate of an x509 key pair.
+	Certificate string `json:"certificate,omitempty"`
+	// PrivateKey is the PEM encoded private key of an x509 key pair. Anyone
+	// able to read this resource can read the private key.
+	PrivateKey string `json:"privateKey,omitempty"`
+}
+
+func (in *InlineCertificate) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *InlineCertificate) AddFinalizer(name string) {
+	in.ObjectMeta.Finalizers = append(in.Finalizers(), name)
+}
+
+func (in *InlineCertificate) RemoveFinalizer(name string) {
+	var newFinalizers []string
+	for _, oldF := range in.Finalizers() {
+		if oldF != name {
+			newFinalizers = append(newFinalizers, oldF)
+		}
+	}
+	in.ObjectMeta.Finalizers = newFinalizers
+}
+
+func (in *InlineCertificate) Finalizers() []string {
+	return in.ObjectMeta.Finalizers
+}
+
+func (in *InlineCertificate) ConsulKind() string {
+	return capi.InlineCertificate
+}
+
+func (in *InlineCertificate) ConsulGlobalResource() bool {
+	return false
+}
+
+func (in *InlineCertificate) ConsulMirroringNS() string {
+	return in.Namespace
+}
+
+func (in *InlineCertificate) KubeKind() string {
+	return inlineCertificateKubeKind
+}
+
+func (in *InlineCertificate) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *InlineCertificate) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *InlineCertificate) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *InlineCertificate) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *InlineCertificate) SyncedCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+func (in *InlineCertificate) SyncedConditionStatus() corev1.ConditionStatus {
+	condition := in.Status.GetCondition(ConditionSynced)
+	if condition == nil {
+		return corev1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+func (in *InlineCertificate) ToConsul(datacenter string) capi.ConfigEntry {
+	return &capi.InlineCertificateConfigEntry{
+		Kind:        in.ConsulKind(),
+		Name:        in.ConsulName(),
+		Certificate: in.Spec.Certificate,
+		PrivateKey:  in.Spec.PrivateKey,
+		Meta:        meta(datacenter),
+	}
+}
+
+func (in *InlineCertificate) MatchesConsul(candidate capi.ConfigEntry) bool {
+	configEntry, ok := candidate.(*capi.InlineCertificateConfigEntry)
+	if !ok {
+		return false
+	}
+	// No datacenter is passed to ToConsul as we ignore the Meta field when checking for equality.
+	return cmp.Equal(in.ToConsul(""), configEntry, cmpopts.IgnoreFields(capi.InlineCertificateConfigEntry{}, "Partition", "Namespace", "Meta", "ModifyIndex", "CreateIndex"), cmpopts.IgnoreUnexported(), cmpopts.EquateEmpty())
+}
+
+func (in *InlineCertificate) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if in.Spec.Certificate == "" {
+		errs = append(errs, field.Required(path.Child("certificate"), "certificate must be set"))
+	}
+	if in.Spec.PrivateKey == "" {
+		errs = append(errs, field.Required(path.Child("privateKey"), "privateKey must be set"))
+	}
+	if len(errs) == 0 {
+		if _, err := tls.X509KeyPair([]byte(in.Spec.Certificate), []byte(in.Spec.PrivateKey)); err != nil {
+			// The error is returned without the values so the private key
+			// isn't echoed back to the user.
+			errs = append(errs, field.Invalid(path, "", "certificate and privateKey must be a valid x509 key pair: "+err.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: inlineCertificateKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+// DefaultNamespaceFields has no behaviour here as inline-certificate config entries have no namespace specific fields.
+func (in *InlineCertificate) DefaultNamespaceFields(_ common.ConsulMeta) {
+}
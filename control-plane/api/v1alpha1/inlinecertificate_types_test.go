@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/cert"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInlineCertificate_ToConsul(t *testing.T) {
+	inlineCert := &InlineCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		Spec: InlineCertificateSpec{
+			Certificate: "cert",
+			PrivateKey:  "key",
+		},
+	}
+	require.Equal(t, &capi.InlineCertificateConfigEntry{
+		Kind:        capi.InlineCertificate,
+		Name:        "name",
+		Certificate: "cert",
+		PrivateKey:  "key",
+		Meta: map[string]string{
+			common.SourceKey:     common.SourceValue,
+			common.DatacenterKey: "datacenter",
+		},
+	}, inlineCert.ToConsul("datacenter"))
+}
+
+func TestInlineCertificate_MatchesConsul(t *testing.T) {
+	inlineCert := &InlineCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		Spec: InlineCertificateSpec{
+			Certificate: "cert",
+			PrivateKey:  "key",
+		},
+	}
+	require.True(t, inlineCert.MatchesConsul(&capi.InlineCertificateConfigEntry{
+		Kind:        capi.InlineCertificate,
+		Name:        "name",
+		Certificate: "cert",
+		PrivateKey:  "key",
+		Namespace:   "ns",
+		ModifyIndex: 1,
+	}))
+	require.False(t, inlineCert.MatchesConsul(&capi.InlineCertificateConfigEntry{
+		Kind:        capi.InlineCertificate,
+		Name:        "name",
+		Certificate: "other",
+		PrivateKey:  "key",
+	}))
+	require.False(t, inlineCert.MatchesConsul(&capi.APIGatewayConfigEntry{Kind: capi.APIGateway, Name: "name"}))
+}
+
+func TestInlineCertificate_Validate(t *testing.T) {
+	_, keyPEM, certPEM, _, err := cert.GenerateCA("test")
+	require.NoError(t, err)
+	_, otherKeyPEM, _, _, err := cert.GenerateCA("other")
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		spec            InlineCertificateSpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: InlineCertificateSpec{Certificate: certPEM, PrivateKey: keyPEM},
+		},
+		"missing fields": {
+			spec: InlineCertificateSpec{},
+			expectedErrMsgs: []string{
+				`spec.certificate: Required value: certificate must be set`,
+				`spec.privateKey: Required value: privateKey must be set`,
+			},
+		},
+		"invalid certificate": {
+			spec: InlineCertificateSpec{Certificate: "cert", PrivateKey: keyPEM},
+			expectedErrMsgs: []string{
+				`spec: Invalid value: "": certificate and privateKey must be a valid x509 key pair: tls: failed to find any PEM data in certificate input`,
+			},
+		},
+		"mismatched key": {
+			spec: InlineCertificateSpec{Certificate: certPEM, PrivateKey: otherKeyPEM},
+			expectedErrMsgs: []string{
+				`spec: Invalid value: "": certificate and privateKey must be a valid x509 key pair: tls: private key does not match public key`,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			inlineCert := &InlineCertificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+				Spec:       c.spec,
+			}
+			err := inlineCert.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+				require.NotContains(t, err.Error(), keyPEM)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
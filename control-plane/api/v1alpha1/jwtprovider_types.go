@@ -0,0 +1,458 @@
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func init() {
+	SchemeBuilder.Register(&JWTProvider{}, &JWTProviderList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// JWTProvider is the Schema for the jwtproviders API
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="jwt-provider"
+type JWTProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JWTProviderSpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// JWTProviderList contains a list of JWTProvider.
+type JWTProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JWTProvider `json:"items"`
+}
+
+// JWTProviderSpec defines the desired state of JWTProvider.
+type JWTProviderSpec struct {
+	// JSONWebKeySet defines a JSON Web Key Set, its location on disk, or the
+	// means with which to fetch a key set from a remote server.
+	JSONWebKeySet *JSONWebKeySet `json:"jsonWebKeySet,omitempty"`
+	// Issuer is the entity that must have issued the JWT.
+	// This value must match the "iss" claim of the token.
+	Issuer string `json:"issuer,omitempty"`
+	// Audiences is the set of audiences the JWT is allowed to access.
+	// If specified, all JWTs verified with this provider must address
+	// at least one of these to be considered valid.
+	Audiences []string `json:"audiences,omitempty"`
+	// Locations where the JWT will be present in requests.
+	// Envoy will check all of these locations to extract a JWT.
+	// If no locations are specified Envoy will default to:
+	// 1. Authorization header with Bearer schema:
+	//    "Authorization: Bearer <token>"
+	// 2. access_token query parameter.
+	Locations []*JWTLocation `json:"locations,omitempty"`
+	// Forwarding defines rules for forwarding verified JWTs to the backend.
+	Forwarding *JWTForwardingConfig `json:"forwarding,omitempty"`
+	// ClockSkewSeconds specifies the maximum allowable time difference
+	// from clock skew when validating the "exp" (Expiration) and "nbf"
+	// (Not Before) claims. Defaults to 30 seconds.
+	ClockSkewSeconds int `json:"clockSkewSeconds,omitempty"`
+	// CacheConfig defines configuration for caching the validation
+	// result for previously seen JWTs.
+	CacheConfig *JWTCacheConfig `json:"cacheConfig,omitempty"`
+}
+
+// JSONWebKeySet defines a key set, its location on disk, or the
+// means with which to fetch a key set from a remote server.
+// Exactly one of Local or Remote must be specified.
+type JSONWebKeySet struct {
+	// Local specifies a local source for the key set.
+	Local *LocalJWKS `json:"local,omitempty"`
+	// Remote specifies how to fetch a key set from a remote server.
+	Remote *RemoteJWKS `json:"remote,omitempty"`
+}
+
+// LocalJWKS specifies a location for a local JWKS.
+// Exactly one of JWKS and Filename must be specified.
+type LocalJWKS struct {
+	// JWKS contains a base64 encoded JWKS.
+	JWKS string `json:"jwks,omitempty"`
+	// Filename configures a location on disk where the JWKS can be
+	// found. If specified, the file must be present on the disk of ALL
+	// proxies with intentions referencing this provider.
+	Filename string `json:"filename,omitempty"`
+}
+
+// RemoteJWKS specifies how to fetch a JWKS from a remote server.
+type RemoteJWKS struct {
+	// URI is the URI of the server to query for the JWKS.
+	URI string `json:"uri,omitempty"`
+	// RequestTimeoutMs is the number of milliseconds to
+	// time out when making a request for the JWKS.
+	RequestTimeoutMs int `json:"requestTimeoutMs,omitempty"`
+	// CacheDuration is the duration after which cached keys
+	// should be expired. Defaults to 5 minutes.
+	CacheDuration metav1.Duration `json:"cacheDuration,omitempty"`
+	// FetchAsynchronously indicates that the JWKS should be fetched
+	// when a client request arrives. Client requests will be paused
+	// until the JWKS is fetched. If false, the proxy listener will wait
+	// for the JWKS to be fetched before being activated.
+	FetchAsynchronously bool `json:"fetchAsynchronously,omitempty"`
+	// RetryPolicy defines a retry policy for fetching JWKS.
+	// There is no retry by default.
+	RetryPolicy *JWKSRetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type JWKSRetryPolicy struct {
+	// NumRetries is the number of times to retry fetching the JWKS.
+	NumRetries int `json:"numRetries,omitempty"`
+	// RetryPolicyBackOff is the backoff policy between retries.
+	// Defaults to Envoy's backoff policy.
+	RetryPolicyBackOff *RetryPolicyBackOff `json:"retryPolicyBackOff,omitempty"`
+}
+
+type RetryPolicyBackOff struct {
+	// BaseInterval to be used for the next back off computation.
+	// Defaults to 1s.
+	BaseInterval metav1.Duration `json:"baseInterval,omitempty"`
+	// MaxInterval is the maximum interval between retries. It should be
+	// greater or equal to BaseInterval. Defaults to 10 times BaseInterval.
+	MaxInterval metav1.Duration `json:"maxInterval,omitempty"`
+}
+
+// JWTLocation is a location where the JWT could be present in requests.
+// Exactly one of Header, QueryParam or Cookie must be specified.
+type JWTLocation struct {
+	// Header defines how to extract a JWT from an HTTP request header.
+	Header *JWTLocationHeader `json:"header,omitempty"`
+	// QueryParam defines how to extract a JWT from an HTTP request query parameter.
+	QueryParam *JWTLocationQueryParam `json:"queryParam,omitempty"`
+	// Cookie defines how to extract a JWT from an HTTP request cookie.
+	Cookie *JWTLocationCookie `json:"cookie,omitempty"`
+}
+
+type JWTLocationHeader struct {
+	// Name is the name of the header containing the token.
+	Name string `json:"name,omitempty"`
+	// ValuePrefix is an optional prefix that precedes the token in the
+	// header value, e.g. "Bearer ".
+	ValuePrefix string `json:"valuePrefix,omitempty"`
+	// Forward defines whether the header with the JWT should be
+	// forwarded after the token has been verified.
+	Forward bool `json:"forward,omitempty"`
+}
+
+type JWTLocationQueryParam struct {
+	// Name is the name of the query param containing the token.
+	Name string `json:"name,omitempty"`
+}
+
+type JWTLocationCookie struct {
+	// Name is the name of the cookie containing the token.
+	Name string `json:"name,omitempty"`
+}
+
+type JWTForwardingConfig struct {
+	// HeaderName is a header name to use when forwarding a verified
+	// JWT to the backend. The header value will be base64-URL-encoded.
+	HeaderName string `json:"headerName,omitempty"`
+	// PadForwardPayloadHeader determines whether padding should be added
+	// to the base64 encoded token forwarded with HeaderName.
+	PadForwardPayloadHeader bool `json:"padForwardPayloadHeader,omitempty"`
+}
+
+type JWTCacheConfig struct {
+	// Size specifies the maximum number of JWT verification
+	// results to cache. Defaults to 0, meaning caching is disabled.
+	Size int `json:"size,omitempty"`
+}
+
+func (in *JWTProvider) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *JWTProvider) AddFinalizer(name string) {
+	in.ObjectMeta.Finalizers = append(in.Finalizers(), name)
+}
+
+func (in *JWTProvider) RemoveFinalizer(name string) {
+	var newFinalizers []string
+	for _, oldF := range in.Finalizers() {
+		if oldF != name {
+			newFinalizers = append(newFinalizers, oldF)
+		}
+	}
+	in.ObjectMeta.Finalizers = newFinalizers
+}
+
+func (in *JWTProvider) Finalizers() []string {
+	return in.ObjectMeta.Finalizers
+}
+
+func (in *JWTProvider) ConsulKind() string {
+	return capi.JWTProvider
+}
+
+func (in *JWTProvider) ConsulGlobalResource() bool {
+	return true
+}
+
+func (in *JWTProvider) ConsulMirroringNS() string {
+	return common.DefaultConsulNamespace
+}
+
+func (in *JWTProvider) KubeKind() string {
+	return common.JWTProvider
+}
+
+func (in *JWTProvider) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *JWTProvider) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *JWTProvider) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *JWTProvider) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *JWTProvider) SyncedCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+func (in *JWTProvider) SyncedConditionStatus() corev1.ConditionStatus {
+	condition := in.Status.GetCondition(ConditionSynced)
+	if condition == nil {
+		return corev1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+func (in *JWTProvider) ToConsul(datacenter string) capi.ConfigEntry {
+	var locations []*capi.JWTLocation
+	for _, location := range in.Spec.Locations {
+		locations = append(locations, location.toConsul())
+	}
+	return &capi.JWTProviderConfigEntry{
+		Kind:             in.ConsulKind(),
+		Name:             in.ConsulName(),
+		JSONWebKeySet:    in.Spec.JSONWebKeySet.toConsul(),
+		Issuer:           in.Spec.Issuer,
+		Audiences:        in.Spec.Audiences,
+		Locations:        locations,
+		Forwarding:       in.Spec.Forwarding.toConsul(),
+		ClockSkewSeconds: in.Spec.ClockSkewSeconds,
+		CacheConfig:      in.Spec.CacheConfig.toConsul(),
+		Meta:             meta(datacenter),
+	}
+}
+
+func (in *JWTProvider) MatchesConsul(candidate capi.ConfigEntry) bool {
+	configEntry, ok := candidate.(*capi.JWTProviderConfigEntry)
+	if !ok {
+		return false
+	}
+	// No datacenter is passed to ToConsul as we ignore the Meta field when checking for equality.
+	return cmp.Equal(in.ToConsul(""), configEntry, cmpopts.IgnoreFields(capi.JWTProviderConfigEntry{}, "Partition", "Namespace", "Meta", "ModifyIndex", "CreateIndex"), cmpopts.IgnoreUnexported(), cmpopts.EquateEmpty())
+}
+
+func (in *JWTProvider) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	errs = append(errs, in.Spec.JSONWebKeySet.validate(path.Child("jsonWebKeySet"))...)
+	for i, location := range in.Spec.Locations {
+		errs = append(errs, location.validate(path.Child("locations").Index(i))...)
+	}
+	if in.Spec.ClockSkewSeconds < 0 {
+		errs = append(errs, field.Invalid(path.Child("clockSkewSeconds"), in.Spec.ClockSkewSeconds, "must be non-negative"))
+	}
+	if in.Spec.CacheConfig != nil && in.Spec.CacheConfig.Size < 0 {
+		errs = append(errs, field.Invalid(path.Child("cacheConfig").Child("size"), in.Spec.CacheConfig.Size, "must be non-negative"))
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: common.JWTProvider},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+// DefaultNamespaceFields has no behaviour here as jwt-provider config entries have no namespace specific fields.
+func (in *JWTProvider) DefaultNamespaceFields(_ common.ConsulMeta) {
+}
+
+func (in *JSONWebKeySet) toConsul() *capi.JSONWebKeySet {
+	if in == nil {
+		return nil
+	}
+	keySet := &capi.JSONWebKeySet{}
+	if in.Local != nil {
+		keySet.Local = &capi.LocalJWKS{
+			JWKS:     in.Local.JWKS,
+			Filename: in.Local.Filename,
+		}
+	}
+	if in.Remote != nil {
+		keySet.Remote = &capi.RemoteJWKS{
+			URI:                 in.Remote.URI,
+			RequestTimeoutMs:    in.Remote.RequestTimeoutMs,
+			CacheDuration:       in.Remote.CacheDuration.Duration,
+			FetchAsynchronously: in.Remote.FetchAsynchronously,
+			RetryPolicy:         in.Remote.RetryPolicy.toConsul(),
+		}
+	}
+	return keySet
+}
+
+func (in *JWKSRetryPolicy) toConsul() *capi.JWKSRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	policy := &capi.JWKSRetryPolicy{NumRetries: in.NumRetries}
+	if in.RetryPolicyBackOff != nil {
+		policy.RetryPolicyBackOff = &capi.RetryPolicyBackOff{
+			BaseInterval: in.RetryPolicyBackOff.BaseInterval.Duration,
+			MaxInterval:  in.RetryPolicyBackOff.MaxInterval.Duration,
+		}
+	}
+	return policy
+}
+
+func (in *JWTLocation) toConsul() *capi.JWTLocation {
+	if in == nil {
+		return nil
+	}
+	location := &capi.JWTLocation{}
+	if in.Header != nil {
+		location.Header = &capi.JWTLocationHeader{
+			Name:        in.Header.Name,
+			ValuePrefix: in.Header.ValuePrefix,
+			Forward:     in.Header.Forward,
+		}
+	}
+	if in.QueryParam != nil {
+		location.QueryParam = &capi.JWTLocationQueryParam{Name: in.QueryParam.Name}
+	}
+	if in.Cookie != nil {
+		location.Cookie = &capi.JWTLocationCookie{Name: in.Cookie.Name}
+	}
+	return location
+}
+
+func (in *JWTForwardingConfig) toConsul() *capi.JWTForwardingConfig {
+	if in == nil {
+		return nil
+	}
+	return &capi.JWTForwardingConfig{
+		HeaderName:              in.HeaderName,
+		PadForwardPayloadHeader: in.PadForwardPayloadHeader,
+	}
+}
+
+func (in *JWTCacheConfig) toConsul() *capi.JWTCacheConfig {
+	if in == nil {
+		return nil
+	}
+	return &capi.JWTCacheConfig{Size: in.Size}
+}
+
+func (in *JSONWebKeySet) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if in == nil {
+		return append(errs, field.Required(path, "jsonWebKeySet must be set"))
+	}
+	if (in.Local == nil) == (in.Remote == nil) {
+		asJSON, _ := json.Marshal(in)
+		return append(errs, field.Invalid(path, string(asJSON), "exactly one of local or remote must be set"))
+	}
+
+	if in.Local != nil {
+		if (in.Local.JWKS == "") == (in.Local.Filename == "") {
+			asJSON, _ := json.Marshal(in.Local)
+			errs = append(errs, field.Invalid(path.Child("local"), string(asJSON), "exactly one of jwks or filename must be set"))
+		} else if in.Local.JWKS != "" {
+			if _, err := base64.StdEncoding.DecodeString(in.Local.JWKS); err != nil {
+				errs = append(errs, field.Invalid(path.Child("local").Child("jwks"), in.Local.JWKS, "must be base64 encoded"))
+			}
+		}
+	}
+
+	if in.Remote != nil {
+		remotePath := path.Child("remote")
+		if in.Remote.URI == "" {
+			errs = append(errs, field.Required(remotePath.Child("uri"), "uri must be set"))
+		} else if u, err := url.ParseRequestURI(in.Remote.URI); err != nil || u.Host == "" {
+			errs = append(errs, field.Invalid(remotePath.Child("uri"), in.Remote.URI, "must be a valid URL"))
+		}
+		if in.Remote.RequestTimeoutMs < 0 {
+			errs = append(errs, field.Invalid(remotePath.Child("requestTimeoutMs"), in.Remote.RequestTimeoutMs, "must be non-negative"))
+		}
+		if in.Remote.CacheDuration.Duration < 0 {
+			errs = append(errs, field.Invalid(remotePath.Child("cacheDuration"), in.Remote.CacheDuration.Duration.String(), "must be non-negative"))
+		}
+		if policy := in.Remote.RetryPolicy; policy != nil {
+			if policy.NumRetries < 0 {
+				errs = append(errs, field.Invalid(remotePath.Child("retryPolicy").Child("numRetries"), policy.NumRetries, "must be non-negative"))
+			}
+			if backOff := policy.RetryPolicyBackOff; backOff != nil && backOff.MaxInterval.Duration != 0 && backOff.MaxInterval.Duration < backOff.BaseInterval.Duration {
+				errs = append(errs, field.Invalid(remotePath.Child("retryPolicy").Child("retryPolicyBackOff").Child("maxInterval"), backOff.MaxInterval.Duration.String(), "must be greater than or equal to baseInterval"))
+			}
+		}
+	}
+	return errs
+}
+
+func (in *JWTLocation) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if in == nil {
+		return append(errs, field.Required(path, "location must not be empty"))
+	}
+	set := 0
+	if in.Header != nil {
+		set++
+	}
+	if in.QueryParam != nil {
+		set++
+	}
+	if in.Cookie != nil {
+		set++
+	}
+	if set != 1 {
+		asJSON, _ := json.Marshal(in)
+		return append(errs, field.Invalid(path, string(asJSON), "exactly one of header, queryParam or cookie must be set"))
+	}
+	if in.Header != nil && in.Header.Name == "" {
+		errs = append(errs, field.Required(path.Child("header").Child("name"), "name must be set"))
+	}
+	if in.QueryParam != nil && in.QueryParam.Name == "" {
+		errs = append(errs, field.Required(path.Child("queryParam").Child("name"), "name must be set"))
+	}
+	if in.Cookie != nil && in.Cookie.Name == "" {
+		errs = append(errs, field.Required(path.Child("cookie").Child("name"), "name must be set"))
+	}
+	return errs
+}
@@ -0,0 +1,218 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJWTProvider_ToConsul(t *testing.T) {
+	provider := &JWTProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "okta"},
+		Spec: JWTProviderSpec{
+			JSONWebKeySet: &JSONWebKeySet{
+				Remote: &RemoteJWKS{
+					URI:                 "https://example.okta.com/oauth2/default/v1/keys",
+					RequestTimeoutMs:    500,
+					CacheDuration:       metav1.Duration{Duration: 10 * time.Minute},
+					FetchAsynchronously: true,
+					RetryPolicy: &JWKSRetryPolicy{
+						NumRetries: 3,
+						RetryPolicyBackOff: &RetryPolicyBackOff{
+							BaseInterval: metav1.Duration{Duration: time.Second},
+							MaxInterval:  metav1.Duration{Duration: 5 * time.Second},
+						},
+					},
+				},
+			},
+			Issuer:    "okta",
+			Audiences: []string{"api"},
+			Locations: []*JWTLocation{
+				{Header: &JWTLocationHeader{Name: "Authorization", ValuePrefix: "Bearer ", Forward: true}},
+				{QueryParam: &JWTLocationQueryParam{Name: "token"}},
+				{Cookie: &JWTLocationCookie{Name: "session"}},
+			},
+			Forwarding:       &JWTForwardingConfig{HeaderName: "x-jwt", PadForwardPayloadHeader: true},
+			ClockSkewSeconds: 20,
+			CacheConfig:      &JWTCacheConfig{Size: 10},
+		},
+	}
+	require.Equal(t, &capi.JWTProviderConfigEntry{
+		Kind: capi.JWTProvider,
+		Name: "okta",
+		JSONWebKeySet: &capi.JSONWebKeySet{
+			Remote: &capi.RemoteJWKS{
+				URI:                 "https://example.okta.com/oauth2/default/v1/keys",
+				RequestTimeoutMs:    500,
+				CacheDuration:       10 * time.Minute,
+				FetchAsynchronously: true,
+				RetryPolicy: &capi.JWKSRetryPolicy{
+					NumRetries: 3,
+					RetryPolicyBackOff: &capi.RetryPolicyBackOff{
+						BaseInterval: time.Second,
+						MaxInterval:  5 * time.Second,
+					},
+				},
+			},
+		},
+		Issuer:    "okta",
+		Audiences: []string{"api"},
+		Locations: []*capi.JWTLocation{
+			{Header: &capi.JWTLocationHeader{Name: "Authorization", ValuePrefix: "Bearer ", Forward: true}},
+			{QueryParam: &capi.JWTLocationQueryParam{Name: "token"}},
+			{Cookie: &capi.JWTLocationCookie{Name: "session"}},
+		},
+		Forwarding:       &capi.JWTForwardingConfig{HeaderName: "x-jwt", PadForwardPayloadHeader: true},
+		ClockSkewSeconds: 20,
+		CacheConfig:      &capi.JWTCacheConfig{Size: 10},
+		Meta: map[string]string{
+			common.SourceKey:     common.SourceValue,
+			common.DatacenterKey: "datacenter",
+		},
+	}, provider.ToConsul("datacenter"))
+}
+
+func TestJWTProvider_MatchesConsul(t *testing.T) {
+	provider := &JWTProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "local"},
+		Spec: JWTProviderSpec{
+			JSONWebKeySet: &JSONWebKeySet{Local: &LocalJWKS{Filename: "/etc/jwks.json"}},
+			Issuer:        "issuer",
+		},
+	}
+	require.True(t, provider.MatchesConsul(&capi.JWTProviderConfigEntry{
+		Kind:          capi.JWTProvider,
+		Name:          "local",
+		JSONWebKeySet: &capi.JSONWebKeySet{Local: &capi.LocalJWKS{Filename: "/etc/jwks.json"}},
+		Issuer:        "issuer",
+		Namespace:     "default",
+		ModifyIndex:   1,
+	}))
+	require.False(t, provider.MatchesConsul(&capi.JWTProviderConfigEntry{
+		Kind:          capi.JWTProvider,
+		Name:          "local",
+		JSONWebKeySet: &capi.JSONWebKeySet{Local: &capi.LocalJWKS{Filename: "/etc/jwks.json"}},
+		Issuer:        "other",
+	}))
+	require.False(t, provider.MatchesConsul(&capi.InlineCertificateConfigEntry{Kind: capi.InlineCertificate, Name: "local"}))
+}
+
+func TestJWTProvider_ConsulNamespace(t *testing.T) {
+	provider := &JWTProvider{ObjectMeta: metav1.ObjectMeta{Name: "okta", Namespace: "apps"}}
+	require.True(t, provider.ConsulGlobalResource())
+	require.Equal(t, common.DefaultConsulNamespace, provider.ConsulMirroringNS())
+}
+
+func TestJWTProvider_Validate(t *testing.T) {
+	remote := &JSONWebKeySet{Remote: &RemoteJWKS{URI: "https://example.com/jwks"}}
+	cases := map[string]struct {
+		spec            JWTProviderSpec
+		expectedErrMsgs []string
+	}{
+		"valid remote": {
+			spec: JWTProviderSpec{JSONWebKeySet: remote},
+		},
+		"valid local jwks": {
+			spec: JWTProviderSpec{JSONWebKeySet: &JSONWebKeySet{Local: &LocalJWKS{JWKS: "eyJrZXlzIjogW119"}}},
+		},
+		"missing key set": {
+			spec: JWTProviderSpec{},
+			expectedErrMsgs: []string{
+				`spec.jsonWebKeySet: Required value: jsonWebKeySet must be set`,
+			},
+		},
+		"local and remote": {
+			spec: JWTProviderSpec{JSONWebKeySet: &JSONWebKeySet{
+				Local:  &LocalJWKS{Filename: "/etc/jwks.json"},
+				Remote: &RemoteJWKS{URI: "https://example.com/jwks"},
+			}},
+			expectedErrMsgs: []string{
+				`spec.jsonWebKeySet: Invalid value: "{\"local\":{\"filename\":\"/etc/jwks.json\"},\"remote\":{\"uri\":\"https://example.com/jwks\",\"cacheDuration\":\"0s\"}}": exactly one of local or remote must be set`,
+			},
+		},
+		"local jwks and filename": {
+			spec: JWTProviderSpec{JSONWebKeySet: &JSONWebKeySet{Local: &LocalJWKS{JWKS: "e30=", Filename: "/etc/jwks.json"}}},
+			expectedErrMsgs: []string{
+				`spec.jsonWebKeySet.local: Invalid value: "{\"jwks\":\"e30=\",\"filename\":\"/etc/jwks.json\"}": exactly one of jwks or filename must be set`,
+			},
+		},
+		"local jwks not base64": {
+			spec: JWTProviderSpec{JSONWebKeySet: &JSONWebKeySet{Local: &LocalJWKS{JWKS: "{}"}}},
+			expectedErrMsgs: []string{
+				`spec.jsonWebKeySet.local.jwks: Invalid value: "{}": must be base64 encoded`,
+			},
+		},
+		"invalid remote": {
+			spec: JWTProviderSpec{JSONWebKeySet: &JSONWebKeySet{Remote: &RemoteJWKS{
+				URI:              "example.com/jwks",
+				RequestTimeoutMs: -1,
+				RetryPolicy: &JWKSRetryPolicy{
+					NumRetries: -1,
+					RetryPolicyBackOff: &RetryPolicyBackOff{
+						BaseInterval: metav1.Duration{Duration: 5 * time.Second},
+						MaxInterval:  metav1.Duration{Duration: time.Second},
+					},
+				},
+			}}},
+			expectedErrMsgs: []string{
+				`spec.jsonWebKeySet.remote.uri: Invalid value: "example.com/jwks": must be a valid URL`,
+				`spec.jsonWebKeySet.remote.requestTimeoutMs: Invalid value: -1: must be non-negative`,
+				`spec.jsonWebKeySet.remote.retryPolicy.numRetries: Invalid value: -1: must be non-negative`,
+				`spec.jsonWebKeySet.remote.retryPolicy.retryPolicyBackOff.maxInterval: Invalid value: "1s": must be greater than or equal to baseInterval`,
+			},
+		},
+		"missing remote uri": {
+			spec: JWTProviderSpec{JSONWebKeySet: &JSONWebKeySet{Remote: &RemoteJWKS{}}},
+			expectedErrMsgs: []string{
+				`spec.jsonWebKeySet.remote.uri: Required value: uri must be set`,
+			},
+		},
+		"invalid locations": {
+			spec: JWTProviderSpec{
+				JSONWebKeySet: remote,
+				Locations: []*JWTLocation{
+					{},
+					{Header: &JWTLocationHeader{Name: "Authorization"}, Cookie: &JWTLocationCookie{Name: "session"}},
+					{QueryParam: &JWTLocationQueryParam{}},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.locations[0]: Invalid value: "{}": exactly one of header, queryParam or cookie must be set`,
+				`spec.locations[1]: Invalid value: "{\"header\":{\"name\":\"Authorization\"},\"cookie\":{\"name\":\"session\"}}": exactly one of header, queryParam or cookie must be set`,
+				`spec.locations[2].queryParam.name: Required value: name must be set`,
+			},
+		},
+		"negative values": {
+			spec: JWTProviderSpec{
+				JSONWebKeySet:    remote,
+				ClockSkewSeconds: -1,
+				CacheConfig:      &JWTCacheConfig{Size: -1},
+			},
+			expectedErrMsgs: []string{
+				`spec.clockSkewSeconds: Invalid value: -1: must be non-negative`,
+				`spec.cacheConfig.size: Invalid value: -1: must be non-negative`,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			provider := &JWTProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider"},
+				Spec:       c.spec,
+			}
+			err := provider.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
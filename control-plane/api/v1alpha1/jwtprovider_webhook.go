@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type JWTProviderWebhook struct {
+	Logger logr.Logger
+
+	// ConsulMeta contains metadata specific to the Consul installation.
+	ConsulMeta common.ConsulMeta
+
+	decoder *admission.Decoder
+	client.Client
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-jwtprovider,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=jwtproviders,versions=v1alpha1,name=mutate-jwtprovider.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *JWTProviderWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var resource JWTProvider
+	err := v.decoder.Decode(req, &resource)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	return common.ValidateConfigEntry(ctx, req, v.Logger, v, &resource, v.ConsulMeta)
+}
+
+func (v *JWTProviderWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
+	var resourceList JWTProviderList
+	if err := v.Client.List(ctx, &resourceList); err != nil {
+		return nil, err
+	}
+	var entries []common.ConfigEntryResource
+	for _, item := range resourceList.Items {
+		entries = append(entries, common.ConfigEntryResource(&item))
+	}
+	return entries, nil
+}
+
+func (v *JWTProviderWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// missingJWTProviders returns a NotFound error for every reference to a
+// JWTProvider that doesn't exist in the cluster.
+func missingJWTProviders(ctx context.Context, c client.Client, refs []jwtProviderRef) (field.ErrorList, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	var providers JWTProviderList
+	if err := c.List(ctx, &providers); err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, provider := range providers.Items {
+		existing[provider.ConsulName()] = true
+	}
+	var errs field.ErrorList
+	for _, ref := range refs {
+		if !existing[ref.name] {
+			errs = append(errs, field.NotFound(ref.path, ref.name))
+		}
+	}
+	return errs, nil
+}
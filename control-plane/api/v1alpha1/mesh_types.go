@@ -172,15 +172,7 @@ func (in *Mesh) KubernetesName() string {
 }
 
 func (in *Mesh) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *Mesh) SetLastSyncedTime(time *metav1.Time) {
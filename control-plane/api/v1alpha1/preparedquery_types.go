@@ -0,0 +1,304 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	PreparedQueryKubeKind = "preparedquery"
+
+	// PreparedQueryTemplateNamePrefixMatch is the only supported prepared
+	// query template type. The template applies to any query whose name
+	// starts with the name of the template.
+	PreparedQueryTemplateNamePrefixMatch = "name_prefix_match"
+)
+
+func init() {
+	SchemeBuilder.Register(&PreparedQuery{}, &PreparedQueryList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// PreparedQuery is the Schema for the preparedqueries API. The name of the
+// resource is the name of the prepared query in Consul.
+// +kubebuilder:printcolumn:name="Service",type="string",JSONPath=".spec.service.service",description="The service the prepared query returns"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the prepared query in Consul",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="prepared-query"
+type PreparedQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PreparedQuerySpec   `json:"spec,omitempty"`
+	Status PreparedQueryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PreparedQueryList contains a list of PreparedQuery.
+type PreparedQueryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PreparedQuery `json:"items"`
+}
+
+// PreparedQuerySpec defines the desired state of PreparedQuery.
+type PreparedQuerySpec struct {
+	// Service defines the service to query.
+	Service PreparedQueryService `json:"service"`
+	// DNS configures DNS lookups of the prepared query.
+	DNS PreparedQueryDNS `json:"dns,omitempty"`
+	// Template turns the prepared query into a template that applies to any
+	// query whose name starts with the name of this resource.
+	Template PreparedQueryTemplate `json:"template,omitempty"`
+}
+
+// PreparedQueryService defines the service to query and how to filter
+// and fail over the results.
+type PreparedQueryService struct {
+	// Service is the name of the service to query.
+	Service string `json:"service"`
+	// Namespace is the Consul namespace of the service. If empty, the
+	// namespace is derived from the Kubernetes namespace of this resource
+	// using the controller's namespace settings.
+	Namespace string `json:"namespace,omitempty"`
+	// Near sorts the results by round trip time from the given node. The
+	// special value "_agent" sorts by distance from the agent serving the
+	// query.
+	Near string `json:"near,omitempty"`
+	// Failover controls what happens if there are no healthy instances of
+	// the service in the local datacenter.
+	Failover PreparedQueryFailover `json:"failover,omitempty"`
+	// IgnoreCheckIDs is a list of health check IDs to ignore when filtering
+	// unhealthy instances.
+	IgnoreCheckIDs []string `json:"ignoreCheckIDs,omitempty"`
+	// OnlyPassing filters out instances with warning checks as well as
+	// critical ones.
+	OnlyPassing bool `json:"onlyPassing,omitempty"`
+	// Tags filters the results by tag. Tags prefixed with "!" must not be
+	// present on the instance.
+	Tags []string `json:"tags,omitempty"`
+	// NodeMeta filters the results to nodes with the given metadata.
+	NodeMeta map[string]string `json:"nodeMeta,omitempty"`
+	// ServiceMeta filters the results to instances with the given metadata.
+	ServiceMeta map[string]string `json:"serviceMeta,omitempty"`
+	// Connect returns only Connect-capable instances of the service.
+	Connect bool `json:"connect,omitempty"`
+}
+
+// PreparedQueryFailover controls failover to other datacenters or peers.
+type PreparedQueryFailover struct {
+	// NearestN fails over to the given number of datacenters, sorted by
+	// round trip time. Cannot be used with Targets.
+	NearestN int `json:"nearestN,omitempty"`
+	// Datacenters is a fixed list of datacenters to fail over to. They are
+	// tried after the NearestN datacenters. Cannot be used with Targets.
+	Datacenters []string `json:"datacenters,omitempty"`
+	// Targets is an ordered list of datacenters or cluster peers to fail
+	// over to. Cannot be used with NearestN or Datacenters.
+	Targets []PreparedQueryFailoverTarget `json:"targets,omitempty"`
+}
+
+// PreparedQueryFailoverTarget is a datacenter or cluster peer to fail over
+// to. Exactly one of Peer or Datacenter must be set.
+type PreparedQueryFailoverTarget struct {
+	// Peer is the name of a cluster peer.
+	Peer string `json:"peer,omitempty"`
+	// Datacenter is the name of a WAN-federated datacenter.
+	Datacenter string `json:"datacenter,omitempty"`
+}
+
+// PreparedQueryDNS configures DNS lookups of the prepared query.
+type PreparedQueryDNS struct {
+	// TTL is the TTL of DNS responses, e.g. "10s".
+	TTL string `json:"ttl,omitempty"`
+}
+
+// PreparedQueryTemplate configures the prepared query as a template.
+type PreparedQueryTemplate struct {
+	// Type is the template type. The only supported type is
+	// "name_prefix_match".
+	Type string `json:"type,omitempty"`
+	// Regexp is a regular expression that is matched against the query
+	// name. Its capture groups can be interpolated into the query.
+	Regexp string `json:"regexp,omitempty"`
+}
+
+// PreparedQueryStatus defines the observed state of PreparedQuery.
+type PreparedQueryStatus struct {
+	Status `json:",inline"`
+	// ID is the ID of the prepared query in Consul.
+	ID string `json:"id,omitempty"`
+}
+
+func (in *PreparedQuery) KubeKind() string {
+	return PreparedQueryKubeKind
+}
+
+func (in *PreparedQuery) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// ConsulName returns the name of the prepared query in Consul.
+func (in *PreparedQuery) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *PreparedQuery) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *PreparedQuery) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *PreparedQuery) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+// ToConsul converts the resource to a Consul prepared query. consulNS is the
+// namespace of the service if it isn't set on the resource. The ID is set
+// from the status.
+func (in *PreparedQuery) ToConsul(consulNS string) *capi.PreparedQueryDefinition {
+	svc := in.Spec.Service
+	namespace := svc.Namespace
+	if namespace == "" {
+		namespace = consulNS
+	}
+	var targets []capi.QueryFailoverTarget
+	for _, t := range svc.Failover.Targets {
+		targets = append(targets, capi.QueryFailoverTarget{Peer: t.Peer, Datacenter: t.Datacenter})
+	}
+	return &capi.PreparedQueryDefinition{
+		ID:   in.Status.ID,
+		Name: in.ConsulName(),
+		Service: capi.ServiceQuery{
+			Service:   svc.Service,
+			Namespace: namespace,
+			Near:      svc.Near,
+			Failover: capi.QueryFailoverOptions{
+				NearestN:    svc.Failover.NearestN,
+				Datacenters: svc.Failover.Datacenters,
+				Targets:     targets,
+			},
+			IgnoreCheckIDs: svc.IgnoreCheckIDs,
+			OnlyPassing:    svc.OnlyPassing,
+			Tags:           svc.Tags,
+			NodeMeta:       svc.NodeMeta,
+			ServiceMeta:    svc.ServiceMeta,
+			Connect:        svc.Connect,
+		},
+		DNS: capi.QueryDNSOptions{
+			TTL: in.Spec.DNS.TTL,
+		},
+		Template: capi.QueryTemplate{
+			Type:   in.Spec.Template.Type,
+			Regexp: in.Spec.Template.Regexp,
+		},
+	}
+}
+
+// MatchesConsul returns true if the prepared query in Consul matches this
+// resource. Consul returns an empty namespace for services in the default
+// namespace and when namespaces aren't supported so an empty namespace
+// matches the default namespace.
+func (in *PreparedQuery) MatchesConsul(candidate *capi.PreparedQueryDefinition, consulNS string) bool {
+	if candidate == nil {
+		return false
+	}
+	desired := in.ToConsul(consulNS)
+	return cmp.Equal(desired, candidate,
+		cmpopts.IgnoreFields(capi.PreparedQueryDefinition{}, "ID", "Session", "Token"),
+		cmpopts.EquateEmpty(),
+		cmp.FilterPath(func(p cmp.Path) bool {
+			return p.String() == "Service.Namespace"
+		}, cmp.Comparer(func(a, b string) bool {
+			return normalizeNamespace(a) == normalizeNamespace(b)
+		})))
+}
+
+func (in *PreparedQuery) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+	svc := in.Spec.Service
+	svcPath := path.Child("service")
+
+	if svc.Service == "" {
+		errs = append(errs, field.Required(svcPath.Child("service"), "service must be set"))
+	}
+	if svc.Failover.NearestN < 0 {
+		errs = append(errs, field.Invalid(svcPath.Child("failover").Child("nearestN"), svc.Failover.NearestN, "must be greater than or equal to 0"))
+	}
+	if (svc.Failover.NearestN > 0 || len(svc.Failover.Datacenters) > 0) && len(svc.Failover.Targets) > 0 {
+		asJSON, _ := json.Marshal(svc.Failover)
+		errs = append(errs, field.Invalid(svcPath.Child("failover"), string(asJSON), "targets cannot be used with nearestN or datacenters"))
+	}
+	for i, dc := range svc.Failover.Datacenters {
+		if dc == "" {
+			errs = append(errs, field.Required(svcPath.Child("failover").Child("datacenters").Index(i), "datacenter cannot be empty"))
+		}
+	}
+	for i, target := range svc.Failover.Targets {
+		if (target.Peer == "") == (target.Datacenter == "") {
+			asJSON, _ := json.Marshal(target)
+			errs = append(errs, field.Invalid(svcPath.Child("failover").Child("targets").Index(i), string(asJSON), "exactly one of peer or datacenter must be set"))
+		}
+	}
+
+	if in.Spec.DNS.TTL != "" {
+		if ttl, err := time.ParseDuration(in.Spec.DNS.TTL); err != nil {
+			errs = append(errs, field.Invalid(path.Child("dns").Child("ttl"), in.Spec.DNS.TTL, err.Error()))
+		} else if ttl < 0 {
+			errs = append(errs, field.Invalid(path.Child("dns").Child("ttl"), in.Spec.DNS.TTL, "must be greater than or equal to 0"))
+		}
+	}
+
+	tmpl := in.Spec.Template
+	switch tmpl.Type {
+	case "":
+		if tmpl.Regexp != "" {
+			errs = append(errs, field.Required(path.Child("template").Child("type"), "type must be set if regexp is set"))
+		}
+	case PreparedQueryTemplateNamePrefixMatch:
+	default:
+		errs = append(errs, field.Invalid(path.Child("template").Child("type"), tmpl.Type, notInSliceMessage([]string{PreparedQueryTemplateNamePrefixMatch})))
+	}
+	if tmpl.Regexp != "" {
+		if _, err := regexp.Compile(tmpl.Regexp); err != nil {
+			errs = append(errs, field.Invalid(path.Child("template").Child("regexp"), tmpl.Regexp, err.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: PreparedQueryKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+func normalizeNamespace(ns string) string {
+	if ns == "" {
+		return common.DefaultConsulNamespace
+	}
+	return ns
+}
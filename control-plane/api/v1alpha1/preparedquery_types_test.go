@@ -0,0 +1,275 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPreparedQuery_ToConsul(t *testing.T) {
+	query := &PreparedQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: PreparedQuerySpec{
+			Service: PreparedQueryService{
+				Service: "web",
+				Near:    "_agent",
+				Failover: PreparedQueryFailover{
+					Targets: []PreparedQueryFailoverTarget{
+						{Peer: "peer-1"},
+						{Datacenter: "dc2"},
+					},
+				},
+				IgnoreCheckIDs: []string{"check"},
+				OnlyPassing:    true,
+				Tags:           []string{"v1", "!canary"},
+				NodeMeta:       map[string]string{"rack": "a"},
+				ServiceMeta:    map[string]string{"version": "1"},
+				Connect:        true,
+			},
+			DNS: PreparedQueryDNS{TTL: "10s"},
+			Template: PreparedQueryTemplate{
+				Type:   PreparedQueryTemplateNamePrefixMatch,
+				Regexp: "^web-(.*)$",
+			},
+		},
+		Status: PreparedQueryStatus{ID: "id"},
+	}
+
+	require.Equal(t, &capi.PreparedQueryDefinition{
+		ID:   "id",
+		Name: "web",
+		Service: capi.ServiceQuery{
+			Service:   "web",
+			Namespace: "ns",
+			Near:      "_agent",
+			Failover: capi.QueryFailoverOptions{
+				Targets: []capi.QueryFailoverTarget{
+					{Peer: "peer-1"},
+					{Datacenter: "dc2"},
+				},
+			},
+			IgnoreCheckIDs: []string{"check"},
+			OnlyPassing:    true,
+			Tags:           []string{"v1", "!canary"},
+			NodeMeta:       map[string]string{"rack": "a"},
+			ServiceMeta:    map[string]string{"version": "1"},
+			Connect:        true,
+		},
+		DNS: capi.QueryDNSOptions{TTL: "10s"},
+		Template: capi.QueryTemplate{
+			Type:   "name_prefix_match",
+			Regexp: "^web-(.*)$",
+		},
+	}, query.ToConsul("ns"))
+
+	// The namespace on the resource takes precedence.
+	query.Spec.Service.Namespace = "other"
+	require.Equal(t, "other", query.ToConsul("ns").Service.Namespace)
+}
+
+func TestPreparedQuery_MatchesConsul(t *testing.T) {
+	query := &PreparedQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: PreparedQuerySpec{
+			Service: PreparedQueryService{
+				Service: "web",
+				Failover: PreparedQueryFailover{
+					NearestN: 2,
+				},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		theirs   *capi.PreparedQueryDefinition
+		consulNS string
+		matches  bool
+	}{
+		"nil": {
+			theirs:  nil,
+			matches: false,
+		},
+		"matches with empty fields and ID": {
+			theirs: &capi.PreparedQueryDefinition{
+				ID:   "id",
+				Name: "web",
+				Service: capi.ServiceQuery{
+					Service:        "web",
+					Failover:       capi.QueryFailoverOptions{NearestN: 2, Datacenters: []string{}},
+					IgnoreCheckIDs: []string{},
+					Tags:           []string{},
+				},
+				Token: "token",
+			},
+			matches: true,
+		},
+		"empty namespace matches default namespace": {
+			theirs: &capi.PreparedQueryDefinition{
+				Name: "web",
+				Service: capi.ServiceQuery{
+					Service:  "web",
+					Failover: capi.QueryFailoverOptions{NearestN: 2},
+				},
+			},
+			consulNS: "default",
+			matches:  true,
+		},
+		"namespace differs": {
+			theirs: &capi.PreparedQueryDefinition{
+				Name: "web",
+				Service: capi.ServiceQuery{
+					Service:   "web",
+					Namespace: "other",
+					Failover:  capi.QueryFailoverOptions{NearestN: 2},
+				},
+			},
+			consulNS: "default",
+			matches:  false,
+		},
+		"failover differs": {
+			theirs: &capi.PreparedQueryDefinition{
+				Name: "web",
+				Service: capi.ServiceQuery{
+					Service:  "web",
+					Failover: capi.QueryFailoverOptions{NearestN: 3},
+				},
+			},
+			matches: false,
+		},
+		"dns differs": {
+			theirs: &capi.PreparedQueryDefinition{
+				Name: "web",
+				Service: capi.ServiceQuery{
+					Service:  "web",
+					Failover: capi.QueryFailoverOptions{NearestN: 2},
+				},
+				DNS: capi.QueryDNSOptions{TTL: "5s"},
+			},
+			matches: false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.matches, query.MatchesConsul(c.theirs, c.consulNS))
+		})
+	}
+}
+
+func TestPreparedQuery_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec            PreparedQuerySpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: PreparedQuerySpec{
+				Service: PreparedQueryService{
+					Service: "web",
+					Failover: PreparedQueryFailover{
+						Targets: []PreparedQueryFailoverTarget{{Peer: "peer"}, {Datacenter: "dc2"}},
+					},
+				},
+				DNS:      PreparedQueryDNS{TTL: "10s"},
+				Template: PreparedQueryTemplate{Type: "name_prefix_match", Regexp: "^web-(.*)$"},
+			},
+		},
+		"missing service": {
+			spec: PreparedQuerySpec{},
+			expectedErrMsgs: []string{
+				`spec.service.service: Required value: service must be set`,
+			},
+		},
+		"invalid failover": {
+			spec: PreparedQuerySpec{
+				Service: PreparedQueryService{
+					Service: "web",
+					Failover: PreparedQueryFailover{
+						NearestN:    -1,
+						Datacenters: []string{""},
+						Targets:     []PreparedQueryFailoverTarget{{}, {Peer: "peer", Datacenter: "dc2"}},
+					},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.service.failover.nearestN: Invalid value: -1: must be greater than or equal to 0`,
+				`spec.service.failover: Invalid value: "{\"nearestN\":-1,\"datacenters\":[\"\"],\"targets\":[{},{\"peer\":\"peer\",\"datacenter\":\"dc2\"}]}": targets cannot be used with nearestN or datacenters`,
+				`spec.service.failover.datacenters[0]: Required value: datacenter cannot be empty`,
+				`spec.service.failover.targets[0]: Invalid value: "{}": exactly one of peer or datacenter must be set`,
+				`spec.service.failover.targets[1]: Invalid value: "{\"peer\":\"peer\",\"datacenter\":\"dc2\"}": exactly one of peer or datacenter must be set`,
+			},
+		},
+		"invalid dns ttl": {
+			spec: PreparedQuerySpec{
+				Service: PreparedQueryService{Service: "web"},
+				DNS:     PreparedQueryDNS{TTL: "10"},
+			},
+			expectedErrMsgs: []string{
+				`spec.dns.ttl: Invalid value: "10": time: missing unit in duration "10"`,
+			},
+		},
+		"negative dns ttl": {
+			spec: PreparedQuerySpec{
+				Service: PreparedQueryService{Service: "web"},
+				DNS:     PreparedQueryDNS{TTL: "-1s"},
+			},
+			expectedErrMsgs: []string{
+				`spec.dns.ttl: Invalid value: "-1s": must be greater than or equal to 0`,
+			},
+		},
+		"invalid template": {
+			spec: PreparedQuerySpec{
+				Service:  PreparedQueryService{Service: "web"},
+				Template: PreparedQueryTemplate{Type: "prefix", Regexp: "(web"},
+			},
+			expectedErrMsgs: []string{
+				`spec.template.type: Invalid value: "prefix": must be one of "name_prefix_match"`,
+				`spec.template.regexp: Invalid value: "(web": error parsing regexp: missing closing ): ` + "`(web`",
+			},
+		},
+		"template regexp without type": {
+			spec: PreparedQuerySpec{
+				Service:  PreparedQueryService{Service: "web"},
+				Template: PreparedQueryTemplate{Regexp: "web"},
+			},
+			expectedErrMsgs: []string{
+				`spec.template.type: Required value: type must be set if regexp is set`,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			query := &PreparedQuery{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec:       c.spec,
+			}
+			err := query.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPreparedQuery_SetSyncedCondition(t *testing.T) {
+	query := &PreparedQuery{}
+	query.SetSyncedCondition(corev1.ConditionTrue, "reason", "message")
+
+	require.Equal(t, corev1.ConditionTrue, query.Status.Conditions[0].Status)
+	require.Equal(t, "reason", query.Status.Conditions[0].Reason)
+	require.Equal(t, "message", query.Status.Conditions[0].Message)
+	require.Equal(t, corev1.ConditionTrue, query.SyncedConditionStatus())
+}
+
+func TestPreparedQuery_KubeKind(t *testing.T) {
+	require.Equal(t, "preparedquery", (&PreparedQuery{}).KubeKind())
+}
@@ -0,0 +1,5 @@
+package v1alpha1
+
+// Hub marks ProxyDefaults as the type other versions of the ProxyDefaults
+// API are converted to and from. It is also the storage version.
+func (*ProxyDefaults) Hub() {}
@@ -26,6 +26,7 @@ func init() {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // ProxyDefaults is the Schema for the proxydefaults API
 // +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
@@ -75,6 +76,43 @@ type ProxyDefaultsSpec struct {
 	MeshGateway MeshGateway `json:"meshGateway,omitempty"`
 	// Expose controls the default expose path configuration for Envoy.
 	Expose Expose `json:"expose,omitempty"`
+	// AccessLogs controls all envoy instances' access logging configuration.
+	AccessLogs *AccessLogs `json:"accessLogs,omitempty"`
+	// EnvoyExtensions are a list of extensions to modify Envoy proxy configuration.
+	EnvoyExtensions EnvoyExtensions `json:"envoyExtensions,omitempty"`
+}
+
+// LogSinkType represents the destination for Envoy access logs.
+// One of "file", "stderr", or "stdout".
+type LogSinkType string
+
+const (
+	DefaultLogSinkType LogSinkType = ""
+	FileLogSinkType    LogSinkType = "file"
+	StdErrLogSinkType  LogSinkType = "stderr"
+	StdOutLogSinkType  LogSinkType = "stdout"
+)
+
+// AccessLogs describes the access logging configuration for all Envoy proxies in the mesh.
+type AccessLogs struct {
+	// Enabled turns on all access logging
+	Enabled bool `json:"enabled,omitempty"`
+	// DisableListenerLogs turns off just listener logs for connections rejected by Envoy because they don't
+	// have a matching listener filter.
+	DisableListenerLogs bool `json:"disableListenerLogs,omitempty"`
+	// Type selects the output for logs
+	// one of "file", "stderr". "stdout"
+	Type LogSinkType `json:"type,omitempty"`
+	// Path is the output file to write logs for file-type logging
+	Path string `json:"path,omitempty"`
+	// JSONFormat is a JSON-formatted string of an Envoy access log format dictionary.
+	// See for more info: https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#format-dictionaries
+	// Defining JSONFormat and TextFormat is invalid.
+	JSONFormat string `json:"jsonFormat,omitempty"`
+	// TextFormat is a representation of Envoy access logs format.
+	// See for more info: https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#format-strings
+	// Defining JSONFormat and TextFormat is invalid.
+	TextFormat string `json:"textFormat,omitempty"`
 }
 
 func (in *ProxyDefaults) GetObjectMeta() metav1.ObjectMeta {
@@ -141,15 +179,7 @@ func (in *ProxyDefaults) KubernetesName() string {
 }
 
 func (in *ProxyDefaults) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *ProxyDefaults) SetLastSyncedTime(time *metav1.Time) {
@@ -165,6 +195,8 @@ func (in *ProxyDefaults) ToConsul(datacenter string) capi.ConfigEntry {
 		Expose:           in.Spec.Expose.toConsul(),
 		Config:           consulConfig,
 		TransparentProxy: in.Spec.TransparentProxy.toConsul(),
+		AccessLogs:       in.Spec.AccessLogs.toConsul(),
+		EnvoyExtensions:  in.Spec.EnvoyExtensions.toConsul(),
 		Meta:             meta(datacenter),
 	}
 }
@@ -196,6 +228,8 @@ func (in *ProxyDefaults) Validate(_ common.ConsulMeta) error {
 		allErrs = append(allErrs, err)
 	}
 	allErrs = append(allErrs, in.Spec.Expose.validate(path.Child("expose"))...)
+	allErrs = append(allErrs, in.Spec.AccessLogs.validate(path.Child("accessLogs"))...)
+	allErrs = append(allErrs, in.Spec.EnvoyExtensions.validate(path.Child("envoyExtensions"))...)
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(
 			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ProxyDefaultsKubeKind},
@@ -237,3 +271,44 @@ func (in *ProxyDefaults) validateConfig(path *field.Path) *field.Error {
 	}
 	return nil
 }
+
+func (in *AccessLogs) toConsul() *capi.AccessLogsConfig {
+	if in == nil {
+		return nil
+	}
+	return &capi.AccessLogsConfig{
+		Enabled:             in.Enabled,
+		DisableListenerLogs: in.DisableListenerLogs,
+		Type:                capi.LogSinkType(in.Type),
+		Path:                in.Path,
+		JSONFormat:          in.JSONFormat,
+		TextFormat:          in.TextFormat,
+	}
+}
+
+func (in *AccessLogs) validate(path *field.Path) field.ErrorList {
+	if in == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	sinkTypes := []string{string(DefaultLogSinkType), string(FileLogSinkType), string(StdErrLogSinkType), string(StdOutLogSinkType)}
+	if !sliceContains(sinkTypes, string(in.Type)) {
+		errs = append(errs, field.Invalid(path.Child("type"), in.Type, notInSliceMessage(sinkTypes)))
+	}
+	if in.Type == FileLogSinkType && in.Path == "" {
+		errs = append(errs, field.Required(path.Child("path"), "path must be specified when using file type access logs"))
+	}
+	if in.Type != FileLogSinkType && in.Path != "" {
+		errs = append(errs, field.Invalid(path.Child("path"), in.Path, "path is only valid for file type access logs"))
+	}
+	if in.JSONFormat != "" && in.TextFormat != "" {
+		errs = append(errs, field.Invalid(path.Child("textFormat"), in.TextFormat, "cannot specify both access log jsonFormat and textFormat"))
+	}
+	if in.JSONFormat != "" {
+		var format map[string]interface{}
+		if err := json.Unmarshal([]byte(in.JSONFormat), &format); err != nil {
+			errs = append(errs, field.Invalid(path.Child("jsonFormat"), in.JSONFormat, fmt.Sprintf("must be a valid JSON object: %s", err)))
+		}
+	}
+	return errs
+}
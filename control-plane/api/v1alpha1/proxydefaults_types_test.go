@@ -176,6 +176,44 @@ func TestProxyDefaults_MatchesConsul(t *testing.T) {
 			},
 			Matches: true,
 		},
+		"envoy extensions round trip through the Consul API": {
+			Ours: ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: common.Global,
+				},
+				Spec: ProxyDefaultsSpec{
+					EnvoyExtensions: EnvoyExtensions{
+						{
+							Name:      "builtin/property-override",
+							Arguments: json.RawMessage(`{"Patches": [{"ResourceFilter": {"ResourceType": "cluster", "TrafficDirection": "outbound"}, "Op": "add", "Path": "/upstream_connection_options/tcp_keepalive/keepalive_probes", "Value": 5}]}`),
+						},
+					},
+				},
+			},
+			Theirs: roundTripProxyDefaults(t, &capi.ProxyConfigEntry{
+				Name: common.Global,
+				Kind: capi.ProxyDefaults,
+				EnvoyExtensions: []capi.EnvoyExtension{
+					{
+						Name: "builtin/property-override",
+						Arguments: map[string]interface{}{
+							"Patches": []interface{}{
+								map[string]interface{}{
+									"ResourceFilter": map[string]interface{}{
+										"ResourceType":     "cluster",
+										"TrafficDirection": "outbound",
+									},
+									"Op":    "add",
+									"Path":  "/upstream_connection_options/tcp_keepalive/keepalive_probes",
+									"Value": 5,
+								},
+							},
+						},
+					},
+				},
+			}),
+			Matches: true,
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -275,6 +313,75 @@ func TestProxyDefaults_ToConsul(t *testing.T) {
 				},
 			},
 		},
+		"envoy extensions": {
+			Ours: ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "name",
+				},
+				Spec: ProxyDefaultsSpec{
+					EnvoyExtensions: EnvoyExtensions{
+						{
+							Name:          "builtin/lua",
+							Required:      true,
+							Arguments:     json.RawMessage(`{"Listener": "inbound", "Script": "function envoy_on_request(handle) end"}`),
+							ConsulVersion: ">= 1.16.0",
+							EnvoyVersion:  ">= 1.26.0",
+						},
+					},
+				},
+			},
+			Exp: &capi.ProxyConfigEntry{
+				Name: "name",
+				Kind: capi.ProxyDefaults,
+				EnvoyExtensions: []capi.EnvoyExtension{
+					{
+						Name:     "builtin/lua",
+						Required: true,
+						Arguments: map[string]interface{}{
+							"Listener": "inbound",
+							"Script":   "function envoy_on_request(handle) end",
+						},
+						ConsulVersion: ">= 1.16.0",
+						EnvoyVersion:  ">= 1.26.0",
+					},
+				},
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: "datacenter",
+				},
+			},
+		},
+		"access logs": {
+			Ours: ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "name",
+				},
+				Spec: ProxyDefaultsSpec{
+					AccessLogs: &AccessLogs{
+						Enabled:             true,
+						DisableListenerLogs: true,
+						Type:                FileLogSinkType,
+						Path:                "/var/log/envoy.logs",
+						TextFormat:          "ITS WORKING %START_TIME%",
+					},
+				},
+			},
+			Exp: &capi.ProxyConfigEntry{
+				Name: "name",
+				Kind: capi.ProxyDefaults,
+				AccessLogs: &capi.AccessLogsConfig{
+					Enabled:             true,
+					DisableListenerLogs: true,
+					Type:                capi.FileLogSinkType,
+					Path:                "/var/log/envoy.logs",
+					TextFormat:          "ITS WORKING %START_TIME%",
+				},
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: "datacenter",
+				},
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -286,6 +393,17 @@ func TestProxyDefaults_ToConsul(t *testing.T) {
 	}
 }
 
+// roundTripProxyDefaults encodes and decodes the entry the same way it is
+// written to and read from Consul, e.g. so numbers become float64.
+func roundTripProxyDefaults(t *testing.T, entry *capi.ProxyConfigEntry) *capi.ProxyConfigEntry {
+	t.Helper()
+	raw, err := json.Marshal(entry)
+	require.NoError(t, err)
+	var out capi.ProxyConfigEntry
+	require.NoError(t, json.Unmarshal(raw, &out))
+	return &out
+}
+
 // Test validation for fields other than Config. Config is tested
 // in separate tests below.
 func TestProxyDefaults_Validate(t *testing.T) {
@@ -391,6 +509,91 @@ func TestProxyDefaults_Validate(t *testing.T) {
 			},
 			"proxydefaults.consul.hashicorp.com \"global\" is invalid: [spec.meshGateway.mode: Invalid value: \"invalid-mode\": must be one of \"remote\", \"local\", \"none\", \"\", spec.transparentProxy.outboundListenerPort: Invalid value: 1000: use the annotation `consul.hashicorp.com/transparent-proxy-outbound-listener-port` to configure the Outbound Listener Port, spec.mode: Invalid value: \"transparent\": use the annotation `consul.hashicorp.com/transparent-proxy` to configure the Transparent Proxy Mode, spec.expose.paths[0].path: Invalid value: \"invalid-path\": must begin with a '/', spec.expose.paths[0].protocol: Invalid value: \"invalid-protocol\": must be one of \"http\", \"http2\"]",
 		},
+		"envoyExtensions": {
+			&ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "global",
+				},
+				Spec: ProxyDefaultsSpec{
+					EnvoyExtensions: EnvoyExtensions{
+						{
+							Name:      "builtin/lua",
+							Arguments: json.RawMessage(`{"Listener": "upstream"}`),
+						},
+					},
+				},
+			},
+			`proxydefaults.consul.hashicorp.com "global" is invalid: [spec.envoyExtensions[0].arguments.Listener: Invalid value: "upstream": must be one of "inbound", "outbound", spec.envoyExtensions[0].arguments.Script: Required value: Script must be set]`,
+		},
+		"accessLogs: valid json format": {
+			&ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "global",
+				},
+				Spec: ProxyDefaultsSpec{
+					AccessLogs: &AccessLogs{
+						Enabled:    true,
+						Type:       StdOutLogSinkType,
+						JSONFormat: `{"start_time": "%START_TIME%"}`,
+					},
+				},
+			},
+			"",
+		},
+		"accessLogs: invalid type": {
+			&ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "global",
+				},
+				Spec: ProxyDefaultsSpec{
+					AccessLogs: &AccessLogs{
+						Type: "syslog",
+					},
+				},
+			},
+			`proxydefaults.consul.hashicorp.com "global" is invalid: spec.accessLogs.type: Invalid value: "syslog": must be one of "", "file", "stderr", "stdout"`,
+		},
+		"accessLogs: file type without path": {
+			&ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "global",
+				},
+				Spec: ProxyDefaultsSpec{
+					AccessLogs: &AccessLogs{
+						Type: FileLogSinkType,
+					},
+				},
+			},
+			`proxydefaults.consul.hashicorp.com "global" is invalid: spec.accessLogs.path: Required value: path must be specified when using file type access logs`,
+		},
+		"accessLogs: path without file type": {
+			&ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "global",
+				},
+				Spec: ProxyDefaultsSpec{
+					AccessLogs: &AccessLogs{
+						Type: StdErrLogSinkType,
+						Path: "/var/log/envoy.logs",
+					},
+				},
+			},
+			`proxydefaults.consul.hashicorp.com "global" is invalid: spec.accessLogs.path: Invalid value: "/var/log/envoy.logs": path is only valid for file type access logs`,
+		},
+		"accessLogs: invalid formats": {
+			&ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "global",
+				},
+				Spec: ProxyDefaultsSpec{
+					AccessLogs: &AccessLogs{
+						JSONFormat: "{start_time",
+						TextFormat: "%START_TIME%",
+					},
+				},
+			},
+			`proxydefaults.consul.hashicorp.com "global" is invalid: [spec.accessLogs.textFormat: Invalid value: "%START_TIME%": cannot specify both access log jsonFormat and textFormat, spec.accessLogs.jsonFormat: Invalid value: "{start_time": must be a valid JSON object: invalid character 's' looking for beginning of object key string]`,
+		},
 	}
 	for name, testCase := range cases {
 		t.Run(name, func(t *testing.T) {
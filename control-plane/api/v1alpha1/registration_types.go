@@ -0,0 +1,371 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const RegistrationKubeKind = "registration"
+
+// registrationCheckStatuses are the statuses a check can be registered with.
+var registrationCheckStatuses = []string{capi.HealthPassing, capi.HealthWarning, capi.HealthCritical}
+
+func init() {
+	SchemeBuilder.Register(&Registration{}, &RegistrationList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// Registration is the Schema for the registrations API. It registers a
+// service that runs outside of Kubernetes, e.g. on a VM or as a managed
+// database, in the Consul catalog.
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.node",description="The Consul node the service is registered on"
+// +kubebuilder:printcolumn:name="Service",type="string",JSONPath=".spec.service.name",description="The name of the service"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+type Registration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegistrationSpec   `json:"spec,omitempty"`
+	Status RegistrationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RegistrationList contains a list of Registration.
+type RegistrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Registration `json:"items"`
+}
+
+// RegistrationSpec defines the desired state of Registration.
+type RegistrationSpec struct {
+	// Node is the name of the Consul node the service is registered on. The
+	// node is created if it doesn't exist, and deleted with its last service
+	// if it was created by a Registration resource.
+	Node string `json:"node"`
+	// Address is the address of the node.
+	Address string `json:"address"`
+	// TaggedAddresses are additional addresses of the node, e.g. "wan".
+	TaggedAddresses map[string]string `json:"taggedAddresses,omitempty"`
+	// NodeMeta is metadata of the node.
+	NodeMeta map[string]string `json:"nodeMeta,omitempty"`
+	// Locality is the region and zone of the node.
+	Locality *RegistrationLocality `json:"locality,omitempty"`
+	// Service is the service registered on the node.
+	Service RegistrationService `json:"service"`
+	// Checks are the health checks of the service. Checks with a definition
+	// are run by consul-esm. Other checks keep their status until it is
+	// updated through the Consul API.
+	Checks []RegistrationCheck `json:"checks,omitempty"`
+}
+
+// RegistrationService is a service registered in the Consul catalog.
+type RegistrationService struct {
+	// ID is the ID of the service on the node. Defaults to the name.
+	ID string `json:"id,omitempty"`
+	// Name is the name of the service.
+	Name string `json:"name"`
+	// Tags are the tags of the service.
+	Tags []string `json:"tags,omitempty"`
+	// Meta is metadata of the service.
+	Meta map[string]string `json:"meta,omitempty"`
+	// Address is the address of the service. Defaults to the address of the
+	// node.
+	Address string `json:"address,omitempty"`
+	// Port is the port of the service.
+	Port int32 `json:"port,omitempty"`
+	// TaggedAddresses are additional addresses of the service, e.g. "lan".
+	TaggedAddresses map[string]RegistrationServiceAddress `json:"taggedAddresses,omitempty"`
+	// Locality is the region and zone of the service.
+	Locality *RegistrationLocality `json:"locality,omitempty"`
+}
+
+// RegistrationServiceAddress is an address and port of a service.
+type RegistrationServiceAddress struct {
+	Address string `json:"address"`
+	Port    int32  `json:"port,omitempty"`
+}
+
+// RegistrationLocality is the region and zone of a node or service.
+type RegistrationLocality struct {
+	Region string `json:"region,omitempty"`
+	Zone   string `json:"zone,omitempty"`
+}
+
+// RegistrationCheck is a health check of a registered service.
+type RegistrationCheck struct {
+	// CheckID is the ID of the check on the node.
+	CheckID string `json:"checkId"`
+	// Name is the name of the check.
+	Name string `json:"name"`
+	// Status is the status the check is registered with: passing, warning or
+	// critical. Defaults to critical.
+	Status string `json:"status,omitempty"`
+	// Notes are human-readable notes about the check.
+	Notes string `json:"notes,omitempty"`
+	// Output is the initial output of the check.
+	Output string `json:"output,omitempty"`
+	// Definition configures how consul-esm probes the service.
+	Definition RegistrationCheckDefinition `json:"definition,omitempty"`
+}
+
+// RegistrationCheckDefinition is an HTTP, TCP or gRPC probe of a service. At
+// most one of HTTP, TCP or GRPC can be set.
+type RegistrationCheckDefinition struct {
+	// HTTP is the URL of an HTTP check.
+	HTTP string `json:"http,omitempty"`
+	// Method is the HTTP method of an HTTP check.
+	Method string `json:"method,omitempty"`
+	// Header are the HTTP headers of an HTTP check.
+	Header map[string][]string `json:"header,omitempty"`
+	// Body is the request body of an HTTP check.
+	Body string `json:"body,omitempty"`
+	// TLSServerName is the server name used to verify the certificate of an
+	// HTTPS check.
+	TLSServerName string `json:"tlsServerName,omitempty"`
+	// TLSSkipVerify disables verifying the certificate of an HTTPS check.
+	TLSSkipVerify bool `json:"tlsSkipVerify,omitempty"`
+	// TCP is the host:port of a TCP check.
+	TCP string `json:"tcp,omitempty"`
+	// TCPUseTLS makes a TCP check perform a TLS handshake.
+	TCPUseTLS bool `json:"tcpUseTLS,omitempty"`
+	// GRPC is the host:port/service of a gRPC health check.
+	GRPC string `json:"grpc,omitempty"`
+	// GRPCUseTLS makes a gRPC check use TLS.
+	GRPCUseTLS bool `json:"grpcUseTLS,omitempty"`
+	// Interval is how often the check runs, e.g. "10s". Required if the check
+	// has a probe.
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Timeout is how long the check may take.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// DeregisterCriticalServiceAfter deregisters the service once the check
+	// has been critical for this long.
+	DeregisterCriticalServiceAfter metav1.Duration `json:"deregisterCriticalServiceAfter,omitempty"`
+}
+
+// RegistrationStatus defines the observed state of Registration.
+type RegistrationStatus struct {
+	Status `json:",inline"`
+	// ObservedGeneration is the generation of the spec that was last
+	// registered. The registration is only written again when the spec
+	// changes so that check statuses set by consul-esm aren't overwritten.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Node is the node the service is registered on.
+	Node string `json:"node,omitempty"`
+	// ServiceID is the ID of the registered service.
+	ServiceID string `json:"serviceId,omitempty"`
+}
+
+func (in *Registration) KubeKind() string {
+	return RegistrationKubeKind
+}
+
+func (in *Registration) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// ServiceID returns the ID of the service on its node.
+func (in *Registration) ServiceID() string {
+	if in.Spec.Service.ID != "" {
+		return in.Spec.Service.ID
+	}
+	return in.Spec.Service.Name
+}
+
+// HasProbes returns true if any of the checks has a probe that consul-esm
+// runs.
+func (in *Registration) HasProbes() bool {
+	for _, check := range in.Spec.Checks {
+		if check.Definition.hasProbe() {
+			return true
+		}
+	}
+	return false
+}
+
+// ToConsul returns the catalog registration of the service in the given
+// Consul namespace.
+func (in *Registration) ToConsul(consulNS string) *capi.CatalogRegistration {
+	serviceID := in.ServiceID()
+	var taggedAddresses map[string]capi.ServiceAddress
+	if len(in.Spec.Service.TaggedAddresses) > 0 {
+		taggedAddresses = make(map[string]capi.ServiceAddress)
+		for name, addr := range in.Spec.Service.TaggedAddresses {
+			taggedAddresses[name] = capi.ServiceAddress{Address: addr.Address, Port: int(addr.Port)}
+		}
+	}
+	var checks capi.HealthChecks
+	for _, check := range in.Spec.Checks {
+		status := check.Status
+		if status == "" {
+			status = capi.HealthCritical
+		}
+		checks = append(checks, &capi.HealthCheck{
+			Node:        in.Spec.Node,
+			CheckID:     check.CheckID,
+			Name:        check.Name,
+			Status:      status,
+			Notes:       check.Notes,
+			Output:      check.Output,
+			ServiceID:   serviceID,
+			ServiceName: in.Spec.Service.Name,
+			Namespace:   consulNS,
+			Definition:  check.Definition.toConsul(),
+		})
+	}
+	return &capi.CatalogRegistration{
+		Node:            in.Spec.Node,
+		Address:         in.Spec.Address,
+		TaggedAddresses: in.Spec.TaggedAddresses,
+		NodeMeta:        in.Spec.NodeMeta,
+		Locality:        in.Spec.Locality.toConsul(),
+		Service: &capi.AgentService{
+			ID:              serviceID,
+			Service:         in.Spec.Service.Name,
+			Tags:            in.Spec.Service.Tags,
+			Meta:            in.Spec.Service.Meta,
+			Address:         in.Spec.Service.Address,
+			Port:            int(in.Spec.Service.Port),
+			TaggedAddresses: taggedAddresses,
+			Locality:        in.Spec.Service.Locality.toConsul(),
+			Namespace:       consulNS,
+		},
+		Checks: checks,
+	}
+}
+
+func (in *Registration) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *Registration) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *Registration) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+func (in *Registration) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if in.Spec.Node == "" {
+		errs = append(errs, field.Required(path.Child("node"), "node must be set"))
+	}
+	if in.Spec.Address == "" {
+		errs = append(errs, field.Required(path.Child("address"), "address must be set"))
+	}
+
+	servicePath := path.Child("service")
+	if in.Spec.Service.Name == "" {
+		errs = append(errs, field.Required(servicePath.Child("name"), "name must be set"))
+	}
+	if in.Spec.Service.Port < 0 || in.Spec.Service.Port > 65535 {
+		errs = append(errs, field.Invalid(servicePath.Child("port"), in.Spec.Service.Port, "port must be between 0 and 65535"))
+	}
+	for name, addr := range in.Spec.Service.TaggedAddresses {
+		addrPath := servicePath.Child("taggedAddresses").Key(name)
+		if addr.Address == "" {
+			errs = append(errs, field.Required(addrPath.Child("address"), "address must be set"))
+		}
+		if addr.Port < 0 || addr.Port > 65535 {
+			errs = append(errs, field.Invalid(addrPath.Child("port"), addr.Port, "port must be between 0 and 65535"))
+		}
+	}
+
+	ids := make(map[string]bool)
+	for i, check := range in.Spec.Checks {
+		checkPath := path.Child("checks").Index(i)
+		if check.CheckID == "" {
+			errs = append(errs, field.Required(checkPath.Child("checkId"), "checkId must be set"))
+		} else if ids[check.CheckID] {
+			errs = append(errs, field.Duplicate(checkPath.Child("checkId"), check.CheckID))
+		}
+		ids[check.CheckID] = true
+		if check.Name == "" {
+			errs = append(errs, field.Required(checkPath.Child("name"), "name must be set"))
+		}
+		if check.Status != "" && !sliceContains(registrationCheckStatuses, check.Status) {
+			errs = append(errs, field.Invalid(checkPath.Child("status"), check.Status, notInSliceMessage(registrationCheckStatuses)))
+		}
+		errs = append(errs, check.Definition.validate(checkPath.Child("definition"))...)
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: RegistrationKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+func (in *RegistrationCheckDefinition) hasProbe() bool {
+	return in.HTTP != "" || in.TCP != "" || in.GRPC != ""
+}
+
+func (in *RegistrationCheckDefinition) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	probes := 0
+	for _, probe := range []string{in.HTTP, in.TCP, in.GRPC} {
+		if probe != "" {
+			probes++
+		}
+	}
+	if probes > 1 {
+		errs = append(errs, field.Invalid(path, fmt.Sprintf("http: %q, tcp: %q, grpc: %q", in.HTTP, in.TCP, in.GRPC),
+			"at most one of http, tcp or grpc can be set"))
+	}
+	if probes > 0 && in.Interval.Duration <= 0 {
+		errs = append(errs, field.Required(path.Child("interval"), "interval must be set if the check has a probe"))
+	}
+	if in.Timeout.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("timeout"), in.Timeout.Duration.String(), "timeout must not be negative"))
+	}
+	if in.DeregisterCriticalServiceAfter.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("deregisterCriticalServiceAfter"), in.DeregisterCriticalServiceAfter.Duration.String(),
+			"deregisterCriticalServiceAfter must not be negative"))
+	}
+	return errs
+}
+
+func (in *RegistrationCheckDefinition) toConsul() capi.HealthCheckDefinition {
+	return capi.HealthCheckDefinition{
+		HTTP:                                   in.HTTP,
+		Header:                                 in.Header,
+		Method:                                 in.Method,
+		Body:                                   in.Body,
+		TLSServerName:                          in.TLSServerName,
+		TLSSkipVerify:                          in.TLSSkipVerify,
+		TCP:                                    in.TCP,
+		TCPUseTLS:                              in.TCPUseTLS,
+		GRPC:                                   in.GRPC,
+		GRPCUseTLS:                             in.GRPCUseTLS,
+		IntervalDuration:                       in.Interval.Duration,
+		TimeoutDuration:                        in.Timeout.Duration,
+		DeregisterCriticalServiceAfterDuration: in.DeregisterCriticalServiceAfter.Duration,
+	}
+}
+
+func (in *RegistrationLocality) toConsul() *capi.Locality {
+	if in == nil {
+		return nil
+	}
+	return &capi.Locality{Region: in.Region, Zone: in.Zone}
+}
@@ -0,0 +1,220 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistration_ToConsul(t *testing.T) {
+	registration := &Registration{
+		ObjectMeta: metav1.ObjectMeta{Name: "db"},
+		Spec: RegistrationSpec{
+			Node:            "db-node",
+			Address:         "10.0.0.1",
+			TaggedAddresses: map[string]string{"wan": "203.0.113.1"},
+			NodeMeta:        map[string]string{"rack": "a"},
+			Locality:        &RegistrationLocality{Region: "us-east-1", Zone: "us-east-1a"},
+			Service: RegistrationService{
+				Name:            "db",
+				Tags:            []string{"primary"},
+				Meta:            map[string]string{"version": "14"},
+				Address:         "db.example.com",
+				Port:            5432,
+				TaggedAddresses: map[string]RegistrationServiceAddress{"wan": {Address: "203.0.113.1", Port: 15432}},
+				Locality:        &RegistrationLocality{Region: "us-east-1"},
+			},
+			Checks: []RegistrationCheck{
+				{
+					CheckID: "db-tcp",
+					Name:    "TCP",
+					Definition: RegistrationCheckDefinition{
+						TCP:      "db.example.com:5432",
+						Interval: metav1.Duration{Duration: 10 * time.Second},
+						Timeout:  metav1.Duration{Duration: time.Second},
+					},
+				},
+				{CheckID: "db-maintenance", Name: "Maintenance", Status: "passing", Notes: "notes"},
+			},
+		},
+	}
+
+	require.Equal(t, &capi.CatalogRegistration{
+		Node:            "db-node",
+		Address:         "10.0.0.1",
+		TaggedAddresses: map[string]string{"wan": "203.0.113.1"},
+		NodeMeta:        map[string]string{"rack": "a"},
+		Locality:        &capi.Locality{Region: "us-east-1", Zone: "us-east-1a"},
+		Service: &capi.AgentService{
+			ID:              "db",
+			Service:         "db",
+			Tags:            []string{"primary"},
+			Meta:            map[string]string{"version": "14"},
+			Address:         "db.example.com",
+			Port:            5432,
+			TaggedAddresses: map[string]capi.ServiceAddress{"wan": {Address: "203.0.113.1", Port: 15432}},
+			Locality:        &capi.Locality{Region: "us-east-1"},
+			Namespace:       "ns",
+		},
+		Checks: capi.HealthChecks{
+			{
+				Node:        "db-node",
+				CheckID:     "db-tcp",
+				Name:        "TCP",
+				Status:      "critical",
+				ServiceID:   "db",
+				ServiceName: "db",
+				Namespace:   "ns",
+				Definition: capi.HealthCheckDefinition{
+					TCP:              "db.example.com:5432",
+					IntervalDuration: 10 * time.Second,
+					TimeoutDuration:  time.Second,
+				},
+			},
+			{
+				Node:        "db-node",
+				CheckID:     "db-maintenance",
+				Name:        "Maintenance",
+				Status:      "passing",
+				Notes:       "notes",
+				ServiceID:   "db",
+				ServiceName: "db",
+				Namespace:   "ns",
+			},
+		},
+	}, registration.ToConsul("ns"))
+	require.True(t, registration.HasProbes())
+
+	// The ID defaults to the name.
+	registration.Spec.Service.ID = "db-1"
+	require.Equal(t, "db-1", registration.ToConsul("").Service.ID)
+	require.Equal(t, "db-1", registration.ToConsul("").Checks[0].ServiceID)
+}
+
+func TestRegistration_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec            RegistrationSpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: RegistrationSpec{
+				Node:    "db-node",
+				Address: "10.0.0.1",
+				Service: RegistrationService{
+					Name:            "db",
+					Port:            5432,
+					TaggedAddresses: map[string]RegistrationServiceAddress{"wan": {Address: "203.0.113.1", Port: 5432}},
+				},
+				Checks: []RegistrationCheck{
+					{
+						CheckID: "db-http",
+						Name:    "HTTP",
+						Status:  "warning",
+						Definition: RegistrationCheckDefinition{
+							HTTP:     "https://db.example.com/health",
+							Interval: metav1.Duration{Duration: 10 * time.Second},
+						},
+					},
+					{CheckID: "db-ttl", Name: "TTL"},
+				},
+			},
+		},
+		"missing fields": {
+			spec: RegistrationSpec{},
+			expectedErrMsgs: []string{
+				`spec.node: Required value: node must be set`,
+				`spec.address: Required value: address must be set`,
+				`spec.service.name: Required value: name must be set`,
+			},
+		},
+		"invalid service": {
+			spec: RegistrationSpec{
+				Node:    "db-node",
+				Address: "10.0.0.1",
+				Service: RegistrationService{
+					Name:            "db",
+					Port:            70000,
+					TaggedAddresses: map[string]RegistrationServiceAddress{"wan": {Port: -1}},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.service.port: Invalid value: 70000: port must be between 0 and 65535`,
+				`spec.service.taggedAddresses[wan].address: Required value: address must be set`,
+				`spec.service.taggedAddresses[wan].port: Invalid value: -1: port must be between 0 and 65535`,
+			},
+		},
+		"invalid checks": {
+			spec: RegistrationSpec{
+				Node:    "db-node",
+				Address: "10.0.0.1",
+				Service: RegistrationService{Name: "db"},
+				Checks: []RegistrationCheck{
+					{Status: "unknown"},
+					{
+						CheckID: "db",
+						Name:    "db",
+						Definition: RegistrationCheckDefinition{
+							HTTP: "http://db.example.com/health",
+							TCP:  "db.example.com:5432",
+						},
+					},
+					{
+						CheckID: "db",
+						Name:    "db",
+						Definition: RegistrationCheckDefinition{
+							Timeout:                        metav1.Duration{Duration: -time.Second},
+							DeregisterCriticalServiceAfter: metav1.Duration{Duration: -time.Second},
+						},
+					},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.checks[0].checkId: Required value: checkId must be set`,
+				`spec.checks[0].name: Required value: name must be set`,
+				`spec.checks[0].status: Invalid value: "unknown": must be one of "passing", "warning", "critical"`,
+				`spec.checks[1].definition: Invalid value: "http: \"http://db.example.com/health\", tcp: \"db.example.com:5432\", grpc: \"\"": at most one of http, tcp or grpc can be set`,
+				`spec.checks[1].definition.interval: Required value: interval must be set if the check has a probe`,
+				`spec.checks[2].checkId: Duplicate value: "db"`,
+				`spec.checks[2].definition.timeout: Invalid value: "-1s": timeout must not be negative`,
+				`spec.checks[2].definition.deregisterCriticalServiceAfter: Invalid value: "-1s": deregisterCriticalServiceAfter must not be negative`,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			registration := &Registration{
+				ObjectMeta: metav1.ObjectMeta{Name: "db"},
+				Spec:       c.spec,
+			}
+			err := registration.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegistration_SetSyncedCondition(t *testing.T) {
+	registration := &Registration{}
+	registration.SetSyncedCondition(corev1.ConditionTrue, "reason", "message")
+
+	require.Equal(t, corev1.ConditionTrue, registration.Status.Conditions[0].Status)
+	require.Equal(t, "reason", registration.Status.Conditions[0].Reason)
+	require.Equal(t, "message", registration.Status.Conditions[0].Message)
+	require.Equal(t, corev1.ConditionTrue, registration.SyncedConditionStatus())
+}
+
+func TestRegistration_KubeKind(t *testing.T) {
+	require.Equal(t, "registration", (&Registration{}).KubeKind())
+}
@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type RegistrationWebhook struct {
+	client.Client
+	Logger logr.Logger
+
+	// ConsulMeta contains metadata specific to the Consul installation.
+	ConsulMeta common.ConsulMeta
+
+	decoder *admission.Decoder
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is
+// it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-registration,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=registrations,versions=v1alpha1,name=mutate-registrations.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *RegistrationWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var registration Registration
+	err := v.decoder.Decode(req, &registration)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	v.Logger.Info("validate", "operation", req.Operation, "name", registration.KubernetesName())
+	if err := registration.Validate(v.ConsulMeta); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Two registrations of the same service would overwrite each other.
+	var list RegistrationList
+	if err := v.Client.List(ctx, &list, client.InNamespace(req.Namespace)); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	for _, item := range list.Items {
+		if item.Name != registration.Name && item.Spec.Node == registration.Spec.Node && item.ServiceID() == registration.ServiceID() {
+			return admission.Errored(http.StatusBadRequest,
+				fmt.Errorf("the service %q on node %q is already registered by the Registration resource %q",
+					registration.ServiceID(), registration.Spec.Node, item.Name))
+		}
+	}
+	return admission.Allowed(fmt.Sprintf("valid %s request", registration.KubeKind()))
+}
+
+func (v *RegistrationWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
@@ -0,0 +1,213 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func init() {
+	SchemeBuilder.Register(&SamenessGroup{}, &SamenessGroupList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SamenessGroup is the Schema for the samenessgroups API
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="sameness-group"
+type SamenessGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SamenessGroupSpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SamenessGroupList contains a list of SamenessGroup.
+type SamenessGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SamenessGroup `json:"items"`
+}
+
+// SamenessGroupSpec defines the desired state of SamenessGroup.
+type SamenessGroupSpec struct {
+	// DefaultForFailover indicates that upstream requests to members of the
+	// given sameness group will implicitly failover between members of this
+	// sameness group. When DefaultForFailover is true, the local partition must
+	// be a member of the sameness group or IncludeLocal must be set to true.
+	// Only one sameness group in a partition can be the default for failover.
+	DefaultForFailover bool `json:"defaultForFailover,omitempty"`
+	// IncludeLocal is used to include the local partition as the first member
+	// of the sameness group. The local partition can only be a member of a
+	// single sameness group.
+	IncludeLocal bool `json:"includeLocal,omitempty"`
+	// Members are the partitions and peers that are part of the sameness group.
+	// If a member of a sameness group does not exist, it will be ignored.
+	Members []SamenessGroupMember `json:"members,omitempty"`
+}
+
+// SamenessGroupMember is a partition or cluster peer in a sameness group.
+type SamenessGroupMember struct {
+	// Partition is the name of an admin partition in the local datacenter.
+	Partition string `json:"partition,omitempty"`
+	// Peer is the name of a cluster peer.
+	Peer string `json:"peer,omitempty"`
+}
+
+func (in *SamenessGroup) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *SamenessGroup) AddFinalizer(name string) {
+	in.ObjectMeta.Finalizers = append(in.Finalizers(), name)
+}
+
+func (in *SamenessGroup) RemoveFinalizer(name string) {
+	var newFinalizers []string
+	for _, oldF := range in.Finalizers() {
+		if oldF != name {
+			newFinalizers = append(newFinalizers, oldF)
+		}
+	}
+	in.ObjectMeta.Finalizers = newFinalizers
+}
+
+func (in *SamenessGroup) Finalizers() []string {
+	return in.ObjectMeta.Finalizers
+}
+
+func (in *SamenessGroup) ConsulKind() string {
+	return capi.SamenessGroup
+}
+
+func (in *SamenessGroup) ConsulGlobalResource() bool {
+	return true
+}
+
+func (in *SamenessGroup) ConsulMirroringNS() string {
+	return common.DefaultConsulNamespace
+}
+
+func (in *SamenessGroup) KubeKind() string {
+	return common.SamenessGroup
+}
+
+func (in *SamenessGroup) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *SamenessGroup) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *SamenessGroup) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *SamenessGroup) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *SamenessGroup) SyncedCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+func (in *SamenessGroup) SyncedConditionStatus() corev1.ConditionStatus {
+	condition := in.Status.GetCondition(ConditionSynced)
+	if condition == nil {
+		return corev1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+func (in *SamenessGroup) ToConsul(datacenter string) capi.ConfigEntry {
+	var members []capi.SamenessGroupMember
+	for _, member := range in.Spec.Members {
+		members = append(members, capi.SamenessGroupMember{
+			Partition: member.Partition,
+			Peer:      member.Peer,
+		})
+	}
+	return &capi.SamenessGroupConfigEntry{
+		Kind:               in.ConsulKind(),
+		Name:               in.ConsulName(),
+		DefaultForFailover: in.Spec.DefaultForFailover,
+		IncludeLocal:       in.Spec.IncludeLocal,
+		Members:            members,
+		Meta:               meta(datacenter),
+	}
+}
+
+func (in *SamenessGroup) MatchesConsul(candidate capi.ConfigEntry) bool {
+	configEntry, ok := candidate.(*capi.SamenessGroupConfigEntry)
+	if !ok {
+		return false
+	}
+	// No datacenter is passed to ToConsul as we ignore the Meta field when checking for equality.
+	return cmp.Equal(in.ToConsul(""), configEntry, cmpopts.IgnoreFields(capi.SamenessGroupConfigEntry{}, "Partition", "Meta", "ModifyIndex", "CreateIndex"), cmpopts.IgnoreUnexported(), cmpopts.EquateEmpty())
+}
+
+func (in *SamenessGroup) Validate(consulMeta common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if !consulMeta.PartitionsEnabled {
+		errs = append(errs, field.Invalid(path, "", "Consul Enterprise Admin Partitions must be enabled to create sameness groups"))
+	}
+	if len(in.Spec.Members) == 0 && !in.Spec.IncludeLocal {
+		errs = append(errs, field.Required(path.Child("members"), "at least one member must be set unless includeLocal is true"))
+	}
+	seen := make(map[SamenessGroupMember]bool)
+	for i, member := range in.Spec.Members {
+		memberPath := path.Child("members").Index(i)
+		if numNotEmpty(member.Partition, member.Peer) != 1 {
+			asJSON, _ := json.Marshal(member)
+			errs = append(errs, field.Invalid(memberPath, string(asJSON), "exactly one of partition or peer must be set"))
+			continue
+		}
+		if seen[member] {
+			asJSON, _ := json.Marshal(member)
+			errs = append(errs, field.Duplicate(memberPath, string(asJSON)))
+		}
+		seen[member] = true
+		// The local partition is always the first member of a sameness group.
+		if consulMeta.PartitionsEnabled && member.Partition == consulMeta.Partition && (i != 0 || in.Spec.IncludeLocal) {
+			errs = append(errs, field.Invalid(memberPath.Child("partition"), member.Partition, "the local partition must be the first member and cannot be listed when includeLocal is true"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: common.SamenessGroup},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+// DefaultNamespaceFields has no behaviour here as sameness-group config entries have no namespace specific fields.
+func (in *SamenessGroup) DefaultNamespaceFields(_ common.ConsulMeta) {
+}
+
+// samenessGroupRef is a reference to a SamenessGroup from another resource.
+type samenessGroupRef struct {
+	name string
+	path *field.Path
+}
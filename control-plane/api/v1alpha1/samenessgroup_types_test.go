@@ -0,0 +1,148 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSamenessGroup_ToConsul(t *testing.T) {
+	group := &SamenessGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group"},
+		Spec: SamenessGroupSpec{
+			DefaultForFailover: true,
+			IncludeLocal:       true,
+			Members: []SamenessGroupMember{
+				{Partition: "ap1"},
+				{Peer: "dc2-default"},
+			},
+		},
+	}
+	require.Equal(t, &capi.SamenessGroupConfigEntry{
+		Kind:               capi.SamenessGroup,
+		Name:               "group",
+		DefaultForFailover: true,
+		IncludeLocal:       true,
+		Members: []capi.SamenessGroupMember{
+			{Partition: "ap1"},
+			{Peer: "dc2-default"},
+		},
+		Meta: map[string]string{
+			common.SourceKey:     common.SourceValue,
+			common.DatacenterKey: "datacenter",
+		},
+	}, group.ToConsul("datacenter"))
+}
+
+func TestSamenessGroup_MatchesConsul(t *testing.T) {
+	group := &SamenessGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group"},
+		Spec: SamenessGroupSpec{
+			Members: []SamenessGroupMember{{Peer: "dc2-default"}},
+		},
+	}
+	require.True(t, group.MatchesConsul(&capi.SamenessGroupConfigEntry{
+		Kind:        capi.SamenessGroup,
+		Name:        "group",
+		Partition:   "default",
+		Members:     []capi.SamenessGroupMember{{Peer: "dc2-default"}},
+		ModifyIndex: 1,
+	}))
+	require.False(t, group.MatchesConsul(&capi.SamenessGroupConfigEntry{
+		Kind:    capi.SamenessGroup,
+		Name:    "group",
+		Members: []capi.SamenessGroupMember{{Peer: "dc3-default"}},
+	}))
+	require.False(t, group.MatchesConsul(&capi.ExportedServicesConfigEntry{Name: "group"}))
+}
+
+func TestSamenessGroup_ConsulNamespace(t *testing.T) {
+	group := &SamenessGroup{ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "apps"}}
+	require.True(t, group.ConsulGlobalResource())
+	require.Equal(t, common.DefaultConsulNamespace, group.ConsulMirroringNS())
+}
+
+func TestSamenessGroup_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec              SamenessGroupSpec
+		partitionsEnabled bool
+		expectedErrMsgs   []string
+	}{
+		"valid": {
+			spec: SamenessGroupSpec{
+				Members: []SamenessGroupMember{{Partition: "default"}, {Partition: "ap1"}, {Peer: "dc2-default"}},
+			},
+			partitionsEnabled: true,
+		},
+		"include local without members": {
+			spec:              SamenessGroupSpec{IncludeLocal: true},
+			partitionsEnabled: true,
+		},
+		"partitions disabled": {
+			spec: SamenessGroupSpec{
+				Members: []SamenessGroupMember{{Peer: "dc2-default"}},
+			},
+			partitionsEnabled: false,
+			expectedErrMsgs: []string{
+				`spec: Invalid value: "": Consul Enterprise Admin Partitions must be enabled to create sameness groups`,
+			},
+		},
+		"no members": {
+			spec:              SamenessGroupSpec{},
+			partitionsEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.members: Required value: at least one member must be set unless includeLocal is true`,
+			},
+		},
+		"invalid members": {
+			spec: SamenessGroupSpec{
+				Members: []SamenessGroupMember{{}, {Partition: "ap1", Peer: "dc2-default"}, {Peer: "dc2-default"}, {Peer: "dc2-default"}},
+			},
+			partitionsEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.members[0]: Invalid value: "{}": exactly one of partition or peer must be set`,
+				`spec.members[1]: Invalid value: "{\"partition\":\"ap1\",\"peer\":\"dc2-default\"}": exactly one of partition or peer must be set`,
+				`spec.members[3]: Duplicate value: "{\"peer\":\"dc2-default\"}"`,
+			},
+		},
+		"local partition not first": {
+			spec: SamenessGroupSpec{
+				Members: []SamenessGroupMember{{Partition: "ap1"}, {Partition: "default"}},
+			},
+			partitionsEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.members[1].partition: Invalid value: "default": the local partition must be the first member and cannot be listed when includeLocal is true`,
+			},
+		},
+		"local partition listed with include local": {
+			spec: SamenessGroupSpec{
+				IncludeLocal: true,
+				Members:      []SamenessGroupMember{{Partition: "default"}},
+			},
+			partitionsEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.members[0].partition: Invalid value: "default": the local partition must be the first member and cannot be listed when includeLocal is true`,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			group := &SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group"},
+				Spec:       c.spec,
+			}
+			err := group.Validate(common.ConsulMeta{PartitionsEnabled: c.partitionsEnabled, Partition: common.DefaultConsulPartition})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
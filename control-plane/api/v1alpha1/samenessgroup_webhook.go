@@ -0,0 +1,105 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type SamenessGroupWebhook struct {
+	Logger logr.Logger
+
+	// ConsulMeta contains metadata specific to the Consul installation.
+	ConsulMeta common.ConsulMeta
+
+	decoder *admission.Decoder
+	client.Client
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-samenessgroup,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=samenessgroups,versions=v1alpha1,name=mutate-samenessgroup.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *SamenessGroupWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var resource SamenessGroup
+	err := v.decoder.Decode(req, &resource)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	resp := common.ValidateConfigEntry(ctx, req, v.Logger, v, &resource, v.ConsulMeta)
+	if !resp.Allowed || !resource.Spec.DefaultForFailover {
+		return resp
+	}
+
+	// Consul only allows a single sameness group per partition to be the
+	// default for failover.
+	var groups SamenessGroupList
+	if err := v.Client.List(ctx, &groups); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	for _, group := range groups.Items {
+		if group.ConsulName() != resource.ConsulName() && group.Spec.DefaultForFailover {
+			return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+				schema.GroupKind{Group: ConsulHashicorpGroup, Kind: common.SamenessGroup},
+				resource.KubernetesName(), field.ErrorList{
+					field.Invalid(field.NewPath("spec").Child("defaultForFailover"), true,
+						"sameness group "+group.ConsulName()+" is already the default for failover"),
+				}))
+		}
+	}
+	return resp
+}
+
+func (v *SamenessGroupWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
+	var resourceList SamenessGroupList
+	if err := v.Client.List(ctx, &resourceList); err != nil {
+		return nil, err
+	}
+	var entries []common.ConfigEntryResource
+	for _, item := range resourceList.Items {
+		entries = append(entries, common.ConfigEntryResource(&item))
+	}
+	return entries, nil
+}
+
+func (v *SamenessGroupWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// missingSamenessGroups returns a NotFound error for every reference to a
+// SamenessGroup that doesn't exist in the cluster.
+func missingSamenessGroups(ctx context.Context, c client.Client, refs []samenessGroupRef) (field.ErrorList, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	var groups SamenessGroupList
+	if err := c.List(ctx, &groups); err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, group := range groups.Items {
+		existing[group.ConsulName()] = true
+	}
+	var errs field.ErrorList
+	for _, ref := range refs {
+		if !existing[ref.name] {
+			errs = append(errs, field.NotFound(ref.path, ref.name))
+		}
+	}
+	return errs, nil
+}
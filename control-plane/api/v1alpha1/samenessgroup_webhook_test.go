@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestValidateSamenessGroup(t *testing.T) {
+	members := []SamenessGroupMember{{Peer: "dc2-default"}}
+	cases := map[string]struct {
+		existingResources []runtime.Object
+		newResource       *SamenessGroup
+		operation         admissionv1.Operation
+		expAllow          bool
+		expErrMessage     string
+	}{
+		"default for failover": {
+			existingResources: []runtime.Object{&SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+				Spec:       SamenessGroupSpec{Members: members},
+			}},
+			newResource: &SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+				Spec:       SamenessGroupSpec{DefaultForFailover: true, Members: members},
+			},
+			operation: admissionv1.Create,
+			expAllow:  true,
+		},
+		"update of the default for failover": {
+			existingResources: []runtime.Object{&SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+				Spec:       SamenessGroupSpec{DefaultForFailover: true, Members: members},
+			}},
+			newResource: &SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+				Spec:       SamenessGroupSpec{DefaultForFailover: true, IncludeLocal: true, Members: members},
+			},
+			operation: admissionv1.Update,
+			expAllow:  true,
+		},
+		"another group is the default for failover": {
+			existingResources: []runtime.Object{&SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+				Spec:       SamenessGroupSpec{DefaultForFailover: true, Members: members},
+			}},
+			newResource: &SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+				Spec:       SamenessGroupSpec{DefaultForFailover: true, Members: members},
+			},
+			operation:     admissionv1.Create,
+			expAllow:      false,
+			expErrMessage: `samenessgroup.consul.hashicorp.com "group" is invalid: spec.defaultForFailover: Invalid value: true: sameness group other is already the default for failover`,
+		},
+		"invalid spec": {
+			newResource: &SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+			},
+			operation:     admissionv1.Create,
+			expAllow:      false,
+			expErrMessage: `samenessgroup.consul.hashicorp.com "group" is invalid: spec.members: Required value: at least one member must be set unless includeLocal is true`,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			marshalledRequestObject, err := json.Marshal(c.newResource)
+			require.NoError(t, err)
+			s := runtime.NewScheme()
+			s.AddKnownTypes(GroupVersion, &SamenessGroup{}, &SamenessGroupList{})
+			client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
+			decoder, err := admission.NewDecoder(s)
+			require.NoError(t, err)
+
+			validator := &SamenessGroupWebhook{
+				Client:  client,
+				Logger:  logrtest.TestLogger{T: t},
+				decoder: decoder,
+				ConsulMeta: common.ConsulMeta{
+					PartitionsEnabled: true,
+					Partition:         common.DefaultConsulPartition,
+				},
+			}
+			response := validator.Handle(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      c.newResource.KubernetesName(),
+					Namespace: "default",
+					Operation: c.operation,
+					Object: runtime.RawExtension{
+						Raw: marshalledRequestObject,
+					},
+				},
+			})
+
+			require.Equal(t, c.expAllow, response.Allowed)
+			if c.expErrMessage != "" {
+				require.Equal(t, c.expErrMessage, response.AdmissionResponse.Result.Message)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"strings"
@@ -95,6 +96,11 @@ type ServiceDefaultsSpec struct {
 	// Applies to HTTP-based protocols only. If not specified, inherits the Envoy default for
 	// route timeouts (15s).
 	LocalRequestTimeoutMs int `json:"localRequestTimeoutMs,omitempty"`
+	// RateLimits is rate limiting configuration that is applied to
+	// inbound traffic for a service. Rate limiting is a Consul Enterprise feature.
+	RateLimits *RateLimits `json:"rateLimits,omitempty"`
+	// EnvoyExtensions are a list of extensions to modify Envoy proxy configuration.
+	EnvoyExtensions EnvoyExtensions `json:"envoyExtensions,omitempty"`
 }
 
 type Upstreams struct {
@@ -174,6 +180,47 @@ type PassiveHealthCheck struct {
 	EnforcingConsecutive5xx *uint32 `json:"enforcing_consecutive_5xx,omitempty"`
 }
 
+// RateLimits is rate limiting configuration that is applied to
+// inbound traffic for a service.
+type RateLimits struct {
+	// InstanceLevel represents rate limit configuration
+	// that is applied per service instance.
+	InstanceLevel InstanceLevelRateLimits `json:"instanceLevel,omitempty"`
+}
+
+type InstanceLevelRateLimits struct {
+	// RequestsPerSecond is the average number of requests per second that can be
+	// made without being throttled. This field is required if RequestsMaxBurst
+	// is set. The allowed number of requests may exceed RequestsPerSecond up to
+	// the value specified in RequestsMaxBurst.
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+	// RequestsMaxBurst is the maximum number of requests that can be sent
+	// in a burst. Should be equal to or greater than RequestsPerSecond.
+	// If unset, defaults to RequestsPerSecond.
+	RequestsMaxBurst int `json:"requestsMaxBurst,omitempty"`
+	// Routes is a list of rate limits applied to specific routes.
+	// For a given request, the first matching route will be applied, if any.
+	// Overrides any top-level configuration.
+	Routes []InstanceLevelRouteRateLimits `json:"routes,omitempty"`
+}
+
+// InstanceLevelRouteRateLimits is rate limit configuration applied to requests
+// matching exactly one of PathExact, PathPrefix or PathRegex.
+type InstanceLevelRouteRateLimits struct {
+	// PathExact is the exact path to match on the HTTP request path.
+	PathExact string `json:"pathExact,omitempty"`
+	// PathPrefix is the path prefix to match on the HTTP request path.
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	// PathRegex is the regular expression to match on the HTTP request path.
+	PathRegex string `json:"pathRegex,omitempty"`
+	// RequestsPerSecond is the average number of requests per
+	// second that can be made without being throttled.
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+	// RequestsMaxBurst is the maximum number of requests that can be sent in a
+	// burst. Defaults to RequestsPerSecond.
+	RequestsMaxBurst int `json:"requestsMaxBurst,omitempty"`
+}
+
 type ServiceDefaultsDestination struct {
 	// Addresses is a list of IPs and/or hostnames that can be dialed
 	// and routed through a terminating gateway.
@@ -226,15 +273,7 @@ func (in *ServiceDefaults) KubernetesName() string {
 }
 
 func (in *ServiceDefaults) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *ServiceDefaults) SetLastSyncedTime(time *metav1.Time) {
@@ -273,6 +312,8 @@ func (in *ServiceDefaults) ToConsul(datacenter string) capi.ConfigEntry {
 		MaxInboundConnections: in.Spec.MaxInboundConnections,
 		LocalConnectTimeoutMs: in.Spec.LocalConnectTimeoutMs,
 		LocalRequestTimeoutMs: in.Spec.LocalRequestTimeoutMs,
+		RateLimits:            in.Spec.RateLimits.toConsul(),
+		EnvoyExtensions:       in.Spec.EnvoyExtensions.toConsul(),
 	}
 }
 
@@ -313,6 +354,8 @@ func (in *ServiceDefaults) Validate(consulMeta common.ConsulMeta) error {
 
 	allErrs = append(allErrs, in.Spec.UpstreamConfig.validate(path.Child("upstreamConfig"), consulMeta.PartitionsEnabled)...)
 	allErrs = append(allErrs, in.Spec.Expose.validate(path.Child("expose"))...)
+	allErrs = append(allErrs, in.Spec.RateLimits.validate(path.Child("rateLimits"))...)
+	allErrs = append(allErrs, in.Spec.EnvoyExtensions.validate(path.Child("envoyExtensions"))...)
 
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(
@@ -474,6 +517,77 @@ func (in *ServiceDefaultsDestination) toConsul() *capi.DestinationConfig {
 	}
 }
 
+func (in *RateLimits) toConsul() *capi.RateLimits {
+	if in == nil {
+		return nil
+	}
+	var routes []capi.InstanceLevelRouteRateLimits
+	for _, route := range in.InstanceLevel.Routes {
+		routes = append(routes, capi.InstanceLevelRouteRateLimits{
+			PathExact:         route.PathExact,
+			PathPrefix:        route.PathPrefix,
+			PathRegex:         route.PathRegex,
+			RequestsPerSecond: route.RequestsPerSecond,
+			RequestsMaxBurst:  route.RequestsMaxBurst,
+		})
+	}
+	return &capi.RateLimits{
+		InstanceLevel: capi.InstanceLevelRateLimits{
+			RequestsPerSecond: in.InstanceLevel.RequestsPerSecond,
+			RequestsMaxBurst:  in.InstanceLevel.RequestsMaxBurst,
+			Routes:            routes,
+		},
+	}
+}
+
+func (in *RateLimits) validate(path *field.Path) field.ErrorList {
+	if in == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	instancePath := path.Child("instanceLevel")
+	limits := in.InstanceLevel
+
+	// A rate must be set at the top level or on at least one route.
+	rateSet := limits.RequestsPerSecond > 0
+	if limits.RequestsPerSecond < 0 {
+		errs = append(errs, field.Invalid(instancePath.Child("requestsPerSecond"), limits.RequestsPerSecond, "must be > 0"))
+	}
+	if limits.RequestsMaxBurst < 0 {
+		errs = append(errs, field.Invalid(instancePath.Child("requestsMaxBurst"), limits.RequestsMaxBurst, "must be > 0"))
+	} else if limits.RequestsMaxBurst > 0 && limits.RequestsPerSecond <= 0 {
+		errs = append(errs, field.Invalid(instancePath.Child("requestsPerSecond"), limits.RequestsPerSecond, "must be > 0 when requestsMaxBurst is set"))
+	}
+
+	for i, route := range limits.Routes {
+		routePath := instancePath.Child("routes").Index(i)
+		if numNotEmpty(route.PathExact, route.PathPrefix, route.PathRegex) != 1 {
+			asJSON, _ := json.Marshal(route)
+			errs = append(errs, field.Invalid(routePath, string(asJSON), "exactly one of pathExact, pathPrefix, or pathRegex must be configured"))
+		}
+		if route.PathExact != "" && invalidPathPrefix(route.PathExact) {
+			errs = append(errs, field.Invalid(routePath.Child("pathExact"), route.PathExact, "must begin with a '/'"))
+		}
+		if route.PathPrefix != "" && invalidPathPrefix(route.PathPrefix) {
+			errs = append(errs, field.Invalid(routePath.Child("pathPrefix"), route.PathPrefix, "must begin with a '/'"))
+		}
+		// Unlike the top level, every route must set its own rate.
+		if route.RequestsPerSecond <= 0 {
+			errs = append(errs, field.Invalid(routePath.Child("requestsPerSecond"), route.RequestsPerSecond, "must be > 0"))
+		} else {
+			rateSet = true
+		}
+		if route.RequestsMaxBurst < 0 {
+			errs = append(errs, field.Invalid(routePath.Child("requestsMaxBurst"), route.RequestsMaxBurst, "must be > 0"))
+		}
+	}
+
+	if !rateSet && len(errs) == 0 {
+		errs = append(errs, field.Required(instancePath.Child("requestsPerSecond"), "requestsPerSecond must be set at the top level or on at least one route"))
+	}
+	return errs
+}
+
 // DefaultNamespaceFields has no behaviour here as service-defaults have no namespace specific fields.
 func (in *ServiceDefaults) DefaultNamespaceFields(_ common.ConsulMeta) {
 }
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -262,6 +263,121 @@ func TestServiceDefaults_ToConsul(t *testing.T) {
 				},
 			},
 		},
+		"rate limits": {
+			&ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: ServiceDefaultsSpec{
+					RateLimits: &RateLimits{
+						InstanceLevel: InstanceLevelRateLimits{
+							RequestsPerSecond: 100,
+							RequestsMaxBurst:  200,
+							Routes: []InstanceLevelRouteRateLimits{
+								{
+									PathExact:         "/exact",
+									RequestsPerSecond: 10,
+									RequestsMaxBurst:  20,
+								},
+								{
+									PathPrefix:        "/prefix",
+									RequestsPerSecond: 30,
+								},
+								{
+									PathRegex:         "/regex/.*",
+									RequestsPerSecond: 40,
+								},
+							},
+						},
+					},
+				},
+			},
+			&capi.ServiceConfigEntry{
+				Name: "foo",
+				Kind: capi.ServiceDefaults,
+				RateLimits: &capi.RateLimits{
+					InstanceLevel: capi.InstanceLevelRateLimits{
+						RequestsPerSecond: 100,
+						RequestsMaxBurst:  200,
+						Routes: []capi.InstanceLevelRouteRateLimits{
+							{
+								PathExact:         "/exact",
+								RequestsPerSecond: 10,
+								RequestsMaxBurst:  20,
+							},
+							{
+								PathPrefix:        "/prefix",
+								RequestsPerSecond: 30,
+							},
+							{
+								PathRegex:         "/regex/.*",
+								RequestsPerSecond: 40,
+							},
+						},
+					},
+				},
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: "datacenter",
+				},
+			},
+		},
+		"envoy extensions": {
+			&ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: ServiceDefaultsSpec{
+					EnvoyExtensions: EnvoyExtensions{
+						{
+							Name:      "builtin/ext-authz",
+							Required:  true,
+							Arguments: json.RawMessage(`{"Config": {"GrpcService": {"Target": {"Service": {"Name": "authz"}}}}}`),
+						},
+						{
+							Name:         "builtin/otel-access-logging",
+							Arguments:    json.RawMessage(`{"ListenerType": "inbound", "Config": {"GrpcService": {"Target": {"URI": "127.0.0.1:4317"}}}}`),
+							EnvoyVersion: ">= 1.26.0",
+						},
+					},
+				},
+			},
+			&capi.ServiceConfigEntry{
+				Name: "foo",
+				Kind: capi.ServiceDefaults,
+				EnvoyExtensions: []capi.EnvoyExtension{
+					{
+						Name:     "builtin/ext-authz",
+						Required: true,
+						Arguments: map[string]interface{}{
+							"Config": map[string]interface{}{
+								"GrpcService": map[string]interface{}{
+									"Target": map[string]interface{}{
+										"Service": map[string]interface{}{"Name": "authz"},
+									},
+								},
+							},
+						},
+					},
+					{
+						Name: "builtin/otel-access-logging",
+						Arguments: map[string]interface{}{
+							"ListenerType": "inbound",
+							"Config": map[string]interface{}{
+								"GrpcService": map[string]interface{}{
+									"Target": map[string]interface{}{"URI": "127.0.0.1:4317"},
+								},
+							},
+						},
+						EnvoyVersion: ">= 1.26.0",
+					},
+				},
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: "datacenter",
+				},
+			},
+		},
 	}
 
 	for name, testCase := range cases {
@@ -585,6 +701,30 @@ func TestServiceDefaults_MatchesConsul(t *testing.T) {
 			},
 			matches: true,
 		},
+		"rate limits do not match": {
+			internal: &ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-service",
+				},
+				Spec: ServiceDefaultsSpec{
+					RateLimits: &RateLimits{
+						InstanceLevel: InstanceLevelRateLimits{
+							RequestsPerSecond: 100,
+						},
+					},
+				},
+			},
+			consul: &capi.ServiceConfigEntry{
+				Kind: capi.ServiceDefaults,
+				Name: "my-test-service",
+				RateLimits: &capi.RateLimits{
+					InstanceLevel: capi.InstanceLevelRateLimits{
+						RequestsPerSecond: 50,
+					},
+				},
+			},
+			matches: false,
+		},
 	}
 
 	for name, testCase := range cases {
@@ -915,6 +1055,101 @@ func TestServiceDefaults_Validate(t *testing.T) {
 			},
 			expectedErrMsg: `servicedefaults.consul.hashicorp.com "my-service" is invalid: spec.destination.port: Invalid value: 0x0: invalid port number`,
 		},
+		"valid - rate limits": {
+			input: &ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-service",
+				},
+				Spec: ServiceDefaultsSpec{
+					RateLimits: &RateLimits{
+						InstanceLevel: InstanceLevelRateLimits{
+							Routes: []InstanceLevelRouteRateLimits{
+								{
+									PathPrefix:        "/admin",
+									RequestsPerSecond: 10,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrMsg: "",
+		},
+		"rateLimits.instanceLevel without a rate": {
+			input: &ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-service",
+				},
+				Spec: ServiceDefaultsSpec{
+					RateLimits: &RateLimits{},
+				},
+			},
+			expectedErrMsg: `servicedefaults.consul.hashicorp.com "my-service" is invalid: spec.rateLimits.instanceLevel.requestsPerSecond: Required value: requestsPerSecond must be set at the top level or on at least one route`,
+		},
+		"rateLimits.instanceLevel": {
+			input: &ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-service",
+				},
+				Spec: ServiceDefaultsSpec{
+					RateLimits: &RateLimits{
+						InstanceLevel: InstanceLevelRateLimits{
+							RequestsMaxBurst: 100,
+						},
+					},
+				},
+			},
+			expectedErrMsg: `servicedefaults.consul.hashicorp.com "my-service" is invalid: spec.rateLimits.instanceLevel.requestsPerSecond: Invalid value: 0: must be > 0 when requestsMaxBurst is set`,
+		},
+		"rateLimits.instanceLevel.routes": {
+			input: &ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-service",
+				},
+				Spec: ServiceDefaultsSpec{
+					RateLimits: &RateLimits{
+						InstanceLevel: InstanceLevelRateLimits{
+							RequestsPerSecond: 100,
+							Routes: []InstanceLevelRouteRateLimits{
+								{
+									PathExact:         "/exact",
+									PathPrefix:        "/prefix",
+									RequestsPerSecond: 10,
+								},
+								{
+									PathPrefix:       "prefix",
+									RequestsMaxBurst: -1,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrMsg: `servicedefaults.consul.hashicorp.com "my-service" is invalid: [spec.rateLimits.instanceLevel.routes[0]: Invalid value: "{\"pathExact\":\"/exact\",\"pathPrefix\":\"/prefix\",\"requestsPerSecond\":10}": exactly one of pathExact, pathPrefix, or pathRegex must be configured, spec.rateLimits.instanceLevel.routes[1].pathPrefix: Invalid value: "prefix": must begin with a '/', spec.rateLimits.instanceLevel.routes[1].requestsPerSecond: Invalid value: 0: must be > 0, spec.rateLimits.instanceLevel.routes[1].requestsMaxBurst: Invalid value: -1: must be > 0]`,
+		},
+		"envoyExtensions": {
+			input: &ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-service",
+				},
+				Spec: ServiceDefaultsSpec{
+					EnvoyExtensions: EnvoyExtensions{
+						{
+							Name:      "builtin/lua",
+							Arguments: json.RawMessage(`{"Listener": "inbound", "Script": "function envoy_on_request(handle) end"}`),
+						},
+						{
+							Name: "builtin/ext-authz",
+						},
+						{
+							Name:      "custom/extension",
+							Arguments: json.RawMessage(`{}`),
+						},
+					},
+				},
+			},
+			expectedErrMsg: `servicedefaults.consul.hashicorp.com "my-service" is invalid: [spec.envoyExtensions[1].arguments: Required value: arguments must be set, spec.envoyExtensions[2].name: Invalid value: "custom/extension": must be one of "builtin/aws/lambda", "builtin/ext-authz", "builtin/lua", "builtin/otel-access-logging", "builtin/property-override", "builtin/wasm"]`,
+		},
 	}
 
 	for name, testCase := range cases {
@@ -0,0 +1,154 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func init() {
+	SchemeBuilder.Register(&ServiceIntention{}, &ServiceIntentionList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ServiceIntention is the Schema for the serviceintention API. Each resource
+// is a single rule from a source to a destination. The rules of all
+// ServiceIntention resources with the same destination are combined into the
+// destination's service-intentions config entry, so that rules for the same
+// destination can be managed separately, e.g. by the teams owning each source.
+// The resource name is serviceintentionrules since serviceintentions is used
+// by ServiceIntentions.
+// +kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".spec.destination.name",description="The destination of the intention"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source.name",description="The source of the intention"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:path=serviceintentionrules,singular=serviceintention,shortName="service-intention"
+type ServiceIntention struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServiceIntentionSpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceIntentionList contains a list of ServiceIntention.
+type ServiceIntentionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceIntention `json:"items"`
+}
+
+// ServiceIntentionSpec defines the desired state of ServiceIntention.
+type ServiceIntentionSpec struct {
+	// Destination is the intention destination that will have the authorization granted to.
+	// A destination can't be managed by both ServiceIntention and ServiceIntentions resources.
+	Destination IntentionDestination `json:"destination,omitempty"`
+	// Source is the intention source and the authorization granted to it.
+	// Only one ServiceIntention resource can set a given source for a destination.
+	Source SourceIntention `json:"source,omitempty"`
+}
+
+func (in *ServiceIntention) KubeKind() string {
+	return common.ServiceIntention
+}
+
+func (in *ServiceIntention) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *ServiceIntention) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *ServiceIntention) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *ServiceIntention) SyncedCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+func (in *ServiceIntention) SyncedConditionStatus() corev1.ConditionStatus {
+	condition := in.Status.GetCondition(ConditionSynced)
+	if condition == nil {
+		return corev1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+// SourceKey identifies the source of the rule. Two rules for the same
+// destination with the same source key conflict since Consul only allows
+// one intention per source.
+func (in *ServiceIntention) SourceKey() string {
+	s := in.Spec.Source
+	return fmt.Sprintf("peer=%s,partition=%s,samenessGroup=%s,namespace=%s,name=%s", s.Peer, s.Partition, s.SamenessGroup, s.Namespace, s.Name)
+}
+
+// ToConsulSource converts the rule to the Consul source intention it adds to
+// the destination's config entry.
+func (in *ServiceIntention) ToConsulSource() *capi.SourceIntention {
+	return in.Spec.Source.toConsul()
+}
+
+func (in *ServiceIntention) Validate(consulMeta common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+	if in.Spec.Destination.Name == "" {
+		errs = append(errs, field.Required(path.Child("destination").Child("name"), `destination.name must be specified`))
+	}
+	if in.Spec.Source.Name == "" && in.Spec.Source.SamenessGroup == "" {
+		errs = append(errs, field.Required(path.Child("source").Child("name"), `source.name or source.samenessGroup must be specified`))
+	}
+	errs = append(errs, in.Spec.Source.validateAction(path.Child("source"))...)
+	if !consulMeta.NamespacesEnabled && in.Spec.Destination.Namespace != "" {
+		errs = append(errs, field.Invalid(path.Child("destination").Child("namespace"), in.Spec.Destination.Namespace, `Consul Enterprise namespaces must be enabled to set destination.namespace`))
+	}
+	errs = append(errs, in.Spec.Source.validateNamespace(path.Child("source"), consulMeta.NamespacesEnabled)...)
+	errs = append(errs, in.Spec.Source.validatePeerAndPartition(path.Child("source"), consulMeta.PartitionsEnabled)...)
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: common.ServiceIntention},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+// DefaultNamespaceFields sets the namespace field on spec.destination to its
+// default value if namespaces are enabled, in the same way as for
+// ServiceIntentions.
+func (in *ServiceIntention) DefaultNamespaceFields(consulMeta common.ConsulMeta) {
+	if consulMeta.NamespacesEnabled && in.Spec.Destination.Namespace == "" {
+		in.Spec.Destination.Namespace = namespaces.ConsulNamespace(in.Namespace, consulMeta.NamespacesEnabled, consulMeta.DestinationNamespace, consulMeta.Mirroring, consulMeta.Prefix)
+	}
+}
+
+// jwtProviderRefs returns the JWT providers referenced by the source's
+// permissions.
+func (in *ServiceIntention) jwtProviderRefs() []jwtProviderRef {
+	return in.Spec.Source.jwtProviderRefs(field.NewPath("spec").Child("source"))
+}
+
+// samenessGroupRefs returns the SamenessGroup referenced by the source.
+func (in *ServiceIntention) samenessGroupRefs() []samenessGroupRef {
+	if in.Spec.Source.SamenessGroup == "" {
+		return nil
+	}
+	return []samenessGroupRef{{name: in.Spec.Source.SamenessGroup, path: field.NewPath("spec").Child("source").Child("samenessGroup")}}
+}
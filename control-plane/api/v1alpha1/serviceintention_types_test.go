@@ -0,0 +1,132 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceIntention_Validate(t *testing.T) {
+	cases := map[string]struct {
+		input             *ServiceIntention
+		namespacesEnabled bool
+		partitionsEnabled bool
+		expectedErrMsgs   []string
+	}{
+		"valid": {
+			input: &ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-to-db"},
+				Spec: ServiceIntentionSpec{
+					Destination: IntentionDestination{Name: "db", Namespace: "ns"},
+					Source:      SourceIntention{Name: "web", Namespace: "ns", Action: "allow"},
+				},
+			},
+			namespacesEnabled: true,
+		},
+		"valid with permissions": {
+			input: &ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-to-db"},
+				Spec: ServiceIntentionSpec{
+					Destination: IntentionDestination{Name: "db"},
+					Source: SourceIntention{
+						Name: "web",
+						Permissions: IntentionPermissions{
+							{Action: "allow", HTTP: &IntentionHTTPPermission{PathPrefix: "/api"}},
+						},
+					},
+				},
+			},
+		},
+		"missing names": {
+			input: &ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-to-db"},
+				Spec: ServiceIntentionSpec{
+					Source: SourceIntention{Action: "allow"},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.destination.name: Required value: destination.name must be specified`,
+				`spec.source.name: Required value: source.name or source.samenessGroup must be specified`,
+			},
+		},
+		"invalid action": {
+			input: &ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-to-db"},
+				Spec: ServiceIntentionSpec{
+					Destination: IntentionDestination{Name: "db"},
+					Source:      SourceIntention{Name: "web", Action: "fail"},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.source.action: Invalid value: "fail": must be one of "allow", "deny"`,
+			},
+		},
+		"namespaces disabled": {
+			input: &ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-to-db"},
+				Spec: ServiceIntentionSpec{
+					Destination: IntentionDestination{Name: "db", Namespace: "ns"},
+					Source:      SourceIntention{Name: "web", Namespace: "ns", Action: "allow"},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.destination.namespace: Invalid value: "ns": Consul Enterprise namespaces must be enabled to set destination.namespace`,
+				`spec.source.namespace: Invalid value: "ns": Consul Enterprise namespaces must be enabled to set source.namespace`,
+			},
+		},
+		"peer and partition": {
+			input: &ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-to-db"},
+				Spec: ServiceIntentionSpec{
+					Destination: IntentionDestination{Name: "db"},
+					Source:      SourceIntention{Name: "web", Peer: "peer", Partition: "part", Action: "allow"},
+				},
+			},
+			partitionsEnabled: true,
+			expectedErrMsgs: []string{
+				`Both source.peer and source.partition cannot be set.`,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.input.Validate(common.ConsulMeta{NamespacesEnabled: c.namespacesEnabled, PartitionsEnabled: c.partitionsEnabled})
+			if len(c.expectedErrMsgs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, msg := range c.expectedErrMsgs {
+				require.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func TestServiceIntention_SourceKey(t *testing.T) {
+	a := &ServiceIntention{Spec: ServiceIntentionSpec{Source: SourceIntention{Name: "web", Namespace: "ns", Action: "allow"}}}
+	b := &ServiceIntention{Spec: ServiceIntentionSpec{Source: SourceIntention{Name: "web", Namespace: "ns", Action: "deny"}}}
+	c := &ServiceIntention{Spec: ServiceIntentionSpec{Source: SourceIntention{Name: "web", Peer: "ns", Action: "allow"}}}
+	require.Equal(t, a.SourceKey(), b.SourceKey())
+	require.NotEqual(t, a.SourceKey(), c.SourceKey())
+}
+
+func TestServiceIntention_DefaultNamespaceFields(t *testing.T) {
+	input := &ServiceIntention{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-to-db", Namespace: "kube-ns"},
+		Spec: ServiceIntentionSpec{
+			Destination: IntentionDestination{Name: "db"},
+			Source:      SourceIntention{Name: "web", Action: "allow"},
+		},
+	}
+	disabled := input.DeepCopy()
+	disabled.DefaultNamespaceFields(common.ConsulMeta{})
+	require.Equal(t, "", disabled.Spec.Destination.Namespace)
+
+	mirrored := input.DeepCopy()
+	mirrored.DefaultNamespaceFields(common.ConsulMeta{NamespacesEnabled: true, Mirroring: true, Prefix: "k8s-"})
+	require.Equal(t, "k8s-kube-ns", mirrored.Spec.Destination.Namespace)
+	require.Equal(t, "", mirrored.Spec.Source.Namespace)
+}
@@ -0,0 +1,142 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type ServiceIntentionWebhook struct {
+	client.Client
+	Logger     logr.Logger
+	decoder    *admission.Decoder
+	ConsulMeta common.ConsulMeta
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is
+// it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-serviceintention,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=serviceintentionrules,versions=v1alpha1,name=mutate-serviceintention.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *ServiceIntentionWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var svcIntention ServiceIntention
+	err := v.decoder.Decode(req, &svcIntention)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	beforeDefaulting, err := json.Marshal(svcIntention)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	svcIntention.DefaultNamespaceFields(v.ConsulMeta)
+	afterDefaulting, err := json.Marshal(svcIntention)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	defaultingPatches, err := jsonpatch.CreatePatch(beforeDefaulting, afterDefaulting)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if req.Operation == admissionv1.Create {
+		v.Logger.Info("validate create", "name", svcIntention.KubernetesName())
+
+		// A destination is managed either by a single ServiceIntentions
+		// resource or by ServiceIntention rules, but not both.
+		var svcIntentionsList ServiceIntentionsList
+		if err := v.Client.List(ctx, &svcIntentionsList); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		for _, item := range svcIntentionsList.Items {
+			if sameIntentionDestination(v.ConsulMeta, item.Spec.Destination, svcIntention.Spec.Destination) {
+				return admission.Errored(http.StatusBadRequest,
+					fmt.Errorf("the destination is managed by the ServiceIntentions resource %s/%s", item.Namespace, item.Name))
+			}
+		}
+	} else if req.Operation == admissionv1.Update {
+		v.Logger.Info("validate update", "name", svcIntention.KubernetesName())
+		var prevIntention ServiceIntention
+		if err := v.decoder.DecodeRaw(*req.OldObject.DeepCopy(), &prevIntention); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+
+		// The destination can't be updated so that the rule isn't left in
+		// the config entry of the previous destination.
+		if prevIntention.Spec.Destination != svcIntention.Spec.Destination {
+			return admission.Errored(http.StatusBadRequest, errors.New("spec.destination.name and spec.destination.namespace are immutable fields for ServiceIntention"))
+		}
+	}
+
+	if err := svcIntention.Validate(v.ConsulMeta); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// JWT requirements must reference existing JWTProvider resources.
+	errs, err := missingJWTProviders(ctx, v.Client, svcIntention.jwtProviderRefs())
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: common.ServiceIntention},
+			svcIntention.KubernetesName(), errs))
+	}
+
+	// The source must reference an existing SamenessGroup resource.
+	errs, err = missingSamenessGroups(ctx, v.Client, svcIntention.samenessGroupRefs())
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: common.ServiceIntention},
+			svcIntention.KubernetesName(), errs))
+	}
+
+	resp := admission.Patched(fmt.Sprintf("valid %s request", svcIntention.KubeKind()), defaultingPatches...)
+	if !crossResourceValidationEnabled(v.ConsulMeta) {
+		return resp
+	}
+
+	// L7 permissions require the destination to have an L7 protocol.
+	errs, err = validateIntentionPermissionProtocol(ctx, v.Client, v.ConsulMeta, svcIntention.Namespace, svcIntention.Spec.Destination,
+		[]*SourceIntention{&svcIntention.Spec.Source}, []*field.Path{field.NewPath("spec").Child("source")})
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return crossResourceResponse(v.ConsulMeta, resp, common.ServiceIntention, svcIntention.KubernetesName(), errs)
+}
+
+func (v *ServiceIntentionWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// sameIntentionDestination returns true if both destinations are written to
+// the same config entry in Consul. Unless namespace mirroring is enabled all
+// config entries are written to the same Consul namespace.
+func sameIntentionDestination(consulMeta common.ConsulMeta, a, b IntentionDestination) bool {
+	if a.Name != b.Name {
+		return false
+	}
+	return !(consulMeta.NamespacesEnabled && consulMeta.Mirroring) || a.Namespace == b.Namespace
+}
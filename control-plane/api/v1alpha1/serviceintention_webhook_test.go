@@ -0,0 +1,166 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestHandle_ServiceIntention(t *testing.T) {
+	rule := func(destination string, sameness string) *ServiceIntention {
+		return &ServiceIntention{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-to-" + destination, Namespace: "default"},
+			Spec: ServiceIntentionSpec{
+				Destination: IntentionDestination{Name: destination},
+				Source:      SourceIntention{Name: "web", SamenessGroup: sameness, Action: "allow"},
+			},
+		}
+	}
+	cases := map[string]struct {
+		existingResources []runtime.Object
+		operation         admissionv1.Operation
+		oldResource       *ServiceIntention
+		newResource       *ServiceIntention
+		namespacesEnabled bool
+		expAllow          bool
+		expErrMessage     string
+		expPatches        []jsonpatch.Operation
+	}{
+		"valid": {
+			operation:   admissionv1.Create,
+			newResource: rule("db", ""),
+			expAllow:    true,
+		},
+		"defaults destination namespace": {
+			operation:         admissionv1.Create,
+			newResource:       rule("db", ""),
+			namespacesEnabled: true,
+			expAllow:          true,
+			expPatches: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/destination/namespace", Value: "default"},
+			},
+		},
+		"invalid rule": {
+			operation: admissionv1.Create,
+			newResource: &ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-to-db", Namespace: "default"},
+				Spec: ServiceIntentionSpec{
+					Destination: IntentionDestination{Name: "db"},
+					Source:      SourceIntention{Name: "web", Action: "fail"},
+				},
+			},
+			expAllow:      false,
+			expErrMessage: `serviceintention.consul.hashicorp.com "web-to-db" is invalid: spec.source.action: Invalid value: "fail": must be one of "allow", "deny"`,
+		},
+		"destination managed by ServiceIntentions": {
+			existingResources: []runtime.Object{&ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{Name: "db"},
+					Sources:     SourceIntentions{{Name: "api", Action: "allow"}},
+				},
+			}},
+			operation:     admissionv1.Create,
+			newResource:   rule("db", ""),
+			expAllow:      false,
+			expErrMessage: "the destination is managed by the ServiceIntentions resource default/db",
+		},
+		"other destination managed by ServiceIntentions": {
+			existingResources: []runtime.Object{&ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{Name: "api"},
+					Sources:     SourceIntentions{{Name: "web", Action: "allow"}},
+				},
+			}},
+			operation:   admissionv1.Create,
+			newResource: rule("db", ""),
+			expAllow:    true,
+		},
+		"sameness group not found": {
+			operation:         admissionv1.Create,
+			newResource:       rule("db", "group"),
+			namespacesEnabled: true,
+			expAllow:          false,
+			expErrMessage:     `serviceintention.consul.hashicorp.com "web-to-db" is invalid: spec.source.samenessGroup: Not found: "group"`,
+		},
+		"updating destination": {
+			operation:     admissionv1.Update,
+			oldResource:   rule("db", ""),
+			newResource:   rule("api", ""),
+			expAllow:      false,
+			expErrMessage: "spec.destination.name and spec.destination.namespace are immutable fields for ServiceIntention",
+		},
+		"updating source": {
+			operation:   admissionv1.Update,
+			oldResource: rule("db", ""),
+			newResource: &ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-to-db", Namespace: "default"},
+				Spec: ServiceIntentionSpec{
+					Destination: IntentionDestination{Name: "db"},
+					Source:      SourceIntention{Name: "web", Action: "deny"},
+				},
+			},
+			expAllow: true,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			marshalledRequestObject, err := json.Marshal(c.newResource)
+			require.NoError(t, err)
+			var marshalledOldObject []byte
+			if c.oldResource != nil {
+				marshalledOldObject, err = json.Marshal(c.oldResource)
+				require.NoError(t, err)
+			}
+			s := runtime.NewScheme()
+			s.AddKnownTypes(GroupVersion, &ServiceIntention{}, &ServiceIntentionList{}, &ServiceIntentions{}, &ServiceIntentionsList{}, &JWTProvider{}, &JWTProviderList{}, &SamenessGroup{}, &SamenessGroupList{})
+			client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
+			decoder, err := admission.NewDecoder(s)
+			require.NoError(t, err)
+
+			validator := &ServiceIntentionWebhook{
+				Client:  client,
+				Logger:  logrtest.TestLogger{T: t},
+				decoder: decoder,
+				ConsulMeta: common.ConsulMeta{
+					NamespacesEnabled: c.namespacesEnabled,
+					Mirroring:         c.namespacesEnabled,
+					PartitionsEnabled: c.namespacesEnabled,
+				},
+			}
+			response := validator.Handle(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      c.newResource.KubernetesName(),
+					Namespace: c.newResource.Namespace,
+					Operation: c.operation,
+					Object: runtime.RawExtension{
+						Raw: marshalledRequestObject,
+					},
+					OldObject: runtime.RawExtension{
+						Raw: marshalledOldObject,
+					},
+				},
+			})
+
+			require.Equal(t, c.expAllow, response.Allowed)
+			if c.expErrMessage != "" {
+				require.Equal(t, c.expErrMessage, response.AdmissionResponse.Result.Message)
+			}
+			if c.expAllow {
+				require.ElementsMatch(t, c.expPatches, response.Patches)
+			}
+		})
+	}
+}
@@ -55,6 +55,9 @@ type ServiceIntentionsSpec struct {
 	// The order of this list does not matter, but out of convenience Consul will always store this
 	// reverse sorted by intention precedence, as that is the order that they will be evaluated at enforcement time.
 	Sources SourceIntentions `json:"sources,omitempty"`
+	// JWT specifies the JWT requirements that all incoming requests to the
+	// destination must satisfy before the source intentions are evaluated.
+	JWT *IntentionJWTRequirement `json:"jwt,omitempty"`
 }
 
 type IntentionDestination struct {
@@ -82,6 +85,8 @@ type SourceIntention struct {
 	Peer string `json:"peer,omitempty"`
 	// Partition is the Admin Partition for the Name parameter.
 	Partition string `json:"partition,omitempty"`
+	// SamenessGroup is the name of the sameness group, if applicable.
+	SamenessGroup string `json:"samenessGroup,omitempty"`
 	// Action is required for an L4 intention, and should be set to one of
 	// "allow" or "deny" for the action that should be taken if this intention matches a request.
 	Action IntentionAction `json:"action,omitempty"`
@@ -102,6 +107,9 @@ type IntentionPermission struct {
 	Action IntentionAction `json:"action,omitempty"`
 	// HTTP is a set of HTTP-specific authorization criteria.
 	HTTP *IntentionHTTPPermission `json:"http,omitempty"`
+	// JWT specifies the JWT requirements a request must satisfy for this
+	// permission to match.
+	JWT *IntentionJWTRequirement `json:"jwt,omitempty"`
 }
 
 type IntentionHTTPPermission struct {
@@ -136,6 +144,28 @@ type IntentionHTTPHeaderPermission struct {
 	Invert bool `json:"invert,omitempty"`
 }
 
+type IntentionJWTRequirement struct {
+	// Providers is a list of providers to consider when verifying a JWT.
+	Providers []*IntentionJWTProvider `json:"providers,omitempty"`
+}
+
+type IntentionJWTProvider struct {
+	// Name is the name of the JWT provider. There MUST be a corresponding
+	// JWTProvider resource with this name.
+	Name string `json:"name,omitempty"`
+	// VerifyClaims is a list of additional claims to verify in a JWT's payload.
+	VerifyClaims []*IntentionJWTClaimVerification `json:"verifyClaims,omitempty"`
+}
+
+type IntentionJWTClaimVerification struct {
+	// Path is the path to the claim in the token JSON.
+	Path []string `json:"path,omitempty"`
+	// Value is the expected value at the given path. If the type at the path
+	// is a list then this value must be contained in the list. If the type at
+	// the path is a string then this value must match.
+	Value string `json:"value,omitempty"`
+}
+
 // IntentionAction is the action that the intention represents. This
 // can be "allow" or "deny" to allowlist or denylist intentions.
 type IntentionAction string
@@ -183,15 +213,7 @@ func (in *ServiceIntentions) KubernetesName() string {
 }
 
 func (in *ServiceIntentions) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *ServiceIntentions) SetLastSyncedTime(time *metav1.Time) {
@@ -220,6 +242,7 @@ func (in *ServiceIntentions) ToConsul(datacenter string) api.ConfigEntry {
 		Name:      in.Spec.Destination.Name,
 		Namespace: in.Spec.Destination.Namespace,
 		Sources:   in.Spec.Sources.toConsul(),
+		JWT:       in.Spec.JWT.toConsul(),
 		Meta:      meta(datacenter),
 	}
 }
@@ -259,16 +282,11 @@ func (in *ServiceIntentions) Validate(consulMeta common.ConsulMeta) error {
 		errs = append(errs, field.Required(path.Child("sources"), `at least one source must be specified`))
 	}
 	for i, source := range in.Spec.Sources {
-		if len(source.Permissions) > 0 && source.Action != "" {
-			asJSON, _ := json.Marshal(source)
-			errs = append(errs, field.Invalid(path.Child("sources").Index(i), string(asJSON), `action and permissions are mutually exclusive and only one of them can be specified`))
-		} else if len(source.Permissions) == 0 {
-			if err := source.Action.validate(path.Child("sources").Index(i)); err != nil {
-				errs = append(errs, err)
-			}
-		} else {
-			errs = append(errs, source.Permissions.validate(path.Child("sources").Index(i))...)
-		}
+		errs = append(errs, source.validateAction(path.Child("sources").Index(i))...)
+	}
+
+	if in.Spec.JWT != nil {
+		errs = append(errs, in.Spec.JWT.validate(path.Child("jwt"))...)
 	}
 
 	errs = append(errs, in.validateNamespaces(consulMeta.NamespacesEnabled)...)
@@ -310,13 +328,14 @@ func (in *SourceIntention) toConsul() *capi.SourceIntention {
 		return nil
 	}
 	return &capi.SourceIntention{
-		Name:        in.Name,
-		Namespace:   in.Namespace,
-		Partition:   in.Partition,
-		Peer:        in.Peer,
-		Action:      in.Action.toConsul(),
-		Permissions: in.Permissions.toConsul(),
-		Description: in.Description,
+		Name:          in.Name,
+		Namespace:     in.Namespace,
+		Partition:     in.Partition,
+		Peer:          in.Peer,
+		SamenessGroup: in.SamenessGroup,
+		Action:        in.Action.toConsul(),
+		Permissions:   in.Permissions.toConsul(),
+		Description:   in.Description,
 	}
 }
 
@@ -330,6 +349,7 @@ func (in IntentionPermissions) toConsul() []*capi.IntentionPermission {
 		consulIntentionPermissions = append(consulIntentionPermissions, &capi.IntentionPermission{
 			Action: permission.Action.toConsul(),
 			HTTP:   permission.HTTP.toConsul(),
+			JWT:    permission.JWT.toConsul(),
 		})
 	}
 	return consulIntentionPermissions
@@ -348,6 +368,27 @@ func (in *IntentionHTTPPermission) toConsul() *capi.IntentionHTTPPermission {
 	}
 }
 
+func (in *IntentionJWTRequirement) toConsul() *capi.IntentionJWTRequirement {
+	if in == nil {
+		return nil
+	}
+	var providers []*capi.IntentionJWTProvider
+	for _, provider := range in.Providers {
+		var claims []*capi.IntentionJWTClaimVerification
+		for _, claim := range provider.VerifyClaims {
+			claims = append(claims, &capi.IntentionJWTClaimVerification{
+				Path:  claim.Path,
+				Value: claim.Value,
+			})
+		}
+		providers = append(providers, &capi.IntentionJWTProvider{
+			Name:         provider.Name,
+			VerifyClaims: claims,
+		})
+	}
+	return &capi.IntentionJWTRequirement{Providers: providers}
+}
+
 func (in IntentionHTTPHeaderPermissions) toConsul() []capi.IntentionHTTPHeaderPermission {
 	var headerPermissions []capi.IntentionHTTPHeaderPermission
 	for _, permission := range in {
@@ -374,6 +415,9 @@ func (in IntentionPermissions) validate(path *field.Path) field.ErrorList {
 		if permission.HTTP != nil {
 			errs = append(errs, permission.HTTP.validate(path.Child("permissions").Index(i))...)
 		}
+		if permission.JWT != nil {
+			errs = append(errs, permission.JWT.validate(path.Child("permissions").Index(i).Child("jwt"))...)
+		}
 	}
 	return errs
 }
@@ -442,6 +486,83 @@ func (in IntentionHTTPHeaderPermissions) validate(path *field.Path) field.ErrorL
 	return errs
 }
 
+func (in *IntentionJWTRequirement) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, provider := range in.Providers {
+		if provider == nil || provider.Name == "" {
+			errs = append(errs, field.Required(path.Child("providers").Index(i).Child("name"), "name must be set"))
+			continue
+		}
+		for j, claim := range provider.VerifyClaims {
+			if claim == nil || len(claim.Path) == 0 {
+				errs = append(errs, field.Required(path.Child("providers").Index(i).Child("verifyClaims").Index(j).Child("path"), "path must be set"))
+			}
+		}
+	}
+	return errs
+}
+
+// jwtProviderRef is a reference to a JWTProvider from a JWT requirement.
+type jwtProviderRef struct {
+	name string
+	path *field.Path
+}
+
+// jwtProviderRefs returns every JWT provider referenced by the intentions in
+// the order they appear in the spec.
+func (in *ServiceIntentions) jwtProviderRefs() []jwtProviderRef {
+	var refs []jwtProviderRef
+	path := field.NewPath("spec")
+	refs = append(refs, in.Spec.JWT.jwtProviderRefs(path.Child("jwt"))...)
+	for i, source := range in.Spec.Sources {
+		refs = append(refs, source.jwtProviderRefs(path.Child("sources").Index(i))...)
+	}
+	return refs
+}
+
+// jwtProviderRefs returns the JWT providers referenced by the permissions of
+// the source.
+func (in *SourceIntention) jwtProviderRefs(path *field.Path) []jwtProviderRef {
+	if in == nil {
+		return nil
+	}
+	var refs []jwtProviderRef
+	for i, permission := range in.Permissions {
+		if permission == nil {
+			continue
+		}
+		refs = append(refs, permission.JWT.jwtProviderRefs(path.Child("permissions").Index(i).Child("jwt"))...)
+	}
+	return refs
+}
+
+func (in *IntentionJWTRequirement) jwtProviderRefs(path *field.Path) []jwtProviderRef {
+	if in == nil {
+		return nil
+	}
+	var refs []jwtProviderRef
+	for i, provider := range in.Providers {
+		if provider == nil || provider.Name == "" {
+			continue
+		}
+		refs = append(refs, jwtProviderRef{name: provider.Name, path: path.Child("providers").Index(i).Child("name")})
+	}
+	return refs
+}
+
+// samenessGroupRefs returns every SamenessGroup referenced by the sources of
+// the intentions.
+func (in *ServiceIntentions) samenessGroupRefs() []samenessGroupRef {
+	var refs []samenessGroupRef
+	for i, source := range in.Spec.Sources {
+		if source == nil || source.SamenessGroup == "" {
+			continue
+		}
+		refs = append(refs, samenessGroupRef{name: source.SamenessGroup, path: field.NewPath("spec").Child("sources").Index(i).Child("samenessGroup")})
+	}
+	return refs
+}
+
 func (in *ServiceIntentions) validateNamespaces(namespacesEnabled bool) field.ErrorList {
 	var errs field.ErrorList
 	path := field.NewPath("spec")
@@ -450,9 +571,7 @@ func (in *ServiceIntentions) validateNamespaces(namespacesEnabled bool) field.Er
 			errs = append(errs, field.Invalid(path.Child("destination").Child("namespace"), in.Spec.Destination.Namespace, `Consul Enterprise namespaces must be enabled to set destination.namespace`))
 		}
 		for i, source := range in.Spec.Sources {
-			if source.Namespace != "" {
-				errs = append(errs, field.Invalid(path.Child("sources").Index(i).Child("namespace"), source.Namespace, `Consul Enterprise namespaces must be enabled to set source.namespace`))
-			}
+			errs = append(errs, source.validateNamespace(path.Child("sources").Index(i), namespacesEnabled)...)
 		}
 	}
 	return errs
@@ -462,13 +581,50 @@ func (in *ServiceIntentions) validateSourcePeerAndPartitions(partitionsEnabled b
 	var errs field.ErrorList
 	path := field.NewPath("spec")
 	for i, source := range in.Spec.Sources {
-		if source.Partition != "" && !partitionsEnabled {
-			errs = append(errs, field.Invalid(path.Child("sources").Index(i).Child("partition"), source.Partition, `Consul Enterprise Admin Partitions must be enabled to set source.partition`))
-		}
+		errs = append(errs, source.validatePeerAndPartition(path.Child("sources").Index(i), partitionsEnabled)...)
+	}
+	return errs
+}
 
-		if source.Peer != "" && source.Partition != "" {
-			errs = append(errs, field.Invalid(path.Child("sources").Index(i), source, `Both source.peer and source.partition cannot be set.`))
+// validateAction validates that the source has either a valid action or
+// valid permissions.
+func (in *SourceIntention) validateAction(path *field.Path) field.ErrorList {
+	if len(in.Permissions) > 0 && in.Action != "" {
+		asJSON, _ := json.Marshal(in)
+		return field.ErrorList{field.Invalid(path, string(asJSON), `action and permissions are mutually exclusive and only one of them can be specified`)}
+	}
+	if len(in.Permissions) == 0 {
+		if err := in.Action.validate(path); err != nil {
+			return field.ErrorList{err}
 		}
+		return nil
+	}
+	return in.Permissions.validate(path)
+}
+
+func (in *SourceIntention) validateNamespace(path *field.Path, namespacesEnabled bool) field.ErrorList {
+	if !namespacesEnabled && in.Namespace != "" {
+		return field.ErrorList{field.Invalid(path.Child("namespace"), in.Namespace, `Consul Enterprise namespaces must be enabled to set source.namespace`)}
+	}
+	return nil
+}
+
+func (in *SourceIntention) validatePeerAndPartition(path *field.Path, partitionsEnabled bool) field.ErrorList {
+	var errs field.ErrorList
+	if in.Partition != "" && !partitionsEnabled {
+		errs = append(errs, field.Invalid(path.Child("partition"), in.Partition, `Consul Enterprise Admin Partitions must be enabled to set source.partition`))
+	}
+
+	if in.Peer != "" && in.Partition != "" {
+		errs = append(errs, field.Invalid(path, in, `Both source.peer and source.partition cannot be set.`))
+	}
+
+	if in.SamenessGroup != "" && !partitionsEnabled {
+		errs = append(errs, field.Invalid(path.Child("samenessGroup"), in.SamenessGroup, `Consul Enterprise Admin Partitions must be enabled to set source.samenessGroup`))
+	}
+
+	if in.SamenessGroup != "" && (in.Peer != "" || in.Partition != "") {
+		errs = append(errs, field.Invalid(path, in, `source.samenessGroup cannot be set with source.peer or source.partition.`))
 	}
 	return errs
 }
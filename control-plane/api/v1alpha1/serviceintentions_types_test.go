@@ -297,6 +297,13 @@ func TestServiceIntentions_ToConsul(t *testing.T) {
 							},
 							Description: "an L7 config",
 						},
+						{
+							Name:          "svc-3",
+							Namespace:     "baz",
+							SamenessGroup: "group",
+							Action:        "allow",
+							Description:   "allow access from the sameness group",
+						},
 					},
 				},
 			},
@@ -350,6 +357,81 @@ func TestServiceIntentions_ToConsul(t *testing.T) {
 						},
 						Description: "an L7 config",
 					},
+					{
+						Name:          "svc-3",
+						Namespace:     "baz",
+						SamenessGroup: "group",
+						Action:        "allow",
+						Description:   "allow access from the sameness group",
+					},
+				},
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: "datacenter",
+				},
+			},
+		},
+		"jwt requirements": {
+			Ours: ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "name",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "svc-name",
+					},
+					JWT: &IntentionJWTRequirement{
+						Providers: []*IntentionJWTProvider{
+							{
+								Name: "okta",
+								VerifyClaims: []*IntentionJWTClaimVerification{
+									{Path: []string{"perms", "role"}, Value: "admin"},
+								},
+							},
+						},
+					},
+					Sources: []*SourceIntention{
+						{
+							Name: "svc1",
+							Permissions: IntentionPermissions{
+								{
+									Action: "allow",
+									HTTP:   &IntentionHTTPPermission{PathPrefix: "/admin"},
+									JWT: &IntentionJWTRequirement{
+										Providers: []*IntentionJWTProvider{{Name: "auth0"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Exp: &capi.ServiceIntentionsConfigEntry{
+				Kind: capi.ServiceIntentions,
+				Name: "svc-name",
+				JWT: &capi.IntentionJWTRequirement{
+					Providers: []*capi.IntentionJWTProvider{
+						{
+							Name: "okta",
+							VerifyClaims: []*capi.IntentionJWTClaimVerification{
+								{Path: []string{"perms", "role"}, Value: "admin"},
+							},
+						},
+					},
+				},
+				Sources: []*capi.SourceIntention{
+					{
+						Name: "svc1",
+						Permissions: []*capi.IntentionPermission{
+							{
+								Action: "allow",
+								HTTP:   &capi.IntentionHTTPPermission{PathPrefix: "/admin"},
+								JWT: &capi.IntentionJWTRequirement{
+									Providers: []*capi.IntentionJWTProvider{{Name: "auth0"}},
+								},
+							},
+						},
+					},
 				},
 				Meta: map[string]string{
 					common.SourceKey:     common.SourceValue,
@@ -1340,7 +1422,7 @@ func TestServiceIntentions_Validate(t *testing.T) {
 			namespacesEnabled: true,
 			partitionsEnabled: true,
 			expectedErrMsgs: []string{
-				`spec.sources[0]: Invalid value: v1alpha1.SourceIntention{Name:"web", Namespace:"namespace-b", Peer:"peer-other", Partition:"partition-other", Action:"allow", Permissions:v1alpha1.IntentionPermissions(nil), Description:""}: Both source.peer and source.partition cannot be set.`,
+				`spec.sources[0]: Invalid value: v1alpha1.SourceIntention{Name:"web", Namespace:"namespace-b", Peer:"peer-other", Partition:"partition-other", SamenessGroup:"", Action:"allow", Permissions:v1alpha1.IntentionPermissions(nil), Description:""}: Both source.peer and source.partition cannot be set.`,
 			},
 		},
 		"multiple source peer and partition specified": {
@@ -1374,8 +1456,92 @@ func TestServiceIntentions_Validate(t *testing.T) {
 			namespacesEnabled: true,
 			partitionsEnabled: true,
 			expectedErrMsgs: []string{
-				`spec.sources[0]: Invalid value: v1alpha1.SourceIntention{Name:"web", Namespace:"namespace-b", Peer:"peer-other", Partition:"partition-other", Action:"allow", Permissions:v1alpha1.IntentionPermissions(nil), Description:""}: Both source.peer and source.partition cannot be set.`,
-				`spec.sources[1]: Invalid value: v1alpha1.SourceIntention{Name:"db", Namespace:"namespace-c", Peer:"peer-2", Partition:"partition-2", Action:"deny", Permissions:v1alpha1.IntentionPermissions(nil), Description:""}: Both source.peer and source.partition cannot be set.`,
+				`spec.sources[0]: Invalid value: v1alpha1.SourceIntention{Name:"web", Namespace:"namespace-b", Peer:"peer-other", Partition:"partition-other", SamenessGroup:"", Action:"allow", Permissions:v1alpha1.IntentionPermissions(nil), Description:""}: Both source.peer and source.partition cannot be set.`,
+				`spec.sources[1]: Invalid value: v1alpha1.SourceIntention{Name:"db", Namespace:"namespace-c", Peer:"peer-2", Partition:"partition-2", SamenessGroup:"", Action:"deny", Permissions:v1alpha1.IntentionPermissions(nil), Description:""}: Both source.peer and source.partition cannot be set.`,
+			},
+		},
+		"source sameness group with peer": {
+			input: &ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "does-not-matter",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "dest-service",
+					},
+					Sources: SourceIntentions{
+						{
+							Name:          "web",
+							Action:        "allow",
+							SamenessGroup: "group",
+							Peer:          "peer-other",
+						},
+					},
+				},
+			},
+			partitionsEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.sources[0]: Invalid value: v1alpha1.SourceIntention{Name:"web", Namespace:"", Peer:"peer-other", Partition:"", SamenessGroup:"group", Action:"allow", Permissions:v1alpha1.IntentionPermissions(nil), Description:""}: source.samenessGroup cannot be set with source.peer or source.partition.`,
+			},
+		},
+		"source sameness group when partitions are disabled": {
+			input: &ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "does-not-matter",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "dest-service",
+					},
+					Sources: SourceIntentions{
+						{
+							Name:          "web",
+							Action:        "allow",
+							SamenessGroup: "group",
+						},
+					},
+				},
+			},
+			partitionsEnabled: false,
+			expectedErrMsgs: []string{
+				`spec.sources[0].samenessGroup: Invalid value: "group": Consul Enterprise Admin Partitions must be enabled to set source.samenessGroup`,
+			},
+		},
+		"jwt providers without names": {
+			input: &ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "does-not-matter",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "dest-service",
+					},
+					JWT: &IntentionJWTRequirement{
+						Providers: []*IntentionJWTProvider{
+							{
+								Name:         "okta",
+								VerifyClaims: []*IntentionJWTClaimVerification{{Value: "admin"}},
+							},
+						},
+					},
+					Sources: SourceIntentions{
+						{
+							Name: "web",
+							Permissions: IntentionPermissions{
+								{
+									Action: "allow",
+									JWT: &IntentionJWTRequirement{
+										Providers: []*IntentionJWTProvider{{}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.jwt.providers[0].verifyClaims[0].path: Required value: path must be set`,
+				`spec.sources[0].permissions[0].jwt.providers[0].name: Required value: name must be set`,
 			},
 		},
 	}
@@ -9,6 +9,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/consul-k8s/control-plane/api/common"
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -67,6 +70,19 @@ func (v *ServiceIntentionsWebhook) Handle(ctx context.Context, req admission.Req
 					fmt.Errorf("an existing ServiceIntentions resource has `spec.destination.name: %s` and `spec.destination.namespace: %s`", svcIntentions.Spec.Destination.Name, svcIntentions.Spec.Destination.Namespace))
 			}
 		}
+
+		// A destination is managed either by a single ServiceIntentions
+		// resource or by ServiceIntention rules, but not both.
+		var svcIntentionList ServiceIntentionList
+		if err := v.Client.List(ctx, &svcIntentionList); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		for _, item := range svcIntentionList.Items {
+			if sameIntentionDestination(v.ConsulMeta, item.Spec.Destination, svcIntentions.Spec.Destination) {
+				return admission.Errored(http.StatusBadRequest,
+					fmt.Errorf("the destination is managed by ServiceIntention resources, e.g. %s/%s", item.Namespace, item.Name))
+			}
+		}
 	} else if req.Operation == admissionv1.Update {
 		v.Logger.Info("validate update", "name", svcIntentions.KubernetesName())
 		var prevIntention, newIntention ServiceIntentions
@@ -88,10 +104,46 @@ func (v *ServiceIntentionsWebhook) Handle(ctx context.Context, req admission.Req
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	// JWT requirements must reference existing JWTProvider resources.
+	errs, err := missingJWTProviders(ctx, v.Client, svcIntentions.jwtProviderRefs())
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: common.ServiceIntentions},
+			svcIntentions.KubernetesName(), errs))
+	}
+
+	// Sources must reference existing SamenessGroup resources.
+	errs, err = missingSamenessGroups(ctx, v.Client, svcIntentions.samenessGroupRefs())
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: common.ServiceIntentions},
+			svcIntentions.KubernetesName(), errs))
+	}
+
 	// We always return an admission.Patched() response, even if there are no patches, since
 	// admission.Patched() with no patches is equal to admission.Allowed() under
 	// the hood.
-	return admission.Patched(fmt.Sprintf("valid %s request", svcIntentions.KubeKind()), defaultingPatches...)
+	resp := admission.Patched(fmt.Sprintf("valid %s request", svcIntentions.KubeKind()), defaultingPatches...)
+	if !crossResourceValidationEnabled(v.ConsulMeta) {
+		return resp
+	}
+
+	// L7 permissions require the destination to have an L7 protocol.
+	var paths []*field.Path
+	for i := range svcIntentions.Spec.Sources {
+		paths = append(paths, field.NewPath("spec").Child("sources").Index(i))
+	}
+	errs, err = validateIntentionPermissionProtocol(ctx, v.Client, v.ConsulMeta, svcIntentions.Namespace, svcIntentions.Spec.Destination, svcIntentions.Spec.Sources, paths)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return crossResourceResponse(v.ConsulMeta, resp, common.ServiceIntentions, svcIntentions.KubernetesName(), errs)
 }
 
 func (v *ServiceIntentionsWebhook) InjectDecoder(d *admission.Decoder) error {
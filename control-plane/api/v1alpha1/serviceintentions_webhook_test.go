@@ -26,6 +26,7 @@ func TestHandle_ServiceIntentions_Create(t *testing.T) {
 		expAllow          bool
 		expErrMessage     string
 		mirror            bool
+		partitionsEnabled bool
 	}{
 		"no duplicates, valid": {
 			existingResources: nil,
@@ -236,6 +237,173 @@ func TestHandle_ServiceIntentions_Create(t *testing.T) {
 			mirror:        false,
 			expErrMessage: "an existing ServiceIntentions resource has `spec.destination.name: foo`",
 		},
+		"destination managed by ServiceIntention resources": {
+			existingResources: []runtime.Object{&ServiceIntention{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar-to-foo",
+					Namespace: otherNS,
+				},
+				Spec: ServiceIntentionSpec{
+					Destination: IntentionDestination{
+						Name: "foo",
+					},
+					Source: SourceIntention{
+						Name:   "bar",
+						Action: "allow",
+					},
+				},
+			}},
+			newResource: &ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo-intention",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "foo",
+					},
+					Sources: SourceIntentions{
+						{
+							Name:   "baz",
+							Action: "allow",
+						},
+					},
+				},
+			},
+			expAllow:      false,
+			mirror:        false,
+			expErrMessage: "the destination is managed by ServiceIntention resources, e.g. other/bar-to-foo",
+		},
+		"jwt providers exist": {
+			existingResources: []runtime.Object{&JWTProvider{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "okta",
+				},
+			}},
+			newResource: &ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo-intention",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "foo",
+					},
+					JWT: &IntentionJWTRequirement{
+						Providers: []*IntentionJWTProvider{{Name: "okta"}},
+					},
+					Sources: SourceIntentions{
+						{
+							Name: "bar",
+							Permissions: IntentionPermissions{
+								{
+									Action: "allow",
+									JWT: &IntentionJWTRequirement{
+										Providers: []*IntentionJWTProvider{{Name: "okta"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expAllow: true,
+			mirror:   false,
+		},
+		"jwt providers not found": {
+			existingResources: []runtime.Object{&JWTProvider{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "okta",
+				},
+			}},
+			newResource: &ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo-intention",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "foo",
+					},
+					JWT: &IntentionJWTRequirement{
+						Providers: []*IntentionJWTProvider{{Name: "auth0"}},
+					},
+					Sources: SourceIntentions{
+						{
+							Name: "bar",
+							Permissions: IntentionPermissions{
+								{
+									Action: "allow",
+									JWT: &IntentionJWTRequirement{
+										Providers: []*IntentionJWTProvider{{Name: "okta"}, {Name: "keycloak"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expAllow:      false,
+			mirror:        false,
+			expErrMessage: `serviceintentions.consul.hashicorp.com "foo-intention" is invalid: [spec.jwt.providers[0].name: Not found: "auth0", spec.sources[0].permissions[0].jwt.providers[1].name: Not found: "keycloak"]`,
+		},
+		"sameness group exists": {
+			existingResources: []runtime.Object{&SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "group",
+				},
+			}},
+			newResource: &ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo-intention",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "foo",
+					},
+					Sources: SourceIntentions{
+						{
+							Name:          "bar",
+							SamenessGroup: "group",
+							Action:        "allow",
+						},
+					},
+				},
+			},
+			expAllow:          true,
+			mirror:            false,
+			partitionsEnabled: true,
+		},
+		"sameness group not found": {
+			existingResources: []runtime.Object{&SamenessGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "group",
+				},
+			}},
+			newResource: &ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo-intention",
+				},
+				Spec: ServiceIntentionsSpec{
+					Destination: IntentionDestination{
+						Name: "foo",
+					},
+					Sources: SourceIntentions{
+						{
+							Name:          "bar",
+							SamenessGroup: "group",
+							Action:        "allow",
+						},
+						{
+							Name:          "baz",
+							SamenessGroup: "other-group",
+							Action:        "deny",
+						},
+					},
+				},
+			},
+			expAllow:          false,
+			mirror:            false,
+			partitionsEnabled: true,
+			expErrMessage:     `serviceintentions.consul.hashicorp.com "foo-intention" is invalid: spec.sources[1].samenessGroup: Not found: "other-group"`,
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -243,7 +411,7 @@ func TestHandle_ServiceIntentions_Create(t *testing.T) {
 			marshalledRequestObject, err := json.Marshal(c.newResource)
 			require.NoError(t, err)
 			s := runtime.NewScheme()
-			s.AddKnownTypes(GroupVersion, &ServiceIntentions{}, &ServiceIntentionsList{})
+			s.AddKnownTypes(GroupVersion, &ServiceIntentions{}, &ServiceIntentionsList{}, &ServiceIntention{}, &ServiceIntentionList{}, &JWTProvider{}, &JWTProviderList{}, &SamenessGroup{}, &SamenessGroupList{})
 			client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
 			decoder, err := admission.NewDecoder(s)
 			require.NoError(t, err)
@@ -255,6 +423,7 @@ func TestHandle_ServiceIntentions_Create(t *testing.T) {
 				ConsulMeta: common.ConsulMeta{
 					NamespacesEnabled: true,
 					Mirroring:         c.mirror,
+					PartitionsEnabled: c.partitionsEnabled,
 				},
 			}
 			response := validator.Handle(ctx, admission.Request{
@@ -432,7 +601,7 @@ func TestHandle_ServiceIntentions_Update(t *testing.T) {
 			marshalledOldRequestObject, err := json.Marshal(c.existingResources[0])
 			require.NoError(t, err)
 			s := runtime.NewScheme()
-			s.AddKnownTypes(GroupVersion, &ServiceIntentions{}, &ServiceIntentionsList{})
+			s.AddKnownTypes(GroupVersion, &ServiceIntentions{}, &ServiceIntentionsList{}, &ServiceIntention{}, &ServiceIntentionList{})
 			client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
 			decoder, err := admission.NewDecoder(s)
 			require.NoError(t, err)
@@ -592,7 +761,7 @@ func TestHandle_ServiceIntentions_Patches(t *testing.T) {
 				marshalledRequestObject, err := json.Marshal(c.newResource)
 				require.NoError(t, err)
 				s := runtime.NewScheme()
-				s.AddKnownTypes(GroupVersion, &ServiceIntentions{}, &ServiceIntentionsList{})
+				s.AddKnownTypes(GroupVersion, &ServiceIntentions{}, &ServiceIntentionsList{}, &ServiceIntention{}, &ServiceIntentionList{})
 				client := fake.NewClientBuilder().WithScheme(s).Build()
 				decoder, err := admission.NewDecoder(s)
 				require.NoError(t, err)
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -29,10 +30,10 @@ func init() {
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
 // +kubebuilder:resource:shortName="service-resolver"
 type ServiceResolver struct {
-	metav1.TypeMeta   `json:",inline"`
-	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Spec              ServiceResolverSpec `json:"spec,omitempty"`
-	Status            `json:"status,omitempty"`
+	metav1.TypeMeta      `json:",inline"`
+	metav1.ObjectMeta    `json:"metadata,omitempty"`
+	Spec                 ServiceResolverSpec `json:"spec,omitempty"`
+	DiscoveryChainStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -128,6 +129,10 @@ type ServiceResolverFailover struct {
 	Datacenters []string `json:"datacenters,omitempty"`
 	// Targets specifies a fixed list of failover targets to try during failover.
 	Targets []ServiceResolverFailoverTarget `json:"targets,omitempty"`
+	// SamenessGroup is the name of the sameness group to try during failover.
+	// Its members are tried in order. It cannot be combined with any other
+	// failover fields.
+	SamenessGroup string `json:"samenessGroup,omitempty"`
 }
 
 type ServiceResolverFailoverTarget struct {
@@ -254,15 +259,7 @@ func (in *ServiceResolver) ConsulName() string {
 }
 
 func (in *ServiceResolver) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *ServiceResolver) SetLastSyncedTime(time *metav1.Time) {
@@ -395,6 +392,7 @@ func (in ServiceResolverFailover) toConsul() capi.ServiceResolverFailover {
 		Namespace:     in.Namespace,
 		Datacenters:   in.Datacenters,
 		Targets:       targets,
+		SamenessGroup: in.SamenessGroup,
 	}
 }
 
@@ -499,12 +497,17 @@ func (in *ServiceResolver) validateEnterprise(consulMeta common.ConsulMeta) fiel
 				errs = append(errs, field.Invalid(path.Child("redirect").Child("partition"), in.Spec.Redirect.Partition, `Consul Enterprise partitions must be enabled to set redirect.partition`))
 			}
 		}
+		for _, k := range in.Spec.Failover.sortedKeys() {
+			if v := in.Spec.Failover[k]; v.SamenessGroup != "" {
+				errs = append(errs, field.Invalid(path.Child("failover").Key(k).Child("samenessGroup"), v.SamenessGroup, `Consul Enterprise partitions must be enabled to set failover.samenessGroup`))
+			}
+		}
 	}
 	return errs
 }
 
 func (in *ServiceResolverFailover) isEmpty() bool {
-	return in.Service == "" && in.ServiceSubset == "" && in.Namespace == "" && len(in.Datacenters) == 0 && len(in.Targets) == 0
+	return in.Service == "" && in.ServiceSubset == "" && in.Namespace == "" && len(in.Datacenters) == 0 && len(in.Targets) == 0 && in.SamenessGroup == ""
 }
 
 func (in *ServiceResolverFailover) validate(path *field.Path) *field.Error {
@@ -512,11 +515,36 @@ func (in *ServiceResolverFailover) validate(path *field.Path) *field.Error {
 		// NOTE: We're passing "{}" here as our value because we know that the
 		// error is we have an empty object.
 		return field.Invalid(path, "{}",
-			"service, serviceSubset, namespace, datacenters, and targets cannot all be empty at once")
+			"service, serviceSubset, namespace, datacenters, targets, and samenessGroup cannot all be empty at once")
+	}
+	if in.SamenessGroup != "" && (in.Service != "" || in.ServiceSubset != "" || in.Namespace != "" || len(in.Datacenters) > 0 || len(in.Targets) > 0) {
+		return field.Invalid(path.Child("samenessGroup"), in.SamenessGroup,
+			"samenessGroup cannot be set with service, serviceSubset, namespace, datacenters, or targets")
 	}
 	return nil
 }
 
+func (in ServiceResolverFailoverMap) sortedKeys() []string {
+	var keys []string
+	for k := range in {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// samenessGroupRefs returns every SamenessGroup referenced by the resolver's
+// failover policies, ordered by subset name.
+func (in *ServiceResolver) samenessGroupRefs() []samenessGroupRef {
+	var refs []samenessGroupRef
+	for _, k := range in.Spec.Failover.sortedKeys() {
+		if name := in.Spec.Failover[k].SamenessGroup; name != "" {
+			refs = append(refs, samenessGroupRef{name: name, path: field.NewPath("spec").Child("failover").Key(k).Child("samenessGroup")})
+		}
+	}
+	return refs
+}
+
 func (in *LoadBalancer) validate(path *field.Path) field.ErrorList {
 	if in == nil {
 		return nil
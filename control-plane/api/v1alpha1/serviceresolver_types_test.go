@@ -361,6 +361,33 @@ func TestServiceResolver_ToConsul(t *testing.T) {
 				},
 			},
 		},
+		"failover sameness group": {
+			Ours: ServiceResolver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "name",
+				},
+				Spec: ServiceResolverSpec{
+					Failover: map[string]ServiceResolverFailover{
+						"*": {
+							SamenessGroup: "group",
+						},
+					},
+				},
+			},
+			Exp: &capi.ServiceResolverConfigEntry{
+				Name: "name",
+				Kind: capi.ServiceResolver,
+				Failover: map[string]capi.ServiceResolverFailover{
+					"*": {
+						SamenessGroup: "group",
+					},
+				},
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: "datacenter",
+				},
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -416,12 +443,12 @@ func TestServiceResolver_GetSyncedConditionStatus(t *testing.T) {
 	for _, status := range cases {
 		t.Run(string(status), func(t *testing.T) {
 			serviceResolver := &ServiceResolver{
-				Status: Status{
+				DiscoveryChainStatus: DiscoveryChainStatus{Status: Status{
 					Conditions: []Condition{{
 						Type:   ConditionSynced,
 						Status: status,
 					}},
-				},
+				}},
 			}
 
 			require.Equal(t, status, serviceResolver.SyncedConditionStatus())
@@ -595,8 +622,61 @@ func TestServiceResolver_Validate(t *testing.T) {
 			},
 			namespacesEnabled: false,
 			expectedErrMsgs: []string{
-				"spec.failover[failA]: Invalid value: \"{}\": service, serviceSubset, namespace, datacenters, and targets cannot all be empty at once",
-				"spec.failover[failB]: Invalid value: \"{}\": service, serviceSubset, namespace, datacenters, and targets cannot all be empty at once",
+				"spec.failover[failA]: Invalid value: \"{}\": service, serviceSubset, namespace, datacenters, targets, and samenessGroup cannot all be empty at once",
+				"spec.failover[failB]: Invalid value: \"{}\": service, serviceSubset, namespace, datacenters, targets, and samenessGroup cannot all be empty at once",
+			},
+		},
+		"failover samenessGroup": {
+			input: &ServiceResolver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: ServiceResolverSpec{
+					Failover: map[string]ServiceResolverFailover{
+						"*": {
+							SamenessGroup: "group",
+						},
+					},
+				},
+			},
+			partitionsEnabled: true,
+			expectedErrMsgs:   nil,
+		},
+		"failover samenessGroup with other fields": {
+			input: &ServiceResolver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: ServiceResolverSpec{
+					Failover: map[string]ServiceResolverFailover{
+						"*": {
+							SamenessGroup: "group",
+							Targets:       []ServiceResolverFailoverTarget{{Peer: "peer"}},
+						},
+					},
+				},
+			},
+			partitionsEnabled: true,
+			expectedErrMsgs: []string{
+				`spec.failover[*].samenessGroup: Invalid value: "group": samenessGroup cannot be set with service, serviceSubset, namespace, datacenters, or targets`,
+			},
+		},
+		"failover samenessGroup without partitions": {
+			input: &ServiceResolver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: ServiceResolverSpec{
+					Failover: map[string]ServiceResolverFailover{
+						"*": {
+							SamenessGroup: "group",
+						},
+					},
+				},
+			},
+			partitionsEnabled: false,
+			expectedErrMsgs: []string{
+				`spec.failover[*].samenessGroup: Invalid value: "group": Consul Enterprise partitions must be enabled to set failover.samenessGroup`,
 			},
 		},
 		"hashPolicy.field invalid": {
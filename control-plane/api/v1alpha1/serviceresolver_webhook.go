@@ -6,6 +6,8 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -37,7 +39,22 @@ func (v *ServiceResolverWebhook) Handle(ctx context.Context, req admission.Reque
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
-	return common.ValidateConfigEntry(ctx, req, v.Logger, v, &svcResolver, v.ConsulMeta)
+	resp := common.ValidateConfigEntry(ctx, req, v.Logger, v, &svcResolver, v.ConsulMeta)
+	if !resp.Allowed {
+		return resp
+	}
+
+	// Failover policies must reference existing SamenessGroup resources.
+	errs, err := missingSamenessGroups(ctx, v.Client, svcResolver.samenessGroupRefs())
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: ServiceResolverKubeKind},
+			svcResolver.KubernetesName(), errs))
+	}
+	return resp
 }
 
 func (v *ServiceResolverWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
@@ -35,8 +35,8 @@ type ServiceRouter struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   ServiceRouterSpec `json:"spec,omitempty"`
-	Status `json:"status,omitempty"`
+	Spec                 ServiceRouterSpec `json:"spec,omitempty"`
+	DiscoveryChainStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -194,15 +194,7 @@ func (in *ServiceRouter) KubernetesName() string {
 }
 
 func (in *ServiceRouter) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *ServiceRouter) SetLastSyncedTime(time *metav1.Time) {
@@ -454,12 +454,12 @@ func TestServiceRouter_GetSyncedConditionStatus(t *testing.T) {
 	for _, status := range cases {
 		t.Run(string(status), func(t *testing.T) {
 			serviceRouter := &ServiceRouter{
-				Status: Status{
+				DiscoveryChainStatus: DiscoveryChainStatus{Status: Status{
 					Conditions: []Condition{{
 						Type:   ConditionSynced,
 						Status: status,
 					}},
-				},
+				}},
 			}
 
 			require.Equal(t, status, serviceRouter.SyncedConditionStatus())
@@ -37,7 +37,18 @@ func (v *ServiceRouterWebhook) Handle(ctx context.Context, req admission.Request
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
-	return common.ValidateConfigEntry(ctx, req, v.Logger, v, &svcRouter, v.ConsulMeta)
+	resp := common.ValidateConfigEntry(ctx, req, v.Logger, v, &svcRouter, v.ConsulMeta)
+	if !resp.Allowed || !crossResourceValidationEnabled(v.ConsulMeta) {
+		return resp
+	}
+
+	// Check that the router and its destinations are services with an L7
+	// protocol.
+	errs, err := validateRouterProtocols(ctx, v.Client, v.ConsulMeta, &svcRouter)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return crossResourceResponse(v.ConsulMeta, resp, common.ServiceRouter, svcRouter.KubernetesName(), errs)
 }
 
 func (v *ServiceRouterWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
@@ -31,8 +31,8 @@ type ServiceSplitter struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   ServiceSplitterSpec `json:"spec,omitempty"`
-	Status `json:"status,omitempty"`
+	Spec                 ServiceSplitterSpec `json:"spec,omitempty"`
+	DiscoveryChainStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -112,15 +112,7 @@ func (in *ServiceSplitter) ConsulName() string {
 }
 
 func (in *ServiceSplitter) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
-	in.Status.Conditions = Conditions{
-		{
-			Type:               ConditionSynced,
-			Status:             status,
-			LastTransitionTime: metav1.Now(),
-			Reason:             reason,
-			Message:            message,
-		},
-	}
+	in.Status.setCondition(ConditionSynced, status, reason, message)
 }
 
 func (in *ServiceSplitter) SetLastSyncedTime(time *metav1.Time) {
@@ -321,12 +321,12 @@ func TestServiceSplitter_GetSyncedConditionStatus(t *testing.T) {
 	for _, status := range cases {
 		t.Run(string(status), func(t *testing.T) {
 			serviceSplitter := &ServiceSplitter{
-				Status: Status{
+				DiscoveryChainStatus: DiscoveryChainStatus{Status: Status{
 					Conditions: []Condition{{
 						Type:   ConditionSynced,
 						Status: status,
 					}},
-				},
+				}},
 			}
 
 			require.Equal(t, status, serviceSplitter.SyncedConditionStatus())
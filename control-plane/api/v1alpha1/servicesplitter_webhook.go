@@ -38,7 +38,17 @@ func (v *ServiceSplitterWebhook) Handle(ctx context.Context, req admission.Reque
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
-	return common.ValidateConfigEntry(ctx, req, v.Logger, v, &serviceSplitter, v.ConsulMeta)
+	resp := common.ValidateConfigEntry(ctx, req, v.Logger, v, &serviceSplitter, v.ConsulMeta)
+	if !resp.Allowed || !crossResourceValidationEnabled(v.ConsulMeta) {
+		return resp
+	}
+
+	// Check that the subsets the splits use are defined by ServiceResolvers.
+	errs, err := validateSplitSubsets(ctx, v.Client, v.ConsulMeta, &serviceSplitter)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return crossResourceResponse(v.ConsulMeta, resp, common.ServiceSplitter, serviceSplitter.KubernetesName(), errs)
 }
 
 func (v *ServiceSplitterWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
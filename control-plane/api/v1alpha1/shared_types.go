@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -79,6 +80,48 @@ type HTTPHeaderModifiers struct {
 	Remove []string `json:"remove,omitempty"`
 }
 
+// ResourceReference is a reference to another config entry, e.g. the
+// APIGateway a route binds to or the InlineCertificate used by a listener.
+type ResourceReference struct {
+	// Kind is the kind of config entry this resource refers to. If unset it
+	// defaults to the only kind allowed in the referencing field.
+	Kind string `json:"kind,omitempty"`
+	// Name is the name of the config entry this resource refers to.
+	Name string `json:"name,omitempty"`
+	// SectionName is a subset of the referenced config entry, e.g. the name of
+	// a listener on an APIGateway.
+	SectionName string `json:"sectionName,omitempty"`
+	// Namespace is the namespace the referenced config entry is in.
+	// Namespacing is a Consul Enterprise feature.
+	Namespace string `json:"namespace,omitempty"`
+	// Partition is the admin partition the referenced config entry is in.
+	// Partitioning is a Consul Enterprise feature.
+	Partition string `json:"partition,omitempty"`
+}
+
+// EnvoyExtension has configuration for an extension that patches Envoy resources.
+type EnvoyExtension struct {
+	// Name is the name of the extension, e.g. "builtin/lua".
+	Name string `json:"name,omitempty"`
+	// Required is whether the proxy should fail to be configured if the
+	// extension can't be applied.
+	Required bool `json:"required,omitempty"`
+	// Arguments are the extension specific arguments.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	// ConsulVersion is a version constraint that the Consul servers must
+	// satisfy for the extension to be applied, e.g. ">= 1.16.0".
+	ConsulVersion string `json:"consulVersion,omitempty"`
+	// EnvoyVersion is a version constraint that the Envoy proxy must
+	// satisfy for the extension to be applied, e.g. ">= 1.26.0".
+	EnvoyVersion string `json:"envoyVersion,omitempty"`
+}
+
+// EnvoyExtensions is a list of extensions applied in order.
+type EnvoyExtensions []EnvoyExtension
+
 func (in MeshGateway) toConsul() capi.MeshGatewayConfig {
 	mode := capi.MeshGatewayMode(in.Mode)
 	switch mode {
@@ -176,6 +219,87 @@ func (in *HTTPHeaderModifiers) toConsul() *capi.HTTPHeaderModifiers {
 	}
 }
 
+func (in ResourceReference) toConsul(defaultKind string) capi.ResourceReference {
+	kind := in.Kind
+	if kind == "" {
+		kind = defaultKind
+	}
+	return capi.ResourceReference{
+		Kind:        kind,
+		Name:        in.Name,
+		SectionName: in.SectionName,
+		Namespace:   in.Namespace,
+		Partition:   in.Partition,
+	}
+}
+
+func (in ResourceReference) validate(path *field.Path, kind string, consulMeta common.ConsulMeta) field.ErrorList {
+	var errs field.ErrorList
+	if in.Kind != "" && in.Kind != kind {
+		errs = append(errs, field.Invalid(path.Child("kind"), in.Kind, notInSliceMessage([]string{kind})))
+	}
+	if in.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "name must be set"))
+	}
+	if in.Namespace != "" && !consulMeta.NamespacesEnabled {
+		errs = append(errs, field.Invalid(path.Child("namespace"), in.Namespace,
+			"Consul Enterprise namespaces must be enabled to set namespace"))
+	}
+	if in.Partition != "" && !consulMeta.PartitionsEnabled {
+		errs = append(errs, field.Invalid(path.Child("partition"), in.Partition,
+			"Consul Enterprise admin-partitions must be enabled to set partition"))
+	}
+	return errs
+}
+
+func (in EnvoyExtensions) toConsul() []capi.EnvoyExtension {
+	if in == nil {
+		return nil
+	}
+	outExtensions := make([]capi.EnvoyExtension, 0, len(in))
+	for _, e := range in {
+		var args map[string]interface{}
+		// We explicitly ignore the error returned by Unmarshal
+		// because validate() ensures that if we get to here that it
+		// won't return an error.
+		if len(e.Arguments) > 0 {
+			_ = json.Unmarshal(e.Arguments, &args)
+		}
+		outExtensions = append(outExtensions, capi.EnvoyExtension{
+			Name:          e.Name,
+			Required:      e.Required,
+			Arguments:     args,
+			ConsulVersion: e.ConsulVersion,
+			EnvoyVersion:  e.EnvoyVersion,
+		})
+	}
+	return outExtensions
+}
+
+func (in EnvoyExtensions) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, e := range in {
+		errs = append(errs, e.validate(path.Index(i))...)
+	}
+	return errs
+}
+
+func (in EnvoyExtension) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	validate, ok := envoyExtensionArguments[in.Name]
+	if !ok {
+		return append(errs, field.Invalid(path.Child("name"), in.Name, notInSliceMessage(envoyExtensionNames())))
+	}
+	if len(in.Arguments) == 0 {
+		return append(errs, field.Required(path.Child("arguments"), "arguments must be set"))
+	}
+	var args map[string]interface{}
+	if err := json.Unmarshal(in.Arguments, &args); err != nil {
+		return append(errs, field.Invalid(path.Child("arguments"), string(in.Arguments), fmt.Sprintf("must be valid map value: %s", err)))
+	}
+	return append(errs, validate(in.Arguments, path.Child("arguments"))...)
+}
+
 func notInSliceMessage(slice []string) string {
 	return fmt.Sprintf(`must be one of "%s"`, strings.Join(slice, `", "`))
 }
@@ -14,6 +14,19 @@ type ConditionType string
 const (
 	// ConditionSynced specifies that the resource has been synced with Consul.
 	ConditionSynced ConditionType = "Synced"
+	// ConditionInSync specifies whether the resource in Consul still matches
+	// the custom resource, i.e. that it hasn't been changed in Consul directly.
+	ConditionInSync ConditionType = "InSync"
+	// ConditionDiscoveryChainCompiled specifies whether Consul could compile
+	// the discovery chain of the service the resource is part of.
+	ConditionDiscoveryChainCompiled ConditionType = "DiscoveryChainCompiled"
+	// ConditionOwnershipConflict specifies that the config entry in Consul
+	// is owned by another Kubernetes cluster or resource.
+	ConditionOwnershipConflict ConditionType = "OwnershipConflict"
+	// ConditionReconcileSuspended specifies that changes to the resource are
+	// not written to Consul because of its consul.hashicorp.com/reconcile
+	// annotation.
+	ConditionReconcileSuspended ConditionType = "ReconcileSuspended"
 )
 
 // Conditions define a readiness condition for a Consul resource.
@@ -78,6 +91,48 @@ type Status struct {
 	// LastSyncedTime is the last time the resource successfully synced with Consul.
 	// +optional
 	LastSyncedTime *metav1.Time `json:"lastSyncedTime,omitempty" description:"last time the condition transitioned from one status to another"`
+
+	// SyncedGeneration is the generation of the resource that was last
+	// successfully synced with Consul.
+	// +optional
+	SyncedGeneration int64 `json:"syncedGeneration,omitempty"`
+
+	// SyncedModifyIndex is the modify index of the config entry in Consul
+	// when the resource was last successfully synced with it. Updates and
+	// deletes only succeed if the config entry still has this index.
+	// +optional
+	SyncedModifyIndex uint64 `json:"syncedModifyIndex,omitempty"`
+
+	// DryRun is the change that syncing the resource would make to the config
+	// entry in Consul. It is only set while the resource has the
+	// consul.hashicorp.com/reconcile: dry-run annotation.
+	// +optional
+	DryRun *DryRun `json:"dryRun,omitempty"`
+}
+
+// DryRun describes the change that syncing a resource would make to its
+// config entry in Consul.
+// +k8s:deepcopy-gen=true
+type DryRun struct {
+	// ObservedGeneration is the generation of the resource the change was
+	// computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Operation is "create" if the config entry doesn't exist in Consul,
+	// "update" if it differs from the resource, or "none" if it matches.
+	// +optional
+	Operation string `json:"operation,omitempty"`
+
+	// ConfigEntry is the JSON config entry that would be written to Consul.
+	// Secrets such as private keys are redacted.
+	// +optional
+	ConfigEntry string `json:"configEntry,omitempty"`
+
+	// Diff lists the top-level fields of the config entry in Consul that
+	// would change, with their current and new values. Secrets are redacted.
+	// +optional
+	Diff []string `json:"diff,omitempty"`
 }
 
 func (s *Status) GetCondition(t ConditionType) *Condition {
@@ -88,3 +143,101 @@ func (s *Status) GetCondition(t ConditionType) *Condition {
 	}
 	return nil
 }
+
+// SetInSyncCondition updates the InSync condition. Other conditions are left
+// as they are.
+func (s *Status) SetInSyncCondition(status corev1.ConditionStatus, reason, message string) {
+	s.setCondition(ConditionInSync, status, reason, message)
+}
+
+// InSyncCondition gets the InSync condition.
+func (s *Status) InSyncCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := s.GetCondition(ConditionInSync)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+// SetOwnershipConflictCondition updates the OwnershipConflict condition.
+func (s *Status) SetOwnershipConflictCondition(status corev1.ConditionStatus, reason, message string) {
+	s.setCondition(ConditionOwnershipConflict, status, reason, message)
+}
+
+// OwnershipConflictCondition gets the OwnershipConflict condition.
+func (s *Status) OwnershipConflictCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := s.GetCondition(ConditionOwnershipConflict)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+// SetReconcileSuspendedCondition updates the ReconcileSuspended condition.
+func (s *Status) SetReconcileSuspendedCondition(status corev1.ConditionStatus, reason, message string) {
+	s.setCondition(ConditionReconcileSuspended, status, reason, message)
+}
+
+// ReconcileSuspendedCondition gets the ReconcileSuspended condition.
+func (s *Status) ReconcileSuspendedCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := s.GetCondition(ConditionReconcileSuspended)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+// SetDryRun records the change that syncing the resource would make to the
+// config entry in Consul.
+func (s *Status) SetDryRun(generation int64, operation, configEntry string, diff []string) {
+	s.DryRun = &DryRun{
+		ObservedGeneration: generation,
+		Operation:          operation,
+		ConfigEntry:        configEntry,
+		Diff:               diff,
+	}
+}
+
+// ClearDryRun removes the change recorded by SetDryRun.
+func (s *Status) ClearDryRun() {
+	s.DryRun = nil
+}
+
+// SetSyncedGeneration updates the generation last synced with Consul.
+func (s *Status) SetSyncedGeneration(generation int64) {
+	s.SyncedGeneration = generation
+}
+
+// GetSyncedGeneration returns the generation last synced with Consul.
+func (s *Status) GetSyncedGeneration() int64 {
+	return s.SyncedGeneration
+}
+
+// SetSyncedModifyIndex updates the modify index last synced with Consul.
+func (s *Status) SetSyncedModifyIndex(index uint64) {
+	s.SyncedModifyIndex = index
+}
+
+// GetSyncedModifyIndex returns the modify index last synced with Consul.
+func (s *Status) GetSyncedModifyIndex() uint64 {
+	return s.SyncedModifyIndex
+}
+
+// setCondition replaces the condition of type t, or appends it if the
+// resource doesn't have that condition yet.
+func (s *Status) setCondition(t ConditionType, status corev1.ConditionStatus, reason, message string) {
+	cond := Condition{
+		Type:               t,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == t {
+			s.Conditions[i] = cond
+			return
+		}
+	}
+	s.Conditions = append(s.Conditions, cond)
+}
@@ -0,0 +1,233 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	tcpRouteKubeKind = "tcproute"
+)
+
+func init() {
+	SchemeBuilder.Register(&TCPRoute{}, &TCPRouteList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// TCPRoute is the Schema for the tcproutes API
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="tcp-route"
+type TCPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TCPRouteSpec `json:"spec,omitempty"`
+	Status `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TCPRouteList contains a list of TCPRoute.
+type TCPRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TCPRoute `json:"items"`
+}
+
+// TCPRouteSpec defines the desired state of TCPRoute.
+type TCPRouteSpec struct {
+	// Parents is the list of APIGateway resources the route binds to. The
+	// sectionName of a parent is the name of a listener on the gateway.
+	Parents []ResourceReference `json:"parents,omitempty"`
+	// Services is the service connections are routed to. Only a single
+	// service is currently supported.
+	Services []TCPService `json:"services,omitempty"`
+}
+
+// TCPService is a service connections are routed to.
+type TCPService struct {
+	// Name is the name of the service.
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace the service is registered in.
+	// Namespacing is a Consul Enterprise feature.
+	Namespace string `json:"namespace,omitempty"`
+	// Partition is the admin partition the service is registered in.
+	// Partitioning is a Consul Enterprise feature.
+	Partition string `json:"partition,omitempty"`
+}
+
+func (in *TCPRoute) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *TCPRoute) AddFinalizer(name string) {
+	in.ObjectMeta.Finalizers = append(in.Finalizers(), name)
+}
+
+func (in *TCPRoute) RemoveFinalizer(name string) {
+	var newFinalizers []string
+	for _, oldF := range in.Finalizers() {
+		if oldF != name {
+			newFinalizers = append(newFinalizers, oldF)
+		}
+	}
+	in.ObjectMeta.Finalizers = newFinalizers
+}
+
+func (in *TCPRoute) Finalizers() []string {
+	return in.ObjectMeta.Finalizers
+}
+
+func (in *TCPRoute) ConsulKind() string {
+	return capi.TCPRoute
+}
+
+func (in *TCPRoute) ConsulGlobalResource() bool {
+	return false
+}
+
+func (in *TCPRoute) ConsulMirroringNS() string {
+	return in.Namespace
+}
+
+func (in *TCPRoute) KubeKind() string {
+	return tcpRouteKubeKind
+}
+
+func (in *TCPRoute) ConsulName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *TCPRoute) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+func (in *TCPRoute) SetSyncedCondition(status corev1.ConditionStatus, reason, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *TCPRoute) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *TCPRoute) SyncedCondition() (status corev1.ConditionStatus, reason, message string) {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown, "", ""
+	}
+	return cond.Status, cond.Reason, cond.Message
+}
+
+func (in *TCPRoute) SyncedConditionStatus() corev1.ConditionStatus {
+	condition := in.Status.GetCondition(ConditionSynced)
+	if condition == nil {
+		return corev1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+func (in *TCPRoute) ToConsul(datacenter string) capi.ConfigEntry {
+	var parents []capi.ResourceReference
+	for _, p := range in.Spec.Parents {
+		parents = append(parents, p.toConsul(capi.APIGateway))
+	}
+	var services []capi.TCPService
+	for _, s := range in.Spec.Services {
+		services = append(services, capi.TCPService{
+			Name:      s.Name,
+			Namespace: s.Namespace,
+			Partition: s.Partition,
+		})
+	}
+	return &capi.TCPRouteConfigEntry{
+		Kind:     in.ConsulKind(),
+		Name:     in.ConsulName(),
+		Parents:  parents,
+		Services: services,
+		Meta:     meta(datacenter),
+	}
+}
+
+func (in *TCPRoute) MatchesConsul(candidate capi.ConfigEntry) bool {
+	configEntry, ok := candidate.(*capi.TCPRouteConfigEntry)
+	if !ok {
+		return false
+	}
+	// No datacenter is passed to ToConsul as we ignore the Meta field when checking for equality.
+	// Status is computed by Consul so it is ignored too.
+	return cmp.Equal(in.ToConsul(""), configEntry, cmpopts.IgnoreFields(capi.TCPRouteConfigEntry{}, "Partition", "Namespace", "Meta", "Status", "ModifyIndex", "CreateIndex"), cmpopts.IgnoreUnexported(), cmpopts.EquateEmpty())
+}
+
+func (in *TCPRoute) Validate(consulMeta common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if len(in.Spec.Parents) == 0 {
+		errs = append(errs, field.Required(path.Child("parents"), "at least one parent must be set"))
+	}
+	for i, p := range in.Spec.Parents {
+		errs = append(errs, p.validate(path.Child("parents").Index(i), capi.APIGateway, consulMeta)...)
+	}
+
+	if len(in.Spec.Services) != 1 {
+		asJSON, _ := json.Marshal(in.Spec.Services)
+		errs = append(errs, field.Invalid(path.Child("services"), string(asJSON), "exactly one service must be set"))
+	}
+	for i, s := range in.Spec.Services {
+		svcPath := path.Child("services").Index(i)
+		if s.Name == "" {
+			errs = append(errs, field.Required(svcPath.Child("name"), "name must be set"))
+		}
+		if s.Namespace != "" && !consulMeta.NamespacesEnabled {
+			errs = append(errs, field.Invalid(svcPath.Child("namespace"), s.Namespace,
+				"Consul Enterprise namespaces must be enabled to set namespace"))
+		}
+		if s.Partition != "" && !consulMeta.PartitionsEnabled {
+			errs = append(errs, field.Invalid(svcPath.Child("partition"), s.Partition,
+				"Consul Enterprise admin-partitions must be enabled to set partition"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: tcpRouteKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
+
+// DefaultNamespaceFields sets the namespace field on spec.parents and spec.services to their default values if namespaces are enabled.
+func (in *TCPRoute) DefaultNamespaceFields(consulMeta common.ConsulMeta) {
+	// If namespaces are enabled we want to set the namespace fields to their
+	// defaults. If namespaces are not enabled (i.e. OSS) we don't set the
+	// namespace fields because this would cause errors
+	// making API calls (because namespace fields can't be set in OSS).
+	if consulMeta.NamespacesEnabled {
+		// Default to the current namespace (i.e. the namespace of the config entry).
+		namespace := namespaces.ConsulNamespace(in.Namespace, consulMeta.NamespacesEnabled, consulMeta.DestinationNamespace, consulMeta.Mirroring, consulMeta.Prefix)
+		for i, parent := range in.Spec.Parents {
+			if parent.Namespace == "" {
+				in.Spec.Parents[i].Namespace = namespace
+			}
+		}
+		for i, service := range in.Spec.Services {
+			if service.Namespace == "" {
+				in.Spec.Services[i].Namespace = namespace
+			}
+		}
+	}
+}
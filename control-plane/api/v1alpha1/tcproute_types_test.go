@@ -0,0 +1,122 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTCPRoute_ToConsul(t *testing.T) {
+	route := &TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		Spec: TCPRouteSpec{
+			Parents:  []ResourceReference{{Name: "gateway", SectionName: "tcp"}},
+			Services: []TCPService{{Name: "db", Namespace: "ns"}},
+		},
+	}
+	require.Equal(t, &capi.TCPRouteConfigEntry{
+		Kind:     capi.TCPRoute,
+		Name:     "name",
+		Parents:  []capi.ResourceReference{{Kind: capi.APIGateway, Name: "gateway", SectionName: "tcp"}},
+		Services: []capi.TCPService{{Name: "db", Namespace: "ns"}},
+		Meta: map[string]string{
+			common.SourceKey:     common.SourceValue,
+			common.DatacenterKey: "datacenter",
+		},
+	}, route.ToConsul("datacenter"))
+}
+
+func TestTCPRoute_MatchesConsul(t *testing.T) {
+	route := &TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		Spec: TCPRouteSpec{
+			Parents:  []ResourceReference{{Name: "gateway"}},
+			Services: []TCPService{{Name: "db"}},
+		},
+	}
+	require.True(t, route.MatchesConsul(&capi.TCPRouteConfigEntry{
+		Kind:        capi.TCPRoute,
+		Name:        "name",
+		Parents:     []capi.ResourceReference{{Kind: capi.APIGateway, Name: "gateway"}},
+		Services:    []capi.TCPService{{Name: "db"}},
+		ModifyIndex: 1,
+		Status: capi.ConfigEntryStatus{
+			Conditions: []capi.Condition{{Type: "Bound", Status: "True"}},
+		},
+	}))
+	require.False(t, route.MatchesConsul(&capi.TCPRouteConfigEntry{
+		Kind:     capi.TCPRoute,
+		Name:     "name",
+		Parents:  []capi.ResourceReference{{Kind: capi.APIGateway, Name: "other"}},
+		Services: []capi.TCPService{{Name: "db"}},
+	}))
+	require.False(t, route.MatchesConsul(&capi.HTTPRouteConfigEntry{Kind: capi.HTTPRoute, Name: "name"}))
+}
+
+func TestTCPRoute_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec            TCPRouteSpec
+		consulMeta      common.ConsulMeta
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: TCPRouteSpec{
+				Parents:  []ResourceReference{{Name: "gateway", Namespace: "ns", Partition: "part"}},
+				Services: []TCPService{{Name: "db", Namespace: "ns", Partition: "part"}},
+			},
+			consulMeta: common.ConsulMeta{NamespacesEnabled: true, PartitionsEnabled: true},
+		},
+		"missing parents and services": {
+			spec: TCPRouteSpec{},
+			expectedErrMsgs: []string{
+				`spec.parents: Required value: at least one parent must be set`,
+				`spec.services: Invalid value: "null": exactly one service must be set`,
+			},
+		},
+		"invalid services": {
+			spec: TCPRouteSpec{
+				Parents:  []ResourceReference{{Name: "gateway"}},
+				Services: []TCPService{{Name: "db"}, {Namespace: "ns", Partition: "part"}},
+			},
+			expectedErrMsgs: []string{
+				`spec.services: Invalid value: "[{\"name\":\"db\"},{\"namespace\":\"ns\",\"partition\":\"part\"}]": exactly one service must be set`,
+				`spec.services[1].name: Required value: name must be set`,
+				`spec.services[1].namespace: Invalid value: "ns": Consul Enterprise namespaces must be enabled to set namespace`,
+				`spec.services[1].partition: Invalid value: "part": Consul Enterprise admin-partitions must be enabled to set partition`,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			route := &TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "name"},
+				Spec:       c.spec,
+			}
+			err := route.Validate(c.consulMeta)
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTCPRoute_DefaultNamespaceFields(t *testing.T) {
+	route := &TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "k8s"},
+		Spec: TCPRouteSpec{
+			Parents:  []ResourceReference{{Name: "gateway", Namespace: "other"}},
+			Services: []TCPService{{Name: "db"}},
+		},
+	}
+	route.DefaultNamespaceFields(common.ConsulMeta{NamespacesEnabled: true, Mirroring: true})
+	require.Equal(t, "other", route.Spec.Parents[0].Namespace)
+	require.Equal(t, "k8s", route.Spec.Services[0].Namespace)
+}
@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type TCPRouteWebhook struct {
+	Logger logr.Logger
+
+	// ConsulMeta contains metadata specific to the Consul installation.
+	ConsulMeta common.ConsulMeta
+
+	decoder *admission.Decoder
+	client.Client
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-tcproute,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=tcproutes,versions=v1alpha1,name=mutate-tcproute.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *TCPRouteWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var resource TCPRoute
+	err := v.decoder.Decode(req, &resource)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	resp := common.ValidateConfigEntry(ctx, req, v.Logger, v, &resource, v.ConsulMeta)
+	if !resp.Allowed {
+		return resp
+	}
+
+	// Check that the gateways referenced by the route exist.
+	path := field.NewPath("spec")
+	errs, err := validateParentReferences(ctx, v.Client, v.ConsulMeta, req.Namespace, resource.Spec.Parents, "tcp", path.Child("parents"))
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.Errored(http.StatusBadRequest, apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: tcpRouteKubeKind},
+			resource.KubernetesName(), errs))
+	}
+
+	// Services may be registered in Consul only, so missing services are
+	// reported through cross-resource validation.
+	if !crossResourceValidationEnabled(v.ConsulMeta) {
+		return resp
+	}
+	var services []serviceReference
+	var paths []*field.Path
+	for i, svc := range resource.Spec.Services {
+		services = append(services, serviceReference{Name: svc.Name, Namespace: svc.Namespace, Partition: svc.Partition})
+		paths = append(paths, path.Child("services").Index(i))
+	}
+	serviceErrs, err := validateServiceReferences(ctx, v.Client, v.ConsulMeta, req.Namespace, services, paths)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return crossResourceResponse(v.ConsulMeta, resp, tcpRouteKubeKind, resource.KubernetesName(), serviceErrs)
+}
+
+func (v *TCPRouteWebhook) List(ctx context.Context) ([]common.ConfigEntryResource, error) {
+	var resourceList TCPRouteList
+	if err := v.Client.List(ctx, &resourceList); err != nil {
+		return nil, err
+	}
+	var entries []common.ConfigEntryResource
+	for _, item := range resourceList.Items {
+		entries = append(entries, common.ConfigEntryResource(&item))
+	}
+	return entries, nil
+}
+
+func (v *TCPRouteWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
@@ -0,0 +1,115 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestHandle_TCPRoute_References(t *testing.T) {
+	gateway := &APIGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+		Spec: APIGatewaySpec{
+			Listeners: []APIGatewayListener{
+				{Name: "http", Port: 80, Protocol: "http"},
+				{Name: "tcp", Port: 9000, Protocol: "tcp"},
+			},
+		},
+	}
+	dbService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}}
+
+	cases := map[string]struct {
+		existingResources []runtime.Object
+		parents           []ResourceReference
+		consulMeta        common.ConsulMeta
+		expAllow          bool
+		expErrMessage     string
+	}{
+		"gateway and service exist": {
+			existingResources: []runtime.Object{gateway, dbService},
+			parents:           []ResourceReference{{Name: "gateway", SectionName: "tcp"}},
+			expAllow:          true,
+		},
+		"gateway listener has wrong protocol": {
+			existingResources: []runtime.Object{gateway, dbService},
+			parents:           []ResourceReference{{Name: "gateway", SectionName: "http"}},
+			expAllow:          false,
+			expErrMessage:     `tcproute.consul.hashicorp.com "route" is invalid: spec.parents[0].sectionName: Invalid value: "http": listener has protocol "http" but must be "tcp"`,
+		},
+		"gateway does not exist": {
+			parents:       []ResourceReference{{Name: "gateway"}},
+			expAllow:      false,
+			expErrMessage: `tcproute.consul.hashicorp.com "route" is invalid: spec.parents[0].name: Not found: "gateway"`,
+		},
+		"service does not exist without cross-resource validation": {
+			existingResources: []runtime.Object{gateway},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true},
+			expAllow:          true,
+		},
+		"service does not exist": {
+			existingResources: []runtime.Object{gateway},
+			parents:           []ResourceReference{{Name: "gateway"}},
+			consulMeta:        common.ConsulMeta{NamespacesEnabled: true, Mirroring: true, CrossResourceValidation: common.CrossResourceValidationDeny},
+			expAllow:          false,
+			expErrMessage:     `tcproute.consul.hashicorp.com "route" is invalid: spec.services[0].name: Not found: "db"`,
+		},
+		"invalid route is rejected before references are checked": {
+			existingResources: []runtime.Object{gateway, dbService},
+			expAllow:          false,
+			expErrMessage:     `tcproute.consul.hashicorp.com "route" is invalid: spec.parents: Required value: at least one parent must be set`,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			route := &TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+				Spec: TCPRouteSpec{
+					Parents:  c.parents,
+					Services: []TCPService{{Name: "db"}},
+				},
+			}
+			marshalledRequestObject, err := json.Marshal(route)
+			require.NoError(t, err)
+			s := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(s))
+			s.AddKnownTypes(GroupVersion, &TCPRoute{}, &TCPRouteList{}, &APIGateway{}, &APIGatewayList{})
+			client := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(c.existingResources...).Build()
+			decoder, err := admission.NewDecoder(s)
+			require.NoError(t, err)
+
+			validator := &TCPRouteWebhook{
+				Client:     client,
+				Logger:     logrtest.TestLogger{T: t},
+				decoder:    decoder,
+				ConsulMeta: c.consulMeta,
+			}
+			response := validator.Handle(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      route.KubernetesName(),
+					Namespace: route.Namespace,
+					Operation: admissionv1.Create,
+					Object: runtime.RawExtension{
+						Raw: marshalledRequestObject,
+					},
+				},
+			})
+
+			require.Equal(t, c.expAllow, response.Allowed)
+			if c.expErrMessage != "" {
+				require.Equal(t, c.expErrMessage, response.AdmissionResponse.Result.Message)
+			}
+		})
+	}
+}
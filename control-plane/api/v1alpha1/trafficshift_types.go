@@ -0,0 +1,256 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const TrafficShiftKubeKind = "trafficshift"
+
+const (
+	// TrafficShiftProgressing is the phase of a traffic shift that is moving
+	// through its steps.
+	TrafficShiftProgressing = "Progressing"
+	// TrafficShiftSucceeded is the phase of a traffic shift that completed
+	// its last step.
+	TrafficShiftSucceeded = "Succeeded"
+	// TrafficShiftRolledBack is the phase of a traffic shift whose analysis
+	// failed so all traffic was shifted back to the stable subset.
+	TrafficShiftRolledBack = "RolledBack"
+
+	// defaultTrafficShiftInterval is how often the analysis runs if the
+	// interval isn't set.
+	defaultTrafficShiftInterval = 30 * time.Second
+)
+
+func init() {
+	SchemeBuilder.Register(&TrafficShift{}, &TrafficShiftList{})
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// TrafficShift is the Schema for the trafficshifts API. It progressively
+// shifts the traffic of a ServiceSplitter from a stable subset to a canary
+// subset, and shifts it back if the canary fails its analysis.
+// +kubebuilder:printcolumn:name="Splitter",type="string",JSONPath=".spec.serviceSplitter",description="The ServiceSplitter whose traffic is shifted"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the traffic shift"
+// +kubebuilder:printcolumn:name="Step",type="integer",JSONPath=".status.currentStep",description="The index of the current step"
+// +kubebuilder:printcolumn:name="Canary Weight",type="number",JSONPath=".status.canaryWeight",description="The percentage of traffic sent to the canary subset"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="traffic-shift"
+type TrafficShift struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TrafficShiftSpec   `json:"spec,omitempty"`
+	Status TrafficShiftStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TrafficShiftList contains a list of TrafficShift.
+type TrafficShiftList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrafficShift `json:"items"`
+}
+
+// TrafficShiftSpec defines the desired state of TrafficShift. Changing the
+// spec starts the traffic shift again from its first step.
+type TrafficShiftSpec struct {
+	// ServiceSplitter is the name of the ServiceSplitter resource in the
+	// namespace of this resource whose splits are updated. Its splits are
+	// replaced by a split for the stable subset and one for the canary subset.
+	ServiceSplitter string `json:"serviceSplitter"`
+	// StableSubset is the ServiceResolver subset of the current version of
+	// the service.
+	StableSubset string `json:"stableSubset"`
+	// CanarySubset is the ServiceResolver subset of the new version of the
+	// service.
+	CanarySubset string `json:"canarySubset"`
+	// Steps are the percentages of traffic sent to the canary subset, in
+	// order. The traffic shift succeeds once the last step is complete.
+	Steps []TrafficShiftStep `json:"steps"`
+	// Analysis checks the canary subset while the traffic shift progresses.
+	// If it fails, all traffic is shifted back to the stable subset.
+	Analysis TrafficShiftAnalysis `json:"analysis,omitempty"`
+}
+
+// TrafficShiftStep is a percentage of traffic sent to the canary subset.
+type TrafficShiftStep struct {
+	// Weight is the percentage of traffic sent to the canary subset, between
+	// 0 and 100.
+	Weight float32 `json:"weight"`
+	// Duration is how long the weight is kept before moving on to the next
+	// step, e.g. "5m". The analysis must pass throughout.
+	Duration metav1.Duration `json:"duration,omitempty"`
+}
+
+// TrafficShiftAnalysis checks the canary subset. Without checks the steps
+// are only paced by their duration.
+type TrafficShiftAnalysis struct {
+	// Interval is how often the checks run. Defaults to 30s.
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// FailureLimit is the number of failed checks that are tolerated before
+	// the traffic shift is rolled back. Defaults to 0.
+	FailureLimit int32 `json:"failureLimit,omitempty"`
+	// ConsulHealth requires the canary subset to have instances in Consul
+	// and all of their health checks to be passing.
+	ConsulHealth bool `json:"consulHealth,omitempty"`
+	// Prometheus are queries whose results must be within bounds.
+	Prometheus []TrafficShiftPrometheusCheck `json:"prometheus,omitempty"`
+}
+
+// TrafficShiftPrometheusCheck is a Prometheus query whose result must be
+// within bounds. At least one of Min or Max must be set.
+type TrafficShiftPrometheusCheck struct {
+	// Name identifies the check in the status.
+	Name string `json:"name"`
+	// Address is the address of the Prometheus server, e.g.
+	// "http://prometheus-server.monitoring".
+	Address string `json:"address"`
+	// Query is a PromQL query that returns a single value, e.g. the error
+	// rate of the canary subset.
+	Query string `json:"query"`
+	// Min is the lowest value of the result that passes.
+	Min *float32 `json:"min,omitempty"`
+	// Max is the highest value of the result that passes.
+	Max *float32 `json:"max,omitempty"`
+}
+
+// TrafficShiftStatus defines the observed state of TrafficShift.
+type TrafficShiftStatus struct {
+	// The Synced condition reports whether the ServiceSplitter could be
+	// updated.
+	Status `json:",inline"`
+	// ObservedGeneration is the generation of the spec the traffic shift
+	// was started for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Phase is Progressing, Succeeded or RolledBack.
+	Phase string `json:"phase,omitempty"`
+	// CurrentStep is the index of the current step.
+	CurrentStep int32 `json:"currentStep,omitempty"`
+	// CanaryWeight is the percentage of traffic currently sent to the canary
+	// subset.
+	CanaryWeight float32 `json:"canaryWeight,omitempty"`
+	// StepStartTime is when the current step started.
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+	// LastAnalysisTime is when the checks last ran.
+	LastAnalysisTime *metav1.Time `json:"lastAnalysisTime,omitempty"`
+	// Failures is the number of failed checks since the traffic shift
+	// started.
+	Failures int32 `json:"failures,omitempty"`
+	// Message describes the last failed check.
+	Message string `json:"message,omitempty"`
+}
+
+func (in *TrafficShift) KubeKind() string {
+	return TrafficShiftKubeKind
+}
+
+func (in *TrafficShift) KubernetesName() string {
+	return in.ObjectMeta.Name
+}
+
+// AnalysisInterval returns how often the checks run.
+func (in *TrafficShift) AnalysisInterval() time.Duration {
+	if in.Spec.Analysis.Interval.Duration > 0 {
+		return in.Spec.Analysis.Interval.Duration
+	}
+	return defaultTrafficShiftInterval
+}
+
+// HasChecks returns true if the canary subset is checked.
+func (in *TrafficShift) HasChecks() bool {
+	return in.Spec.Analysis.ConsulHealth || len(in.Spec.Analysis.Prometheus) > 0
+}
+
+func (in *TrafficShift) SetSyncedCondition(status corev1.ConditionStatus, reason string, message string) {
+	in.Status.setCondition(ConditionSynced, status, reason, message)
+}
+
+func (in *TrafficShift) SetLastSyncedTime(time *metav1.Time) {
+	in.Status.LastSyncedTime = time
+}
+
+func (in *TrafficShift) SyncedConditionStatus() corev1.ConditionStatus {
+	cond := in.Status.GetCondition(ConditionSynced)
+	if cond == nil {
+		return corev1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+func (in *TrafficShift) Validate(_ common.ConsulMeta) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+
+	if in.Spec.ServiceSplitter == "" {
+		errs = append(errs, field.Required(path.Child("serviceSplitter"), "serviceSplitter must be set"))
+	}
+	if in.Spec.StableSubset == "" {
+		errs = append(errs, field.Required(path.Child("stableSubset"), "stableSubset must be set"))
+	}
+	if in.Spec.CanarySubset == "" {
+		errs = append(errs, field.Required(path.Child("canarySubset"), "canarySubset must be set"))
+	} else if in.Spec.CanarySubset == in.Spec.StableSubset {
+		errs = append(errs, field.Invalid(path.Child("canarySubset"), in.Spec.CanarySubset, "canarySubset must be different from stableSubset"))
+	}
+
+	if len(in.Spec.Steps) == 0 {
+		errs = append(errs, field.Required(path.Child("steps"), "at least one step must be set"))
+	}
+	for i, step := range in.Spec.Steps {
+		stepPath := path.Child("steps").Index(i)
+		if step.Weight < 0 || step.Weight > 100 {
+			errs = append(errs, field.Invalid(stepPath.Child("weight"), step.Weight, "weight must be between 0 and 100"))
+		}
+		if step.Duration.Duration < 0 {
+			errs = append(errs, field.Invalid(stepPath.Child("duration"), step.Duration.Duration.String(), "duration must not be negative"))
+		}
+	}
+
+	analysisPath := path.Child("analysis")
+	if in.Spec.Analysis.Interval.Duration < 0 {
+		errs = append(errs, field.Invalid(analysisPath.Child("interval"), in.Spec.Analysis.Interval.Duration.String(), "interval must not be negative"))
+	}
+	if in.Spec.Analysis.FailureLimit < 0 {
+		errs = append(errs, field.Invalid(analysisPath.Child("failureLimit"), in.Spec.Analysis.FailureLimit, "failureLimit must not be negative"))
+	}
+	names := make(map[string]bool)
+	for i, check := range in.Spec.Analysis.Prometheus {
+		checkPath := analysisPath.Child("prometheus").Index(i)
+		if check.Name == "" {
+			errs = append(errs, field.Required(checkPath.Child("name"), "name must be set"))
+		} else if names[check.Name] {
+			errs = append(errs, field.Duplicate(checkPath.Child("name"), check.Name))
+		}
+		names[check.Name] = true
+		if check.Address == "" {
+			errs = append(errs, field.Required(checkPath.Child("address"), "address must be set"))
+		}
+		if check.Query == "" {
+			errs = append(errs, field.Required(checkPath.Child("query"), "query must be set"))
+		}
+		if check.Min == nil && check.Max == nil {
+			errs = append(errs, field.Required(checkPath, "at least one of min or max must be set"))
+		} else if check.Min != nil && check.Max != nil && *check.Min > *check.Max {
+			errs = append(errs, field.Invalid(checkPath.Child("max"), *check.Max, fmt.Sprintf("max must not be less than min %v", *check.Min)))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: ConsulHashicorpGroup, Kind: TrafficShiftKubeKind},
+			in.KubernetesName(), errs)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTrafficShift_Validate(t *testing.T) {
+	float := func(f float32) *float32 { return &f }
+	steps := []TrafficShiftStep{
+		{Weight: 10, Duration: metav1.Duration{Duration: time.Minute}},
+		{Weight: 100},
+	}
+
+	cases := map[string]struct {
+		spec            TrafficShiftSpec
+		expectedErrMsgs []string
+	}{
+		"valid": {
+			spec: TrafficShiftSpec{
+				ServiceSplitter: "web",
+				StableSubset:    "v1",
+				CanarySubset:    "v2",
+				Steps:           steps,
+				Analysis: TrafficShiftAnalysis{
+					Interval:     metav1.Duration{Duration: 10 * time.Second},
+					FailureLimit: 1,
+					ConsulHealth: true,
+					Prometheus: []TrafficShiftPrometheusCheck{
+						{Name: "errors", Address: "http://prometheus", Query: "rate(errors[1m])", Max: float(0.01)},
+						{Name: "requests", Address: "http://prometheus", Query: "rate(requests[1m])", Min: float(1), Max: float(100)},
+					},
+				},
+			},
+		},
+		"missing fields": {
+			spec: TrafficShiftSpec{},
+			expectedErrMsgs: []string{
+				`spec.serviceSplitter: Required value: serviceSplitter must be set`,
+				`spec.stableSubset: Required value: stableSubset must be set`,
+				`spec.canarySubset: Required value: canarySubset must be set`,
+				`spec.steps: Required value: at least one step must be set`,
+			},
+		},
+		"same subsets": {
+			spec: TrafficShiftSpec{
+				ServiceSplitter: "web",
+				StableSubset:    "v1",
+				CanarySubset:    "v1",
+				Steps:           steps,
+			},
+			expectedErrMsgs: []string{
+				`spec.canarySubset: Invalid value: "v1": canarySubset must be different from stableSubset`,
+			},
+		},
+		"invalid steps": {
+			spec: TrafficShiftSpec{
+				ServiceSplitter: "web",
+				StableSubset:    "v1",
+				CanarySubset:    "v2",
+				Steps: []TrafficShiftStep{
+					{Weight: -1},
+					{Weight: 101, Duration: metav1.Duration{Duration: -time.Second}},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.steps[0].weight: Invalid value: -1: weight must be between 0 and 100`,
+				`spec.steps[1].weight: Invalid value: 101: weight must be between 0 and 100`,
+				`spec.steps[1].duration: Invalid value: "-1s": duration must not be negative`,
+			},
+		},
+		"invalid analysis": {
+			spec: TrafficShiftSpec{
+				ServiceSplitter: "web",
+				StableSubset:    "v1",
+				CanarySubset:    "v2",
+				Steps:           steps,
+				Analysis: TrafficShiftAnalysis{
+					Interval:     metav1.Duration{Duration: -time.Second},
+					FailureLimit: -1,
+					Prometheus: []TrafficShiftPrometheusCheck{
+						{},
+						{Name: "errors", Address: "http://prometheus", Query: "errors", Max: float(1)},
+						{Name: "errors", Address: "http://prometheus", Query: "errors", Min: float(2), Max: float(1)},
+					},
+				},
+			},
+			expectedErrMsgs: []string{
+				`spec.analysis.interval: Invalid value: "-1s": interval must not be negative`,
+				`spec.analysis.failureLimit: Invalid value: -1: failureLimit must not be negative`,
+				`spec.analysis.prometheus[0].name: Required value: name must be set`,
+				`spec.analysis.prometheus[0].address: Required value: address must be set`,
+				`spec.analysis.prometheus[0].query: Required value: query must be set`,
+				`spec.analysis.prometheus[0]: Required value: at least one of min or max must be set`,
+				`spec.analysis.prometheus[2].name: Duplicate value: "errors"`,
+				`spec.analysis.prometheus[2].max: Invalid value: 1: max must not be less than min 2`,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			shift := &TrafficShift{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec:       c.spec,
+			}
+			err := shift.Validate(common.ConsulMeta{})
+			if len(c.expectedErrMsgs) != 0 {
+				require.Error(t, err)
+				for _, s := range c.expectedErrMsgs {
+					require.Contains(t, err.Error(), s)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTrafficShift_AnalysisInterval(t *testing.T) {
+	shift := &TrafficShift{}
+	require.Equal(t, 30*time.Second, shift.AnalysisInterval())
+	shift.Spec.Analysis.Interval = metav1.Duration{Duration: time.Minute}
+	require.Equal(t, time.Minute, shift.AnalysisInterval())
+}
+
+func TestTrafficShift_SetSyncedCondition(t *testing.T) {
+	shift := &TrafficShift{}
+	shift.SetSyncedCondition(corev1.ConditionTrue, "reason", "message")
+
+	require.Equal(t, corev1.ConditionTrue, shift.Status.Conditions[0].Status)
+	require.Equal(t, "reason", shift.Status.Conditions[0].Reason)
+	require.Equal(t, "message", shift.Status.Conditions[0].Message)
+	require.Equal(t, corev1.ConditionTrue, shift.SyncedConditionStatus())
+}
+
+func TestTrafficShift_KubeKind(t *testing.T) {
+	require.Equal(t, "trafficshift", (&TrafficShift{}).KubeKind())
+}
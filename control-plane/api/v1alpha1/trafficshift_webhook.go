@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:object:generate=false
+
+type TrafficShiftWebhook struct {
+	client.Client
+	Logger logr.Logger
+
+	// ConsulMeta contains metadata specific to the Consul installation.
+	ConsulMeta common.ConsulMeta
+
+	decoder *admission.Decoder
+}
+
+// NOTE: The path value in the below line is the path to the webhook.
+// If it is updated, run code-gen, update subcommand/controller/command.go
+// and the consul-helm value for the path to the webhook.
+//
+// NOTE: The below line cannot be combined with any other comment. If it is
+// it will break the code generation.
+//
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-v1alpha1-trafficshift,mutating=true,failurePolicy=fail,groups=consul.hashicorp.com,resources=trafficshifts,versions=v1alpha1,name=mutate-trafficshifts.consul.hashicorp.com,sideEffects=None,admissionReviewVersions=v1beta1;v1
+
+func (v *TrafficShiftWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var shift TrafficShift
+	err := v.decoder.Decode(req, &shift)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	v.Logger.Info("validate", "operation", req.Operation, "name", shift.KubernetesName())
+	if err := shift.Validate(v.ConsulMeta); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Two traffic shifts would fight over the weights of the same splitter.
+	if req.Operation == admissionv1.Create {
+		var list TrafficShiftList
+		if err := v.Client.List(ctx, &list, client.InNamespace(req.Namespace)); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		for _, item := range list.Items {
+			if item.Name != shift.Name && item.Spec.ServiceSplitter == shift.Spec.ServiceSplitter {
+				return admission.Errored(http.StatusBadRequest,
+					fmt.Errorf("the ServiceSplitter %q is already shifted by the TrafficShift resource %q", shift.Spec.ServiceSplitter, item.Name))
+			}
+		}
+	}
+	return admission.Allowed(fmt.Sprintf("valid %s request", shift.KubeKind()))
+}
+
+func (v *TrafficShiftWebhook) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
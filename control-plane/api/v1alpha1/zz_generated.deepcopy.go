@@ -7,84 +7,102 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Condition) DeepCopyInto(out *Condition) {
+func (in *ACLBindingRule) DeepCopyInto(out *ACLBindingRule) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
-func (in *Condition) DeepCopy() *Condition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLBindingRule.
+func (in *ACLBindingRule) DeepCopy() *ACLBindingRule {
 	if in == nil {
 		return nil
 	}
-	out := new(Condition)
+	out := new(ACLBindingRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ACLBindingRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Conditions) DeepCopyInto(out *Conditions) {
-	{
-		in := &in
-		*out = make(Conditions, len(*in))
+func (in *ACLBindingRuleList) DeepCopyInto(out *ACLBindingRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ACLBindingRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Conditions.
-func (in Conditions) DeepCopy() Conditions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLBindingRuleList.
+func (in *ACLBindingRuleList) DeepCopy() *ACLBindingRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(Conditions)
+	out := new(ACLBindingRuleList)
 	in.DeepCopyInto(out)
-	return *out
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ACLBindingRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CookieConfig) DeepCopyInto(out *CookieConfig) {
+func (in *ACLBindingRuleSpec) DeepCopyInto(out *ACLBindingRuleSpec) {
 	*out = *in
-	out.TTL = in.TTL
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CookieConfig.
-func (in *CookieConfig) DeepCopy() *CookieConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLBindingRuleSpec.
+func (in *ACLBindingRuleSpec) DeepCopy() *ACLBindingRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CookieConfig)
+	out := new(ACLBindingRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExportedService) DeepCopyInto(out *ExportedService) {
+func (in *ACLNodeIdentity) DeepCopyInto(out *ACLNodeIdentity) {
 	*out = *in
-	if in.Consumers != nil {
-		in, out := &in.Consumers, &out.Consumers
-		*out = make([]ServiceConsumer, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedService.
-func (in *ExportedService) DeepCopy() *ExportedService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLNodeIdentity.
+func (in *ACLNodeIdentity) DeepCopy() *ACLNodeIdentity {
 	if in == nil {
 		return nil
 	}
-	out := new(ExportedService)
+	out := new(ACLNodeIdentity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExportedServices) DeepCopyInto(out *ExportedServices) {
+func (in *ACLPolicy) DeepCopyInto(out *ACLPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -92,18 +110,18 @@ func (in *ExportedServices) DeepCopyInto(out *ExportedServices) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedServices.
-func (in *ExportedServices) DeepCopy() *ExportedServices {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLPolicy.
+func (in *ACLPolicy) DeepCopy() *ACLPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ExportedServices)
+	out := new(ACLPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ExportedServices) DeepCopyObject() runtime.Object {
+func (in *ACLPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -111,31 +129,31 @@ func (in *ExportedServices) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExportedServicesList) DeepCopyInto(out *ExportedServicesList) {
+func (in *ACLPolicyList) DeepCopyInto(out *ACLPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ExportedServices, len(*in))
+		*out = make([]ACLPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedServicesList.
-func (in *ExportedServicesList) DeepCopy() *ExportedServicesList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLPolicyList.
+func (in *ACLPolicyList) DeepCopy() *ACLPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(ExportedServicesList)
+	out := new(ACLPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ExportedServicesList) DeepCopyObject() runtime.Object {
+func (in *ACLPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -143,178 +161,154 @@ func (in *ExportedServicesList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExportedServicesSpec) DeepCopyInto(out *ExportedServicesSpec) {
+func (in *ACLPolicySpec) DeepCopyInto(out *ACLPolicySpec) {
 	*out = *in
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = make([]ExportedService, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Datacenters != nil {
+		in, out := &in.Datacenters, &out.Datacenters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedServicesSpec.
-func (in *ExportedServicesSpec) DeepCopy() *ExportedServicesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLPolicySpec.
+func (in *ACLPolicySpec) DeepCopy() *ACLPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExportedServicesSpec)
+	out := new(ACLPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Expose) DeepCopyInto(out *Expose) {
+func (in *ACLRole) DeepCopyInto(out *ACLRole) {
 	*out = *in
-	if in.Paths != nil {
-		in, out := &in.Paths, &out.Paths
-		*out = make([]ExposePath, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Expose.
-func (in *Expose) DeepCopy() *Expose {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLRole.
+func (in *ACLRole) DeepCopy() *ACLRole {
 	if in == nil {
 		return nil
 	}
-	out := new(Expose)
+	out := new(ACLRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExposePath) DeepCopyInto(out *ExposePath) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposePath.
-func (in *ExposePath) DeepCopy() *ExposePath {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ACLRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ExposePath)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayServiceTLSConfig) DeepCopyInto(out *GatewayServiceTLSConfig) {
+func (in *ACLRoleList) DeepCopyInto(out *ACLRoleList) {
 	*out = *in
-	if in.SDS != nil {
-		in, out := &in.SDS, &out.SDS
-		*out = new(GatewayTLSSDSConfig)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ACLRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayServiceTLSConfig.
-func (in *GatewayServiceTLSConfig) DeepCopy() *GatewayServiceTLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLRoleList.
+func (in *ACLRoleList) DeepCopy() *ACLRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayServiceTLSConfig)
+	out := new(ACLRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ACLRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayTLSConfig) DeepCopyInto(out *GatewayTLSConfig) {
+func (in *ACLRoleSpec) DeepCopyInto(out *ACLRoleSpec) {
 	*out = *in
-	if in.SDS != nil {
-		in, out := &in.SDS, &out.SDS
-		*out = new(GatewayTLSSDSConfig)
-		**out = **in
-	}
-	if in.CipherSuites != nil {
-		in, out := &in.CipherSuites, &out.CipherSuites
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSConfig.
-func (in *GatewayTLSConfig) DeepCopy() *GatewayTLSConfig {
-	if in == nil {
-		return nil
+	if in.ServiceIdentities != nil {
+		in, out := &in.ServiceIdentities, &out.ServiceIdentities
+		*out = make([]ACLServiceIdentity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeIdentities != nil {
+		in, out := &in.NodeIdentities, &out.NodeIdentities
+		*out = make([]ACLNodeIdentity, len(*in))
+		copy(*out, *in)
 	}
-	out := new(GatewayTLSConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayTLSSDSConfig) DeepCopyInto(out *GatewayTLSSDSConfig) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSSDSConfig.
-func (in *GatewayTLSSDSConfig) DeepCopy() *GatewayTLSSDSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLRoleSpec.
+func (in *ACLRoleSpec) DeepCopy() *ACLRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayTLSSDSConfig)
+	out := new(ACLRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPHeaderModifiers) DeepCopyInto(out *HTTPHeaderModifiers) {
+func (in *ACLServiceIdentity) DeepCopyInto(out *ACLServiceIdentity) {
 	*out = *in
-	if in.Add != nil {
-		in, out := &in.Add, &out.Add
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Set != nil {
-		in, out := &in.Set, &out.Set
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Remove != nil {
-		in, out := &in.Remove, &out.Remove
+	if in.Datacenters != nil {
+		in, out := &in.Datacenters, &out.Datacenters
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderModifiers.
-func (in *HTTPHeaderModifiers) DeepCopy() *HTTPHeaderModifiers {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLServiceIdentity.
+func (in *ACLServiceIdentity) DeepCopy() *ACLServiceIdentity {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPHeaderModifiers)
+	out := new(ACLServiceIdentity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HashPolicy) DeepCopyInto(out *HashPolicy) {
+func (in *ACLStatus) DeepCopyInto(out *ACLStatus) {
 	*out = *in
-	if in.CookieConfig != nil {
-		in, out := &in.CookieConfig, &out.CookieConfig
-		*out = new(CookieConfig)
-		**out = **in
-	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HashPolicy.
-func (in *HashPolicy) DeepCopy() *HashPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLStatus.
+func (in *ACLStatus) DeepCopy() *ACLStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HashPolicy)
+	out := new(ACLStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressGateway) DeepCopyInto(out *IngressGateway) {
+func (in *APIGateway) DeepCopyInto(out *APIGateway) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -322,18 +316,18 @@ func (in *IngressGateway) DeepCopyInto(out *IngressGateway) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGateway.
-func (in *IngressGateway) DeepCopy() *IngressGateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIGateway.
+func (in *APIGateway) DeepCopy() *APIGateway {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressGateway)
+	out := new(APIGateway)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IngressGateway) DeepCopyObject() runtime.Object {
+func (in *APIGateway) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -341,31 +335,31 @@ func (in *IngressGateway) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressGatewayList) DeepCopyInto(out *IngressGatewayList) {
+func (in *APIGatewayList) DeepCopyInto(out *APIGatewayList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IngressGateway, len(*in))
+		*out = make([]APIGateway, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGatewayList.
-func (in *IngressGatewayList) DeepCopy() *IngressGatewayList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIGatewayList.
+func (in *APIGatewayList) DeepCopy() *APIGatewayList {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressGatewayList)
+	out := new(APIGatewayList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IngressGatewayList) DeepCopyObject() runtime.Object {
+func (in *APIGatewayList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -373,273 +367,149 @@ func (in *IngressGatewayList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressGatewaySpec) DeepCopyInto(out *IngressGatewaySpec) {
+func (in *APIGatewayListener) DeepCopyInto(out *APIGatewayListener) {
 	*out = *in
 	in.TLS.DeepCopyInto(&out.TLS)
-	if in.Listeners != nil {
-		in, out := &in.Listeners, &out.Listeners
-		*out = make([]IngressListener, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGatewaySpec.
-func (in *IngressGatewaySpec) DeepCopy() *IngressGatewaySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIGatewayListener.
+func (in *APIGatewayListener) DeepCopy() *APIGatewayListener {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressGatewaySpec)
+	out := new(APIGatewayListener)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressListener) DeepCopyInto(out *IngressListener) {
+func (in *APIGatewaySpec) DeepCopyInto(out *APIGatewaySpec) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(GatewayTLSConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = make([]IngressService, len(*in))
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]APIGatewayListener, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressListener.
-func (in *IngressListener) DeepCopy() *IngressListener {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIGatewaySpec.
+func (in *APIGatewaySpec) DeepCopy() *APIGatewaySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressListener)
+	out := new(APIGatewaySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressService) DeepCopyInto(out *IngressService) {
+func (in *APIGatewayTLSConfiguration) DeepCopyInto(out *APIGatewayTLSConfiguration) {
 	*out = *in
-	if in.Hosts != nil {
-		in, out := &in.Hosts, &out.Hosts
-		*out = make([]string, len(*in))
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]ResourceReference, len(*in))
 		copy(*out, *in)
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(GatewayServiceTLSConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RequestHeaders != nil {
-		in, out := &in.RequestHeaders, &out.RequestHeaders
-		*out = new(HTTPHeaderModifiers)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ResponseHeaders != nil {
-		in, out := &in.ResponseHeaders, &out.ResponseHeaders
-		*out = new(HTTPHeaderModifiers)
-		(*in).DeepCopyInto(*out)
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressService.
-func (in *IngressService) DeepCopy() *IngressService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIGatewayTLSConfiguration.
+func (in *APIGatewayTLSConfiguration) DeepCopy() *APIGatewayTLSConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressService)
+	out := new(APIGatewayTLSConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntentionDestination) DeepCopyInto(out *IntentionDestination) {
+func (in *AccessLogs) DeepCopyInto(out *AccessLogs) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionDestination.
-func (in *IntentionDestination) DeepCopy() *IntentionDestination {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogs.
+func (in *AccessLogs) DeepCopy() *AccessLogs {
 	if in == nil {
 		return nil
 	}
-	out := new(IntentionDestination)
+	out := new(AccessLogs)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntentionHTTPHeaderPermission) DeepCopyInto(out *IntentionHTTPHeaderPermission) {
+func (in *CompiledDiscoveryChain) DeepCopyInto(out *CompiledDiscoveryChain) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionHTTPHeaderPermission.
-func (in *IntentionHTTPHeaderPermission) DeepCopy() *IntentionHTTPHeaderPermission {
-	if in == nil {
-		return nil
-	}
-	out := new(IntentionHTTPHeaderPermission)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in IntentionHTTPHeaderPermissions) DeepCopyInto(out *IntentionHTTPHeaderPermissions) {
-	{
-		in := &in
-		*out = make(IntentionHTTPHeaderPermissions, len(*in))
-		copy(*out, *in)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionHTTPHeaderPermissions.
-func (in IntentionHTTPHeaderPermissions) DeepCopy() IntentionHTTPHeaderPermissions {
-	if in == nil {
-		return nil
-	}
-	out := new(IntentionHTTPHeaderPermissions)
-	in.DeepCopyInto(out)
-	return *out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntentionHTTPPermission) DeepCopyInto(out *IntentionHTTPPermission) {
-	*out = *in
-	if in.Header != nil {
-		in, out := &in.Header, &out.Header
-		*out = make(IntentionHTTPHeaderPermissions, len(*in))
-		copy(*out, *in)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]DiscoveryChainNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Methods != nil {
-		in, out := &in.Methods, &out.Methods
-		*out = make([]string, len(*in))
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]DiscoveryChainTarget, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionHTTPPermission.
-func (in *IntentionHTTPPermission) DeepCopy() *IntentionHTTPPermission {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompiledDiscoveryChain.
+func (in *CompiledDiscoveryChain) DeepCopy() *CompiledDiscoveryChain {
 	if in == nil {
 		return nil
 	}
-	out := new(IntentionHTTPPermission)
+	out := new(CompiledDiscoveryChain)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntentionPermission) DeepCopyInto(out *IntentionPermission) {
+func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
-	if in.HTTP != nil {
-		in, out := &in.HTTP, &out.HTTP
-		*out = new(IntentionHTTPPermission)
-		(*in).DeepCopyInto(*out)
-	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionPermission.
-func (in *IntentionPermission) DeepCopy() *IntentionPermission {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
 	if in == nil {
 		return nil
 	}
-	out := new(IntentionPermission)
+	out := new(Condition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in IntentionPermissions) DeepCopyInto(out *IntentionPermissions) {
+func (in Conditions) DeepCopyInto(out *Conditions) {
 	{
 		in := &in
-		*out = make(IntentionPermissions, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(IntentionPermission)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionPermissions.
-func (in IntentionPermissions) DeepCopy() IntentionPermissions {
-	if in == nil {
-		return nil
-	}
-	out := new(IntentionPermissions)
-	in.DeepCopyInto(out)
-	return *out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LeastRequestConfig) DeepCopyInto(out *LeastRequestConfig) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeastRequestConfig.
-func (in *LeastRequestConfig) DeepCopy() *LeastRequestConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(LeastRequestConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinkedService) DeepCopyInto(out *LinkedService) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkedService.
-func (in *LinkedService) DeepCopy() *LinkedService {
-	if in == nil {
-		return nil
-	}
-	out := new(LinkedService)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
-	*out = *in
-	if in.RingHashConfig != nil {
-		in, out := &in.RingHashConfig, &out.RingHashConfig
-		*out = new(RingHashConfig)
-		**out = **in
-	}
-	if in.LeastRequestConfig != nil {
-		in, out := &in.LeastRequestConfig, &out.LeastRequestConfig
-		*out = new(LeastRequestConfig)
-		**out = **in
-	}
-	if in.HashPolicies != nil {
-		in, out := &in.HashPolicies, &out.HashPolicies
-		*out = make([]HashPolicy, len(*in))
+		*out = make(Conditions, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancer.
-func (in *LoadBalancer) DeepCopy() *LoadBalancer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Conditions.
+func (in Conditions) DeepCopy() Conditions {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancer)
+	out := new(Conditions)
 	in.DeepCopyInto(out)
-	return out
+	return *out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Mesh) DeepCopyInto(out *Mesh) {
+func (in *ConsulKV) DeepCopyInto(out *ConsulKV) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -647,18 +517,18 @@ func (in *Mesh) DeepCopyInto(out *Mesh) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mesh.
-func (in *Mesh) DeepCopy() *Mesh {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKV.
+func (in *ConsulKV) DeepCopy() *ConsulKV {
 	if in == nil {
 		return nil
 	}
-	out := new(Mesh)
+	out := new(ConsulKV)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Mesh) DeepCopyObject() runtime.Object {
+func (in *ConsulKV) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -666,81 +536,91 @@ func (in *Mesh) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MeshDirectionalTLSConfig) DeepCopyInto(out *MeshDirectionalTLSConfig) {
+func (in *ConsulKVDataSource) DeepCopyInto(out *ConsulKVDataSource) {
 	*out = *in
-	if in.CipherSuites != nil {
-		in, out := &in.CipherSuites, &out.CipherSuites
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConsulKVSourceReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(ConsulKVSourceReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshDirectionalTLSConfig.
-func (in *MeshDirectionalTLSConfig) DeepCopy() *MeshDirectionalTLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKVDataSource.
+func (in *ConsulKVDataSource) DeepCopy() *ConsulKVDataSource {
 	if in == nil {
 		return nil
 	}
-	out := new(MeshDirectionalTLSConfig)
+	out := new(ConsulKVDataSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MeshGateway) DeepCopyInto(out *MeshGateway) {
+func (in *ConsulKVEntry) DeepCopyInto(out *ConsulKVEntry) {
 	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ConsulKVValueSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshGateway.
-func (in *MeshGateway) DeepCopy() *MeshGateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKVEntry.
+func (in *ConsulKVEntry) DeepCopy() *ConsulKVEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(MeshGateway)
+	out := new(ConsulKVEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MeshHTTPConfig) DeepCopyInto(out *MeshHTTPConfig) {
+func (in *ConsulKVKeyStatus) DeepCopyInto(out *ConsulKVKeyStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshHTTPConfig.
-func (in *MeshHTTPConfig) DeepCopy() *MeshHTTPConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKVKeyStatus.
+func (in *ConsulKVKeyStatus) DeepCopy() *ConsulKVKeyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MeshHTTPConfig)
+	out := new(ConsulKVKeyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MeshList) DeepCopyInto(out *MeshList) {
+func (in *ConsulKVList) DeepCopyInto(out *ConsulKVList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Mesh, len(*in))
+		*out = make([]ConsulKV, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshList.
-func (in *MeshList) DeepCopy() *MeshList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKVList.
+func (in *ConsulKVList) DeepCopy() *ConsulKVList {
 	if in == nil {
 		return nil
 	}
-	out := new(MeshList)
+	out := new(ConsulKVList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MeshList) DeepCopyObject() runtime.Object {
+func (in *ConsulKVList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -748,104 +628,97 @@ func (in *MeshList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MeshSpec) DeepCopyInto(out *MeshSpec) {
+func (in *ConsulKVSourceReference) DeepCopyInto(out *ConsulKVSourceReference) {
 	*out = *in
-	out.TransparentProxy = in.TransparentProxy
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(MeshTLSConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.HTTP != nil {
-		in, out := &in.HTTP, &out.HTTP
-		*out = new(MeshHTTPConfig)
-		**out = **in
-	}
-	if in.Peering != nil {
-		in, out := &in.Peering, &out.Peering
-		*out = new(PeeringMeshConfig)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshSpec.
-func (in *MeshSpec) DeepCopy() *MeshSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKVSourceReference.
+func (in *ConsulKVSourceReference) DeepCopy() *ConsulKVSourceReference {
 	if in == nil {
 		return nil
 	}
-	out := new(MeshSpec)
+	out := new(ConsulKVSourceReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MeshTLSConfig) DeepCopyInto(out *MeshTLSConfig) {
+func (in *ConsulKVSpec) DeepCopyInto(out *ConsulKVSpec) {
 	*out = *in
-	if in.Incoming != nil {
-		in, out := &in.Incoming, &out.Incoming
-		*out = new(MeshDirectionalTLSConfig)
-		(*in).DeepCopyInto(*out)
+	if in.DataFrom != nil {
+		in, out := &in.DataFrom, &out.DataFrom
+		*out = make([]ConsulKVDataSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Outgoing != nil {
-		in, out := &in.Outgoing, &out.Outgoing
-		*out = new(MeshDirectionalTLSConfig)
-		(*in).DeepCopyInto(*out)
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]ConsulKVEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshTLSConfig.
-func (in *MeshTLSConfig) DeepCopy() *MeshTLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKVSpec.
+func (in *ConsulKVSpec) DeepCopy() *ConsulKVSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MeshTLSConfig)
+	out := new(ConsulKVSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PassiveHealthCheck) DeepCopyInto(out *PassiveHealthCheck) {
+func (in *ConsulKVStatus) DeepCopyInto(out *ConsulKVStatus) {
 	*out = *in
-	out.Interval = in.Interval
-	if in.EnforcingConsecutive5xx != nil {
-		in, out := &in.EnforcingConsecutive5xx, &out.EnforcingConsecutive5xx
-		*out = new(uint32)
-		**out = **in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]ConsulKVKeyStatus, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PassiveHealthCheck.
-func (in *PassiveHealthCheck) DeepCopy() *PassiveHealthCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKVStatus.
+func (in *ConsulKVStatus) DeepCopy() *ConsulKVStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PassiveHealthCheck)
+	out := new(ConsulKVStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Peer) DeepCopyInto(out *Peer) {
+func (in *ConsulKVValueSource) DeepCopyInto(out *ConsulKVValueSource) {
 	*out = *in
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(Secret)
-		**out = **in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Peer.
-func (in *Peer) DeepCopy() *Peer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulKVValueSource.
+func (in *ConsulKVValueSource) DeepCopy() *ConsulKVValueSource {
 	if in == nil {
 		return nil
 	}
-	out := new(Peer)
+	out := new(ConsulKVValueSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringAcceptor) DeepCopyInto(out *PeeringAcceptor) {
+func (in *ConsulNamespace) DeepCopyInto(out *ConsulNamespace) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -853,18 +726,18 @@ func (in *PeeringAcceptor) DeepCopyInto(out *PeeringAcceptor) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAcceptor.
-func (in *PeeringAcceptor) DeepCopy() *PeeringAcceptor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulNamespace.
+func (in *ConsulNamespace) DeepCopy() *ConsulNamespace {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringAcceptor)
+	out := new(ConsulNamespace)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PeeringAcceptor) DeepCopyObject() runtime.Object {
+func (in *ConsulNamespace) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -872,225 +745,2933 @@ func (in *PeeringAcceptor) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringAcceptorList) DeepCopyInto(out *PeeringAcceptorList) {
+func (in *ConsulNamespaceList) DeepCopyInto(out *ConsulNamespaceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PeeringAcceptor, len(*in))
+		*out = make([]ConsulNamespace, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAcceptorList.
-func (in *PeeringAcceptorList) DeepCopy() *PeeringAcceptorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulNamespaceList.
+func (in *ConsulNamespaceList) DeepCopy() *ConsulNamespaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulNamespaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsulNamespaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulNamespaceSpec) DeepCopyInto(out *ConsulNamespaceSpec) {
+	*out = *in
+	if in.Meta != nil {
+		in, out := &in.Meta, &out.Meta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ACLs != nil {
+		in, out := &in.ACLs, &out.ACLs
+		*out = new(NamespaceACLConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulNamespaceSpec.
+func (in *ConsulNamespaceSpec) DeepCopy() *ConsulNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulPartition) DeepCopyInto(out *ConsulPartition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulPartition.
+func (in *ConsulPartition) DeepCopy() *ConsulPartition {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulPartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsulPartition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulPartitionList) DeepCopyInto(out *ConsulPartitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConsulPartition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulPartitionList.
+func (in *ConsulPartitionList) DeepCopy() *ConsulPartitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulPartitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsulPartitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulPartitionSpec) DeepCopyInto(out *ConsulPartitionSpec) {
+	*out = *in
+	if in.DefaultNamespace != nil {
+		in, out := &in.DefaultNamespace, &out.DefaultNamespace
+		*out = new(PartitionDefaultNamespace)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulPartitionSpec.
+func (in *ConsulPartitionSpec) DeepCopy() *ConsulPartitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulPartitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CookieConfig) DeepCopyInto(out *CookieConfig) {
+	*out = *in
+	out.TTL = in.TTL
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CookieConfig.
+func (in *CookieConfig) DeepCopy() *CookieConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CookieConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveryChainNode) DeepCopyInto(out *DiscoveryChainNode) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Splits != nil {
+		in, out := &in.Splits, &out.Splits
+		*out = make([]DiscoveryChainSplit, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryChainNode.
+func (in *DiscoveryChainNode) DeepCopy() *DiscoveryChainNode {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveryChainNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveryChainSplit) DeepCopyInto(out *DiscoveryChainSplit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryChainSplit.
+func (in *DiscoveryChainSplit) DeepCopy() *DiscoveryChainSplit {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveryChainSplit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveryChainStatus) DeepCopyInto(out *DiscoveryChainStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.DiscoveryChain != nil {
+		in, out := &in.DiscoveryChain, &out.DiscoveryChain
+		*out = new(CompiledDiscoveryChain)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryChainStatus.
+func (in *DiscoveryChainStatus) DeepCopy() *DiscoveryChainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveryChainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveryChainTarget) DeepCopyInto(out *DiscoveryChainTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryChainTarget.
+func (in *DiscoveryChainTarget) DeepCopy() *DiscoveryChainTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveryChainTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRun) DeepCopyInto(out *DryRun) {
+	*out = *in
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRun.
+func (in *DryRun) DeepCopy() *DryRun {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyExtension) DeepCopyInto(out *EnvoyExtension) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyExtension.
+func (in *EnvoyExtension) DeepCopy() *EnvoyExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in EnvoyExtensions) DeepCopyInto(out *EnvoyExtensions) {
+	{
+		in := &in
+		*out = make(EnvoyExtensions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyExtensions.
+func (in EnvoyExtensions) DeepCopy() EnvoyExtensions {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyExtensions)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedService) DeepCopyInto(out *ExportedService) {
+	*out = *in
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]ServiceConsumer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedService.
+func (in *ExportedService) DeepCopy() *ExportedService {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedServices) DeepCopyInto(out *ExportedServices) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedServices.
+func (in *ExportedServices) DeepCopy() *ExportedServices {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedServices)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExportedServices) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedServicesList) DeepCopyInto(out *ExportedServicesList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExportedServices, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedServicesList.
+func (in *ExportedServicesList) DeepCopy() *ExportedServicesList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedServicesList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExportedServicesList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedServicesSpec) DeepCopyInto(out *ExportedServicesSpec) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ExportedService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedServicesSpec.
+func (in *ExportedServicesSpec) DeepCopy() *ExportedServicesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedServicesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Expose) DeepCopyInto(out *Expose) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]ExposePath, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Expose.
+func (in *Expose) DeepCopy() *Expose {
+	if in == nil {
+		return nil
+	}
+	out := new(Expose)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposePath) DeepCopyInto(out *ExposePath) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposePath.
+func (in *ExposePath) DeepCopy() *ExposePath {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposePath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalService) DeepCopyInto(out *ExternalService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalService.
+func (in *ExternalService) DeepCopy() *ExternalService {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExternalService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalServiceList) DeepCopyInto(out *ExternalServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExternalService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalServiceList.
+func (in *ExternalServiceList) DeepCopy() *ExternalServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExternalServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalServiceSpec) DeepCopyInto(out *ExternalServiceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalServiceSpec.
+func (in *ExternalServiceSpec) DeepCopy() *ExternalServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalServiceStatus) DeepCopyInto(out *ExternalServiceStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalServiceStatus.
+func (in *ExternalServiceStatus) DeepCopy() *ExternalServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayServiceTLSConfig) DeepCopyInto(out *GatewayServiceTLSConfig) {
+	*out = *in
+	if in.SDS != nil {
+		in, out := &in.SDS, &out.SDS
+		*out = new(GatewayTLSSDSConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayServiceTLSConfig.
+func (in *GatewayServiceTLSConfig) DeepCopy() *GatewayServiceTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayServiceTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTLSConfig) DeepCopyInto(out *GatewayTLSConfig) {
+	*out = *in
+	if in.SDS != nil {
+		in, out := &in.SDS, &out.SDS
+		*out = new(GatewayTLSSDSConfig)
+		**out = **in
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSConfig.
+func (in *GatewayTLSConfig) DeepCopy() *GatewayTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTLSSDSConfig) DeepCopyInto(out *GatewayTLSSDSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSSDSConfig.
+func (in *GatewayTLSSDSConfig) DeepCopy() *GatewayTLSSDSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTLSSDSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPFilters) DeepCopyInto(out *HTTPFilters) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeaderFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.URLRewrite != nil {
+		in, out := &in.URLRewrite, &out.URLRewrite
+		*out = new(URLRewrite)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPFilters.
+func (in *HTTPFilters) DeepCopy() *HTTPFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderFilter) DeepCopyInto(out *HTTPHeaderFilter) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderFilter.
+func (in *HTTPHeaderFilter) DeepCopy() *HTTPHeaderFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderMatch) DeepCopyInto(out *HTTPHeaderMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderMatch.
+func (in *HTTPHeaderMatch) DeepCopy() *HTTPHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderModifiers) DeepCopyInto(out *HTTPHeaderModifiers) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderModifiers.
+func (in *HTTPHeaderModifiers) DeepCopy() *HTTPHeaderModifiers {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderModifiers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPMatch) DeepCopyInto(out *HTTPMatch) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	out.Path = in.Path
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = make([]HTTPQueryMatch, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPMatch.
+func (in *HTTPMatch) DeepCopy() *HTTPMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPPathMatch) DeepCopyInto(out *HTTPPathMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPathMatch.
+func (in *HTTPPathMatch) DeepCopy() *HTTPPathMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPPathMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPQueryMatch) DeepCopyInto(out *HTTPQueryMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPQueryMatch.
+func (in *HTTPQueryMatch) DeepCopy() *HTTPQueryMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPQueryMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRoute) DeepCopyInto(out *HTTPRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRoute.
+func (in *HTTPRoute) DeepCopy() *HTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HTTPRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRouteList) DeepCopyInto(out *HTTPRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HTTPRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteList.
+func (in *HTTPRouteList) DeepCopy() *HTTPRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HTTPRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRouteRule) DeepCopyInto(out *HTTPRouteRule) {
+	*out = *in
+	in.Filters.DeepCopyInto(&out.Filters)
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]HTTPMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]HTTPService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteRule.
+func (in *HTTPRouteRule) DeepCopy() *HTTPRouteRule {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRouteRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRouteSpec) DeepCopyInto(out *HTTPRouteSpec) {
+	*out = *in
+	if in.Parents != nil {
+		in, out := &in.Parents, &out.Parents
+		*out = make([]ResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]HTTPRouteRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteSpec.
+func (in *HTTPRouteSpec) DeepCopy() *HTTPRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPService) DeepCopyInto(out *HTTPService) {
+	*out = *in
+	in.Filters.DeepCopyInto(&out.Filters)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPService.
+func (in *HTTPService) DeepCopy() *HTTPService {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HashPolicy) DeepCopyInto(out *HashPolicy) {
+	*out = *in
+	if in.CookieConfig != nil {
+		in, out := &in.CookieConfig, &out.CookieConfig
+		*out = new(CookieConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HashPolicy.
+func (in *HashPolicy) DeepCopy() *HashPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HashPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGateway) DeepCopyInto(out *IngressGateway) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGateway.
+func (in *IngressGateway) DeepCopy() *IngressGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IngressGateway) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGatewayAutoscaling) DeepCopyInto(out *IngressGatewayAutoscaling) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGatewayAutoscaling.
+func (in *IngressGatewayAutoscaling) DeepCopy() *IngressGatewayAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGatewayAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGatewayDeployment) DeepCopyInto(out *IngressGatewayDeployment) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(IngressGatewayPodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(IngressGatewayAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGatewayDeployment.
+func (in *IngressGatewayDeployment) DeepCopy() *IngressGatewayDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGatewayDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGatewayList) DeepCopyInto(out *IngressGatewayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IngressGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGatewayList.
+func (in *IngressGatewayList) DeepCopy() *IngressGatewayList {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGatewayList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IngressGatewayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGatewayPodDisruptionBudget) DeepCopyInto(out *IngressGatewayPodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGatewayPodDisruptionBudget.
+func (in *IngressGatewayPodDisruptionBudget) DeepCopy() *IngressGatewayPodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGatewayPodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGatewaySpec) DeepCopyInto(out *IngressGatewaySpec) {
+	*out = *in
+	in.TLS.DeepCopyInto(&out.TLS)
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]IngressListener, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(IngressGatewayDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGatewaySpec.
+func (in *IngressGatewaySpec) DeepCopy() *IngressGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressListener) DeepCopyInto(out *IngressListener) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(GatewayTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]IngressService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressListener.
+func (in *IngressListener) DeepCopy() *IngressListener {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressListener)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressService) DeepCopyInto(out *IngressService) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(GatewayServiceTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = new(HTTPHeaderModifiers)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(HTTPHeaderModifiers)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressService.
+func (in *IngressService) DeepCopy() *IngressService {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InlineCertificate) DeepCopyInto(out *InlineCertificate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineCertificate.
+func (in *InlineCertificate) DeepCopy() *InlineCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(InlineCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InlineCertificate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InlineCertificateList) DeepCopyInto(out *InlineCertificateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InlineCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineCertificateList.
+func (in *InlineCertificateList) DeepCopy() *InlineCertificateList {
+	if in == nil {
+		return nil
+	}
+	out := new(InlineCertificateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InlineCertificateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InlineCertificateSpec) DeepCopyInto(out *InlineCertificateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineCertificateSpec.
+func (in *InlineCertificateSpec) DeepCopy() *InlineCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InlineCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceLevelRateLimits) DeepCopyInto(out *InstanceLevelRateLimits) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]InstanceLevelRouteRateLimits, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceLevelRateLimits.
+func (in *InstanceLevelRateLimits) DeepCopy() *InstanceLevelRateLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceLevelRateLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceLevelRouteRateLimits) DeepCopyInto(out *InstanceLevelRouteRateLimits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceLevelRouteRateLimits.
+func (in *InstanceLevelRouteRateLimits) DeepCopy() *InstanceLevelRouteRateLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceLevelRouteRateLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntentionDestination) DeepCopyInto(out *IntentionDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionDestination.
+func (in *IntentionDestination) DeepCopy() *IntentionDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntentionHTTPHeaderPermission) DeepCopyInto(out *IntentionHTTPHeaderPermission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionHTTPHeaderPermission.
+func (in *IntentionHTTPHeaderPermission) DeepCopy() *IntentionHTTPHeaderPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionHTTPHeaderPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in IntentionHTTPHeaderPermissions) DeepCopyInto(out *IntentionHTTPHeaderPermissions) {
+	{
+		in := &in
+		*out = make(IntentionHTTPHeaderPermissions, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionHTTPHeaderPermissions.
+func (in IntentionHTTPHeaderPermissions) DeepCopy() IntentionHTTPHeaderPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionHTTPHeaderPermissions)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntentionHTTPPermission) DeepCopyInto(out *IntentionHTTPPermission) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = make(IntentionHTTPHeaderPermissions, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionHTTPPermission.
+func (in *IntentionHTTPPermission) DeepCopy() *IntentionHTTPPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionHTTPPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntentionJWTClaimVerification) DeepCopyInto(out *IntentionJWTClaimVerification) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionJWTClaimVerification.
+func (in *IntentionJWTClaimVerification) DeepCopy() *IntentionJWTClaimVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionJWTClaimVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntentionJWTProvider) DeepCopyInto(out *IntentionJWTProvider) {
+	*out = *in
+	if in.VerifyClaims != nil {
+		in, out := &in.VerifyClaims, &out.VerifyClaims
+		*out = make([]*IntentionJWTClaimVerification, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(IntentionJWTClaimVerification)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionJWTProvider.
+func (in *IntentionJWTProvider) DeepCopy() *IntentionJWTProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionJWTProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntentionJWTRequirement) DeepCopyInto(out *IntentionJWTRequirement) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]*IntentionJWTProvider, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(IntentionJWTProvider)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionJWTRequirement.
+func (in *IntentionJWTRequirement) DeepCopy() *IntentionJWTRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionJWTRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntentionPermission) DeepCopyInto(out *IntentionPermission) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(IntentionHTTPPermission)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(IntentionJWTRequirement)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionPermission.
+func (in *IntentionPermission) DeepCopy() *IntentionPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in IntentionPermissions) DeepCopyInto(out *IntentionPermissions) {
+	{
+		in := &in
+		*out = make(IntentionPermissions, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(IntentionPermission)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntentionPermissions.
+func (in IntentionPermissions) DeepCopy() IntentionPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(IntentionPermissions)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONWebKeySet) DeepCopyInto(out *JSONWebKeySet) {
+	*out = *in
+	if in.Local != nil {
+		in, out := &in.Local, &out.Local
+		*out = new(LocalJWKS)
+		**out = **in
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteJWKS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONWebKeySet.
+func (in *JSONWebKeySet) DeepCopy() *JSONWebKeySet {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONWebKeySet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWKSRetryPolicy) DeepCopyInto(out *JWKSRetryPolicy) {
+	*out = *in
+	if in.RetryPolicyBackOff != nil {
+		in, out := &in.RetryPolicyBackOff, &out.RetryPolicyBackOff
+		*out = new(RetryPolicyBackOff)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWKSRetryPolicy.
+func (in *JWKSRetryPolicy) DeepCopy() *JWKSRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(JWKSRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTCacheConfig) DeepCopyInto(out *JWTCacheConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTCacheConfig.
+func (in *JWTCacheConfig) DeepCopy() *JWTCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTForwardingConfig) DeepCopyInto(out *JWTForwardingConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTForwardingConfig.
+func (in *JWTForwardingConfig) DeepCopy() *JWTForwardingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTForwardingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTLocation) DeepCopyInto(out *JWTLocation) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(JWTLocationHeader)
+		**out = **in
+	}
+	if in.QueryParam != nil {
+		in, out := &in.QueryParam, &out.QueryParam
+		*out = new(JWTLocationQueryParam)
+		**out = **in
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(JWTLocationCookie)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTLocation.
+func (in *JWTLocation) DeepCopy() *JWTLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTLocationCookie) DeepCopyInto(out *JWTLocationCookie) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTLocationCookie.
+func (in *JWTLocationCookie) DeepCopy() *JWTLocationCookie {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTLocationCookie)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTLocationHeader) DeepCopyInto(out *JWTLocationHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTLocationHeader.
+func (in *JWTLocationHeader) DeepCopy() *JWTLocationHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTLocationHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTLocationQueryParam) DeepCopyInto(out *JWTLocationQueryParam) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTLocationQueryParam.
+func (in *JWTLocationQueryParam) DeepCopy() *JWTLocationQueryParam {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTLocationQueryParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTProvider) DeepCopyInto(out *JWTProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTProvider.
+func (in *JWTProvider) DeepCopy() *JWTProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JWTProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTProviderList) DeepCopyInto(out *JWTProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JWTProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTProviderList.
+func (in *JWTProviderList) DeepCopy() *JWTProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JWTProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTProviderSpec) DeepCopyInto(out *JWTProviderSpec) {
+	*out = *in
+	if in.JSONWebKeySet != nil {
+		in, out := &in.JSONWebKeySet, &out.JSONWebKeySet
+		*out = new(JSONWebKeySet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]*JWTLocation, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(JWTLocation)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Forwarding != nil {
+		in, out := &in.Forwarding, &out.Forwarding
+		*out = new(JWTForwardingConfig)
+		**out = **in
+	}
+	if in.CacheConfig != nil {
+		in, out := &in.CacheConfig, &out.CacheConfig
+		*out = new(JWTCacheConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTProviderSpec.
+func (in *JWTProviderSpec) DeepCopy() *JWTProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeastRequestConfig) DeepCopyInto(out *LeastRequestConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeastRequestConfig.
+func (in *LeastRequestConfig) DeepCopy() *LeastRequestConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LeastRequestConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkedService) DeepCopyInto(out *LinkedService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkedService.
+func (in *LinkedService) DeepCopy() *LinkedService {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkedService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
+	*out = *in
+	if in.RingHashConfig != nil {
+		in, out := &in.RingHashConfig, &out.RingHashConfig
+		*out = new(RingHashConfig)
+		**out = **in
+	}
+	if in.LeastRequestConfig != nil {
+		in, out := &in.LeastRequestConfig, &out.LeastRequestConfig
+		*out = new(LeastRequestConfig)
+		**out = **in
+	}
+	if in.HashPolicies != nil {
+		in, out := &in.HashPolicies, &out.HashPolicies
+		*out = make([]HashPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancer.
+func (in *LoadBalancer) DeepCopy() *LoadBalancer {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalJWKS) DeepCopyInto(out *LocalJWKS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalJWKS.
+func (in *LocalJWKS) DeepCopy() *LocalJWKS {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalJWKS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mesh) DeepCopyInto(out *Mesh) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mesh.
+func (in *Mesh) DeepCopy() *Mesh {
+	if in == nil {
+		return nil
+	}
+	out := new(Mesh)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Mesh) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshDirectionalTLSConfig) DeepCopyInto(out *MeshDirectionalTLSConfig) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshDirectionalTLSConfig.
+func (in *MeshDirectionalTLSConfig) DeepCopy() *MeshDirectionalTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshDirectionalTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshGateway) DeepCopyInto(out *MeshGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshGateway.
+func (in *MeshGateway) DeepCopy() *MeshGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshHTTPConfig) DeepCopyInto(out *MeshHTTPConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshHTTPConfig.
+func (in *MeshHTTPConfig) DeepCopy() *MeshHTTPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshHTTPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshList) DeepCopyInto(out *MeshList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Mesh, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshList.
+func (in *MeshList) DeepCopy() *MeshList {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MeshList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshSpec) DeepCopyInto(out *MeshSpec) {
+	*out = *in
+	out.TransparentProxy = in.TransparentProxy
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MeshTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(MeshHTTPConfig)
+		**out = **in
+	}
+	if in.Peering != nil {
+		in, out := &in.Peering, &out.Peering
+		*out = new(PeeringMeshConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshSpec.
+func (in *MeshSpec) DeepCopy() *MeshSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshTLSConfig) DeepCopyInto(out *MeshTLSConfig) {
+	*out = *in
+	if in.Incoming != nil {
+		in, out := &in.Incoming, &out.Incoming
+		*out = new(MeshDirectionalTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outgoing != nil {
+		in, out := &in.Outgoing, &out.Outgoing
+		*out = new(MeshDirectionalTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshTLSConfig.
+func (in *MeshTLSConfig) DeepCopy() *MeshTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceACLConfig) DeepCopyInto(out *NamespaceACLConfig) {
+	*out = *in
+	if in.PolicyDefaults != nil {
+		in, out := &in.PolicyDefaults, &out.PolicyDefaults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoleDefaults != nil {
+		in, out := &in.RoleDefaults, &out.RoleDefaults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceACLConfig.
+func (in *NamespaceACLConfig) DeepCopy() *NamespaceACLConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceACLConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionDefaultNamespace) DeepCopyInto(out *PartitionDefaultNamespace) {
+	*out = *in
+	if in.Meta != nil {
+		in, out := &in.Meta, &out.Meta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ACLs != nil {
+		in, out := &in.ACLs, &out.ACLs
+		*out = new(NamespaceACLConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionDefaultNamespace.
+func (in *PartitionDefaultNamespace) DeepCopy() *PartitionDefaultNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionDefaultNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PassiveHealthCheck) DeepCopyInto(out *PassiveHealthCheck) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.EnforcingConsecutive5xx != nil {
+		in, out := &in.EnforcingConsecutive5xx, &out.EnforcingConsecutive5xx
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PassiveHealthCheck.
+func (in *PassiveHealthCheck) DeepCopy() *PassiveHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PassiveHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Peer) DeepCopyInto(out *Peer) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(Secret)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Peer.
+func (in *Peer) DeepCopy() *Peer {
+	if in == nil {
+		return nil
+	}
+	out := new(Peer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringAcceptor) DeepCopyInto(out *PeeringAcceptor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAcceptor.
+func (in *PeeringAcceptor) DeepCopy() *PeeringAcceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringAcceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringAcceptor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringAcceptorList) DeepCopyInto(out *PeeringAcceptorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PeeringAcceptor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAcceptorList.
+func (in *PeeringAcceptorList) DeepCopy() *PeeringAcceptorList {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringAcceptorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringAcceptorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringAcceptorSpec) DeepCopyInto(out *PeeringAcceptorSpec) {
+	*out = *in
+	if in.Peer != nil {
+		in, out := &in.Peer, &out.Peer
+		*out = new(Peer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAcceptorSpec.
+func (in *PeeringAcceptorSpec) DeepCopy() *PeeringAcceptorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringAcceptorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringAcceptorStatus) DeepCopyInto(out *PeeringAcceptorStatus) {
+	*out = *in
+	if in.LatestPeeringVersion != nil {
+		in, out := &in.LatestPeeringVersion, &out.LatestPeeringVersion
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRefStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncedTime != nil {
+		in, out := &in.LastSyncedTime, &out.LastSyncedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAcceptorStatus.
+func (in *PeeringAcceptorStatus) DeepCopy() *PeeringAcceptorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringAcceptorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringDialer) DeepCopyInto(out *PeeringDialer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringDialer.
+func (in *PeeringDialer) DeepCopy() *PeeringDialer {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringDialer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringDialer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringDialerList) DeepCopyInto(out *PeeringDialerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PeeringDialer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringDialerList.
+func (in *PeeringDialerList) DeepCopy() *PeeringDialerList {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringDialerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringDialerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringDialerSpec) DeepCopyInto(out *PeeringDialerSpec) {
+	*out = *in
+	if in.Peer != nil {
+		in, out := &in.Peer, &out.Peer
+		*out = new(Peer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringDialerSpec.
+func (in *PeeringDialerSpec) DeepCopy() *PeeringDialerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringDialerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringDialerStatus) DeepCopyInto(out *PeeringDialerStatus) {
+	*out = *in
+	if in.LatestPeeringVersion != nil {
+		in, out := &in.LatestPeeringVersion, &out.LatestPeeringVersion
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRefStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncedTime != nil {
+		in, out := &in.LastSyncedTime, &out.LastSyncedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringDialerStatus.
+func (in *PeeringDialerStatus) DeepCopy() *PeeringDialerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringDialerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringMeshConfig) DeepCopyInto(out *PeeringMeshConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringMeshConfig.
+func (in *PeeringMeshConfig) DeepCopy() *PeeringMeshConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringMeshConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQuery) DeepCopyInto(out *PreparedQuery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQuery.
+func (in *PreparedQuery) DeepCopy() *PreparedQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreparedQuery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQueryDNS) DeepCopyInto(out *PreparedQueryDNS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQueryDNS.
+func (in *PreparedQueryDNS) DeepCopy() *PreparedQueryDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQueryDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQueryFailover) DeepCopyInto(out *PreparedQueryFailover) {
+	*out = *in
+	if in.Datacenters != nil {
+		in, out := &in.Datacenters, &out.Datacenters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]PreparedQueryFailoverTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQueryFailover.
+func (in *PreparedQueryFailover) DeepCopy() *PreparedQueryFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQueryFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQueryFailoverTarget) DeepCopyInto(out *PreparedQueryFailoverTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQueryFailoverTarget.
+func (in *PreparedQueryFailoverTarget) DeepCopy() *PreparedQueryFailoverTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQueryFailoverTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQueryList) DeepCopyInto(out *PreparedQueryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PreparedQuery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQueryList.
+func (in *PreparedQueryList) DeepCopy() *PreparedQueryList {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQueryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreparedQueryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQueryService) DeepCopyInto(out *PreparedQueryService) {
+	*out = *in
+	in.Failover.DeepCopyInto(&out.Failover)
+	if in.IgnoreCheckIDs != nil {
+		in, out := &in.IgnoreCheckIDs, &out.IgnoreCheckIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeMeta != nil {
+		in, out := &in.NodeMeta, &out.NodeMeta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceMeta != nil {
+		in, out := &in.ServiceMeta, &out.ServiceMeta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQueryService.
+func (in *PreparedQueryService) DeepCopy() *PreparedQueryService {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQueryService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQuerySpec) DeepCopyInto(out *PreparedQuerySpec) {
+	*out = *in
+	in.Service.DeepCopyInto(&out.Service)
+	out.DNS = in.DNS
+	out.Template = in.Template
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQuerySpec.
+func (in *PreparedQuerySpec) DeepCopy() *PreparedQuerySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQuerySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQueryStatus) DeepCopyInto(out *PreparedQueryStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQueryStatus.
+func (in *PreparedQueryStatus) DeepCopy() *PreparedQueryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQueryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreparedQueryTemplate) DeepCopyInto(out *PreparedQueryTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreparedQueryTemplate.
+func (in *PreparedQueryTemplate) DeepCopy() *PreparedQueryTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PreparedQueryTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDefaults) DeepCopyInto(out *ProxyDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaults.
+func (in *ProxyDefaults) DeepCopy() *ProxyDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProxyDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDefaultsList) DeepCopyInto(out *ProxyDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProxyDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaultsList.
+func (in *ProxyDefaultsList) DeepCopy() *ProxyDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProxyDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDefaultsSpec) DeepCopyInto(out *ProxyDefaultsSpec) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(ProxyMode)
+		**out = **in
+	}
+	if in.TransparentProxy != nil {
+		in, out := &in.TransparentProxy, &out.TransparentProxy
+		*out = new(TransparentProxy)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	out.MeshGateway = in.MeshGateway
+	in.Expose.DeepCopyInto(&out.Expose)
+	if in.AccessLogs != nil {
+		in, out := &in.AccessLogs, &out.AccessLogs
+		*out = new(AccessLogs)
+		**out = **in
+	}
+	if in.EnvoyExtensions != nil {
+		in, out := &in.EnvoyExtensions, &out.EnvoyExtensions
+		*out = make(EnvoyExtensions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaultsSpec.
+func (in *ProxyDefaultsSpec) DeepCopy() *ProxyDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimits) DeepCopyInto(out *RateLimits) {
+	*out = *in
+	in.InstanceLevel.DeepCopyInto(&out.InstanceLevel)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimits.
+func (in *RateLimits) DeepCopy() *RateLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Registration) DeepCopyInto(out *Registration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registration.
+func (in *Registration) DeepCopy() *Registration {
+	if in == nil {
+		return nil
+	}
+	out := new(Registration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Registration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationCheck) DeepCopyInto(out *RegistrationCheck) {
+	*out = *in
+	in.Definition.DeepCopyInto(&out.Definition)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationCheck.
+func (in *RegistrationCheck) DeepCopy() *RegistrationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistrationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationCheckDefinition) DeepCopyInto(out *RegistrationCheckDefinition) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	out.Interval = in.Interval
+	out.Timeout = in.Timeout
+	out.DeregisterCriticalServiceAfter = in.DeregisterCriticalServiceAfter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationCheckDefinition.
+func (in *RegistrationCheckDefinition) DeepCopy() *RegistrationCheckDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistrationCheckDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationList) DeepCopyInto(out *RegistrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Registration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationList.
+func (in *RegistrationList) DeepCopy() *RegistrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RegistrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationLocality) DeepCopyInto(out *RegistrationLocality) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationLocality.
+func (in *RegistrationLocality) DeepCopy() *RegistrationLocality {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistrationLocality)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationService) DeepCopyInto(out *RegistrationService) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Meta != nil {
+		in, out := &in.Meta, &out.Meta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TaggedAddresses != nil {
+		in, out := &in.TaggedAddresses, &out.TaggedAddresses
+		*out = make(map[string]RegistrationServiceAddress, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Locality != nil {
+		in, out := &in.Locality, &out.Locality
+		*out = new(RegistrationLocality)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationService.
+func (in *RegistrationService) DeepCopy() *RegistrationService {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringAcceptorList)
+	out := new(RegistrationService)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PeeringAcceptorList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringAcceptorSpec) DeepCopyInto(out *PeeringAcceptorSpec) {
+func (in *RegistrationServiceAddress) DeepCopyInto(out *RegistrationServiceAddress) {
 	*out = *in
-	if in.Peer != nil {
-		in, out := &in.Peer, &out.Peer
-		*out = new(Peer)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAcceptorSpec.
-func (in *PeeringAcceptorSpec) DeepCopy() *PeeringAcceptorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationServiceAddress.
+func (in *RegistrationServiceAddress) DeepCopy() *RegistrationServiceAddress {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringAcceptorSpec)
+	out := new(RegistrationServiceAddress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringAcceptorStatus) DeepCopyInto(out *PeeringAcceptorStatus) {
+func (in *RegistrationSpec) DeepCopyInto(out *RegistrationSpec) {
 	*out = *in
-	if in.LatestPeeringVersion != nil {
-		in, out := &in.LatestPeeringVersion, &out.LatestPeeringVersion
-		*out = new(uint64)
-		**out = **in
+	if in.TaggedAddresses != nil {
+		in, out := &in.TaggedAddresses, &out.TaggedAddresses
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(SecretRefStatus)
+	if in.NodeMeta != nil {
+		in, out := &in.NodeMeta, &out.NodeMeta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Locality != nil {
+		in, out := &in.Locality, &out.Locality
+		*out = new(RegistrationLocality)
 		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(Conditions, len(*in))
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]RegistrationCheck, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.LastSyncedTime != nil {
-		in, out := &in.LastSyncedTime, &out.LastSyncedTime
-		*out = (*in).DeepCopy()
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAcceptorStatus.
-func (in *PeeringAcceptorStatus) DeepCopy() *PeeringAcceptorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationSpec.
+func (in *RegistrationSpec) DeepCopy() *RegistrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringAcceptorStatus)
+	out := new(RegistrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringDialer) DeepCopyInto(out *PeeringDialer) {
+func (in *RegistrationStatus) DeepCopyInto(out *RegistrationStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringDialer.
-func (in *PeeringDialer) DeepCopy() *PeeringDialer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationStatus.
+func (in *RegistrationStatus) DeepCopy() *RegistrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringDialer)
+	out := new(RegistrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PeeringDialer) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringDialerList) DeepCopyInto(out *PeeringDialerList) {
+func (in *RemoteJWKS) DeepCopyInto(out *RemoteJWKS) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PeeringDialer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.CacheDuration = in.CacheDuration
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(JWKSRetryPolicy)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringDialerList.
-func (in *PeeringDialerList) DeepCopy() *PeeringDialerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteJWKS.
+func (in *RemoteJWKS) DeepCopy() *RemoteJWKS {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringDialerList)
+	out := new(RemoteJWKS)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PeeringDialerList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringDialerSpec) DeepCopyInto(out *PeeringDialerSpec) {
+func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
-	if in.Peer != nil {
-		in, out := &in.Peer, &out.Peer
-		*out = new(Peer)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringDialerSpec.
-func (in *PeeringDialerSpec) DeepCopy() *PeeringDialerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReference.
+func (in *ResourceReference) DeepCopy() *ResourceReference {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringDialerSpec)
+	out := new(ResourceReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringDialerStatus) DeepCopyInto(out *PeeringDialerStatus) {
+func (in *RetryPolicyBackOff) DeepCopyInto(out *RetryPolicyBackOff) {
 	*out = *in
-	if in.LatestPeeringVersion != nil {
-		in, out := &in.LatestPeeringVersion, &out.LatestPeeringVersion
-		*out = new(uint64)
-		**out = **in
-	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(SecretRefStatus)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LastSyncedTime != nil {
-		in, out := &in.LastSyncedTime, &out.LastSyncedTime
-		*out = (*in).DeepCopy()
-	}
+	out.BaseInterval = in.BaseInterval
+	out.MaxInterval = in.MaxInterval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringDialerStatus.
-func (in *PeeringDialerStatus) DeepCopy() *PeeringDialerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicyBackOff.
+func (in *RetryPolicyBackOff) DeepCopy() *RetryPolicyBackOff {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringDialerStatus)
+	out := new(RetryPolicyBackOff)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PeeringMeshConfig) DeepCopyInto(out *PeeringMeshConfig) {
+func (in *RingHashConfig) DeepCopyInto(out *RingHashConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringMeshConfig.
-func (in *PeeringMeshConfig) DeepCopy() *PeeringMeshConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RingHashConfig.
+func (in *RingHashConfig) DeepCopy() *RingHashConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PeeringMeshConfig)
+	out := new(RingHashConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyDefaults) DeepCopyInto(out *ProxyDefaults) {
+func (in *SamenessGroup) DeepCopyInto(out *SamenessGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1098,18 +3679,18 @@ func (in *ProxyDefaults) DeepCopyInto(out *ProxyDefaults) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaults.
-func (in *ProxyDefaults) DeepCopy() *ProxyDefaults {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamenessGroup.
+func (in *SamenessGroup) DeepCopy() *SamenessGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyDefaults)
+	out := new(SamenessGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProxyDefaults) DeepCopyObject() runtime.Object {
+func (in *SamenessGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1117,31 +3698,31 @@ func (in *ProxyDefaults) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyDefaultsList) DeepCopyInto(out *ProxyDefaultsList) {
+func (in *SamenessGroupList) DeepCopyInto(out *SamenessGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProxyDefaults, len(*in))
+		*out = make([]SamenessGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaultsList.
-func (in *ProxyDefaultsList) DeepCopy() *ProxyDefaultsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamenessGroupList.
+func (in *SamenessGroupList) DeepCopy() *SamenessGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyDefaultsList)
+	out := new(SamenessGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProxyDefaultsList) DeepCopyObject() runtime.Object {
+func (in *SamenessGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1149,48 +3730,36 @@ func (in *ProxyDefaultsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyDefaultsSpec) DeepCopyInto(out *ProxyDefaultsSpec) {
+func (in *SamenessGroupMember) DeepCopyInto(out *SamenessGroupMember) {
 	*out = *in
-	if in.Mode != nil {
-		in, out := &in.Mode, &out.Mode
-		*out = new(ProxyMode)
-		**out = **in
-	}
-	if in.TransparentProxy != nil {
-		in, out := &in.TransparentProxy, &out.TransparentProxy
-		*out = new(TransparentProxy)
-		**out = **in
-	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(json.RawMessage, len(*in))
-		copy(*out, *in)
-	}
-	out.MeshGateway = in.MeshGateway
-	in.Expose.DeepCopyInto(&out.Expose)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaultsSpec.
-func (in *ProxyDefaultsSpec) DeepCopy() *ProxyDefaultsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamenessGroupMember.
+func (in *SamenessGroupMember) DeepCopy() *SamenessGroupMember {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyDefaultsSpec)
+	out := new(SamenessGroupMember)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RingHashConfig) DeepCopyInto(out *RingHashConfig) {
+func (in *SamenessGroupSpec) DeepCopyInto(out *SamenessGroupSpec) {
 	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]SamenessGroupMember, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RingHashConfig.
-func (in *RingHashConfig) DeepCopy() *RingHashConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamenessGroupSpec.
+func (in *SamenessGroupSpec) DeepCopy() *SamenessGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RingHashConfig)
+	out := new(SamenessGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1278,42 +3847,150 @@ func (in *ServiceDefaultsDestination) DeepCopyInto(out *ServiceDefaultsDestinati
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDefaultsDestination.
-func (in *ServiceDefaultsDestination) DeepCopy() *ServiceDefaultsDestination {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDefaultsDestination.
+func (in *ServiceDefaultsDestination) DeepCopy() *ServiceDefaultsDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceDefaultsDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceDefaultsList) DeepCopyInto(out *ServiceDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDefaultsList.
+func (in *ServiceDefaultsList) DeepCopy() *ServiceDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceDefaultsSpec) DeepCopyInto(out *ServiceDefaultsSpec) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(ProxyMode)
+		**out = **in
+	}
+	if in.TransparentProxy != nil {
+		in, out := &in.TransparentProxy, &out.TransparentProxy
+		*out = new(TransparentProxy)
+		**out = **in
+	}
+	out.MeshGateway = in.MeshGateway
+	in.Expose.DeepCopyInto(&out.Expose)
+	if in.UpstreamConfig != nil {
+		in, out := &in.UpstreamConfig, &out.UpstreamConfig
+		*out = new(Upstreams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(ServiceDefaultsDestination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(RateLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvoyExtensions != nil {
+		in, out := &in.EnvoyExtensions, &out.EnvoyExtensions
+		*out = make(EnvoyExtensions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDefaultsSpec.
+func (in *ServiceDefaultsSpec) DeepCopy() *ServiceDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceIntention) DeepCopyInto(out *ServiceIntention) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceIntention.
+func (in *ServiceIntention) DeepCopy() *ServiceIntention {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceDefaultsDestination)
+	out := new(ServiceIntention)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceIntention) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceDefaultsList) DeepCopyInto(out *ServiceDefaultsList) {
+func (in *ServiceIntentionList) DeepCopyInto(out *ServiceIntentionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ServiceDefaults, len(*in))
+		*out = make([]ServiceIntention, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDefaultsList.
-func (in *ServiceDefaultsList) DeepCopy() *ServiceDefaultsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceIntentionList.
+func (in *ServiceIntentionList) DeepCopy() *ServiceIntentionList {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceDefaultsList)
+	out := new(ServiceIntentionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ServiceDefaultsList) DeepCopyObject() runtime.Object {
+func (in *ServiceIntentionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1321,38 +3998,18 @@ func (in *ServiceDefaultsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceDefaultsSpec) DeepCopyInto(out *ServiceDefaultsSpec) {
+func (in *ServiceIntentionSpec) DeepCopyInto(out *ServiceIntentionSpec) {
 	*out = *in
-	if in.Mode != nil {
-		in, out := &in.Mode, &out.Mode
-		*out = new(ProxyMode)
-		**out = **in
-	}
-	if in.TransparentProxy != nil {
-		in, out := &in.TransparentProxy, &out.TransparentProxy
-		*out = new(TransparentProxy)
-		**out = **in
-	}
-	out.MeshGateway = in.MeshGateway
-	in.Expose.DeepCopyInto(&out.Expose)
-	if in.UpstreamConfig != nil {
-		in, out := &in.UpstreamConfig, &out.UpstreamConfig
-		*out = new(Upstreams)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Destination != nil {
-		in, out := &in.Destination, &out.Destination
-		*out = new(ServiceDefaultsDestination)
-		(*in).DeepCopyInto(*out)
-	}
+	out.Destination = in.Destination
+	in.Source.DeepCopyInto(&out.Source)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDefaultsSpec.
-func (in *ServiceDefaultsSpec) DeepCopy() *ServiceDefaultsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceIntentionSpec.
+func (in *ServiceIntentionSpec) DeepCopy() *ServiceIntentionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceDefaultsSpec)
+	out := new(ServiceIntentionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1431,6 +4088,11 @@ func (in *ServiceIntentionsSpec) DeepCopyInto(out *ServiceIntentionsSpec) {
 			}
 		}
 	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(IntentionJWTRequirement)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceIntentionsSpec.
@@ -1449,7 +4111,7 @@ func (in *ServiceResolver) DeepCopyInto(out *ServiceResolver) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.DiscoveryChainStatus.DeepCopyInto(&out.DiscoveryChainStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceResolver.
@@ -1796,7 +4458,7 @@ func (in *ServiceRouter) DeepCopyInto(out *ServiceRouter) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.DiscoveryChainStatus.DeepCopyInto(&out.DiscoveryChainStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRouter.
@@ -1923,7 +4585,7 @@ func (in *ServiceSplitter) DeepCopyInto(out *ServiceSplitter) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.DiscoveryChainStatus.DeepCopyInto(&out.DiscoveryChainStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSplitter.
@@ -2063,6 +4725,11 @@ func (in *Status) DeepCopyInto(out *Status) {
 		in, out := &in.LastSyncedTime, &out.LastSyncedTime
 		*out = (*in).DeepCopy()
 	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRun)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Status.
@@ -2075,6 +4742,105 @@ func (in *Status) DeepCopy() *Status {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPRoute) DeepCopyInto(out *TCPRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPRoute.
+func (in *TCPRoute) DeepCopy() *TCPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TCPRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPRouteList) DeepCopyInto(out *TCPRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TCPRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPRouteList.
+func (in *TCPRouteList) DeepCopy() *TCPRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TCPRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPRouteSpec) DeepCopyInto(out *TCPRouteSpec) {
+	*out = *in
+	if in.Parents != nil {
+		in, out := &in.Parents, &out.Parents
+		*out = make([]ResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]TCPService, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPRouteSpec.
+func (in *TCPRouteSpec) DeepCopy() *TCPRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPService) DeepCopyInto(out *TCPService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPService.
+func (in *TCPService) DeepCopy() *TCPService {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TerminatingGateway) DeepCopyInto(out *TerminatingGateway) {
 	*out = *in
@@ -2154,6 +4920,174 @@ func (in *TerminatingGatewaySpec) DeepCopy() *TerminatingGatewaySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficShift) DeepCopyInto(out *TrafficShift) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficShift.
+func (in *TrafficShift) DeepCopy() *TrafficShift {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficShift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrafficShift) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficShiftAnalysis) DeepCopyInto(out *TrafficShiftAnalysis) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = make([]TrafficShiftPrometheusCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficShiftAnalysis.
+func (in *TrafficShiftAnalysis) DeepCopy() *TrafficShiftAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficShiftAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficShiftList) DeepCopyInto(out *TrafficShiftList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TrafficShift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficShiftList.
+func (in *TrafficShiftList) DeepCopy() *TrafficShiftList {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficShiftList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrafficShiftList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficShiftPrometheusCheck) DeepCopyInto(out *TrafficShiftPrometheusCheck) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(float32)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(float32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficShiftPrometheusCheck.
+func (in *TrafficShiftPrometheusCheck) DeepCopy() *TrafficShiftPrometheusCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficShiftPrometheusCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficShiftSpec) DeepCopyInto(out *TrafficShiftSpec) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TrafficShiftStep, len(*in))
+		copy(*out, *in)
+	}
+	in.Analysis.DeepCopyInto(&out.Analysis)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficShiftSpec.
+func (in *TrafficShiftSpec) DeepCopy() *TrafficShiftSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficShiftSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficShiftStatus) DeepCopyInto(out *TrafficShiftStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.StepStartTime != nil {
+		in, out := &in.StepStartTime, &out.StepStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAnalysisTime != nil {
+		in, out := &in.LastAnalysisTime, &out.LastAnalysisTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficShiftStatus.
+func (in *TrafficShiftStatus) DeepCopy() *TrafficShiftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficShiftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficShiftStep) DeepCopyInto(out *TrafficShiftStep) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficShiftStep.
+func (in *TrafficShiftStep) DeepCopy() *TrafficShiftStep {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficShiftStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransparentProxy) DeepCopyInto(out *TransparentProxy) {
 	*out = *in
@@ -2184,6 +5118,21 @@ func (in *TransparentProxyMeshConfig) DeepCopy() *TransparentProxyMeshConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLRewrite) DeepCopyInto(out *URLRewrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLRewrite.
+func (in *URLRewrite) DeepCopy() *URLRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(URLRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Upstream) DeepCopyInto(out *Upstream) {
 	*out = *in
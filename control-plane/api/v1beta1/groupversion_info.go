@@ -0,0 +1,22 @@
+// Package v1beta1 contains API Schema definitions for the consul.hashicorp.com v1beta1 API group.
+// Resources in this version are converted to and from v1alpha1, which remains
+// the storage version, by the conversion webhook served by the controller.
+// +kubebuilder:object:generate=true
+// +groupName=consul.hashicorp.com
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects.
+	GroupVersion = schema.GroupVersion{Group: "consul.hashicorp.com", Version: "v1beta1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
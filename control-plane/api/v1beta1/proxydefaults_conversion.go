@@ -0,0 +1,284 @@
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this ProxyDefaults to the hub version (v1alpha1).
+func (in *ProxyDefaults) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.ProxyDefaults)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub type %T", hub)
+	}
+	src := in.DeepCopy()
+	config, err := src.Spec.Config.toRaw()
+	if err != nil {
+		return err
+	}
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.ProxyDefaultsSpec{
+		Mode:             src.Spec.Mode,
+		TransparentProxy: src.Spec.TransparentProxy,
+		Config:           config,
+		MeshGateway:      src.Spec.MeshGateway,
+		Expose:           src.Spec.Expose,
+		AccessLogs:       src.Spec.AccessLogs,
+		EnvoyExtensions:  src.Spec.EnvoyExtensions,
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (in *ProxyDefaults) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.ProxyDefaults)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub type %T", hub)
+	}
+	src = src.DeepCopy()
+	config, err := proxyConfigFromRaw(src.Spec.Config)
+	if err != nil {
+		return err
+	}
+	in.ObjectMeta = src.ObjectMeta
+	in.Spec = ProxyDefaultsSpec{
+		Mode:             src.Spec.Mode,
+		TransparentProxy: src.Spec.TransparentProxy,
+		Config:           config,
+		MeshGateway:      src.Spec.MeshGateway,
+		Expose:           src.Spec.Expose,
+		AccessLogs:       src.Spec.AccessLogs,
+		EnvoyExtensions:  src.Spec.EnvoyExtensions,
+	}
+	in.Status = src.Status
+	return nil
+}
+
+// proxyConfigOption maps a typed field of ProxyConfig to its key in the
+// proxy config map used by v1alpha1 and Consul.
+type proxyConfigOption struct {
+	key string
+	// set sets the field from a config map value. It returns false if the
+	// value doesn't have the type of the field, in which case the value is
+	// kept in Additional so that it is not lost.
+	set func(c *ProxyConfig, value interface{}) bool
+	// get returns the config map value of the field or nil if it is unset.
+	get func(c *ProxyConfig) interface{}
+}
+
+var proxyConfigOptions = []proxyConfigOption{
+	stringOption("protocol", func(c *ProxyConfig) **string { return &c.Protocol }),
+	stringOption("bind_address", func(c *ProxyConfig) **string { return &c.BindAddress }),
+	intOption("bind_port", func(c *ProxyConfig) **int { return &c.BindPort }),
+	millisecondsOption("local_connect_timeout_ms", func(c *ProxyConfig) **metav1.Duration { return &c.LocalConnectTimeout }),
+	millisecondsOption("local_request_timeout_ms", func(c *ProxyConfig) **metav1.Duration { return &c.LocalRequestTimeout }),
+	millisecondsOption("handshake_timeout_ms", func(c *ProxyConfig) **metav1.Duration { return &c.HandshakeTimeout }),
+	intOption("max_inbound_connections", func(c *ProxyConfig) **int { return &c.MaxInboundConnections }),
+	stringOption("balance_inbound_connections", func(c *ProxyConfig) **string { return &c.BalanceInboundConnections }),
+	stringOption("envoy_prometheus_bind_addr", func(c *ProxyConfig) **string { return &c.EnvoyPrometheusBindAddr }),
+	stringOption("envoy_stats_bind_addr", func(c *ProxyConfig) **string { return &c.EnvoyStatsBindAddr }),
+	stringOption("envoy_statsd_url", func(c *ProxyConfig) **string { return &c.EnvoyStatsdURL }),
+	stringOption("envoy_dogstatsd_url", func(c *ProxyConfig) **string { return &c.EnvoyDogstatsdURL }),
+	{
+		key: "envoy_stats_tags",
+		set: func(c *ProxyConfig, value interface{}) bool {
+			values, ok := value.([]interface{})
+			// An empty list would be dropped by omitempty so it stays in Additional.
+			if !ok || len(values) == 0 {
+				return false
+			}
+			tags := make([]string, 0, len(values))
+			for _, v := range values {
+				tag, ok := v.(string)
+				if !ok {
+					return false
+				}
+				tags = append(tags, tag)
+			}
+			c.EnvoyStatsTags = tags
+			return true
+		},
+		get: func(c *ProxyConfig) interface{} {
+			if len(c.EnvoyStatsTags) == 0 {
+				return nil
+			}
+			return c.EnvoyStatsTags
+		},
+	},
+}
+
+func stringOption(key string, field func(c *ProxyConfig) **string) proxyConfigOption {
+	return proxyConfigOption{
+		key: key,
+		set: func(c *ProxyConfig, value interface{}) bool {
+			s, ok := value.(string)
+			if ok {
+				*field(c) = &s
+			}
+			return ok
+		},
+		get: func(c *ProxyConfig) interface{} {
+			if s := *field(c); s != nil {
+				return *s
+			}
+			return nil
+		},
+	}
+}
+
+func intOption(key string, field func(c *ProxyConfig) **int) proxyConfigOption {
+	return proxyConfigOption{
+		key: key,
+		set: func(c *ProxyConfig, value interface{}) bool {
+			n, ok := value.(json.Number)
+			if !ok {
+				return false
+			}
+			i, err := n.Int64()
+			if err != nil || int64(int(i)) != i {
+				return false
+			}
+			v := int(i)
+			*field(c) = &v
+			return true
+		},
+		get: func(c *ProxyConfig) interface{} {
+			if i := *field(c); i != nil {
+				return *i
+			}
+			return nil
+		},
+	}
+}
+
+// millisecondsOption is an option that Consul configures as a number of
+// milliseconds. Fractional milliseconds are supported down to the nanosecond.
+func millisecondsOption(key string, field func(c *ProxyConfig) **metav1.Duration) proxyConfigOption {
+	msPerNs := big.NewRat(int64(time.Millisecond), 1)
+	return proxyConfigOption{
+		key: key,
+		set: func(c *ProxyConfig, value interface{}) bool {
+			n, ok := value.(json.Number)
+			if !ok {
+				return false
+			}
+			r, ok := new(big.Rat).SetString(n.String())
+			if !ok {
+				return false
+			}
+			r.Mul(r, msPerNs)
+			if !r.IsInt() || !r.Num().IsInt64() {
+				return false
+			}
+			*field(c) = &metav1.Duration{Duration: time.Duration(r.Num().Int64())}
+			return true
+		},
+		get: func(c *ProxyConfig) interface{} {
+			d := *field(c)
+			if d == nil {
+				return nil
+			}
+			ms := new(big.Rat).SetFrac64(int64(d.Duration), int64(time.Millisecond))
+			if ms.IsInt() {
+				return json.Number(ms.Num().String())
+			}
+			s := strings.TrimRight(ms.FloatString(6), "0")
+			return json.Number(s)
+		},
+	}
+}
+
+// proxyConfigFromRaw converts the v1alpha1 proxy config map to a ProxyConfig.
+func proxyConfigFromRaw(raw json.RawMessage) (*ProxyConfig, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	config, err := decodeConfigMap(raw)
+	// Config that isn't a map is invalid but must not be lost, so it is kept
+	// as is for the webhook to reject.
+	if err != nil || config == nil {
+		return &ProxyConfig{Additional: raw}, nil
+	}
+
+	out := &ProxyConfig{}
+	converted := false
+	for _, opt := range proxyConfigOptions {
+		if value, ok := config[opt.key]; ok && opt.set(out, value) {
+			delete(config, opt.key)
+			converted = true
+		}
+	}
+	switch {
+	case !converted:
+		// Nothing was converted to a typed field so the config is kept as is.
+		out.Additional = raw
+	case len(config) > 0:
+		out.Additional, err = json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// toRaw converts the ProxyConfig to the v1alpha1 proxy config map.
+func (in *ProxyConfig) toRaw() (json.RawMessage, error) {
+	if in == nil {
+		return nil, nil
+	}
+	typed := make(map[string]interface{})
+	for _, opt := range proxyConfigOptions {
+		if value := opt.get(in); value != nil {
+			typed[opt.key] = value
+		}
+	}
+	config, err := decodeConfigMap(in.Additional)
+	if err != nil {
+		if len(typed) > 0 {
+			return nil, fmt.Errorf("config.additional must be a map: %s", err)
+		}
+		// Invalid config converted from v1alpha1 is kept as is.
+		return in.Additional, nil
+	}
+	// Options with a typed field can't be set in Additional since they would
+	// be converted to the typed field when converted back.
+	for _, opt := range proxyConfigOptions {
+		if value, ok := config[opt.key]; ok && opt.set(&ProxyConfig{}, value) {
+			return nil, fmt.Errorf("config.additional.%s must be set using its typed field in config", opt.key)
+		}
+	}
+	if len(typed) == 0 {
+		return in.Additional, nil
+	}
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	for key, value := range typed {
+		config[key] = value
+	}
+	return json.Marshal(config)
+}
+
+// decodeConfigMap decodes a proxy config map keeping numbers as json.Number
+// so that they are converted without losing precision.
+func decodeConfigMap(raw json.RawMessage) (map[string]interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	var config map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
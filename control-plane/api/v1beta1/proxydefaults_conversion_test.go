@@ -0,0 +1,277 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProxyDefaults_ConvertFrom(t *testing.T) {
+	cases := map[string]struct {
+		config    string
+		expConfig *ProxyConfig
+	}{
+		"nil config": {},
+		"typed options": {
+			config: `{
+				"protocol": "http",
+				"bind_port": 21000,
+				"local_connect_timeout_ms": 5000,
+				"local_request_timeout_ms": 1.5,
+				"envoy_stats_tags": ["a=b"]
+			}`,
+			expConfig: &ProxyConfig{
+				Protocol:            strPtr("http"),
+				BindPort:            intPtr(21000),
+				LocalConnectTimeout: &metav1.Duration{Duration: 5 * time.Second},
+				LocalRequestTimeout: &metav1.Duration{Duration: 1500 * time.Microsecond},
+				EnvoyStatsTags:      []string{"a=b"},
+			},
+		},
+		"other options are additional": {
+			config: `{"protocol": "http", "envoy_tracing_json": "{}"}`,
+			expConfig: &ProxyConfig{
+				Protocol:   strPtr("http"),
+				Additional: json.RawMessage(`{"envoy_tracing_json":"{}"}`),
+			},
+		},
+		"options with a different type are additional": {
+			config: `{"protocol": 1, "bind_port": "21000", "handshake_timeout_ms": 0.0000001}`,
+			expConfig: &ProxyConfig{
+				Additional: json.RawMessage(`{"protocol": 1, "bind_port": "21000", "handshake_timeout_ms": 0.0000001}`),
+			},
+		},
+		"config that isn't a map is kept": {
+			config: `["protocol"]`,
+			expConfig: &ProxyConfig{
+				Additional: json.RawMessage(`["protocol"]`),
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			src := &v1alpha1.ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "global"},
+			}
+			if c.config != "" {
+				src.Spec.Config = json.RawMessage(c.config)
+			}
+			var dst ProxyDefaults
+			require.NoError(t, dst.ConvertFrom(src))
+			require.Equal(t, "global", dst.Name)
+			require.Equal(t, c.expConfig, dst.Spec.Config)
+		})
+	}
+}
+
+func TestProxyDefaults_ConvertTo(t *testing.T) {
+	cases := map[string]struct {
+		config    *ProxyConfig
+		expConfig string
+		expErr    string
+	}{
+		"nil config": {},
+		"typed options": {
+			config: &ProxyConfig{
+				Protocol:            strPtr("grpc"),
+				HandshakeTimeout:    &metav1.Duration{Duration: 10 * time.Second},
+				LocalRequestTimeout: &metav1.Duration{Duration: time.Nanosecond},
+				Additional:          json.RawMessage(`{"envoy_tracing_json":"{}"}`),
+			},
+			expConfig: `{"envoy_tracing_json":"{}","handshake_timeout_ms":10000,"local_request_timeout_ms":0.000001,"protocol":"grpc"}`,
+		},
+		"additional only is kept": {
+			config:    &ProxyConfig{Additional: json.RawMessage(`{"b": 1, "a": 2}`)},
+			expConfig: `{"b": 1, "a": 2}`,
+		},
+		"typed option in additional": {
+			config: &ProxyConfig{Additional: json.RawMessage(`{"protocol": "http"}`)},
+			expErr: "config.additional.protocol must be set using its typed field in config",
+		},
+		"additional that isn't a map": {
+			config: &ProxyConfig{Protocol: strPtr("http"), Additional: json.RawMessage(`[]`)},
+			expErr: "config.additional must be a map",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			src := &ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "global"},
+				Spec:       ProxyDefaultsSpec{Config: c.config},
+			}
+			var dst v1alpha1.ProxyDefaults
+			err := src.ConvertTo(&dst)
+			if c.expErr != "" {
+				require.ErrorContains(t, err, c.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "global", dst.Name)
+			if c.expConfig == "" {
+				require.Nil(t, dst.Spec.Config)
+			} else {
+				require.Equal(t, c.expConfig, string(dst.Spec.Config))
+			}
+		})
+	}
+}
+
+// FuzzProxyDefaults_HubRoundTrip checks that converting v1alpha1
+// ProxyDefaults to v1beta1 and back doesn't lose any data.
+func FuzzProxyDefaults_HubRoundTrip(f *testing.F) {
+	for seed := int64(0); seed < 500; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		src := &v1alpha1.ProxyDefaults{}
+		fuzzer(seed).Fuzz(src)
+		src.TypeMeta = metav1.TypeMeta{}
+
+		var spoke ProxyDefaults
+		require.NoError(t, spoke.ConvertFrom(src))
+		dst := &v1alpha1.ProxyDefaults{}
+		require.NoError(t, spoke.ConvertTo(dst))
+
+		// Config map keys may be reordered so the config is compared as JSON.
+		requireEqualJSON(t, src.Spec.Config, dst.Spec.Config)
+		src.Spec.Config, dst.Spec.Config = nil, nil
+		require.Equal(t, src, dst)
+	})
+}
+
+// FuzzProxyDefaults_SpokeRoundTrip checks that converting v1beta1
+// ProxyDefaults to v1alpha1 and back doesn't lose any data.
+func FuzzProxyDefaults_SpokeRoundTrip(f *testing.F) {
+	for seed := int64(0); seed < 500; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		src := &ProxyDefaults{}
+		fuzzer(seed).Fuzz(src)
+		src.TypeMeta = metav1.TypeMeta{}
+
+		var hub v1alpha1.ProxyDefaults
+		require.NoError(t, src.ConvertTo(&hub))
+		dst := &ProxyDefaults{}
+		require.NoError(t, dst.ConvertFrom(&hub))
+
+		// An empty config has nothing to convert so it is dropped.
+		if reflect.DeepEqual(src.Spec.Config, &ProxyConfig{}) {
+			src.Spec.Config = nil
+		}
+		requireEqualJSON(t, additional(src.Spec.Config), additional(dst.Spec.Config))
+		if src.Spec.Config != nil && dst.Spec.Config != nil {
+			src.Spec.Config.Additional, dst.Spec.Config.Additional = nil, nil
+		}
+		require.Equal(t, src, dst)
+	})
+}
+
+// fuzzer returns a fuzzer that generates proxy config maps for v1alpha1 and
+// valid typed config for v1beta1.
+func fuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.NewWithSeed(seed).NilChance(0.2).Funcs(
+		func(config *json.RawMessage, c fuzz.Continue) {
+			switch c.Intn(10) {
+			case 0:
+				*config = nil
+			case 1:
+				*config = json.RawMessage(`[1, "2"]`)
+			default:
+				*config = mustMarshal(randomConfigMap(c, true))
+			}
+		},
+		func(config *ProxyConfig, c fuzz.Continue) {
+			c.FuzzNoCustom(config)
+			if len(config.EnvoyStatsTags) == 0 {
+				config.EnvoyStatsTags = nil
+			}
+			config.Additional = nil
+			if additional := randomConfigMap(c, false); len(additional) > 0 {
+				config.Additional = mustMarshal(additional)
+			}
+		},
+	)
+}
+
+// randomConfigMap returns a proxy config map. The options that have a typed
+// field in ProxyConfig are only included if withTyped is true.
+func randomConfigMap(c fuzz.Continue, withTyped bool) map[string]interface{} {
+	config := make(map[string]interface{})
+	for i, n := 0, c.Intn(4); i < n; i++ {
+		config[fmt.Sprintf("option_%d", c.Intn(100))] = randomConfigValue(c)
+	}
+	if withTyped {
+		for _, opt := range proxyConfigOptions {
+			if c.RandBool() {
+				config[opt.key] = randomConfigValue(c)
+			}
+		}
+	}
+	return config
+}
+
+func randomConfigValue(c fuzz.Continue) interface{} {
+	switch c.Intn(7) {
+	case 0:
+		return c.RandString()
+	case 1:
+		return c.Int31()
+	case 2:
+		return json.Number(fmt.Sprintf("%d.%d", c.Intn(100000), c.Intn(10000)))
+	case 3:
+		return c.Float64()
+	case 4:
+		return c.RandBool()
+	case 5:
+		var tags []string
+		for i, n := 0, c.Intn(3); i < n; i++ {
+			tags = append(tags, c.RandString())
+		}
+		return tags
+	default:
+		return map[string]interface{}{c.RandString(): c.RandString()}
+	}
+}
+
+func requireEqualJSON(t *testing.T, exp, actual json.RawMessage) {
+	t.Helper()
+	if exp == nil || actual == nil {
+		require.Equal(t, exp, actual)
+		return
+	}
+	var expValue, actualValue interface{}
+	require.NoError(t, json.Unmarshal(exp, &expValue))
+	require.NoError(t, json.Unmarshal(actual, &actualValue))
+	require.Equal(t, expValue, actualValue)
+}
+
+func additional(config *ProxyConfig) json.RawMessage {
+	if config == nil {
+		return nil
+	}
+	return config.Additional
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
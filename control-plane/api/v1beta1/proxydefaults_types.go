@@ -0,0 +1,105 @@
+package v1beta1
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&ProxyDefaults{}, &ProxyDefaultsList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ProxyDefaults is the Schema for the proxydefaults API
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="The sync status of the resource with Consul"
+// +kubebuilder:printcolumn:name="Last Synced",type="date",JSONPath=".status.lastSyncedTime",description="The last successful synced time of the resource with Consul"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
+// +kubebuilder:resource:shortName="proxy-defaults"
+type ProxyDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ProxyDefaultsSpec `json:"spec,omitempty"`
+	v1alpha1.Status   `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProxyDefaultsList contains a list of ProxyDefaults.
+type ProxyDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProxyDefaults `json:"items"`
+}
+
+// ProxyDefaultsSpec defines the desired state of ProxyDefaults.
+type ProxyDefaultsSpec struct {
+	// Mode can be one of "direct" or "transparent". "transparent" represents that inbound and outbound
+	// application traffic is being captured and redirected through the proxy. This mode does not
+	// enable the traffic redirection itself. Instead it signals Consul to configure Envoy as if
+	// traffic is already being redirected. "direct" represents that the proxy's listeners must be
+	// dialed directly by the local application and other proxies.
+	// Note: This cannot be set using the CRD and should be set using annotations on the
+	// services that are part of the mesh.
+	Mode *v1alpha1.ProxyMode `json:"mode,omitempty"`
+	// TransparentProxy controls configuration specific to proxies in transparent mode.
+	// Note: This cannot be set using the CRD and should be set using annotations on the
+	// services that are part of the mesh.
+	TransparentProxy *v1alpha1.TransparentProxy `json:"transparentProxy,omitempty"`
+	// Config is the configuration used by Connect proxies.
+	// Any values that your proxy allows can be configured globally here.
+	Config *ProxyConfig `json:"config,omitempty"`
+	// MeshGateway controls the default mesh gateway configuration for this service.
+	MeshGateway v1alpha1.MeshGateway `json:"meshGateway,omitempty"`
+	// Expose controls the default expose path configuration for Envoy.
+	Expose v1alpha1.Expose `json:"expose,omitempty"`
+	// AccessLogs controls all envoy instances' access logging configuration.
+	AccessLogs *v1alpha1.AccessLogs `json:"accessLogs,omitempty"`
+	// EnvoyExtensions are a list of extensions to modify Envoy proxy configuration.
+	EnvoyExtensions v1alpha1.EnvoyExtensions `json:"envoyExtensions,omitempty"`
+}
+
+// ProxyConfig is the configuration of Connect proxies. The commonly used
+// options are typed fields, everything else can be set with Additional.
+// See https://www.consul.io/docs/connect/proxies/envoy#proxy-config-options.
+type ProxyConfig struct {
+	// Protocol is the default protocol of services in the mesh, one of
+	// "tcp", "http", "http2" or "grpc".
+	Protocol *string `json:"protocol,omitempty"`
+	// BindAddress overrides the address the proxy's public listener binds to.
+	BindAddress *string `json:"bindAddress,omitempty"`
+	// BindPort overrides the port the proxy's public listener binds to.
+	BindPort *int `json:"bindPort,omitempty"`
+	// LocalConnectTimeout is the timeout for connections to the local application.
+	LocalConnectTimeout *metav1.Duration `json:"localConnectTimeout,omitempty"`
+	// LocalRequestTimeout is the timeout for HTTP requests to the local application.
+	LocalRequestTimeout *metav1.Duration `json:"localRequestTimeout,omitempty"`
+	// HandshakeTimeout is the timeout for the TLS handshake of inbound connections.
+	HandshakeTimeout *metav1.Duration `json:"handshakeTimeout,omitempty"`
+	// MaxInboundConnections is the maximum number of concurrent inbound connections.
+	MaxInboundConnections *int `json:"maxInboundConnections,omitempty"`
+	// BalanceInboundConnections is the strategy used to balance inbound
+	// connections across worker threads. Only "exact_balance" is supported.
+	BalanceInboundConnections *string `json:"balanceInboundConnections,omitempty"`
+	// EnvoyPrometheusBindAddr is the address to expose Envoy's Prometheus metrics on.
+	EnvoyPrometheusBindAddr *string `json:"envoyPrometheusBindAddr,omitempty"`
+	// EnvoyStatsBindAddr is the address to expose Envoy's /stats endpoint on.
+	EnvoyStatsBindAddr *string `json:"envoyStatsBindAddr,omitempty"`
+	// EnvoyStatsdURL is the URL of a StatsD sink for Envoy metrics.
+	EnvoyStatsdURL *string `json:"envoyStatsdURL,omitempty"`
+	// EnvoyDogstatsdURL is the URL of a DogStatsD sink for Envoy metrics.
+	EnvoyDogstatsdURL *string `json:"envoyDogstatsdURL,omitempty"`
+	// EnvoyStatsTags are additional tags added to Envoy metrics.
+	EnvoyStatsTags []string `json:"envoyStatsTags,omitempty"`
+	// Additional is an arbitrary map of any other configuration values, using
+	// the keys documented for Consul. The options that have a typed field
+	// must be set using that field.
+	// Supports JSON config values. See https://www.consul.io/docs/connect/proxies/envoy#configuration-formatting
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Additional json.RawMessage `json:"additional,omitempty"`
+}
@@ -0,0 +1,201 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.BindAddress != nil {
+		in, out := &in.BindAddress, &out.BindAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.BindPort != nil {
+		in, out := &in.BindPort, &out.BindPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.LocalConnectTimeout != nil {
+		in, out := &in.LocalConnectTimeout, &out.LocalConnectTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LocalRequestTimeout != nil {
+		in, out := &in.LocalRequestTimeout, &out.LocalRequestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HandshakeTimeout != nil {
+		in, out := &in.HandshakeTimeout, &out.HandshakeTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInboundConnections != nil {
+		in, out := &in.MaxInboundConnections, &out.MaxInboundConnections
+		*out = new(int)
+		**out = **in
+	}
+	if in.BalanceInboundConnections != nil {
+		in, out := &in.BalanceInboundConnections, &out.BalanceInboundConnections
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnvoyPrometheusBindAddr != nil {
+		in, out := &in.EnvoyPrometheusBindAddr, &out.EnvoyPrometheusBindAddr
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnvoyStatsBindAddr != nil {
+		in, out := &in.EnvoyStatsBindAddr, &out.EnvoyStatsBindAddr
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnvoyStatsdURL != nil {
+		in, out := &in.EnvoyStatsdURL, &out.EnvoyStatsdURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnvoyDogstatsdURL != nil {
+		in, out := &in.EnvoyDogstatsdURL, &out.EnvoyDogstatsdURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnvoyStatsTags != nil {
+		in, out := &in.EnvoyStatsTags, &out.EnvoyStatsTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDefaults) DeepCopyInto(out *ProxyDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaults.
+func (in *ProxyDefaults) DeepCopy() *ProxyDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProxyDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDefaultsList) DeepCopyInto(out *ProxyDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProxyDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaultsList.
+func (in *ProxyDefaultsList) DeepCopy() *ProxyDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProxyDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDefaultsSpec) DeepCopyInto(out *ProxyDefaultsSpec) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(v1alpha1.ProxyMode)
+		**out = **in
+	}
+	if in.TransparentProxy != nil {
+		in, out := &in.TransparentProxy, &out.TransparentProxy
+		*out = new(v1alpha1.TransparentProxy)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.MeshGateway = in.MeshGateway
+	in.Expose.DeepCopyInto(&out.Expose)
+	if in.AccessLogs != nil {
+		in, out := &in.AccessLogs, &out.AccessLogs
+		*out = new(v1alpha1.AccessLogs)
+		**out = **in
+	}
+	if in.EnvoyExtensions != nil {
+		in, out := &in.EnvoyExtensions, &out.EnvoyExtensions
+		*out = make(v1alpha1.EnvoyExtensions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaultsSpec.
+func (in *ProxyDefaultsSpec) DeepCopy() *ProxyDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,386 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultImportedPollPeriod is how often the ImportedSource polls Consul
+	// for imported services when no PollPeriod is configured.
+	DefaultImportedPollPeriod = 30 * time.Second
+
+	// maxKubeNameLength is the maximum length of a Kubernetes service name.
+	maxKubeNameLength = 63
+
+	// kubeNameHashLength is the length of the hash suffix added to the
+	// Kubernetes service names of imported services.
+	kubeNameHashLength = 8
+
+	// exportWildcard is the name or namespace of an exported service that
+	// exports every service or namespace of the partition.
+	exportWildcard = "*"
+)
+
+// invalidKubeNameChars matches the characters that aren't allowed in a
+// Kubernetes service name.
+var invalidKubeNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ImportedService is a service imported into the local Consul cluster from
+// a cluster peer or from another admin partition.
+type ImportedService struct {
+	// Name is the name of the service in Consul.
+	Name string
+
+	// Namespace is the Consul namespace of the service. Empty unless
+	// Consul namespaces are in use.
+	Namespace string
+
+	// Peer is the name of the peer the service was imported from. Exactly
+	// one of Peer or Partition is set.
+	Peer string
+
+	// Partition is the name of the admin partition the service was
+	// imported from.
+	Partition string
+
+	// VirtualDNS is the Consul DNS name that resolves to the virtual IP
+	// of the imported service, e.g. web.virtual.dc2.consul or
+	// web.virtual.ns1.ns.dc2.peer.consul.
+	VirtualDNS string
+
+	// Ports is the sorted, deduplicated list of ports the service
+	// instances listen on.
+	Ports []int32
+
+	// GatewayPort is the port of the ingress gateway listener that routes
+	// to the service.
+	GatewayPort int32
+
+	// GatewayAddresses are the addresses of the healthy instances of the
+	// ingress gateway.
+	GatewayAddresses []string
+}
+
+// ImportedSink is implemented by sinks that can materialize services
+// imported from cluster peers and admin partitions.
+type ImportedSink interface {
+	// SetImportedServices is called with the imported services that should
+	// be created. The key is the Kubernetes service name.
+	SetImportedServices(map[string]*ImportedService)
+}
+
+// ImportedSource is the source for the sync that watches services imported
+// into the local Consul cluster from peers and other admin partitions and
+// updates an ImportedSink whenever the set of imported services changes.
+//
+// Unlike Source, it polls rather than using a blocking query because the
+// imported services span several catalogs (one per peer or partition).
+type ImportedSource struct {
+	// ConsulClientConfig is the config for the Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+	Domain              string       // Consul DNS domain
+	Sink                ImportedSink // Sink is the sink to update with services
+	Prefix              string       // Prefix is a prefix to prepend to services
+	Log                 hclog.Logger // Logger
+
+	// SyncPeers syncs services imported from cluster peers.
+	SyncPeers bool
+
+	// SyncPartitions syncs services exported to the local partition from
+	// other admin partitions.
+	SyncPartitions bool
+
+	// Partition is the admin partition of this cluster. Empty when admin
+	// partitions are not in use.
+	Partition string
+
+	// EnableNamespaces looks for services imported from peers in every
+	// Consul namespace of the partition.
+	EnableNamespaces bool
+
+	// Gateway is the name of the ingress gateway that routes to the imported
+	// services. The ImportedSource owns its config entry.
+	Gateway string
+
+	// GatewayNamespace is the Consul namespace of the ingress gateway and of
+	// the service resolvers that route to the imported services.
+	GatewayNamespace string
+
+	// GatewayBasePort is the first port of the ingress gateway listeners.
+	GatewayBasePort int32
+
+	// PollPeriod is the duration to wait between polls of Consul.
+	PollPeriod time.Duration
+}
+
+// Run is the long-running runloop for watching imported services and
+// updating the Sink.
+func (s *ImportedSource) Run(ctx context.Context) {
+	pollPeriod := s.PollPeriod
+	if pollPeriod == 0 {
+		pollPeriod = DefaultImportedPollPeriod
+	}
+
+	for {
+		consulClient, err := consul.NewClientFromConnMgr(s.ConsulClientConfig, s.ConsulServerConnMgr)
+		if err != nil {
+			s.Log.Error("failed to create Consul API client", "err", err)
+			return
+		}
+
+		services, err := s.importedServices(ctx, consulClient)
+		if err == nil {
+			err = s.syncGateway(consulClient, services)
+		}
+		if err != nil {
+			s.Log.Warn("error syncing imported services, will retry", "err", err)
+		} else {
+			s.Log.Info("received imported services from Consul", "count", len(services))
+			s.Sink.SetImportedServices(services)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollPeriod):
+		}
+	}
+}
+
+// importedServices returns all the services imported into the local cluster,
+// keyed by the Kubernetes service name they should be synced as.
+func (s *ImportedSource) importedServices(ctx context.Context, client *api.Client) (map[string]*ImportedService, error) {
+	services := make(map[string]*ImportedService)
+	add := func(svc *ImportedService, opts *api.QueryOptions) error {
+		svc.VirtualDNS = s.virtualDNS(svc)
+		if err := s.addPorts(client, svc, opts); err != nil {
+			return err
+		}
+		services[s.kubeName(svc)] = svc
+		return nil
+	}
+
+	if s.SyncPeers {
+		peerings, _, err := client.Peerings().List(ctx, &api.QueryOptions{Partition: s.Partition})
+		if err != nil {
+			return nil, fmt.Errorf("listing peerings: %w", err)
+		}
+		// Services imported from a peer are in the namespace they were
+		// exported from.
+		namespaces, err := s.namespaces(client, s.Partition)
+		if err != nil {
+			return nil, err
+		}
+		for _, peering := range peerings {
+			if peering.State != api.PeeringStateActive {
+				continue
+			}
+			for _, namespace := range namespaces {
+				opts := &api.QueryOptions{Peer: peering.Name, Partition: s.Partition, Namespace: namespace}
+				serviceMap, _, err := client.Catalog().Services(opts)
+				if err != nil {
+					return nil, fmt.Errorf("listing services imported from peer %q: %w", peering.Name, err)
+				}
+				for name := range serviceMap {
+					if err := add(&ImportedService{Name: name, Namespace: namespace, Peer: peering.Name}, opts); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	if s.SyncPartitions {
+		localPartition := s.Partition
+		if localPartition == "" {
+			localPartition = "default"
+		}
+		partitions, _, err := client.Partitions().List(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing partitions: %w", err)
+		}
+		for _, partition := range partitions {
+			if partition.Name == localPartition {
+				continue
+			}
+			entry, _, err := client.ConfigEntries().Get(api.ExportedServices, partition.Name, &api.QueryOptions{Partition: partition.Name})
+			if isNotFoundErr(err) {
+				// A partition that exports nothing has no exported-services entry.
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("reading exported services of partition %q: %w", partition.Name, err)
+			}
+			exported, ok := entry.(*api.ExportedServicesConfigEntry)
+			if !ok {
+				continue
+			}
+			for _, exportedSvc := range exported.Services {
+				if !exportedTo(exportedSvc, localPartition) {
+					continue
+				}
+				exportedNames, err := s.expandExported(client, partition.Name, exportedSvc)
+				if err != nil {
+					return nil, err
+				}
+				for _, name := range exportedNames {
+					svc := &ImportedService{Name: name.Name, Namespace: name.Namespace, Partition: partition.Name}
+					opts := &api.QueryOptions{Partition: partition.Name, Namespace: name.Namespace}
+					if err := add(svc, opts); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	return services, nil
+}
+
+// namespaces returns the Consul namespaces of partition to look for imported
+// services in. It is a single empty namespace if namespaces aren't enabled.
+func (s *ImportedSource) namespaces(client *api.Client, partition string) ([]string, error) {
+	if !s.EnableNamespaces {
+		return []string{""}, nil
+	}
+	namespaces, _, err := client.Namespaces().List(&api.QueryOptions{Partition: partition})
+	if err != nil {
+		return nil, fmt.Errorf("listing namespaces of partition %q: %w", partition, err)
+	}
+	var names []string
+	for _, ns := range namespaces {
+		names = append(names, ns.Name)
+	}
+	return names, nil
+}
+
+// expandExported returns the services that svc of the exported-services
+// config entry of partition exports. A wildcard name or namespace is expanded
+// to the services registered in the partition.
+func (s *ImportedSource) expandExported(client *api.Client, partition string, svc api.ExportedService) ([]api.CompoundServiceName, error) {
+	namespaces := []string{svc.Namespace}
+	if svc.Namespace == exportWildcard {
+		var err error
+		if namespaces, err = s.namespaces(client, partition); err != nil {
+			return nil, err
+		}
+	}
+	if svc.Name != exportWildcard {
+		var names []api.CompoundServiceName
+		for _, namespace := range namespaces {
+			names = append(names, api.CompoundServiceName{Name: svc.Name, Namespace: namespace})
+		}
+		return names, nil
+	}
+
+	var names []api.CompoundServiceName
+	for _, namespace := range namespaces {
+		serviceMap, _, err := client.Catalog().Services(&api.QueryOptions{Partition: partition, Namespace: namespace})
+		if err != nil {
+			return nil, fmt.Errorf("listing services exported from partition %q: %w", partition, err)
+		}
+		for name := range serviceMap {
+			// The consul service is never exported.
+			if name == "consul" {
+				continue
+			}
+			names = append(names, api.CompoundServiceName{Name: name, Namespace: namespace})
+		}
+	}
+	s.Log.Debug("expanded wildcard export", "partition", partition, "namespace", svc.Namespace, "services", len(names))
+	return names, nil
+}
+
+// addPorts populates the ports of svc from the instances registered in
+// Consul.
+func (s *ImportedSource) addPorts(client *api.Client, svc *ImportedService, opts *api.QueryOptions) error {
+	entries, _, err := client.Health().Service(svc.Name, "", false, opts)
+	if err != nil {
+		return fmt.Errorf("listing instances of imported service %q: %w", svc.Name, err)
+	}
+
+	ports := make(map[int32]struct{})
+	for _, entry := range entries {
+		if entry.Service != nil && entry.Service.Port != 0 {
+			ports[int32(entry.Service.Port)] = struct{}{}
+		}
+	}
+
+	for port := range ports {
+		svc.Ports = append(svc.Ports, port)
+	}
+	sort.Slice(svc.Ports, func(i, j int) bool { return svc.Ports[i] < svc.Ports[j] })
+	return nil
+}
+
+// kubeName returns the name of the Kubernetes service for an imported service,
+// made of the service name, its namespace if it isn't the default one, and
+// the peer or partition it was imported from. The name always ends with a
+// hash of these parts, so that services whose names only differ in
+// characters that had to be replaced, in where the dashes are, or after the
+// length limit, don't collide.
+func (s *ImportedSource) kubeName(svc *ImportedService) string {
+	kind, from := "peer", svc.Peer
+	if from == "" {
+		kind, from = "partition", svc.Partition
+	}
+	parts := []string{svc.Name}
+	if svc.Namespace != "" && svc.Namespace != "default" {
+		parts = append(parts, svc.Namespace)
+	}
+	parts = append(parts, from)
+	name := strings.Trim(invalidKubeNameChars.ReplaceAllString(strings.ToLower(s.Prefix+strings.Join(parts, "-")), "-"), "-")
+
+	// Consul names can't contain slashes, so the hashed string is unambiguous.
+	raw := strings.Join([]string{s.Prefix, svc.Name, svc.Namespace, kind, from}, "/")
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(raw)))[:kubeNameHashLength]
+	if maxLen := maxKubeNameLength - kubeNameHashLength - 1; len(name) > maxLen {
+		name = strings.TrimRight(name[:maxLen], "-")
+	}
+	return name + "-" + hash
+}
+
+// virtualDNS returns the Consul DNS name that resolves to the virtual IP of
+// svc. Services in a namespace use the labelled form that includes it, e.g.
+// web.virtual.ns1.ns.dc2.peer.consul.
+func (s *ImportedSource) virtualDNS(svc *ImportedService) string {
+	if svc.Partition != "" {
+		if svc.Namespace != "" {
+			return fmt.Sprintf("%s.virtual.%s.ns.%s.ap.%s", svc.Name, svc.Namespace, svc.Partition, s.Domain)
+		}
+		return fmt.Sprintf("%s.virtual.%s.ap.%s", svc.Name, svc.Partition, s.Domain)
+	}
+	if svc.Namespace != "" {
+		return fmt.Sprintf("%s.virtual.%s.ns.%s.peer.%s", svc.Name, svc.Namespace, svc.Peer, s.Domain)
+	}
+	return fmt.Sprintf("%s.virtual.%s.%s", svc.Name, svc.Peer, s.Domain)
+}
+
+// exportedTo returns true if svc is exported to the given partition.
+func exportedTo(svc api.ExportedService, partition string) bool {
+	for _, consumer := range svc.Consumers {
+		if consumer.Partition == partition {
+			return true
+		}
+	}
+	return false
+}
+
+// isNotFoundErr returns true if err is a 404 response from Consul.
+func isNotFoundErr(err error) bool {
+	var statusErr api.StatusError
+	return errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound
+}
@@ -0,0 +1,216 @@
+package catalog
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/consul/api"
+)
+
+const (
+	// DefaultGatewayBasePort is the first port of the ingress gateway
+	// listeners when no GatewayBasePort is configured.
+	DefaultGatewayBasePort = 20000
+
+	// importedSourceKey and importedSourceValue are set in the meta of the
+	// config entries the ImportedSource writes.
+	importedSourceKey   = "external-source"
+	importedSourceValue = "kubernetes"
+
+	// importedManagedKey marks the config entries the ImportedSource owns.
+	// Entries without it are never modified or deleted.
+	importedManagedKey = "consul-k8s-imported-services"
+)
+
+// syncGateway routes the imported services through the ingress gateway. Each
+// service gets a service resolver, named after its Kubernetes service, that
+// redirects to the peer or partition it was imported from, and a TCP
+// listener on the gateway. It sets the gateway port and addresses of the
+// services and removes from services those that can't be routed.
+func (s *ImportedSource) syncGateway(client *api.Client, services map[string]*ImportedService) error {
+	if s.Gateway == "" {
+		return nil
+	}
+	opts := &api.QueryOptions{Partition: s.Partition, Namespace: s.GatewayNamespace}
+	writeOpts := &api.WriteOptions{Partition: s.Partition, Namespace: s.GatewayNamespace}
+
+	var current *api.IngressGatewayConfigEntry
+	entry, _, err := client.ConfigEntries().Get(api.IngressGateway, s.Gateway, opts)
+	if err != nil && !isNotFoundErr(err) {
+		return fmt.Errorf("reading ingress gateway %q: %w", s.Gateway, err)
+	}
+	if err == nil {
+		var ok bool
+		current, ok = entry.(*api.IngressGatewayConfigEntry)
+		if !ok || !isImportedManaged(entry) {
+			return fmt.Errorf("ingress gateway %q is not managed by catalog sync, it must be dedicated to imported services", s.Gateway)
+		}
+	}
+
+	if err := s.syncResolvers(client, services, opts, writeOpts); err != nil {
+		return err
+	}
+
+	// Keep the listener port of the services that are already routed so that
+	// their Kubernetes services don't change.
+	routes := make(map[string]int32)
+	used := make(map[int32]bool)
+	if current != nil {
+		for port, name := range gatewayRoutes(current.Listeners) {
+			routes[name] = port
+			used[port] = true
+		}
+	}
+	basePort := s.GatewayBasePort
+	if basePort == 0 {
+		basePort = DefaultGatewayBasePort
+	}
+	next := basePort
+	for _, name := range sortedNames(services) {
+		port, ok := routes[name]
+		if !ok {
+			for used[next] {
+				next++
+			}
+			port = next
+			used[port] = true
+		}
+		services[name].GatewayPort = port
+	}
+
+	desired := &api.IngressGatewayConfigEntry{
+		Kind: api.IngressGateway,
+		Name: s.Gateway,
+		Meta: importedMeta(),
+	}
+	for _, name := range sortedNames(services) {
+		desired.Listeners = append(desired.Listeners, api.IngressListener{
+			Port:     int(services[name].GatewayPort),
+			Protocol: "tcp",
+			Services: []api.IngressService{{Name: name, Namespace: s.GatewayNamespace, Partition: s.Partition}},
+		})
+	}
+	if current == nil || !reflect.DeepEqual(gatewayRoutes(current.Listeners), gatewayRoutes(desired.Listeners)) {
+		if _, _, err := client.ConfigEntries().Set(desired, writeOpts); err != nil {
+			return fmt.Errorf("writing ingress gateway %q: %w", s.Gateway, err)
+		}
+	}
+
+	// Resolvers are deleted once the gateway no longer routes to them.
+	if err := s.deleteStaleResolvers(client, services, opts, writeOpts); err != nil {
+		return err
+	}
+
+	instances, _, err := client.Health().Service(s.Gateway, "", true, opts)
+	if err != nil {
+		return fmt.Errorf("listing instances of ingress gateway %q: %w", s.Gateway, err)
+	}
+	var addresses []string
+	for _, instance := range instances {
+		address := instance.Service.Address
+		if address == "" {
+			address = instance.Node.Address
+		}
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, svc := range services {
+		svc.GatewayAddresses = addresses
+	}
+	return nil
+}
+
+// syncResolvers writes the service resolvers that redirect to the imported
+// services. Services whose resolver name is taken by an entry we don't own
+// are removed from services.
+func (s *ImportedSource) syncResolvers(client *api.Client, services map[string]*ImportedService, opts *api.QueryOptions, writeOpts *api.WriteOptions) error {
+	for _, name := range sortedNames(services) {
+		svc := services[name]
+		desired := &api.ServiceResolverConfigEntry{
+			Kind: api.ServiceResolver,
+			Name: name,
+			Meta: importedMeta(),
+			Redirect: &api.ServiceResolverRedirect{
+				Service:   svc.Name,
+				Namespace: svc.Namespace,
+				Partition: svc.Partition,
+				Peer:      svc.Peer,
+			},
+		}
+
+		entry, _, err := client.ConfigEntries().Get(api.ServiceResolver, name, opts)
+		if err != nil && !isNotFoundErr(err) {
+			return fmt.Errorf("reading service resolver %q: %w", name, err)
+		}
+		if err == nil {
+			current, ok := entry.(*api.ServiceResolverConfigEntry)
+			if !ok || !isImportedManaged(entry) {
+				s.Log.Warn("service resolver is not managed by catalog sync, not syncing imported service", "name", name)
+				delete(services, name)
+				continue
+			}
+			if reflect.DeepEqual(current.Redirect, desired.Redirect) {
+				continue
+			}
+		}
+		if _, _, err := client.ConfigEntries().Set(desired, writeOpts); err != nil {
+			return fmt.Errorf("writing service resolver %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// deleteStaleResolvers deletes the service resolvers we own that don't route
+// to any of services.
+func (s *ImportedSource) deleteStaleResolvers(client *api.Client, services map[string]*ImportedService, opts *api.QueryOptions, writeOpts *api.WriteOptions) error {
+	entries, _, err := client.ConfigEntries().List(api.ServiceResolver, opts)
+	if err != nil {
+		return fmt.Errorf("listing service resolvers: %w", err)
+	}
+	for _, entry := range entries {
+		if _, ok := services[entry.GetName()]; ok || !isImportedManaged(entry) {
+			continue
+		}
+		if _, err := client.ConfigEntries().Delete(api.ServiceResolver, entry.GetName(), writeOpts); err != nil {
+			return fmt.Errorf("deleting service resolver %q: %w", entry.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// gatewayRoutes returns the service each single-service listener of an
+// ingress gateway routes to, keyed by port.
+func gatewayRoutes(listeners []api.IngressListener) map[int32]string {
+	routes := make(map[int32]string)
+	for _, listener := range listeners {
+		if len(listener.Services) == 1 {
+			routes[int32(listener.Port)] = listener.Services[0].Name
+		}
+	}
+	return routes
+}
+
+// importedMeta returns the meta of the config entries the ImportedSource
+// writes.
+func importedMeta() map[string]string {
+	return map[string]string{
+		importedSourceKey:  importedSourceValue,
+		importedManagedKey: "true",
+	}
+}
+
+// isImportedManaged returns true if entry was written by the ImportedSource.
+func isImportedManaged(entry api.ConfigEntry) bool {
+	return entry.GetMeta()[importedManagedKey] == "true"
+}
+
+// sortedNames returns the keys of services in order.
+func sortedNames(services map[string]*ImportedService) []string {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,108 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that imported services are routed through the ingress gateway and
+// keep their listener port across syncs.
+func TestImportedSource_syncGateway(t *testing.T) {
+	t.Parallel()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	client := testClient.APIClient
+
+	_, err := client.Catalog().Register(&api.CatalogRegistration{
+		Node:    "gateway-node",
+		Address: "10.0.0.1",
+		Service: &api.AgentService{
+			Kind:    api.ServiceKindIngressGateway,
+			Service: "imported-gateway",
+			Address: "10.1.0.1",
+			Port:    8443,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	// A resolver that we don't own is left alone.
+	_, _, err = client.ConfigEntries().Set(&api.ServiceResolverConfigEntry{
+		Kind:           api.ServiceResolver,
+		Name:           "db-dc2",
+		ConnectTimeout: 10,
+	}, nil)
+	require.NoError(t, err)
+
+	source := &ImportedSource{
+		Log:     hclog.Default(),
+		Gateway: "imported-gateway",
+	}
+	services := map[string]*ImportedService{
+		"web-dc2": {Name: "web", Peer: "dc2", Ports: []int32{8080}},
+		"api-dc2": {Name: "api", Peer: "dc2", Ports: []int32{9090}},
+		"db-dc2":  {Name: "db", Peer: "dc2", Ports: []int32{5432}},
+	}
+	require.NoError(t, source.syncGateway(client, services))
+
+	require.NotContains(t, services, "db-dc2")
+	require.Equal(t, int32(20000), services["api-dc2"].GatewayPort)
+	require.Equal(t, int32(20001), services["web-dc2"].GatewayPort)
+	require.Equal(t, []string{"10.1.0.1"}, services["web-dc2"].GatewayAddresses)
+
+	entry, _, err := client.ConfigEntries().Get(api.ServiceResolver, "web-dc2", nil)
+	require.NoError(t, err)
+	resolver := entry.(*api.ServiceResolverConfigEntry)
+	require.Equal(t, &api.ServiceResolverRedirect{Service: "web", Peer: "dc2"}, resolver.Redirect)
+	require.Equal(t, "true", resolver.Meta[importedManagedKey])
+
+	entry, _, err = client.ConfigEntries().Get(api.IngressGateway, "imported-gateway", nil)
+	require.NoError(t, err)
+	gateway := entry.(*api.IngressGatewayConfigEntry)
+	require.Equal(t, map[int32]string{20000: "api-dc2", 20001: "web-dc2"}, gatewayRoutes(gateway.Listeners))
+
+	// The web service keeps its port, the new service doesn't reuse the port
+	// of the removed one until the gateway no longer routes to it, and the
+	// resolver of the removed service is deleted.
+	services = map[string]*ImportedService{
+		"web-dc2":   {Name: "web", Peer: "dc2", Ports: []int32{8080}},
+		"cache-dc2": {Name: "cache", Peer: "dc2", Ports: []int32{6379}},
+	}
+	require.NoError(t, source.syncGateway(client, services))
+	require.Equal(t, int32(20001), services["web-dc2"].GatewayPort)
+	require.Equal(t, int32(20002), services["cache-dc2"].GatewayPort)
+
+	_, _, err = client.ConfigEntries().Get(api.ServiceResolver, "api-dc2", nil)
+	require.True(t, isNotFoundErr(err))
+	_, _, err = client.ConfigEntries().Get(api.ServiceResolver, "db-dc2", nil)
+	require.NoError(t, err)
+}
+
+// Test that an ingress gateway that we don't own isn't taken over.
+func TestImportedSource_syncGatewayUnmanaged(t *testing.T) {
+	t.Parallel()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	client := testClient.APIClient
+
+	_, _, err := client.ConfigEntries().Set(&api.IngressGatewayConfigEntry{
+		Kind: api.IngressGateway,
+		Name: "imported-gateway",
+	}, nil)
+	require.NoError(t, err)
+
+	source := &ImportedSource{
+		Log:     hclog.Default(),
+		Gateway: "imported-gateway",
+	}
+	err = source.syncGateway(client, map[string]*ImportedService{
+		"web-dc2": {Name: "web", Peer: "dc2", Ports: []int32{8080}},
+	})
+	require.EqualError(t, err, `ingress gateway "imported-gateway" is not managed by catalog sync, it must be dedicated to imported services`)
+
+	_, _, err = client.ConfigEntries().Get(api.ServiceResolver, "web-dc2", nil)
+	require.True(t, isNotFoundErr(err))
+}
@@ -0,0 +1,130 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportedSource_kubeName(t *testing.T) {
+	cases := map[string]struct {
+		prefix string
+		svc    *ImportedService
+		exp    string
+	}{
+		"peer": {
+			svc: &ImportedService{Name: "web", Peer: "dc2"},
+			exp: "web-dc2-b30e81db",
+		},
+		"partition": {
+			svc: &ImportedService{Name: "web", Partition: "ap1"},
+			exp: "web-ap1-5468748f",
+		},
+		"partition with the name of a peer": {
+			svc: &ImportedService{Name: "web", Partition: "dc2"},
+			exp: "web-dc2-e9342f4b",
+		},
+		"namespace": {
+			svc: &ImportedService{Name: "web", Namespace: "ns1", Partition: "ap1"},
+			exp: "web-ns1-ap1-436764c3",
+		},
+		"another namespace": {
+			svc: &ImportedService{Name: "web", Namespace: "ns2", Partition: "ap1"},
+			exp: "web-ns2-ap1-b1527f81",
+		},
+		"default namespace": {
+			svc: &ImportedService{Name: "web", Namespace: "default", Partition: "ap1"},
+			exp: "web-ap1-b3d20b4b",
+		},
+		"dash in the service name": {
+			svc: &ImportedService{Name: "web-api", Peer: "dc2"},
+			exp: "web-api-dc2-73e466cb",
+		},
+		"dash in the peer name": {
+			svc: &ImportedService{Name: "web", Peer: "api-dc2"},
+			exp: "web-api-dc2-d9b5b131",
+		},
+		"prefix and uppercase": {
+			prefix: "consul-",
+			svc:    &ImportedService{Name: "WEB", Peer: "DC2"},
+			exp:    "consul-web-dc2-8d5b1919",
+		},
+		"invalid characters": {
+			svc: &ImportedService{Name: "web_api", Peer: "dc2"},
+			exp: "web-api-dc2-dcb61780",
+		},
+		"truncated": {
+			svc: &ImportedService{Name: strings.Repeat("a", 60), Peer: "dc2"},
+			exp: strings.Repeat("a", 54) + "-62f3e66a",
+		},
+		"truncated with another hash": {
+			svc: &ImportedService{Name: strings.Repeat("a", 60), Peer: "dc3"},
+			exp: strings.Repeat("a", 54) + "-bf30a553",
+		},
+	}
+
+	names := make(map[string]string)
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &ImportedSource{Prefix: c.prefix}
+			require.Equal(t, c.exp, s.kubeName(c.svc))
+		})
+		names[c.exp] = name
+	}
+	require.Len(t, names, len(cases), "two cases have the same name")
+}
+
+func TestImportedSource_virtualDNS(t *testing.T) {
+	cases := map[string]struct {
+		svc *ImportedService
+		exp string
+	}{
+		"peer": {
+			svc: &ImportedService{Name: "web", Peer: "dc2"},
+			exp: "web.virtual.dc2.consul",
+		},
+		"peer with namespace": {
+			svc: &ImportedService{Name: "web", Namespace: "ns1", Peer: "dc2"},
+			exp: "web.virtual.ns1.ns.dc2.peer.consul",
+		},
+		"partition": {
+			svc: &ImportedService{Name: "web", Partition: "ap1"},
+			exp: "web.virtual.ap1.ap.consul",
+		},
+		"partition with namespace": {
+			svc: &ImportedService{Name: "web", Namespace: "ns1", Partition: "ap1"},
+			exp: "web.virtual.ns1.ns.ap1.ap.consul",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &ImportedSource{Domain: "consul"}
+			require.Equal(t, c.exp, s.virtualDNS(c.svc))
+		})
+	}
+}
+
+func TestIsNotFoundErr(t *testing.T) {
+	require.True(t, isNotFoundErr(fmt.Errorf("reading entry: %w", api.StatusError{Code: 404, Body: "not found"})))
+	require.False(t, isNotFoundErr(api.StatusError{Code: 500, Body: "404 partitions"}))
+	require.False(t, isNotFoundErr(errors.New("Unexpected response code: 404")))
+	require.False(t, isNotFoundErr(nil))
+}
+
+func TestExportedTo(t *testing.T) {
+	svc := api.ExportedService{
+		Name: "web",
+		Consumers: []api.ServiceConsumer{
+			{Partition: "ap1"},
+			{Peer: "dc2"},
+		},
+	}
+	require.True(t, exportedTo(svc, "ap1"))
+	require.False(t, exportedTo(svc, "dc2"))
+	require.False(t, exportedTo(svc, "default"))
+}
@@ -2,6 +2,8 @@ package catalog
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -9,8 +11,10 @@ import (
 	"github.com/hashicorp/consul-k8s/control-plane/helper/coalesce"
 	"github.com/hashicorp/go-hclog"
 	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -23,6 +27,15 @@ const (
 	// K8SMaxPeriod is the maximum time to wait before forcing a sync, even
 	// if there are active changes going on.
 	K8SMaxPeriod = 5 * time.Second
+
+	// importedLabel is the label set on Kube services created for services
+	// imported from cluster peers and admin partitions.
+	importedLabel = "consul-imported"
+
+	importedPeerAnnotation       = "consul.hashicorp.com/imported-peer"
+	importedPartitionAnnotation  = "consul.hashicorp.com/imported-partition"
+	importedNamespaceAnnotation  = "consul.hashicorp.com/imported-namespace"
+	importedVirtualDNSAnnotation = "consul.hashicorp.com/imported-virtual-dns"
 )
 
 // Sink is the destination where services are registered.
@@ -46,6 +59,10 @@ type K8SSink struct {
 	Namespace string               // Namespace is the namespace to sync to
 	Log       hclog.Logger         // Logger
 
+	// ImportedNamespace is the namespace to sync services imported from
+	// cluster peers and admin partitions to. Defaults to Namespace.
+	ImportedNamespace string
+
 	// SyncPeriod is the duration to wait between registering or deregistering
 	// services in Kubernetes. This can be fairly short since no work will be
 	// done if there are no changes.
@@ -79,6 +96,13 @@ type K8SSink struct {
 	// that were created by this sync process. Keys are Kube service names.
 	// It's populated from Kubernetes data.
 	serviceMapConsul map[string]*apiv1.Service
+
+	// importedServices holds services imported from cluster peers and
+	// admin partitions that should be synced to Kube as ClusterIP services.
+	// Keys are Kube service names. It's nil until SetImportedServices is
+	// called so that we never clean up imported services when the imported
+	// sync isn't enabled.
+	importedServices map[string]*ImportedService
 	triggerCh        chan struct{}
 }
 
@@ -102,6 +126,15 @@ func (s *K8SSink) SetServices(svcs map[string]string) {
 	s.trigger() // Any service change probably requires syncing
 }
 
+// SetImportedServices implements ImportedSink.
+func (s *K8SSink) SetImportedServices(svcs map[string]*ImportedService) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.importedServices = svcs
+	s.trigger()
+}
+
 // Informer implements the controller.Resource interface.
 // It tells Kubernetes that we want to watch for changes to Services.
 func (s *K8SSink) Informer() cache.SharedIndexInformer {
@@ -222,6 +255,7 @@ func (s *K8SSink) Run(ch <-chan struct{}) {
 
 		s.lock.Lock()
 		create, update, delete := s.crudList()
+		imported := s.importedServices
 		s.lock.Unlock()
 		s.Log.Debug("sync triggered", "create", len(create), "update", len(update), "delete", len(delete))
 
@@ -245,6 +279,10 @@ func (s *K8SSink) Run(ch <-chan struct{}) {
 				s.Log.Warn("error creating service", "name", svc.Name, "error", err)
 			}
 		}
+
+		if imported != nil {
+			s.syncImported(imported)
+		}
 	}
 }
 
@@ -307,6 +345,175 @@ func (s *K8SSink) crudList() ([]*apiv1.Service, []*apiv1.Service, []string) {
 	return create, update, delete
 }
 
+// syncImported creates, updates and deletes the services for services
+// imported from cluster peers and admin partitions. They are selector-less
+// ClusterIP services whose endpoints are the instances of the ingress gateway
+// that routes to the imported service, because the addresses of its
+// instances aren't routable from this cluster. Unlike the ExternalName
+// services, imported services may live in a different namespace than the one
+// we're watching so we list them on every sync.
+func (s *K8SSink) syncImported(imported map[string]*ImportedService) {
+	namespace := s.importedNamespace()
+	svcClient := s.Client.CoreV1().Services(namespace)
+	endpointsClient := s.Client.CoreV1().Endpoints(namespace)
+
+	existing, err := svcClient.List(s.Ctx, metav1.ListOptions{LabelSelector: importedLabel + "=true"})
+	if err != nil {
+		s.Log.Warn("error listing imported services", "namespace", namespace, "error", err)
+		return
+	}
+
+	existingByName := make(map[string]*apiv1.Service, len(existing.Items))
+	for i := range existing.Items {
+		svc := &existing.Items[i]
+		if _, ok := imported[svc.Name]; !ok {
+			if err := svcClient.Delete(s.Ctx, svc.Name, metav1.DeleteOptions{}); err != nil {
+				s.Log.Warn("error deleting imported service", "name", svc.Name, "error", err)
+			}
+			if err := endpointsClient.Delete(s.Ctx, svc.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				s.Log.Warn("error deleting imported service endpoints", "name", svc.Name, "error", err)
+			}
+			continue
+		}
+		existingByName[svc.Name] = svc
+	}
+
+	for name, importedSvc := range imported {
+		if len(importedSvc.Ports) == 0 {
+			s.Log.Debug("imported service has no ports, not syncing", "name", name)
+			continue
+		}
+
+		desired := importedServiceFor(name, importedSvc)
+		if current, ok := existingByName[name]; ok {
+			if current.Spec.Type != desired.Spec.Type ||
+				!reflect.DeepEqual(current.Spec.Ports, desired.Spec.Ports) ||
+				!reflect.DeepEqual(current.Annotations, desired.Annotations) {
+				current.Annotations = desired.Annotations
+				// Services created before imported services were routed
+				// through the ingress gateway are ExternalName services.
+				// The cluster IP is allocated when they're converted.
+				current.Spec.Type = desired.Spec.Type
+				current.Spec.ExternalName = ""
+				current.Spec.Ports = desired.Spec.Ports
+				if _, err := svcClient.Update(s.Ctx, current, metav1.UpdateOptions{}); err != nil {
+					s.Log.Warn("error updating imported service", "name", name, "error", err)
+					continue
+				}
+			}
+		} else {
+			// If this is a registered K8S service, ignore.
+			s.lock.Lock()
+			_, exists := s.serviceMap[name]
+			s.lock.Unlock()
+			if exists && namespace == s.namespace() {
+				s.Log.Warn("service already registered in K8S, not registering imported service", "name", name)
+				continue
+			}
+			if _, err := svcClient.Create(s.Ctx, desired, metav1.CreateOptions{}); err != nil {
+				s.Log.Warn("error creating imported service", "name", name, "error", err)
+				continue
+			}
+		}
+
+		desiredEndpoints := importedEndpointsFor(name, importedSvc)
+		currentEndpoints, err := endpointsClient.Get(s.Ctx, name, metav1.GetOptions{})
+		switch {
+		case k8serrors.IsNotFound(err):
+			if _, err := endpointsClient.Create(s.Ctx, desiredEndpoints, metav1.CreateOptions{}); err != nil {
+				s.Log.Warn("error creating imported service endpoints", "name", name, "error", err)
+			}
+		case err != nil:
+			s.Log.Warn("error reading imported service endpoints", "name", name, "error", err)
+		case !reflect.DeepEqual(currentEndpoints.Subsets, desiredEndpoints.Subsets):
+			currentEndpoints.Labels = desiredEndpoints.Labels
+			currentEndpoints.Subsets = desiredEndpoints.Subsets
+			if _, err := endpointsClient.Update(s.Ctx, currentEndpoints, metav1.UpdateOptions{}); err != nil {
+				s.Log.Warn("error updating imported service endpoints", "name", name, "error", err)
+			}
+		}
+	}
+}
+
+// importedServiceFor returns the ClusterIP service for an imported service.
+// Each port of the service targets the ingress gateway listener of the
+// service.
+func importedServiceFor(name string, svc *ImportedService) *apiv1.Service {
+	annotations := map[string]string{
+		// Ensure we don't sync the service back to Consul
+		"consul.hashicorp.com/service-sync": "false",
+		importedVirtualDNSAnnotation:        svc.VirtualDNS,
+	}
+	if svc.Peer != "" {
+		annotations[importedPeerAnnotation] = svc.Peer
+	}
+	if svc.Partition != "" {
+		annotations[importedPartitionAnnotation] = svc.Partition
+	}
+	if svc.Namespace != "" {
+		annotations[importedNamespaceAnnotation] = svc.Namespace
+	}
+
+	var ports []apiv1.ServicePort
+	for _, port := range svc.Ports {
+		ports = append(ports, apiv1.ServicePort{
+			Name:       fmt.Sprintf("port-%d", port),
+			Protocol:   apiv1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromInt(int(svc.GatewayPort)),
+		})
+	}
+
+	return &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{importedLabel: "true"},
+			Annotations: annotations,
+		},
+		Spec: apiv1.ServiceSpec{
+			Type:  apiv1.ServiceTypeClusterIP,
+			Ports: ports,
+		},
+	}
+}
+
+// importedEndpointsFor returns the endpoints of the service for an imported
+// service: the ingress gateway instances, on the listener port of the
+// service.
+func importedEndpointsFor(name string, svc *ImportedService) *apiv1.Endpoints {
+	var addresses []apiv1.EndpointAddress
+	for _, address := range svc.GatewayAddresses {
+		addresses = append(addresses, apiv1.EndpointAddress{IP: address})
+	}
+	var ports []apiv1.EndpointPort
+	for _, port := range svc.Ports {
+		ports = append(ports, apiv1.EndpointPort{
+			Name:     fmt.Sprintf("port-%d", port),
+			Protocol: apiv1.ProtocolTCP,
+			Port:     svc.GatewayPort,
+		})
+	}
+
+	endpoints := &apiv1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{importedLabel: "true"},
+		},
+	}
+	if len(addresses) > 0 {
+		endpoints.Subsets = []apiv1.EndpointSubset{{Addresses: addresses, Ports: ports}}
+	}
+	return endpoints
+}
+
+// importedNamespace returns the K8S namespace to sync imported services to.
+func (s *K8SSink) importedNamespace() string {
+	if s.ImportedNamespace != "" {
+		return s.ImportedNamespace
+	}
+	return s.namespace()
+}
+
 // namespace returns the K8S namespace to setup the resource watchers in.
 func (s *K8SSink) namespace() string {
 	if s.Namespace != "" {
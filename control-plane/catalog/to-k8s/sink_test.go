@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -403,3 +404,155 @@ func testSink(t *testing.T, client kubernetes.Interface) (*K8SSink, func()) {
 	closer := controller.TestControllerRun(sink)
 	return sink, closer
 }
+
+// Test that imported services are created in the imported namespace as
+// ExternalName services pointing at their virtual DNS names.
+func TestK8SSink_createImported(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+
+	sink, closer := testSink(t, client)
+	defer closer()
+	sink.ImportedNamespace = "imported"
+
+	sink.SetImportedServices(map[string]*ImportedService{
+		"web-dc2": {
+			Name:             "web",
+			Peer:             "dc2",
+			VirtualDNS:       "web.virtual.dc2.consul",
+			Ports:            []int32{8080},
+			GatewayPort:      20000,
+			GatewayAddresses: []string{"10.0.0.1", "10.0.0.2"},
+		},
+	})
+
+	var svc *apiv1.Service
+	retry.Run(t, func(r *retry.R) {
+		var err error
+		svc, err = client.CoreV1().Services("imported").Get(context.Background(), "web-dc2", metav1.GetOptions{})
+		if err != nil {
+			r.Fatalf("err: %s", err)
+		}
+	})
+	require.Equal(t, apiv1.ServiceTypeClusterIP, svc.Spec.Type)
+	require.Empty(t, svc.Spec.Selector)
+	require.Equal(t, "true", svc.Labels["consul-imported"])
+	require.Equal(t, "dc2", svc.Annotations["consul.hashicorp.com/imported-peer"])
+	require.Equal(t, "web.virtual.dc2.consul", svc.Annotations["consul.hashicorp.com/imported-virtual-dns"])
+	require.Equal(t, "false", svc.Annotations["consul.hashicorp.com/service-sync"])
+	require.Len(t, svc.Spec.Ports, 1)
+	require.Equal(t, int32(8080), svc.Spec.Ports[0].Port)
+	require.Equal(t, intstr.FromInt(20000), svc.Spec.Ports[0].TargetPort)
+
+	var endpoints *apiv1.Endpoints
+	retry.Run(t, func(r *retry.R) {
+		var err error
+		endpoints, err = client.CoreV1().Endpoints("imported").Get(context.Background(), "web-dc2", metav1.GetOptions{})
+		if err != nil {
+			r.Fatalf("err: %s", err)
+		}
+	})
+	require.Len(t, endpoints.Subsets, 1)
+	require.Equal(t, []apiv1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}}, endpoints.Subsets[0].Addresses)
+	require.Equal(t, []apiv1.EndpointPort{{Name: "port-8080", Protocol: apiv1.ProtocolTCP, Port: 20000}}, endpoints.Subsets[0].Ports)
+}
+
+// Test that imported services synced as ExternalName services are converted
+// to ClusterIP services.
+func TestK8SSink_convertImportedExternalName(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset(&apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-dc2",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"consul-imported": "true"},
+		},
+		Spec: apiv1.ServiceSpec{
+			Type:         apiv1.ServiceTypeExternalName,
+			ExternalName: "web.virtual.dc2.consul",
+		},
+	})
+
+	sink, closer := testSink(t, client)
+	defer closer()
+
+	sink.SetImportedServices(map[string]*ImportedService{
+		"web-dc2": {
+			Name:             "web",
+			Peer:             "dc2",
+			VirtualDNS:       "web.virtual.dc2.consul",
+			Ports:            []int32{8080},
+			GatewayPort:      20000,
+			GatewayAddresses: []string{"10.0.0.1"},
+		},
+	})
+
+	retry.Run(t, func(r *retry.R) {
+		svc, err := client.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), "web-dc2", metav1.GetOptions{})
+		if err != nil {
+			r.Fatalf("err: %s", err)
+		}
+		if svc.Spec.Type != apiv1.ServiceTypeClusterIP {
+			r.Fatalf("expected ClusterIP service, got %s", svc.Spec.Type)
+		}
+		if svc.Spec.ExternalName != "" {
+			r.Fatalf("expected no external name, got %s", svc.Spec.ExternalName)
+		}
+		if _, err := client.CoreV1().Endpoints(metav1.NamespaceDefault).Get(context.Background(), "web-dc2", metav1.GetOptions{}); err != nil {
+			r.Fatalf("err: %s", err)
+		}
+	})
+}
+
+// Test that imported services that are no longer imported are deleted and
+// that ExternalName services are left alone.
+func TestK8SSink_deleteImported(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+
+	sink, closer := testSink(t, client)
+	defer closer()
+
+	sink.SetServices(map[string]string{"web": "web.service.local."})
+	sink.SetImportedServices(map[string]*ImportedService{
+		"api-dc2": {
+			Name:        "api",
+			Peer:        "dc2",
+			VirtualDNS:  "api.virtual.dc2.consul",
+			Ports:       []int32{9090},
+			GatewayPort: 20000,
+		},
+	})
+
+	retry.Run(t, func(r *retry.R) {
+		list, err := client.CoreV1().Services(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			r.Fatalf("err: %s", err)
+		}
+		if len(list.Items) != 2 {
+			r.Fatalf("expected 2 services, got %d", len(list.Items))
+		}
+	})
+
+	sink.SetImportedServices(map[string]*ImportedService{})
+
+	retry.Run(t, func(r *retry.R) {
+		list, err := client.CoreV1().Services(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			r.Fatalf("err: %s", err)
+		}
+		if len(list.Items) != 1 {
+			r.Fatalf("expected 1 service, got %d", len(list.Items))
+		}
+		if list.Items[0].Name != "web" {
+			r.Fatalf("expected web service to remain, got %s", list.Items[0].Name)
+		}
+		endpoints, err := client.CoreV1().Endpoints(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			r.Fatalf("err: %s", err)
+		}
+		if len(endpoints.Items) != 0 {
+			r.Fatalf("expected imported service endpoints to be deleted, got %d", len(endpoints.Items))
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	cmdACLInit "github.com/hashicorp/consul-k8s/control-plane/subcommand/acl-init"
+	cmdConfigImport "github.com/hashicorp/consul-k8s/control-plane/subcommand/config-import"
 	cmdConnectInit "github.com/hashicorp/consul-k8s/control-plane/subcommand/connect-init"
 	cmdConsulLogout "github.com/hashicorp/consul-k8s/control-plane/subcommand/consul-logout"
 	cmdController "github.com/hashicorp/consul-k8s/control-plane/subcommand/controller"
@@ -92,6 +93,10 @@ func init() {
 		"install-cni": func() (cli.Command, error) {
 			return &cmdInstallCNI.Command{UI: ui}, nil
 		},
+
+		"config import": func() (cli.Command, error) {
+			return &cmdConfigImport.Command{UI: ui}, nil
+		},
 	}
 }
 
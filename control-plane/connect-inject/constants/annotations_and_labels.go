@@ -41,8 +41,16 @@ const (
 
 	// AnnotationGatewayWANAddress is the key of the annotation that when the source
 	// of the mesh-gateway is 'Static', is the value of the WAN address for the gateway.
+	// It can be a comma-separated list of addresses, the first of which is the
+	// primary WAN address.
 	AnnotationGatewayWANAddress = "consul.hashicorp.com/gateway-wan-address-static"
 
+	// AnnotationGatewayWANResolve is the key of the annotation that, when set to
+	// "true", resolves the hostnames among the WAN addresses of a gateway, e.g. the
+	// hostname of an AWS load balancer, and registers their IP addresses as
+	// additional WAN addresses.
+	AnnotationGatewayWANResolve = "consul.hashicorp.com/gateway-wan-address-resolve"
+
 	// AnnotationGatewayWANPort is the key of the annotation whose value is the
 	// WAN port for the mesh-gateway service registration.
 	AnnotationGatewayWANPort = "consul.hashicorp.com/gateway-wan-port"
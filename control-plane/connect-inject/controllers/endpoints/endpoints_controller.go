@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/go-logr/logr"
@@ -20,13 +21,20 @@ import (
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/go-multierror"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -41,6 +49,7 @@ const (
 	meshGateway        = "mesh-gateway"
 	terminatingGateway = "terminating-gateway"
 	ingressGateway     = "ingress-gateway"
+	apiGateway         = "api-gateway"
 
 	kubernetesSuccessReasonMsg = "Kubernetes health checks passing"
 	envoyPrometheusBindAddr    = "envoy_prometheus_bind_addr"
@@ -60,6 +69,13 @@ const (
 
 	// consulKubernetesCheckName is the name of health check in Consul for Kubernetes readiness status.
 	consulKubernetesCheckName = "Kubernetes Readiness Check"
+
+	// wanResolvePeriod is how often the Endpoints of gateways that resolve the
+	// hostnames of their WAN addresses are reconciled to pick up DNS changes.
+	wanResolvePeriod = 5 * time.Minute
+
+	// wanResolveTimeout bounds the DNS lookup of a WAN address hostname.
+	wanResolveTimeout = 5 * time.Second
 )
 
 type Controller struct {
@@ -112,6 +128,9 @@ type Controller struct {
 	// will delete any tokens associated with this auth method
 	// whenever service instances are deregistered.
 	AuthMethod string
+	// LookupHost resolves the hostnames of gateway WAN addresses when the
+	// gateway asks for it. Defaults to net.DefaultResolver.LookupHost.
+	LookupHost func(ctx context.Context, host string) ([]string, error)
 
 	MetricsConfig metrics.Config
 	Log           logr.Logger
@@ -177,6 +196,11 @@ func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	// against service instances in Consul to deregister them if they are not in the map.
 	endpointAddressMap := map[string]bool{}
 
+	// resolveWAN is set when a gateway resolves the hostnames of its WAN
+	// addresses, in which case the Endpoints are reconciled periodically
+	// because DNS changes don't trigger any Kubernetes event.
+	resolveWAN := false
+
 	// Register all addresses of this Endpoints object as service instances in Consul.
 	for _, subset := range serviceEndpoints.Subsets {
 		for address, healthStatus := range mapAddresses(subset) {
@@ -206,6 +230,9 @@ func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 				}
 				if isGateway(pod) {
 					endpointPods.Add(address.TargetRef.Name)
+					if pod.Annotations[constants.AnnotationGatewayWANResolve] == "true" {
+						resolveWAN = true
+					}
 					if err = r.registerGateway(apiClient, pod, serviceEndpoints, healthStatus, endpointAddressMap); err != nil {
 						r.Log.Error(err, "failed to register gateway or health check", "name", serviceEndpoints.Name, "ns", serviceEndpoints.Namespace)
 						errs = multierror.Append(errs, err)
@@ -223,6 +250,9 @@ func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		errs = multierror.Append(errs, err)
 	}
 
+	if resolveWAN {
+		return ctrl.Result{RequeueAfter: wanResolvePeriod}, errs
+	}
 	return ctrl.Result{}, errs
 }
 
@@ -230,12 +260,130 @@ func (r *Controller) Logger(name types.NamespacedName) logr.Logger {
 	return r.Log.WithValues("request", name)
 }
 
+// SetupWithManager watches Endpoints, as well as the Services and Nodes that
+// the WAN addresses of gateways are read from, so that gateways are
+// re-registered as soon as their addresses change.
 func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Endpoints{}).
+		Watches(
+			&source.Kind{Type: &corev1.Service{}},
+			handler.EnqueueRequestsFromMapFunc(r.endpointsForService),
+			builder.WithPredicates(serviceAddressesChanged()),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Node{}},
+			handler.EnqueueRequestsFromMapFunc(r.endpointsForNode),
+			builder.WithPredicates(nodeAddressesChanged()),
+		).
 		Complete(r)
 }
 
+// endpointsForService maps a Service to the Endpoints of the same name.
+func (r *Controller) endpointsForService(svc client.Object) []reconcile.Request {
+	if shouldIgnore(svc.GetNamespace(), r.DenyK8sNamespacesSet, r.AllowK8sNamespacesSet) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: svc.GetName(), Namespace: svc.GetNamespace()}}}
+}
+
+// endpointsForNode maps a Node to the Endpoints that target the gateway pods
+// running on it.
+func (r *Controller) endpointsForNode(node client.Object) []reconcile.Request {
+	var pods corev1.PodList
+	if err := r.Client.List(r.Context, &pods); err != nil {
+		r.Log.Error(err, "failed to list pods", "node", node.GetName())
+		return nil
+	}
+	// gatewayPods holds the gateway pods on the node by namespace.
+	gatewayPods := make(map[string]map[string]bool)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != node.GetName() || !isGateway(pod) ||
+			shouldIgnore(pod.Namespace, r.DenyK8sNamespacesSet, r.AllowK8sNamespacesSet) {
+			continue
+		}
+		if gatewayPods[pod.Namespace] == nil {
+			gatewayPods[pod.Namespace] = make(map[string]bool)
+		}
+		gatewayPods[pod.Namespace][pod.Name] = true
+	}
+
+	var requests []reconcile.Request
+	for ns, names := range gatewayPods {
+		var endpointsList corev1.EndpointsList
+		if err := r.Client.List(r.Context, &endpointsList, client.InNamespace(ns)); err != nil {
+			r.Log.Error(err, "failed to list endpoints", "node", node.GetName(), "ns", ns)
+			continue
+		}
+		for _, endpoints := range endpointsList.Items {
+			if endpointsTargetPods(endpoints, names) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: endpoints.Name, Namespace: endpoints.Namespace},
+				})
+			}
+		}
+	}
+	return requests
+}
+
+// endpointsTargetPods returns true if any address of endpoints targets one
+// of the named pods.
+func endpointsTargetPods(endpoints corev1.Endpoints, names map[string]bool) bool {
+	for _, subset := range endpoints.Subsets {
+		for address := range mapAddresses(subset) {
+			if address.TargetRef != nil && address.TargetRef.Kind == "Pod" && names[address.TargetRef.Name] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serviceAddressesChanged passes updates of the Service fields that gateway
+// WAN addresses are read from. Creates and deletes of Services already come
+// with an Endpoints event.
+func serviceAddressesChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSvc, ok := e.ObjectOld.(*corev1.Service)
+			if !ok {
+				return false
+			}
+			newSvc, ok := e.ObjectNew.(*corev1.Service)
+			if !ok {
+				return false
+			}
+			return oldSvc.Spec.Type != newSvc.Spec.Type ||
+				oldSvc.Spec.ClusterIP != newSvc.Spec.ClusterIP ||
+				!equality.Semantic.DeepEqual(oldSvc.Spec.ClusterIPs, newSvc.Spec.ClusterIPs) ||
+				!equality.Semantic.DeepEqual(oldSvc.Status.LoadBalancer, newSvc.Status.LoadBalancer)
+		},
+	}
+}
+
+// nodeAddressesChanged passes updates of the addresses of a Node.
+func nodeAddressesChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses)
+		},
+	}
+}
+
 // registerServicesAndHealthCheck creates Consul registrations for the service and proxy and registers them with Consul.
 // It also upserts a Kubernetes health check for the service based on whether the endpoint address is ready.
 func (r *Controller) registerServicesAndHealthCheck(apiClient *api.Client, pod corev1.Pod, serviceEndpoints corev1.Endpoints, healthStatus string, endpointAddressMap map[string]bool) error {
@@ -651,19 +799,14 @@ func (r *Controller) createGatewayRegistrations(pod corev1.Pod, serviceEndpoints
 			meta[metaKeyConsulWANFederation] = "1"
 		}
 
-		wanAddr, wanPort, err := r.getWanData(pod, serviceEndpoints)
+		wanAddrs, wanPort, err := r.getWanData(pod, serviceEndpoints)
 		if err != nil {
 			return nil, err
 		}
-		service.TaggedAddresses = map[string]api.ServiceAddress{
-			"lan": {
-				Address: pod.Status.PodIP,
-				Port:    port,
-			},
-			"wan": {
-				Address: wanAddr,
-				Port:    wanPort,
-			},
+		service.TaggedAddresses = wanTaggedAddresses(wanAddrs, wanPort)
+		service.TaggedAddresses["lan"] = api.ServiceAddress{
+			Address: pod.Status.PodIP,
+			Port:    port,
 		}
 	case terminatingGateway:
 		service.Kind = api.ServiceKindTerminatingGateway
@@ -679,21 +822,36 @@ func (r *Controller) createGatewayRegistrations(pod corev1.Pod, serviceEndpoints
 			consulNS = ns
 		}
 
-		wanAddr, wanPort, err := r.getWanData(pod, serviceEndpoints)
+		wanAddrs, wanPort, err := r.getWanData(pod, serviceEndpoints)
 		if err != nil {
 			return nil, err
 		}
 		service.Port = 21000
-		service.TaggedAddresses = map[string]api.ServiceAddress{
-			"lan": {
-				Address: pod.Status.PodIP,
-				Port:    21000,
-			},
-			"wan": {
-				Address: wanAddr,
-				Port:    wanPort,
+		service.TaggedAddresses = wanTaggedAddresses(wanAddrs, wanPort)
+		service.TaggedAddresses["lan"] = api.ServiceAddress{
+			Address: pod.Status.PodIP,
+			Port:    21000,
+		}
+		service.Proxy = &api.AgentServiceConnectProxyConfig{
+			Config: map[string]interface{}{
+				"envoy_gateway_no_default_bind": true,
+				"envoy_gateway_bind_addresses": map[string]interface{}{
+					"all-interfaces": map[string]interface{}{
+						"address": "0.0.0.0",
+					},
+				},
 			},
 		}
+	case apiGateway:
+		service.Kind = api.ServiceKindAPIGateway
+		if ns, ok := pod.Annotations[constants.AnnotationGatewayNamespace]; ok && r.EnableConsulNamespaces {
+			service.Namespace = ns
+			consulNS = ns
+		}
+
+		// API gateway listeners are configured from the api-gateway config
+		// entry, so the registration only needs a port for health checking.
+		service.Port = 21000
 		service.Proxy = &api.AgentServiceConnectProxyConfig{
 			Config: map[string]interface{}{
 				"envoy_gateway_no_default_bind": true,
@@ -706,11 +864,11 @@ func (r *Controller) createGatewayRegistrations(pod corev1.Pod, serviceEndpoints
 		}
 
 	default:
-		return nil, fmt.Errorf("%s must be one of %s, %s, or %s", constants.AnnotationGatewayKind, meshGateway, terminatingGateway, ingressGateway)
+		return nil, fmt.Errorf("%s must be one of %s, %s, %s, or %s", constants.AnnotationGatewayKind, meshGateway, terminatingGateway, ingressGateway, apiGateway)
 	}
 
 	if r.MetricsConfig.DefaultEnableMetrics && r.MetricsConfig.EnableGatewayMetrics {
-		if pod.Annotations[constants.AnnotationGatewayKind] == ingressGateway {
+		if kind := pod.Annotations[constants.AnnotationGatewayKind]; kind == ingressGateway || kind == apiGateway {
 			service.Proxy.Config["envoy_prometheus_bind_addr"] = fmt.Sprintf("%s:20200", pod.Status.PodIP)
 		} else {
 			service.Proxy = &api.AgentServiceConnectProxyConfig{
@@ -743,40 +901,42 @@ func (r *Controller) createGatewayRegistrations(pod corev1.Pod, serviceEndpoints
 	return serviceRegistration, nil
 }
 
-func (r *Controller) getWanData(pod corev1.Pod, endpoints corev1.Endpoints) (string, int, error) {
-	var wanAddr string
+// getWanData returns the WAN addresses and port of a gateway pod. The first
+// address is the primary WAN address of the gateway. Depending on the source,
+// there can be several addresses, e.g. for dual-stack Services, load balancers
+// with several ingress points, or nodes with both IPv4 and IPv6 addresses.
+func (r *Controller) getWanData(pod corev1.Pod, endpoints corev1.Endpoints) ([]string, int, error) {
+	var wanAddrs []string
 	source, ok := pod.Annotations[constants.AnnotationGatewayWANSource]
 	if !ok {
-		return "", 0, fmt.Errorf("failed to read annotation %s", constants.AnnotationGatewayWANSource)
+		return nil, 0, fmt.Errorf("failed to read annotation %s", constants.AnnotationGatewayWANSource)
 	}
 	switch source {
 	case "NodeName":
-		wanAddr = pod.Spec.NodeName
+		wanAddrs = []string{pod.Spec.NodeName}
 	case "NodeIP":
-		wanAddr = pod.Status.HostIP
+		wanAddrs = r.nodeAddresses(pod)
 	case "Static":
-		wanAddr = pod.Annotations[constants.AnnotationGatewayWANAddress]
+		wanAddrs = strings.Split(pod.Annotations[constants.AnnotationGatewayWANAddress], ",")
 	case "Service":
 		svc, err := r.getService(endpoints)
 		if err != nil {
-			return "", 0, fmt.Errorf("failed to read service %s in namespace %s", endpoints.Name, endpoints.Namespace)
+			return nil, 0, fmt.Errorf("failed to read service %s in namespace %s", endpoints.Name, endpoints.Namespace)
 		}
 		switch svc.Spec.Type {
 		case corev1.ServiceTypeNodePort:
-			wanAddr = pod.Status.HostIP
+			wanAddrs = r.nodeAddresses(pod)
 		case corev1.ServiceTypeClusterIP:
-			wanAddr = svc.Spec.ClusterIP
+			wanAddrs = append([]string{svc.Spec.ClusterIP}, svc.Spec.ClusterIPs...)
 		case corev1.ServiceTypeLoadBalancer:
 			if len(svc.Status.LoadBalancer.Ingress) == 0 {
-				return "", 0, fmt.Errorf("failed to read ingress config for loadbalancer for service %s in namespace %s", endpoints.Name, endpoints.Namespace)
+				return nil, 0, fmt.Errorf("failed to read ingress config for loadbalancer for service %s in namespace %s", endpoints.Name, endpoints.Namespace)
 			}
 			for _, ingr := range svc.Status.LoadBalancer.Ingress {
 				if ingr.IP != "" {
-					wanAddr = ingr.IP
-					break
+					wanAddrs = append(wanAddrs, ingr.IP)
 				} else if ingr.Hostname != "" {
-					wanAddr = ingr.Hostname
-					break
+					wanAddrs = append(wanAddrs, ingr.Hostname)
 				}
 			}
 		}
@@ -784,9 +944,121 @@ func (r *Controller) getWanData(pod corev1.Pod, endpoints corev1.Endpoints) (str
 
 	wanPort, err := strconv.Atoi(pod.Annotations[constants.AnnotationGatewayWANPort])
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to parse WAN port from value %s", pod.Annotations[constants.AnnotationGatewayWANPort])
+		return nil, 0, fmt.Errorf("failed to parse WAN port from value %s", pod.Annotations[constants.AnnotationGatewayWANPort])
+	}
+
+	wanAddrs = uniqueAddresses(wanAddrs)
+	if pod.Annotations[constants.AnnotationGatewayWANResolve] == "true" {
+		wanAddrs = r.resolveHostnames(wanAddrs)
+	}
+	return wanAddrs, wanPort, nil
+}
+
+// nodeAddresses returns the host IP of pod, followed by the other addresses of
+// its node with the same type as the host IP, e.g. the IPv6 InternalIP of a
+// dual-stack node. If the node can't be read, only the host IP is returned.
+func (r *Controller) nodeAddresses(pod corev1.Pod) []string {
+	addrs := []string{pod.Status.HostIP}
+	if pod.Spec.NodeName == "" {
+		return addrs
+	}
+	var node corev1.Node
+	if err := r.Client.Get(r.Context, types.NamespacedName{Name: pod.Spec.NodeName}, &node); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			r.Log.Error(err, "failed to get node", "name", pod.Spec.NodeName)
+		}
+		return addrs
+	}
+	var hostIPType corev1.NodeAddressType
+	for _, addr := range node.Status.Addresses {
+		if addr.Address == pod.Status.HostIP {
+			hostIPType = addr.Type
+			break
+		}
+	}
+	if hostIPType == "" {
+		return addrs
+	}
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == hostIPType {
+			addrs = append(addrs, addr.Address)
+		}
+	}
+	return addrs
+}
+
+// resolveHostnames appends the IP addresses that the hostnames among addrs
+// resolve to. Lookup failures are logged so that the gateway is still
+// registered with its other addresses.
+func (r *Controller) resolveHostnames(addrs []string) []string {
+	lookupHost := r.LookupHost
+	if lookupHost == nil {
+		lookupHost = net.DefaultResolver.LookupHost
+	}
+	resolved := addrs
+	for _, addr := range addrs {
+		if net.ParseIP(addr) != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), wanResolveTimeout)
+		ips, err := lookupHost(ctx, addr)
+		cancel()
+		if err != nil {
+			r.Log.Error(err, "failed to resolve WAN address", "host", addr)
+			continue
+		}
+		resolved = append(resolved, ips...)
+	}
+	return uniqueAddresses(resolved)
+}
+
+// uniqueAddresses returns addrs without empty and duplicate addresses, keeping
+// their order.
+func uniqueAddresses(addrs []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		unique = append(unique, addr)
+	}
+	return unique
+}
+
+// wanTaggedAddresses returns the tagged addresses for the WAN addresses of a
+// gateway. The primary address is tagged "wan". When there are several
+// addresses, the first IPv4 and IPv6 addresses are also tagged "wan_ipv4" and
+// "wan_ipv6", and the other addresses are tagged "wan_1", "wan_2" and so on.
+func wanTaggedAddresses(addrs []string, port int) map[string]api.ServiceAddress {
+	tagged := make(map[string]api.ServiceAddress)
+	if len(addrs) == 0 {
+		tagged["wan"] = api.ServiceAddress{Port: port}
+		return tagged
+	}
+	tagged["wan"] = api.ServiceAddress{Address: addrs[0], Port: port}
+	if len(addrs) == 1 {
+		return tagged
+	}
+	for i, addr := range addrs {
+		if i > 0 {
+			tagged[fmt.Sprintf("wan_%d", i)] = api.ServiceAddress{Address: addr, Port: port}
+		}
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			continue
+		}
+		tag := "wan_ipv6"
+		if ip.To4() != nil {
+			tag = "wan_ipv4"
+		}
+		if _, ok := tagged[tag]; !ok {
+			tagged[tag] = api.ServiceAddress{Address: addr, Port: port}
+		}
 	}
-	return wanAddr, wanPort, nil
+	return tagged
 }
 
 func (r *Controller) getService(endpoints corev1.Endpoints) (*corev1.Service, error) {
@@ -24,6 +24,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 func TestShouldIgnore(t *testing.T) {
@@ -1451,6 +1452,75 @@ func TestReconcileCreateEndpoint(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "API Gateway",
+			svcName:       "api-gateway",
+			consulSvcName: "api-gateway",
+			k8sObjects: func() []runtime.Object {
+				gateway := createGatewayPod("api-gateway", "1.2.3.4", map[string]string{
+					constants.AnnotationGatewayConsulServiceName: "api-gateway",
+					constants.AnnotationGatewayKind:              apiGateway,
+				})
+				endpoint := &corev1.Endpoints{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "api-gateway",
+						Namespace: "default",
+					},
+					Subsets: []corev1.EndpointSubset{
+						{
+							Addresses: []corev1.EndpointAddress{
+								{
+									IP: "1.2.3.4",
+									TargetRef: &corev1.ObjectReference{
+										Kind:      "Pod",
+										Name:      "api-gateway",
+										Namespace: "default",
+									},
+								},
+							},
+						},
+					},
+				}
+				return []runtime.Object{gateway, endpoint}
+			},
+			expectedConsulSvcInstances: []*api.CatalogService{
+				{
+					ServiceID:      "api-gateway",
+					ServiceName:    "api-gateway",
+					ServiceAddress: "1.2.3.4",
+					ServicePort:    21000,
+					ServiceMeta: map[string]string{
+						constants.MetaKeyPodName: "api-gateway",
+						metaKeyKubeServiceName:   "api-gateway",
+						constants.MetaKeyKubeNS:  "default",
+						metaKeyManagedBy:         constants.ManagedByValue,
+						metaKeySyntheticNode:     "true",
+					},
+					ServiceTags: []string{},
+					ServiceProxy: &api.AgentServiceConnectProxyConfig{
+						Config: map[string]interface{}{
+							"envoy_gateway_no_default_bind": true,
+							"envoy_gateway_bind_addresses": map[string]interface{}{
+								"all-interfaces": map[string]interface{}{
+									"address": "0.0.0.0",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedHealthChecks: []*api.HealthCheck{
+				{
+					CheckID:     "default/api-gateway",
+					ServiceName: "api-gateway",
+					ServiceID:   "api-gateway",
+					Name:        consulKubernetesCheckName,
+					Status:      api.HealthPassing,
+					Output:      kubernetesSuccessReasonMsg,
+					Type:        consulKubernetesCheckType,
+				},
+			},
+		},
 		{
 			name:           "Ingress Gateway with Metrics enabled",
 			metricsEnabled: true,
@@ -5586,7 +5656,7 @@ func Test_GetWANData(t *testing.T) {
 		gatewayPod      corev1.Pod
 		gatewayEndpoint corev1.Endpoints
 		k8sObjects      func() []runtime.Object
-		wanAddr         string
+		wanAddrs        []string
 		wanPort         int
 		expErr          string
 	}{
@@ -5635,8 +5705,8 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-nodename",
-			wanPort: 1234,
+			wanAddrs: []string{"test-nodename"},
+			wanPort:  1234,
 		},
 		"source=HostIP": {
 			gatewayPod: corev1.Pod{
@@ -5683,8 +5753,8 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-host-ip",
-			wanPort: 1234,
+			wanAddrs: []string{"test-host-ip"},
+			wanPort:  1234,
 		},
 		"source=Static": {
 			gatewayPod: corev1.Pod{
@@ -5731,8 +5801,8 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-wan-address",
-			wanPort: 1234,
+			wanAddrs: []string{"test-wan-address"},
+			wanPort:  1234,
 		},
 		"source=Service, serviceType=NodePort": {
 			gatewayPod: corev1.Pod{
@@ -5779,8 +5849,8 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-host-ip",
-			wanPort: 1234,
+			wanAddrs: []string{"test-host-ip"},
+			wanPort:  1234,
 		},
 		"source=Service, serviceType=ClusterIP": {
 			gatewayPod: corev1.Pod{
@@ -5827,8 +5897,8 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-cluster-ip",
-			wanPort: 1234,
+			wanAddrs: []string{"test-cluster-ip"},
+			wanPort:  1234,
 		},
 		"source=Service, serviceType=LoadBalancer,IP": {
 			gatewayPod: corev1.Pod{
@@ -5875,8 +5945,8 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-loadbalancer-ip",
-			wanPort: 1234,
+			wanAddrs: []string{"test-loadbalancer-ip"},
+			wanPort:  1234,
 		},
 		"source=Service, serviceType=LoadBalancer,Hostname": {
 			gatewayPod: corev1.Pod{
@@ -5923,8 +5993,8 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-loadbalancer-hostname",
-			wanPort: 1234,
+			wanAddrs: []string{"test-loadbalancer-hostname"},
+			wanPort:  1234,
 		},
 		"no Source annotation": {
 			gatewayPod: corev1.Pod{
@@ -5970,9 +6040,9 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-loadbalancer-hostname",
-			wanPort: 1234,
-			expErr:  "failed to read annotation consul.hashicorp.com/gateway-wan-address-source",
+			wanAddrs: []string{"test-loadbalancer-hostname"},
+			wanPort:  1234,
+			expErr:   "failed to read annotation consul.hashicorp.com/gateway-wan-address-source",
 		},
 		"no Service with Source=Service": {
 			gatewayPod: corev1.Pod{
@@ -5998,7 +6068,7 @@ func Test_GetWANData(t *testing.T) {
 				},
 			},
 			k8sObjects: func() []runtime.Object { return nil },
-			wanAddr:    "test-loadbalancer-hostname",
+			wanAddrs:   []string{"test-loadbalancer-hostname"},
 			wanPort:    1234,
 			expErr:     "failed to read service gateway in namespace default",
 		},
@@ -6047,9 +6117,9 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-loadbalancer-hostname",
-			wanPort: 1234,
-			expErr:  "failed to parse WAN port from value not-a-valid-port",
+			wanAddrs: []string{"test-loadbalancer-hostname"},
+			wanPort:  1234,
+			expErr:   "failed to parse WAN port from value not-a-valid-port",
 		},
 		"source=Service, serviceType=LoadBalancer no Ingress configured": {
 			gatewayPod: corev1.Pod{
@@ -6092,9 +6162,9 @@ func Test_GetWANData(t *testing.T) {
 				}
 				return []runtime.Object{service}
 			},
-			wanAddr: "test-loadbalancer-hostname",
-			wanPort: 1234,
-			expErr:  "failed to read ingress config for loadbalancer for service gateway in namespace default",
+			wanAddrs: []string{"test-loadbalancer-hostname"},
+			wanPort:  1234,
+			expErr:   "failed to read ingress config for loadbalancer for service gateway in namespace default",
 		},
 	}
 
@@ -6104,10 +6174,10 @@ func Test_GetWANData(t *testing.T) {
 			epCtrl := Controller{
 				Client: fakeClient,
 			}
-			addr, port, err := epCtrl.getWanData(c.gatewayPod, c.gatewayEndpoint)
+			addrs, port, err := epCtrl.getWanData(c.gatewayPod, c.gatewayEndpoint)
 			if c.expErr == "" {
 				require.NoError(t, err)
-				require.Equal(t, c.wanAddr, addr)
+				require.Equal(t, c.wanAddrs, addrs)
 				require.Equal(t, c.wanPort, port)
 			} else {
 				require.EqualError(t, err, c.expErr)
@@ -6116,6 +6186,240 @@ func Test_GetWANData(t *testing.T) {
 	}
 }
 
+func Test_GetWANData_MultipleAddresses(t *testing.T) {
+	gatewayPod := func(source string, annotations map[string]string) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gateway",
+				Namespace: "default",
+				Annotations: map[string]string{
+					constants.AnnotationGatewayWANSource: source,
+					constants.AnnotationGatewayWANPort:   "443",
+				},
+			},
+			Spec:   corev1.PodSpec{NodeName: "node"},
+			Status: corev1.PodStatus{HostIP: "10.0.0.1"},
+		}
+		for k, v := range annotations {
+			pod.Annotations[k] = v
+		}
+		return pod
+	}
+	gatewayService := func(svcType corev1.ServiceType, clusterIPs []string, ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: svcType, ClusterIPs: clusterIPs},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress},
+			},
+		}
+		if len(clusterIPs) > 0 {
+			svc.Spec.ClusterIP = clusterIPs[0]
+		}
+		return svc
+	}
+	dualStackNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+				{Type: corev1.NodeInternalIP, Address: "fd00::1"},
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.1"},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		gatewayPod corev1.Pod
+		k8sObjects []runtime.Object
+		wanAddrs   []string
+	}{
+		"Static with a list of addresses": {
+			gatewayPod: gatewayPod("Static", map[string]string{
+				constants.AnnotationGatewayWANAddress: "203.0.113.1, 2001:db8::1,,203.0.113.1",
+			}),
+			wanAddrs: []string{"203.0.113.1", "2001:db8::1"},
+		},
+		"NodeIP on a dual-stack node": {
+			gatewayPod: gatewayPod("NodeIP", nil),
+			k8sObjects: []runtime.Object{dualStackNode},
+			wanAddrs:   []string{"10.0.0.1", "fd00::1"},
+		},
+		"NodeIP without a node": {
+			gatewayPod: gatewayPod("NodeIP", nil),
+			wanAddrs:   []string{"10.0.0.1"},
+		},
+		"Service of type NodePort on a dual-stack node": {
+			gatewayPod: gatewayPod("Service", nil),
+			k8sObjects: []runtime.Object{dualStackNode, gatewayService(corev1.ServiceTypeNodePort, []string{"10.96.0.1"})},
+			wanAddrs:   []string{"10.0.0.1", "fd00::1"},
+		},
+		"dual-stack Service of type ClusterIP": {
+			gatewayPod: gatewayPod("Service", nil),
+			k8sObjects: []runtime.Object{gatewayService(corev1.ServiceTypeClusterIP, []string{"10.96.0.1", "fd00:96::1"})},
+			wanAddrs:   []string{"10.96.0.1", "fd00:96::1"},
+		},
+		"Service of type LoadBalancer with several ingress points": {
+			gatewayPod: gatewayPod("Service", nil),
+			k8sObjects: []runtime.Object{gatewayService(corev1.ServiceTypeLoadBalancer, nil,
+				corev1.LoadBalancerIngress{IP: "203.0.113.1"},
+				corev1.LoadBalancerIngress{IP: "2001:db8::1"},
+				corev1.LoadBalancerIngress{Hostname: "gateway.example.com"},
+			)},
+			wanAddrs: []string{"203.0.113.1", "2001:db8::1", "gateway.example.com"},
+		},
+		"resolved load balancer hostname": {
+			gatewayPod: gatewayPod("Service", map[string]string{constants.AnnotationGatewayWANResolve: "true"}),
+			k8sObjects: []runtime.Object{gatewayService(corev1.ServiceTypeLoadBalancer, nil,
+				corev1.LoadBalancerIngress{Hostname: "gateway.example.com"},
+			)},
+			wanAddrs: []string{"gateway.example.com", "203.0.113.10", "2001:db8::10"},
+		},
+		"unresolvable load balancer hostname": {
+			gatewayPod: gatewayPod("Service", map[string]string{constants.AnnotationGatewayWANResolve: "true"}),
+			k8sObjects: []runtime.Object{gatewayService(corev1.ServiceTypeLoadBalancer, nil,
+				corev1.LoadBalancerIngress{Hostname: "unknown.example.com"},
+				corev1.LoadBalancerIngress{IP: "203.0.113.1"},
+			)},
+			wanAddrs: []string{"unknown.example.com", "203.0.113.1"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			epCtrl := Controller{
+				Client: fake.NewClientBuilder().WithRuntimeObjects(c.k8sObjects...).Build(),
+				Log:    logrtest.TestLogger{T: t},
+				LookupHost: func(_ context.Context, host string) ([]string, error) {
+					if host == "gateway.example.com" {
+						return []string{"203.0.113.10", "2001:db8::10"}, nil
+					}
+					return nil, fmt.Errorf("no such host %s", host)
+				},
+			}
+			endpoints := corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"}}
+			addrs, port, err := epCtrl.getWanData(c.gatewayPod, endpoints)
+			require.NoError(t, err)
+			require.Equal(t, c.wanAddrs, addrs)
+			require.Equal(t, 443, port)
+		})
+	}
+}
+
+func Test_WANTaggedAddresses(t *testing.T) {
+	cases := map[string]struct {
+		addrs    []string
+		expected map[string]api.ServiceAddress
+	}{
+		"no address": {
+			expected: map[string]api.ServiceAddress{
+				"wan": {Port: 443},
+			},
+		},
+		"single address": {
+			addrs: []string{"203.0.113.1"},
+			expected: map[string]api.ServiceAddress{
+				"wan": {Address: "203.0.113.1", Port: 443},
+			},
+		},
+		"dual-stack and hostname": {
+			addrs: []string{"gateway.example.com", "203.0.113.1", "2001:db8::1", "203.0.113.2"},
+			expected: map[string]api.ServiceAddress{
+				"wan":      {Address: "gateway.example.com", Port: 443},
+				"wan_1":    {Address: "203.0.113.1", Port: 443},
+				"wan_2":    {Address: "2001:db8::1", Port: 443},
+				"wan_3":    {Address: "203.0.113.2", Port: 443},
+				"wan_ipv4": {Address: "203.0.113.1", Port: 443},
+				"wan_ipv6": {Address: "2001:db8::1", Port: 443},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.expected, wanTaggedAddresses(c.addrs, 443))
+		})
+	}
+}
+
+func TestEndpointsForNode(t *testing.T) {
+	gateway := func(name, namespace, node string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Annotations: map[string]string{constants.AnnotationGatewayKind: meshGateway},
+			},
+			Spec: corev1.PodSpec{NodeName: node},
+		}
+	}
+	endpointsFor := func(name, namespace string, pods ...string) *corev1.Endpoints {
+		endpoints := &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Subsets:    []corev1.EndpointSubset{{}},
+		}
+		for _, pod := range pods {
+			endpoints.Subsets[0].Addresses = append(endpoints.Subsets[0].Addresses, corev1.EndpointAddress{
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: pod, Namespace: namespace},
+			})
+		}
+		return endpoints
+	}
+	app := createServicePod("app", "1.2.3.4", true, true)
+	app.Spec.NodeName = "node"
+
+	fakeClient := fake.NewClientBuilder().WithRuntimeObjects(
+		gateway("mesh-gateway", "consul", "node"),
+		gateway("mesh-gateway-2", "consul", "other-node"),
+		gateway("ignored-gateway", "kube-system", "node"),
+		app,
+		endpointsFor("mesh-gateway", "consul", "mesh-gateway", "mesh-gateway-2"),
+		endpointsFor("other-mesh-gateway", "consul", "mesh-gateway-2"),
+		endpointsFor("ignored-gateway", "kube-system", "ignored-gateway"),
+		endpointsFor("app", "default", "app"),
+	).Build()
+	epCtrl := Controller{
+		Client:                fakeClient,
+		Context:               context.Background(),
+		Log:                   logrtest.TestLogger{T: t},
+		AllowK8sNamespacesSet: mapset.NewSetWith("*"),
+		DenyK8sNamespacesSet:  mapset.NewSetWith("kube-system"),
+	}
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+	require.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Name: "mesh-gateway", Namespace: "consul"}}},
+		epCtrl.endpointsForNode(node))
+}
+
+func TestAddressPredicates(t *testing.T) {
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.96.0.1"},
+	}
+	svcWithIngress := svc.DeepCopy()
+	svcWithIngress.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "203.0.113.1"}}
+	svcWithLabel := svc.DeepCopy()
+	svcWithLabel.Labels = map[string]string{"foo": "bar"}
+
+	servicePredicate := serviceAddressesChanged()
+	require.True(t, servicePredicate.Update(event.UpdateEvent{ObjectOld: svc, ObjectNew: svcWithIngress}))
+	require.False(t, servicePredicate.Update(event.UpdateEvent{ObjectOld: svc, ObjectNew: svcWithLabel}))
+	require.False(t, servicePredicate.Create(event.CreateEvent{Object: svc}))
+
+	node := &corev1.Node{
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}}},
+	}
+	nodeWithNewAddress := node.DeepCopy()
+	nodeWithNewAddress.Status.Addresses[0].Address = "10.0.0.2"
+	nodeWithCondition := node.DeepCopy()
+	nodeWithCondition.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+
+	nodePredicate := nodeAddressesChanged()
+	require.True(t, nodePredicate.Update(event.UpdateEvent{ObjectOld: node, ObjectNew: nodeWithNewAddress}))
+	require.False(t, nodePredicate.Update(event.UpdateEvent{ObjectOld: node, ObjectNew: nodeWithCondition}))
+	require.False(t, nodePredicate.Create(event.CreateEvent{Object: node}))
+}
+
 func createServicePod(name, ip string, inject bool, managedByEndpointsController bool) *corev1.Pod {
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
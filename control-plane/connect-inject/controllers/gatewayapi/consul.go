@@ -0,0 +1,73 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+)
+
+// consulClient creates a Consul API client for a reconcile.
+func (c Config) consulClient() (*capi.Client, error) {
+	serverState, err := c.ConsulServerConnMgr.State()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Consul server state: %w", err)
+	}
+	return consul.NewClientFromConnMgrState(c.ConsulClientConfig, serverState)
+}
+
+// writeEntry writes a config entry translated from the Kubernetes object
+// kubeName in kubeNS. It returns a conditionError without writing when a
+// config entry with the same name that was not translated from that object
+// already exists in Consul.
+func (c Config) writeEntry(client *capi.Client, entry capi.ConfigEntry, kubeNS, kubeName string) (*conditionError, error) {
+	if c.EnableConsulNamespaces && entry.GetNamespace() != "" {
+		if _, err := namespaces.EnsureExists(client, entry.GetNamespace(), c.CrossNSACLPolicy); err != nil {
+			return nil, fmt.Errorf("failed to ensure Consul namespace %q exists: %w", entry.GetNamespace(), err)
+		}
+	}
+
+	existing, _, err := client.ConfigEntries().Get(entry.GetKind(), entry.GetName(), &capi.QueryOptions{
+		Namespace: entry.GetNamespace(),
+		Partition: entry.GetPartition(),
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return nil, fmt.Errorf("failed to read %s %q from Consul: %w", entry.GetKind(), entry.GetName(), err)
+	}
+	if existing != nil && !ownedBy(existing.GetMeta(), kubeNS, kubeName) {
+		return newConditionError(reasonInvalid, fmt.Sprintf("%s %q already exists in Consul and was not created from %s/%s", entry.GetKind(), entry.GetName(), kubeNS, kubeName)), nil
+	}
+
+	if _, _, err := client.ConfigEntries().Set(entry, &capi.WriteOptions{
+		Namespace: entry.GetNamespace(),
+		Partition: entry.GetPartition(),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to write %s %q to Consul: %w", entry.GetKind(), entry.GetName(), err)
+	}
+	return nil, nil
+}
+
+// deleteEntry deletes a config entry from Consul if it was translated from the
+// Kubernetes object kubeName in kubeNS.
+func (c Config) deleteEntry(client *capi.Client, kind, name, consulNS, kubeNS, kubeName string) error {
+	opts := &capi.QueryOptions{Namespace: consulNS, Partition: c.ConsulPartition}
+	existing, _, err := client.ConfigEntries().Get(kind, name, opts)
+	if isNotFoundErr(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read %s %q from Consul: %w", kind, name, err)
+	}
+	if !ownedBy(existing.GetMeta(), kubeNS, kubeName) {
+		return nil
+	}
+	if _, err := client.ConfigEntries().Delete(kind, name, &capi.WriteOptions{Namespace: consulNS, Partition: c.ConsulPartition}); err != nil {
+		return fmt.Errorf("failed to delete %s %q from Consul: %w", kind, name, err)
+	}
+	return nil
+}
+
+func isNotFoundErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "404")
+}
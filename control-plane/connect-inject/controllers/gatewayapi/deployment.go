@@ -0,0 +1,363 @@
+package gatewayapi
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// labelGatewayName is the label on the resources created for a Gateway
+	// whose value is the name of the Gateway.
+	labelGatewayName = "gateway.consul.hashicorp.com/name"
+	// labelGatewayManaged is the label on the resources created for a Gateway
+	// that marks them as managed by the Gateway controller.
+	labelGatewayManaged = "gateway.consul.hashicorp.com/managed"
+
+	apiGatewayKind = "api-gateway"
+
+	gatewayReadyPort = 21000
+	volumeName       = "consul-connect-inject-data"
+	proxyIDFile      = "/consul/connect-inject/proxyid"
+	tokenPath        = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// DeploymentConfig configures the deployments that run the gateways.
+type DeploymentConfig struct {
+	ImageConsulDataplane string
+	ImageConsulK8S       string
+
+	// ConsulAddress is the address of the Consul servers. It may be a DNS name
+	// or an exec= string.
+	ConsulAddress       string
+	ConsulGRPCPort      int
+	ConsulHTTPPort      int
+	ConsulAPITimeout    string
+	ConsulCACert        string
+	ConsulTLSServerName string
+	TLSEnabled          bool
+	SkipServerWatch     bool
+
+	// AuthMethod is the name of the Kubernetes auth method the gateways log in
+	// with when ACLs are enabled.
+	AuthMethod string
+
+	// ServiceType is the type of the Service created for each Gateway.
+	ServiceType corev1.ServiceType
+	// Replicas is the number of gateway pods deployed for each Gateway.
+	Replicas int32
+
+	LogLevel string
+	LogJSON  bool
+}
+
+// gatewayLabels returns the labels that select the resources created for gw.
+func gatewayLabels(gw gateway) map[string]string {
+	return map[string]string{
+		labelGatewayName:    gw.Name,
+		labelGatewayManaged: "true",
+	}
+}
+
+// gatewayServiceAccount returns the ServiceAccount the gateway pods run as.
+// Its name is the name of the Gateway so that the ACL binding rules of the
+// auth method give the gateway a service identity for its Consul service.
+func gatewayServiceAccount(gw gateway) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gw.Name,
+			Namespace: gw.Namespace,
+			Labels:    gatewayLabels(gw),
+		},
+	}
+}
+
+// gatewayService returns the Service that exposes the listeners of gw.
+func (c Config) gatewayService(gw gateway, listeners []listener) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gw.Name,
+			Namespace: gw.Namespace,
+			Labels:    gatewayLabels(gw),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     c.Deployment.ServiceType,
+			Selector: gatewayLabels(gw),
+		},
+	}
+	seen := make(map[int32]bool)
+	for _, l := range listeners {
+		// Listeners can share a port when they have different hostnames.
+		if seen[l.Port] {
+			continue
+		}
+		seen[l.Port] = true
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       fmt.Sprintf("%s-%d", strings.ToLower(l.Protocol), l.Port),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       l.Port,
+			TargetPort: intstr.FromInt(int(l.Port)),
+		})
+	}
+	return svc
+}
+
+// gatewayDeployment returns the Deployment that runs the gateway pods for gw.
+// The pods are registered with Consul by the endpoints controller, which
+// recognizes them by their gateway annotations.
+func (c Config) gatewayDeployment(gw gateway, listeners []listener) (*appsv1.Deployment, error) {
+	consulNS := c.consulNamespace(gw.Namespace)
+
+	annotations := map[string]string{
+		constants.AnnotationInject:                   "false",
+		constants.AnnotationGatewayKind:              apiGatewayKind,
+		constants.AnnotationGatewayConsulServiceName: gw.Name,
+	}
+	if c.EnableConsulNamespaces {
+		annotations[constants.AnnotationGatewayNamespace] = consulNS
+	}
+
+	podLabels := gatewayLabels(gw)
+	podLabels[constants.KeyManagedBy] = constants.ManagedByValue
+
+	initContainer, err := c.gatewayInitContainer(gw, consulNS)
+	if err != nil {
+		return nil, err
+	}
+
+	container := corev1.Container{
+		Name:  "consul-dataplane",
+		Image: c.Deployment.ImageConsulDataplane,
+		Env: []corev1.EnvVar{
+			{
+				Name:      "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+			},
+			{
+				Name:      "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+			},
+			{
+				Name:      "POD_IP",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}},
+			},
+		},
+		Command: []string{"/bin/sh", "-ec", strings.Join(c.dataplaneArgs(consulNS), " ")},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: volumeName, MountPath: "/consul/connect-inject"},
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: "gateway-health", ContainerPort: gatewayReadyPort},
+		},
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(gatewayReadyPort)},
+			},
+			InitialDelaySeconds: 10,
+			PeriodSeconds:       10,
+			FailureThreshold:    3,
+		},
+		SecurityContext: &corev1.SecurityContext{
+			// Gateway listeners commonly bind to privileged ports such as 80 and 443.
+			Capabilities: &corev1.Capabilities{
+				Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+	}
+	seen := make(map[int32]bool)
+	for _, l := range listeners {
+		if seen[l.Port] {
+			continue
+		}
+		seen[l.Port] = true
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          fmt.Sprintf("gateway-%d", l.Port),
+			ContainerPort: l.Port,
+		})
+	}
+
+	replicas := c.Deployment.Replicas
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gw.Name,
+			Namespace: gw.Namespace,
+			Labels:    gatewayLabels(gw),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: gatewayLabels(gw)},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: gw.Name,
+					Volumes: []corev1.Volume{
+						{
+							Name: volumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{initContainer},
+					Containers:     []corev1.Container{container},
+				},
+			},
+		},
+	}, nil
+}
+
+const gatewayInitCommandTpl = `
+consul-k8s-control-plane connect-init -pod-name=${POD_NAME} -pod-namespace=${POD_NAMESPACE} \
+  -gateway-kind="{{ .GatewayKind }}" \
+  -consul-node-name="{{ .ConsulNodeName }}" \
+  -proxy-id-file={{ .ProxyIDFile }} \
+  -log-level={{ .LogLevel }} \
+  -log-json={{ .LogJSON }}
+`
+
+// gatewayInitContainer returns the connect-init container that waits for the
+// gateway pod to be registered with Consul and writes its proxy ID for the
+// dataplane.
+func (c Config) gatewayInitContainer(gw gateway, consulNS string) (corev1.Container, error) {
+	var buf bytes.Buffer
+	tpl := template.Must(template.New("root").Parse(strings.TrimSpace(gatewayInitCommandTpl)))
+	err := tpl.Execute(&buf, struct {
+		GatewayKind    string
+		ConsulNodeName string
+		ProxyIDFile    string
+		LogLevel       string
+		LogJSON        bool
+	}{
+		GatewayKind:    apiGatewayKind,
+		ConsulNodeName: constants.ConsulNodeName,
+		ProxyIDFile:    proxyIDFile,
+		LogLevel:       c.Deployment.LogLevel,
+		LogJSON:        c.Deployment.LogJSON,
+	})
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	container := corev1.Container{
+		Name:  "consul-gateway-init",
+		Image: c.Deployment.ImageConsulK8S,
+		Env: []corev1.EnvVar{
+			{
+				Name:      "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+			},
+			{
+				Name:      "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+			},
+			{Name: "CONSUL_ADDRESSES", Value: c.Deployment.ConsulAddress},
+			{Name: "CONSUL_GRPC_PORT", Value: strconv.Itoa(c.Deployment.ConsulGRPCPort)},
+			{Name: "CONSUL_HTTP_PORT", Value: strconv.Itoa(c.Deployment.ConsulHTTPPort)},
+			{Name: "CONSUL_API_TIMEOUT", Value: c.Deployment.ConsulAPITimeout},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: volumeName, MountPath: "/consul/connect-inject"},
+		},
+		Command: []string{"/bin/sh", "-ec", buf.String()},
+	}
+	if c.Deployment.TLSEnabled {
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "CONSUL_USE_TLS", Value: "true"},
+			corev1.EnvVar{Name: "CONSUL_CACERT_PEM", Value: c.Deployment.ConsulCACert},
+			corev1.EnvVar{Name: "CONSUL_TLS_SERVER_NAME", Value: c.Deployment.ConsulTLSServerName})
+	}
+	if c.Deployment.AuthMethod != "" {
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "CONSUL_LOGIN_AUTH_METHOD", Value: c.Deployment.AuthMethod},
+			corev1.EnvVar{Name: "CONSUL_LOGIN_BEARER_TOKEN_FILE", Value: tokenPath},
+			corev1.EnvVar{Name: "CONSUL_LOGIN_META", Value: "pod=$(POD_NAMESPACE)/$(POD_NAME)"})
+		if c.EnableConsulNamespaces {
+			container.Env = append(container.Env, corev1.EnvVar{Name: "CONSUL_LOGIN_NAMESPACE", Value: c.loginNamespace(consulNS)})
+		}
+		if c.ConsulPartition != "" {
+			container.Env = append(container.Env, corev1.EnvVar{Name: "CONSUL_LOGIN_PARTITION", Value: c.ConsulPartition})
+		}
+	}
+	if c.EnableConsulNamespaces {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "CONSUL_NAMESPACE", Value: consulNS})
+	}
+	if c.ConsulPartition != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "CONSUL_PARTITION", Value: c.ConsulPartition})
+	}
+	return container, nil
+}
+
+// dataplaneArgs returns the consul-dataplane command that runs the gateway.
+func (c Config) dataplaneArgs(consulNS string) []string {
+	args := []string{
+		"consul-dataplane",
+		fmt.Sprintf("-addresses=%q", c.Deployment.ConsulAddress),
+		"-grpc-port=" + strconv.Itoa(c.Deployment.ConsulGRPCPort),
+		"-proxy-service-id=" + fmt.Sprintf("$(cat %s)", proxyIDFile),
+		"-service-node-name=" + constants.ConsulNodeName,
+		"-envoy-ready-bind-address=$POD_IP",
+		"-envoy-ready-bind-port=" + strconv.Itoa(gatewayReadyPort),
+		"-log-level=" + c.Deployment.LogLevel,
+		"-log-json=" + strconv.FormatBool(c.Deployment.LogJSON),
+	}
+	if c.Deployment.SkipServerWatch {
+		args = append(args, "-server-watch-disabled=true")
+	}
+	if c.Deployment.AuthMethod != "" {
+		args = append(args,
+			"-credential-type=login",
+			"-login-auth-method="+c.Deployment.AuthMethod,
+			"-login-bearer-token-path="+tokenPath,
+			"-login-meta=pod=$POD_NAMESPACE/$POD_NAME",
+		)
+		if c.EnableConsulNamespaces {
+			args = append(args, "-login-namespace="+c.loginNamespace(consulNS))
+		}
+		if c.ConsulPartition != "" {
+			args = append(args, "-login-partition="+c.ConsulPartition)
+		}
+	}
+	if c.EnableConsulNamespaces {
+		args = append(args, "-service-namespace="+consulNS)
+	}
+	if c.ConsulPartition != "" {
+		args = append(args, "-service-partition="+c.ConsulPartition)
+	}
+	if c.Deployment.TLSEnabled {
+		if c.Deployment.ConsulTLSServerName != "" {
+			args = append(args, "-tls-server-name="+c.Deployment.ConsulTLSServerName)
+		}
+		if c.Deployment.ConsulCACert != "" {
+			args = append(args, "-ca-certs="+constants.ConsulCAFile)
+		}
+	} else {
+		args = append(args, "-tls-disabled")
+	}
+	return args
+}
+
+// loginNamespace returns the namespace of the auth method gateways log in
+// with. With mirroring the auth method lives in the default namespace.
+func (c Config) loginNamespace(consulNS string) string {
+	if c.EnableNSMirroring {
+		return "default"
+	}
+	return consulNS
+}
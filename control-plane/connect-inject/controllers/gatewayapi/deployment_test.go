@@ -0,0 +1,116 @@
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/constants"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGatewayService(t *testing.T) {
+	gw := gateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"}}
+	listeners := []listener{
+		{Name: "http", Port: 80, Protocol: protocolHTTP},
+		{Name: "other-host", Port: 80, Protocol: protocolHTTP, Hostname: strPtr("other.example.com")},
+		{Name: "tcp", Port: 5432, Protocol: protocolTCP},
+	}
+	config := Config{Deployment: DeploymentConfig{ServiceType: corev1.ServiceTypeNodePort}}
+
+	svc := config.gatewayService(gw, listeners)
+	require.Equal(t, corev1.ServiceTypeNodePort, svc.Spec.Type)
+	require.Equal(t, gatewayLabels(gw), svc.Spec.Selector)
+	require.Len(t, svc.Spec.Ports, 2)
+	require.Equal(t, "http-80", svc.Spec.Ports[0].Name)
+	require.Equal(t, int32(80), svc.Spec.Ports[0].Port)
+	require.Equal(t, "tcp-5432", svc.Spec.Ports[1].Name)
+}
+
+func TestGatewayDeployment(t *testing.T) {
+	gw := gateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "apps"}}
+	listeners := []listener{{Name: "http", Port: 80, Protocol: protocolHTTP}}
+
+	cases := map[string]struct {
+		config         Config
+		expAnnotations map[string]string
+		expArgs        []string
+		expInitEnv     map[string]string
+	}{
+		"defaults": {
+			config: Config{Deployment: DeploymentConfig{ConsulAddress: "consul-server", ConsulGRPCPort: 8502}},
+			expAnnotations: map[string]string{
+				constants.AnnotationInject:                   "false",
+				constants.AnnotationGatewayKind:              "api-gateway",
+				constants.AnnotationGatewayConsulServiceName: "gateway",
+			},
+			expArgs:    []string{`-addresses="consul-server"`, "-grpc-port=8502", "-tls-disabled"},
+			expInitEnv: map[string]string{"CONSUL_ADDRESSES": "consul-server"},
+		},
+		"TLS, ACLs and mirrored namespaces": {
+			config: Config{
+				EnableConsulNamespaces: true,
+				EnableNSMirroring:      true,
+				ConsulPartition:        "part",
+				Deployment: DeploymentConfig{
+					ConsulAddress:       "consul-server",
+					TLSEnabled:          true,
+					ConsulCACert:        "ca",
+					ConsulTLSServerName: "server.dc1.consul",
+					AuthMethod:          "k8s-auth-method",
+				},
+			},
+			expAnnotations: map[string]string{
+				constants.AnnotationInject:                   "false",
+				constants.AnnotationGatewayKind:              "api-gateway",
+				constants.AnnotationGatewayConsulServiceName: "gateway",
+				constants.AnnotationGatewayNamespace:         "apps",
+			},
+			expArgs: []string{
+				"-credential-type=login",
+				"-login-auth-method=k8s-auth-method",
+				"-login-namespace=default",
+				"-login-partition=part",
+				"-service-namespace=apps",
+				"-service-partition=part",
+				"-tls-server-name=server.dc1.consul",
+				"-ca-certs=" + constants.ConsulCAFile,
+			},
+			expInitEnv: map[string]string{
+				"CONSUL_USE_TLS":           "true",
+				"CONSUL_CACERT_PEM":        "ca",
+				"CONSUL_LOGIN_AUTH_METHOD": "k8s-auth-method",
+				"CONSUL_LOGIN_NAMESPACE":   "default",
+				"CONSUL_NAMESPACE":         "apps",
+				"CONSUL_PARTITION":         "part",
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			deployment, err := c.config.gatewayDeployment(gw, listeners)
+			require.NoError(t, err)
+
+			pod := deployment.Spec.Template
+			require.Equal(t, c.expAnnotations, pod.Annotations)
+			require.Equal(t, constants.ManagedByValue, pod.Labels[constants.KeyManagedBy])
+			require.Equal(t, "gateway", pod.Spec.ServiceAccountName)
+			require.Equal(t, int32(1), *deployment.Spec.Replicas)
+
+			require.Len(t, pod.Spec.InitContainers, 1)
+			require.Contains(t, pod.Spec.InitContainers[0].Command[2], `-gateway-kind="api-gateway"`)
+			env := make(map[string]string)
+			for _, e := range pod.Spec.InitContainers[0].Env {
+				env[e.Name] = e.Value
+			}
+			for k, v := range c.expInitEnv {
+				require.Equal(t, v, env[k], k)
+			}
+
+			require.Len(t, pod.Spec.Containers, 1)
+			for _, arg := range c.expArgs {
+				require.Contains(t, pod.Spec.Containers[0].Command[2], arg)
+			}
+		})
+	}
+}
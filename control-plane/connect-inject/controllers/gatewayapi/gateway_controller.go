@@ -0,0 +1,618 @@
+package gatewayapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	capi "github.com/hashicorp/consul/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	finalizerName = "gateway-finalizer.consul.hashicorp.com"
+
+	// annotationConfigHash is the annotation on the Deployments and Services
+	// created for Gateways that holds a hash of their desired spec. It lets
+	// the controller skip updates that would only undo server-side defaults.
+	annotationConfigHash = "gateway.consul.hashicorp.com/config-hash"
+
+	addressTypeIP       = "IPAddress"
+	addressTypeHostname = "Hostname"
+)
+
+// GatewayController deploys the Gateways of Consul managed GatewayClasses and
+// translates them into api-gateway config entries.
+type GatewayController struct {
+	client.Client
+	Config
+	// Log is the logger for this controller.
+	Log logr.Logger
+	// Scheme is used to set owner references on the resources created for
+	// each Gateway.
+	Scheme *runtime.Scheme
+	// RouteGVKs are the route kinds whose attachment to Gateways is counted.
+	RouteGVKs []schema.GroupVersionKind
+}
+
+// listenerResult is the outcome of validating a Gateway listener.
+type listenerResult struct {
+	listener     listener
+	accepted     *conditionError
+	resolvedRefs *conditionError
+	certificates []*corev1.Secret
+}
+
+func (l listenerResult) valid() bool {
+	return l.accepted == nil && l.resolvedRefs == nil
+}
+
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+func (r *GatewayController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("gateway", req.NamespacedName)
+
+	obj := newObject(GatewayGVK)
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "failed to get Gateway")
+		return ctrl.Result{}, err
+	}
+	var gw gateway
+	if err := decode(obj, &gw); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	managed, err := managedClass(ctx, r.Client, gw.Spec.GatewayClassName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Gateways that are being deleted or no longer belong to a Consul
+	// GatewayClass are removed from Consul. Their Deployment and Service are
+	// garbage collected through their owner references.
+	if !managed || !obj.GetDeletionTimestamp().IsZero() {
+		if !controllerutil.ContainsFinalizer(obj, finalizerName) {
+			return ctrl.Result{}, nil
+		}
+		apiClient, err := r.consulClient()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.Info("deleting Gateway from Consul")
+		if err := r.deleteGateway(ctx, apiClient, gw); err != nil {
+			logger.Error(err, "failed to delete Gateway from Consul")
+			return ctrl.Result{}, err
+		}
+		controllerutil.RemoveFinalizer(obj, finalizerName)
+		return ctrl.Result{}, r.Update(ctx, obj)
+	}
+
+	if !controllerutil.ContainsFinalizer(obj, finalizerName) {
+		controllerutil.AddFinalizer(obj, finalizerName)
+		if err := r.Update(ctx, obj); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	results, err := r.resolveListeners(ctx, gw)
+	if err != nil {
+		logger.Error(err, "failed to resolve Gateway listeners")
+		return ctrl.Result{}, err
+	}
+
+	apiClient, err := r.consulClient()
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+
+	// Write the certificates before the gateway that references them.
+	for i := range results {
+		if !results[i].valid() {
+			continue
+		}
+		for _, secret := range results[i].certificates {
+			conflict, err := r.writeEntry(apiClient, r.translateCertificate(secret), secret.Namespace, secret.Name)
+			if err != nil {
+				logger.Error(err, "failed to write certificate to Consul", "secret", secret.Name)
+				return ctrl.Result{}, err
+			}
+			if conflict != nil {
+				results[i].resolvedRefs = newConditionError(reasonInvalidCertificateRef, conflict.message)
+			}
+		}
+	}
+
+	var listeners []listener
+	certificates := make(map[string][]*corev1.Secret)
+	for _, result := range results {
+		if result.valid() {
+			listeners = append(listeners, result.listener)
+			certificates[result.listener.Name] = result.certificates
+		}
+	}
+
+	gatewayErr, err := r.writeEntry(apiClient, r.translateGateway(gw, listeners, certificates), gw.Namespace, gw.Name)
+	if err != nil {
+		logger.Error(err, "failed to write Gateway to Consul")
+		return ctrl.Result{}, err
+	}
+	if gatewayErr == nil && len(listeners) == 0 {
+		gatewayErr = newConditionError(reasonListenersNotValid, "Gateway has no valid listeners")
+	}
+
+	deployment, svc, err := r.ensureResources(ctx, obj, gw, listeners)
+	if err != nil {
+		logger.Error(err, "failed to deploy Gateway")
+		return ctrl.Result{}, err
+	}
+
+	attached, err := r.attachedRoutes(ctx, gw)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := r.gatewayStatus(gw, results, gatewayErr, deployment, svc, attached)
+	if reflect.DeepEqual(status, gw.Status) {
+		return ctrl.Result{}, nil
+	}
+	if err := setStatus(obj, &status); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.Client.Status().Update(ctx, obj)
+}
+
+// resolveListeners validates each listener of gw and resolves the Secrets of
+// its TLS configuration.
+func (r *GatewayController) resolveListeners(ctx context.Context, gw gateway) ([]listenerResult, error) {
+	var results []listenerResult
+	for _, l := range gw.Spec.Listeners {
+		result := listenerResult{listener: l}
+		if _, ok := consulProtocol(l.Protocol); !ok {
+			result.accepted = newConditionError(reasonUnsupportedProtocol, fmt.Sprintf("protocol %q is not supported, must be one of HTTP, HTTPS or TCP", l.Protocol))
+			results = append(results, result)
+			continue
+		}
+		if l.TLS != nil && stringOr(l.TLS.Mode, "Terminate") != "Terminate" {
+			result.accepted = newConditionError(reasonUnsupportedValue, "only the Terminate TLS mode is supported")
+			results = append(results, result)
+			continue
+		}
+		if l.Protocol == protocolHTTPS && (l.TLS == nil || len(l.TLS.CertificateRefs) == 0) {
+			result.resolvedRefs = newConditionError(reasonInvalidCertificateRef, "HTTPS listeners must reference a certificate")
+		}
+		if l.TLS != nil {
+			for _, ref := range l.TLS.CertificateRefs {
+				secret, refErr, err := resolveCertificate(ctx, r.Client, gw.Namespace, ref)
+				if err != nil {
+					return nil, err
+				}
+				if refErr != nil {
+					result.resolvedRefs = refErr
+					break
+				}
+				result.certificates = append(result.certificates, secret)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// ensureResources creates or updates the ServiceAccount, Service and
+// Deployment of gw.
+func (r *GatewayController) ensureResources(ctx context.Context, owner *unstructured.Unstructured, gw gateway, listeners []listener) (*appsv1.Deployment, *corev1.Service, error) {
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sa, func() error {
+		sa.Labels = gatewayServiceAccount(gw).Labels
+		return ctrl.SetControllerReference(owner, sa, r.Scheme)
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	desiredSvc := r.gatewayService(gw, listeners)
+	svcHash, err := configHash(desiredSvc.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		if svc.Annotations[annotationConfigHash] != svcHash {
+			// Keep the node ports the API server allocated for ports that didn't change.
+			nodePorts := make(map[int32]int32)
+			for _, p := range svc.Spec.Ports {
+				nodePorts[p.Port] = p.NodePort
+			}
+			ports := desiredSvc.Spec.Ports
+			for i := range ports {
+				ports[i].NodePort = nodePorts[ports[i].Port]
+			}
+			svc.Labels = desiredSvc.Labels
+			svc.Spec.Type = desiredSvc.Spec.Type
+			svc.Spec.Selector = desiredSvc.Spec.Selector
+			svc.Spec.Ports = ports
+			metav1.SetMetaDataAnnotation(&svc.ObjectMeta, annotationConfigHash, svcHash)
+		}
+		return ctrl.SetControllerReference(owner, svc, r.Scheme)
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	desiredDeployment, err := r.gatewayDeployment(gw, listeners)
+	if err != nil {
+		return nil, nil, err
+	}
+	deploymentHash, err := configHash(desiredDeployment.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		if deployment.Annotations[annotationConfigHash] != deploymentHash {
+			deployment.Labels = desiredDeployment.Labels
+			deployment.Spec = desiredDeployment.Spec
+			metav1.SetMetaDataAnnotation(&deployment.ObjectMeta, annotationConfigHash, deploymentHash)
+		}
+		return ctrl.SetControllerReference(owner, deployment, r.Scheme)
+	}); err != nil {
+		return nil, nil, err
+	}
+	return deployment, svc, nil
+}
+
+// gatewayStatus returns the status of gw from the results of its listeners,
+// the error writing it to Consul and the state of its Deployment and Service.
+func (r *GatewayController) gatewayStatus(gw gateway, results []listenerResult, gatewayErr *conditionError, deployment *appsv1.Deployment, svc *corev1.Service, attached map[string]int32) gatewayStatus {
+	status := gatewayStatus{Addresses: gatewayAddresses(svc)}
+
+	var programmedErr *conditionError
+	if deployment.Status.AvailableReplicas == 0 || len(status.Addresses) == 0 {
+		programmedErr = newConditionError(reasonPending, "waiting for the gateway pods to become ready")
+	}
+	if gatewayErr != nil {
+		programmedErr = gatewayErr
+	}
+
+	accepted := condition(conditionAccepted, reasonAccepted, "Gateway is accepted", gatewayErr, gw.Generation)
+	for _, result := range results {
+		if !result.valid() && gatewayErr == nil {
+			accepted.Reason = reasonListenersNotValid
+			accepted.Message = "Gateway is accepted but some of its listeners are not valid"
+			break
+		}
+	}
+	status.Conditions = mergeConditions(gw.Status.Conditions,
+		accepted,
+		condition(conditionProgrammed, reasonProgrammed, "Gateway is programmed", programmedErr, gw.Generation))
+
+	existing := make(map[string][]metav1.Condition)
+	for _, l := range gw.Status.Listeners {
+		existing[l.Name] = l.Conditions
+	}
+	for _, result := range results {
+		listenerProgrammedErr := programmedErr
+		if result.accepted != nil {
+			listenerProgrammedErr = result.accepted
+		} else if result.resolvedRefs != nil {
+			listenerProgrammedErr = result.resolvedRefs
+		}
+		ls := listenerStatus{
+			Name:           result.listener.Name,
+			SupportedKinds: []routeGroupKind{},
+			AttachedRoutes: attached[result.listener.Name],
+			Conditions: mergeConditions(existing[result.listener.Name],
+				condition(conditionAccepted, reasonAccepted, "Listener is accepted", result.accepted, gw.Generation),
+				condition(conditionResolvedRefs, reasonResolvedRefs, "Listener references are resolved", result.resolvedRefs, gw.Generation),
+				condition(conditionProgrammed, reasonProgrammed, "Listener is programmed", listenerProgrammedErr, gw.Generation)),
+		}
+		for _, kind := range supportedKinds(result.listener.Protocol) {
+			group := gatewayGroup
+			ls.SupportedKinds = append(ls.SupportedKinds, routeGroupKind{Group: &group, Kind: kind})
+		}
+		status.Listeners = append(status.Listeners, ls)
+	}
+	return status
+}
+
+// gatewayAddresses returns the addresses a Gateway is reachable at through its
+// Service.
+func gatewayAddresses(svc *corev1.Service) []gatewayAddress {
+	var addresses []gatewayAddress
+	ipType, hostnameType := addressTypeIP, addressTypeHostname
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, gatewayAddress{Type: &ipType, Value: ingress.IP})
+			}
+			if ingress.Hostname != "" {
+				addresses = append(addresses, gatewayAddress{Type: &hostnameType, Value: ingress.Hostname})
+			}
+		}
+		return addresses
+	}
+	if svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		addresses = append(addresses, gatewayAddress{Type: &ipType, Value: svc.Spec.ClusterIP})
+	}
+	return addresses
+}
+
+// attachedRoutes returns the number of routes attached to each listener of gw.
+func (r *GatewayController) attachedRoutes(ctx context.Context, gw gateway) (map[string]int32, error) {
+	attached := make(map[string]int32)
+	for _, gvk := range r.RouteGVKs {
+		routes := newList(gvk)
+		if err := r.Client.List(ctx, routes); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, err
+		}
+		for i := range routes.Items {
+			var rt route
+			if err := decode(&routes.Items[i], &rt); err != nil {
+				return nil, err
+			}
+			for _, ref := range rt.Spec.ParentRefs {
+				if !refersTo(ref, rt.Namespace, gw) {
+					continue
+				}
+				for _, l := range gw.Spec.Listeners {
+					if !listenerMatches(ref, l) {
+						continue
+					}
+					allowed, err := listenerAllowsRoute(ctx, r.Client, gw, l, gvk.Kind, rt.Namespace)
+					if err != nil {
+						return nil, err
+					}
+					if allowed {
+						attached[l.Name]++
+					}
+				}
+			}
+		}
+	}
+	return attached, nil
+}
+
+// deleteGateway deletes the api-gateway config entry of gw and the
+// inline-certificate config entries of the Secrets that no other managed
+// Gateway references.
+func (r *GatewayController) deleteGateway(ctx context.Context, apiClient *capi.Client, gw gateway) error {
+	if err := r.deleteEntry(apiClient, capi.APIGateway, gw.Name, r.consulNamespace(gw.Namespace), gw.Namespace, gw.Name); err != nil {
+		return err
+	}
+
+	gateways := newList(GatewayGVK)
+	if err := r.Client.List(ctx, gateways); err != nil {
+		return err
+	}
+	inUse := make(map[types.NamespacedName]bool)
+	for i := range gateways.Items {
+		var other gateway
+		if err := decode(&gateways.Items[i], &other); err != nil {
+			return err
+		}
+		if other.Namespace == gw.Namespace && other.Name == gw.Name || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for secret := range certificateRefs(other) {
+			inUse[secret] = true
+		}
+	}
+	for secret := range certificateRefs(gw) {
+		if inUse[secret] {
+			continue
+		}
+		if err := r.deleteEntry(apiClient, capi.InlineCertificate, secret.Name, r.consulNamespace(secret.Namespace), secret.Namespace, secret.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// certificateRefs returns the Secrets referenced by the listeners of gw.
+func certificateRefs(gw gateway) map[types.NamespacedName]bool {
+	refs := make(map[types.NamespacedName]bool)
+	for _, l := range gw.Spec.Listeners {
+		if l.TLS == nil {
+			continue
+		}
+		for _, ref := range l.TLS.CertificateRefs {
+			if stringOr(ref.Group, "") == "" && stringOr(ref.Kind, kindSecret) == kindSecret {
+				refs[types.NamespacedName{Name: ref.Name, Namespace: stringOr(ref.Namespace, gw.Namespace)}] = true
+			}
+		}
+	}
+	return refs
+}
+
+// refersTo returns whether the parentRef of a route in routeNamespace refers
+// to gw.
+func refersTo(ref parentReference, routeNamespace string, gw gateway) bool {
+	return stringOr(ref.Group, gatewayGroup) == gatewayGroup &&
+		stringOr(ref.Kind, kindGateway) == kindGateway &&
+		stringOr(ref.Namespace, routeNamespace) == gw.Namespace &&
+		ref.Name == gw.Name
+}
+
+// listenerMatches returns whether the parentRef selects the listener l.
+func listenerMatches(ref parentReference, l listener) bool {
+	if ref.SectionName != nil && *ref.SectionName != l.Name {
+		return false
+	}
+	if ref.Port != nil && *ref.Port != l.Port {
+		return false
+	}
+	return true
+}
+
+// listenerAllowsRoute returns whether a route of kind in routeNamespace may
+// attach to the listener l of gw.
+func listenerAllowsRoute(ctx context.Context, c client.Client, gw gateway, l listener, kind, routeNamespace string) (bool, error) {
+	kindSupported := false
+	for _, supported := range supportedKinds(l.Protocol) {
+		if supported == kind {
+			kindSupported = true
+		}
+	}
+	if !kindSupported {
+		return false, nil
+	}
+
+	from := "Same"
+	if l.AllowedRoutes != nil {
+		if len(l.AllowedRoutes.Kinds) > 0 {
+			listed := false
+			for _, k := range l.AllowedRoutes.Kinds {
+				if stringOr(k.Group, gatewayGroup) == gatewayGroup && k.Kind == kind {
+					listed = true
+				}
+			}
+			if !listed {
+				return false, nil
+			}
+		}
+		if l.AllowedRoutes.Namespaces != nil {
+			from = stringOr(l.AllowedRoutes.Namespaces.From, from)
+		}
+	}
+
+	switch from {
+	case "All":
+		return true, nil
+	case "Selector":
+		if l.AllowedRoutes.Namespaces.Selector == nil {
+			return false, nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(l.AllowedRoutes.Namespaces.Selector)
+		if err != nil {
+			return false, nil
+		}
+		var ns corev1.Namespace
+		if err := c.Get(ctx, types.NamespacedName{Name: routeNamespace}, &ns); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return selector.Matches(labels.Set(ns.Labels)), nil
+	default:
+		return routeNamespace == gw.Namespace, nil
+	}
+}
+
+// configHash returns a hash of the JSON encoding of spec.
+func configHash(spec interface{}) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:16], nil
+}
+
+func (r *GatewayController) SetupWithManager(mgr ctrl.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(newObject(GatewayGVK)).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Watches(&source.Kind{Type: newObject(GatewayClassGVK)}, handler.EnqueueRequestsFromMapFunc(r.gatewaysForClass)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.gatewaysForSecret)).
+		Watches(&source.Kind{Type: newObject(ReferenceGrantGVK)}, handler.EnqueueRequestsFromMapFunc(r.gatewaysForGrant))
+	for _, gvk := range r.RouteGVKs {
+		builder = builder.Watches(&source.Kind{Type: newObject(gvk)}, handler.EnqueueRequestsFromMapFunc(gatewaysForRoute))
+	}
+	return builder.Complete(r)
+}
+
+// gatewaysForClass returns the Gateways of a GatewayClass.
+func (r *GatewayController) gatewaysForClass(obj client.Object) []reconcile.Request {
+	return r.gatewaysMatching(func(gw gateway) bool {
+		return gw.Spec.GatewayClassName == obj.GetName()
+	})
+}
+
+// gatewaysForSecret returns the Gateways whose listeners reference a Secret.
+func (r *GatewayController) gatewaysForSecret(obj client.Object) []reconcile.Request {
+	secret := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	return r.gatewaysMatching(func(gw gateway) bool {
+		return certificateRefs(gw)[secret]
+	})
+}
+
+// gatewaysForGrant returns the Gateways that may reference Secrets in the
+// namespace of a ReferenceGrant.
+func (r *GatewayController) gatewaysForGrant(obj client.Object) []reconcile.Request {
+	return r.gatewaysMatching(func(gw gateway) bool {
+		for secret := range certificateRefs(gw) {
+			if secret.Namespace == obj.GetNamespace() {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (r *GatewayController) gatewaysMatching(match func(gateway) bool) []reconcile.Request {
+	gateways := newList(GatewayGVK)
+	if err := r.Client.List(context.Background(), gateways); err != nil {
+		r.Log.Error(err, "failed to list Gateways")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range gateways.Items {
+		var gw gateway
+		if err := decode(&gateways.Items[i], &gw); err != nil {
+			continue
+		}
+		if match(gw) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}})
+		}
+	}
+	return requests
+}
+
+// gatewaysForRoute returns the Gateways a route refers to.
+func gatewaysForRoute(obj client.Object) []reconcile.Request {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	var rt route
+	if err := decode(u, &rt); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, ref := range rt.Spec.ParentRefs {
+		if stringOr(ref.Group, gatewayGroup) != gatewayGroup || stringOr(ref.Kind, kindGateway) != kindGateway {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      ref.Name,
+			Namespace: stringOr(ref.Namespace, rt.Namespace),
+		}})
+	}
+	return requests
+}
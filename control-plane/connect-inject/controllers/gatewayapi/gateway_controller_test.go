@@ -0,0 +1,224 @@
+package gatewayapi
+
+import (
+	"context"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestListenerAllowsRoute(t *testing.T) {
+	same, all, selector := "Same", "All", "Selector"
+	group := gatewayGroup
+	gw := gateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"}}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: map[string]string{"gateway": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	}
+
+	cases := map[string]struct {
+		listener   listener
+		kind       string
+		namespace  string
+		expAllowed bool
+	}{
+		"defaults to the same namespace": {
+			listener:   listener{Protocol: protocolHTTP},
+			kind:       kindHTTPRoute,
+			namespace:  "default",
+			expAllowed: true,
+		},
+		"denies other namespaces by default": {
+			listener:  listener{Protocol: protocolHTTP},
+			kind:      kindHTTPRoute,
+			namespace: "apps",
+		},
+		"denies kinds not supported by the protocol": {
+			listener:  listener{Protocol: protocolHTTP},
+			kind:      kindTCPRoute,
+			namespace: "default",
+		},
+		"denies kinds not in allowedRoutes.kinds": {
+			listener: listener{
+				Protocol:      protocolHTTP,
+				AllowedRoutes: &allowedRoutes{Kinds: []routeGroupKind{{Group: &group, Kind: "GRPCRoute"}}},
+			},
+			kind:      kindHTTPRoute,
+			namespace: "default",
+		},
+		"same": {
+			listener:  listener{Protocol: protocolTCP, AllowedRoutes: &allowedRoutes{Namespaces: &routeNamespaces{From: &same}}},
+			kind:      kindTCPRoute,
+			namespace: "apps",
+		},
+		"all": {
+			listener:   listener{Protocol: protocolTCP, AllowedRoutes: &allowedRoutes{Namespaces: &routeNamespaces{From: &all}}},
+			kind:       kindTCPRoute,
+			namespace:  "apps",
+			expAllowed: true,
+		},
+		"selector matches": {
+			listener: listener{Protocol: protocolHTTPS, AllowedRoutes: &allowedRoutes{Namespaces: &routeNamespaces{
+				From:     &selector,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"gateway": "true"}},
+			}}},
+			kind:       kindHTTPRoute,
+			namespace:  "apps",
+			expAllowed: true,
+		},
+		"selector does not match": {
+			listener: listener{Protocol: protocolHTTPS, AllowedRoutes: &allowedRoutes{Namespaces: &routeNamespaces{
+				From:     &selector,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"gateway": "true"}},
+			}}},
+			kind:      kindHTTPRoute,
+			namespace: "other",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(namespaces...).Build()
+			allowed, err := listenerAllowsRoute(context.Background(), fakeClient, gw, c.listener, c.kind, c.namespace)
+			require.NoError(t, err)
+			require.Equal(t, c.expAllowed, allowed)
+		})
+	}
+}
+
+func TestGatewayAddresses(t *testing.T) {
+	cases := map[string]struct {
+		svc      corev1.Service
+		expected []string
+	}{
+		"load balancer": {
+			svc: corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
+				Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+					{IP: "1.2.3.4"},
+					{Hostname: "gateway.example.com"},
+				}}},
+			},
+			expected: []string{"1.2.3.4", "gateway.example.com"},
+		},
+		"pending load balancer": {
+			svc: corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"}},
+		},
+		"cluster IP": {
+			svc:      corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"}},
+			expected: []string{"10.0.0.1"},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var values []string
+			for _, address := range gatewayAddresses(&c.svc) {
+				values = append(values, address.Value)
+			}
+			require.Equal(t, c.expected, values)
+		})
+	}
+}
+
+func TestGatewayController_gatewayStatus(t *testing.T) {
+	gw := gateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default", Generation: 3}}
+	results := []listenerResult{
+		{listener: listener{Name: "http", Port: 80, Protocol: protocolHTTP}},
+		{
+			listener:     listener{Name: "https", Port: 443, Protocol: protocolHTTPS},
+			resolvedRefs: newConditionError(reasonInvalidCertificateRef, "Secret default/cert not found"),
+		},
+	}
+	deployment := &appsv1.Deployment{Status: appsv1.DeploymentStatus{AvailableReplicas: 1}}
+	svc := &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"}}
+	controller := &GatewayController{Log: logrtest.TestLogger{T: t}}
+
+	status := controller.gatewayStatus(gw, results, nil, deployment, svc, map[string]int32{"http": 2})
+
+	require.Len(t, status.Addresses, 1)
+	accepted := meta.FindStatusCondition(status.Conditions, conditionAccepted)
+	require.Equal(t, metav1.ConditionTrue, accepted.Status)
+	require.Equal(t, reasonListenersNotValid, accepted.Reason)
+	require.Equal(t, int64(3), accepted.ObservedGeneration)
+	require.True(t, meta.IsStatusConditionTrue(status.Conditions, conditionProgrammed))
+
+	require.Len(t, status.Listeners, 2)
+	require.Equal(t, int32(2), status.Listeners[0].AttachedRoutes)
+	require.Equal(t, kindHTTPRoute, status.Listeners[0].SupportedKinds[0].Kind)
+	require.True(t, meta.IsStatusConditionTrue(status.Listeners[0].Conditions, conditionProgrammed))
+	require.False(t, meta.IsStatusConditionTrue(status.Listeners[1].Conditions, conditionResolvedRefs))
+	require.False(t, meta.IsStatusConditionTrue(status.Listeners[1].Conditions, conditionProgrammed))
+
+	// Without ready pods the Gateway isn't programmed yet.
+	deployment.Status.AvailableReplicas = 0
+	status = controller.gatewayStatus(gw, results, nil, deployment, svc, nil)
+	programmed := meta.FindStatusCondition(status.Conditions, conditionProgrammed)
+	require.Equal(t, metav1.ConditionFalse, programmed.Status)
+	require.Equal(t, reasonPending, programmed.Reason)
+}
+
+func TestGatewayController_attachedRoutes(t *testing.T) {
+	routeObject := func(gvk schema.GroupVersionKind, namespace, name string, refs ...parentReference) client.Object {
+		rt := route{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       routeSpec{ParentRefs: refs},
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&rt)
+		require.NoError(t, err)
+		obj := &unstructured.Unstructured{Object: content}
+		obj.SetGroupVersionKind(gvk)
+		return obj
+	}
+	gwObj := gatewayObject("default", "gateway",
+		listener{Name: "http", Port: 80, Protocol: protocolHTTP},
+		listener{Name: "http-alt", Port: 8080, Protocol: protocolHTTP},
+		listener{Name: "tcp", Port: 5432, Protocol: protocolTCP})
+	var gw gateway
+	require.NoError(t, decode(gwObj, &gw))
+
+	fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		gwObj,
+		routeObject(HTTPRouteGVK, "default", "all-http", parentReference{Name: "gateway"}),
+		routeObject(HTTPRouteGVK, "default", "alt", parentReference{Name: "gateway", SectionName: strPtr("http-alt")}),
+		routeObject(HTTPRouteGVK, "apps", "other-namespace", parentReference{Name: "gateway", Namespace: strPtr("default")}),
+		routeObject(HTTPRouteGVK, "default", "other-gateway", parentReference{Name: "other"}),
+		routeObject(TCPRouteGVK, "default", "db", parentReference{Name: "gateway", Port: int32Ptr(5432)}),
+	).Build()
+	controller := &GatewayController{
+		Client:    fakeClient,
+		Log:       logrtest.TestLogger{T: t},
+		RouteGVKs: []schema.GroupVersionKind{HTTPRouteGVK, TCPRouteGVK},
+	}
+
+	attached, err := controller.attachedRoutes(context.Background(), gw)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int32{"http": 1, "http-alt": 2, "tcp": 1}, attached)
+}
+
+func TestCertificateRefs(t *testing.T) {
+	gw := gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+		Spec: gatewaySpec{Listeners: []listener{
+			{Name: "https", TLS: &listenerTLS{CertificateRefs: []objectReference{
+				{Name: "cert"},
+				{Name: "shared", Namespace: strPtr("certs")},
+				{Name: "config", Kind: strPtr("ConfigMap")},
+			}}},
+			{Name: "http"},
+		}},
+	}
+
+	refs := certificateRefs(gw)
+	require.Len(t, refs, 2)
+	require.True(t, refs[client.ObjectKey{Name: "cert", Namespace: "default"}])
+	require.True(t, refs[client.ObjectKey{Name: "shared", Namespace: "certs"}])
+}
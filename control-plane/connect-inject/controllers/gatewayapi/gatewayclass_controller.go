@@ -0,0 +1,76 @@
+package gatewayapi
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GatewayClassController accepts the GatewayClasses whose controllerName is
+// ControllerName.
+type GatewayClassController struct {
+	client.Client
+	// Log is the logger for this controller.
+	Log logr.Logger
+}
+
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses/status,verbs=get;update;patch
+
+func (r *GatewayClassController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := newObject(GatewayClassGVK)
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		r.Log.Error(err, "failed to get GatewayClass", "name", req.Name)
+		return ctrl.Result{}, err
+	}
+
+	var class gatewayClass
+	if err := decode(obj, &class); err != nil {
+		return ctrl.Result{}, err
+	}
+	if class.Spec.ControllerName != ControllerName {
+		return ctrl.Result{}, nil
+	}
+
+	conditions := mergeConditions(class.Status.Conditions,
+		condition(conditionAccepted, reasonAccepted, "GatewayClass is accepted by Consul", nil, class.Generation))
+	if reflect.DeepEqual(conditions, class.Status.Conditions) {
+		return ctrl.Result{}, nil
+	}
+	class.Status.Conditions = conditions
+	if err := setStatus(obj, &class.Status); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Log.Info("accepting GatewayClass", "name", req.Name)
+	return ctrl.Result{}, r.Client.Status().Update(ctx, obj)
+}
+
+func (r *GatewayClassController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(newObject(GatewayClassGVK)).
+		Complete(r)
+}
+
+// managedClass returns whether the GatewayClass name is managed by Consul.
+// A missing GatewayClass is not managed.
+func managedClass(ctx context.Context, c client.Client, name string) (bool, error) {
+	obj := newObject(GatewayClassGVK)
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	var class gatewayClass
+	if err := decode(obj, &class); err != nil {
+		return false, err
+	}
+	return class.Spec.ControllerName == ControllerName, nil
+}
@@ -0,0 +1,93 @@
+package gatewayapi
+
+import (
+	"context"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGatewayClassController_Reconcile(t *testing.T) {
+	cases := map[string]struct {
+		controllerName string
+		expAccepted    bool
+	}{
+		"accepts classes for Consul": {
+			controllerName: ControllerName,
+			expAccepted:    true,
+		},
+		"ignores classes for other controllers": {
+			controllerName: "example.com/gateway-controller",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).
+				WithObjects(gatewayClassObject("class", c.controllerName)).Build()
+			controller := &GatewayClassController{
+				Client: fakeClient,
+				Log:    logrtest.TestLogger{T: t},
+			}
+
+			_, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "class"}})
+			require.NoError(t, err)
+
+			obj := newObject(GatewayClassGVK)
+			require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "class"}, obj))
+			var class gatewayClass
+			require.NoError(t, decode(obj, &class))
+			require.Equal(t, c.expAccepted, meta.IsStatusConditionTrue(class.Status.Conditions, conditionAccepted))
+
+			managed, err := managedClass(ctx, fakeClient, "class")
+			require.NoError(t, err)
+			require.Equal(t, c.expAccepted, managed)
+		})
+	}
+}
+
+func TestGatewayClassController_ReconcileMissing(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).Build()
+	controller := &GatewayClassController{
+		Client: fakeClient,
+		Log:    logrtest.TestLogger{T: t},
+	}
+
+	_, err := controller.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "class"}})
+	require.NoError(t, err)
+
+	managed, err := managedClass(context.Background(), fakeClient, "class")
+	require.NoError(t, err)
+	require.False(t, managed)
+}
+
+func gatewayClassObject(name, controllerName string) *unstructured.Unstructured {
+	obj := newObject(GatewayClassGVK)
+	obj.SetName(name)
+	obj.SetGeneration(1)
+	obj.Object["spec"] = map[string]interface{}{"controllerName": controllerName}
+	return obj
+}
+
+// gatewayObject returns a Gateway of the Consul managed GatewayClass "consul".
+func gatewayObject(namespace, name string, listeners ...listener) *unstructured.Unstructured {
+	gw := gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       gatewaySpec{GatewayClassName: "consul", Listeners: listeners},
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&gw)
+	if err != nil {
+		panic(err)
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetGroupVersionKind(GatewayGVK)
+	return obj
+}
@@ -0,0 +1,114 @@
+package gatewayapi
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// referenceAllowed returns whether an object of fromKind in fromNamespace may
+// reference the object toName of toGroup/toKind in toNamespace. References
+// within a namespace are always allowed. Cross-namespace references must be
+// permitted by a ReferenceGrant in the namespace of the referenced object.
+func referenceAllowed(ctx context.Context, c client.Client, fromKind, fromNamespace, toGroup, toKind, toNamespace, toName string) (bool, error) {
+	if fromNamespace == toNamespace {
+		return true, nil
+	}
+
+	grants := newList(ReferenceGrantGVK)
+	if err := c.List(ctx, grants, client.InNamespace(toNamespace)); err != nil {
+		// Without the ReferenceGrant CRD nothing can grant the reference.
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for i := range grants.Items {
+		var grant referenceGrant
+		if err := decode(&grants.Items[i], &grant); err != nil {
+			return false, err
+		}
+		if !grantsFrom(grant, fromKind, fromNamespace) {
+			continue
+		}
+		for _, to := range grant.Spec.To {
+			if to.Group == toGroup && to.Kind == toKind && (to.Name == nil || *to.Name == toName) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func grantsFrom(grant referenceGrant, kind, namespace string) bool {
+	for _, from := range grant.Spec.From {
+		if from.Group == gatewayGroup && from.Kind == kind && from.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveBackend returns the Service referenced by a route backend. If the
+// reference can't be resolved the returned conditionError explains why.
+func resolveBackend(ctx context.Context, c client.Client, routeKind, routeNamespace string, ref backendRef) (*corev1.Service, *conditionError, error) {
+	group, kind := stringOr(ref.Group, ""), stringOr(ref.Kind, kindService)
+	if group != "" || kind != kindService {
+		return nil, newConditionError(reasonInvalidKind, fmt.Sprintf("backend %q has unsupported kind %s/%s", ref.Name, group, kind)), nil
+	}
+
+	namespace := stringOr(ref.Namespace, routeNamespace)
+	allowed, err := referenceAllowed(ctx, c, routeKind, routeNamespace, "", kindService, namespace, ref.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !allowed {
+		return nil, newConditionError(reasonRefNotPermitted, fmt.Sprintf("reference to Service %s/%s is not permitted by any ReferenceGrant", namespace, ref.Name)), nil
+	}
+
+	var svc corev1.Service
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &svc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, newConditionError(reasonBackendNotFound, fmt.Sprintf("Service %s/%s not found", namespace, ref.Name)), nil
+		}
+		return nil, nil, err
+	}
+	return &svc, nil, nil
+}
+
+// resolveCertificate returns the TLS Secret referenced by a Gateway listener.
+// If the reference can't be resolved or the Secret doesn't hold a valid
+// key pair the returned conditionError explains why.
+func resolveCertificate(ctx context.Context, c client.Client, gatewayNamespace string, ref objectReference) (*corev1.Secret, *conditionError, error) {
+	group, kind := stringOr(ref.Group, ""), stringOr(ref.Kind, kindSecret)
+	if group != "" || kind != kindSecret {
+		return nil, newConditionError(reasonInvalidCertificateRef, fmt.Sprintf("certificate %q has unsupported kind %s/%s", ref.Name, group, kind)), nil
+	}
+
+	namespace := stringOr(ref.Namespace, gatewayNamespace)
+	allowed, err := referenceAllowed(ctx, c, kindGateway, gatewayNamespace, "", kindSecret, namespace, ref.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !allowed {
+		return nil, newConditionError(reasonRefNotPermitted, fmt.Sprintf("reference to Secret %s/%s is not permitted by any ReferenceGrant", namespace, ref.Name)), nil
+	}
+
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, newConditionError(reasonInvalidCertificateRef, fmt.Sprintf("Secret %s/%s not found", namespace, ref.Name)), nil
+		}
+		return nil, nil, err
+	}
+	if _, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]); err != nil {
+		return nil, newConditionError(reasonInvalidCertificateRef, fmt.Sprintf("Secret %s/%s does not contain a valid TLS key pair: %s", namespace, ref.Name, err)), nil
+	}
+	return &secret, nil, nil
+}
@@ -0,0 +1,146 @@
+package gatewayapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveBackend(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "backend"}}
+
+	cases := map[string]struct {
+		ref       backendRef
+		objects   []client.Object
+		expReason string
+	}{
+		"same namespace": {
+			ref:     backendRef{objectReference: objectReference{Name: "api"}},
+			objects: []client.Object{&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}},
+		},
+		"missing service": {
+			ref:       backendRef{objectReference: objectReference{Name: "api"}},
+			expReason: reasonBackendNotFound,
+		},
+		"unsupported kind": {
+			ref:       backendRef{objectReference: objectReference{Name: "api", Group: strPtr("example.com"), Kind: strPtr("Backend")}},
+			expReason: reasonInvalidKind,
+		},
+		"cross namespace without grant": {
+			ref:       backendRef{objectReference: objectReference{Name: "api", Namespace: strPtr("backend")}},
+			objects:   []client.Object{svc},
+			expReason: reasonRefNotPermitted,
+		},
+		"cross namespace with grant": {
+			ref:     backendRef{objectReference: objectReference{Name: "api", Namespace: strPtr("backend")}},
+			objects: []client.Object{svc, referenceGrantObject("backend", kindHTTPRoute, "default", kindService, "")},
+		},
+		"cross namespace with grant for the service name": {
+			ref:     backendRef{objectReference: objectReference{Name: "api", Namespace: strPtr("backend")}},
+			objects: []client.Object{svc, referenceGrantObject("backend", kindHTTPRoute, "default", kindService, "api")},
+		},
+		"cross namespace with grant for another service": {
+			ref:       backendRef{objectReference: objectReference{Name: "api", Namespace: strPtr("backend")}},
+			objects:   []client.Object{svc, referenceGrantObject("backend", kindHTTPRoute, "default", kindService, "web")},
+			expReason: reasonRefNotPermitted,
+		},
+		"cross namespace with grant from another namespace": {
+			ref:       backendRef{objectReference: objectReference{Name: "api", Namespace: strPtr("backend")}},
+			objects:   []client.Object{svc, referenceGrantObject("backend", kindHTTPRoute, "other", kindService, "")},
+			expReason: reasonRefNotPermitted,
+		},
+		"cross namespace with grant for another route kind": {
+			ref:       backendRef{objectReference: objectReference{Name: "api", Namespace: strPtr("backend")}},
+			objects:   []client.Object{svc, referenceGrantObject("backend", kindTCPRoute, "default", kindService, "")},
+			expReason: reasonRefNotPermitted,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(c.objects...).Build()
+
+			resolved, refErr, err := resolveBackend(context.Background(), fakeClient, kindHTTPRoute, "default", c.ref)
+			require.NoError(t, err)
+			if c.expReason != "" {
+				require.NotNil(t, refErr)
+				require.Equal(t, c.expReason, refErr.reason)
+				require.Nil(t, resolved)
+				return
+			}
+			require.Nil(t, refErr)
+			require.Equal(t, c.ref.Name, resolved.Name)
+		})
+	}
+}
+
+func TestResolveCertificate(t *testing.T) {
+	cases := map[string]struct {
+		ref       objectReference
+		objects   []client.Object
+		expReason string
+	}{
+		"missing secret": {
+			ref:       objectReference{Name: "cert"},
+			expReason: reasonInvalidCertificateRef,
+		},
+		"invalid key pair": {
+			ref: objectReference{Name: "cert"},
+			objects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "default"},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+			}},
+			expReason: reasonInvalidCertificateRef,
+		},
+		"unsupported kind": {
+			ref:       objectReference{Name: "cert", Kind: strPtr("ConfigMap")},
+			expReason: reasonInvalidCertificateRef,
+		},
+		"cross namespace without grant": {
+			ref:       objectReference{Name: "cert", Namespace: strPtr("certs")},
+			expReason: reasonRefNotPermitted,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(c.objects...).Build()
+
+			secret, refErr, err := resolveCertificate(context.Background(), fakeClient, "default", c.ref)
+			require.NoError(t, err)
+			require.Nil(t, secret)
+			require.NotNil(t, refErr)
+			require.Equal(t, c.expReason, refErr.reason)
+		})
+	}
+}
+
+func testScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(s)
+	return s
+}
+
+// referenceGrantObject returns a ReferenceGrant in namespace that allows
+// fromKind objects in fromNamespace to reference toKind objects. An empty
+// toName allows references to any object of toKind.
+func referenceGrantObject(namespace, fromKind, fromNamespace, toKind, toName string) *unstructured.Unstructured {
+	to := map[string]interface{}{"group": "", "kind": toKind}
+	if toName != "" {
+		to["name"] = toName
+	}
+	obj := newObject(ReferenceGrantGVK)
+	obj.SetName("grant")
+	obj.SetNamespace(namespace)
+	obj.Object["spec"] = map[string]interface{}{
+		"from": []interface{}{map[string]interface{}{"group": gatewayGroup, "kind": fromKind, "namespace": fromNamespace}},
+		"to":   []interface{}{to},
+	}
+	return obj
+}
@@ -0,0 +1,268 @@
+package gatewayapi
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The Gateway API types are read and written as unstructured objects so that
+// the control plane does not need to vendor a particular Gateway API release.
+// Only the fields that are translated into Consul config entries or written
+// back as status are decoded into the views below.
+
+const (
+	// ControllerName is the value GatewayClasses must set in spec.controllerName
+	// for their Gateways to be managed by Consul.
+	ControllerName = "consul.hashicorp.com/gateway-controller"
+
+	gatewayGroup = "gateway.networking.k8s.io"
+
+	kindGateway        = "Gateway"
+	kindGatewayClass   = "GatewayClass"
+	kindHTTPRoute      = "HTTPRoute"
+	kindTCPRoute       = "TCPRoute"
+	kindReferenceGrant = "ReferenceGrant"
+	kindSecret         = "Secret"
+	kindService        = "Service"
+)
+
+var (
+	GatewayClassGVK   = schema.GroupVersionKind{Group: gatewayGroup, Version: "v1beta1", Kind: kindGatewayClass}
+	GatewayGVK        = schema.GroupVersionKind{Group: gatewayGroup, Version: "v1beta1", Kind: kindGateway}
+	HTTPRouteGVK      = schema.GroupVersionKind{Group: gatewayGroup, Version: "v1beta1", Kind: kindHTTPRoute}
+	TCPRouteGVK       = schema.GroupVersionKind{Group: gatewayGroup, Version: "v1alpha2", Kind: kindTCPRoute}
+	ReferenceGrantGVK = schema.GroupVersionKind{Group: gatewayGroup, Version: "v1beta1", Kind: kindReferenceGrant}
+)
+
+// newObject returns an empty unstructured object of the given kind.
+func newObject(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	return u
+}
+
+// newList returns an empty unstructured list for the given kind.
+func newList(gvk schema.GroupVersionKind) *unstructured.UnstructuredList {
+	u := &unstructured.UnstructuredList{}
+	u.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return u
+}
+
+// decode converts the unstructured object into one of the views below.
+func decode(u *unstructured.Unstructured, out interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), out)
+}
+
+// setStatus replaces the status of the unstructured object. status must be a
+// pointer to one of the status views below.
+func setStatus(u *unstructured.Unstructured, status interface{}) error {
+	s, err := runtime.DefaultUnstructuredConverter.ToUnstructured(status)
+	if err != nil {
+		return err
+	}
+	u.Object["status"] = s
+	return nil
+}
+
+type gatewayClass struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              gatewayClassSpec   `json:"spec"`
+	Status            gatewayClassStatus `json:"status"`
+}
+
+type gatewayClassSpec struct {
+	ControllerName string `json:"controllerName"`
+}
+
+type gatewayClassStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type gateway struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              gatewaySpec   `json:"spec"`
+	Status            gatewayStatus `json:"status"`
+}
+
+type gatewaySpec struct {
+	GatewayClassName string     `json:"gatewayClassName"`
+	Listeners        []listener `json:"listeners"`
+}
+
+type listener struct {
+	Name          string         `json:"name"`
+	Hostname      *string        `json:"hostname,omitempty"`
+	Port          int32          `json:"port"`
+	Protocol      string         `json:"protocol"`
+	TLS           *listenerTLS   `json:"tls,omitempty"`
+	AllowedRoutes *allowedRoutes `json:"allowedRoutes,omitempty"`
+}
+
+type listenerTLS struct {
+	Mode            *string           `json:"mode,omitempty"`
+	CertificateRefs []objectReference `json:"certificateRefs,omitempty"`
+}
+
+type allowedRoutes struct {
+	Namespaces *routeNamespaces `json:"namespaces,omitempty"`
+	Kinds      []routeGroupKind `json:"kinds,omitempty"`
+}
+
+type routeNamespaces struct {
+	From     *string               `json:"from,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+type routeGroupKind struct {
+	Group *string `json:"group,omitempty"`
+	Kind  string  `json:"kind"`
+}
+
+// objectReference covers SecretObjectReference and BackendObjectReference.
+type objectReference struct {
+	Group     *string `json:"group,omitempty"`
+	Kind      *string `json:"kind,omitempty"`
+	Name      string  `json:"name"`
+	Namespace *string `json:"namespace,omitempty"`
+	Port      *int32  `json:"port,omitempty"`
+}
+
+type gatewayStatus struct {
+	Addresses  []gatewayAddress   `json:"addresses,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	Listeners  []listenerStatus   `json:"listeners,omitempty"`
+}
+
+type gatewayAddress struct {
+	Type  *string `json:"type,omitempty"`
+	Value string  `json:"value"`
+}
+
+type listenerStatus struct {
+	Name           string             `json:"name"`
+	SupportedKinds []routeGroupKind   `json:"supportedKinds"`
+	AttachedRoutes int32              `json:"attachedRoutes"`
+	Conditions     []metav1.Condition `json:"conditions"`
+}
+
+type parentReference struct {
+	Group       *string `json:"group,omitempty"`
+	Kind        *string `json:"kind,omitempty"`
+	Namespace   *string `json:"namespace,omitempty"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName,omitempty"`
+	Port        *int32  `json:"port,omitempty"`
+}
+
+type route struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              routeSpec   `json:"spec"`
+	Status            routeStatus `json:"status"`
+}
+
+// routeSpec covers both HTTPRoute and TCPRoute. TCPRoutes only set
+// parentRefs and the backendRefs of their rules.
+type routeSpec struct {
+	ParentRefs []parentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+	Rules      []routeRule       `json:"rules,omitempty"`
+}
+
+type routeRule struct {
+	Matches     []httpRouteMatch  `json:"matches,omitempty"`
+	Filters     []httpRouteFilter `json:"filters,omitempty"`
+	BackendRefs []backendRef      `json:"backendRefs,omitempty"`
+}
+
+type backendRef struct {
+	objectReference `json:",inline"`
+	Weight          *int32            `json:"weight,omitempty"`
+	Filters         []httpRouteFilter `json:"filters,omitempty"`
+}
+
+type httpRouteMatch struct {
+	Path        *httpPathMatch   `json:"path,omitempty"`
+	Headers     []httpValueMatch `json:"headers,omitempty"`
+	QueryParams []httpValueMatch `json:"queryParams,omitempty"`
+	Method      *string          `json:"method,omitempty"`
+}
+
+type httpPathMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+type httpValueMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Name  string  `json:"name"`
+	Value string  `json:"value"`
+}
+
+type httpRouteFilter struct {
+	Type                  string              `json:"type"`
+	RequestHeaderModifier *httpHeaderModifier `json:"requestHeaderModifier,omitempty"`
+	URLRewrite            *httpURLRewrite     `json:"urlRewrite,omitempty"`
+}
+
+type httpHeaderModifier struct {
+	Set    []httpHeader `json:"set,omitempty"`
+	Add    []httpHeader `json:"add,omitempty"`
+	Remove []string     `json:"remove,omitempty"`
+}
+
+type httpHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type httpURLRewrite struct {
+	Path *httpPathModifier `json:"path,omitempty"`
+}
+
+type httpPathModifier struct {
+	Type               string  `json:"type"`
+	ReplaceFullPath    *string `json:"replaceFullPath,omitempty"`
+	ReplacePrefixMatch *string `json:"replacePrefixMatch,omitempty"`
+}
+
+type routeStatus struct {
+	Parents []routeParentStatus `json:"parents"`
+}
+
+type routeParentStatus struct {
+	ParentRef      parentReference    `json:"parentRef"`
+	ControllerName string             `json:"controllerName"`
+	Conditions     []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type referenceGrant struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              referenceGrantSpec `json:"spec"`
+}
+
+type referenceGrantSpec struct {
+	From []referenceGrantFrom `json:"from"`
+	To   []referenceGrantTo   `json:"to"`
+}
+
+type referenceGrantFrom struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+}
+
+type referenceGrantTo struct {
+	Group string  `json:"group"`
+	Kind  string  `json:"kind"`
+	Name  *string `json:"name,omitempty"`
+}
+
+// stringOr returns the value of s or def if s is unset.
+func stringOr(s *string, def string) string {
+	if s == nil {
+		return def
+	}
+	return *s
+}
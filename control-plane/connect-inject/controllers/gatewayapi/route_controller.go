@@ -0,0 +1,349 @@
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// RouteController translates the HTTPRoutes or TCPRoutes attached to Consul
+// managed Gateways into http-route or tcp-route config entries.
+type RouteController struct {
+	client.Client
+	Config
+	// Log is the logger for this controller.
+	Log logr.Logger
+	// GVK is the kind of route this controller reconciles, either
+	// HTTPRouteGVK or TCPRouteGVK.
+	GVK schema.GroupVersionKind
+}
+
+// parentResult is the outcome of attaching a route to one of its parents.
+type parentResult struct {
+	ref              parentReference
+	gatewayNamespace string
+	accepted         *conditionError
+}
+
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;tcproutes,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status;tcproutes/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+func (r *RouteController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("route", req.NamespacedName, "kind", r.GVK.Kind)
+
+	obj := newObject(r.GVK)
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "failed to get route")
+		return ctrl.Result{}, err
+	}
+	var rt route
+	if err := decode(obj, &rt); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	parents, err := r.resolveParents(ctx, rt)
+	if err != nil {
+		logger.Error(err, "failed to resolve route parents")
+		return ctrl.Result{}, err
+	}
+
+	// Routes that are being deleted or no longer attach to a Consul managed
+	// Gateway are removed from Consul.
+	if len(parents) == 0 || !obj.GetDeletionTimestamp().IsZero() {
+		if !controllerutil.ContainsFinalizer(obj, finalizerName) {
+			return ctrl.Result{}, nil
+		}
+		apiClient, err := r.consulClient()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.Info("deleting route from Consul")
+		if err := r.deleteEntry(apiClient, r.consulKind(), rt.Name, r.consulNamespace(rt.Namespace), rt.Namespace, rt.Name); err != nil {
+			logger.Error(err, "failed to delete route from Consul")
+			return ctrl.Result{}, err
+		}
+		if obj.GetDeletionTimestamp().IsZero() {
+			if err := r.updateStatus(ctx, obj, rt, nil, nil, nil); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		controllerutil.RemoveFinalizer(obj, finalizerName)
+		return ctrl.Result{}, r.Update(ctx, obj)
+	}
+
+	if !controllerutil.ContainsFinalizer(obj, finalizerName) {
+		controllerutil.AddFinalizer(obj, finalizerName)
+		if err := r.Update(ctx, obj); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	services, refsErr, err := r.resolveBackends(ctx, rt)
+	if err != nil {
+		logger.Error(err, "failed to resolve route backends")
+		return ctrl.Result{}, err
+	}
+
+	var routeErr *conditionError
+	if r.GVK.Kind == kindTCPRoute && len(services) > 1 {
+		routeErr = newConditionError(reasonUnsupportedValue, "Consul TCPRoutes support a single backend")
+	}
+
+	var consulParents []capi.ResourceReference
+	for _, p := range parents {
+		if p.accepted == nil {
+			consulParents = append(consulParents, r.translateParent(p.gatewayNamespace, p.ref))
+		}
+	}
+
+	apiClient, err := r.consulClient()
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+	if len(consulParents) == 0 || routeErr != nil {
+		err = r.deleteEntry(apiClient, r.consulKind(), rt.Name, r.consulNamespace(rt.Namespace), rt.Namespace, rt.Name)
+	} else {
+		var entry capi.ConfigEntry
+		if r.GVK.Kind == kindTCPRoute {
+			entry = r.translateTCPRoute(rt, consulParents, services)
+		} else {
+			entry = r.translateHTTPRoute(rt, consulParents, services)
+		}
+		routeErr, err = r.writeEntry(apiClient, entry, rt.Namespace, rt.Name)
+	}
+	if err != nil {
+		logger.Error(err, "failed to sync route to Consul")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.updateStatus(ctx, obj, rt, parents, routeErr, refsErr)
+}
+
+// resolveParents returns the parents of rt that are Consul managed Gateways,
+// and whether the route may attach to each of them.
+func (r *RouteController) resolveParents(ctx context.Context, rt route) ([]parentResult, error) {
+	var results []parentResult
+	for _, ref := range rt.Spec.ParentRefs {
+		if stringOr(ref.Group, gatewayGroup) != gatewayGroup || stringOr(ref.Kind, kindGateway) != kindGateway {
+			continue
+		}
+		namespace := stringOr(ref.Namespace, rt.Namespace)
+		obj := newObject(GatewayGVK)
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, obj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		var gw gateway
+		if err := decode(obj, &gw); err != nil {
+			return nil, err
+		}
+		managed, err := managedClass(ctx, r.Client, gw.Spec.GatewayClassName)
+		if err != nil {
+			return nil, err
+		}
+		if !managed || !gw.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		result := parentResult{ref: ref, gatewayNamespace: namespace}
+		result.accepted, err = r.attach(ctx, gw, ref, rt.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// attach returns why a route in routeNamespace can't attach to gw through
+// ref, or nil if it can.
+func (r *RouteController) attach(ctx context.Context, gw gateway, ref parentReference, routeNamespace string) (*conditionError, error) {
+	matched := false
+	for _, l := range gw.Spec.Listeners {
+		if !listenerMatches(ref, l) {
+			continue
+		}
+		matched = true
+		allowed, err := listenerAllowsRoute(ctx, r.Client, gw, l, r.GVK.Kind, routeNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			return nil, nil
+		}
+	}
+	if !matched {
+		return newConditionError(reasonNoMatchingParent, fmt.Sprintf("Gateway %s/%s has no matching listener", gw.Namespace, gw.Name)), nil
+	}
+	return newConditionError(reasonNotAllowedByListeners, fmt.Sprintf("the listeners of Gateway %s/%s do not allow %s from namespace %s", gw.Namespace, gw.Name, r.GVK.Kind, routeNamespace)), nil
+}
+
+// resolveBackends returns the Services referenced by the backends of rt that
+// could be resolved, and why the first unresolved backend couldn't be.
+func (r *RouteController) resolveBackends(ctx context.Context, rt route) (map[backendKey]*corev1.Service, *conditionError, error) {
+	services := make(map[backendKey]*corev1.Service)
+	var firstErr *conditionError
+	for i, rule := range rt.Spec.Rules {
+		for j, ref := range rule.BackendRefs {
+			svc, refErr, err := resolveBackend(ctx, r.Client, r.GVK.Kind, rt.Namespace, ref)
+			if err != nil {
+				return nil, nil, err
+			}
+			if refErr != nil {
+				if firstErr == nil {
+					firstErr = refErr
+				}
+				continue
+			}
+			services[backendKey{rule: i, backend: j}] = svc
+		}
+	}
+	return services, firstErr, nil
+}
+
+// updateStatus sets the status of each of the Consul managed parents of rt.
+// The status written by other controllers for their parents is kept.
+func (r *RouteController) updateStatus(ctx context.Context, obj *unstructured.Unstructured, rt route, parents []parentResult, routeErr, refsErr *conditionError) error {
+	status := routeStatus{Parents: []routeParentStatus{}}
+	existing := make(map[string][]metav1.Condition)
+	for _, p := range rt.Status.Parents {
+		if p.ControllerName != ControllerName {
+			status.Parents = append(status.Parents, p)
+			continue
+		}
+		existing[parentKey(p.ParentRef, rt.Namespace)] = p.Conditions
+	}
+	for _, p := range parents {
+		acceptedErr := p.accepted
+		if acceptedErr == nil {
+			acceptedErr = routeErr
+		}
+		status.Parents = append(status.Parents, routeParentStatus{
+			ParentRef:      p.ref,
+			ControllerName: ControllerName,
+			Conditions: mergeConditions(existing[parentKey(p.ref, rt.Namespace)],
+				condition(conditionAccepted, reasonAccepted, "route is accepted", acceptedErr, rt.Generation),
+				condition(conditionResolvedRefs, reasonResolvedRefs, "route references are resolved", refsErr, rt.Generation)),
+		})
+	}
+
+	if reflect.DeepEqual(status.Parents, rt.Status.Parents) ||
+		len(status.Parents) == 0 && len(rt.Status.Parents) == 0 {
+		return nil
+	}
+	if err := setStatus(obj, &status); err != nil {
+		return err
+	}
+	return r.Client.Status().Update(ctx, obj)
+}
+
+// parentKey identifies the Gateway and listener a parentRef of a route in
+// routeNamespace refers to.
+func parentKey(ref parentReference, routeNamespace string) string {
+	port := ""
+	if ref.Port != nil {
+		port = fmt.Sprint(*ref.Port)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", stringOr(ref.Namespace, routeNamespace), ref.Name, stringOr(ref.SectionName, ""), port)
+}
+
+func (r *RouteController) consulKind() string {
+	if r.GVK.Kind == kindTCPRoute {
+		return capi.TCPRoute
+	}
+	return capi.HTTPRoute
+}
+
+func (r *RouteController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(r.GVK.Kind).
+		For(newObject(r.GVK)).
+		Watches(&source.Kind{Type: newObject(GatewayGVK)}, handler.EnqueueRequestsFromMapFunc(r.routesForGateway)).
+		Watches(&source.Kind{Type: &corev1.Service{}}, handler.EnqueueRequestsFromMapFunc(r.routesForService)).
+		Watches(&source.Kind{Type: newObject(ReferenceGrantGVK)}, handler.EnqueueRequestsFromMapFunc(r.routesForGrant)).
+		Complete(r)
+}
+
+// routesForGateway returns the routes that refer to a Gateway.
+func (r *RouteController) routesForGateway(obj client.Object) []reconcile.Request {
+	gw := gateway{ObjectMeta: metav1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()}}
+	return r.routesMatching(func(rt route) bool {
+		for _, ref := range rt.Spec.ParentRefs {
+			if refersTo(ref, rt.Namespace, gw) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// routesForService returns the routes with a backend that refers to a Service.
+func (r *RouteController) routesForService(obj client.Object) []reconcile.Request {
+	return r.routesMatching(func(rt route) bool {
+		for _, rule := range rt.Spec.Rules {
+			for _, ref := range rule.BackendRefs {
+				if stringOr(ref.Kind, kindService) == kindService && ref.Name == obj.GetName() &&
+					stringOr(ref.Namespace, rt.Namespace) == obj.GetNamespace() {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+// routesForGrant returns the routes with a backend in the namespace of a
+// ReferenceGrant.
+func (r *RouteController) routesForGrant(obj client.Object) []reconcile.Request {
+	return r.routesMatching(func(rt route) bool {
+		for _, rule := range rt.Spec.Rules {
+			for _, ref := range rule.BackendRefs {
+				if stringOr(ref.Namespace, rt.Namespace) == obj.GetNamespace() {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+func (r *RouteController) routesMatching(match func(route) bool) []reconcile.Request {
+	routes := newList(r.GVK)
+	if err := r.Client.List(context.Background(), routes); err != nil {
+		r.Log.Error(err, "failed to list routes", "kind", r.GVK.Kind)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range routes.Items {
+		var rt route
+		if err := decode(&routes.Items[i], &rt); err != nil {
+			continue
+		}
+		if match(rt) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rt.Name, Namespace: rt.Namespace}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,201 @@
+package gatewayapi
+
+import (
+	"context"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRouteController_resolveParents(t *testing.T) {
+	all := "All"
+	objects := []client.Object{
+		gatewayClassObject("consul", ControllerName),
+		gatewayClassObject("other", "example.com/gateway-controller"),
+		gatewayObject("default", "gateway",
+			listener{Name: "http", Port: 80, Protocol: protocolHTTP},
+			listener{Name: "tcp", Port: 5432, Protocol: protocolTCP}),
+		gatewayObject("shared", "shared",
+			listener{Name: "http", Port: 80, Protocol: protocolHTTP, AllowedRoutes: &allowedRoutes{Namespaces: &routeNamespaces{From: &all}}}),
+	}
+	otherClass := gatewayObject("default", "unmanaged", listener{Name: "http", Port: 80, Protocol: protocolHTTP})
+	otherClass.Object["spec"].(map[string]interface{})["gatewayClassName"] = "other"
+	objects = append(objects, otherClass)
+
+	cases := map[string]struct {
+		namespace  string
+		parentRefs []parentReference
+		expReasons []string
+	}{
+		"attaches to a listener in the same namespace": {
+			namespace:  "default",
+			parentRefs: []parentReference{{Name: "gateway", SectionName: strPtr("http")}},
+			expReasons: []string{""},
+		},
+		"attaches to a gateway without a section name": {
+			namespace:  "default",
+			parentRefs: []parentReference{{Name: "gateway"}},
+			expReasons: []string{""},
+		},
+		"listener with another protocol": {
+			namespace:  "default",
+			parentRefs: []parentReference{{Name: "gateway", SectionName: strPtr("tcp")}},
+			expReasons: []string{reasonNotAllowedByListeners},
+		},
+		"missing listener": {
+			namespace:  "default",
+			parentRefs: []parentReference{{Name: "gateway", SectionName: strPtr("https")}},
+			expReasons: []string{reasonNoMatchingParent},
+		},
+		"listener port mismatch": {
+			namespace:  "default",
+			parentRefs: []parentReference{{Name: "gateway", Port: int32Ptr(8080)}},
+			expReasons: []string{reasonNoMatchingParent},
+		},
+		"listener in another namespace allowing the same namespace": {
+			namespace:  "apps",
+			parentRefs: []parentReference{{Name: "gateway", Namespace: strPtr("default")}},
+			expReasons: []string{reasonNotAllowedByListeners},
+		},
+		"listener in another namespace allowing all namespaces": {
+			namespace:  "apps",
+			parentRefs: []parentReference{{Name: "shared", Namespace: strPtr("shared")}},
+			expReasons: []string{""},
+		},
+		"ignores gateways of other classes, missing gateways and other kinds": {
+			namespace: "default",
+			parentRefs: []parentReference{
+				{Name: "unmanaged"},
+				{Name: "missing"},
+				{Name: "gateway", Kind: strPtr("Service"), Group: strPtr("")},
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(objects...).Build()
+			controller := &RouteController{
+				Client: fakeClient,
+				Log:    logrtest.TestLogger{T: t},
+				GVK:    HTTPRouteGVK,
+			}
+			rt := route{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: c.namespace},
+				Spec:       routeSpec{ParentRefs: c.parentRefs},
+			}
+
+			results, err := controller.resolveParents(context.Background(), rt)
+			require.NoError(t, err)
+			var reasons []string
+			for _, result := range results {
+				reason := ""
+				if result.accepted != nil {
+					reason = result.accepted.reason
+				}
+				reasons = append(reasons, reason)
+			}
+			require.Equal(t, c.expReasons, reasons)
+		})
+	}
+}
+
+func TestRouteController_updateStatus(t *testing.T) {
+	ctx := context.Background()
+	otherParent := routeParentStatus{
+		ParentRef:      parentReference{Name: "other"},
+		ControllerName: "example.com/gateway-controller",
+		Conditions: []metav1.Condition{{
+			Type:               conditionAccepted,
+			Status:             metav1.ConditionTrue,
+			Reason:             reasonAccepted,
+			LastTransitionTime: metav1.Now().Rfc3339Copy(),
+		}},
+	}
+	rt := route{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default", Generation: 2},
+		Spec:       routeSpec{ParentRefs: []parentReference{{Name: "gateway"}, {Name: "other"}}},
+		Status:     routeStatus{Parents: []routeParentStatus{otherParent}},
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&rt)
+	require.NoError(t, err)
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetGroupVersionKind(HTTPRouteGVK)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(obj).Build()
+	controller := &RouteController{
+		Client: fakeClient,
+		Log:    logrtest.TestLogger{T: t},
+		GVK:    HTTPRouteGVK,
+	}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "route", Namespace: "default"}, obj))
+
+	parents := []parentResult{{ref: parentReference{Name: "gateway"}, gatewayNamespace: "default"}}
+	refsErr := newConditionError(reasonBackendNotFound, "Service default/api not found")
+	require.NoError(t, controller.updateStatus(ctx, obj, rt, parents, nil, refsErr))
+
+	updated := newObject(HTTPRouteGVK)
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "route", Namespace: "default"}, updated))
+	var got route
+	require.NoError(t, decode(updated, &got))
+	require.Len(t, got.Status.Parents, 2)
+	require.Equal(t, otherParent.ControllerName, got.Status.Parents[0].ControllerName)
+
+	ours := got.Status.Parents[1]
+	require.Equal(t, ControllerName, ours.ControllerName)
+	require.Equal(t, "gateway", ours.ParentRef.Name)
+	require.True(t, meta.IsStatusConditionTrue(ours.Conditions, conditionAccepted))
+	resolved := meta.FindStatusCondition(ours.Conditions, conditionResolvedRefs)
+	require.NotNil(t, resolved)
+	require.Equal(t, metav1.ConditionFalse, resolved.Status)
+	require.Equal(t, reasonBackendNotFound, resolved.Reason)
+	require.Equal(t, int64(2), resolved.ObservedGeneration)
+
+	// Removing our parents keeps the status of other controllers.
+	require.NoError(t, controller.updateStatus(ctx, updated, got, nil, nil, nil))
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "route", Namespace: "default"}, updated))
+	got = route{}
+	require.NoError(t, decode(updated, &got))
+	require.Len(t, got.Status.Parents, 1)
+	require.Equal(t, otherParent.ControllerName, got.Status.Parents[0].ControllerName)
+}
+
+func TestRouteController_routesForService(t *testing.T) {
+	routeObject := func(name string, backends ...backendRef) client.Object {
+		rt := route{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       routeSpec{Rules: []routeRule{{BackendRefs: backends}}},
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&rt)
+		require.NoError(t, err)
+		obj := &unstructured.Unstructured{Object: content}
+		obj.SetGroupVersionKind(HTTPRouteGVK)
+		return obj
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		routeObject("api", backendRef{objectReference: objectReference{Name: "api"}}),
+		routeObject("web", backendRef{objectReference: objectReference{Name: "web"}}),
+		routeObject("other-namespace", backendRef{objectReference: objectReference{Name: "api", Namespace: strPtr("other")}}),
+	).Build()
+	controller := &RouteController{
+		Client: fakeClient,
+		Log:    logrtest.TestLogger{T: t},
+		GVK:    HTTPRouteGVK,
+	}
+
+	requests := controller.routesForService(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}})
+	require.Len(t, requests, 1)
+	require.Equal(t, "api", requests[0].Name)
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
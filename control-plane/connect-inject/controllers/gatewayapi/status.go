@@ -0,0 +1,73 @@
+package gatewayapi
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types and reasons defined by the Gateway API specification.
+const (
+	conditionAccepted     = "Accepted"
+	conditionProgrammed   = "Programmed"
+	conditionResolvedRefs = "ResolvedRefs"
+
+	reasonAccepted              = "Accepted"
+	reasonProgrammed            = "Programmed"
+	reasonPending               = "Pending"
+	reasonResolvedRefs          = "ResolvedRefs"
+	reasonListenersNotValid     = "ListenersNotValid"
+	reasonUnsupportedProtocol   = "UnsupportedProtocol"
+	reasonUnsupportedValue      = "UnsupportedValue"
+	reasonInvalid               = "Invalid"
+	reasonInvalidCertificateRef = "InvalidCertificateRef"
+	reasonInvalidKind           = "InvalidKind"
+	reasonRefNotPermitted       = "RefNotPermitted"
+	reasonBackendNotFound       = "BackendNotFound"
+	reasonNotAllowedByListeners = "NotAllowedByListeners"
+	reasonNoMatchingParent      = "NoMatchingParent"
+)
+
+// condition returns a condition of the given type that is True when err is
+// nil, and False with the reason and message of err otherwise.
+func condition(conditionType, trueReason, trueMessage string, err *conditionError, generation int64) metav1.Condition {
+	if err != nil {
+		return metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             err.reason,
+			Message:            err.message,
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             trueReason,
+		Message:            trueMessage,
+		ObservedGeneration: generation,
+	}
+}
+
+// mergeConditions sets each of the new conditions on existing, keeping the
+// last transition time of conditions whose status did not change.
+func mergeConditions(existing []metav1.Condition, conditions ...metav1.Condition) []metav1.Condition {
+	merged := append([]metav1.Condition(nil), existing...)
+	for _, c := range conditions {
+		meta.SetStatusCondition(&merged, c)
+	}
+	return merged
+}
+
+// conditionError is the reason and message of a False condition.
+type conditionError struct {
+	reason  string
+	message string
+}
+
+func (e *conditionError) Error() string {
+	return e.message
+}
+
+func newConditionError(reason, message string) *conditionError {
+	return &conditionError{reason: reason, message: message}
+}
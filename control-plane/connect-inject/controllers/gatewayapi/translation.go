@@ -0,0 +1,294 @@
+package gatewayapi
+
+import (
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/constants"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// metaKeyKubeName is the meta key name for the name of the Kubernetes
+	// object a config entry was translated from.
+	metaKeyKubeName = "k8s-name"
+
+	protocolHTTP  = "HTTP"
+	protocolHTTPS = "HTTPS"
+	protocolTCP   = "TCP"
+)
+
+// Config holds the settings shared by the Gateway API controllers.
+type Config struct {
+	// ConsulClientConfig is the config to create a Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+
+	// ConsulPartition is the Consul admin partition config entries are
+	// written to. It is empty when admin partitions are disabled.
+	ConsulPartition string
+
+	// Only necessary if Consul Enterprise namespaces are enabled.
+	EnableConsulNamespaces     bool
+	ConsulDestinationNamespace string
+	EnableNSMirroring          bool
+	NSMirroringPrefix          string
+	CrossNSACLPolicy           string
+
+	// Deployment configures the deployments that run the gateways.
+	Deployment DeploymentConfig
+}
+
+// consulNamespace returns the Consul namespace that objects in the Kubernetes
+// namespace kubeNS are written to.
+func (c Config) consulNamespace(kubeNS string) string {
+	return namespaces.ConsulNamespace(kubeNS, c.EnableConsulNamespaces, c.ConsulDestinationNamespace, c.EnableNSMirroring, c.NSMirroringPrefix)
+}
+
+// consulProtocol returns the api-gateway listener protocol for a Gateway
+// listener protocol and whether the protocol is supported.
+func consulProtocol(protocol string) (string, bool) {
+	switch protocol {
+	case protocolHTTP, protocolHTTPS:
+		return "http", true
+	case protocolTCP:
+		return "tcp", true
+	}
+	return "", false
+}
+
+// supportedKinds returns the route kinds that may attach to a listener with
+// the given protocol.
+func supportedKinds(protocol string) []string {
+	switch protocol {
+	case protocolHTTP, protocolHTTPS:
+		return []string{kindHTTPRoute}
+	case protocolTCP:
+		return []string{kindTCPRoute}
+	}
+	return nil
+}
+
+// translateGateway translates a Gateway into an api-gateway config entry. Only
+// the given listeners are translated. certificates holds the TLS Secrets of
+// each listener by listener name.
+func (c Config) translateGateway(gw gateway, listeners []listener, certificates map[string][]*corev1.Secret) *capi.APIGatewayConfigEntry {
+	entry := &capi.APIGatewayConfigEntry{
+		Kind:      capi.APIGateway,
+		Name:      gw.Name,
+		Namespace: c.consulNamespace(gw.Namespace),
+		Partition: c.ConsulPartition,
+		Meta:      entryMeta(gw.Namespace, gw.Name),
+	}
+	for _, l := range listeners {
+		protocol, _ := consulProtocol(l.Protocol)
+		listener := capi.APIGatewayListener{
+			Name:     l.Name,
+			Hostname: stringOr(l.Hostname, ""),
+			Port:     int(l.Port),
+			Protocol: protocol,
+		}
+		for _, secret := range certificates[l.Name] {
+			listener.TLS.Certificates = append(listener.TLS.Certificates, capi.ResourceReference{
+				Kind:      capi.InlineCertificate,
+				Name:      secret.Name,
+				Namespace: c.consulNamespace(secret.Namespace),
+				Partition: c.ConsulPartition,
+			})
+		}
+		entry.Listeners = append(entry.Listeners, listener)
+	}
+	return entry
+}
+
+// translateCertificate translates a TLS Secret into an inline-certificate
+// config entry.
+func (c Config) translateCertificate(secret *corev1.Secret) *capi.InlineCertificateConfigEntry {
+	return &capi.InlineCertificateConfigEntry{
+		Kind:        capi.InlineCertificate,
+		Name:        secret.Name,
+		Namespace:   c.consulNamespace(secret.Namespace),
+		Partition:   c.ConsulPartition,
+		Certificate: string(secret.Data[corev1.TLSCertKey]),
+		PrivateKey:  string(secret.Data[corev1.TLSPrivateKeyKey]),
+		Meta:        entryMeta(secret.Namespace, secret.Name),
+	}
+}
+
+// translateParent returns the Consul reference to the Gateway a route is
+// attached to.
+func (c Config) translateParent(gatewayNamespace string, ref parentReference) capi.ResourceReference {
+	return capi.ResourceReference{
+		Kind:        capi.APIGateway,
+		Name:        ref.Name,
+		SectionName: stringOr(ref.SectionName, ""),
+		Namespace:   c.consulNamespace(gatewayNamespace),
+		Partition:   c.ConsulPartition,
+	}
+}
+
+// backendKey identifies a backendRef by the index of its rule and its index
+// within that rule.
+type backendKey struct {
+	rule, backend int
+}
+
+// translateHTTPRoute translates an HTTPRoute into an http-route config entry.
+// Backends that aren't in services could not be resolved and are left out.
+func (c Config) translateHTTPRoute(r route, parents []capi.ResourceReference, services map[backendKey]*corev1.Service) *capi.HTTPRouteConfigEntry {
+	entry := &capi.HTTPRouteConfigEntry{
+		Kind:      capi.HTTPRoute,
+		Name:      r.Name,
+		Namespace: c.consulNamespace(r.Namespace),
+		Partition: c.ConsulPartition,
+		Parents:   parents,
+		Hostnames: r.Spec.Hostnames,
+		Meta:      entryMeta(r.Namespace, r.Name),
+	}
+	for i, rule := range r.Spec.Rules {
+		consulRule := capi.HTTPRouteRule{
+			Filters: translateHTTPFilters(rule.Filters),
+		}
+		for _, match := range rule.Matches {
+			consulRule.Matches = append(consulRule.Matches, translateHTTPMatch(match))
+		}
+		for j, ref := range rule.BackendRefs {
+			svc, ok := services[backendKey{rule: i, backend: j}]
+			if !ok {
+				continue
+			}
+			weight := 1
+			if ref.Weight != nil {
+				weight = int(*ref.Weight)
+			}
+			consulRule.Services = append(consulRule.Services, capi.HTTPService{
+				Name:      svc.Name,
+				Weight:    weight,
+				Filters:   translateHTTPFilters(ref.Filters),
+				Namespace: c.consulNamespace(svc.Namespace),
+				Partition: c.ConsulPartition,
+			})
+		}
+		entry.Rules = append(entry.Rules, consulRule)
+	}
+	return entry
+}
+
+// translateTCPRoute translates a TCPRoute into a tcp-route config entry.
+// Backends that aren't in services could not be resolved and are left out.
+func (c Config) translateTCPRoute(r route, parents []capi.ResourceReference, services map[backendKey]*corev1.Service) *capi.TCPRouteConfigEntry {
+	entry := &capi.TCPRouteConfigEntry{
+		Kind:      capi.TCPRoute,
+		Name:      r.Name,
+		Namespace: c.consulNamespace(r.Namespace),
+		Partition: c.ConsulPartition,
+		Parents:   parents,
+		Meta:      entryMeta(r.Namespace, r.Name),
+	}
+	for i, rule := range r.Spec.Rules {
+		for j := range rule.BackendRefs {
+			svc, ok := services[backendKey{rule: i, backend: j}]
+			if !ok {
+				continue
+			}
+			entry.Services = append(entry.Services, capi.TCPService{
+				Name:      svc.Name,
+				Namespace: c.consulNamespace(svc.Namespace),
+				Partition: c.ConsulPartition,
+			})
+		}
+	}
+	return entry
+}
+
+func translateHTTPMatch(match httpRouteMatch) capi.HTTPMatch {
+	consulMatch := capi.HTTPMatch{
+		Method: capi.HTTPMatchMethod(stringOr(match.Method, "")),
+		Path: capi.HTTPPathMatch{
+			Match: capi.HTTPPathMatchPrefix,
+			Value: "/",
+		},
+	}
+	if match.Path != nil {
+		switch stringOr(match.Path.Type, "PathPrefix") {
+		case "Exact":
+			consulMatch.Path.Match = capi.HTTPPathMatchExact
+		case "RegularExpression":
+			consulMatch.Path.Match = capi.HTTPPathMatchRegularExpression
+		}
+		consulMatch.Path.Value = stringOr(match.Path.Value, "/")
+	}
+	for _, header := range match.Headers {
+		matchType := capi.HTTPHeaderMatchExact
+		if stringOr(header.Type, "Exact") == "RegularExpression" {
+			matchType = capi.HTTPHeaderMatchRegularExpression
+		}
+		consulMatch.Headers = append(consulMatch.Headers, capi.HTTPHeaderMatch{
+			Match: matchType,
+			Name:  header.Name,
+			Value: header.Value,
+		})
+	}
+	for _, query := range match.QueryParams {
+		matchType := capi.HTTPQueryMatchExact
+		if stringOr(query.Type, "Exact") == "RegularExpression" {
+			matchType = capi.HTTPQueryMatchRegularExpression
+		}
+		consulMatch.Query = append(consulMatch.Query, capi.HTTPQueryMatch{
+			Match: matchType,
+			Name:  query.Name,
+			Value: query.Value,
+		})
+	}
+	return consulMatch
+}
+
+// translateHTTPFilters translates the request header and URL rewrite filters.
+// Consul only supports rewriting the matched path prefix so other URL rewrites
+// are left out.
+func translateHTTPFilters(filters []httpRouteFilter) capi.HTTPFilters {
+	var consulFilters capi.HTTPFilters
+	for _, filter := range filters {
+		switch {
+		case filter.RequestHeaderModifier != nil:
+			modifier := filter.RequestHeaderModifier
+			headerFilter := capi.HTTPHeaderFilter{Remove: modifier.Remove}
+			for _, h := range modifier.Add {
+				if headerFilter.Add == nil {
+					headerFilter.Add = make(map[string]string)
+				}
+				headerFilter.Add[h.Name] = h.Value
+			}
+			for _, h := range modifier.Set {
+				if headerFilter.Set == nil {
+					headerFilter.Set = make(map[string]string)
+				}
+				headerFilter.Set[h.Name] = h.Value
+			}
+			consulFilters.Headers = append(consulFilters.Headers, headerFilter)
+		case filter.URLRewrite != nil && filter.URLRewrite.Path != nil && filter.URLRewrite.Path.ReplacePrefixMatch != nil:
+			consulFilters.URLRewrite = &capi.URLRewrite{Path: *filter.URLRewrite.Path.ReplacePrefixMatch}
+		}
+	}
+	return consulFilters
+}
+
+// entryMeta returns the meta of config entries translated from the Kubernetes
+// object name in namespace.
+func entryMeta(namespace, name string) map[string]string {
+	return map[string]string{
+		common.SourceKey:        common.SourceValue,
+		constants.MetaKeyKubeNS: namespace,
+		metaKeyKubeName:         name,
+	}
+}
+
+// ownedBy returns whether the config entry meta was written for the
+// Kubernetes object name in namespace.
+func ownedBy(meta map[string]string, namespace, name string) bool {
+	return meta[common.SourceKey] == common.SourceValue &&
+		meta[constants.MetaKeyKubeNS] == namespace &&
+		meta[metaKeyKubeName] == name
+}
@@ -0,0 +1,258 @@
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/constants"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestTranslateHTTPRoute(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1beta1",
+		"kind":       "HTTPRoute",
+		"metadata": map[string]interface{}{
+			"name":      "route",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "gateway", "sectionName": "http"},
+			},
+			"hostnames": []interface{}{"example.com"},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"matches": []interface{}{
+						map[string]interface{}{
+							"path":    map[string]interface{}{"type": "Exact", "value": "/api"},
+							"headers": []interface{}{map[string]interface{}{"name": "x-version", "value": "2"}},
+							"method":  "GET",
+						},
+					},
+					"filters": []interface{}{
+						map[string]interface{}{
+							"type": "RequestHeaderModifier",
+							"requestHeaderModifier": map[string]interface{}{
+								"set":    []interface{}{map[string]interface{}{"name": "x-set", "value": "a"}},
+								"remove": []interface{}{"x-remove"},
+							},
+						},
+						map[string]interface{}{
+							"type": "URLRewrite",
+							"urlRewrite": map[string]interface{}{
+								"path": map[string]interface{}{"type": "ReplacePrefixMatch", "replacePrefixMatch": "/v2"},
+							},
+						},
+					},
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "api", "port": int64(8080), "weight": int64(90)},
+						map[string]interface{}{"name": "api-canary", "namespace": "canary", "port": int64(8080), "weight": int64(10)},
+						map[string]interface{}{"name": "missing", "port": int64(8080)},
+					},
+				},
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "web"},
+					},
+				},
+			},
+		},
+	}}
+	var r route
+	require.NoError(t, decode(obj, &r))
+	require.Equal(t, "api", r.Spec.Rules[0].BackendRefs[0].Name)
+	require.Equal(t, "canary", *r.Spec.Rules[0].BackendRefs[1].Namespace)
+	require.Equal(t, int32(8080), *r.Spec.Rules[0].BackendRefs[0].Port)
+
+	config := Config{}
+	parents := []capi.ResourceReference{config.translateParent("default", r.Spec.ParentRefs[0])}
+	services := map[backendKey]*corev1.Service{
+		{rule: 0, backend: 0}: {ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		{rule: 0, backend: 1}: {ObjectMeta: metav1.ObjectMeta{Name: "api-canary", Namespace: "canary"}},
+		{rule: 1, backend: 0}: {ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	}
+
+	entry := config.translateHTTPRoute(r, parents, services)
+	require.Equal(t, &capi.HTTPRouteConfigEntry{
+		Kind: capi.HTTPRoute,
+		Name: "route",
+		Parents: []capi.ResourceReference{
+			{Kind: capi.APIGateway, Name: "gateway", SectionName: "http"},
+		},
+		Hostnames: []string{"example.com"},
+		Rules: []capi.HTTPRouteRule{
+			{
+				Matches: []capi.HTTPMatch{
+					{
+						Method:  capi.HTTPMatchMethodGet,
+						Path:    capi.HTTPPathMatch{Match: capi.HTTPPathMatchExact, Value: "/api"},
+						Headers: []capi.HTTPHeaderMatch{{Match: capi.HTTPHeaderMatchExact, Name: "x-version", Value: "2"}},
+					},
+				},
+				Filters: capi.HTTPFilters{
+					Headers:    []capi.HTTPHeaderFilter{{Set: map[string]string{"x-set": "a"}, Remove: []string{"x-remove"}}},
+					URLRewrite: &capi.URLRewrite{Path: "/v2"},
+				},
+				Services: []capi.HTTPService{
+					{Name: "api", Weight: 90},
+					{Name: "api-canary", Weight: 10},
+				},
+			},
+			{
+				Services: []capi.HTTPService{
+					{Name: "web", Weight: 1},
+				},
+			},
+		},
+		Meta: map[string]string{
+			common.SourceKey:        common.SourceValue,
+			constants.MetaKeyKubeNS: "default",
+			metaKeyKubeName:         "route",
+		},
+	}, entry)
+}
+
+func TestTranslateHTTPRoute_Namespaces(t *testing.T) {
+	r := route{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+		Spec: routeSpec{
+			Rules: []routeRule{{BackendRefs: []backendRef{{objectReference: objectReference{Name: "api", Namespace: strPtr("other")}}}}},
+		},
+	}
+	services := map[backendKey]*corev1.Service{
+		{rule: 0, backend: 0}: {ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "other"}},
+	}
+
+	cases := map[string]struct {
+		config       Config
+		expRouteNS   string
+		expService   string
+		expGateway   string
+		expPartition string
+	}{
+		"namespaces disabled": {
+			config: Config{},
+		},
+		"destination namespace": {
+			config:     Config{EnableConsulNamespaces: true, ConsulDestinationNamespace: "dest"},
+			expRouteNS: "dest",
+			expService: "dest",
+			expGateway: "dest",
+		},
+		"mirroring with prefix": {
+			config:       Config{EnableConsulNamespaces: true, EnableNSMirroring: true, NSMirroringPrefix: "k8s-", ConsulPartition: "part"},
+			expRouteNS:   "k8s-default",
+			expService:   "k8s-other",
+			expGateway:   "k8s-gateways",
+			expPartition: "part",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			parent := c.config.translateParent("gateways", parentReference{Name: "gateway"})
+			entry := c.config.translateHTTPRoute(r, []capi.ResourceReference{parent}, services)
+			require.Equal(t, c.expRouteNS, entry.Namespace)
+			require.Equal(t, c.expPartition, entry.Partition)
+			require.Equal(t, c.expGateway, entry.Parents[0].Namespace)
+			require.Equal(t, c.expPartition, entry.Parents[0].Partition)
+			require.Equal(t, c.expService, entry.Rules[0].Services[0].Namespace)
+			require.Equal(t, c.expPartition, entry.Rules[0].Services[0].Partition)
+		})
+	}
+}
+
+func TestTranslateTCPRoute(t *testing.T) {
+	r := route{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: routeSpec{
+			Rules: []routeRule{{BackendRefs: []backendRef{
+				{objectReference: objectReference{Name: "postgres"}},
+				{objectReference: objectReference{Name: "missing"}},
+			}}},
+		},
+	}
+	services := map[backendKey]*corev1.Service{
+		{rule: 0, backend: 0}: {ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "default"}},
+	}
+	parents := []capi.ResourceReference{{Kind: capi.APIGateway, Name: "gateway"}}
+
+	entry := Config{}.translateTCPRoute(r, parents, services)
+	require.Equal(t, &capi.TCPRouteConfigEntry{
+		Kind:     capi.TCPRoute,
+		Name:     "db",
+		Parents:  parents,
+		Services: []capi.TCPService{{Name: "postgres"}},
+		Meta: map[string]string{
+			common.SourceKey:        common.SourceValue,
+			constants.MetaKeyKubeNS: "default",
+			metaKeyKubeName:         "db",
+		},
+	}, entry)
+}
+
+func TestTranslateGateway(t *testing.T) {
+	gw := gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+	}
+	listeners := []listener{
+		{Name: "http", Port: 80, Protocol: protocolHTTP},
+		{Name: "https", Port: 443, Protocol: protocolHTTPS, Hostname: strPtr("*.example.com")},
+		{Name: "tcp", Port: 5432, Protocol: protocolTCP},
+	}
+	certificates := map[string][]*corev1.Secret{
+		"https": {{ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "certs"}}},
+	}
+
+	entry := Config{}.translateGateway(gw, listeners, certificates)
+	expListeners := []capi.APIGatewayListener{
+		{Name: "http", Port: 80, Protocol: "http"},
+		{Name: "https", Port: 443, Protocol: "http", Hostname: "*.example.com"},
+		{Name: "tcp", Port: 5432, Protocol: "tcp"},
+	}
+	expListeners[1].TLS.Certificates = []capi.ResourceReference{{Kind: capi.InlineCertificate, Name: "cert"}}
+	require.Equal(t, capi.APIGateway, entry.Kind)
+	require.Equal(t, "gateway", entry.Name)
+	require.Equal(t, expListeners, entry.Listeners)
+	require.True(t, ownedBy(entry.Meta, "default", "gateway"))
+	require.False(t, ownedBy(entry.Meta, "default", "other"))
+}
+
+func TestTranslateHTTPMatch(t *testing.T) {
+	cases := map[string]struct {
+		match httpRouteMatch
+		exp   capi.HTTPMatch
+	}{
+		"defaults to prefix /": {
+			match: httpRouteMatch{},
+			exp:   capi.HTTPMatch{Path: capi.HTTPPathMatch{Match: capi.HTTPPathMatchPrefix, Value: "/"}},
+		},
+		"path prefix": {
+			match: httpRouteMatch{Path: &httpPathMatch{Value: strPtr("/api")}},
+			exp:   capi.HTTPMatch{Path: capi.HTTPPathMatch{Match: capi.HTTPPathMatchPrefix, Value: "/api"}},
+		},
+		"regular expression path and query": {
+			match: httpRouteMatch{
+				Path:        &httpPathMatch{Type: strPtr("RegularExpression"), Value: strPtr("/v[0-9]+")},
+				QueryParams: []httpValueMatch{{Type: strPtr("RegularExpression"), Name: "q", Value: ".*"}},
+			},
+			exp: capi.HTTPMatch{
+				Path:  capi.HTTPPathMatch{Match: capi.HTTPPathMatchRegularExpression, Value: "/v[0-9]+"},
+				Query: []capi.HTTPQueryMatch{{Match: capi.HTTPQueryMatchRegularExpression, Name: "q", Value: ".*"}},
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.exp, translateHTTPMatch(c.match))
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
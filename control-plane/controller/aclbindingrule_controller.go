@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	capi "github.com/hashicorp/consul/api"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ACLBindingRuleController is the controller for ACLBindingRule resources.
+type ACLBindingRuleController struct {
+	client.Client
+	Log                   logr.Logger
+	Scheme                *runtime.Scheme
+	ACLResourceController *ACLResourceController
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=aclbindingrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=aclbindingrules/status,verbs=get;update;patch
+
+func (r *ACLBindingRuleController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var bindingRule consulv1alpha1.ACLBindingRule
+	err := r.Get(ctx, req.NamespacedName, &bindingRule)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	write := func(consulClient *capi.Client, consulNS string) (bool, error) {
+		desired := bindingRule.ToConsul(consulNS)
+
+		// Binding rules don't have names so the only way to find the rule
+		// created by this resource is by the ID stored in the status. If the
+		// rule has been deleted from Consul it is recreated, unless a rule
+		// with the same contents that no other resource owns exists in which
+		// case it is adopted.
+		if bindingRule.Status.ID != "" {
+			existing, _, err := consulClient.ACL().BindingRuleRead(bindingRule.Status.ID, &capi.QueryOptions{Namespace: consulNS})
+			if err != nil {
+				return false, fmt.Errorf("reading binding rule: %w", err)
+			}
+			if existing != nil {
+				if bindingRule.MatchesConsul(existing) {
+					return false, nil
+				}
+				if _, _, err := consulClient.ACL().BindingRuleUpdate(&desired, &capi.WriteOptions{Namespace: consulNS}); err != nil {
+					return false, fmt.Errorf("updating binding rule: %w", err)
+				}
+				return true, nil
+			}
+		}
+
+		rules, _, err := consulClient.ACL().BindingRuleList(bindingRule.Spec.AuthMethod, &capi.QueryOptions{Namespace: consulNS})
+		if err != nil {
+			return false, fmt.Errorf("listing binding rules: %w", err)
+		}
+		claims, err := r.claims(ctx)
+		if err != nil {
+			return false, err
+		}
+		for _, rule := range rules {
+			if bindingRule.MatchesConsul(rule) && claims.otherOwner(&bindingRule, rule.ID) == "" {
+				bindingRule.Status.ID = rule.ID
+				return true, nil
+			}
+		}
+
+		desired.ID = ""
+		created, _, err := consulClient.ACL().BindingRuleCreate(&desired, &capi.WriteOptions{Namespace: consulNS})
+		if err != nil {
+			return false, fmt.Errorf("creating binding rule: %w", err)
+		}
+		bindingRule.Status.ID = created.ID
+		return true, nil
+	}
+	del := func(consulClient *capi.Client, consulNS string) error {
+		if bindingRule.Status.ID == "" {
+			return nil
+		}
+		_, err := consulClient.ACL().BindingRuleDelete(bindingRule.Status.ID, &capi.WriteOptions{Namespace: consulNS})
+		return err
+	}
+	return r.ACLResourceController.ReconcileACLResource(ctx, r.Client, logger, &bindingRule, write, del)
+}
+
+// claims returns the IDs of the binding rules in Consul that ACLBindingRule
+// resources own.
+func (r *ACLBindingRuleController) claims(ctx context.Context) (aclClaims, error) {
+	var list consulv1alpha1.ACLBindingRuleList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("listing ACL binding rules: %w", err)
+	}
+	claims := make(aclClaims)
+	for i := range list.Items {
+		claims.claim(&list.Items[i], list.Items[i].Status.ID)
+	}
+	return claims, nil
+}
+
+func (r *ACLBindingRuleController) SetupWithManager(mgr ctrl.Manager) error {
+	return setupWithManager(mgr, &consulv1alpha1.ACLBindingRule{}, r)
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/acls"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	capi "github.com/hashicorp/consul/api"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ACLPolicyController is the controller for ACLPolicy resources.
+type ACLPolicyController struct {
+	client.Client
+	Log                   logr.Logger
+	Scheme                *runtime.Scheme
+	ACLResourceController *ACLResourceController
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=aclpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=aclpolicies/status,verbs=get;update;patch
+
+func (r *ACLPolicyController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var policy consulv1alpha1.ACLPolicy
+	err := r.Get(ctx, req.NamespacedName, &policy)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	write := func(consulClient *capi.Client, consulNS string) (bool, error) {
+		existing, _, err := consulClient.ACL().PolicyReadByName(policy.ConsulName(), &capi.QueryOptions{Namespace: consulNS})
+		if acls.IsACLNotFoundErr(err) {
+			existing, err = nil, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("reading policy: %w", err)
+		}
+		if existing != nil && existing.ID == policy.Status.ID && policy.MatchesConsul(existing) {
+			return false, nil
+		}
+		// A policy with the same contents is adopted so that a reconcile that
+		// created it but failed to record its ID in the status can recover,
+		// unless another resource owns it.
+		if existing != nil && existing.ID != policy.Status.ID {
+			claims, err := r.claims(ctx)
+			if err != nil {
+				return false, err
+			}
+			if owner := claims.otherOwner(&policy, existing.ID); owner != "" {
+				return false, fmt.Errorf("policy %q already exists in Consul and is managed by ACLPolicy %s", policy.ConsulName(), owner)
+			}
+		}
+		if existing != nil && policy.MatchesConsul(existing) {
+			policy.Status.ID = existing.ID
+			return true, nil
+		}
+
+		written, err := acls.CreateOrUpdatePolicy(consulClient, policy.ToConsul(consulNS), func(existing *capi.ACLPolicy) (bool, error) {
+			// Only overwrite policies that were created by this resource.
+			if existing.ID != policy.Status.ID {
+				return false, fmt.Errorf("policy %q already exists in Consul and is not managed by this resource", policy.ConsulName())
+			}
+			return true, nil
+		}, &capi.WriteOptions{Namespace: consulNS})
+		if err != nil {
+			return false, err
+		}
+		policy.Status.ID = written.ID
+		return true, nil
+	}
+	del := func(consulClient *capi.Client, consulNS string) error {
+		if policy.Status.ID == "" {
+			return nil
+		}
+		_, err := consulClient.ACL().PolicyDelete(policy.Status.ID, &capi.WriteOptions{Namespace: consulNS})
+		return err
+	}
+	return r.ACLResourceController.ReconcileACLResource(ctx, r.Client, logger, &policy, write, del)
+}
+
+// claims returns the IDs of the policies in Consul that ACLPolicy resources
+// own.
+func (r *ACLPolicyController) claims(ctx context.Context) (aclClaims, error) {
+	var list consulv1alpha1.ACLPolicyList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("listing ACL policies: %w", err)
+	}
+	claims := make(aclClaims)
+	for i := range list.Items {
+		claims.claim(&list.Items[i], list.Items[i].Status.ID)
+	}
+	return claims, nil
+}
+
+func (r *ACLPolicyController) SetupWithManager(mgr ctrl.Manager) error {
+	return setupWithManager(mgr, &consulv1alpha1.ACLPolicy{}, r)
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ACLResourceController reconciles ACL resources (policies, roles and
+// binding rules) generically. The resource-specific controllers call
+// ReconcileACLResource with functions that write and delete their resource.
+type ACLResourceController struct {
+	// ConsulClientConfig is the config for the Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+
+	// EnableConsulNamespaces indicates that a user is running Consul Enterprise
+	// with version 1.7+ which supports namespaces.
+	EnableConsulNamespaces bool
+	// ConsulDestinationNamespace is the namespace all ACL resources are
+	// written to if mirroring is disabled.
+	ConsulDestinationNamespace string
+	// EnableNSMirroring causes Consul namespaces to be created to match the
+	// k8s namespace of the ACL resource.
+	EnableNSMirroring bool
+	// NSMirroringPrefix is an optional prefix that can be added to the Consul
+	// namespaces created while mirroring.
+	NSMirroringPrefix string
+	// CrossNSACLPolicy is the name of the ACL policy to attach to
+	// any created Consul namespaces to allow cross namespace service discovery.
+	CrossNSACLPolicy string
+
+	// ResyncPeriod is how often ACL resources are re-read from Consul to
+	// correct changes made to them outside of Kubernetes. They are only
+	// re-read when the resource changes if it is zero.
+	ResyncPeriod time.Duration
+}
+
+// aclWriteFunc creates or updates an ACL resource in the given Consul
+// namespace and records its Consul ID on the resource. It returns true if
+// Consul was changed.
+type aclWriteFunc func(consulClient *capi.Client, consulNS string) (bool, error)
+
+// aclDeleteFunc deletes an ACL resource from the given Consul namespace.
+type aclDeleteFunc func(consulClient *capi.Client, consulNS string) error
+
+// aclClaims maps the Consul IDs recorded in the status of ACL resources of
+// one kind to the resource that recorded them. ACL policies, roles and
+// binding rules have no meta to record their owner in, so the ID in the
+// status of a resource is the only record that it owns the Consul resource.
+type aclClaims map[string]types.NamespacedName
+
+// claim records that resource owns the Consul resource with the given ID.
+func (c aclClaims) claim(resource client.Object, id string) {
+	if id != "" {
+		c[id] = types.NamespacedName{Namespace: resource.GetNamespace(), Name: resource.GetName()}
+	}
+}
+
+// otherOwner returns the resource other than self that owns the Consul
+// resource with the given ID, or an empty string if there is none.
+func (c aclClaims) otherOwner(self client.Object, id string) string {
+	owner, ok := c[id]
+	if !ok || (owner.Namespace == self.GetNamespace() && owner.Name == self.GetName()) {
+		return ""
+	}
+	return owner.String()
+}
+
+// ReconcileACLResource reconciles resource, which must already have been
+// read from Kubernetes. The resource is written to Consul on every
+// reconcile so that changes made in Consul are corrected.
+func (r *ACLResourceController) ReconcileACLResource(ctx context.Context, kubeClient client.Client, logger logr.Logger, resource consulResource, write aclWriteFunc, del aclDeleteFunc) (ctrl.Result, error) {
+	consulClient, err := consulClientFromConnMgr(r.ConsulClientConfig, r.ConsulServerConnMgr)
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+	consulNS := namespaces.ConsulNamespace(resource.GetNamespace(), r.EnableConsulNamespaces,
+		r.ConsulDestinationNamespace, r.EnableNSMirroring, r.NSMirroringPrefix)
+
+	if !resource.GetDeletionTimestamp().IsZero() {
+		if containsString(resource.GetFinalizers(), FinalizerName) {
+			logger.Info("deletion event")
+			if err := del(consulClient, consulNS); err != nil && !isNotFoundErr(err) {
+				return resourceSyncFailed(ctx, logger, kubeClient.Status(), resource, ConsulAgentError,
+					fmt.Errorf("deleting from consul: %w", err))
+			}
+			logger.Info("deletion from Consul successful")
+			controllerutil.RemoveFinalizer(resource, FinalizerName)
+			if err := kubeClient.Update(ctx, resource); err != nil {
+				return ctrl.Result{}, err
+			}
+			logger.Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !containsString(resource.GetFinalizers(), FinalizerName) {
+		controllerutil.AddFinalizer(resource, FinalizerName)
+		resource.SetSyncedCondition(corev1.ConditionUnknown, "", "")
+		if err := kubeClient.Update(ctx, resource); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.EnableConsulNamespaces {
+		created, err := namespaces.EnsureExists(consulClient, consulNS, r.CrossNSACLPolicy)
+		if err != nil {
+			return resourceSyncFailed(ctx, logger, kubeClient.Status(), resource, ConsulAgentError,
+				fmt.Errorf("creating consul namespace %q: %w", consulNS, err))
+		}
+		if created {
+			logger.Info("consul namespace created", "ns", consulNS)
+		}
+	}
+
+	changed, err := write(consulClient, consulNS)
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, kubeClient.Status(), resource, ConsulAgentError,
+			fmt.Errorf("writing to consul: %w", err))
+	}
+	if changed {
+		logger.Info("written to Consul")
+	} else if resource.SyncedConditionStatus() == corev1.ConditionTrue {
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+	}
+	if _, err := resourceSyncSuccessful(ctx, kubeClient.Status(), resource); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+}
@@ -0,0 +1,458 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/acls"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/controller"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const aclBootstrapToken = "b78c7852-4a8e-4ba4-9e03-2bcd4a47c0f8"
+
+func TestACLPolicyController_createsUpdatesAndCorrectsDrift(t *testing.T) {
+	t.Parallel()
+
+	policy := &v1alpha1.ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ACLPolicySpec{
+			Description: "web policy",
+			Rules:       `service "web" { policy = "write" }`,
+		},
+	}
+	ctx := context.Background()
+	fakeClient, testClient := aclTestClients(t, policy)
+	consulClient := testClient.APIClient
+	r := &controller.ACLPolicyController{
+		Client:                fakeClient,
+		Log:                   logrtest.TestLogger{T: t},
+		ACLResourceController: aclResourceController(testClient),
+	}
+	namespacedName := types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}
+
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, policy)
+	require.NotEmpty(t, policy.Status.ID)
+	consulPolicy, _, err := consulClient.ACL().PolicyReadByName("web", nil)
+	require.NoError(t, err)
+	require.Equal(t, policy.Status.ID, consulPolicy.ID)
+	require.Equal(t, "web policy", consulPolicy.Description)
+	require.Equal(t, `service "web" { policy = "write" }`, consulPolicy.Rules)
+
+	// Update the rules and check they're updated in Consul.
+	policy.Spec.Rules = `service "web" { policy = "read" }`
+	require.NoError(t, fakeClient.Update(ctx, policy))
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, policy)
+	consulPolicy, _, err = consulClient.ACL().PolicyReadByName("web", nil)
+	require.NoError(t, err)
+	require.Equal(t, policy.Status.ID, consulPolicy.ID)
+	require.Equal(t, `service "web" { policy = "read" }`, consulPolicy.Rules)
+
+	// Changes made directly in Consul are reverted.
+	consulPolicy.Rules = `service "web" { policy = "deny" }`
+	_, _, err = consulClient.ACL().PolicyUpdate(consulPolicy, nil)
+	require.NoError(t, err)
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, policy)
+	consulPolicy, _, err = consulClient.ACL().PolicyReadByName("web", nil)
+	require.NoError(t, err)
+	require.Equal(t, `service "web" { policy = "read" }`, consulPolicy.Rules)
+
+	// The policy is recreated if it's deleted from Consul.
+	_, err = consulClient.ACL().PolicyDelete(consulPolicy.ID, nil)
+	require.NoError(t, err)
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, policy)
+	consulPolicy, _, err = consulClient.ACL().PolicyReadByName("web", nil)
+	require.NoError(t, err)
+	require.NotNil(t, consulPolicy)
+	require.Equal(t, policy.Status.ID, consulPolicy.ID)
+}
+
+func TestACLPolicyController_doesNotOverwriteUnmanagedPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := &v1alpha1.ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ACLPolicySpec{
+			Rules: `service "web" { policy = "write" }`,
+		},
+	}
+	ctx := context.Background()
+	fakeClient, testClient := aclTestClients(t, policy)
+	consulClient := testClient.APIClient
+	_, _, err := consulClient.ACL().PolicyCreate(&capi.ACLPolicy{
+		Name:  "web",
+		Rules: `service "web" { policy = "read" }`,
+	}, nil)
+	require.NoError(t, err)
+
+	r := &controller.ACLPolicyController{
+		Client:                fakeClient,
+		Log:                   logrtest.TestLogger{T: t},
+		ACLResourceController: aclResourceController(testClient),
+	}
+	namespacedName := types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.EqualError(t, err, `writing to consul: policy "web" already exists in Consul and is not managed by this resource`)
+
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, policy))
+	require.Equal(t, corev1.ConditionFalse, policy.SyncedConditionStatus())
+	require.Empty(t, policy.Status.ID)
+
+	consulPolicy, _, err := consulClient.ACL().PolicyReadByName("web", nil)
+	require.NoError(t, err)
+	require.Equal(t, `service "web" { policy = "read" }`, consulPolicy.Rules)
+}
+
+func TestACLPolicyController_adoptsMatchingPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := &v1alpha1.ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ACLPolicySpec{
+			Rules: `service "web" { policy = "write" }`,
+		},
+	}
+	fakeClient, testClient := aclTestClients(t, policy)
+	consulClient := testClient.APIClient
+	// Simulate a previous reconcile that created the policy but failed to
+	// record its ID in the status.
+	created, _, err := consulClient.ACL().PolicyCreate(&capi.ACLPolicy{
+		Name:  "web",
+		Rules: `service "web" { policy = "write" }`,
+	}, nil)
+	require.NoError(t, err)
+
+	r := &controller.ACLPolicyController{
+		Client:                fakeClient,
+		Log:                   logrtest.TestLogger{T: t},
+		ACLResourceController: aclResourceController(testClient),
+	}
+	namespacedName := types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, policy)
+	require.Equal(t, created.ID, policy.Status.ID)
+}
+
+func TestACLPolicyController_doesNotAdoptPolicyOwnedByOtherResource(t *testing.T) {
+	t.Parallel()
+
+	policy := &v1alpha1.ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ACLPolicySpec{
+			Rules: `service "web" { policy = "write" }`,
+		},
+	}
+	ctx := context.Background()
+	fakeClient, testClient := aclTestClients(t, policy)
+	consulClient := testClient.APIClient
+	created, _, err := consulClient.ACL().PolicyCreate(&capi.ACLPolicy{
+		Name:  "web",
+		Rules: `service "web" { policy = "write" }`,
+	}, nil)
+	require.NoError(t, err)
+
+	// The policy is owned by a resource with the same name in another
+	// namespace.
+	other := &v1alpha1.ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "other",
+		},
+		Spec: policy.Spec,
+	}
+	require.NoError(t, fakeClient.Create(ctx, other))
+	other.Status.ID = created.ID
+	require.NoError(t, fakeClient.Status().Update(ctx, other))
+
+	r := &controller.ACLPolicyController{
+		Client:                fakeClient,
+		Log:                   logrtest.TestLogger{T: t},
+		ACLResourceController: aclResourceController(testClient),
+	}
+	namespacedName := types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.EqualError(t, err, `writing to consul: policy "web" already exists in Consul and is managed by ACLPolicy other/web`)
+
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, policy))
+	require.Equal(t, corev1.ConditionFalse, policy.SyncedConditionStatus())
+	require.Empty(t, policy.Status.ID)
+}
+
+func TestACLPolicyController_requeuesAfterResyncPeriod(t *testing.T) {
+	t.Parallel()
+
+	policy := &v1alpha1.ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ACLPolicySpec{
+			Rules: `service "web" { policy = "write" }`,
+		},
+	}
+	ctx := context.Background()
+	fakeClient, testClient := aclTestClients(t, policy)
+	aclCtrl := aclResourceController(testClient)
+	aclCtrl.ResyncPeriod = time.Minute
+	r := &controller.ACLPolicyController{
+		Client:                fakeClient,
+		Log:                   logrtest.TestLogger{T: t},
+		ACLResourceController: aclCtrl,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}}
+
+	// The policy is requeued both when it's written and when it's already
+	// in sync so that changes made in Consul are corrected.
+	for i := 0; i < 2; i++ {
+		resp, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, time.Minute, resp.RequeueAfter)
+	}
+}
+
+func TestACLRoleController_createsUpdatesAndCorrectsDrift(t *testing.T) {
+	t.Parallel()
+
+	role := &v1alpha1.ACLRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "role",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ACLRoleSpec{
+			Description: "web role",
+			Policies:    []string{"policy-1"},
+		},
+	}
+	ctx := context.Background()
+	fakeClient, testClient := aclTestClients(t, role)
+	consulClient := testClient.APIClient
+	for _, name := range []string{"policy-1", "policy-2"} {
+		_, _, err := consulClient.ACL().PolicyCreate(&capi.ACLPolicy{Name: name}, nil)
+		require.NoError(t, err)
+	}
+	r := &controller.ACLRoleController{
+		Client:                fakeClient,
+		Log:                   logrtest.TestLogger{T: t},
+		ACLResourceController: aclResourceController(testClient),
+	}
+	namespacedName := types.NamespacedName{Name: role.Name, Namespace: role.Namespace}
+
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, role)
+	require.NotEmpty(t, role.Status.ID)
+	consulRole, _, err := consulClient.ACL().RoleReadByName("role", nil)
+	require.NoError(t, err)
+	require.Equal(t, role.Status.ID, consulRole.ID)
+	require.Equal(t, "web role", consulRole.Description)
+	require.Len(t, consulRole.Policies, 1)
+	require.Equal(t, "policy-1", consulRole.Policies[0].Name)
+
+	// Update the role and check it's updated in Consul.
+	role.Spec.Policies = []string{"policy-2"}
+	role.Spec.ServiceIdentities = []v1alpha1.ACLServiceIdentity{{ServiceName: "web"}}
+	require.NoError(t, fakeClient.Update(ctx, role))
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, role)
+	consulRole, _, err = consulClient.ACL().RoleReadByName("role", nil)
+	require.NoError(t, err)
+	require.Equal(t, role.Status.ID, consulRole.ID)
+	require.Len(t, consulRole.Policies, 1)
+	require.Equal(t, "policy-2", consulRole.Policies[0].Name)
+	require.Len(t, consulRole.ServiceIdentities, 1)
+	require.Equal(t, "web", consulRole.ServiceIdentities[0].ServiceName)
+
+	// Changes made directly in Consul are reverted.
+	consulRole.ServiceIdentities = nil
+	_, _, err = consulClient.ACL().RoleUpdate(consulRole, nil)
+	require.NoError(t, err)
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, role)
+	consulRole, _, err = consulClient.ACL().RoleReadByName("role", nil)
+	require.NoError(t, err)
+	require.Len(t, consulRole.ServiceIdentities, 1)
+}
+
+func TestACLBindingRuleController_createsUpdatesAndCorrectsDrift(t *testing.T) {
+	t.Parallel()
+
+	bindingRule := &v1alpha1.ACLBindingRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rule",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ACLBindingRuleSpec{
+			AuthMethod: test.AuthMethod,
+			Selector:   "serviceaccount.name==web",
+			BindType:   "service",
+			BindName:   "web",
+		},
+	}
+	ctx := context.Background()
+	fakeClient, testClient := aclTestClients(t, bindingRule)
+	consulClient := testClient.APIClient
+	test.SetupK8sAuthMethod(t, consulClient, "web", "default")
+
+	r := &controller.ACLBindingRuleController{
+		Client:                fakeClient,
+		Log:                   logrtest.TestLogger{T: t},
+		ACLResourceController: aclResourceController(testClient),
+	}
+	namespacedName := types.NamespacedName{Name: bindingRule.Name, Namespace: bindingRule.Namespace}
+
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, bindingRule)
+	require.NotEmpty(t, bindingRule.Status.ID)
+	consulRule, _, err := consulClient.ACL().BindingRuleRead(bindingRule.Status.ID, nil)
+	require.NoError(t, err)
+	require.Equal(t, "serviceaccount.name==web", consulRule.Selector)
+	require.Equal(t, "web", consulRule.BindName)
+
+	// Update the rule and check it's updated in place in Consul.
+	bindingRule.Spec.BindName = "${serviceaccount.name}"
+	require.NoError(t, fakeClient.Update(ctx, bindingRule))
+	ruleID := bindingRule.Status.ID
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, bindingRule)
+	require.Equal(t, ruleID, bindingRule.Status.ID)
+	consulRule, _, err = consulClient.ACL().BindingRuleRead(ruleID, nil)
+	require.NoError(t, err)
+	require.Equal(t, "${serviceaccount.name}", consulRule.BindName)
+
+	// The rule is recreated if it's deleted from Consul.
+	_, err = consulClient.ACL().BindingRuleDelete(ruleID, nil)
+	require.NoError(t, err)
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, bindingRule)
+	require.NotEqual(t, ruleID, bindingRule.Status.ID)
+	consulRule, _, err = consulClient.ACL().BindingRuleRead(bindingRule.Status.ID, nil)
+	require.NoError(t, err)
+	require.NotNil(t, consulRule)
+
+	// A matching rule is adopted rather than duplicated if the ID in the
+	// status is lost.
+	ruleID = bindingRule.Status.ID
+	bindingRule.Status.ID = ""
+	require.NoError(t, fakeClient.Status().Update(ctx, bindingRule))
+	reconcileAndRequireSynced(t, r, fakeClient, namespacedName, bindingRule)
+	require.Equal(t, ruleID, bindingRule.Status.ID)
+	rules, _, err := consulClient.ACL().BindingRuleList(test.AuthMethod, nil)
+	require.NoError(t, err)
+	matching := 0
+	for _, rule := range rules {
+		if bindingRule.MatchesConsul(rule) {
+			matching++
+		}
+	}
+	require.Equal(t, 1, matching)
+
+	// A rule with the same contents doesn't adopt the rule owned by this
+	// resource.
+	other := &v1alpha1.ACLBindingRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rule",
+			Namespace: "other",
+		},
+		Spec: bindingRule.Spec,
+	}
+	require.NoError(t, fakeClient.Create(ctx, other))
+	otherName := types.NamespacedName{Name: other.Name, Namespace: other.Namespace}
+	reconcileAndRequireSynced(t, r, fakeClient, otherName, other)
+	require.NotEmpty(t, other.Status.ID)
+	require.NotEqual(t, bindingRule.Status.ID, other.Status.ID)
+}
+
+func TestACLPolicyController_deletesPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	policy := &v1alpha1.ACLPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "web",
+			Namespace:         "default",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{controller.FinalizerName},
+		},
+	}
+	fakeClient, testClient := aclTestClients(t, policy)
+	consulClient := testClient.APIClient
+	created, _, err := consulClient.ACL().PolicyCreate(&capi.ACLPolicy{Name: "web"}, nil)
+	require.NoError(t, err)
+	policy.Status.ID = created.ID
+	require.NoError(t, fakeClient.Status().Update(ctx, policy))
+
+	r := &controller.ACLPolicyController{
+		Client:                fakeClient,
+		Log:                   logrtest.TestLogger{T: t},
+		ACLResourceController: aclResourceController(testClient),
+	}
+	resp, err := r.Reconcile(ctx, ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace},
+	})
+	require.NoError(t, err)
+	require.False(t, resp.Requeue)
+
+	_, _, err = consulClient.ACL().PolicyReadByName("web", nil)
+	require.True(t, acls.IsACLNotFoundErr(err))
+}
+
+type aclResource interface {
+	client.Object
+	SyncedConditionStatus() corev1.ConditionStatus
+}
+
+func aclTestClients(t *testing.T, obj runtime.Object) (client.Client, *test.TestServerClient) {
+	t.Helper()
+	s := runtime.NewScheme()
+	// The list types are needed to find the resources that own ACL
+	// resources in Consul.
+	s.AddKnownTypes(v1alpha1.GroupVersion, obj, &v1alpha1.ACLPolicyList{}, &v1alpha1.ACLRoleList{}, &v1alpha1.ACLBindingRuleList{})
+	fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(obj).Build()
+	testClient := test.TestServerWithMockConnMgrWatcher(t, func(c *testutil.TestServerConfig) {
+		c.ACL.Enabled = true
+		c.ACL.Tokens.InitialManagement = aclBootstrapToken
+	})
+	// The bootstrap token is only usable once the leader has initialized ACLs.
+	retry.Run(t, func(r *retry.R) {
+		_, _, err := testClient.APIClient.ACL().TokenReadSelf(nil)
+		require.NoError(r, err)
+	})
+	return fakeClient, testClient
+}
+
+func aclResourceController(testClient *test.TestServerClient) *controller.ACLResourceController {
+	return &controller.ACLResourceController{
+		ConsulClientConfig:  testClient.Cfg,
+		ConsulServerConnMgr: testClient.Watcher,
+	}
+}
+
+func reconcileAndRequireSynced(t *testing.T, r interface {
+	Reconcile(context.Context, ctrl.Request) (ctrl.Result, error)
+}, fakeClient client.Client, namespacedName types.NamespacedName, obj aclResource) {
+	t.Helper()
+	resp, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	require.False(t, resp.Requeue)
+	require.NoError(t, fakeClient.Get(context.Background(), namespacedName, obj))
+	require.Equal(t, corev1.ConditionTrue, obj.SyncedConditionStatus())
+	require.Contains(t, obj.GetFinalizers(), controller.FinalizerName)
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/acls"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	capi "github.com/hashicorp/consul/api"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ACLRoleController is the controller for ACLRole resources.
+type ACLRoleController struct {
+	client.Client
+	Log                   logr.Logger
+	Scheme                *runtime.Scheme
+	ACLResourceController *ACLResourceController
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=aclroles,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=aclroles/status,verbs=get;update;patch
+
+func (r *ACLRoleController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var role consulv1alpha1.ACLRole
+	err := r.Get(ctx, req.NamespacedName, &role)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	write := func(consulClient *capi.Client, consulNS string) (bool, error) {
+		existing, _, err := consulClient.ACL().RoleReadByName(role.ConsulName(), &capi.QueryOptions{Namespace: consulNS})
+		if err != nil {
+			return false, fmt.Errorf("reading role: %w", err)
+		}
+		if existing != nil && existing.ID == role.Status.ID && role.MatchesConsul(existing) {
+			return false, nil
+		}
+		// A role with the same contents is adopted so that a reconcile that
+		// created it but failed to record its ID in the status can recover,
+		// unless another resource owns it.
+		if existing != nil && existing.ID != role.Status.ID {
+			claims, err := r.claims(ctx)
+			if err != nil {
+				return false, err
+			}
+			if owner := claims.otherOwner(&role, existing.ID); owner != "" {
+				return false, fmt.Errorf("role %q already exists in Consul and is managed by ACLRole %s", role.ConsulName(), owner)
+			}
+		}
+		if existing != nil && role.MatchesConsul(existing) {
+			role.Status.ID = existing.ID
+			return true, nil
+		}
+
+		written, err := acls.CreateOrUpdateRole(consulClient, role.ToConsul(consulNS), func(existing *capi.ACLRole) (bool, error) {
+			// Only overwrite roles that were created by this resource.
+			if existing.ID != role.Status.ID {
+				return false, fmt.Errorf("role %q already exists in Consul and is not managed by this resource", role.ConsulName())
+			}
+			return true, nil
+		}, &capi.WriteOptions{Namespace: consulNS})
+		if err != nil {
+			return false, err
+		}
+		role.Status.ID = written.ID
+		return true, nil
+	}
+	del := func(consulClient *capi.Client, consulNS string) error {
+		if role.Status.ID == "" {
+			return nil
+		}
+		_, err := consulClient.ACL().RoleDelete(role.Status.ID, &capi.WriteOptions{Namespace: consulNS})
+		return err
+	}
+	return r.ACLResourceController.ReconcileACLResource(ctx, r.Client, logger, &role, write, del)
+}
+
+// claims returns the IDs of the roles in Consul that ACLRole resources own.
+func (r *ACLRoleController) claims(ctx context.Context) (aclClaims, error) {
+	var list consulv1alpha1.ACLRoleList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("listing ACL roles: %w", err)
+	}
+	claims := make(aclClaims)
+	for i := range list.Items {
+		claims.claim(&list.Items[i], list.Items[i].Status.ID)
+	}
+	return claims, nil
+}
+
+func (r *ACLRoleController) SetupWithManager(mgr ctrl.Manager) error {
+	return setupWithManager(mgr, &consulv1alpha1.ACLRole{}, r)
+}
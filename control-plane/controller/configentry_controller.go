@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -29,6 +32,36 @@ const (
 	ConsulAgentError             = "ConsulAgentError"
 	ExternallyManagedConfigError = "ExternallyManagedConfigError"
 	MigrationFailedError         = "MigrationFailedError"
+	OwnershipConflict            = "OwnershipConflict"
+	Drifted                      = "Drifted"
+	DriftCorrected               = "DriftCorrected"
+
+	// Reasons changes to a resource are not written to Consul, set by its
+	// consul.hashicorp.com/reconcile annotation.
+	Paused = "Paused"
+	DryRun = "DryRun"
+
+	// Operations a dry run reports for a config entry.
+	DryRunCreate = "create"
+	DryRunUpdate = "update"
+	DryRunNone   = "none"
+
+	// Reasons the discovery chain of a service can't be compiled.
+	DiscoveryChainCompileError = "DiscoveryChainCompileError"
+	SubsetNotFound             = "SubsetNotFound"
+	ProtocolMismatch           = "ProtocolMismatch"
+	CircularReference          = "CircularReference"
+
+	// DriftPolicyCorrect overwrites config entries in Consul that have
+	// drifted from their custom resource.
+	DriftPolicyCorrect = "correct"
+	// DriftPolicyReport only reports config entries in Consul that have
+	// drifted from their custom resource.
+	DriftPolicyReport = "report"
+
+	// ownershipConflictRequeue is how often a resource whose config entry is
+	// owned by another cluster or resource checks whether it can take over.
+	ownershipConflictRequeue = time.Minute
 )
 
 // Controller is implemented by CRD-specific controllers. It is used by
@@ -84,6 +117,22 @@ type ConfigEntryController struct {
 	// any created Consul namespaces to allow cross namespace service discovery.
 	// Only necessary if ACLs are enabled.
 	CrossNSACLPolicy string
+
+	// ResyncPeriod is how often config entries are re-read from Consul to
+	// detect changes made to them outside of Kubernetes. Drift detection is
+	// disabled if it is zero.
+	ResyncPeriod time.Duration
+
+	// DriftPolicy is what to do with a config entry that was changed in
+	// Consul since it was last synced, either DriftPolicyCorrect or
+	// DriftPolicyReport.
+	DriftPolicy string
+
+	// ClusterID identifies this Kubernetes cluster. If set, config entries
+	// are written with the cluster ID and the resource that manages them so
+	// that clusters in the same datacenter don't overwrite each other's
+	// config entries.
+	ClusterID string
 }
 
 // ReconcileEntry reconciles an update to a resource. CRD-specific controller's
@@ -115,6 +164,7 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 	}
 
 	consulEntry := configEntry.ToConsul(r.DatacenterName)
+	r.setOwner(consulEntry, configEntry)
 
 	if configEntry.GetDeletionTimestamp().IsZero() {
 		// The object is not being deleted, so if it does not have our finalizer,
@@ -140,18 +190,26 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 			if err != nil && !isNotFoundErr(err) {
 				return ctrl.Result{}, fmt.Errorf("getting config entry from consul: %w", err)
 			} else if err == nil {
-				// Only delete the resource from Consul if it is owned by our datacenter.
-				if entry.GetMeta()[common.DatacenterKey] == r.DatacenterName {
-					_, err := consulClient.ConfigEntries().Delete(configEntry.ConsulKind(), configEntry.ConsulName(), &capi.WriteOptions{
+				// Only delete the resource from Consul if it is owned by our datacenter
+				// and by this resource.
+				if entry.GetMeta()[common.DatacenterKey] != r.DatacenterName {
+					logger.Info("config entry in Consul was created in another datacenter - skipping delete from Consul", "external-datacenter", entry.GetMeta()[common.DatacenterKey])
+				} else if owner := r.otherOwner(entry, configEntry); owner != "" {
+					logger.Info("config entry in Consul is owned by another resource - skipping delete from Consul", "owner", owner)
+				} else if modifiedOutside(entry, configEntry) {
+					logger.Info("config entry in Consul was modified outside of Kubernetes since it was last synced - skipping delete from Consul", "modify-index", entry.GetModifyIndex())
+				} else {
+					deleted, _, err := consulClient.ConfigEntries().DeleteCAS(configEntry.ConsulKind(), configEntry.ConsulName(), writeIndex(entry, configEntry), &capi.WriteOptions{
 						Namespace: r.consulNamespace(consulEntry, configEntry.ConsulMirroringNS(), configEntry.ConsulGlobalResource()),
 					})
 					if err != nil {
 						return r.syncFailed(ctx, logger, crdCtrl, configEntry, ConsulAgentError,
 							fmt.Errorf("deleting config entry from consul: %w", err))
 					}
+					if !deleted {
+						return concurrentModification(logger)
+					}
 					logger.Info("deletion from Consul successful")
-				} else {
-					logger.Info("config entry in Consul was created in another datacenter - skipping delete from Consul", "external-datacenter", entry.GetMeta()[common.DatacenterKey])
 				}
 			}
 			// remove our finalizer from the list and update it.
@@ -159,6 +217,7 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 			if err := crdCtrl.Update(ctx, configEntry); err != nil {
 				return ctrl.Result{}, err
 			}
+			configEntryDrifted.DeleteLabelValues(configEntry.KubeKind(), configEntry.GetNamespace(), configEntry.GetName())
 			logger.Info("finalizer removed")
 		}
 
@@ -166,6 +225,23 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 		return ctrl.Result{}, nil
 	}
 
+	switch configEntry.GetObjectMeta().Annotations[common.ReconcileKey] {
+	case common.ReconcilePaused:
+		before := configEntry.DeepCopyObject()
+		configEntry.ClearDryRun()
+		return r.syncSuspended(ctx, logger, crdCtrl, configEntry, before, Paused,
+			fmt.Sprintf("changes are not synced to Consul because of the %s: %s annotation", common.ReconcileKey, common.ReconcilePaused))
+	case common.ReconcileDryRun:
+		return r.syncDryRun(ctx, logger, crdCtrl, consulClient, consulEntry, configEntry)
+	}
+	resumed := false
+	if status, _, _ := configEntry.ReconcileSuspendedCondition(); status == corev1.ConditionTrue {
+		logger.Info("reconcile annotation removed, syncing to consul")
+		configEntry.SetReconcileSuspendedCondition(corev1.ConditionFalse, "", "")
+		configEntry.ClearDryRun()
+		resumed = true
+	}
+
 	// Check to see if consul has config entry with the same name
 	entry, _, err := consulClient.ConfigEntries().Get(configEntry.ConsulKind(), configEntry.ConsulName(), &capi.QueryOptions{
 		Namespace: r.consulNamespace(consulEntry, configEntry.ConsulMirroringNS(), configEntry.ConsulGlobalResource()),
@@ -174,6 +250,14 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 	if isNotFoundErr(err) {
 		logger.Info("config entry not found in consul")
 
+		if r.drifted(configEntry) {
+			logger.Info("config entry was deleted from consul since it was last synced")
+			if r.DriftPolicy == DriftPolicyReport {
+				return r.syncDrifted(ctx, logger, crdCtrl, configEntry, "config entry was deleted from Consul")
+			}
+			r.driftCorrected(configEntry, "config entry was deleted from Consul")
+		}
+
 		// If Consul namespaces are enabled we may need to create the
 		// destination consul namespace first.
 		if r.EnableConsulNamespaces {
@@ -188,15 +272,22 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 			}
 		}
 
-		// Create the config entry
-		_, writeMeta, err := consulClient.ConfigEntries().Set(consulEntry, &capi.WriteOptions{
+		// Create the config entry. An index of 0 only creates it if it
+		// still doesn't exist.
+		written, writeMeta, err := consulClient.ConfigEntries().CAS(consulEntry, 0, &capi.WriteOptions{
 			Namespace: r.consulNamespace(consulEntry, configEntry.ConsulMirroringNS(), configEntry.ConsulGlobalResource()),
 		})
 		if err != nil {
+			discoveryChainRejected(configEntry, err)
 			return r.syncFailed(ctx, logger, crdCtrl, configEntry, ConsulAgentError,
 				fmt.Errorf("writing config entry to consul: %w", err))
 		}
+		if !written {
+			return concurrentModification(logger)
+		}
 		logger.Info("config entry created", "request-time", writeMeta.RequestTime)
+		r.recordModifyIndex(logger, consulClient, consulEntry, configEntry)
+		r.compileDiscoveryChain(logger, consulClient, consulEntry, configEntry)
 		return r.syncSuccessful(ctx, crdCtrl, configEntry)
 	}
 
@@ -208,11 +299,14 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 
 	requiresMigration := false
 	sourceDatacenter := entry.GetMeta()[common.DatacenterKey]
+	owner := r.otherOwner(entry, configEntry)
 
-	// Check if the config entry is managed by our datacenter.
+	// Check if the config entry is managed by our datacenter and this resource.
 	// Do not process resource if the entry was not created within our datacenter
 	// as it was created in a different cluster which will be managing that config entry.
-	if sourceDatacenter != r.DatacenterName {
+	// Likewise, another cluster in our datacenter or another resource in this
+	// cluster may be managing it.
+	if sourceDatacenter != r.DatacenterName || owner != "" {
 
 		// Note that there is a special case where we will migrate a config entry
 		// that wasn't created by the controller if it has the migrate-entry annotation set to true.
@@ -220,12 +314,19 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 		// chart versions where they had previously created config entries themselves but
 		// now want to manage them through custom resources.
 		if configEntry.GetObjectMeta().Annotations[common.MigrateEntryKey] != common.MigrateEntryTrue {
-			return r.syncFailed(ctx, logger, crdCtrl, configEntry, ExternallyManagedConfigError,
-				sourceDatacenterMismatchErr(sourceDatacenter))
+			if sourceDatacenter != r.DatacenterName {
+				return r.syncFailed(ctx, logger, crdCtrl, configEntry, ExternallyManagedConfigError,
+					sourceDatacenterMismatchErr(sourceDatacenter))
+			}
+			logger.Info("config entry is owned by another resource", "owner", owner)
+			return r.syncOwnershipConflict(ctx, crdCtrl, configEntry, fmt.Sprintf("config entry in Consul is managed by %s", owner))
 		}
 
 		requiresMigration = true
 	}
+	if status, _, _ := configEntry.OwnershipConflictCondition(); status == corev1.ConditionTrue {
+		configEntry.SetOwnershipConflictCondition(corev1.ConditionFalse, "", "")
+	}
 
 	if !configEntry.MatchesConsul(entry) {
 		if requiresMigration {
@@ -237,40 +338,104 @@ func (r *ConfigEntryController) ReconcileEntry(ctx context.Context, crdCtrl Cont
 		}
 
 		logger.Info("config entry does not match consul", "modify-index", entry.GetModifyIndex())
-		_, writeMeta, err := consulClient.ConfigEntries().Set(consulEntry, &capi.WriteOptions{
+		// Updates are checked against the index the config entry had when
+		// the resource was last synced so that changes made outside of
+		// Kubernetes are only overwritten when correcting drift or migrating.
+		index := writeIndex(entry, configEntry)
+		if r.drifted(configEntry) {
+			summary := driftSummary(consulEntry, entry)
+			logger.Info("config entry in consul has drifted since it was last synced", "diff", summary)
+			if r.DriftPolicy == DriftPolicyReport {
+				return r.syncDrifted(ctx, logger, crdCtrl, configEntry, summary)
+			}
+			r.driftCorrected(configEntry, summary)
+			index = entry.GetModifyIndex()
+		} else if modifiedOutside(entry, configEntry) {
+			if configEntry.GetObjectMeta().Annotations[common.MigrateEntryKey] != common.MigrateEntryTrue {
+				logger.Info("config entry in consul was modified outside of Kubernetes since it was last synced", "modify-index", entry.GetModifyIndex())
+				return r.syncOwnershipConflict(ctx, crdCtrl, configEntry,
+					fmt.Sprintf("config entry in Consul was modified outside of Kubernetes since it was last synced; delete it from Consul or set the %s annotation to overwrite it", common.MigrateEntryKey))
+			}
+			index = entry.GetModifyIndex()
+		}
+		written, writeMeta, err := consulClient.ConfigEntries().CAS(consulEntry, index, &capi.WriteOptions{
 			Namespace: r.consulNamespace(consulEntry, configEntry.ConsulMirroringNS(), configEntry.ConsulGlobalResource()),
 		})
 		if err != nil {
+			discoveryChainRejected(configEntry, err)
 			return r.syncUnknownWithError(ctx, logger, crdCtrl, configEntry, ConsulAgentError,
 				fmt.Errorf("updating config entry in consul: %w", err))
 		}
+		if !written {
+			return concurrentModification(logger)
+		}
 		logger.Info("config entry updated", "request-time", writeMeta.RequestTime)
+		r.recordModifyIndex(logger, consulClient, consulEntry, configEntry)
+		r.compileDiscoveryChain(logger, consulClient, consulEntry, configEntry)
 		return r.syncSuccessful(ctx, crdCtrl, configEntry)
-	} else if requiresMigration && entry.GetMeta()[common.DatacenterKey] != r.DatacenterName {
-		// If we get here then we're doing a migration and the entry in Consul
-		// matches the entry in Kubernetes. We just need to update the metadata
-		// of the entry in Consul to say that it's now managed by Kubernetes.
+	} else if requiresMigration || r.missingOwner(entry) {
+		// If we get here then we're doing a migration, or taking over an entry
+		// this resource synced before the cluster had a cluster ID, and the
+		// entry in Consul matches the entry in Kubernetes. We just need to
+		// update the metadata of the entry in Consul to say that it's now
+		// managed by Kubernetes, or by this cluster.
 		logger.Info("migrating config entry to be managed by Kubernetes")
-		_, writeMeta, err := consulClient.ConfigEntries().Set(consulEntry, &capi.WriteOptions{
+		written, writeMeta, err := consulClient.ConfigEntries().CAS(consulEntry, entry.GetModifyIndex(), &capi.WriteOptions{
 			Namespace: r.consulNamespace(consulEntry, configEntry.ConsulMirroringNS(), configEntry.ConsulGlobalResource()),
 		})
 		if err != nil {
+			discoveryChainRejected(configEntry, err)
 			return r.syncUnknownWithError(ctx, logger, crdCtrl, configEntry, ConsulAgentError,
 				fmt.Errorf("updating config entry in consul: %w", err))
 		}
+		if !written {
+			return concurrentModification(logger)
+		}
 		logger.Info("config entry migrated", "request-time", writeMeta.RequestTime)
+		r.recordModifyIndex(logger, consulClient, consulEntry, configEntry)
+		r.compileDiscoveryChain(logger, consulClient, consulEntry, configEntry)
 		return r.syncSuccessful(ctx, crdCtrl, configEntry)
 	} else if configEntry.SyncedConditionStatus() != corev1.ConditionTrue {
+		configEntry.SetSyncedModifyIndex(entry.GetModifyIndex())
+		r.compileDiscoveryChain(logger, consulClient, consulEntry, configEntry)
 		return r.syncSuccessful(ctx, crdCtrl, configEntry)
 	}
 
-	return ctrl.Result{}, nil
+	// The discovery chain can change without this resource changing, e.g.
+	// when a resolver referenced by a router is changed, so it's compiled
+	// again even though the config entry is in sync. The config entry
+	// matches the resource, so its index is recorded even if it was
+	// rewritten outside of Kubernetes.
+	before := configEntry.DeepCopyObject()
+	configEntry.SetSyncedModifyIndex(entry.GetModifyIndex())
+	r.compileDiscoveryChain(logger, consulClient, consulEntry, configEntry)
+	if r.ResyncPeriod > 0 {
+		configEntryDrifted.WithLabelValues(configEntry.KubeKind(), configEntry.GetNamespace(), configEntry.GetName()).Set(0)
+		if status, _, _ := configEntry.InSyncCondition(); status != corev1.ConditionTrue {
+			configEntry.SetInSyncCondition(corev1.ConditionTrue, "", "")
+		}
+	}
+	if resumed || !reflect.DeepEqual(before, configEntry) {
+		if err := crdCtrl.UpdateStatus(ctx, configEntry); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
 }
 
 // setupWithManager sets up the controller manager for the given resource
 // with our default options.
 func setupWithManager(mgr ctrl.Manager, resource client.Object, reconciler reconcile.Reconciler) error {
-	options := controller.Options{
+	return ctrl.NewControllerManagedBy(mgr).
+		For(resource).
+		WithOptions(controllerOptions()).
+		Complete(reconciler)
+}
+
+// controllerOptions returns the options used by all controllers for CRDs
+// that are synced to Consul.
+func controllerOptions() controller.Options {
+	return controller.Options{
 		// Taken from https://github.com/kubernetes/client-go/blob/master/util/workqueue/default_rate_limiters.go#L39
 		// and modified from a starting backoff of 5ms and max of 1000s to a
 		// starting backoff of 200ms and a max of 5s to better fit our most
@@ -291,11 +456,6 @@ func setupWithManager(mgr ctrl.Manager, resource client.Object, reconciler recon
 			&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
 		),
 	}
-
-	return ctrl.NewControllerManagedBy(mgr).
-		For(resource).
-		WithOptions(options).
-		Complete(reconciler)
 }
 
 func (r *ConfigEntryController) consulNamespace(configEntry capi.ConfigEntry, namespace string, globalResource bool) string {
@@ -336,7 +496,198 @@ func (r *ConfigEntryController) syncSuccessful(ctx context.Context, updater Cont
 	configEntry.SetSyncedCondition(corev1.ConditionTrue, "", "")
 	timeNow := metav1.NewTime(time.Now())
 	configEntry.SetLastSyncedTime(&timeNow)
-	return ctrl.Result{}, updater.UpdateStatus(ctx, configEntry)
+	configEntry.SetSyncedGeneration(configEntry.GetGeneration())
+	if r.ResyncPeriod > 0 {
+		configEntryDrifted.WithLabelValues(configEntry.KubeKind(), configEntry.GetNamespace(), configEntry.GetName()).Set(0)
+		// Keep the reason if the entry was just corrected.
+		if status, _, _ := configEntry.InSyncCondition(); status != corev1.ConditionTrue {
+			configEntry.SetInSyncCondition(corev1.ConditionTrue, "", "")
+		}
+	}
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, updater.UpdateStatus(ctx, configEntry)
+}
+
+// setOwner records this cluster and the resource as the owner of the config
+// entry if a cluster ID is set.
+func (r *ConfigEntryController) setOwner(consulEntry capi.ConfigEntry, configEntry common.ConfigEntryResource) {
+	if r.ClusterID == "" {
+		return
+	}
+	meta := consulEntry.GetMeta()
+	meta[common.ClusterIDKey] = r.ClusterID
+	meta[common.ResourceKey] = ownerResource(configEntry)
+}
+
+// otherOwner returns the cluster ID and resource of the owner of the config
+// entry if it isn't this resource, or an empty string otherwise. Config
+// entries written without a cluster ID may belong to any cluster, so they are
+// only taken over if this resource last synced them, i.e. the cluster ID was
+// just set, or by resources with the migrate-entry annotation.
+func (r *ConfigEntryController) otherOwner(entry capi.ConfigEntry, configEntry common.ConfigEntryResource) string {
+	if r.ClusterID == "" {
+		return ""
+	}
+	clusterID := entry.GetMeta()[common.ClusterIDKey]
+	resource := entry.GetMeta()[common.ResourceKey]
+	if clusterID == "" {
+		if index := configEntry.GetSyncedModifyIndex(); index != 0 && index == entry.GetModifyIndex() {
+			return ""
+		}
+		return unknownOwner
+	}
+	if clusterID == r.ClusterID && resource == ownerResource(configEntry) {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", clusterID, resource)
+}
+
+// unknownOwner is the owner reported for config entries written without a
+// cluster ID when this cluster has one.
+const unknownOwner = "a cluster without a cluster ID"
+
+// missingOwner returns true if the config entry should record this cluster
+// as its owner but was written without a cluster ID.
+func (r *ConfigEntryController) missingOwner(entry capi.ConfigEntry) bool {
+	return r.ClusterID != "" && entry.GetMeta()[common.ClusterIDKey] == ""
+}
+
+// ownerResource identifies the resource that owns a config entry.
+func ownerResource(configEntry common.ConfigEntryResource) string {
+	return fmt.Sprintf("%s/%s", configEntry.GetNamespace(), configEntry.GetName())
+}
+
+// syncOwnershipConflict reports that the config entry is owned by another
+// cluster or resource, or was modified outside of Kubernetes. The conflict is
+// not returned as an error so that the resource is checked again periodically
+// rather than retried with backoff.
+func (r *ConfigEntryController) syncOwnershipConflict(ctx context.Context, updater Controller, configEntry common.ConfigEntryResource, msg string) (ctrl.Result, error) {
+	configEntry.SetSyncedCondition(corev1.ConditionFalse, OwnershipConflict, msg)
+	configEntry.SetOwnershipConflictCondition(corev1.ConditionTrue, OwnershipConflict, msg)
+	if err := updater.UpdateStatus(ctx, configEntry); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: ownershipConflictRequeue}, nil
+}
+
+// writeIndex returns the modify index that updates and deletes of the config
+// entry are checked against. It's the index the config entry had when the
+// resource was last synced, or the index that was just read if the resource
+// hasn't recorded one, e.g. because it was synced by an older version.
+func writeIndex(entry capi.ConfigEntry, configEntry common.ConfigEntryResource) uint64 {
+	if index := configEntry.GetSyncedModifyIndex(); index != 0 {
+		return index
+	}
+	return entry.GetModifyIndex()
+}
+
+// modifiedOutside returns true if the config entry was written by something
+// other than the resource since the resource was last synced.
+func modifiedOutside(entry capi.ConfigEntry, configEntry common.ConfigEntryResource) bool {
+	index := configEntry.GetSyncedModifyIndex()
+	return index != 0 && index != entry.GetModifyIndex()
+}
+
+// recordModifyIndex records the modify index of the config entry the resource
+// just wrote. If it can't be read the index is cleared, so the next write is
+// checked against the index it reads instead.
+func (r *ConfigEntryController) recordModifyIndex(logger logr.Logger, consulClient *capi.Client, consulEntry capi.ConfigEntry, configEntry common.ConfigEntryResource) {
+	entry, _, err := consulClient.ConfigEntries().Get(configEntry.ConsulKind(), configEntry.ConsulName(), &capi.QueryOptions{
+		Namespace: r.consulNamespace(consulEntry, configEntry.ConsulMirroringNS(), configEntry.ConsulGlobalResource()),
+	})
+	if err != nil {
+		logger.Error(err, "failed to read modify index of config entry")
+		configEntry.SetSyncedModifyIndex(0)
+		return
+	}
+	configEntry.SetSyncedModifyIndex(entry.GetModifyIndex())
+}
+
+// concurrentModification requeues the resource when the config entry was
+// changed in Consul between reading and writing it, so that the change is
+// taken into account, e.g. when another cluster took ownership of it.
+func concurrentModification(logger logr.Logger) (ctrl.Result, error) {
+	logger.Info("config entry was modified in Consul concurrently, retrying")
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// drifted returns true if drift detection is enabled and the resource hasn't
+// changed since it was last synced, so any difference with Consul must come
+// from the config entry being changed in Consul directly.
+func (r *ConfigEntryController) drifted(configEntry common.ConfigEntryResource) bool {
+	return r.ResyncPeriod > 0 && configEntry.GetSyncedGeneration() == configEntry.GetGeneration()
+}
+
+// syncDrifted reports that the config entry in Consul has drifted from the
+// resource without correcting it. The resource is checked again after the
+// resync period.
+func (r *ConfigEntryController) syncDrifted(ctx context.Context, logger logr.Logger, updater Controller, configEntry common.ConfigEntryResource, summary string) (ctrl.Result, error) {
+	configEntryDrifted.WithLabelValues(configEntry.KubeKind(), configEntry.GetNamespace(), configEntry.GetName()).Set(1)
+	configEntry.SetSyncedCondition(corev1.ConditionFalse, Drifted, summary)
+	configEntry.SetInSyncCondition(corev1.ConditionFalse, Drifted, summary)
+	if err := updater.UpdateStatus(ctx, configEntry); err != nil {
+		logger.Error(err, "failed to report drift")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+}
+
+// driftCorrected records that the config entry in Consul is about to be
+// overwritten because it had drifted from the resource.
+func (r *ConfigEntryController) driftCorrected(configEntry common.ConfigEntryResource, summary string) {
+	configEntryDriftCorrections.WithLabelValues(configEntry.KubeKind()).Inc()
+	configEntry.SetInSyncCondition(corev1.ConditionTrue, DriftCorrected, summary)
+}
+
+// syncDryRun records the change that syncing the resource would make to the
+// config entry in Consul on the resource's status without making it.
+func (r *ConfigEntryController) syncDryRun(ctx context.Context, logger logr.Logger, updater Controller, consulClient *capi.Client, consulEntry capi.ConfigEntry, configEntry common.ConfigEntryResource) (ctrl.Result, error) {
+	before := configEntry.DeepCopyObject()
+	entry, _, err := consulClient.ConfigEntries().Get(configEntry.ConsulKind(), configEntry.ConsulName(), &capi.QueryOptions{
+		Namespace: r.consulNamespace(consulEntry, configEntry.ConsulMirroringNS(), configEntry.ConsulGlobalResource()),
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return r.syncFailed(ctx, logger, updater, configEntry, ConsulAgentError, err)
+	}
+
+	message := fmt.Sprintf("changes are not synced to Consul because of the %s: %s annotation", common.ReconcileKey, common.ReconcileDryRun)
+	operation := DryRunUpdate
+	if err != nil {
+		entry = nil
+		operation = DryRunCreate
+	} else if configEntry.MatchesConsul(entry) {
+		operation = DryRunNone
+	}
+	if entry != nil && configEntry.GetObjectMeta().Annotations[common.MigrateEntryKey] != common.MigrateEntryTrue {
+		if sourceDatacenter := entry.GetMeta()[common.DatacenterKey]; sourceDatacenter != r.DatacenterName {
+			message = fmt.Sprintf("%s; syncing would fail: %s", message, sourceDatacenterMismatchErr(sourceDatacenter))
+		} else if owner := r.otherOwner(entry, configEntry); owner != "" {
+			message = fmt.Sprintf("%s; syncing would fail: config entry in Consul is managed by %s", message, owner)
+		}
+	}
+
+	payload, err := dryRunPayload(consulEntry)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("marshalling config entry: %w", err)
+	}
+	configEntry.SetDryRun(configEntry.GetGeneration(), operation, string(payload), configEntryDiff(consulEntry, entry))
+	logger.Info("dry run", "operation", operation)
+	return r.syncSuspended(ctx, logger, updater, configEntry, before, DryRun, message)
+}
+
+// syncSuspended reports that changes to the resource are not written to
+// Consul. The status is only updated if it changed since before, so that
+// updating it doesn't trigger another reconcile.
+func (r *ConfigEntryController) syncSuspended(ctx context.Context, logger logr.Logger, updater Controller, configEntry common.ConfigEntryResource, before runtime.Object, reason, message string) (ctrl.Result, error) {
+	if status, curReason, curMessage := configEntry.ReconcileSuspendedCondition(); status != corev1.ConditionTrue || curReason != reason || curMessage != message {
+		configEntry.SetReconcileSuspendedCondition(corev1.ConditionTrue, reason, message)
+	}
+	if !reflect.DeepEqual(before, configEntry) {
+		logger.Info("reconcile suspended", "reason", reason)
+		if err := updater.UpdateStatus(ctx, configEntry); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
 }
 
 func (r *ConfigEntryController) syncUnknown(ctx context.Context, updater Controller, configEntry common.ConfigEntryResource) error {
@@ -378,6 +729,200 @@ func (r *ConfigEntryController) nonMatchingMigrationError(kubeEntry common.Confi
 	return fmt.Errorf("migration failed: Kubernetes resource does not match existing Consul config entry: consul=%s, kube=%s", consulJSON, kubeJSON)
 }
 
+// compileDiscoveryChain records the discovery chain Consul compiles for the
+// service on the status of resources that are part of a discovery chain.
+func (r *ConfigEntryController) compileDiscoveryChain(logger logr.Logger, consulClient *capi.Client, consulEntry capi.ConfigEntry, configEntry common.ConfigEntryResource) {
+	chainEntry, ok := configEntry.(common.DiscoveryChainResource)
+	if !ok {
+		return
+	}
+	resp, _, err := consulClient.DiscoveryChain().Get(configEntry.ConsulName(), nil, &capi.QueryOptions{
+		Namespace: r.consulNamespace(consulEntry, configEntry.ConsulMirroringNS(), configEntry.ConsulGlobalResource()),
+	})
+	if err != nil {
+		logger.Error(err, "failed to compile discovery chain")
+		chainEntry.SetDiscoveryChainCondition(corev1.ConditionFalse, discoveryChainErrorReason(err), err.Error())
+		return
+	}
+	chainEntry.SetDiscoveryChain(resp.Chain)
+	chainEntry.SetDiscoveryChainCondition(corev1.ConditionTrue, "", "")
+}
+
+// discoveryChainRejected records on the status of resources that are part
+// of a discovery chain that Consul rejected the config entry. The previously
+// compiled chain is kept since it is still the one in use.
+func discoveryChainRejected(configEntry common.ConfigEntryResource, err error) {
+	chainEntry, ok := configEntry.(common.DiscoveryChainResource)
+	if !ok {
+		return
+	}
+	// Consul responds with a 500 when the config entry fails validation.
+	// Other errors, e.g. connection errors, say nothing about the chain.
+	if !strings.Contains(err.Error(), "Unexpected response code: 500") {
+		return
+	}
+	chainEntry.SetDiscoveryChainCondition(corev1.ConditionFalse, discoveryChainErrorReason(err), err.Error())
+}
+
+// discoveryChainErrorReason classifies the error Consul returns when it
+// can't compile a discovery chain.
+func discoveryChainErrorReason(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "does not have a subset named"):
+		return SubsetNotFound
+	case strings.Contains(msg, "does not permit advanced routing or splitting behavior"),
+		strings.Contains(msg, "protocol"):
+		return ProtocolMismatch
+	case strings.Contains(msg, "circular"):
+		return CircularReference
+	default:
+		return DiscoveryChainCompileError
+	}
+}
+
+// driftSummary describes the top-level fields of the config entry in Consul
+// that differ from the config entry generated from the resource.
+func driftSummary(want, got capi.ConfigEntry) string {
+	wantFields, err := configEntryFields(want)
+	if err != nil {
+		return fmt.Sprintf("config entry in Consul does not match the resource: %s", err)
+	}
+	gotFields, err := configEntryFields(got)
+	if err != nil {
+		return fmt.Sprintf("config entry in Consul does not match the resource: %s", err)
+	}
+	var fields []string
+	for k, v := range wantFields {
+		if !reflect.DeepEqual(v, gotFields[k]) {
+			fields = append(fields, k)
+		}
+	}
+	for k := range gotFields {
+		if _, ok := wantFields[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	if len(fields) == 0 {
+		return "config entry in Consul does not match the resource"
+	}
+	sort.Strings(fields)
+	return fmt.Sprintf("config entry in Consul does not match the resource: fields %s differ", strings.Join(fields, ", "))
+}
+
+// configEntryDiff describes the top-level fields of the config entry in
+// Consul that writing want would change, with their current and new values.
+// got is nil if the config entry doesn't exist in Consul.
+func configEntryDiff(want, got capi.ConfigEntry) []string {
+	wantFields, err := configEntryFields(want)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	gotFields := map[string]interface{}{}
+	if got != nil {
+		if gotFields, err = configEntryFields(got); err != nil {
+			return []string{err.Error()}
+		}
+	}
+	var diff []string
+	for k, v := range wantFields {
+		if !reflect.DeepEqual(v, gotFields[k]) {
+			diff = append(diff, fieldDiff(k, redactField(want.GetKind(), k, gotFields[k]), redactField(want.GetKind(), k, v)))
+		}
+	}
+	for k, v := range gotFields {
+		if _, ok := wantFields[k]; !ok {
+			diff = append(diff, fieldDiff(k, redactField(want.GetKind(), k, v), nil))
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// fieldDiff formats the change of a field from one value to another.
+func fieldDiff(field string, from, to interface{}) string {
+	fromJSON, _ := json.Marshal(from)
+	toJSON, _ := json.Marshal(to)
+	return fmt.Sprintf("%s: %s -> %s", field, fromJSON, toJSON)
+}
+
+// redacted replaces the value of secret fields in a dry run.
+const redacted = "[redacted]"
+
+// secretFields are the top-level fields of config entries, by kind, that
+// hold secrets and so are redacted from dry runs.
+var secretFields = map[string][]string{
+	capi.InlineCertificate: {"PrivateKey"},
+}
+
+// dryRunPayload returns the JSON of the config entry recorded on the
+// resource's status by a dry run, with its secret fields redacted.
+func dryRunPayload(entry capi.ConfigEntry) ([]byte, error) {
+	if len(secretFields[entry.GetKind()]) == 0 {
+		return json.Marshal(entry)
+	}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range fields {
+		fields[k] = redactField(entry.GetKind(), k, v)
+	}
+	return json.Marshal(fields)
+}
+
+// redactField returns value with secrets replaced so that they aren't
+// written to the resource's status. Values that aren't set are left as is
+// so that the diff still shows a secret being added or removed.
+func redactField(kind, field string, value interface{}) interface{} {
+	if value == nil || value == "" {
+		return value
+	}
+	for _, secret := range secretFields[kind] {
+		if field == secret {
+			return redacted
+		}
+	}
+	return value
+}
+
+// configEntryFields returns the top-level fields of entry that are set,
+// leaving out the fields Consul manages itself.
+func configEntryFields(entry capi.ConfigEntry) (map[string]interface{}, error) {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for _, k := range []string{"Meta", "CreateIndex", "ModifyIndex", "Namespace", "Partition"} {
+		delete(fields, k)
+	}
+	for k, v := range fields {
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			delete(fields, k)
+			continue
+		}
+		switch value := v.(type) {
+		case []interface{}:
+			if len(value) == 0 {
+				delete(fields, k)
+			}
+		case map[string]interface{}:
+			if len(value) == 0 {
+				delete(fields, k)
+			}
+		}
+	}
+	return fields, nil
+}
+
 func isNotFoundErr(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "404")
 }
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -1484,8 +1485,644 @@ func TestConfigEntryControllers_setsSyncedToTrue(t *testing.T) {
 	req.Equal(corev1.ConditionTrue, svcDefaults.SyncedConditionStatus())
 }
 
+// Test that a config entry changed in Consul directly is either corrected or
+// only reported depending on the drift policy.
+func TestConfigEntryControllers_driftDetection(t *testing.T) {
+	t.Parallel()
+	kubeNS := "default"
+
+	cases := map[string]struct {
+		policy          string
+		expProtocol     string
+		expSynced       corev1.ConditionStatus
+		expInSync       corev1.ConditionStatus
+		expInSyncReason string
+		expInSyncMsg    string
+	}{
+		"correct": {
+			policy:          DriftPolicyCorrect,
+			expProtocol:     "http",
+			expSynced:       corev1.ConditionTrue,
+			expInSync:       corev1.ConditionTrue,
+			expInSyncReason: DriftCorrected,
+			expInSyncMsg:    "config entry in Consul does not match the resource: fields Protocol differ",
+		},
+		"report": {
+			policy:          DriftPolicyReport,
+			expProtocol:     "tcp",
+			expSynced:       corev1.ConditionFalse,
+			expInSync:       corev1.ConditionFalse,
+			expInSyncReason: Drifted,
+			expInSyncMsg:    "config entry in Consul does not match the resource: fields Protocol differ",
+		},
+	}
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := runtime.NewScheme()
+			svcDefaults := &v1alpha1.ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "foo",
+					Namespace:  kubeNS,
+					Generation: 1,
+				},
+				Spec: v1alpha1.ServiceDefaultsSpec{
+					Protocol: "http",
+				},
+			}
+			s.AddKnownTypes(v1alpha1.GroupVersion, svcDefaults)
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(svcDefaults).Build()
+
+			testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+			testClient.TestServer.WaitForServiceIntentions(t)
+			consulClient := testClient.APIClient
+			reconciler := &ServiceDefaultsController{
+				Client: fakeClient,
+				Log:    logrtest.TestLogger{T: t},
+				ConfigEntryController: &ConfigEntryController{
+					ConsulClientConfig:  testClient.Cfg,
+					ConsulServerConnMgr: testClient.Watcher,
+					DatacenterName:      datacenterName,
+					ResyncPeriod:        time.Minute,
+					DriftPolicy:         c.policy,
+				},
+			}
+			namespacedName := types.NamespacedName{
+				Namespace: kubeNS,
+				Name:      svcDefaults.KubernetesName(),
+			}
+
+			// The first reconcile creates the config entry.
+			resp, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			require.NoError(t, err)
+			require.Equal(t, time.Minute, resp.RequeueAfter)
+			require.NoError(t, fakeClient.Get(ctx, namespacedName, svcDefaults))
+			require.Equal(t, int64(1), svcDefaults.GetSyncedGeneration())
+			status, _, _ := svcDefaults.InSyncCondition()
+			require.Equal(t, corev1.ConditionTrue, status)
+
+			// Change the config entry in Consul directly.
+			drifted := svcDefaults.ToConsul(datacenterName).(*capi.ServiceConfigEntry)
+			drifted.Protocol = "tcp"
+			_, _, err = consulClient.ConfigEntries().Set(drifted, nil)
+			require.NoError(t, err)
+
+			resp, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			require.NoError(t, err)
+			require.Equal(t, time.Minute, resp.RequeueAfter)
+
+			entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceDefaults, svcDefaults.ConsulName(), nil)
+			require.NoError(t, err)
+			require.Equal(t, c.expProtocol, entry.(*capi.ServiceConfigEntry).Protocol)
+
+			require.NoError(t, fakeClient.Get(ctx, namespacedName, svcDefaults))
+			require.Equal(t, c.expSynced, svcDefaults.SyncedConditionStatus())
+			status, reason, message := svcDefaults.InSyncCondition()
+			require.Equal(t, c.expInSync, status)
+			require.Equal(t, c.expInSyncReason, reason)
+			require.Equal(t, c.expInSyncMsg, message)
+		})
+	}
+}
+
+// Test that the discovery chain Consul compiles is published on the status
+// of routers, and that chains Consul rejects are reported.
+func TestConfigEntryControllers_discoveryChainStatus(t *testing.T) {
+	t.Parallel()
+	kubeNS := "default"
+	ctx := context.Background()
+
+	router := &v1alpha1.ServiceRouter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: kubeNS,
+		},
+		Spec: v1alpha1.ServiceRouterSpec{
+			Routes: []v1alpha1.ServiceRoute{
+				{
+					Match: &v1alpha1.ServiceRouteMatch{
+						HTTP: &v1alpha1.ServiceRouteHTTPMatch{PathPrefix: "/v2"},
+					},
+					Destination: &v1alpha1.ServiceRouteDestination{ServiceSubset: "v2"},
+				},
+			},
+		},
+	}
+	s := runtime.NewScheme()
+	s.AddKnownTypes(v1alpha1.GroupVersion, router)
+	fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(router).Build()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForServiceIntentions(t)
+	consulClient := testClient.APIClient
+	for _, entry := range []capi.ConfigEntry{
+		&capi.ProxyConfigEntry{
+			Kind:   capi.ProxyDefaults,
+			Name:   capi.ProxyConfigGlobal,
+			Config: map[string]interface{}{"protocol": "http"},
+		},
+		&capi.ServiceResolverConfigEntry{
+			Kind: capi.ServiceResolver,
+			Name: "foo",
+			Subsets: map[string]capi.ServiceResolverSubset{
+				"v1": {Filter: "Service.Meta.version == v1"},
+			},
+		},
+	} {
+		_, _, err := consulClient.ConfigEntries().Set(entry, nil)
+		require.NoError(t, err)
+	}
+
+	reconciler := &ServiceRouterController{
+		Client: fakeClient,
+		Log:    logrtest.TestLogger{T: t},
+		ConfigEntryController: &ConfigEntryController{
+			ConsulClientConfig:  testClient.Cfg,
+			ConsulServerConnMgr: testClient.Watcher,
+			DatacenterName:      datacenterName,
+		},
+	}
+	namespacedName := types.NamespacedName{
+		Namespace: kubeNS,
+		Name:      router.KubernetesName(),
+	}
+
+	// The v2 subset doesn't exist so Consul rejects the router.
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.Error(t, err)
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, router))
+	status, reason, message := router.DiscoveryChainCondition()
+	require.Equal(t, corev1.ConditionFalse, status)
+	require.Equal(t, SubsetNotFound, reason)
+	require.Contains(t, message, `does not have a subset named "v2"`)
+	require.Nil(t, router.DiscoveryChain)
+
+	// Once the route uses an existing subset the chain is compiled.
+	router.Spec.Routes[0].Destination.ServiceSubset = "v1"
+	require.NoError(t, fakeClient.Update(ctx, router))
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, router))
+	status, reason, message = router.DiscoveryChainCondition()
+	require.Equal(t, corev1.ConditionTrue, status)
+	require.Empty(t, reason)
+	require.Empty(t, message)
+	require.Equal(t, corev1.ConditionTrue, router.SyncedConditionStatus())
+
+	chain := router.DiscoveryChain
+	require.NotNil(t, chain)
+	require.Equal(t, "http", chain.Protocol)
+	require.Equal(t, "router:foo.default.default", chain.StartNode)
+	var targets []string
+	for _, target := range chain.Targets {
+		targets = append(targets, target.ID)
+	}
+	require.Contains(t, targets, "v1.foo.default.default.dc1")
+	require.Contains(t, targets, "foo.default.default.dc1")
+}
+
+func TestDiscoveryChainErrorReason(t *testing.T) {
+	cases := map[string]string{
+		`Unexpected response code: 500 (service "foo" does not have a subset named "v2")`:                                                                SubsetNotFound,
+		`Unexpected response code: 500 (discovery chain "foo" uses a protocol "tcp" that does not permit advanced routing or splitting behavior)`:        ProtocolMismatch,
+		`Unexpected response code: 500 (detected circular resolver redirect: [foo.default.default.dc1 bar.default.default.dc1 foo.default.default.dc1])`: CircularReference,
+		`Unexpected response code: 500 (something else)`:                                                                                                 DiscoveryChainCompileError,
+	}
+	for msg, reason := range cases {
+		require.Equal(t, reason, discoveryChainErrorReason(errors.New(msg)), msg)
+	}
+}
+
+// Test that clusters with a cluster ID don't overwrite config entries owned
+// by other clusters or resources in the same datacenter.
+func TestConfigEntryControllers_ownership(t *testing.T) {
+	t.Parallel()
+	kubeNS := "default"
+
+	cases := map[string]struct {
+		consulMeta  map[string]string
+		consulProto string
+		annotations map[string]string
+		// syncedIndex records the index of the config entry in the status of
+		// the resource, as if it synced it before the cluster ID was set.
+		syncedIndex bool
+		// modifiedAfterSync changes the protocol of the config entry to grpc
+		// after its index is recorded.
+		modifiedAfterSync bool
+		expConflict       string
+		expProtocol       string
+		expClusterID      string
+		expResourceID     string
+	}{
+		"owned by another cluster": {
+			consulMeta: map[string]string{
+				common.ClusterIDKey: "blue",
+				common.ResourceKey:  "default/foo",
+			},
+			consulProto:   "tcp",
+			expConflict:   "config entry in Consul is managed by blue/default/foo",
+			expProtocol:   "tcp",
+			expClusterID:  "blue",
+			expResourceID: "default/foo",
+		},
+		"owned by another resource in this cluster": {
+			consulMeta: map[string]string{
+				common.ClusterIDKey: "green",
+				common.ResourceKey:  "other/foo",
+			},
+			consulProto:   "tcp",
+			expConflict:   "config entry in Consul is managed by green/other/foo",
+			expProtocol:   "tcp",
+			expClusterID:  "green",
+			expResourceID: "other/foo",
+		},
+		"owned by this resource": {
+			consulMeta: map[string]string{
+				common.ClusterIDKey: "green",
+				common.ResourceKey:  "default/foo",
+			},
+			consulProto:   "tcp",
+			expProtocol:   "http",
+			expClusterID:  "green",
+			expResourceID: "default/foo",
+		},
+		"written without a cluster ID": {
+			consulMeta:  map[string]string{},
+			consulProto: "tcp",
+			expConflict: "config entry in Consul is managed by a cluster without a cluster ID",
+			expProtocol: "tcp",
+		},
+		"synced by this resource before the cluster ID was set": {
+			consulMeta:    map[string]string{},
+			consulProto:   "tcp",
+			syncedIndex:   true,
+			expProtocol:   "http",
+			expClusterID:  "green",
+			expResourceID: "default/foo",
+		},
+		"synced by this resource before the cluster ID was set and in sync": {
+			consulMeta:    map[string]string{},
+			consulProto:   "http",
+			syncedIndex:   true,
+			expProtocol:   "http",
+			expClusterID:  "green",
+			expResourceID: "default/foo",
+		},
+		"synced by this resource before the cluster ID was set and written since": {
+			consulMeta:        map[string]string{},
+			consulProto:       "tcp",
+			syncedIndex:       true,
+			modifiedAfterSync: true,
+			expConflict:       "config entry in Consul is managed by a cluster without a cluster ID",
+			expProtocol:       "grpc",
+		},
+		"migrated from a cluster without a cluster ID": {
+			consulMeta:    map[string]string{},
+			consulProto:   "http",
+			annotations:   map[string]string{common.MigrateEntryKey: common.MigrateEntryTrue},
+			expProtocol:   "http",
+			expClusterID:  "green",
+			expResourceID: "default/foo",
+		},
+		"migrated from another cluster": {
+			consulMeta: map[string]string{
+				common.ClusterIDKey: "blue",
+				common.ResourceKey:  "default/foo",
+			},
+			consulProto:   "http",
+			annotations:   map[string]string{common.MigrateEntryKey: common.MigrateEntryTrue},
+			expProtocol:   "http",
+			expClusterID:  "green",
+			expResourceID: "default/foo",
+		},
+	}
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			svcDefaults := &v1alpha1.ServiceDefaults{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "foo",
+					Namespace:   kubeNS,
+					Annotations: c.annotations,
+				},
+				Spec: v1alpha1.ServiceDefaultsSpec{
+					Protocol: "http",
+				},
+			}
+			s := runtime.NewScheme()
+			s.AddKnownTypes(v1alpha1.GroupVersion, svcDefaults)
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(svcDefaults).Build()
+
+			testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+			testClient.TestServer.WaitForServiceIntentions(t)
+			consulClient := testClient.APIClient
+
+			existing := &capi.ServiceConfigEntry{
+				Kind:     capi.ServiceDefaults,
+				Name:     "foo",
+				Protocol: c.consulProto,
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: datacenterName,
+				},
+			}
+			for k, v := range c.consulMeta {
+				existing.Meta[k] = v
+			}
+			_, _, err := consulClient.ConfigEntries().Set(existing, nil)
+			require.NoError(t, err)
+			if c.syncedIndex {
+				entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceDefaults, "foo", nil)
+				require.NoError(t, err)
+				svcDefaults.Status.SyncedModifyIndex = entry.GetModifyIndex()
+				require.NoError(t, fakeClient.Update(ctx, svcDefaults))
+			}
+			if c.modifiedAfterSync {
+				existing.Protocol = "grpc"
+				_, _, err := consulClient.ConfigEntries().Set(existing, nil)
+				require.NoError(t, err)
+			}
+
+			reconciler := &ServiceDefaultsController{
+				Client: fakeClient,
+				Log:    logrtest.TestLogger{T: t},
+				ConfigEntryController: &ConfigEntryController{
+					ConsulClientConfig:  testClient.Cfg,
+					ConsulServerConnMgr: testClient.Watcher,
+					DatacenterName:      datacenterName,
+					ClusterID:           "green",
+				},
+			}
+			namespacedName := types.NamespacedName{
+				Namespace: kubeNS,
+				Name:      svcDefaults.KubernetesName(),
+			}
+			resp, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			require.NoError(t, err)
+
+			entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceDefaults, "foo", nil)
+			require.NoError(t, err)
+			require.Equal(t, c.expProtocol, entry.(*capi.ServiceConfigEntry).Protocol)
+			require.Equal(t, c.expClusterID, entry.GetMeta()[common.ClusterIDKey])
+			require.Equal(t, c.expResourceID, entry.GetMeta()[common.ResourceKey])
+
+			require.NoError(t, fakeClient.Get(ctx, namespacedName, svcDefaults))
+			status, reason, message := svcDefaults.OwnershipConflictCondition()
+			if c.expConflict != "" {
+				require.Equal(t, time.Minute, resp.RequeueAfter)
+				require.Equal(t, corev1.ConditionTrue, status)
+				require.Equal(t, OwnershipConflict, reason)
+				require.Equal(t, c.expConflict, message)
+				require.Equal(t, corev1.ConditionFalse, svcDefaults.SyncedConditionStatus())
+
+				// The resource must not delete a config entry it doesn't own.
+				svcDefaults.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				require.NoError(t, fakeClient.Update(ctx, svcDefaults))
+				_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+				require.NoError(t, err)
+				_, _, err = consulClient.ConfigEntries().Get(capi.ServiceDefaults, "foo", nil)
+				require.NoError(t, err)
+			} else {
+				require.NotEqual(t, corev1.ConditionTrue, status)
+				require.Equal(t, corev1.ConditionTrue, svcDefaults.SyncedConditionStatus())
+			}
+		})
+	}
+}
+
+// Test that config entries changed in Consul since they were last synced are
+// not overwritten or deleted unless the resource has the migrate-entry
+// annotation.
+func TestConfigEntryControllers_checksSyncedModifyIndex(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svcDefaults := &v1alpha1.ServiceDefaults{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ServiceDefaultsSpec{
+			Protocol: "http",
+		},
+	}
+	s := runtime.NewScheme()
+	s.AddKnownTypes(v1alpha1.GroupVersion, svcDefaults)
+	fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(svcDefaults).Build()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForServiceIntentions(t)
+	consulClient := testClient.APIClient
+
+	reconciler := &ServiceDefaultsController{
+		Client: fakeClient,
+		Log:    logrtest.TestLogger{T: t},
+		ConfigEntryController: &ConfigEntryController{
+			ConsulClientConfig:  testClient.Cfg,
+			ConsulServerConnMgr: testClient.Watcher,
+			DatacenterName:      datacenterName,
+		},
+	}
+	namespacedName := types.NamespacedName{Namespace: "default", Name: "foo"}
+	reconcile := func() ctrl.Result {
+		resp, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+		require.NoError(t, err)
+		require.NoError(t, fakeClient.Get(ctx, namespacedName, svcDefaults))
+		return resp
+	}
+	modify := func(protocol string) {
+		entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceDefaults, "foo", nil)
+		require.NoError(t, err)
+		entry.(*capi.ServiceConfigEntry).Protocol = protocol
+		_, _, err = consulClient.ConfigEntries().Set(entry, nil)
+		require.NoError(t, err)
+	}
+	requireProtocol := func(protocol string) capi.ConfigEntry {
+		entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceDefaults, "foo", nil)
+		require.NoError(t, err)
+		require.Equal(t, protocol, entry.(*capi.ServiceConfigEntry).Protocol)
+		return entry
+	}
+
+	reconcile()
+	entry := requireProtocol("http")
+	require.Equal(t, entry.GetModifyIndex(), svcDefaults.Status.SyncedModifyIndex)
+
+	// An update after the config entry was changed in Consul is reported as
+	// a conflict.
+	modify("tcp")
+	svcDefaults.Spec.Protocol = "grpc"
+	require.NoError(t, fakeClient.Update(ctx, svcDefaults))
+	resp := reconcile()
+	require.Equal(t, time.Minute, resp.RequeueAfter)
+	requireProtocol("tcp")
+	status, reason, message := svcDefaults.OwnershipConflictCondition()
+	require.Equal(t, corev1.ConditionTrue, status)
+	require.Equal(t, OwnershipConflict, reason)
+	require.Equal(t, "config entry in Consul was modified outside of Kubernetes since it was last synced; delete it from Consul or set the consul.hashicorp.com/migrate-entry annotation to overwrite it", message)
+	require.Equal(t, corev1.ConditionFalse, svcDefaults.SyncedConditionStatus())
+
+	// The migrate-entry annotation overwrites it.
+	svcDefaults.Annotations = map[string]string{common.MigrateEntryKey: common.MigrateEntryTrue}
+	require.NoError(t, fakeClient.Update(ctx, svcDefaults))
+	reconcile()
+	entry = requireProtocol("grpc")
+	require.Equal(t, entry.GetModifyIndex(), svcDefaults.Status.SyncedModifyIndex)
+	require.Equal(t, corev1.ConditionTrue, svcDefaults.SyncedConditionStatus())
+	status, _, _ = svcDefaults.OwnershipConflictCondition()
+	require.Equal(t, corev1.ConditionFalse, status)
+
+	// The config entry isn't deleted if it was changed in Consul.
+	modify("tcp")
+	svcDefaults.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	require.NoError(t, fakeClient.Update(ctx, svcDefaults))
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	requireProtocol("tcp")
+}
+
 // Test that if the config entry exists in Consul but is not managed by the
 // controller, creating/updating the resource fails.
+func TestConfigEntryControllers_reconcileAnnotation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	kubeNS := "default"
+	svcDefaults := &v1alpha1.ServiceDefaults{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   kubeNS,
+			Annotations: map[string]string{common.ReconcileKey: common.ReconcilePaused},
+		},
+		Spec: v1alpha1.ServiceDefaultsSpec{
+			Protocol: "http",
+		},
+	}
+	s := runtime.NewScheme()
+	s.AddKnownTypes(v1alpha1.GroupVersion, svcDefaults)
+	fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(svcDefaults).Build()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForServiceIntentions(t)
+	consulClient := testClient.APIClient
+
+	reconciler := &ServiceDefaultsController{
+		Client: fakeClient,
+		Log:    logrtest.TestLogger{T: t},
+		ConfigEntryController: &ConfigEntryController{
+			ConsulClientConfig:  testClient.Cfg,
+			ConsulServerConnMgr: testClient.Watcher,
+			DatacenterName:      datacenterName,
+		},
+	}
+	namespacedName := types.NamespacedName{Namespace: kubeNS, Name: svcDefaults.KubernetesName()}
+	reconcile := func() {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+		require.NoError(t, err)
+		require.NoError(t, fakeClient.Get(ctx, namespacedName, svcDefaults))
+	}
+	setAnnotation := func(value string) {
+		if value == "" {
+			svcDefaults.Annotations = nil
+		} else {
+			svcDefaults.Annotations = map[string]string{common.ReconcileKey: value}
+		}
+		require.NoError(t, fakeClient.Update(ctx, svcDefaults))
+	}
+	requireProtocol := func(protocol string) {
+		entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceDefaults, "foo", nil)
+		if protocol == "" {
+			require.True(t, isNotFoundErr(err))
+			return
+		}
+		require.NoError(t, err)
+		require.Equal(t, protocol, entry.(*capi.ServiceConfigEntry).Protocol)
+	}
+
+	// Paused resources are not written to Consul.
+	reconcile()
+	requireProtocol("")
+	status, reason, _ := svcDefaults.ReconcileSuspendedCondition()
+	require.Equal(t, corev1.ConditionTrue, status)
+	require.Equal(t, Paused, reason)
+	require.Nil(t, svcDefaults.Status.DryRun)
+
+	// A dry run records the config entry that would be created.
+	setAnnotation(common.ReconcileDryRun)
+	reconcile()
+	requireProtocol("")
+	status, reason, _ = svcDefaults.ReconcileSuspendedCondition()
+	require.Equal(t, corev1.ConditionTrue, status)
+	require.Equal(t, DryRun, reason)
+	require.NotNil(t, svcDefaults.Status.DryRun)
+	require.Equal(t, DryRunCreate, svcDefaults.Status.DryRun.Operation)
+	require.Contains(t, svcDefaults.Status.DryRun.ConfigEntry, `"Protocol":"http"`)
+	require.Contains(t, svcDefaults.Status.DryRun.Diff, `Protocol: null -> "http"`)
+
+	// The status isn't updated again if nothing changed.
+	resourceVersion := svcDefaults.ResourceVersion
+	reconcile()
+	require.Equal(t, resourceVersion, svcDefaults.ResourceVersion)
+
+	// Removing the annotation syncs the resource.
+	setAnnotation("")
+	reconcile()
+	requireProtocol("http")
+	status, _, _ = svcDefaults.ReconcileSuspendedCondition()
+	require.Equal(t, corev1.ConditionFalse, status)
+	require.Nil(t, svcDefaults.Status.DryRun)
+	require.Equal(t, corev1.ConditionTrue, svcDefaults.SyncedConditionStatus())
+
+	// A dry run of a change records the fields that would change.
+	svcDefaults.Spec.Protocol = "grpc"
+	setAnnotation(common.ReconcileDryRun)
+	reconcile()
+	requireProtocol("http")
+	require.Equal(t, DryRunUpdate, svcDefaults.Status.DryRun.Operation)
+	require.Equal(t, []string{`Protocol: "http" -> "grpc"`}, svcDefaults.Status.DryRun.Diff)
+
+	// Deleting a paused resource still deletes the config entry.
+	setAnnotation(common.ReconcilePaused)
+	svcDefaults.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	require.NoError(t, fakeClient.Update(ctx, svcDefaults))
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	requireProtocol("")
+}
+
+func TestConfigEntryControllers_dryRunRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	cert := &v1alpha1.InlineCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "default"},
+		Spec: v1alpha1.InlineCertificateSpec{
+			Certificate: "certificate",
+			PrivateKey:  "private-key",
+		},
+	}
+	want := cert.ToConsul(datacenterName)
+
+	payload, err := dryRunPayload(want)
+	require.NoError(t, err)
+	require.Contains(t, string(payload), `"Certificate":"certificate"`)
+	require.Contains(t, string(payload), `"PrivateKey":"[redacted]"`)
+	require.NotContains(t, string(payload), "private-key")
+
+	// A new private key is reported as changed without showing either key.
+	require.Equal(t, []string{`PrivateKey: null -> "[redacted]"`}, configEntryDiff(want, &capi.InlineCertificateConfigEntry{
+		Kind:        capi.InlineCertificate,
+		Name:        "cert",
+		Certificate: "certificate",
+	}))
+	require.Equal(t, []string{`PrivateKey: "[redacted]" -> "[redacted]"`}, configEntryDiff(want, &capi.InlineCertificateConfigEntry{
+		Kind:        capi.InlineCertificate,
+		Name:        "cert",
+		Certificate: "certificate",
+		PrivateKey:  "old-private-key",
+	}))
+}
+
 func TestConfigEntryControllers_doesNotCreateUnownedConfigEntry(t *testing.T) {
 	t.Parallel()
 	kubeNS := "default"
@@ -0,0 +1,494 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// KVConflictError is the reason used when keys were modified in Consul
+	// outside of the ConsulKV resource that owns them, or already existed
+	// before the resource created them.
+	KVConflictError = "KVConflictError"
+	// KVSourceError is the reason used when a ConfigMap or Secret referenced
+	// by a ConsulKV resource can't be read.
+	KVSourceError = "KVSourceError"
+)
+
+// ConsulKVController reconciles ConsulKV resources with the Consul KV store.
+//
+// The keys created by a resource are recorded in its status along with the
+// modify index of the controller's last write. All writes and deletes use
+// check-and-set against that index so that keys that were created or changed
+// by something else are never overwritten or pruned. Instead the conflict is
+// reported in the Synced condition until it is resolved, e.g. by deleting the
+// key from Consul.
+//
+// Keys are also tagged with flags that identify the resource and the cluster
+// it's in, so that a resource that is recreated takes back the keys that are
+// missing from its status rather than reporting them as conflicts.
+type ConsulKVController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// APIReader reads ConfigMaps and Secrets directly from the API server.
+	// They are only watched as metadata so that the controller doesn't cache
+	// the contents of every ConfigMap and Secret in the cluster.
+	APIReader client.Reader
+
+	// ConsulClientConfig is the config for the Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+
+	// EnableConsulNamespaces indicates that a user is running Consul Enterprise
+	// with version 1.7+ which supports namespaces.
+	EnableConsulNamespaces bool
+	// ConsulDestinationNamespace is the namespace keys are written to if
+	// mirroring is disabled.
+	ConsulDestinationNamespace string
+	// EnableNSMirroring causes Consul namespaces to be created to match the
+	// k8s namespace of the ConsulKV resource.
+	EnableNSMirroring bool
+	// NSMirroringPrefix is an optional prefix that can be added to the Consul
+	// namespaces created while mirroring.
+	NSMirroringPrefix string
+	// CrossNSACLPolicy is the name of the ACL policy to attach to
+	// any created Consul namespaces to allow cross namespace service discovery.
+	CrossNSACLPolicy string
+
+	// DatacenterName and ClusterID identify the cluster the controller runs
+	// in so that resources with the same name in other clusters don't take
+	// each other's keys.
+	DatacenterName string
+	ClusterID      string
+
+	// ResyncPeriod is how often resources with conflicting keys are
+	// re-checked. They are only re-checked when the resource or its sources
+	// change if it is zero.
+	ResyncPeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=consulkvs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=consulkvs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps;secrets,verbs=get;list;watch
+
+func (r *ConsulKVController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var kv consulv1alpha1.ConsulKV
+	err := r.Get(ctx, req.NamespacedName, &kv)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	consulClient, err := consulClientFromConnMgr(r.ConsulClientConfig, r.ConsulServerConnMgr)
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+	consulNS := namespaces.ConsulNamespace(kv.Namespace, r.EnableConsulNamespaces,
+		r.ConsulDestinationNamespace, r.EnableNSMirroring, r.NSMirroringPrefix)
+
+	if !kv.GetDeletionTimestamp().IsZero() {
+		if containsString(kv.Finalizers, FinalizerName) {
+			logger.Info("deletion event")
+			if err := r.deleteOwnedKeys(logger, consulClient, consulNS, &kv); err != nil {
+				return resourceSyncFailed(ctx, logger, r.Status(), &kv, ConsulAgentError,
+					fmt.Errorf("deleting keys from consul: %w", err))
+			}
+			logger.Info("deletion from Consul successful")
+			controllerutil.RemoveFinalizer(&kv, FinalizerName)
+			if err := r.Update(ctx, &kv); err != nil {
+				return ctrl.Result{}, err
+			}
+			logger.Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !containsString(kv.Finalizers, FinalizerName) {
+		controllerutil.AddFinalizer(&kv, FinalizerName)
+		kv.SetSyncedCondition(corev1.ConditionUnknown, "", "")
+		if err := r.Update(ctx, &kv); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	desired, err := r.desiredKeys(ctx, &kv)
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &kv, KVSourceError, err)
+	}
+
+	if r.EnableConsulNamespaces {
+		created, err := namespaces.EnsureExists(consulClient, consulNS, r.CrossNSACLPolicy)
+		if err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &kv, ConsulAgentError,
+				fmt.Errorf("creating consul namespace %q: %w", consulNS, err))
+		}
+		if created {
+			logger.Info("consul namespace created", "ns", consulNS)
+		}
+	}
+
+	changed, conflicts, err := r.syncKeys(consulClient, consulNS, &kv, desired)
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &kv, ConsulAgentError,
+			fmt.Errorf("writing keys to consul: %w", err))
+	}
+	if len(conflicts) > 0 {
+		// Conflicts are only resolved by changes outside of the controller so
+		// they're reported rather than retried.
+		kv.SetSyncedCondition(corev1.ConditionFalse, KVConflictError,
+			fmt.Sprintf("keys were modified outside of this resource: %s", strings.Join(conflicts, ", ")))
+		if err := r.Status().Update(ctx, &kv); err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.Info("keys were modified outside of this resource", "keys", conflicts)
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+	}
+	if changed {
+		logger.Info("keys written to Consul")
+	} else if kv.SyncedConditionStatus() == corev1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+	return resourceSyncSuccessful(ctx, r.Status(), &kv)
+}
+
+func (r *ConsulKVController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&consulv1alpha1.ConsulKV{}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForConfigMap), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForSecret), builder.OnlyMetadata).
+		WithOptions(controllerOptions()).
+		Complete(r)
+}
+
+// syncKeys writes the desired keys and prunes owned keys that are no longer
+// desired. The owned keys in the status of kv are updated to reflect the
+// writes. It returns whether Consul was changed and the keys that couldn't be
+// written or pruned because of conflicts.
+func (r *ConsulKVController) syncKeys(consulClient *capi.Client, consulNS string, kv *consulv1alpha1.ConsulKV, desired map[string][]byte) (bool, []string, error) {
+	owned := kv.OwnedKeys()
+	defer kv.SetOwnedKeys(owned)
+
+	keys := make([]string, 0, len(desired)+len(owned))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	for key := range owned {
+		if _, ok := desired[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	flags := r.ownerFlags(kv)
+	var changed bool
+	var conflicts []string
+	for _, key := range keys {
+		existing, _, err := consulClient.KV().Get(key, &capi.QueryOptions{Namespace: consulNS})
+		if err != nil {
+			return changed, conflicts, fmt.Errorf("reading key %q: %w", key, err)
+		}
+		value, isDesired := desired[key]
+		index, isOwned := owned[key]
+
+		// Keys that no longer exist in Consul are no longer owned. If they're
+		// still desired they are recreated below.
+		if existing == nil && isOwned {
+			delete(owned, key)
+			index, isOwned = 0, false
+			changed = true
+		}
+		// Keys tagged with this resource's flags were written by it even if
+		// its status doesn't record them. Keys in the status were modified
+		// outside of the resource if their index changed, whatever their flags.
+		if existing != nil && existing.Flags == flags && !isOwned {
+			index, isOwned = existing.ModifyIndex, true
+			owned[key] = index
+			changed = true
+		}
+		if existing != nil && isOwned && existing.ModifyIndex != index {
+			conflicts = append(conflicts, key)
+			continue
+		}
+
+		switch {
+		case !isDesired && !isOwned:
+			// The key was already deleted.
+		case !isDesired:
+			ok, _, err := kvTxn(consulClient, capi.KVDeleteCAS, key, nil, 0, index, consulNS)
+			if err != nil {
+				return changed, conflicts, err
+			}
+			if !ok {
+				conflicts = append(conflicts, key)
+				continue
+			}
+			delete(owned, key)
+			changed = true
+		case existing != nil && !isOwned:
+			// The key exists but wasn't created by this resource.
+			conflicts = append(conflicts, key)
+		case existing != nil && bytes.Equal(existing.Value, value) && existing.Flags == flags:
+			// The key is up to date.
+		default:
+			// Keys that don't exist are created with an index of 0 so that
+			// the write fails if they're created concurrently.
+			ok, newIndex, err := kvTxn(consulClient, capi.KVCAS, key, value, flags, index, consulNS)
+			if err != nil {
+				return changed, conflicts, err
+			}
+			if !ok {
+				conflicts = append(conflicts, key)
+				continue
+			}
+			owned[key] = newIndex
+			changed = true
+		}
+	}
+	return changed, conflicts, nil
+}
+
+// deleteOwnedKeys deletes the keys owned by kv. Keys that were modified
+// outside of the resource are left in Consul.
+func (r *ConsulKVController) deleteOwnedKeys(logger logr.Logger, consulClient *capi.Client, consulNS string, kv *consulv1alpha1.ConsulKV) error {
+	for _, k := range kv.Status.Keys {
+		ok, _, err := kvTxn(consulClient, capi.KVDeleteCAS, k.Key, nil, 0, k.ModifyIndex, consulNS)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			logger.Info("key was modified outside of this resource and was not deleted", "key", k.Key)
+		}
+	}
+	return nil
+}
+
+// kvTxn performs a check-and-set operation on key in a transaction so that
+// the new modify index can be read atomically. It returns false if the
+// check-and-set failed because the key's index didn't match index.
+func kvTxn(consulClient *capi.Client, verb capi.KVOp, key string, value []byte, flags, index uint64, consulNS string) (bool, uint64, error) {
+	ok, resp, _, err := consulClient.Txn().Txn(capi.TxnOps{
+		&capi.TxnOp{
+			KV: &capi.KVTxnOp{
+				Verb:      verb,
+				Key:       key,
+				Value:     value,
+				Flags:     flags,
+				Index:     index,
+				Namespace: consulNS,
+			},
+		},
+	}, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("writing key %q: %w", key, err)
+	}
+	if !ok {
+		var errs []string
+		for _, txnErr := range resp.Errors {
+			if strings.Contains(txnErr.What, "index is stale") {
+				return false, 0, nil
+			}
+			errs = append(errs, txnErr.What)
+		}
+		return false, 0, fmt.Errorf("writing key %q: %s", key, strings.Join(errs, ", "))
+	}
+	if len(resp.Results) == 0 || resp.Results[0].KV == nil {
+		return true, 0, nil
+	}
+	return true, resp.Results[0].KV.ModifyIndex, nil
+}
+
+// ownerFlags returns the flags that keys written by kv are tagged with. They
+// identify the resource by its cluster, namespace and name so that they don't
+// change when it's recreated.
+func (r *ConsulKVController) ownerFlags(kv *consulv1alpha1.ConsulKV) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join([]string{r.DatacenterName, r.ClusterID, kv.Namespace, kv.Name}, "/")))
+	return h.Sum64()
+}
+
+// desiredKeys returns the full Consul keys and values that kv should write.
+func (r *ConsulKVController) desiredKeys(ctx context.Context, kv *consulv1alpha1.ConsulKV) (map[string][]byte, error) {
+	desired := make(map[string][]byte)
+	for _, src := range kv.Spec.DataFrom {
+		data, err := r.sourceData(ctx, kv.Namespace, src)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range data {
+			desired[kv.ConsulKey(src.Prefix+key)] = value
+		}
+	}
+	for _, entry := range kv.Spec.Entries {
+		if entry.ValueFrom == nil {
+			desired[kv.ConsulKey(entry.Key)] = []byte(entry.Value)
+			continue
+		}
+		value, ok, err := r.sourceValue(ctx, kv.Namespace, entry.ValueFrom)
+		if err != nil {
+			return nil, fmt.Errorf("reading value for key %q: %w", entry.Key, err)
+		}
+		if ok {
+			desired[kv.ConsulKey(entry.Key)] = value
+		}
+	}
+	return desired, nil
+}
+
+// sourceData returns all keys of the ConfigMap or Secret referenced by src.
+func (r *ConsulKVController) sourceData(ctx context.Context, namespace string, src consulv1alpha1.ConsulKVDataSource) (map[string][]byte, error) {
+	data := make(map[string][]byte)
+	if src.ConfigMapRef != nil {
+		var configMap corev1.ConfigMap
+		err := r.APIReader.Get(ctx, types.NamespacedName{Name: src.ConfigMapRef.Name, Namespace: namespace}, &configMap)
+		if k8serr.IsNotFound(err) && src.ConfigMapRef.Optional {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("reading configmap %q: %w", src.ConfigMapRef.Name, err)
+		}
+		for key, value := range configMap.Data {
+			data[key] = []byte(value)
+		}
+		for key, value := range configMap.BinaryData {
+			data[key] = value
+		}
+	}
+	if src.SecretRef != nil {
+		var secret corev1.Secret
+		err := r.APIReader.Get(ctx, types.NamespacedName{Name: src.SecretRef.Name, Namespace: namespace}, &secret)
+		if k8serr.IsNotFound(err) && src.SecretRef.Optional {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("reading secret %q: %w", src.SecretRef.Name, err)
+		}
+		for key, value := range secret.Data {
+			data[key] = value
+		}
+	}
+	return data, nil
+}
+
+// sourceValue returns the value of the ConfigMap or Secret key referenced by
+// src. It returns false if the key doesn't exist and is optional.
+func (r *ConsulKVController) sourceValue(ctx context.Context, namespace string, src *consulv1alpha1.ConsulKVValueSource) ([]byte, bool, error) {
+	if ref := src.ConfigMapKeyRef; ref != nil {
+		optional := ref.Optional != nil && *ref.Optional
+		var configMap corev1.ConfigMap
+		err := r.APIReader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &configMap)
+		if k8serr.IsNotFound(err) && optional {
+			return nil, false, nil
+		} else if err != nil {
+			return nil, false, fmt.Errorf("reading configmap %q: %w", ref.Name, err)
+		}
+		if value, ok := configMap.Data[ref.Key]; ok {
+			return []byte(value), true, nil
+		}
+		if value, ok := configMap.BinaryData[ref.Key]; ok {
+			return value, true, nil
+		}
+		if optional {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("configmap %q has no key %q", ref.Name, ref.Key)
+	}
+
+	ref := src.SecretKeyRef
+	optional := ref.Optional != nil && *ref.Optional
+	var secret corev1.Secret
+	err := r.APIReader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &secret)
+	if k8serr.IsNotFound(err) && optional {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("reading secret %q: %w", ref.Name, err)
+	}
+	if value, ok := secret.Data[ref.Key]; ok {
+		return value, true, nil
+	}
+	if optional {
+		return nil, false, nil
+	}
+	return nil, false, fmt.Errorf("secret %q has no key %q", ref.Name, ref.Key)
+}
+
+// requestsForConfigMap returns requests for the ConsulKV resources that
+// reference a ConfigMap.
+func (r *ConsulKVController) requestsForConfigMap(object client.Object) []reconcile.Request {
+	return r.requestsForSource(object, false)
+}
+
+// requestsForSecret returns requests for the ConsulKV resources that
+// reference a Secret.
+func (r *ConsulKVController) requestsForSecret(object client.Object) []reconcile.Request {
+	return r.requestsForSource(object, true)
+}
+
+// requestsForSource returns requests for the ConsulKV resources in the same
+// namespace as a ConfigMap or Secret that reference it. The watched objects
+// only contain metadata so whether it's a Secret is passed in.
+func (r *ConsulKVController) requestsForSource(object client.Object, isSecret bool) []reconcile.Request {
+	var kvList consulv1alpha1.ConsulKVList
+	if err := r.List(context.Background(), &kvList, client.InNamespace(object.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list ConsulKV resources")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, kv := range kvList.Items {
+		if consulKVReferences(kv, isSecret, object.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: kv.Name, Namespace: kv.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// consulKVReferences returns true if kv references the Secret or ConfigMap
+// with the given name.
+func consulKVReferences(kv consulv1alpha1.ConsulKV, isSecret bool, name string) bool {
+	for _, src := range kv.Spec.DataFrom {
+		if isSecret && src.SecretRef != nil && src.SecretRef.Name == name ||
+			!isSecret && src.ConfigMapRef != nil && src.ConfigMapRef.Name == name {
+			return true
+		}
+	}
+	for _, entry := range kv.Spec.Entries {
+		if entry.ValueFrom == nil {
+			continue
+		}
+		if isSecret && entry.ValueFrom.SecretKeyRef != nil && entry.ValueFrom.SecretKeyRef.Name == name ||
+			!isSecret && entry.ValueFrom.ConfigMapKeyRef != nil && entry.ValueFrom.ConfigMapKeyRef.Name == name {
+			return true
+		}
+	}
+	return false
+}
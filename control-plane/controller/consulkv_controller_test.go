@@ -0,0 +1,431 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestConsulKVController_writesKeysFromAllSources(t *testing.T) {
+	t.Parallel()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+		Data:       map[string]string{"port": "8080", "host": "web"},
+		BinaryData: map[string][]byte{"bin": []byte("binary")},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("hunter2"), "user": []byte("admin")},
+	}
+	kv := &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "default"},
+		Spec: v1alpha1.ConsulKVSpec{
+			Prefix: "config/web",
+			DataFrom: []v1alpha1.ConsulKVDataSource{
+				{ConfigMapRef: &v1alpha1.ConsulKVSourceReference{Name: "config"}},
+				{Prefix: "missing/", SecretRef: &v1alpha1.ConsulKVSourceReference{Name: "missing", Optional: true}},
+			},
+			Entries: []v1alpha1.ConsulKVEntry{
+				{Key: "port", Value: "9090"},
+				{
+					Key: "db/password",
+					ValueFrom: &v1alpha1.ConsulKVValueSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "secret"},
+							Key:                  "password",
+						},
+					},
+				},
+			},
+		},
+	}
+	r, consulClient := consulKVTestController(t, kv, configMap, secret)
+
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+	requireKVs(t, consulClient, map[string]string{
+		"config/web/bin":         "binary",
+		"config/web/db/password": "hunter2",
+		"config/web/host":        "web",
+		// Entries take precedence over data sources.
+		"config/web/port": "9090",
+	})
+	requireOwnedKeys(t, kv, "config/web/bin", "config/web/db/password", "config/web/host", "config/web/port")
+}
+
+func TestConsulKVController_updatesAndPrunesOwnedKeys(t *testing.T) {
+	t.Parallel()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+		Data:       map[string]string{"port": "8080", "host": "web"},
+	}
+	kv := &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "default"},
+		Spec: v1alpha1.ConsulKVSpec{
+			Prefix: "config/web",
+			DataFrom: []v1alpha1.ConsulKVDataSource{
+				{ConfigMapRef: &v1alpha1.ConsulKVSourceReference{Name: "config"}},
+			},
+		},
+	}
+	r, consulClient := consulKVTestController(t, kv, configMap)
+	ctx := context.Background()
+
+	// A key under the prefix that wasn't created by the resource.
+	_, err := consulClient.KV().Put(&capi.KVPair{Key: "config/web/other", Value: []byte("other")}, nil)
+	require.NoError(t, err)
+
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+	requireKVs(t, consulClient, map[string]string{
+		"config/web/host":  "web",
+		"config/web/other": "other",
+		"config/web/port":  "8080",
+	})
+
+	// Update and remove keys from the ConfigMap.
+	configMap.Data = map[string]string{"port": "9090"}
+	require.NoError(t, r.Update(ctx, configMap))
+
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+	requireKVs(t, consulClient, map[string]string{
+		"config/web/other": "other",
+		"config/web/port":  "9090",
+	})
+	requireOwnedKeys(t, kv, "config/web/port")
+
+	// Keys deleted from Consul are recreated.
+	_, err = consulClient.KV().Delete("config/web/port", nil)
+	require.NoError(t, err)
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+	requireKVs(t, consulClient, map[string]string{
+		"config/web/other": "other",
+		"config/web/port":  "9090",
+	})
+}
+
+func TestConsulKVController_detectsConflicts(t *testing.T) {
+	t.Parallel()
+
+	kv := &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "default"},
+		Spec: v1alpha1.ConsulKVSpec{
+			Prefix: "config/web",
+			Entries: []v1alpha1.ConsulKVEntry{
+				{Key: "port", Value: "8080"},
+				{Key: "host", Value: "web"},
+			},
+		},
+	}
+	r, consulClient := consulKVTestController(t, kv)
+	ctx := context.Background()
+
+	// The key already exists so it isn't owned by the resource.
+	_, err := consulClient.KV().Put(&capi.KVPair{Key: "config/web/host", Value: []byte("other")}, nil)
+	require.NoError(t, err)
+
+	reconcileKV(t, r, kv, corev1.ConditionFalse, KVConflictError)
+	require.Equal(t, "keys were modified outside of this resource: config/web/host", kv.Status.Conditions[0].Message)
+	requireKVs(t, consulClient, map[string]string{
+		"config/web/host": "other",
+		"config/web/port": "8080",
+	})
+	requireOwnedKeys(t, kv, "config/web/port")
+
+	// Owned keys that are modified outside of the resource are not
+	// overwritten or pruned.
+	_, err = consulClient.KV().Delete("config/web/host", nil)
+	require.NoError(t, err)
+	_, err = consulClient.KV().Put(&capi.KVPair{Key: "config/web/port", Value: []byte("9090")}, nil)
+	require.NoError(t, err)
+	kv.Spec.Entries = []v1alpha1.ConsulKVEntry{{Key: "host", Value: "web"}}
+	require.NoError(t, r.Update(ctx, kv))
+
+	reconcileKV(t, r, kv, corev1.ConditionFalse, KVConflictError)
+	require.Equal(t, "keys were modified outside of this resource: config/web/port", kv.Status.Conditions[0].Message)
+	requireKVs(t, consulClient, map[string]string{
+		"config/web/host": "web",
+		"config/web/port": "9090",
+	})
+	requireOwnedKeys(t, kv, "config/web/host", "config/web/port")
+
+	// The conflict is resolved by deleting the key from Consul.
+	_, err = consulClient.KV().Delete("config/web/port", nil)
+	require.NoError(t, err)
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+	requireOwnedKeys(t, kv, "config/web/host")
+}
+
+func TestConsulKVController_takesBackKeysMissingFromStatus(t *testing.T) {
+	t.Parallel()
+
+	kv := &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "default"},
+		Spec: v1alpha1.ConsulKVSpec{
+			Prefix: "config/web",
+			Entries: []v1alpha1.ConsulKVEntry{
+				{Key: "port", Value: "8080"},
+				{Key: "host", Value: "web"},
+			},
+		},
+	}
+	r, consulClient := consulKVTestController(t, kv)
+	ctx := context.Background()
+
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+	requireOwnedKeys(t, kv, "config/web/host", "config/web/port")
+
+	// Simulate the resource being removed without its finalizer running and
+	// then recreated, which loses its status but leaves its keys in Consul.
+	kv.Finalizers = nil
+	require.NoError(t, r.Update(ctx, kv))
+	require.NoError(t, r.Delete(ctx, kv))
+	kv = &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "default"},
+		Spec:       kv.Spec,
+	}
+	require.NoError(t, r.Create(ctx, kv))
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+	requireOwnedKeys(t, kv, "config/web/host", "config/web/port")
+
+	// Keys in the status that are modified outside of the resource aren't
+	// taken back even if their flags weren't changed.
+	_, err := consulClient.KV().Put(&capi.KVPair{Key: "config/web/port", Value: []byte("9090"), Flags: r.ownerFlags(kv)}, nil)
+	require.NoError(t, err)
+	reconcileKV(t, r, kv, corev1.ConditionFalse, KVConflictError)
+	require.Equal(t, "keys were modified outside of this resource: config/web/port", kv.Status.Conditions[0].Message)
+	requireKVs(t, consulClient, map[string]string{
+		"config/web/host": "web",
+		"config/web/port": "9090",
+	})
+
+	// Keys of another resource with the same prefix are still conflicts.
+	other := &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec:       kv.Spec,
+	}
+	require.NoError(t, r.Create(ctx, other))
+	reconcileKV(t, r, other, corev1.ConditionFalse, KVConflictError)
+}
+
+func TestConsulKVController_doesNotTakeKeysFromOtherClusters(t *testing.T) {
+	t.Parallel()
+
+	kv := &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "default"},
+		Spec: v1alpha1.ConsulKVSpec{
+			Prefix:  "config/web",
+			Entries: []v1alpha1.ConsulKVEntry{{Key: "port", Value: "8080"}},
+		},
+	}
+	r, consulClient := consulKVTestController(t, kv)
+	r.DatacenterName = "dc1"
+	r.ClusterID = "cluster-1"
+
+	// The same resource in another cluster wrote the key.
+	otherCluster := &ConsulKVController{DatacenterName: "dc1", ClusterID: "cluster-2"}
+	_, err := consulClient.KV().Put(&capi.KVPair{Key: "config/web/port", Value: []byte("9090"), Flags: otherCluster.ownerFlags(kv)}, nil)
+	require.NoError(t, err)
+
+	reconcileKV(t, r, kv, corev1.ConditionFalse, KVConflictError)
+	requireKVs(t, consulClient, map[string]string{"config/web/port": "9090"})
+	requireOwnedKeys(t, kv)
+}
+
+func TestConsulKVController_sourceErrors(t *testing.T) {
+	t.Parallel()
+
+	kv := &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "default"},
+		Spec: v1alpha1.ConsulKVSpec{
+			Prefix: "config/web",
+			Entries: []v1alpha1.ConsulKVEntry{
+				{
+					Key: "port",
+					ValueFrom: &v1alpha1.ConsulKVValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+							Key:                  "port",
+						},
+					},
+				},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+		Data:       map[string]string{"host": "web"},
+	}
+	r, consulClient := consulKVTestController(t, kv, configMap)
+	ctx := context.Background()
+
+	reconcileKV(t, r, kv, corev1.ConditionFalse, KVSourceError)
+	require.Equal(t, `reading value for key "port": configmap "config" has no key "port"`, kv.Status.Conditions[0].Message)
+
+	// Optional keys are skipped if they don't exist.
+	optional := true
+	kv.Spec.Entries[0].ValueFrom.ConfigMapKeyRef.Optional = &optional
+	require.NoError(t, r.Update(ctx, kv))
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+	requireKVs(t, consulClient, map[string]string{})
+}
+
+func TestConsulKVController_deletesOwnedKeys(t *testing.T) {
+	t.Parallel()
+
+	kv := &v1alpha1.ConsulKV{
+		ObjectMeta: metav1.ObjectMeta{Name: "kv", Namespace: "default"},
+		Spec: v1alpha1.ConsulKVSpec{
+			Prefix: "config/web",
+			Entries: []v1alpha1.ConsulKVEntry{
+				{Key: "port", Value: "8080"},
+				{Key: "host", Value: "web"},
+			},
+		},
+	}
+	r, consulClient := consulKVTestController(t, kv)
+	ctx := context.Background()
+
+	_, err := consulClient.KV().Put(&capi.KVPair{Key: "config/web/other", Value: []byte("other")}, nil)
+	require.NoError(t, err)
+	reconcileKV(t, r, kv, corev1.ConditionTrue, "")
+
+	// Modify one of the owned keys so it isn't deleted.
+	_, err = consulClient.KV().Put(&capi.KVPair{Key: "config/web/host", Value: []byte("modified")}, nil)
+	require.NoError(t, err)
+
+	kv.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	require.NoError(t, r.Update(ctx, kv))
+	resp, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: kv.Name, Namespace: kv.Namespace}})
+	require.NoError(t, err)
+	require.False(t, resp.Requeue)
+
+	requireKVs(t, consulClient, map[string]string{
+		"config/web/host":  "modified",
+		"config/web/other": "other",
+	})
+	// The resource is removed once its finalizer is removed.
+	err = r.Get(ctx, types.NamespacedName{Name: kv.Name, Namespace: kv.Namespace}, kv)
+	require.True(t, k8serr.IsNotFound(err))
+}
+
+func TestConsulKVController_requestsForSource(t *testing.T) {
+	t.Parallel()
+
+	kvs := []runtime.Object{
+		&v1alpha1.ConsulKV{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-from-configmap", Namespace: "default"},
+			Spec: v1alpha1.ConsulKVSpec{
+				DataFrom: []v1alpha1.ConsulKVDataSource{{ConfigMapRef: &v1alpha1.ConsulKVSourceReference{Name: "source"}}},
+			},
+		},
+		&v1alpha1.ConsulKV{
+			ObjectMeta: metav1.ObjectMeta{Name: "value-from-secret", Namespace: "default"},
+			Spec: v1alpha1.ConsulKVSpec{
+				Entries: []v1alpha1.ConsulKVEntry{{
+					Key: "key",
+					ValueFrom: &v1alpha1.ConsulKVValueSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "source"},
+						},
+					},
+				}},
+			},
+		},
+		&v1alpha1.ConsulKV{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"},
+			Spec: v1alpha1.ConsulKVSpec{
+				DataFrom: []v1alpha1.ConsulKVDataSource{{ConfigMapRef: &v1alpha1.ConsulKVSourceReference{Name: "source"}}},
+			},
+		},
+	}
+	r := &ConsulKVController{
+		Client: fake.NewClientBuilder().WithScheme(consulKVTestScheme(t)).WithRuntimeObjects(kvs...).Build(),
+		Log:    logrtest.TestLogger{T: t},
+	}
+
+	require.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "data-from-configmap", Namespace: "default"}},
+	}, r.requestsForConfigMap(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"}}))
+	require.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "value-from-secret", Namespace: "default"}},
+	}, r.requestsForSecret(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"}}))
+	require.Empty(t, r.requestsForSecret(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "unreferenced", Namespace: "default"}}))
+}
+
+func consulKVTestScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.ConsulKV{}, &v1alpha1.ConsulKVList{})
+	return s
+}
+
+func consulKVTestController(t *testing.T, objs ...runtime.Object) (*ConsulKVController, *capi.Client) {
+	t.Helper()
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForLeader(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(consulKVTestScheme(t)).WithRuntimeObjects(objs...).Build()
+	return &ConsulKVController{
+		Client:              fakeClient,
+		APIReader:           fakeClient,
+		Log:                 logrtest.TestLogger{T: t},
+		ConsulClientConfig:  testClient.Cfg,
+		ConsulServerConnMgr: testClient.Watcher,
+	}, testClient.APIClient
+}
+
+// reconcileKV reconciles kv and refreshes it from Kubernetes. It checks the
+// synced condition has the expected status and reason.
+func reconcileKV(t *testing.T, r *ConsulKVController, kv *v1alpha1.ConsulKV, expStatus corev1.ConditionStatus, expReason string) {
+	t.Helper()
+	namespacedName := types.NamespacedName{Name: kv.Name, Namespace: kv.Namespace}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: namespacedName})
+	// Conflicts are reported in the status rather than retried.
+	if expStatus == corev1.ConditionTrue || expReason == KVConflictError {
+		require.NoError(t, err)
+	} else {
+		require.Error(t, err)
+	}
+	require.NoError(t, r.Get(context.Background(), namespacedName, kv))
+	require.Equal(t, expStatus, kv.SyncedConditionStatus())
+	require.Equal(t, expReason, kv.Status.Conditions[0].Reason)
+	require.Contains(t, kv.Finalizers, FinalizerName)
+}
+
+// requireKVs checks that the keys under config/ match exp.
+func requireKVs(t *testing.T, consulClient *capi.Client, exp map[string]string) {
+	t.Helper()
+	pairs, _, err := consulClient.KV().List("config/", nil)
+	require.NoError(t, err)
+	actual := make(map[string]string)
+	for _, pair := range pairs {
+		actual[pair.Key] = string(pair.Value)
+	}
+	require.Equal(t, exp, actual)
+}
+
+// requireOwnedKeys checks that the status of kv records exactly keys and that
+// the recorded indexes are non-zero.
+func requireOwnedKeys(t *testing.T, kv *v1alpha1.ConsulKV, keys ...string) {
+	t.Helper()
+	var actual []string
+	for _, k := range kv.Status.Keys {
+		require.NotZero(t, k.ModifyIndex, "key %q", k.Key)
+		actual = append(actual, k.Key)
+	}
+	require.Equal(t, keys, actual)
+}
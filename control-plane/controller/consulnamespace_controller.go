@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ConsulNamespaceController reconciles ConsulNamespace resources with
+// Consul Enterprise namespaces.
+type ConsulNamespaceController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// ConsulClientConfig is the config for the Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+	// ConsulPartition is the admin partition namespaces are created in.
+	// Empty if admin partitions are not enabled.
+	ConsulPartition string
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=consulnamespaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=consulnamespaces/status,verbs=get;update;patch
+
+// Reconcile creates, updates or deletes the Consul namespace for a
+// ConsulNamespace resource. Namespaces that already exist in Consul are
+// adopted and updated to match the resource, but only namespaces the
+// resource created are deleted with it.
+func (r *ConsulNamespaceController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var ns consulv1alpha1.ConsulNamespace
+	err := r.Get(ctx, req.NamespacedName, &ns)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	consulClient, err := consulClientFromConnMgr(r.ConsulClientConfig, r.ConsulServerConnMgr)
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+
+	if !ns.GetDeletionTimestamp().IsZero() {
+		if containsString(ns.Finalizers, FinalizerName) {
+			logger.Info("deletion event")
+			if ns.ShouldDelete() {
+				existing, _, err := consulClient.Namespaces().Read(ns.ConsulName(), &capi.QueryOptions{Partition: r.ConsulPartition})
+				if err != nil {
+					return resourceSyncFailed(ctx, logger, r.Status(), &ns, ConsulAgentError,
+						fmt.Errorf("reading namespace from consul: %w", err))
+				}
+				switch {
+				case existing == nil:
+				case !ns.CreatedInConsul(existing):
+					logger.Info("namespace was not created by this resource, it is retained in Consul")
+				default:
+					_, err := consulClient.Namespaces().Delete(ns.ConsulName(), &capi.WriteOptions{Partition: r.ConsulPartition})
+					if err != nil && !isNotFoundErr(err) {
+						return resourceSyncFailed(ctx, logger, r.Status(), &ns, ConsulAgentError,
+							fmt.Errorf("deleting namespace from consul: %w", err))
+					}
+					logger.Info("deletion from Consul successful")
+				}
+			} else {
+				logger.Info("namespace is retained in Consul")
+			}
+			controllerutil.RemoveFinalizer(&ns, FinalizerName)
+			if err := r.Update(ctx, &ns); err != nil {
+				return ctrl.Result{}, err
+			}
+			logger.Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !containsString(ns.Finalizers, FinalizerName) {
+		controllerutil.AddFinalizer(&ns, FinalizerName)
+		ns.SetSyncedCondition(corev1.ConditionUnknown, "", "")
+		if err := r.Update(ctx, &ns); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	existing, _, err := consulClient.Namespaces().Read(ns.ConsulName(), &capi.QueryOptions{Partition: r.ConsulPartition})
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &ns, ConsulAgentError,
+			fmt.Errorf("reading namespace from consul: %w", err))
+	}
+
+	desired := ns.ToConsul(r.ConsulPartition)
+	switch {
+	case existing == nil:
+		desired.Meta[common.ResourceKey] = ns.CreatedMarker()
+		if _, _, err := consulClient.Namespaces().Create(desired, nil); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &ns, ConsulAgentError,
+				fmt.Errorf("creating namespace in consul: %w", err))
+		}
+		logger.Info("namespace created")
+	case existing.DeletedAt != nil:
+		return resourceSyncFailed(ctx, logger, r.Status(), &ns, ConsulAgentError,
+			fmt.Errorf("namespace %q is being deleted in consul", ns.ConsulName()))
+	case !ns.MatchesConsul(existing):
+		preserveCreatedMarker(desired, existing)
+		if _, _, err := consulClient.Namespaces().Update(desired, nil); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &ns, ConsulAgentError,
+				fmt.Errorf("updating namespace in consul: %w", err))
+		}
+		logger.Info("namespace updated")
+	case ns.SyncedConditionStatus() == corev1.ConditionTrue:
+		return ctrl.Result{}, nil
+	}
+
+	return resourceSyncSuccessful(ctx, r.Status(), &ns)
+}
+
+// preserveCreatedMarker copies the marker of the resource that created the
+// existing namespace to desired so that updates don't change who owns it.
+func preserveCreatedMarker(desired, existing *capi.Namespace) {
+	if marker, ok := existing.Meta[common.ResourceKey]; ok {
+		desired.Meta[common.ResourceKey] = marker
+	}
+}
+
+func (r *ConsulNamespaceController) SetupWithManager(mgr ctrl.Manager) error {
+	return setupWithManager(mgr, &consulv1alpha1.ConsulNamespace{}, r)
+}
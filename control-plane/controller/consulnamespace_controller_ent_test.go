@@ -0,0 +1,159 @@
+//go:build enterprise
+
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/controller"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConsulNamespaceController_createsAndUpdatesNamespace(t *testing.T) {
+	t.Parallel()
+
+	ns := &v1alpha1.ConsulNamespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a",
+		},
+		Spec: v1alpha1.ConsulNamespaceSpec{
+			Description: "team a",
+			Meta:        map[string]string{"team": "a"},
+		},
+	}
+	s := runtime.NewScheme()
+	s.AddKnownTypes(v1alpha1.GroupVersion, ns)
+	ctx := context.Background()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	consulClient := testClient.APIClient
+
+	fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(ns).Build()
+	r := &controller.ConsulNamespaceController{
+		Client:              fakeClient,
+		Log:                 logrtest.TestLogger{T: t},
+		Scheme:              s,
+		ConsulClientConfig:  testClient.Cfg,
+		ConsulServerConnMgr: testClient.Watcher,
+	}
+	namespacedName := types.NamespacedName{Name: ns.KubernetesName()}
+
+	resp, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	require.False(t, resp.Requeue)
+
+	consulNS, _, err := consulClient.Namespaces().Read("team-a", nil)
+	require.NoError(t, err)
+	require.NotNil(t, consulNS)
+	require.Equal(t, "team a", consulNS.Description)
+	require.Equal(t, "a", consulNS.Meta["team"])
+	require.Equal(t, common.SourceValue, consulNS.Meta[common.SourceKey])
+
+	err = fakeClient.Get(ctx, namespacedName, ns)
+	require.NoError(t, err)
+	require.Equal(t, corev1.ConditionTrue, ns.SyncedConditionStatus())
+	require.Contains(t, ns.Finalizers, controller.FinalizerName)
+
+	// Update the description and check it's updated in Consul.
+	ns.Spec.Description = "updated"
+	require.NoError(t, fakeClient.Update(ctx, ns))
+
+	resp, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	require.False(t, resp.Requeue)
+
+	consulNS, _, err = consulClient.Namespaces().Read("team-a", nil)
+	require.NoError(t, err)
+	require.Equal(t, "updated", consulNS.Description)
+}
+
+func TestConsulNamespaceController_deletesNamespace(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		DeletionPolicy string
+		Created        bool
+		ExpDeleted     bool
+	}{
+		"delete": {
+			DeletionPolicy: v1alpha1.DeletionPolicyDelete,
+			Created:        true,
+			ExpDeleted:     true,
+		},
+		"delete taken over namespace": {
+			DeletionPolicy: v1alpha1.DeletionPolicyDelete,
+			Created:        false,
+			ExpDeleted:     false,
+		},
+		"retain": {
+			DeletionPolicy: v1alpha1.DeletionPolicyRetain,
+			Created:        true,
+			ExpDeleted:     false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ns := &v1alpha1.ConsulNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "team-a",
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					Finalizers:        []string{controller.FinalizerName},
+				},
+				Spec: v1alpha1.ConsulNamespaceSpec{
+					DeletionPolicy: c.DeletionPolicy,
+				},
+			}
+			s := runtime.NewScheme()
+			s.AddKnownTypes(v1alpha1.GroupVersion, ns)
+
+			testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+			consulClient := testClient.APIClient
+			consulNS := &capi.Namespace{Name: "team-a", Meta: map[string]string{}}
+			if c.Created {
+				consulNS.Meta[common.ResourceKey] = ns.CreatedMarker()
+			}
+			_, _, err := consulClient.Namespaces().Create(consulNS, nil)
+			require.NoError(t, err)
+
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(ns).Build()
+			r := &controller.ConsulNamespaceController{
+				Client:              fakeClient,
+				Log:                 logrtest.TestLogger{T: t},
+				Scheme:              s,
+				ConsulClientConfig:  testClient.Cfg,
+				ConsulServerConnMgr: testClient.Watcher,
+			}
+
+			resp, err := r.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: ns.KubernetesName()},
+			})
+			require.NoError(t, err)
+			require.False(t, resp.Requeue)
+
+			consulNS, _, err = consulClient.Namespaces().Read("team-a", nil)
+			require.NoError(t, err)
+			if c.ExpDeleted {
+				// Namespaces are deleted asynchronously so they're either
+				// already gone or marked for deletion.
+				require.True(t, consulNS == nil || consulNS.DeletedAt != nil)
+			} else {
+				require.NotNil(t, consulNS)
+				require.Nil(t, consulNS.DeletedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ConsulPartitionController reconciles ConsulPartition resources with
+// Consul Enterprise admin partitions. It only runs in clusters in the default
+// partition because managing partitions requires an ACL token with
+// operator = "write", which tokens in other partitions can't have.
+type ConsulPartitionController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// ConsulClientConfig is the config for the Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=consulpartitions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=consulpartitions/status,verbs=get;update;patch
+
+// Reconcile creates, updates or deletes the Consul admin partition for a
+// ConsulPartition resource. If the resource configures the partition's
+// default namespace, that namespace is updated as well.
+func (r *ConsulPartitionController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var partition consulv1alpha1.ConsulPartition
+	err := r.Get(ctx, req.NamespacedName, &partition)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	consulClient, err := consulClientFromConnMgr(r.ConsulClientConfig, r.ConsulServerConnMgr)
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+
+	if !partition.GetDeletionTimestamp().IsZero() {
+		if containsString(partition.Finalizers, FinalizerName) {
+			logger.Info("deletion event")
+			if partition.ShouldDelete() {
+				defaultNS, err := r.readDefaultNamespace(consulClient, partition.ConsulName())
+				if err != nil {
+					return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError, err)
+				}
+				switch {
+				case defaultNS == nil:
+				case !partition.CreatedInConsul(defaultNS):
+					logger.Info("partition was not created by this resource, it is retained in Consul")
+				default:
+					_, err := consulClient.Partitions().Delete(ctx, partition.ConsulName(), nil)
+					if err != nil && !isNotFoundErr(err) {
+						return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError,
+							fmt.Errorf("deleting partition from consul: %w", err))
+					}
+					logger.Info("deletion from Consul successful")
+				}
+			} else {
+				logger.Info("partition is retained in Consul")
+			}
+			controllerutil.RemoveFinalizer(&partition, FinalizerName)
+			if err := r.Update(ctx, &partition); err != nil {
+				return ctrl.Result{}, err
+			}
+			logger.Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !containsString(partition.Finalizers, FinalizerName) {
+		controllerutil.AddFinalizer(&partition, FinalizerName)
+		partition.SetSyncedCondition(corev1.ConditionUnknown, "", "")
+		if err := r.Update(ctx, &partition); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	existing, _, err := consulClient.Partitions().Read(ctx, partition.ConsulName(), nil)
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError,
+			fmt.Errorf("reading partition from consul: %w", err))
+	}
+
+	changed := false
+	switch {
+	case existing == nil:
+		if _, _, err := consulClient.Partitions().Create(ctx, partition.ToConsul(), nil); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError,
+				fmt.Errorf("creating partition in consul: %w", err))
+		}
+		logger.Info("partition created")
+		// Partitions have no meta, so the marker that this resource created
+		// the partition is written to its default namespace. If this fails
+		// the partition is treated as taken over and is never deleted.
+		defaultNS, err := r.readDefaultNamespace(consulClient, partition.ConsulName())
+		if err == nil && defaultNS == nil {
+			err = fmt.Errorf("default namespace of partition %q not found in consul", partition.ConsulName())
+		}
+		if err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError, err)
+		}
+		if defaultNS.Meta == nil {
+			defaultNS.Meta = map[string]string{}
+		}
+		defaultNS.Meta[common.ResourceKey] = partition.CreatedMarker()
+		if _, _, err := consulClient.Namespaces().Update(defaultNS, nil); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError,
+				fmt.Errorf("updating default namespace of partition in consul: %w", err))
+		}
+		changed = true
+	case existing.DeletedAt != nil:
+		return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError,
+			fmt.Errorf("partition %q is being deleted in consul", partition.ConsulName()))
+	case !partition.MatchesConsul(existing):
+		if _, _, err := consulClient.Partitions().Update(ctx, partition.ToConsul(), nil); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError,
+				fmt.Errorf("updating partition in consul: %w", err))
+		}
+		logger.Info("partition updated")
+		changed = true
+	}
+
+	if defaultNS := partition.DefaultNamespaceToConsul(); defaultNS != nil {
+		ns, err := r.readDefaultNamespace(consulClient, partition.ConsulName())
+		if err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError, err)
+		}
+		if !defaultNS.MatchesConsul(ns) {
+			desired := defaultNS.ToConsul(partition.ConsulName())
+			if ns != nil {
+				preserveCreatedMarker(desired, ns)
+			}
+			if _, _, err := consulClient.Namespaces().Update(desired, nil); err != nil {
+				return resourceSyncFailed(ctx, logger, r.Status(), &partition, ConsulAgentError,
+					fmt.Errorf("updating default namespace of partition in consul: %w", err))
+			}
+			logger.Info("default namespace of partition updated")
+			changed = true
+		}
+	}
+
+	if !changed && partition.SyncedConditionStatus() == corev1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+	return resourceSyncSuccessful(ctx, r.Status(), &partition)
+}
+
+// readDefaultNamespace returns the default namespace of partition, or nil if
+// the partition doesn't exist.
+func (r *ConsulPartitionController) readDefaultNamespace(consulClient *capi.Client, partition string) (*capi.Namespace, error) {
+	ns, _, err := consulClient.Namespaces().Read(common.DefaultConsulNamespace, &capi.QueryOptions{Partition: partition})
+	if err != nil && !isNotFoundErr(err) {
+		return nil, fmt.Errorf("reading default namespace of partition from consul: %w", err)
+	}
+	return ns, nil
+}
+
+func (r *ConsulPartitionController) SetupWithManager(mgr ctrl.Manager) error {
+	return setupWithManager(mgr, &consulv1alpha1.ConsulPartition{}, r)
+}
@@ -0,0 +1,117 @@
+//go:build enterprise
+
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/controller"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConsulPartitionController_createsPartition(t *testing.T) {
+	t.Parallel()
+
+	partition := &v1alpha1.ConsulPartition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a",
+		},
+		Spec: v1alpha1.ConsulPartitionSpec{
+			Description: "team a",
+			DefaultNamespace: &v1alpha1.PartitionDefaultNamespace{
+				Meta: map[string]string{"team": "a"},
+			},
+		},
+	}
+	s := runtime.NewScheme()
+	s.AddKnownTypes(v1alpha1.GroupVersion, partition)
+	ctx := context.Background()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	consulClient := testClient.APIClient
+
+	fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(partition).Build()
+	r := &controller.ConsulPartitionController{
+		Client:              fakeClient,
+		Log:                 logrtest.TestLogger{T: t},
+		Scheme:              s,
+		ConsulClientConfig:  testClient.Cfg,
+		ConsulServerConnMgr: testClient.Watcher,
+	}
+	namespacedName := types.NamespacedName{Name: partition.KubernetesName()}
+
+	resp, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	require.False(t, resp.Requeue)
+
+	consulPartition, _, err := consulClient.Partitions().Read(ctx, "team-a", nil)
+	require.NoError(t, err)
+	require.NotNil(t, consulPartition)
+	require.Equal(t, "team a", consulPartition.Description)
+
+	defaultNS, _, err := consulClient.Namespaces().Read(common.DefaultConsulNamespace, &capi.QueryOptions{Partition: "team-a"})
+	require.NoError(t, err)
+	require.Equal(t, "a", defaultNS.Meta["team"])
+	require.Equal(t, partition.CreatedMarker(), defaultNS.Meta[common.ResourceKey])
+
+	err = fakeClient.Get(ctx, namespacedName, partition)
+	require.NoError(t, err)
+	require.Equal(t, corev1.ConditionTrue, partition.SyncedConditionStatus())
+	require.Contains(t, partition.Finalizers, controller.FinalizerName)
+}
+
+// Test that partitions that existed before the resource took them over are
+// retained when the resource is deleted.
+func TestConsulPartitionController_retainsTakenOverPartition(t *testing.T) {
+	t.Parallel()
+
+	partition := &v1alpha1.ConsulPartition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "team-a",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{controller.FinalizerName},
+		},
+		Spec: v1alpha1.ConsulPartitionSpec{
+			DeletionPolicy: v1alpha1.DeletionPolicyDelete,
+		},
+	}
+	s := runtime.NewScheme()
+	s.AddKnownTypes(v1alpha1.GroupVersion, partition)
+	ctx := context.Background()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	consulClient := testClient.APIClient
+	_, _, err := consulClient.Partitions().Create(ctx, &capi.Partition{Name: "team-a"}, nil)
+	require.NoError(t, err)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(partition).Build()
+	r := &controller.ConsulPartitionController{
+		Client:              fakeClient,
+		Log:                 logrtest.TestLogger{T: t},
+		Scheme:              s,
+		ConsulClientConfig:  testClient.Cfg,
+		ConsulServerConnMgr: testClient.Watcher,
+	}
+
+	resp, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: partition.KubernetesName()}})
+	require.NoError(t, err)
+	require.False(t, resp.Requeue)
+
+	consulPartition, _, err := consulClient.Partitions().Read(ctx, "team-a", nil)
+	require.NoError(t, err)
+	require.NotNil(t, consulPartition)
+	require.Nil(t, consulPartition.DeletedAt)
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// consulResource is implemented by custom resources that are synced to Consul
+// but are not config entries, e.g. ConsulNamespace. These resources have
+// their own Consul APIs so they can't be reconciled by ConfigEntryController,
+// but they share the same status handling.
+type consulResource interface {
+	client.Object
+	// SetSyncedCondition updates the synced condition.
+	SetSyncedCondition(status corev1.ConditionStatus, reason, message string)
+	// SetLastSyncedTime updates the last synced time.
+	SetLastSyncedTime(time *metav1.Time)
+	// SyncedConditionStatus returns the status of the synced condition.
+	SyncedConditionStatus() corev1.ConditionStatus
+}
+
+// consulClientFromConnMgr creates a Consul API client from the current state
+// of the server connection manager.
+func consulClientFromConnMgr(cfg *consul.Config, connMgr consul.ServerConnectionManager) (*capi.Client, error) {
+	serverState, err := connMgr.State()
+	if err != nil {
+		return nil, err
+	}
+	return consul.NewClientFromConnMgrState(cfg, serverState)
+}
+
+// resourceSyncFailed sets the synced condition of resource to false and
+// returns err so that the request is retried.
+func resourceSyncFailed(ctx context.Context, logger logr.Logger, statusWriter client.StatusWriter, resource consulResource, errType string, err error) (ctrl.Result, error) {
+	resource.SetSyncedCondition(corev1.ConditionFalse, errType, err.Error())
+	if updateErr := statusWriter.Update(ctx, resource); updateErr != nil {
+		// Log the original error here because we are returning the updateErr.
+		// Otherwise the original error would be lost.
+		logger.Error(err, "sync failed")
+		return ctrl.Result{}, updateErr
+	}
+	return ctrl.Result{}, err
+}
+
+// resourceSyncSuccessful sets the synced condition of resource to true.
+func resourceSyncSuccessful(ctx context.Context, statusWriter client.StatusWriter, resource consulResource) (ctrl.Result, error) {
+	resource.SetSyncedCondition(corev1.ConditionTrue, "", "")
+	timeNow := metav1.NewTime(time.Now())
+	resource.SetLastSyncedTime(&timeNow)
+	return ctrl.Result{}, statusWriter.Update(ctx, resource)
+}
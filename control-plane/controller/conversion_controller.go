@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// CRDGVK is the GroupVersionKind of CustomResourceDefinitions. They are
+// handled as unstructured objects so that the controller doesn't depend on
+// the apiextensions API.
+var CRDGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// ConversionWebhookController manages the Consul CRDs that serve more than
+// one version. It configures their conversion webhook with the CA bundle of
+// the controller's webhooks and migrates the stored resources to the storage
+// version so that older versions can be removed from the CRD.
+type ConversionWebhookController struct {
+	client.Client
+	Log logr.Logger
+	// WebhookConfigName is the name of the MutatingWebhookConfiguration of
+	// the controller. The CA bundle of its webhooks is used for the
+	// conversion webhook since both are served by the controller.
+	WebhookConfigName string
+}
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=get;update
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch
+
+func (r *ConversionWebhookController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("crd", req.Name)
+
+	crd := newCRD()
+	if err := r.Get(ctx, req.NamespacedName, crd); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !conversionWebhookCRD(crd) {
+		return ctrl.Result{}, nil
+	}
+
+	var webhookConfig admissionv1.MutatingWebhookConfiguration
+	if err := r.Get(ctx, types.NamespacedName{Name: r.WebhookConfigName}, &webhookConfig); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// The webhook configuration is watched so the CRD is reconciled
+			// again once it exists.
+			logger.Info("webhook configuration not found", "name", r.WebhookConfigName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	var caBundle []byte
+	if len(webhookConfig.Webhooks) > 0 {
+		caBundle = webhookConfig.Webhooks[0].ClientConfig.CABundle
+	}
+	if len(caBundle) == 0 {
+		logger.Info("webhook configuration has no CA bundle yet", "name", r.WebhookConfigName)
+		return ctrl.Result{}, nil
+	}
+
+	current, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+	if decoded, err := base64.StdEncoding.DecodeString(current); err != nil || !bytes.Equal(decoded, caBundle) {
+		if err := unstructured.SetNestedField(crd.Object, base64.StdEncoding.EncodeToString(caBundle), "spec", "conversion", "webhook", "clientConfig", "caBundle"); err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.Info("updating conversion webhook CA bundle")
+		if err := r.Update(ctx, crd); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, r.migrateStoredVersions(ctx, logger, crd)
+}
+
+// migrateStoredVersions rewrites the resources of the CRD when some of them
+// may still be stored in a version other than the storage version. The API
+// server stores every resource that is written in the storage version so
+// once they have all been written the CRD's stored versions are updated to
+// only include the storage version.
+func (r *ConversionWebhookController) migrateStoredVersions(ctx context.Context, logger logr.Logger, crd *unstructured.Unstructured) error {
+	storageVersion := crdStorageVersion(crd)
+	storedVersions, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	if storageVersion == "" || (len(storedVersions) == 1 && storedVersions[0] == storageVersion) {
+		return nil
+	}
+
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: storageVersion, Kind: kind + "List"})
+	if err := r.List(ctx, list); err != nil {
+		return err
+	}
+	logger.Info("migrating resources to the storage version", "storedVersions", storedVersions, "storageVersion", storageVersion, "count", len(list.Items))
+	for i := range list.Items {
+		// A conflict means the resource was written, and so stored in the
+		// storage version, after it was listed.
+		err := r.Update(ctx, &list.Items[i])
+		if err != nil && !k8serrors.IsNotFound(err) && !k8serrors.IsConflict(err) {
+			return err
+		}
+	}
+
+	if err := unstructured.SetNestedStringSlice(crd.Object, []string{storageVersion}, "status", "storedVersions"); err != nil {
+		return err
+	}
+	return r.Status().Update(ctx, crd)
+}
+
+func (r *ConversionWebhookController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("conversion-webhook").
+		For(newCRD(), builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			crd, ok := obj.(*unstructured.Unstructured)
+			return ok && conversionWebhookCRD(crd)
+		}))).
+		Watches(&source.Kind{Type: &admissionv1.MutatingWebhookConfiguration{}},
+			handler.EnqueueRequestsFromMapFunc(r.crdsForWebhookConfig)).
+		Complete(r)
+}
+
+// crdsForWebhookConfig enqueues the CRDs with a conversion webhook when the
+// controller's webhook configuration changes.
+func (r *ConversionWebhookController) crdsForWebhookConfig(obj client.Object) []reconcile.Request {
+	if obj.GetName() != r.WebhookConfigName {
+		return nil
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(CRDGVK.GroupVersion().WithKind(CRDGVK.Kind + "List"))
+	if err := r.List(context.Background(), list); err != nil {
+		r.Log.Error(err, "failed to list CRDs")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		if conversionWebhookCRD(&list.Items[i]) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: list.Items[i].GetName()}})
+		}
+	}
+	return requests
+}
+
+func newCRD() *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(CRDGVK)
+	return crd
+}
+
+// conversionWebhookCRD returns whether the CRD is a Consul CRD that uses
+// a conversion webhook.
+func conversionWebhookCRD(crd *unstructured.Unstructured) bool {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	strategy, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "strategy")
+	return group == v1alpha1.ConsulHashicorpGroup && strategy == "Webhook"
+}
+
+// crdStorageVersion returns the name of the storage version of the CRD.
+func crdStorageVersion(crd *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if storage, _, _ := unstructured.NestedBool(version, "storage"); storage {
+			name, _, _ := unstructured.NestedString(version, "name")
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConversionWebhookController(t *testing.T) {
+	caBundle := []byte("ca")
+	cases := map[string]struct {
+		strategy          string
+		webhookCABundle   []byte
+		storedVersions    []string
+		expCABundle       string
+		expStoredVersions []string
+		expMigrated       bool
+	}{
+		"sets CA bundle": {
+			strategy:          "Webhook",
+			webhookCABundle:   caBundle,
+			storedVersions:    []string{"v1alpha1"},
+			expCABundle:       base64.StdEncoding.EncodeToString(caBundle),
+			expStoredVersions: []string{"v1alpha1"},
+		},
+		"migrates resources to the storage version": {
+			strategy:          "Webhook",
+			webhookCABundle:   caBundle,
+			storedVersions:    []string{"v1alpha1", "v1beta1"},
+			expCABundle:       base64.StdEncoding.EncodeToString(caBundle),
+			expStoredVersions: []string{"v1alpha1"},
+			expMigrated:       true,
+		},
+		"no CA bundle yet": {
+			strategy:          "Webhook",
+			storedVersions:    []string{"v1alpha1", "v1beta1"},
+			expStoredVersions: []string{"v1alpha1", "v1beta1"},
+		},
+		"no conversion webhook": {
+			strategy:          "None",
+			webhookCABundle:   caBundle,
+			storedVersions:    []string{"v1alpha1", "v1beta1"},
+			expStoredVersions: []string{"v1alpha1", "v1beta1"},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(s))
+			require.NoError(t, v1alpha1.AddToScheme(s))
+
+			crd := newCRD()
+			crd.SetName("proxydefaults.consul.hashicorp.com")
+			crd.Object["spec"] = map[string]interface{}{
+				"group": v1alpha1.ConsulHashicorpGroup,
+				"names": map[string]interface{}{"kind": "ProxyDefaults"},
+				"conversion": map[string]interface{}{
+					"strategy": c.strategy,
+				},
+				"versions": []interface{}{
+					map[string]interface{}{"name": "v1alpha1", "storage": true},
+					map[string]interface{}{"name": "v1beta1", "storage": false},
+				},
+			}
+			require.NoError(t, unstructured.SetNestedStringSlice(crd.Object, c.storedVersions, "status", "storedVersions"))
+			webhookConfig := &admissionv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "consul-controller"},
+				Webhooks: []admissionv1.MutatingWebhook{
+					{
+						Name:         "mutate-proxydefaults.consul.hashicorp.com",
+						ClientConfig: admissionv1.WebhookClientConfig{CABundle: c.webhookCABundle},
+					},
+				},
+			}
+			proxyDefaults := &v1alpha1.ProxyDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "default"},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(crd, webhookConfig, proxyDefaults).Build()
+
+			r := &ConversionWebhookController{
+				Client:            fakeClient,
+				Log:               logrtest.TestLogger{T: t},
+				WebhookConfigName: "consul-controller",
+			}
+			resp, err := r.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: crd.GetName()},
+			})
+			require.NoError(t, err)
+			require.False(t, resp.Requeue)
+
+			updated := newCRD()
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: crd.GetName()}, updated))
+			actualCABundle, _, _ := unstructured.NestedString(updated.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+			require.Equal(t, c.expCABundle, actualCABundle)
+			storedVersions, _, _ := unstructured.NestedStringSlice(updated.Object, "status", "storedVersions")
+			require.Equal(t, c.expStoredVersions, storedVersions)
+
+			var actual v1alpha1.ProxyDefaults
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(proxyDefaults), &actual))
+			require.Equal(t, c.expMigrated, actual.ResourceVersion != proxyDefaults.ResourceVersion)
+		})
+	}
+}
+
+func TestConversionWebhookController_crdsForWebhookConfig(t *testing.T) {
+	crds := []client.Object{}
+	for name, strategy := range map[string]string{
+		"proxydefaults.consul.hashicorp.com":   "Webhook",
+		"servicedefaults.consul.hashicorp.com": "None",
+	} {
+		crd := newCRD()
+		crd.SetName(name)
+		crd.Object["spec"] = map[string]interface{}{
+			"group":      v1alpha1.ConsulHashicorpGroup,
+			"conversion": map[string]interface{}{"strategy": strategy},
+		}
+		crds = append(crds, crd)
+	}
+	r := &ConversionWebhookController{
+		Client:            fake.NewClientBuilder().WithObjects(crds...).Build(),
+		Log:               logrtest.TestLogger{T: t},
+		WebhookConfigName: "consul-controller",
+	}
+
+	requests := r.crdsForWebhookConfig(&admissionv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "consul-controller"},
+	})
+	require.Len(t, requests, 1)
+	require.Equal(t, "proxydefaults.consul.hashicorp.com", requests[0].Name)
+
+	require.Empty(t, r.crdsForWebhookConfig(&admissionv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+	}))
+}
@@ -0,0 +1,387 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/constants"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// TerminatingGatewayError is the reason used when the TerminatingGateway
+	// of an ExternalService resource can't be read or updated.
+	TerminatingGatewayError = "TerminatingGatewayError"
+	// ServiceDefaultsConflictError is the reason used when a ServiceDefaults
+	// resource for an external service already exists and isn't managed by
+	// its ExternalService resource.
+	ServiceDefaultsConflictError = "ServiceDefaultsConflictError"
+
+	// externalServicesNodeName is the Consul node that external services are
+	// registered on. The address of each service is set on the service.
+	externalServicesNodeName = "k8s-external-services"
+)
+
+// ExternalServiceController reconciles ExternalService resources. Each
+// service is either registered in the Consul catalog or, for destinations,
+// configured by a ServiceDefaults resource that the controller manages. The
+// service is then added to the services of its TerminatingGateway resource.
+// The ServiceDefaults and TerminatingGateway controllers write those
+// resources to Consul.
+type ExternalServiceController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// ConsulClientConfig is the config for the Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+
+	// EnableConsulNamespaces indicates that a user is running Consul Enterprise
+	// with version 1.7+ which supports namespaces.
+	EnableConsulNamespaces bool
+	// ConsulDestinationNamespace is the namespace services are registered in
+	// if mirroring is disabled.
+	ConsulDestinationNamespace string
+	// EnableNSMirroring causes Consul namespaces to be created to match the
+	// k8s namespace of the ExternalService resource.
+	EnableNSMirroring bool
+	// NSMirroringPrefix is an optional prefix that can be added to the Consul
+	// namespaces created while mirroring.
+	NSMirroringPrefix string
+	// CrossNSACLPolicy is the name of the ACL policy to attach to
+	// any created Consul namespaces to allow cross namespace service discovery.
+	CrossNSACLPolicy string
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=externalservices,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=externalservices/status,verbs=get;update;patch
+
+func (r *ExternalServiceController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var svc consulv1alpha1.ExternalService
+	err := r.Get(ctx, req.NamespacedName, &svc)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	consulClient, err := consulClientFromConnMgr(r.ConsulClientConfig, r.ConsulServerConnMgr)
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+	consulNS := namespaces.ConsulNamespace(svc.Namespace, r.EnableConsulNamespaces,
+		r.ConsulDestinationNamespace, r.EnableNSMirroring, r.NSMirroringPrefix)
+
+	if !svc.GetDeletionTimestamp().IsZero() {
+		if containsString(svc.Finalizers, FinalizerName) {
+			logger.Info("deletion event")
+			if err := r.unlink(ctx, &svc, svc.Status.TerminatingGateway, consulNS); err != nil {
+				return resourceSyncFailed(ctx, logger, r.Status(), &svc, TerminatingGatewayError, err)
+			}
+			if _, err := r.deregister(consulClient, &svc, consulNS); err != nil {
+				return resourceSyncFailed(ctx, logger, r.Status(), &svc, ConsulAgentError,
+					fmt.Errorf("deregistering service from consul: %w", err))
+			}
+			if _, err := r.syncServiceDefaults(ctx, &svc, false); err != nil {
+				return resourceSyncFailed(ctx, logger, r.Status(), &svc, ServiceDefaultsConflictError, err)
+			}
+			logger.Info("deletion from Consul successful")
+			controllerutil.RemoveFinalizer(&svc, FinalizerName)
+			if err := r.Update(ctx, &svc); err != nil {
+				return ctrl.Result{}, err
+			}
+			logger.Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !containsString(svc.Finalizers, FinalizerName) {
+		controllerutil.AddFinalizer(&svc, FinalizerName)
+		svc.SetSyncedCondition(corev1.ConditionUnknown, "", "")
+		if err := r.Update(ctx, &svc); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.EnableConsulNamespaces {
+		created, err := namespaces.EnsureExists(consulClient, consulNS, r.CrossNSACLPolicy)
+		if err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &svc, ConsulAgentError,
+				fmt.Errorf("creating consul namespace %q: %w", consulNS, err))
+		}
+		if created {
+			logger.Info("consul namespace created", "ns", consulNS)
+		}
+	}
+
+	// Destinations are dialed by their address so they aren't registered in
+	// the catalog.
+	var registered bool
+	if svc.Spec.Destination {
+		registered, err = r.deregister(consulClient, &svc, consulNS)
+	} else {
+		registered, err = r.register(consulClient, &svc, consulNS)
+	}
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &svc, ConsulAgentError,
+			fmt.Errorf("registering service in consul: %w", err))
+	}
+
+	wantDefaults := svc.Spec.Destination || svc.Spec.Protocol != ""
+	defaultsChanged, err := r.syncServiceDefaults(ctx, &svc, wantDefaults)
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &svc, ServiceDefaultsConflictError, err)
+	}
+
+	linkedGateway := svc.Status.TerminatingGateway
+	if linkedGateway != "" && linkedGateway != svc.Spec.TerminatingGateway {
+		if err := r.unlink(ctx, &svc, linkedGateway, consulNS); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &svc, TerminatingGatewayError, err)
+		}
+	}
+	linked, err := r.link(ctx, &svc, consulNS)
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &svc, TerminatingGatewayError, err)
+	}
+	svc.Status.TerminatingGateway = svc.Spec.TerminatingGateway
+
+	if registered || defaultsChanged || linked || linkedGateway != svc.Status.TerminatingGateway {
+		logger.Info("external service synced")
+	} else if svc.SyncedConditionStatus() == corev1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+	return resourceSyncSuccessful(ctx, r.Status(), &svc)
+}
+
+func (r *ExternalServiceController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&consulv1alpha1.ExternalService{}).
+		Owns(&consulv1alpha1.ServiceDefaults{}).
+		Watches(&source.Kind{Type: &consulv1alpha1.TerminatingGateway{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForGateway)).
+		WithOptions(controllerOptions()).
+		Complete(r)
+}
+
+// register registers svc on the external services node unless it is already
+// registered with the same address and port. It returns true if the catalog
+// was changed.
+func (r *ExternalServiceController) register(consulClient *capi.Client, svc *consulv1alpha1.ExternalService, consulNS string) (bool, error) {
+	existing, err := r.registeredService(consulClient, svc, consulNS)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil && existing.Address == svc.Spec.Address && existing.Port == int(svc.Spec.Port) {
+		return false, nil
+	}
+	_, err = consulClient.Catalog().Register(&capi.CatalogRegistration{
+		Node:           externalServicesNodeName,
+		Address:        "127.0.0.1",
+		SkipNodeUpdate: true,
+		NodeMeta: map[string]string{
+			common.SourceKey: common.SourceValue,
+			"external-node":  "true",
+		},
+		Service: &capi.AgentService{
+			ID:        externalServiceID(svc),
+			Service:   svc.ConsulName(),
+			Address:   svc.Spec.Address,
+			Port:      int(svc.Spec.Port),
+			Namespace: consulNS,
+			Meta: map[string]string{
+				common.SourceKey:        common.SourceValue,
+				constants.MetaKeyKubeNS: svc.Namespace,
+			},
+		},
+	}, nil)
+	return err == nil, err
+}
+
+// deregister removes svc from the catalog if it is registered. It returns
+// true if the catalog was changed.
+func (r *ExternalServiceController) deregister(consulClient *capi.Client, svc *consulv1alpha1.ExternalService, consulNS string) (bool, error) {
+	existing, err := r.registeredService(consulClient, svc, consulNS)
+	if err != nil || existing == nil {
+		return false, err
+	}
+	_, err = consulClient.Catalog().Deregister(&capi.CatalogDeregistration{
+		Node:      externalServicesNodeName,
+		ServiceID: existing.ID,
+		Namespace: consulNS,
+	}, nil)
+	return err == nil, err
+}
+
+// registeredService returns the registration of svc on the external services
+// node, or nil if it isn't registered.
+func (r *ExternalServiceController) registeredService(consulClient *capi.Client, svc *consulv1alpha1.ExternalService, consulNS string) (*capi.AgentService, error) {
+	node, _, err := consulClient.Catalog().Node(externalServicesNodeName, &capi.QueryOptions{Namespace: consulNS})
+	if err != nil || node == nil {
+		return nil, err
+	}
+	return node.Services[externalServiceID(svc)], nil
+}
+
+// syncServiceDefaults creates or updates the ServiceDefaults resource of svc,
+// or deletes it if it is not wanted. ServiceDefaults resources that weren't
+// created for svc are never changed. It returns true if the resource changed.
+func (r *ExternalServiceController) syncServiceDefaults(ctx context.Context, svc *consulv1alpha1.ExternalService, want bool) (bool, error) {
+	defaults := &consulv1alpha1.ServiceDefaults{ObjectMeta: metav1.ObjectMeta{Name: svc.Name, Namespace: svc.Namespace}}
+	err := r.Get(ctx, client.ObjectKeyFromObject(defaults), defaults)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return false, fmt.Errorf("reading ServiceDefaults %q: %w", svc.Name, err)
+	}
+	exists := err == nil
+	if exists && !metav1.IsControlledBy(defaults, svc) {
+		if want {
+			return false, fmt.Errorf("ServiceDefaults %q already exists and is not managed by this resource", svc.Name)
+		}
+		return false, nil
+	}
+
+	if !want {
+		if !exists {
+			return false, nil
+		}
+		if err := r.Delete(ctx, defaults); err != nil && !k8serr.IsNotFound(err) {
+			return false, fmt.Errorf("deleting ServiceDefaults %q: %w", svc.Name, err)
+		}
+		return true, nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, defaults, func() error {
+		defaults.Spec.Protocol = svc.Spec.Protocol
+		defaults.Spec.Destination = nil
+		if svc.Spec.Destination {
+			defaults.Spec.Destination = &consulv1alpha1.ServiceDefaultsDestination{
+				Addresses: []string{svc.Spec.Address},
+				Port:      uint32(svc.Spec.Port),
+			}
+		}
+		return ctrl.SetControllerReference(svc, defaults, r.Scheme)
+	})
+	if err != nil {
+		return false, fmt.Errorf("writing ServiceDefaults %q: %w", svc.Name, err)
+	}
+	return result != controllerutil.OperationResultNone, nil
+}
+
+// link adds svc to the services of its TerminatingGateway resource, or
+// updates its entry there. It returns true if the gateway changed.
+func (r *ExternalServiceController) link(ctx context.Context, svc *consulv1alpha1.ExternalService, consulNS string) (bool, error) {
+	var gateway consulv1alpha1.TerminatingGateway
+	name := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Spec.TerminatingGateway}
+	if err := r.Get(ctx, name, &gateway); err != nil {
+		return false, fmt.Errorf("reading TerminatingGateway %q: %w", svc.Spec.TerminatingGateway, err)
+	}
+
+	desired := svc.LinkedService(r.linkedNamespace(consulNS))
+	found := false
+	for i, linked := range gateway.Spec.Services {
+		if linked.Name != desired.Name || linked.Namespace != desired.Namespace {
+			continue
+		}
+		if linked == desired {
+			return false, nil
+		}
+		gateway.Spec.Services[i] = desired
+		found = true
+		break
+	}
+	if !found {
+		gateway.Spec.Services = append(gateway.Spec.Services, desired)
+	}
+	if err := r.Update(ctx, &gateway); err != nil {
+		return false, fmt.Errorf("updating TerminatingGateway %q: %w", gateway.Name, err)
+	}
+	return true, nil
+}
+
+// unlink removes svc from the services of the TerminatingGateway resource
+// with the given name, if it still exists.
+func (r *ExternalServiceController) unlink(ctx context.Context, svc *consulv1alpha1.ExternalService, gatewayName, consulNS string) error {
+	if gatewayName == "" {
+		return nil
+	}
+	var gateway consulv1alpha1.TerminatingGateway
+	err := r.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: gatewayName}, &gateway)
+	if k8serr.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("reading TerminatingGateway %q: %w", gatewayName, err)
+	}
+
+	linkedNS := r.linkedNamespace(consulNS)
+	services := gateway.Spec.Services[:0]
+	for _, linked := range gateway.Spec.Services {
+		if linked.Name != svc.ConsulName() || linked.Namespace != linkedNS {
+			services = append(services, linked)
+		}
+	}
+	if len(services) == len(gateway.Spec.Services) {
+		return nil
+	}
+	gateway.Spec.Services = services
+	if err := r.Update(ctx, &gateway); err != nil {
+		return fmt.Errorf("updating TerminatingGateway %q: %w", gatewayName, err)
+	}
+	return nil
+}
+
+// linkedNamespace returns the namespace of the service in the terminating
+// gateway, which is empty unless Consul namespaces are enabled.
+func (r *ExternalServiceController) linkedNamespace(consulNS string) string {
+	if !r.EnableConsulNamespaces {
+		return ""
+	}
+	return consulNS
+}
+
+// requestsForGateway returns requests for the ExternalService resources in
+// the same namespace as a TerminatingGateway that are linked to it, so that
+// their entries are restored if the gateway is edited.
+func (r *ExternalServiceController) requestsForGateway(object client.Object) []reconcile.Request {
+	var list consulv1alpha1.ExternalServiceList
+	if err := r.List(context.Background(), &list, client.InNamespace(object.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list ExternalService resources")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, svc := range list.Items {
+		if svc.Spec.TerminatingGateway == object.GetName() || svc.Status.TerminatingGateway == object.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// externalServiceID returns the ID of the catalog registration of svc. It
+// includes the Kubernetes namespace because all external services are
+// registered on the same node.
+func externalServiceID(svc *consulv1alpha1.ExternalService) string {
+	return fmt.Sprintf("%s-%s", svc.ConsulName(), svc.Namespace)
+}
@@ -0,0 +1,291 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExternalServiceController_registersAndLinksService(t *testing.T) {
+	t.Parallel()
+
+	svc := &v1alpha1.ExternalService{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: v1alpha1.ExternalServiceSpec{
+			TerminatingGateway: "terminating-gateway",
+			Address:            "api.example.com",
+			Port:               443,
+			CAFile:             "/etc/ssl/ca.pem",
+			SNI:                "api.example.com",
+		},
+	}
+	gateway := &v1alpha1.TerminatingGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "terminating-gateway", Namespace: "default"},
+		Spec: v1alpha1.TerminatingGatewaySpec{
+			Services: []v1alpha1.LinkedService{{Name: "other"}},
+		},
+	}
+	r, consulClient := externalServiceTestController(t, svc, gateway)
+	ctx := context.Background()
+
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+	require.Equal(t, "terminating-gateway", svc.Status.TerminatingGateway)
+	registered := requireExternalServiceRegistration(t, consulClient, "api-default")
+	require.Equal(t, "api", registered.Service)
+	require.Equal(t, "api.example.com", registered.Address)
+	require.Equal(t, 443, registered.Port)
+	require.Equal(t, []v1alpha1.LinkedService{
+		{Name: "other"},
+		{Name: "api", CAFile: "/etc/ssl/ca.pem", SNI: "api.example.com"},
+	}, requireTerminatingGateway(t, r, "terminating-gateway").Spec.Services)
+	requireNoServiceDefaults(t, r, "api")
+
+	// Changes to the resource are applied to the registration and the link.
+	svc.Spec.Port = 8443
+	svc.Spec.SNI = ""
+	require.NoError(t, r.Update(ctx, svc))
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+	require.Equal(t, 8443, requireExternalServiceRegistration(t, consulClient, "api-default").Port)
+	require.Equal(t, []v1alpha1.LinkedService{
+		{Name: "other"},
+		{Name: "api", CAFile: "/etc/ssl/ca.pem"},
+	}, requireTerminatingGateway(t, r, "terminating-gateway").Spec.Services)
+
+	// Links that are removed from the gateway are restored.
+	gateway = requireTerminatingGateway(t, r, "terminating-gateway")
+	gateway.Spec.Services = gateway.Spec.Services[:1]
+	require.NoError(t, r.Update(ctx, gateway))
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+	require.Len(t, requireTerminatingGateway(t, r, "terminating-gateway").Spec.Services, 2)
+}
+
+func TestExternalServiceController_destination(t *testing.T) {
+	t.Parallel()
+
+	svc := &v1alpha1.ExternalService{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", UID: "uid"},
+		Spec: v1alpha1.ExternalServiceSpec{
+			TerminatingGateway: "terminating-gateway",
+			Address:            "api.example.com",
+			Port:               443,
+			Protocol:           "http",
+		},
+	}
+	gateway := &v1alpha1.TerminatingGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "terminating-gateway", Namespace: "default"},
+	}
+	r, consulClient := externalServiceTestController(t, svc, gateway)
+	ctx := context.Background()
+
+	// Services with a protocol get a ServiceDefaults resource.
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+	defaults := requireServiceDefaults(t, r, "api")
+	require.Equal(t, "http", defaults.Spec.Protocol)
+	require.Nil(t, defaults.Spec.Destination)
+	require.True(t, metav1.IsControlledBy(defaults, svc))
+	requireExternalServiceRegistration(t, consulClient, "api-default")
+
+	// Destinations are configured by the ServiceDefaults resource instead of
+	// the catalog.
+	svc.Spec.Destination = true
+	require.NoError(t, r.Update(ctx, svc))
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+	defaults = requireServiceDefaults(t, r, "api")
+	require.Equal(t, &v1alpha1.ServiceDefaultsDestination{
+		Addresses: []string{"api.example.com"},
+		Port:      443,
+	}, defaults.Spec.Destination)
+	node, _, err := consulClient.Catalog().Node(externalServicesNodeName, nil)
+	require.NoError(t, err)
+	require.NotContains(t, node.Services, "api-default")
+	require.Equal(t, []v1alpha1.LinkedService{{Name: "api"}},
+		requireTerminatingGateway(t, r, "terminating-gateway").Spec.Services)
+
+	// Without a protocol or destination the resource is deleted.
+	svc.Spec.Destination = false
+	svc.Spec.Protocol = ""
+	require.NoError(t, r.Update(ctx, svc))
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+	requireNoServiceDefaults(t, r, "api")
+	requireExternalServiceRegistration(t, consulClient, "api-default")
+}
+
+func TestExternalServiceController_serviceDefaultsConflict(t *testing.T) {
+	t.Parallel()
+
+	svc := &v1alpha1.ExternalService{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: v1alpha1.ExternalServiceSpec{
+			TerminatingGateway: "terminating-gateway",
+			Address:            "api.example.com",
+			Port:               443,
+			Protocol:           "http",
+		},
+	}
+	defaults := &v1alpha1.ServiceDefaults{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec:       v1alpha1.ServiceDefaultsSpec{Protocol: "tcp"},
+	}
+	gateway := &v1alpha1.TerminatingGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "terminating-gateway", Namespace: "default"},
+	}
+	r, _ := externalServiceTestController(t, svc, defaults, gateway)
+
+	reconcileExternalService(t, r, svc, corev1.ConditionFalse, ServiceDefaultsConflictError)
+	require.Equal(t, `ServiceDefaults "api" already exists and is not managed by this resource`, svc.Status.Conditions[0].Message)
+	require.Equal(t, "tcp", requireServiceDefaults(t, r, "api").Spec.Protocol)
+}
+
+func TestExternalServiceController_missingGateway(t *testing.T) {
+	t.Parallel()
+
+	svc := &v1alpha1.ExternalService{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: v1alpha1.ExternalServiceSpec{
+			TerminatingGateway: "terminating-gateway",
+			Address:            "api.example.com",
+			Port:               443,
+		},
+	}
+	r, _ := externalServiceTestController(t, svc)
+
+	reconcileExternalService(t, r, svc, corev1.ConditionFalse, TerminatingGatewayError)
+	require.Contains(t, svc.Status.Conditions[0].Message, `reading TerminatingGateway "terminating-gateway"`)
+}
+
+func TestExternalServiceController_changesGateway(t *testing.T) {
+	t.Parallel()
+
+	svc := &v1alpha1.ExternalService{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: v1alpha1.ExternalServiceSpec{
+			TerminatingGateway: "gateway-1",
+			Address:            "api.example.com",
+			Port:               443,
+		},
+	}
+	gateway1 := &v1alpha1.TerminatingGateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway-1", Namespace: "default"}}
+	gateway2 := &v1alpha1.TerminatingGateway{ObjectMeta: metav1.ObjectMeta{Name: "gateway-2", Namespace: "default"}}
+	r, _ := externalServiceTestController(t, svc, gateway1, gateway2)
+
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+	require.Len(t, requireTerminatingGateway(t, r, "gateway-1").Spec.Services, 1)
+
+	svc.Spec.TerminatingGateway = "gateway-2"
+	require.NoError(t, r.Update(context.Background(), svc))
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+	require.Equal(t, "gateway-2", svc.Status.TerminatingGateway)
+	require.Empty(t, requireTerminatingGateway(t, r, "gateway-1").Spec.Services)
+	require.Len(t, requireTerminatingGateway(t, r, "gateway-2").Spec.Services, 1)
+}
+
+func TestExternalServiceController_deletesService(t *testing.T) {
+	t.Parallel()
+
+	svc := &v1alpha1.ExternalService{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: v1alpha1.ExternalServiceSpec{
+			TerminatingGateway: "terminating-gateway",
+			Address:            "api.example.com",
+			Port:               443,
+			Protocol:           "http",
+		},
+	}
+	gateway := &v1alpha1.TerminatingGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "terminating-gateway", Namespace: "default"},
+		Spec: v1alpha1.TerminatingGatewaySpec{
+			Services: []v1alpha1.LinkedService{{Name: "other"}},
+		},
+	}
+	r, consulClient := externalServiceTestController(t, svc, gateway)
+	ctx := context.Background()
+	reconcileExternalService(t, r, svc, corev1.ConditionTrue, "")
+
+	require.NoError(t, r.Delete(ctx, svc))
+	namespacedName := types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+
+	err = r.Get(ctx, namespacedName, svc)
+	require.True(t, k8serr.IsNotFound(err))
+	node, _, err := consulClient.Catalog().Node(externalServicesNodeName, nil)
+	require.NoError(t, err)
+	require.NotContains(t, node.Services, "api-default")
+	require.Equal(t, []v1alpha1.LinkedService{{Name: "other"}},
+		requireTerminatingGateway(t, r, "terminating-gateway").Spec.Services)
+	requireNoServiceDefaults(t, r, "api")
+}
+
+func externalServiceTestController(t *testing.T, objs ...runtime.Object) (*ExternalServiceController, *capi.Client) {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForLeader(t)
+	return &ExternalServiceController{
+		Client:              fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build(),
+		Log:                 logrtest.TestLogger{T: t},
+		Scheme:              s,
+		ConsulClientConfig:  testClient.Cfg,
+		ConsulServerConnMgr: testClient.Watcher,
+	}, testClient.APIClient
+}
+
+// reconcileExternalService reconciles svc and refreshes it from Kubernetes. It
+// checks the synced condition has the expected status and reason.
+func reconcileExternalService(t *testing.T, r *ExternalServiceController, svc *v1alpha1.ExternalService, expStatus corev1.ConditionStatus, expReason string) {
+	t.Helper()
+	namespacedName := types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: namespacedName})
+	if expStatus == corev1.ConditionTrue {
+		require.NoError(t, err)
+	} else {
+		require.Error(t, err)
+	}
+	require.NoError(t, r.Get(context.Background(), namespacedName, svc))
+	require.Equal(t, expStatus, svc.SyncedConditionStatus())
+	require.Equal(t, expReason, svc.Status.Conditions[0].Reason)
+	require.Contains(t, svc.Finalizers, FinalizerName)
+}
+
+func requireExternalServiceRegistration(t *testing.T, consulClient *capi.Client, id string) *capi.AgentService {
+	t.Helper()
+	node, _, err := consulClient.Catalog().Node(externalServicesNodeName, nil)
+	require.NoError(t, err)
+	require.NotNil(t, node)
+	require.Contains(t, node.Services, id)
+	return node.Services[id]
+}
+
+func requireTerminatingGateway(t *testing.T, r *ExternalServiceController, name string) *v1alpha1.TerminatingGateway {
+	t.Helper()
+	var gateway v1alpha1.TerminatingGateway
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &gateway))
+	return &gateway
+}
+
+func requireServiceDefaults(t *testing.T, r *ExternalServiceController, name string) *v1alpha1.ServiceDefaults {
+	t.Helper()
+	var defaults v1alpha1.ServiceDefaults
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &defaults))
+	return &defaults
+}
+
+func requireNoServiceDefaults(t *testing.T, r *ExternalServiceController, name string) {
+	t.Helper()
+	var defaults v1alpha1.ServiceDefaults
+	err := r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &defaults)
+	require.True(t, k8serr.IsNotFound(err))
+}
@@ -2,14 +2,24 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
 )
 
 // IngressGatewayController is the controller for IngressGateway resources.
@@ -18,13 +28,40 @@ type IngressGatewayController struct {
 	Log                   logr.Logger
 	Scheme                *runtime.Scheme
 	ConfigEntryController *ConfigEntryController
+
+	// Deployment configures the gateway pods deployed for IngressGateway
+	// resources with a deployment section. If nil, the controller only syncs
+	// the config entries and the gateways must be deployed separately.
+	Deployment *IngressGatewayDeploymentConfig
 }
 
 // +kubebuilder:rbac:groups=consul.hashicorp.com,resources=ingressgateways,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=consul.hashicorp.com,resources=ingressgateways/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 
 func (r *IngressGatewayController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	return r.ConfigEntryController.ReconcileEntry(ctx, r, req, &consulv1alpha1.IngressGateway{})
+	result, err := r.ConfigEntryController.ReconcileEntry(ctx, r, req, &consulv1alpha1.IngressGateway{})
+	if err != nil || r.Deployment == nil {
+		return result, err
+	}
+
+	var gw consulv1alpha1.IngressGateway
+	if err := r.Get(ctx, req.NamespacedName, &gw); err != nil {
+		return result, client.IgnoreNotFound(err)
+	}
+	// The resources of deleted gateways are garbage collected through their
+	// owner references.
+	if !gw.GetDeletionTimestamp().IsZero() {
+		return result, nil
+	}
+	if err := r.reconcileDeployment(ctx, &gw); err != nil {
+		r.Logger(req.NamespacedName).Error(err, "failed to deploy ingress gateway")
+		return ctrl.Result{}, err
+	}
+	return result, nil
 }
 
 func (r *IngressGatewayController) Logger(name types.NamespacedName) logr.Logger {
@@ -36,5 +73,190 @@ func (r *IngressGatewayController) UpdateStatus(ctx context.Context, obj client.
 }
 
 func (r *IngressGatewayController) SetupWithManager(mgr ctrl.Manager) error {
-	return setupWithManager(mgr, &consulv1alpha1.IngressGateway{}, r)
+	if r.Deployment == nil {
+		return setupWithManager(mgr, &consulv1alpha1.IngressGateway{}, r)
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&consulv1alpha1.IngressGateway{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv1.HorizontalPodAutoscaler{}).
+		WithOptions(controllerOptions()).
+		Complete(r)
+}
+
+// reconcileDeployment creates or updates the ServiceAccount, Service,
+// Deployment, PodDisruptionBudget and HorizontalPodAutoscaler of gw. The
+// resources the deployment section of gw no longer asks for are deleted.
+func (r *IngressGatewayController) reconcileDeployment(ctx context.Context, gw *consulv1alpha1.IngressGateway) error {
+	d := gw.Spec.Deployment
+	if d == nil {
+		return r.deleteOwned(ctx, gw,
+			&appsv1.Deployment{}, &corev1.Service{}, &corev1.ServiceAccount{},
+			&policyv1.PodDisruptionBudget{}, &autoscalingv1.HorizontalPodAutoscaler{})
+	}
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sa, func() error {
+		if err := r.checkControlled(gw, sa, "ServiceAccount"); err != nil {
+			return err
+		}
+		sa.Labels = ingressGatewayLabels(gw)
+		return ctrl.SetControllerReference(gw, sa, r.Scheme)
+	}); err != nil {
+		return err
+	}
+
+	desiredSvc := ingressGatewayService(gw)
+	svcHash, err := ingressGatewayConfigHash(desiredSvc)
+	if err != nil {
+		return err
+	}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		if err := r.checkControlled(gw, svc, "Service"); err != nil {
+			return err
+		}
+		// Keep the node ports the API server allocated for ports that
+		// didn't change.
+		nodePorts := make(map[int32]int32)
+		for _, p := range svc.Spec.Ports {
+			nodePorts[p.Port] = p.NodePort
+		}
+		ports := desiredSvc.Spec.Ports
+		for i := range ports {
+			ports[i].NodePort = nodePorts[ports[i].Port]
+		}
+		if svc.Annotations[annotationIngressGatewayConfigHash] != svcHash || serviceDrifted(svc.Spec, desiredSvc.Spec) {
+			svc.Labels = desiredSvc.Labels
+			svc.Annotations = desiredSvc.Annotations
+			svc.Spec.Type = desiredSvc.Spec.Type
+			svc.Spec.Selector = desiredSvc.Spec.Selector
+			svc.Spec.Ports = ports
+			metav1.SetMetaDataAnnotation(&svc.ObjectMeta, annotationIngressGatewayConfigHash, svcHash)
+		}
+		return ctrl.SetControllerReference(gw, svc, r.Scheme)
+	}); err != nil {
+		return err
+	}
+
+	cec := r.ConfigEntryController
+	consulNS := namespaces.ConsulNamespace(gw.Namespace, cec.EnableConsulNamespaces,
+		cec.ConsulDestinationNamespace, cec.EnableNSMirroring, cec.NSMirroringPrefix)
+	desiredDeployment, err := r.Deployment.ingressGatewayDeployment(gw, consulNS, cec.EnableConsulNamespaces, cec.EnableNSMirroring)
+	if err != nil {
+		return err
+	}
+	deploymentHash, err := ingressGatewayConfigHash(desiredDeployment.Spec)
+	if err != nil {
+		return err
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		if err := r.checkControlled(gw, deployment, "Deployment"); err != nil {
+			return err
+		}
+		if deployment.Annotations[annotationIngressGatewayConfigHash] != deploymentHash || deploymentDrifted(deployment.Spec, desiredDeployment.Spec, d.Autoscaling != nil) {
+			// The replicas of autoscaled gateways are owned by the autoscaler.
+			replicas := deployment.Spec.Replicas
+			deployment.Labels = desiredDeployment.Labels
+			deployment.Spec = desiredDeployment.Spec
+			if d.Autoscaling != nil && replicas != nil {
+				deployment.Spec.Replicas = replicas
+			}
+			metav1.SetMetaDataAnnotation(&deployment.ObjectMeta, annotationIngressGatewayConfigHash, deploymentHash)
+		}
+		return ctrl.SetControllerReference(gw, deployment, r.Scheme)
+	}); err != nil {
+		return err
+	}
+
+	if d.PodDisruptionBudget == nil {
+		if err := r.deleteOwned(ctx, gw, &policyv1.PodDisruptionBudget{}); err != nil {
+			return err
+		}
+	} else {
+		desiredPDB := ingressGatewayPodDisruptionBudget(gw)
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, pdb, func() error {
+			if err := r.checkControlled(gw, pdb, "PodDisruptionBudget"); err != nil {
+				return err
+			}
+			pdb.Labels = desiredPDB.Labels
+			pdb.Spec = desiredPDB.Spec
+			return ctrl.SetControllerReference(gw, pdb, r.Scheme)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.Autoscaling == nil {
+		return r.deleteOwned(ctx, gw, &autoscalingv1.HorizontalPodAutoscaler{})
+	}
+	desiredHPA := ingressGatewayAutoscaler(gw)
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, hpa, func() error {
+		if err := r.checkControlled(gw, hpa, "HorizontalPodAutoscaler"); err != nil {
+			return err
+		}
+		hpa.Labels = desiredHPA.Labels
+		hpa.Spec = desiredHPA.Spec
+		return ctrl.SetControllerReference(gw, hpa, r.Scheme)
+	})
+	return err
+}
+
+// serviceDrifted returns true if the fields of the Service's spec that the
+// controller sets were changed since it last wrote them. Node ports are only
+// compared if the desired spec sets them.
+func serviceDrifted(current, desired corev1.ServiceSpec) bool {
+	return current.Type != desired.Type ||
+		!equality.Semantic.DeepEqual(current.Selector, desired.Selector) ||
+		!equality.Semantic.DeepDerivative(desired.Ports, current.Ports) ||
+		len(desired.Ports) != len(current.Ports)
+}
+
+// deploymentDrifted returns true if the fields of the Deployment's spec that
+// the controller sets were changed since it last wrote them. Fields the
+// controller leaves unset are ignored so that defaults filled in by the API
+// server don't count as drift, as are the replicas of autoscaled gateways and
+// map entries added by others, such as the pod template annotation of
+// kubectl rollout restart.
+func deploymentDrifted(current, desired appsv1.DeploymentSpec, autoscaled bool) bool {
+	if autoscaled {
+		desired.Replicas = current.Replicas
+	}
+	return !equality.Semantic.DeepDerivative(desired, current) ||
+		len(desired.Template.Spec.Containers) != len(current.Template.Spec.Containers) ||
+		len(desired.Template.Spec.InitContainers) != len(current.Template.Spec.InitContainers)
+}
+
+// checkControlled returns an error if obj exists and isn't controlled by gw,
+// so that resources the gateway didn't create are never overwritten.
+func (r *IngressGatewayController) checkControlled(gw *consulv1alpha1.IngressGateway, obj client.Object, kind string) error {
+	if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, gw) {
+		return fmt.Errorf("%s %q already exists and is not managed by IngressGateway %q", kind, obj.GetName(), gw.Name)
+	}
+	return nil
+}
+
+// deleteOwned deletes the resources named after gw that gw controls. objs
+// must be empty objects of the kinds to delete.
+func (r *IngressGatewayController) deleteOwned(ctx context.Context, gw *consulv1alpha1.IngressGateway, objs ...client.Object) error {
+	for _, obj := range objs {
+		err := r.Get(ctx, types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}, obj)
+		if k8serr.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(obj, gw) {
+			continue
+		}
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
 }
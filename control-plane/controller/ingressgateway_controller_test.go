@@ -0,0 +1,273 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/constants"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIngressGatewayController_deploysGateway(t *testing.T) {
+	t.Parallel()
+
+	minAvailable := intstr.FromInt(1)
+	gw := testIngressGateway()
+	gw.Spec.Deployment = &v1alpha1.IngressGatewayDeployment{
+		Replicas:            pointer.Int32(2),
+		ServiceAnnotations:  map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+		PodDisruptionBudget: &v1alpha1.IngressGatewayPodDisruptionBudget{MinAvailable: &minAvailable},
+	}
+	r, consulClient := ingressGatewayTestController(t, gw)
+	ctx := context.Background()
+
+	reconcileIngressGateway(t, r, gw)
+	entry, _, err := consulClient.ConfigEntries().Get(capi.IngressGateway, "ingress", nil)
+	require.NoError(t, err)
+	require.Len(t, entry.(*capi.IngressGatewayConfigEntry).Listeners, 2)
+
+	sa := &corev1.ServiceAccount{}
+	requireIngressGatewayResource(t, r, gw, sa)
+
+	svc := &corev1.Service{}
+	requireIngressGatewayResource(t, r, gw, svc)
+	require.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	require.Equal(t, "nlb", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-type"])
+	require.Equal(t, []int32{8080, 9090}, servicePorts(svc))
+
+	deployment := &appsv1.Deployment{}
+	requireIngressGatewayResource(t, r, gw, deployment)
+	require.Equal(t, int32(2), *deployment.Spec.Replicas)
+	pod := deployment.Spec.Template
+	require.Equal(t, "ingress-gateway", pod.Annotations[constants.AnnotationGatewayKind])
+	require.Equal(t, "ingress", pod.Annotations[constants.AnnotationGatewayConsulServiceName])
+	require.Equal(t, "Service", pod.Annotations[constants.AnnotationGatewayWANSource])
+	require.Equal(t, "8080", pod.Annotations[constants.AnnotationGatewayWANPort])
+	require.Equal(t, "ingress", pod.Spec.ServiceAccountName)
+	require.Equal(t, deployment.Spec.Selector.MatchLabels, svc.Spec.Selector)
+	require.Equal(t, "hashicorp/consul-dataplane", pod.Spec.Containers[0].Image)
+	require.Equal(t, "hashicorp/consul-k8s-control-plane", pod.Spec.InitContainers[0].Image)
+
+	pdb := &policyv1.PodDisruptionBudget{}
+	requireIngressGatewayResource(t, r, gw, pdb)
+	require.Equal(t, &minAvailable, pdb.Spec.MinAvailable)
+	require.True(t, k8serr.IsNotFound(r.Get(ctx, types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}, &autoscalingv1.HorizontalPodAutoscaler{})))
+
+	// Adding a listener exposes its port.
+	gw.Spec.Listeners = append(gw.Spec.Listeners, v1alpha1.IngressListener{
+		Port:     9091,
+		Protocol: "tcp",
+		Services: []v1alpha1.IngressService{{Name: "cache"}},
+	})
+	require.NoError(t, r.Update(ctx, gw))
+	reconcileIngressGateway(t, r, gw)
+	requireIngressGatewayResource(t, r, gw, svc)
+	require.Equal(t, []int32{8080, 9090, 9091}, servicePorts(svc))
+	requireIngressGatewayResource(t, r, gw, deployment)
+	require.Len(t, deployment.Spec.Template.Spec.Containers[0].Ports, 4)
+
+	// Autoscaled gateways keep the replicas set by the autoscaler.
+	deployment.Spec.Replicas = pointer.Int32(3)
+	require.NoError(t, r.Update(ctx, deployment))
+	gw.Spec.Deployment.Autoscaling = &v1alpha1.IngressGatewayAutoscaling{MinReplicas: pointer.Int32(2), MaxReplicas: 5}
+	gw.Spec.Deployment.PodDisruptionBudget = nil
+	require.NoError(t, r.Update(ctx, gw))
+	reconcileIngressGateway(t, r, gw)
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{}
+	requireIngressGatewayResource(t, r, gw, hpa)
+	require.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+	require.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	require.Equal(t, int32(80), *hpa.Spec.TargetCPUUtilizationPercentage)
+	require.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+	requireIngressGatewayResource(t, r, gw, deployment)
+	require.Equal(t, int32(3), *deployment.Spec.Replicas)
+	require.True(t, k8serr.IsNotFound(r.Get(ctx, types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}, &policyv1.PodDisruptionBudget{})))
+
+	// Removing the deployment section removes the gateway pods.
+	gw.Spec.Deployment = nil
+	require.NoError(t, r.Update(ctx, gw))
+	reconcileIngressGateway(t, r, gw)
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.ServiceAccount{}, &autoscalingv1.HorizontalPodAutoscaler{}} {
+		err := r.Get(ctx, types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}, obj)
+		require.True(t, k8serr.IsNotFound(err), "%T was not deleted", obj)
+	}
+}
+
+func TestIngressGatewayController_revertsEdits(t *testing.T) {
+	t.Parallel()
+
+	gw := testIngressGateway()
+	gw.Spec.Deployment = &v1alpha1.IngressGatewayDeployment{Replicas: pointer.Int32(2)}
+	r, _ := ingressGatewayTestController(t, gw)
+	ctx := context.Background()
+	reconcileIngressGateway(t, r, gw)
+
+	// Defaults filled in by the API server don't cause updates.
+	svc := &corev1.Service{}
+	requireIngressGatewayResource(t, r, gw, svc)
+	svc.Spec.ClusterIP = "10.0.0.1"
+	svc.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	for i := range svc.Spec.Ports {
+		svc.Spec.Ports[i].NodePort = 30000 + int32(i)
+	}
+	require.NoError(t, r.Update(ctx, svc))
+	deployment := &appsv1.Deployment{}
+	requireIngressGatewayResource(t, r, gw, deployment)
+	deployment.Spec.RevisionHistoryLimit = pointer.Int32(10)
+	deployment.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+	deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+	deployment.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = "2023-01-01T00:00:00Z"
+	require.NoError(t, r.Update(ctx, deployment))
+	svcVersion, deploymentVersion := svc.ResourceVersion, deployment.ResourceVersion
+	reconcileIngressGateway(t, r, gw)
+	requireIngressGatewayResource(t, r, gw, svc)
+	require.Equal(t, svcVersion, svc.ResourceVersion)
+	requireIngressGatewayResource(t, r, gw, deployment)
+	require.Equal(t, deploymentVersion, deployment.ResourceVersion)
+
+	// Edits to the fields the controller sets are reverted.
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Selector["app"] = "other"
+	svc.Spec.Ports = svc.Spec.Ports[:1]
+	require.NoError(t, r.Update(ctx, svc))
+	deployment.Spec.Replicas = pointer.Int32(5)
+	deployment.Spec.Template.Spec.Containers[0].Image = "other"
+	deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, corev1.Container{Name: "sidecar", Image: "sidecar"})
+	require.NoError(t, r.Update(ctx, deployment))
+	reconcileIngressGateway(t, r, gw)
+	requireIngressGatewayResource(t, r, gw, svc)
+	require.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	require.Equal(t, deployment.Spec.Selector.MatchLabels, svc.Spec.Selector)
+	require.Equal(t, []int32{8080, 9090}, servicePorts(svc))
+	require.Equal(t, int32(30000), svc.Spec.Ports[0].NodePort)
+	requireIngressGatewayResource(t, r, gw, deployment)
+	require.Equal(t, int32(2), *deployment.Spec.Replicas)
+	require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	require.Equal(t, "hashicorp/consul-dataplane", deployment.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestIngressGatewayController_unmanagedResources(t *testing.T) {
+	t.Parallel()
+
+	gw := testIngressGateway()
+	gw.Spec.Deployment = &v1alpha1.IngressGatewayDeployment{}
+	// A Service with the name of the gateway that the gateway doesn't control.
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	r, _ := ingressGatewayTestController(t, gw, svc)
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}})
+	require.EqualError(t, err, `Service "ingress" already exists and is not managed by IngressGateway "ingress"`)
+
+	// Unmanaged resources aren't deleted with the deployment section.
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}, gw))
+	gw.Spec.Deployment = nil
+	require.NoError(t, r.Update(context.Background(), gw))
+	reconcileIngressGateway(t, r, gw)
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}, svc))
+}
+
+func TestIngressGatewayController_deploymentsDisabled(t *testing.T) {
+	t.Parallel()
+
+	gw := testIngressGateway()
+	gw.Spec.Deployment = &v1alpha1.IngressGatewayDeployment{}
+	r, _ := ingressGatewayTestController(t, gw)
+	r.Deployment = nil
+
+	reconcileIngressGateway(t, r, gw)
+	err := r.Get(context.Background(), types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}, &appsv1.Deployment{})
+	require.True(t, k8serr.IsNotFound(err))
+}
+
+func testIngressGateway() *v1alpha1.IngressGateway {
+	return &v1alpha1.IngressGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "default"},
+		Spec: v1alpha1.IngressGatewaySpec{
+			Listeners: []v1alpha1.IngressListener{
+				{
+					Port:     8080,
+					Protocol: "tcp",
+					Services: []v1alpha1.IngressService{{Name: "web"}},
+				},
+				{
+					Port:     9090,
+					Protocol: "tcp",
+					Services: []v1alpha1.IngressService{{Name: "api"}},
+				},
+			},
+		},
+	}
+}
+
+func ingressGatewayTestController(t *testing.T, objs ...runtime.Object) (*IngressGatewayController, *capi.Client) {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForLeader(t)
+	return &IngressGatewayController{
+		Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build(),
+		Log:    logrtest.TestLogger{T: t},
+		Scheme: s,
+		ConfigEntryController: &ConfigEntryController{
+			ConsulClientConfig:  testClient.Cfg,
+			ConsulServerConnMgr: testClient.Watcher,
+			DatacenterName:      "datacenter",
+		},
+		Deployment: &IngressGatewayDeploymentConfig{
+			ImageConsulDataplane: "hashicorp/consul-dataplane",
+			ImageConsulK8S:       "hashicorp/consul-k8s-control-plane",
+			ConsulAddress:        "consul-server",
+			ConsulGRPCPort:       8502,
+			ConsulHTTPPort:       8500,
+			LogLevel:             "info",
+		},
+	}, testClient.APIClient
+}
+
+// reconcileIngressGateway reconciles gw and refreshes it from Kubernetes.
+func reconcileIngressGateway(t *testing.T, r *IngressGatewayController, gw *v1alpha1.IngressGateway) {
+	t.Helper()
+	namespacedName := types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	require.NoError(t, r.Get(context.Background(), namespacedName, gw))
+	require.Equal(t, corev1.ConditionTrue, gw.SyncedConditionStatus())
+}
+
+// requireIngressGatewayResource reads the resource of gw into obj and checks
+// it is controlled by gw.
+func requireIngressGatewayResource(t *testing.T, r *IngressGatewayController, gw *v1alpha1.IngressGateway, obj client.Object) {
+	t.Helper()
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}, obj))
+	require.True(t, metav1.IsControlledBy(obj, gw))
+	require.Equal(t, "true", obj.GetLabels()[labelIngressGatewayManaged])
+}
+
+func servicePorts(svc *corev1.Service) []int32 {
+	var ports []int32
+	for _, p := range svc.Spec.Ports {
+		ports = append(ports, p.Port)
+	}
+	return ports
+}
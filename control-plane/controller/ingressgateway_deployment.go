@@ -0,0 +1,453 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// labelIngressGatewayName is the label on the resources created for an
+	// IngressGateway whose value is the name of the IngressGateway.
+	labelIngressGatewayName = "ingressgateway.consul.hashicorp.com/name"
+	// labelIngressGatewayManaged is the label on the resources created for an
+	// IngressGateway that marks them as managed by the controller.
+	labelIngressGatewayManaged = "ingressgateway.consul.hashicorp.com/managed"
+	// annotationIngressGatewayConfigHash is the annotation with the hash of
+	// the spec the controller last wrote to a resource. Resources are only
+	// updated when the hash changes or the fields the controller sets were
+	// edited, so that defaults filled in by the API server don't cause an
+	// update on every reconcile.
+	annotationIngressGatewayConfigHash = "ingressgateway.consul.hashicorp.com/config-hash"
+
+	ingressGatewayKind        = "ingress-gateway"
+	ingressGatewayVolumeName  = "consul-connect-inject-data"
+	ingressGatewayProxyIDFile = "/consul/connect-inject/proxyid"
+	ingressGatewayTokenPath   = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	defaultIngressGatewayTargetCPU = 80
+)
+
+// IngressGatewayDeploymentConfig configures the deployments the controller
+// manages for IngressGateway resources.
+type IngressGatewayDeploymentConfig struct {
+	ImageConsulDataplane string
+	ImageConsulK8S       string
+
+	// ConsulAddress is the address of the Consul servers. It may be a DNS name
+	// or an exec= string.
+	ConsulAddress       string
+	ConsulGRPCPort      int
+	ConsulHTTPPort      int
+	ConsulAPITimeout    string
+	ConsulCACert        string
+	ConsulTLSServerName string
+	TLSEnabled          bool
+	SkipServerWatch     bool
+	ConsulPartition     string
+
+	// AuthMethod is the name of the Kubernetes auth method the gateways log in
+	// with when ACLs are enabled. The gateway pods run as a ServiceAccount
+	// named after the IngressGateway so that the binding rules of the auth
+	// method give them a service identity for the gateway service.
+	AuthMethod string
+
+	LogLevel string
+	LogJSON  bool
+}
+
+// ingressGatewayLabels returns the labels that select the resources created
+// for gw.
+func ingressGatewayLabels(gw *consulv1alpha1.IngressGateway) map[string]string {
+	return map[string]string{
+		labelIngressGatewayName:    gw.Name,
+		labelIngressGatewayManaged: "true",
+	}
+}
+
+// ingressGatewayPorts returns the distinct ports of the listeners of gw.
+func ingressGatewayPorts(gw *consulv1alpha1.IngressGateway) []int32 {
+	var ports []int32
+	seen := make(map[int]bool)
+	for _, l := range gw.Spec.Listeners {
+		if seen[l.Port] {
+			continue
+		}
+		seen[l.Port] = true
+		ports = append(ports, int32(l.Port))
+	}
+	return ports
+}
+
+// ingressGatewayService returns the Service that exposes the listeners of gw.
+func ingressGatewayService(gw *consulv1alpha1.IngressGateway) *corev1.Service {
+	serviceType := gw.Spec.Deployment.ServiceType
+	if serviceType == "" {
+		serviceType = corev1.ServiceTypeLoadBalancer
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        gw.Name,
+			Namespace:   gw.Namespace,
+			Labels:      ingressGatewayLabels(gw),
+			Annotations: gw.Spec.Deployment.ServiceAnnotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     serviceType,
+			Selector: ingressGatewayLabels(gw),
+		},
+	}
+	for _, port := range ingressGatewayPorts(gw) {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       fmt.Sprintf("gateway-%d", port),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromInt(int(port)),
+		})
+	}
+	return svc
+}
+
+// ingressGatewayDeployment returns the Deployment that runs the gateway pods
+// for gw. The pods are registered with Consul by the endpoints controller,
+// which recognizes them by their gateway annotations, in the same way as the
+// ingress gateways deployed by the Helm chart.
+func (c IngressGatewayDeploymentConfig) ingressGatewayDeployment(gw *consulv1alpha1.IngressGateway, consulNS string, enableNamespaces, enableNSMirroring bool) (*appsv1.Deployment, error) {
+	annotations := map[string]string{
+		constants.AnnotationInject:                   "false",
+		constants.AnnotationGatewayKind:              ingressGatewayKind,
+		constants.AnnotationGatewayConsulServiceName: gw.Name,
+		constants.AnnotationGatewayWANSource:         "Service",
+	}
+	// The WAN address of ingress gateways is the address of their Service and
+	// its port the first listener port.
+	ports := ingressGatewayPorts(gw)
+	if len(ports) > 0 {
+		annotations[constants.AnnotationGatewayWANPort] = strconv.Itoa(int(ports[0]))
+	} else {
+		annotations[constants.AnnotationGatewayWANPort] = strconv.Itoa(consulv1alpha1.IngressGatewayReadyPort)
+	}
+	if enableNamespaces {
+		annotations[constants.AnnotationGatewayNamespace] = consulNS
+	}
+
+	podLabels := ingressGatewayLabels(gw)
+	podLabels[constants.KeyManagedBy] = constants.ManagedByValue
+
+	initContainer, err := c.ingressGatewayInitContainer(consulNS, enableNamespaces, enableNSMirroring)
+	if err != nil {
+		return nil, err
+	}
+
+	container := corev1.Container{
+		Name:  ingressGatewayKind,
+		Image: c.ImageConsulDataplane,
+		Env: []corev1.EnvVar{
+			{
+				Name:      "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+			},
+			{
+				Name:      "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+			},
+			{
+				Name:      "POD_IP",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}},
+			},
+		},
+		Command: []string{"/bin/sh", "-ec", strings.Join(c.ingressGatewayDataplaneArgs(consulNS, enableNamespaces, enableNSMirroring), " ")},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: ingressGatewayVolumeName, MountPath: "/consul/connect-inject"},
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: "gateway-health", ContainerPort: consulv1alpha1.IngressGatewayReadyPort},
+		},
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(consulv1alpha1.IngressGatewayReadyPort)},
+			},
+			// The fields the API server defaults are set so that they
+			// aren't mistaken for edits to the Deployment.
+			InitialDelaySeconds: 10,
+			TimeoutSeconds:      1,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		SecurityContext: &corev1.SecurityContext{
+			// Listeners commonly bind to privileged ports such as 80 and 443.
+			Capabilities: &corev1.Capabilities{
+				Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+	}
+	if gw.Spec.Deployment.Resources != nil {
+		container.Resources = *gw.Spec.Deployment.Resources
+	}
+	for _, port := range ports {
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          fmt.Sprintf("gateway-%d", port),
+			ContainerPort: port,
+		})
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gw.Name,
+			Namespace: gw.Namespace,
+			Labels:    ingressGatewayLabels(gw),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(ingressGatewayReplicas(gw)),
+			Selector: &metav1.LabelSelector{MatchLabels: ingressGatewayLabels(gw)},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: gw.Name,
+					NodeSelector:       gw.Spec.Deployment.NodeSelector,
+					Tolerations:        gw.Spec.Deployment.Tolerations,
+					Volumes: []corev1.Volume{
+						{
+							Name: ingressGatewayVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{initContainer},
+					Containers:     []corev1.Container{container},
+				},
+			},
+		},
+	}, nil
+}
+
+// ingressGatewayReplicas returns the number of gateway pods of gw. If gw is
+// autoscaled it is the number of pods the deployment starts with.
+func ingressGatewayReplicas(gw *consulv1alpha1.IngressGateway) int32 {
+	d := gw.Spec.Deployment
+	if d.Autoscaling != nil {
+		if d.Autoscaling.MinReplicas != nil {
+			return *d.Autoscaling.MinReplicas
+		}
+		return 1
+	}
+	if d.Replicas != nil {
+		return *d.Replicas
+	}
+	return 1
+}
+
+// ingressGatewayPodDisruptionBudget returns the PodDisruptionBudget of the
+// gateway pods of gw.
+func ingressGatewayPodDisruptionBudget(gw *consulv1alpha1.IngressGateway) *policyv1.PodDisruptionBudget {
+	pdb := gw.Spec.Deployment.PodDisruptionBudget
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gw.Name,
+			Namespace: gw.Namespace,
+			Labels:    ingressGatewayLabels(gw),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   pdb.MinAvailable,
+			MaxUnavailable: pdb.MaxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: ingressGatewayLabels(gw)},
+		},
+	}
+}
+
+// ingressGatewayAutoscaler returns the HorizontalPodAutoscaler that scales
+// the Deployment of gw.
+func ingressGatewayAutoscaler(gw *consulv1alpha1.IngressGateway) *autoscalingv1.HorizontalPodAutoscaler {
+	as := gw.Spec.Deployment.Autoscaling
+	targetCPU := as.TargetCPUUtilizationPercentage
+	if targetCPU == nil {
+		targetCPU = pointer.Int32(defaultIngressGatewayTargetCPU)
+	}
+	return &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gw.Name,
+			Namespace: gw.Namespace,
+			Labels:    ingressGatewayLabels(gw),
+		},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       gw.Name,
+			},
+			MinReplicas:                    pointer.Int32(ingressGatewayReplicas(gw)),
+			MaxReplicas:                    as.MaxReplicas,
+			TargetCPUUtilizationPercentage: targetCPU,
+		},
+	}
+}
+
+const ingressGatewayInitCommandTpl = `
+consul-k8s-control-plane connect-init -pod-name=${POD_NAME} -pod-namespace=${POD_NAMESPACE} \
+  -gateway-kind="{{ .GatewayKind }}" \
+  -consul-node-name="{{ .ConsulNodeName }}" \
+  -proxy-id-file={{ .ProxyIDFile }} \
+  -log-level={{ .LogLevel }} \
+  -log-json={{ .LogJSON }}
+`
+
+// ingressGatewayInitContainer returns the connect-init container that waits
+// for the gateway pod to be registered with Consul and writes its proxy ID
+// for the dataplane.
+func (c IngressGatewayDeploymentConfig) ingressGatewayInitContainer(consulNS string, enableNamespaces, enableNSMirroring bool) (corev1.Container, error) {
+	var buf bytes.Buffer
+	tpl := template.Must(template.New("root").Parse(strings.TrimSpace(ingressGatewayInitCommandTpl)))
+	err := tpl.Execute(&buf, struct {
+		GatewayKind    string
+		ConsulNodeName string
+		ProxyIDFile    string
+		LogLevel       string
+		LogJSON        bool
+	}{
+		GatewayKind:    ingressGatewayKind,
+		ConsulNodeName: constants.ConsulNodeName,
+		ProxyIDFile:    ingressGatewayProxyIDFile,
+		LogLevel:       c.LogLevel,
+		LogJSON:        c.LogJSON,
+	})
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	container := corev1.Container{
+		Name:  "ingress-gateway-init",
+		Image: c.ImageConsulK8S,
+		Env: []corev1.EnvVar{
+			{
+				Name:      "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+			},
+			{
+				Name:      "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+			},
+			{Name: "CONSUL_ADDRESSES", Value: c.ConsulAddress},
+			{Name: "CONSUL_GRPC_PORT", Value: strconv.Itoa(c.ConsulGRPCPort)},
+			{Name: "CONSUL_HTTP_PORT", Value: strconv.Itoa(c.ConsulHTTPPort)},
+			{Name: "CONSUL_API_TIMEOUT", Value: c.ConsulAPITimeout},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: ingressGatewayVolumeName, MountPath: "/consul/connect-inject"},
+		},
+		Command: []string{"/bin/sh", "-ec", buf.String()},
+	}
+	if c.TLSEnabled {
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "CONSUL_USE_TLS", Value: "true"},
+			corev1.EnvVar{Name: "CONSUL_CACERT_PEM", Value: c.ConsulCACert},
+			corev1.EnvVar{Name: "CONSUL_TLS_SERVER_NAME", Value: c.ConsulTLSServerName})
+	}
+	if c.AuthMethod != "" {
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "CONSUL_LOGIN_AUTH_METHOD", Value: c.AuthMethod},
+			corev1.EnvVar{Name: "CONSUL_LOGIN_BEARER_TOKEN_FILE", Value: ingressGatewayTokenPath},
+			corev1.EnvVar{Name: "CONSUL_LOGIN_META", Value: "pod=$(POD_NAMESPACE)/$(POD_NAME)"})
+		if enableNamespaces {
+			container.Env = append(container.Env, corev1.EnvVar{Name: "CONSUL_LOGIN_NAMESPACE", Value: ingressGatewayLoginNamespace(consulNS, enableNSMirroring)})
+		}
+		if c.ConsulPartition != "" {
+			container.Env = append(container.Env, corev1.EnvVar{Name: "CONSUL_LOGIN_PARTITION", Value: c.ConsulPartition})
+		}
+	}
+	if enableNamespaces {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "CONSUL_NAMESPACE", Value: consulNS})
+	}
+	if c.ConsulPartition != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "CONSUL_PARTITION", Value: c.ConsulPartition})
+	}
+	return container, nil
+}
+
+// ingressGatewayDataplaneArgs returns the consul-dataplane command that runs
+// the gateway.
+func (c IngressGatewayDeploymentConfig) ingressGatewayDataplaneArgs(consulNS string, enableNamespaces, enableNSMirroring bool) []string {
+	args := []string{
+		"consul-dataplane",
+		fmt.Sprintf("-addresses=%q", c.ConsulAddress),
+		"-grpc-port=" + strconv.Itoa(c.ConsulGRPCPort),
+		"-proxy-service-id=" + fmt.Sprintf("$(cat %s)", ingressGatewayProxyIDFile),
+		"-service-node-name=" + constants.ConsulNodeName,
+		"-envoy-ready-bind-address=$POD_IP",
+		"-envoy-ready-bind-port=" + strconv.Itoa(consulv1alpha1.IngressGatewayReadyPort),
+		"-log-level=" + c.LogLevel,
+		"-log-json=" + strconv.FormatBool(c.LogJSON),
+	}
+	if c.SkipServerWatch {
+		args = append(args, "-server-watch-disabled=true")
+	}
+	if c.AuthMethod != "" {
+		args = append(args,
+			"-credential-type=login",
+			"-login-auth-method="+c.AuthMethod,
+			"-login-bearer-token-path="+ingressGatewayTokenPath,
+			"-login-meta=pod=$POD_NAMESPACE/$POD_NAME",
+		)
+		if enableNamespaces {
+			args = append(args, "-login-namespace="+ingressGatewayLoginNamespace(consulNS, enableNSMirroring))
+		}
+		if c.ConsulPartition != "" {
+			args = append(args, "-login-partition="+c.ConsulPartition)
+		}
+	}
+	if enableNamespaces {
+		args = append(args, "-service-namespace="+consulNS)
+	}
+	if c.ConsulPartition != "" {
+		args = append(args, "-service-partition="+c.ConsulPartition)
+	}
+	if c.TLSEnabled {
+		if c.ConsulTLSServerName != "" {
+			args = append(args, "-tls-server-name="+c.ConsulTLSServerName)
+		}
+		if c.ConsulCACert != "" {
+			args = append(args, "-ca-certs="+constants.ConsulCAFile)
+		}
+	} else {
+		args = append(args, "-tls-disabled")
+	}
+	return args
+}
+
+// ingressGatewayLoginNamespace returns the namespace of the auth method
+// gateways log in with. With mirroring the auth method lives in the default
+// namespace.
+func ingressGatewayLoginNamespace(consulNS string, enableNSMirroring bool) string {
+	if enableNSMirroring {
+		return "default"
+	}
+	return consulNS
+}
+
+// ingressGatewayConfigHash returns a short hash of spec.
+func ingressGatewayConfigHash(spec interface{}) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:16], nil
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// configEntryDrifted reports whether the config entry in Consul has
+	// drifted from its custom resource. It is only set when drift detection
+	// is enabled.
+	configEntryDrifted = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "consul_config_entry_drifted",
+		Help: "Whether the config entry in Consul differs from its custom resource (1) or not (0).",
+	}, []string{"kind", "namespace", "name"})
+
+	// configEntryDriftCorrections counts the config entries that were
+	// rewritten because they had drifted from their custom resource.
+	configEntryDriftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "consul_config_entry_drift_corrections_total",
+		Help: "Total number of times a config entry in Consul was overwritten because it had drifted from its custom resource.",
+	}, []string{"kind"})
+)
+
+func init() {
+	// Register with the controller-runtime registry so the metrics are served
+	// from the manager's metrics endpoint.
+	metrics.Registry.MustRegister(configEntryDrifted, configEntryDriftCorrections)
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// PreparedQueryController reconciles PreparedQuery resources with Consul
+// prepared queries. Prepared queries are identified by the ID stored in the
+// status of the resource so that queries that were created outside of
+// Kubernetes are never modified.
+type PreparedQueryController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// ConsulClientConfig is the config for the Consul API client. Prepared
+	// queries are created in the partition of the client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+
+	// EnableConsulNamespaces indicates that a user is running Consul Enterprise
+	// with version 1.7+ which supports namespaces.
+	EnableConsulNamespaces bool
+	// ConsulDestinationNamespace is the namespace of the queried services if
+	// mirroring is disabled and the resource doesn't set a namespace.
+	ConsulDestinationNamespace string
+	// EnableNSMirroring causes the queried services to be looked up in the
+	// Consul namespace matching the k8s namespace of the resource.
+	EnableNSMirroring bool
+	// NSMirroringPrefix is an optional prefix that can be added to the Consul
+	// namespaces when mirroring.
+	NSMirroringPrefix string
+
+	// ResyncPeriod is how often prepared queries are re-read from Consul to
+	// correct changes made to them outside of Kubernetes. They are only
+	// re-read when the resource changes if it is zero.
+	ResyncPeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=preparedqueries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=preparedqueries/status,verbs=get;update;patch
+
+func (r *PreparedQueryController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var query consulv1alpha1.PreparedQuery
+	err := r.Get(ctx, req.NamespacedName, &query)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	consulClient, err := consulClientFromConnMgr(r.ConsulClientConfig, r.ConsulServerConnMgr)
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+
+	if !query.GetDeletionTimestamp().IsZero() {
+		if containsString(query.Finalizers, FinalizerName) {
+			logger.Info("deletion event")
+			if query.Status.ID != "" {
+				_, err := consulClient.PreparedQuery().Delete(query.Status.ID, nil)
+				if err != nil && !isNotFoundErr(err) {
+					return resourceSyncFailed(ctx, logger, r.Status(), &query, ConsulAgentError,
+						fmt.Errorf("deleting prepared query from consul: %w", err))
+				}
+			}
+			logger.Info("deletion from Consul successful")
+			controllerutil.RemoveFinalizer(&query, FinalizerName)
+			if err := r.Update(ctx, &query); err != nil {
+				return ctrl.Result{}, err
+			}
+			logger.Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !containsString(query.Finalizers, FinalizerName) {
+		controllerutil.AddFinalizer(&query, FinalizerName)
+		query.SetSyncedCondition(corev1.ConditionUnknown, "", "")
+		if err := r.Update(ctx, &query); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	queries, _, err := consulClient.PreparedQuery().List(nil)
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &query, ConsulAgentError,
+			fmt.Errorf("listing prepared queries from consul: %w", err))
+	}
+	consulNS := ""
+	if r.EnableConsulNamespaces {
+		consulNS = namespaces.ConsulNamespace(query.Namespace, r.EnableConsulNamespaces,
+			r.ConsulDestinationNamespace, r.EnableNSMirroring, r.NSMirroringPrefix)
+	}
+
+	var existing *capi.PreparedQueryDefinition
+	var adopted bool
+	for _, q := range queries {
+		if query.Status.ID != "" && q.ID == query.Status.ID {
+			existing = q
+			break
+		}
+		if q.Name == query.ConsulName() {
+			// A query with the same contents is adopted so that a reconcile
+			// that created it but failed to record its ID in the status can
+			// recover.
+			if !query.MatchesConsul(q, consulNS) {
+				return resourceSyncFailed(ctx, logger, r.Status(), &query, ConsulAgentError,
+					fmt.Errorf("prepared query %q already exists in Consul and is not managed by this resource", query.ConsulName()))
+			}
+			existing = q
+			query.Status.ID = q.ID
+			adopted = true
+			logger.Info("prepared query adopted", "id", q.ID)
+			break
+		}
+	}
+
+	switch {
+	case existing == nil:
+		// The query either hasn't been created yet or was deleted from Consul.
+		def := query.ToConsul(consulNS)
+		def.ID = ""
+		id, _, err := consulClient.PreparedQuery().Create(def, nil)
+		if err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &query, ConsulAgentError,
+				fmt.Errorf("creating prepared query in consul: %w", err))
+		}
+		query.Status.ID = id
+		logger.Info("prepared query created", "id", id)
+	case !query.MatchesConsul(existing, consulNS):
+		if _, err := consulClient.PreparedQuery().Update(query.ToConsul(consulNS), nil); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &query, ConsulAgentError,
+				fmt.Errorf("updating prepared query in consul: %w", err))
+		}
+		logger.Info("prepared query updated", "id", query.Status.ID)
+	case query.SyncedConditionStatus() == corev1.ConditionTrue && !adopted:
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+	}
+
+	if _, err := resourceSyncSuccessful(ctx, r.Status(), &query); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+}
+
+func (r *PreparedQueryController) SetupWithManager(mgr ctrl.Manager) error {
+	return setupWithManager(mgr, &consulv1alpha1.PreparedQuery{}, r)
+}
@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPreparedQueryController_createsAndUpdatesQuery(t *testing.T) {
+	t.Parallel()
+
+	query := &v1alpha1.PreparedQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1alpha1.PreparedQuerySpec{
+			Service: v1alpha1.PreparedQueryService{
+				Service: "web",
+				Failover: v1alpha1.PreparedQueryFailover{
+					Targets: []v1alpha1.PreparedQueryFailoverTarget{{Datacenter: "dc2"}},
+				},
+				OnlyPassing: true,
+				Tags:        []string{"v1"},
+			},
+			DNS: v1alpha1.PreparedQueryDNS{TTL: "10s"},
+		},
+	}
+	r, consulClient := preparedQueryTestController(t, query)
+	ctx := context.Background()
+
+	reconcilePreparedQuery(t, r, query, corev1.ConditionTrue, "")
+	require.NotEmpty(t, query.Status.ID)
+	id := query.Status.ID
+	def := requirePreparedQuery(t, consulClient, id)
+	require.Equal(t, "web", def.Name)
+	require.Equal(t, "web", def.Service.Service)
+	require.Equal(t, []capi.QueryFailoverTarget{{Datacenter: "dc2"}}, def.Service.Failover.Targets)
+	require.True(t, def.Service.OnlyPassing)
+	require.Equal(t, []string{"v1"}, def.Service.Tags)
+	require.Equal(t, "10s", def.DNS.TTL)
+
+	// Updating the resource updates the query in place.
+	query.Spec.Service.Tags = []string{"v2"}
+	query.Spec.Template = v1alpha1.PreparedQueryTemplate{Type: v1alpha1.PreparedQueryTemplateNamePrefixMatch}
+	require.NoError(t, r.Update(ctx, query))
+	reconcilePreparedQuery(t, r, query, corev1.ConditionTrue, "")
+	require.Equal(t, id, query.Status.ID)
+	def = requirePreparedQuery(t, consulClient, id)
+	require.Equal(t, []string{"v2"}, def.Service.Tags)
+	require.Equal(t, "name_prefix_match", def.Template.Type)
+}
+
+func TestPreparedQueryController_correctsDrift(t *testing.T) {
+	t.Parallel()
+
+	query := &v1alpha1.PreparedQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1alpha1.PreparedQuerySpec{
+			Service: v1alpha1.PreparedQueryService{Service: "web"},
+		},
+	}
+	r, consulClient := preparedQueryTestController(t, query)
+
+	reconcilePreparedQuery(t, r, query, corev1.ConditionTrue, "")
+	id := query.Status.ID
+
+	// Changes made directly in Consul are reverted.
+	def := requirePreparedQuery(t, consulClient, id)
+	def.Service.Service = "other"
+	def.DNS.TTL = "1m"
+	_, err := consulClient.PreparedQuery().Update(def, nil)
+	require.NoError(t, err)
+	reconcilePreparedQuery(t, r, query, corev1.ConditionTrue, "")
+	def = requirePreparedQuery(t, consulClient, id)
+	require.Equal(t, "web", def.Service.Service)
+	require.Empty(t, def.DNS.TTL)
+
+	// Queries deleted from Consul are recreated.
+	_, err = consulClient.PreparedQuery().Delete(id, nil)
+	require.NoError(t, err)
+	reconcilePreparedQuery(t, r, query, corev1.ConditionTrue, "")
+	require.NotEqual(t, id, query.Status.ID)
+	def = requirePreparedQuery(t, consulClient, query.Status.ID)
+	require.Equal(t, "web", def.Service.Service)
+}
+
+func TestPreparedQueryController_requeuesAfterResyncPeriod(t *testing.T) {
+	t.Parallel()
+
+	query := &v1alpha1.PreparedQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1alpha1.PreparedQuerySpec{
+			Service: v1alpha1.PreparedQueryService{Service: "web"},
+		},
+	}
+	r, _ := preparedQueryTestController(t, query)
+	r.ResyncPeriod = time.Minute
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: query.Name, Namespace: query.Namespace}}
+
+	// The query is requeued both when it's written and when it's already in
+	// sync so that changes made in Consul are corrected.
+	for i := 0; i < 2; i++ {
+		resp, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, time.Minute, resp.RequeueAfter)
+	}
+}
+
+func TestPreparedQueryController_unmanagedQuery(t *testing.T) {
+	t.Parallel()
+
+	query := &v1alpha1.PreparedQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1alpha1.PreparedQuerySpec{
+			Service: v1alpha1.PreparedQueryService{Service: "web"},
+		},
+	}
+	r, consulClient := preparedQueryTestController(t, query)
+
+	// A query with the same name that wasn't created by the resource.
+	id, _, err := consulClient.PreparedQuery().Create(&capi.PreparedQueryDefinition{
+		Name:    "web",
+		Service: capi.ServiceQuery{Service: "other"},
+	}, nil)
+	require.NoError(t, err)
+
+	reconcilePreparedQuery(t, r, query, corev1.ConditionFalse, ConsulAgentError)
+	require.Equal(t, `prepared query "web" already exists in Consul and is not managed by this resource`, query.Status.Conditions[0].Message)
+	require.Empty(t, query.Status.ID)
+	require.Equal(t, "other", requirePreparedQuery(t, consulClient, id).Service.Service)
+}
+
+func TestPreparedQueryController_adoptsMatchingQuery(t *testing.T) {
+	t.Parallel()
+
+	query := &v1alpha1.PreparedQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1alpha1.PreparedQuerySpec{
+			Service: v1alpha1.PreparedQueryService{Service: "web"},
+		},
+	}
+	r, consulClient := preparedQueryTestController(t, query)
+	ctx := context.Background()
+
+	reconcilePreparedQuery(t, r, query, corev1.ConditionTrue, "")
+	id := query.Status.ID
+
+	// Simulate a status update that failed after the query was created.
+	query.Status.ID = ""
+	require.NoError(t, r.Status().Update(ctx, query))
+	reconcilePreparedQuery(t, r, query, corev1.ConditionTrue, "")
+	require.Equal(t, id, query.Status.ID)
+
+	queries, _, err := consulClient.PreparedQuery().List(nil)
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+}
+
+func TestPreparedQueryController_deletesQuery(t *testing.T) {
+	t.Parallel()
+
+	query := &v1alpha1.PreparedQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1alpha1.PreparedQuerySpec{
+			Service: v1alpha1.PreparedQueryService{Service: "web"},
+		},
+	}
+	r, consulClient := preparedQueryTestController(t, query)
+	ctx := context.Background()
+
+	reconcilePreparedQuery(t, r, query, corev1.ConditionTrue, "")
+
+	query.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	require.NoError(t, r.Update(ctx, query))
+	namespacedName := types.NamespacedName{Name: query.Name, Namespace: query.Namespace}
+	resp, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+	require.False(t, resp.Requeue)
+
+	queries, _, err := consulClient.PreparedQuery().List(nil)
+	require.NoError(t, err)
+	require.Empty(t, queries)
+	err = r.Get(ctx, namespacedName, query)
+	require.True(t, k8serr.IsNotFound(err))
+}
+
+func preparedQueryTestController(t *testing.T, objs ...runtime.Object) (*PreparedQueryController, *capi.Client) {
+	t.Helper()
+	s := runtime.NewScheme()
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.PreparedQuery{}, &v1alpha1.PreparedQueryList{})
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForLeader(t)
+	return &PreparedQueryController{
+		Client:              fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build(),
+		Log:                 logrtest.TestLogger{T: t},
+		ConsulClientConfig:  testClient.Cfg,
+		ConsulServerConnMgr: testClient.Watcher,
+	}, testClient.APIClient
+}
+
+// reconcilePreparedQuery reconciles query and refreshes it from Kubernetes. It
+// checks the synced condition has the expected status and reason.
+func reconcilePreparedQuery(t *testing.T, r *PreparedQueryController, query *v1alpha1.PreparedQuery, expStatus corev1.ConditionStatus, expReason string) {
+	t.Helper()
+	namespacedName := types.NamespacedName{Name: query.Name, Namespace: query.Namespace}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: namespacedName})
+	if expStatus == corev1.ConditionTrue {
+		require.NoError(t, err)
+	} else {
+		require.Error(t, err)
+	}
+	require.NoError(t, r.Get(context.Background(), namespacedName, query))
+	require.Equal(t, expStatus, query.SyncedConditionStatus())
+	require.Equal(t, expReason, query.Status.Conditions[0].Reason)
+	require.Contains(t, query.Finalizers, FinalizerName)
+}
+
+func requirePreparedQuery(t *testing.T, consulClient *capi.Client, id string) *capi.PreparedQueryDefinition {
+	t.Helper()
+	defs, _, err := consulClient.PreparedQuery().Get(id, nil)
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	return defs[0]
+}
@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/constants"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// RegistrationConflictError is the reason used when the service of a
+	// Registration resource is already registered in Consul by something else.
+	RegistrationConflictError = "RegistrationConflictError"
+
+	// metaKeyRegistration is the service meta key of the name of the
+	// Registration resource that registered the service. Service meta keys
+	// can't contain slashes so it is used with the k8s-namespace key rather
+	// than common.ResourceKey.
+	metaKeyRegistration = "k8s-registration"
+)
+
+// RegistrationController reconciles Registration resources with the Consul
+// catalog.
+//
+// Registered services are marked with the resource that registered them so
+// that services registered by something else are never overwritten or
+// deregistered. The registration is only written when the spec changes, or
+// the service is missing from the catalog, so that check statuses updated by
+// consul-esm are kept.
+type RegistrationController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// ConsulClientConfig is the config for the Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+
+	// EnableConsulNamespaces indicates that a user is running Consul Enterprise
+	// with version 1.7+ which supports namespaces.
+	EnableConsulNamespaces bool
+	// ConsulDestinationNamespace is the namespace services are registered in
+	// if mirroring is disabled.
+	ConsulDestinationNamespace string
+	// EnableNSMirroring causes Consul namespaces to be created to match the
+	// k8s namespace of the Registration resource.
+	EnableNSMirroring bool
+	// NSMirroringPrefix is an optional prefix that can be added to the Consul
+	// namespaces created while mirroring.
+	NSMirroringPrefix string
+	// CrossNSACLPolicy is the name of the ACL policy to attach to
+	// any created Consul namespaces to allow cross namespace service discovery.
+	CrossNSACLPolicy string
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=registrations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=registrations/status,verbs=get;update;patch
+
+func (r *RegistrationController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var registration consulv1alpha1.Registration
+	err := r.Get(ctx, req.NamespacedName, &registration)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	consulClient, err := consulClientFromConnMgr(r.ConsulClientConfig, r.ConsulServerConnMgr)
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+	consulNS := namespaces.ConsulNamespace(registration.Namespace, r.EnableConsulNamespaces,
+		r.ConsulDestinationNamespace, r.EnableNSMirroring, r.NSMirroringPrefix)
+
+	if !registration.GetDeletionTimestamp().IsZero() {
+		if containsString(registration.Finalizers, FinalizerName) {
+			logger.Info("deletion event")
+			if err := r.deregister(logger, consulClient, &registration, consulNS); err != nil {
+				return resourceSyncFailed(ctx, logger, r.Status(), &registration, ConsulAgentError,
+					fmt.Errorf("deregistering service from consul: %w", err))
+			}
+			logger.Info("deletion from Consul successful")
+			controllerutil.RemoveFinalizer(&registration, FinalizerName)
+			if err := r.Update(ctx, &registration); err != nil {
+				return ctrl.Result{}, err
+			}
+			logger.Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !containsString(registration.Finalizers, FinalizerName) {
+		controllerutil.AddFinalizer(&registration, FinalizerName)
+		registration.SetSyncedCondition(corev1.ConditionUnknown, "", "")
+		if err := r.Update(ctx, &registration); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.EnableConsulNamespaces {
+		created, err := namespaces.EnsureExists(consulClient, consulNS, r.CrossNSACLPolicy)
+		if err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &registration, ConsulAgentError,
+				fmt.Errorf("creating consul namespace %q: %w", consulNS, err))
+		}
+		if created {
+			logger.Info("consul namespace created", "ns", consulNS)
+		}
+	}
+
+	// The service moved to another node or ID, so the old registration is
+	// removed first.
+	moved := registration.Status.ServiceID != "" &&
+		(registration.Status.Node != registration.Spec.Node || registration.Status.ServiceID != registration.ServiceID())
+	if moved {
+		if err := r.deregister(logger, consulClient, &registration, consulNS); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), &registration, ConsulAgentError,
+				fmt.Errorf("deregistering service from consul: %w", err))
+		}
+	}
+
+	existing, err := registeredService(consulClient, registration.Spec.Node, registration.ServiceID(), consulNS)
+	if err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &registration, ConsulAgentError,
+			fmt.Errorf("reading service from consul: %w", err))
+	}
+	if existing != nil && !registrationOwns(&registration, existing) {
+		return resourceSyncFailed(ctx, logger, r.Status(), &registration, RegistrationConflictError,
+			fmt.Errorf("service %q is already registered on node %q and is not managed by this resource",
+				registration.ServiceID(), registration.Spec.Node))
+	}
+
+	if existing != nil && !moved && registration.Status.ObservedGeneration == registration.Generation {
+		if registration.SyncedConditionStatus() == corev1.ConditionTrue {
+			return ctrl.Result{}, nil
+		}
+		return resourceSyncSuccessful(ctx, r.Status(), &registration)
+	}
+
+	if _, err := consulClient.Catalog().Register(r.catalogRegistration(&registration, consulNS), nil); err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &registration, ConsulAgentError,
+			fmt.Errorf("registering service in consul: %w", err))
+	}
+	logger.Info("service registered in Consul")
+	registration.Status.ObservedGeneration = registration.Generation
+	registration.Status.Node = registration.Spec.Node
+	registration.Status.ServiceID = registration.ServiceID()
+	return resourceSyncSuccessful(ctx, r.Status(), &registration)
+}
+
+func (r *RegistrationController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&consulv1alpha1.Registration{}).
+		WithOptions(controllerOptions()).
+		Complete(r)
+}
+
+// catalogRegistration returns the catalog registration of the service with
+// the meta that marks it as registered by this resource. Nodes with checks
+// that have a probe are marked to be probed by consul-esm.
+func (r *RegistrationController) catalogRegistration(registration *consulv1alpha1.Registration, consulNS string) *capi.CatalogRegistration {
+	reg := registration.ToConsul(consulNS)
+
+	nodeMeta := map[string]string{
+		common.SourceKey: common.SourceValue,
+		"external-node":  "true",
+	}
+	if registration.HasProbes() {
+		nodeMeta["external-probe"] = "true"
+	}
+	for k, v := range registration.Spec.NodeMeta {
+		nodeMeta[k] = v
+	}
+	reg.NodeMeta = nodeMeta
+
+	serviceMeta := map[string]string{
+		common.SourceKey:        common.SourceValue,
+		constants.MetaKeyKubeNS: registration.Namespace,
+		metaKeyRegistration:     registration.Name,
+	}
+	for k, v := range registration.Spec.Service.Meta {
+		serviceMeta[k] = v
+	}
+	reg.Service.Meta = serviceMeta
+	return reg
+}
+
+// deregister removes the service recorded in the status of registration from
+// the catalog if it is still managed by the resource. The node is removed
+// too once it has no services left, unless it wasn't created from
+// Kubernetes.
+func (r *RegistrationController) deregister(logger logr.Logger, consulClient *capi.Client, registration *consulv1alpha1.Registration, consulNS string) error {
+	node, serviceID := registration.Status.Node, registration.Status.ServiceID
+	if serviceID == "" {
+		return nil
+	}
+	existing, err := registeredService(consulClient, node, serviceID, consulNS)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if !registrationOwns(registration, existing) {
+			logger.Info("service is not managed by this resource and was not deregistered", "node", node, "service-id", serviceID)
+		} else {
+			_, err := consulClient.Catalog().Deregister(&capi.CatalogDeregistration{
+				Node:      node,
+				ServiceID: serviceID,
+				Namespace: consulNS,
+			}, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Services in other Consul namespaces are only listed with the wildcard
+	// namespace.
+	var listNS string
+	if r.EnableConsulNamespaces {
+		listNS = common.WildcardNamespace
+	}
+	services, _, err := consulClient.Catalog().NodeServiceList(node, &capi.QueryOptions{Namespace: listNS})
+	if err != nil {
+		return err
+	}
+	if services == nil || services.Node == nil || len(services.Services) > 0 ||
+		services.Node.Meta[common.SourceKey] != common.SourceValue {
+		return nil
+	}
+	_, err = consulClient.Catalog().Deregister(&capi.CatalogDeregistration{Node: node}, nil)
+	return err
+}
+
+// registeredService returns the registration of the service with the given
+// ID on node, or nil if it isn't registered.
+func registeredService(consulClient *capi.Client, node, serviceID, consulNS string) (*capi.AgentService, error) {
+	services, _, err := consulClient.Catalog().NodeServiceList(node, &capi.QueryOptions{Namespace: consulNS})
+	if err != nil || services == nil {
+		return nil, err
+	}
+	for _, svc := range services.Services {
+		if svc.ID == serviceID {
+			return svc, nil
+		}
+	}
+	return nil, nil
+}
+
+// registrationOwns returns true if svc was registered by registration.
+func registrationOwns(registration *consulv1alpha1.Registration, svc *capi.AgentService) bool {
+	return svc.Meta[common.SourceKey] == common.SourceValue &&
+		svc.Meta[constants.MetaKeyKubeNS] == registration.Namespace &&
+		svc.Meta[metaKeyRegistration] == registration.Name
+}
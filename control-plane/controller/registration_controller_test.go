@@ -0,0 +1,237 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRegistrationController_registersService(t *testing.T) {
+	t.Parallel()
+
+	registration := testRegistration()
+	r, consulClient := registrationTestController(t, registration)
+	ctx := context.Background()
+
+	reconcileRegistration(t, r, registration, corev1.ConditionTrue, "")
+	require.Equal(t, "db-node", registration.Status.Node)
+	require.Equal(t, "db", registration.Status.ServiceID)
+
+	services := requireNodeServices(t, consulClient, "db-node")
+	require.Equal(t, "10.0.0.1", services.Node.Address)
+	require.Equal(t, "true", services.Node.Meta["external-node"])
+	require.Equal(t, "true", services.Node.Meta["external-probe"])
+	require.Len(t, services.Services, 1)
+	svc := services.Services[0]
+	require.Equal(t, "db", svc.Service)
+	require.Equal(t, 5432, svc.Port)
+	require.Equal(t, "db", svc.Meta["k8s-registration"])
+	require.Equal(t, "default", svc.Meta["k8s-namespace"])
+	require.Equal(t, "14", svc.Meta["version"])
+
+	checks, _, err := consulClient.Health().Checks("db", nil)
+	require.NoError(t, err)
+	require.Len(t, checks, 1)
+	require.Equal(t, "db-tcp", checks[0].CheckID)
+	require.Equal(t, capi.HealthCritical, checks[0].Status)
+	require.Equal(t, "db.example.com:5432", checks[0].Definition.TCP)
+
+	// Check statuses updated by consul-esm are kept while the spec doesn't
+	// change.
+	_, err = consulClient.Catalog().Register(&capi.CatalogRegistration{
+		Node:           "db-node",
+		SkipNodeUpdate: true,
+		Check: &capi.AgentCheck{
+			Node:      "db-node",
+			CheckID:   "db-tcp",
+			Name:      "TCP",
+			Status:    capi.HealthPassing,
+			ServiceID: "db",
+		},
+	}, nil)
+	require.NoError(t, err)
+	reconcileRegistration(t, r, registration, corev1.ConditionTrue, "")
+	checks, _, err = consulClient.Health().Checks("db", nil)
+	require.NoError(t, err)
+	require.Equal(t, capi.HealthPassing, checks[0].Status)
+
+	// Changes to the spec are registered.
+	registration.Spec.Service.Port = 5433
+	registration.Generation++
+	require.NoError(t, r.Update(ctx, registration))
+	reconcileRegistration(t, r, registration, corev1.ConditionTrue, "")
+	require.Equal(t, 5433, requireNodeServices(t, consulClient, "db-node").Services[0].Port)
+
+	// Services deregistered from Consul are registered again.
+	_, err = consulClient.Catalog().Deregister(&capi.CatalogDeregistration{Node: "db-node", ServiceID: "db"}, nil)
+	require.NoError(t, err)
+	reconcileRegistration(t, r, registration, corev1.ConditionTrue, "")
+	require.Len(t, requireNodeServices(t, consulClient, "db-node").Services, 1)
+}
+
+func TestRegistrationController_movesService(t *testing.T) {
+	t.Parallel()
+
+	registration := testRegistration()
+	r, consulClient := registrationTestController(t, registration)
+
+	reconcileRegistration(t, r, registration, corev1.ConditionTrue, "")
+
+	registration.Spec.Node = "db-node-2"
+	registration.Generation++
+	require.NoError(t, r.Update(context.Background(), registration))
+	reconcileRegistration(t, r, registration, corev1.ConditionTrue, "")
+	require.Equal(t, "db-node-2", registration.Status.Node)
+	require.Len(t, requireNodeServices(t, consulClient, "db-node-2").Services, 1)
+
+	// The old node was created for the service so it is removed with it.
+	services, _, err := consulClient.Catalog().NodeServiceList("db-node", nil)
+	require.NoError(t, err)
+	require.Nil(t, services.Node)
+}
+
+func TestRegistrationController_unmanagedService(t *testing.T) {
+	t.Parallel()
+
+	registration := testRegistration()
+	r, consulClient := registrationTestController(t, registration)
+
+	// A service with the same ID that wasn't registered by the resource.
+	_, err := consulClient.Catalog().Register(&capi.CatalogRegistration{
+		Node:    "db-node",
+		Address: "10.0.0.2",
+		Service: &capi.AgentService{ID: "db", Service: "other"},
+	}, nil)
+	require.NoError(t, err)
+
+	reconcileRegistration(t, r, registration, corev1.ConditionFalse, RegistrationConflictError)
+	require.Equal(t, `service "db" is already registered on node "db-node" and is not managed by this resource`,
+		registration.Status.Conditions[0].Message)
+	require.Equal(t, "other", requireNodeServices(t, consulClient, "db-node").Services[0].Service)
+}
+
+func TestRegistrationController_deletesService(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		otherService bool
+		expNode      bool
+	}{
+		"last service removes the node": {},
+		"node with other services is kept": {
+			otherService: true,
+			expNode:      true,
+		},
+	}
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			registration := testRegistration()
+			r, consulClient := registrationTestController(t, registration)
+			ctx := context.Background()
+			reconcileRegistration(t, r, registration, corev1.ConditionTrue, "")
+			if c.otherService {
+				_, err := consulClient.Catalog().Register(&capi.CatalogRegistration{
+					Node:           "db-node",
+					SkipNodeUpdate: true,
+					Service:        &capi.AgentService{ID: "cache", Service: "cache"},
+				}, nil)
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, r.Delete(ctx, registration))
+			namespacedName := types.NamespacedName{Name: registration.Name, Namespace: registration.Namespace}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			require.NoError(t, err)
+
+			err = r.Get(ctx, namespacedName, registration)
+			require.True(t, k8serr.IsNotFound(err))
+			services, _, err := consulClient.Catalog().NodeServiceList("db-node", nil)
+			require.NoError(t, err)
+			if !c.expNode {
+				require.Nil(t, services.Node)
+				return
+			}
+			require.Len(t, services.Services, 1)
+			require.Equal(t, "cache", services.Services[0].ID)
+		})
+	}
+}
+
+func testRegistration() *v1alpha1.Registration {
+	return &v1alpha1.Registration{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Generation: 1},
+		Spec: v1alpha1.RegistrationSpec{
+			Node:    "db-node",
+			Address: "10.0.0.1",
+			Service: v1alpha1.RegistrationService{
+				Name:    "db",
+				Port:    5432,
+				Address: "db.example.com",
+				Meta:    map[string]string{"version": "14"},
+			},
+			Checks: []v1alpha1.RegistrationCheck{{
+				CheckID: "db-tcp",
+				Name:    "TCP",
+				Definition: v1alpha1.RegistrationCheckDefinition{
+					TCP:      "db.example.com:5432",
+					Interval: metav1.Duration{Duration: 10 * time.Second},
+				},
+			}},
+		},
+	}
+}
+
+func registrationTestController(t *testing.T, objs ...runtime.Object) (*RegistrationController, *capi.Client) {
+	t.Helper()
+	s := runtime.NewScheme()
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.Registration{}, &v1alpha1.RegistrationList{})
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForLeader(t)
+	return &RegistrationController{
+		Client:              fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build(),
+		Log:                 logrtest.TestLogger{T: t},
+		ConsulClientConfig:  testClient.Cfg,
+		ConsulServerConnMgr: testClient.Watcher,
+	}, testClient.APIClient
+}
+
+// reconcileRegistration reconciles registration and refreshes it from
+// Kubernetes. It checks the synced condition has the expected status and
+// reason.
+func reconcileRegistration(t *testing.T, r *RegistrationController, registration *v1alpha1.Registration, expStatus corev1.ConditionStatus, expReason string) {
+	t.Helper()
+	namespacedName := types.NamespacedName{Name: registration.Name, Namespace: registration.Namespace}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: namespacedName})
+	if expStatus == corev1.ConditionTrue {
+		require.NoError(t, err)
+	} else {
+		require.Error(t, err)
+	}
+	require.NoError(t, r.Get(context.Background(), namespacedName, registration))
+	require.Equal(t, expStatus, registration.SyncedConditionStatus())
+	require.Equal(t, expReason, registration.Status.Conditions[0].Reason)
+	require.Contains(t, registration.Finalizers, FinalizerName)
+}
+
+func requireNodeServices(t *testing.T, consulClient *capi.Client, node string) *capi.CatalogNodeServiceList {
+	t.Helper()
+	services, _, err := consulClient.Catalog().NodeServiceList(node, nil)
+	require.NoError(t, err)
+	require.NotNil(t, services)
+	return services
+}
@@ -0,0 +1,417 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// IntentionSourceConflict is the reason used when a ServiceIntention
+// resource sets the same source for a destination as an older resource.
+const IntentionSourceConflict = "IntentionSourceConflict"
+
+// ServiceIntentionController reconciles ServiceIntention resources. Unlike
+// other config entry resources, each ServiceIntention is a single rule and
+// all rules with the same destination are written to Consul as one
+// service-intentions config entry.
+//
+// Every reconcile of a rule recomputes the config entry of its destination
+// from all of the destination's rules and updates the status of each of them.
+// If two rules set the same source for a destination, the oldest rule is used
+// and the others report an IntentionSourceConflict.
+type ServiceIntentionController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// ConfigEntryController holds the Consul settings shared by all config
+	// entry controllers.
+	ConfigEntryController *ConfigEntryController
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=serviceintentionrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=serviceintentionrules/status,verbs=get;update;patch
+
+func (r *ServiceIntentionController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var rule consulv1alpha1.ServiceIntention
+	err := r.Get(ctx, req.NamespacedName, &rule)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+
+	if rule.GetDeletionTimestamp().IsZero() {
+		if !containsString(rule.Finalizers, FinalizerName) {
+			controllerutil.AddFinalizer(&rule, FinalizerName)
+			rule.SetSyncedCondition(corev1.ConditionUnknown, "", "")
+			if err := r.Update(ctx, &rule); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	} else if !containsString(rule.Finalizers, FinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	logger = logger.WithValues("destination", rule.Spec.Destination.Name)
+	return r.reconcileDestination(ctx, logger, rule.Spec.Destination)
+}
+
+// reconcileDestination writes the config entry of the destination from its
+// rules and updates the status of every rule.
+func (r *ServiceIntentionController) reconcileDestination(ctx context.Context, logger logr.Logger, destination consulv1alpha1.IntentionDestination) (ctrl.Result, error) {
+	consulNS := r.consulNamespace(destination)
+
+	var ruleList consulv1alpha1.ServiceIntentionList
+	if err := r.List(ctx, &ruleList); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Only rules with the finalizer are written to Consul so that they are
+	// always removed from the config entry before they are deleted.
+	var active, deleted []*consulv1alpha1.ServiceIntention
+	for i := range ruleList.Items {
+		rule := &ruleList.Items[i]
+		if rule.Spec.Destination.Name != destination.Name || r.consulNamespace(rule.Spec.Destination) != consulNS ||
+			!containsString(rule.Finalizers, FinalizerName) {
+			continue
+		}
+		if rule.GetDeletionTimestamp().IsZero() {
+			active = append(active, rule)
+		} else {
+			deleted = append(deleted, rule)
+		}
+	}
+
+	// The destination can't be managed by ServiceIntentions and
+	// ServiceIntention resources at the same time.
+	var intentionsList consulv1alpha1.ServiceIntentionsList
+	if err := r.List(ctx, &intentionsList); err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, intentions := range intentionsList.Items {
+		if intentions.Spec.Destination.Name == destination.Name && r.consulNamespace(intentions.Spec.Destination) == consulNS {
+			owner := fmt.Sprintf("ServiceIntentions resource %s/%s", intentions.Namespace, intentions.Name)
+			logger.Info("destination is managed by another resource", "owner", owner)
+			return r.ownershipConflict(ctx, active, deleted, OwnershipConflict,
+				fmt.Sprintf("config entry in Consul is managed by %s", owner))
+		}
+	}
+
+	included, conflicts := resolveIntentionSources(active)
+
+	consulClient, err := consulClientFromConnMgr(r.ConfigEntryController.ConsulClientConfig, r.ConfigEntryController.ConsulServerConnMgr)
+	if err != nil {
+		logger.Error(err, "failed to create Consul API client")
+		return ctrl.Result{}, err
+	}
+
+	entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceIntentions, destination.Name, &capi.QueryOptions{Namespace: consulNS})
+	if err != nil && !isNotFoundErr(err) {
+		return r.rulesSyncFailed(ctx, logger, active, ConsulAgentError,
+			fmt.Errorf("getting config entry from consul: %w", err))
+	}
+	if err == nil {
+		if sourceDatacenter := entry.GetMeta()[common.DatacenterKey]; sourceDatacenter != r.ConfigEntryController.DatacenterName {
+			return r.ownershipConflict(ctx, active, deleted, ExternallyManagedConfigError,
+				sourceDatacenterMismatchErr(sourceDatacenter).Error())
+		}
+		if owner := r.otherOwner(entry, active); owner != "" {
+			logger.Info("config entry is owned by another resource", "owner", owner)
+			return r.ownershipConflict(ctx, active, deleted, OwnershipConflict,
+				fmt.Sprintf("config entry in Consul is managed by %s", owner))
+		}
+	}
+
+	desired := r.desiredEntry(destination, included)
+	written := false
+	switch {
+	case err != nil && len(included) == 0:
+		// The config entry was already deleted.
+	case err != nil:
+		if r.ConfigEntryController.EnableConsulNamespaces {
+			created, err := namespaces.EnsureExists(consulClient, consulNS, r.ConfigEntryController.CrossNSACLPolicy)
+			if err != nil {
+				return r.rulesSyncFailed(ctx, logger, active, ConsulAgentError,
+					fmt.Errorf("creating consul namespace %q: %w", consulNS, err))
+			}
+			if created {
+				logger.Info("consul namespace created", "ns", consulNS)
+			}
+		}
+		// An index of 0 only creates the config entry if it still doesn't exist.
+		ok, _, err := consulClient.ConfigEntries().CAS(r.toConsul(desired), 0, &capi.WriteOptions{Namespace: consulNS})
+		if err != nil {
+			return r.rulesSyncFailed(ctx, logger, active, ConsulAgentError,
+				fmt.Errorf("writing config entry to consul: %w", err))
+		}
+		if !ok {
+			return concurrentModification(logger)
+		}
+		logger.Info("config entry created", "sources", len(included))
+		written = true
+	case len(included) == 0:
+		ok, _, err := consulClient.ConfigEntries().DeleteCAS(capi.ServiceIntentions, destination.Name, entry.GetModifyIndex(), &capi.WriteOptions{Namespace: consulNS})
+		if err != nil {
+			return r.rulesSyncFailed(ctx, logger, active, ConsulAgentError,
+				fmt.Errorf("deleting config entry from consul: %w", err))
+		}
+		if !ok {
+			return concurrentModification(logger)
+		}
+		logger.Info("config entry deleted")
+	case !desired.MatchesConsul(entry) || r.ConfigEntryController.missingOwner(entry):
+		ok, _, err := consulClient.ConfigEntries().CAS(r.toConsul(desired), entry.GetModifyIndex(), &capi.WriteOptions{Namespace: consulNS})
+		if err != nil {
+			return r.rulesSyncFailed(ctx, logger, active, ConsulAgentError,
+				fmt.Errorf("updating config entry in consul: %w", err))
+		}
+		if !ok {
+			return concurrentModification(logger)
+		}
+		logger.Info("config entry updated", "sources", len(included))
+		written = true
+	}
+
+	for _, rule := range included {
+		if err := r.updateRuleStatus(ctx, rule, corev1.ConditionTrue, "", "", written); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	for _, conflict := range conflicts {
+		msg := fmt.Sprintf("source is already set for the destination by ServiceIntention %s/%s", conflict.winner.Namespace, conflict.winner.Name)
+		if err := r.updateRuleStatus(ctx, conflict.rule, corev1.ConditionFalse, IntentionSourceConflict, msg, false); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, r.removeFinalizers(ctx, logger, deleted)
+}
+
+func (r *ServiceIntentionController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&consulv1alpha1.ServiceIntention{}).
+		Watches(&source.Kind{Type: &consulv1alpha1.ServiceIntentions{}}, handler.EnqueueRequestsFromMapFunc(r.rulesForServiceIntentions)).
+		WithOptions(controllerOptions()).
+		Complete(r)
+}
+
+// rulesForServiceIntentions enqueues a rule for the destination of a
+// ServiceIntentions resource so that the rules take over the destination
+// once the ServiceIntentions resource is deleted.
+func (r *ServiceIntentionController) rulesForServiceIntentions(object client.Object) []reconcile.Request {
+	intentions, ok := object.(*consulv1alpha1.ServiceIntentions)
+	if !ok {
+		return nil
+	}
+	var ruleList consulv1alpha1.ServiceIntentionList
+	if err := r.List(context.Background(), &ruleList); err != nil {
+		r.Log.Error(err, "failed to list ServiceIntention resources")
+		return nil
+	}
+	for _, rule := range ruleList.Items {
+		if rule.Spec.Destination.Name == intentions.Spec.Destination.Name &&
+			r.consulNamespace(rule.Spec.Destination) == r.consulNamespace(intentions.Spec.Destination) {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}}}
+		}
+	}
+	return nil
+}
+
+// consulNamespace returns the Consul namespace of the destination's config
+// entry. The destination namespace is defaulted by the webhook when Consul
+// namespaces are enabled.
+func (r *ServiceIntentionController) consulNamespace(destination consulv1alpha1.IntentionDestination) string {
+	return r.ConfigEntryController.consulNamespace(&capi.ServiceIntentionsConfigEntry{Namespace: destination.Namespace}, destination.Namespace, false)
+}
+
+// desiredEntry returns the ServiceIntentions that the destination's config
+// entry is written from. Its sources are ordered by precedence.
+func (r *ServiceIntentionController) desiredEntry(destination consulv1alpha1.IntentionDestination, rules []*consulv1alpha1.ServiceIntention) *consulv1alpha1.ServiceIntentions {
+	sorted := make([]*consulv1alpha1.ServiceIntention, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return intentionSourcePrecedence(sorted[i].Spec.Source) > intentionSourcePrecedence(sorted[j].Spec.Source)
+	})
+	desired := &consulv1alpha1.ServiceIntentions{
+		Spec: consulv1alpha1.ServiceIntentionsSpec{Destination: destination},
+	}
+	for _, rule := range sorted {
+		source := rule.Spec.Source
+		desired.Spec.Sources = append(desired.Spec.Sources, &source)
+	}
+	return desired
+}
+
+// toConsul converts the desired entry to a Consul config entry marked as
+// aggregated from ServiceIntention resources.
+func (r *ServiceIntentionController) toConsul(desired *consulv1alpha1.ServiceIntentions) capi.ConfigEntry {
+	entry := desired.ToConsul(r.ConfigEntryController.DatacenterName)
+	meta := entry.GetMeta()
+	meta[common.SourceKindKey] = common.ServiceIntention
+	if r.ConfigEntryController.ClusterID != "" {
+		meta[common.ClusterIDKey] = r.ConfigEntryController.ClusterID
+		meta[common.ResourceKey] = common.ServiceIntention
+	}
+	return entry
+}
+
+// otherOwner returns the owner of the config entry if it wasn't written from
+// ServiceIntention resources in this cluster, or an empty string otherwise.
+// Config entries written without a cluster ID are only taken over if one of
+// the active rules has the migrate-entry annotation.
+func (r *ServiceIntentionController) otherOwner(entry capi.ConfigEntry, active []*consulv1alpha1.ServiceIntention) string {
+	meta := entry.GetMeta()
+	clusterID := meta[common.ClusterIDKey]
+	if r.ConfigEntryController.ClusterID != "" && clusterID == "" && !migrateEntry(active) {
+		return unknownOwner
+	}
+	if r.ConfigEntryController.ClusterID != "" && clusterID != "" && clusterID != r.ConfigEntryController.ClusterID {
+		return fmt.Sprintf("%s/%s", clusterID, meta[common.ResourceKey])
+	}
+	if meta[common.SourceKindKey] != common.ServiceIntention {
+		if resource := meta[common.ResourceKey]; resource != "" {
+			return resource
+		}
+		return "a ServiceIntentions resource"
+	}
+	return ""
+}
+
+// migrateEntry returns true if any of rules has the migrate-entry annotation.
+func migrateEntry(rules []*consulv1alpha1.ServiceIntention) bool {
+	for _, rule := range rules {
+		if rule.Annotations[common.MigrateEntryKey] == common.MigrateEntryTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// ownershipConflict reports on every rule that the destination can't be
+// managed by them. Deleted rules were never written to the config entry so
+// their finalizer is removed.
+func (r *ServiceIntentionController) ownershipConflict(ctx context.Context, active, deleted []*consulv1alpha1.ServiceIntention, reason, message string) (ctrl.Result, error) {
+	for _, rule := range active {
+		if err := r.updateRuleStatus(ctx, rule, corev1.ConditionFalse, reason, message, false); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.removeFinalizers(ctx, r.Log, deleted); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: ownershipConflictRequeue}, nil
+}
+
+// rulesSyncFailed sets the synced condition of every rule to false and
+// returns err so that the request is retried.
+func (r *ServiceIntentionController) rulesSyncFailed(ctx context.Context, logger logr.Logger, rules []*consulv1alpha1.ServiceIntention, errType string, err error) (ctrl.Result, error) {
+	for _, rule := range rules {
+		if updateErr := r.updateRuleStatus(ctx, rule, corev1.ConditionFalse, errType, err.Error(), false); updateErr != nil {
+			// Log the original error here because we are returning the updateErr.
+			// Otherwise the original error would be lost.
+			logger.Error(err, "sync failed")
+			return ctrl.Result{}, updateErr
+		}
+	}
+	return ctrl.Result{}, err
+}
+
+// updateRuleStatus sets the synced condition of the rule. The status is only
+// written if it changed, or if the config entry was written in which case
+// the last synced time of the rules it includes is updated.
+func (r *ServiceIntentionController) updateRuleStatus(ctx context.Context, rule *consulv1alpha1.ServiceIntention, status corev1.ConditionStatus, reason, message string, written bool) error {
+	currentStatus, currentReason, currentMessage := rule.SyncedCondition()
+	if !written && currentStatus == status && currentReason == reason && currentMessage == message {
+		return nil
+	}
+	rule.SetSyncedCondition(status, reason, message)
+	if status == corev1.ConditionTrue {
+		timeNow := metav1.NewTime(time.Now())
+		rule.SetLastSyncedTime(&timeNow)
+	}
+	return r.Status().Update(ctx, rule)
+}
+
+// removeFinalizers removes the finalizer from deleted rules once they are no
+// longer part of the config entry.
+func (r *ServiceIntentionController) removeFinalizers(ctx context.Context, logger logr.Logger, deleted []*consulv1alpha1.ServiceIntention) error {
+	for _, rule := range deleted {
+		controllerutil.RemoveFinalizer(rule, FinalizerName)
+		if err := r.Update(ctx, rule); err != nil {
+			return err
+		}
+		logger.Info("finalizer removed", "name", rule.Name, "ns", rule.Namespace)
+	}
+	return nil
+}
+
+// intentionSourceConflict is a rule whose source is already set by winner.
+type intentionSourceConflict struct {
+	rule   *consulv1alpha1.ServiceIntention
+	winner *consulv1alpha1.ServiceIntention
+}
+
+// resolveIntentionSources returns the rules to include in the config entry
+// and the rules that conflict with them. When several rules set the same
+// source the oldest one is included.
+func resolveIntentionSources(rules []*consulv1alpha1.ServiceIntention) ([]*consulv1alpha1.ServiceIntention, []intentionSourceConflict) {
+	sorted := make([]*consulv1alpha1.ServiceIntention, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	winners := make(map[string]*consulv1alpha1.ServiceIntention)
+	var included []*consulv1alpha1.ServiceIntention
+	var conflicts []intentionSourceConflict
+	for _, rule := range sorted {
+		key := rule.SourceKey()
+		if winner, ok := winners[key]; ok {
+			conflicts = append(conflicts, intentionSourceConflict{rule: rule, winner: winner})
+			continue
+		}
+		winners[key] = rule
+		included = append(included, rule)
+	}
+	return included, conflicts
+}
+
+// intentionSourcePrecedence returns the precedence of a source, higher is
+// evaluated first. Like Consul, exact names take precedence over wildcards
+// and namespaces over names.
+func intentionSourcePrecedence(source consulv1alpha1.SourceIntention) int {
+	precedence := 0
+	if source.Namespace != common.WildcardNamespace {
+		precedence += 2
+	}
+	if source.Name != "*" {
+		precedence++
+	}
+	return precedence
+}
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceIntentionController_aggregatesRules(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	created := time.Now().Add(-time.Hour)
+	rule := func(name, source string, action v1alpha1.IntentionAction, age time.Duration) *v1alpha1.ServiceIntention {
+		return &v1alpha1.ServiceIntention{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created.Add(-age)),
+			},
+			Spec: v1alpha1.ServiceIntentionSpec{
+				Destination: v1alpha1.IntentionDestination{Name: "db"},
+				Source:      v1alpha1.SourceIntention{Name: source, Action: action},
+			},
+		}
+	}
+	web := rule("web-to-db", "web", "allow", 2*time.Minute)
+	api := rule("api-to-db", "api", "deny", time.Minute)
+	duplicate := rule("web-to-db-2", "web", "deny", 0)
+	wildcard := rule("all-to-db", "*", "deny", 0)
+
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(web, api, duplicate, wildcard).Build()
+
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForServiceIntentions(t)
+	consulClient := testClient.APIClient
+
+	r := &ServiceIntentionController{
+		Client: fakeClient,
+		Log:    logrtest.TestLogger{T: t},
+		Scheme: s,
+		ConfigEntryController: &ConfigEntryController{
+			ConsulClientConfig:  testClient.Cfg,
+			ConsulServerConnMgr: testClient.Watcher,
+			DatacenterName:      datacenterName,
+		},
+	}
+	reconcileAll := func(rules ...*v1alpha1.ServiceIntention) {
+		for _, rule := range rules {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rule)})
+			require.NoError(t, err)
+		}
+	}
+	requireSources := func(expected ...*capi.SourceIntention) {
+		entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceIntentions, "db", nil)
+		require.NoError(t, err)
+		intentions, ok := entry.(*capi.ServiceIntentionsConfigEntry)
+		require.True(t, ok)
+		require.Equal(t, common.ServiceIntention, intentions.Meta[common.SourceKindKey])
+		require.Equal(t, datacenterName, intentions.Meta[common.DatacenterKey])
+		// Consul sorts the sources by precedence so they are compared as a set.
+		actual := make(map[string]capi.IntentionAction)
+		for _, source := range intentions.Sources {
+			actual[source.Name] = source.Action
+		}
+		exp := make(map[string]capi.IntentionAction)
+		for _, source := range expected {
+			exp[source.Name] = source.Action
+		}
+		require.Equal(t, exp, actual)
+	}
+	requireStatus := func(rule *v1alpha1.ServiceIntention, status corev1.ConditionStatus, reason string) {
+		var actual v1alpha1.ServiceIntention
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rule), &actual))
+		actualStatus, actualReason, _ := actual.SyncedCondition()
+		require.Equal(t, status, actualStatus, rule.Name)
+		require.Equal(t, reason, actualReason, rule.Name)
+		require.Contains(t, actual.Finalizers, FinalizerName)
+	}
+
+	// The finalizer is added by the first reconcile of each rule, after which
+	// every rule with the same destination is written to the config entry.
+	reconcileAll(web, api, duplicate, wildcard)
+	requireSources(
+		&capi.SourceIntention{Name: "api", Action: capi.IntentionActionDeny},
+		&capi.SourceIntention{Name: "web", Action: capi.IntentionActionAllow},
+		&capi.SourceIntention{Name: "*", Action: capi.IntentionActionDeny},
+	)
+	requireStatus(web, corev1.ConditionTrue, "")
+	requireStatus(api, corev1.ConditionTrue, "")
+	requireStatus(wildcard, corev1.ConditionTrue, "")
+	requireStatus(duplicate, corev1.ConditionFalse, IntentionSourceConflict)
+
+	// Deleting the oldest rule for a source makes the next one take its place.
+	require.NoError(t, fakeClient.Delete(ctx, web))
+	reconcileAll(web)
+	requireSources(
+		&capi.SourceIntention{Name: "api", Action: capi.IntentionActionDeny},
+		&capi.SourceIntention{Name: "web", Action: capi.IntentionActionDeny},
+		&capi.SourceIntention{Name: "*", Action: capi.IntentionActionDeny},
+	)
+	requireStatus(duplicate, corev1.ConditionTrue, "")
+	err := fakeClient.Get(ctx, client.ObjectKeyFromObject(web), &v1alpha1.ServiceIntention{})
+	require.True(t, client.IgnoreNotFound(err) == nil && err != nil, "rule should be deleted")
+
+	// The config entry is deleted with the last rule.
+	for _, rule := range []*v1alpha1.ServiceIntention{api, duplicate, wildcard} {
+		require.NoError(t, fakeClient.Delete(ctx, rule))
+	}
+	reconcileAll(api, duplicate, wildcard)
+	_, _, err = consulClient.ConfigEntries().Get(capi.ServiceIntentions, "db", nil)
+	require.True(t, isNotFoundErr(err))
+}
+
+func TestServiceIntentionController_conflicts(t *testing.T) {
+	t.Parallel()
+	rule := &v1alpha1.ServiceIntention{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "web-to-db",
+			Namespace:  "default",
+			Finalizers: []string{FinalizerName},
+		},
+		Spec: v1alpha1.ServiceIntentionSpec{
+			Destination: v1alpha1.IntentionDestination{Name: "db"},
+			Source:      v1alpha1.SourceIntention{Name: "web", Action: "allow"},
+		},
+	}
+	cases := map[string]struct {
+		existingResources []runtime.Object
+		consulEntry       *capi.ServiceIntentionsConfigEntry
+		clusterID         string
+		expReason         string
+		expMessage        string
+	}{
+		"destination managed by ServiceIntentions": {
+			existingResources: []runtime.Object{&v1alpha1.ServiceIntentions{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec: v1alpha1.ServiceIntentionsSpec{
+					Destination: v1alpha1.IntentionDestination{Name: "db"},
+					Sources:     v1alpha1.SourceIntentions{{Name: "api", Action: "allow"}},
+				},
+			}},
+			expReason:  OwnershipConflict,
+			expMessage: "config entry in Consul is managed by ServiceIntentions resource default/db",
+		},
+		"config entry created in Consul": {
+			consulEntry: &capi.ServiceIntentionsConfigEntry{
+				Kind:    capi.ServiceIntentions,
+				Name:    "db",
+				Sources: []*capi.SourceIntention{{Name: "api", Action: capi.IntentionActionAllow}},
+			},
+			expReason:  ExternallyManagedConfigError,
+			expMessage: "config entry already exists in Consul",
+		},
+		"config entry written by ServiceIntentions": {
+			consulEntry: &capi.ServiceIntentionsConfigEntry{
+				Kind:    capi.ServiceIntentions,
+				Name:    "db",
+				Sources: []*capi.SourceIntention{{Name: "api", Action: capi.IntentionActionAllow}},
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: datacenterName,
+				},
+			},
+			expReason:  OwnershipConflict,
+			expMessage: "config entry in Consul is managed by a ServiceIntentions resource",
+		},
+		"config entry written by ServiceIntention rules without a cluster ID": {
+			consulEntry: &capi.ServiceIntentionsConfigEntry{
+				Kind:    capi.ServiceIntentions,
+				Name:    "db",
+				Sources: []*capi.SourceIntention{{Name: "api", Action: capi.IntentionActionAllow}},
+				Meta: map[string]string{
+					common.SourceKey:     common.SourceValue,
+					common.DatacenterKey: datacenterName,
+					common.SourceKindKey: common.ServiceIntention,
+				},
+			},
+			clusterID:  "green",
+			expReason:  OwnershipConflict,
+			expMessage: "config entry in Consul is managed by a cluster without a cluster ID",
+		},
+	}
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := runtime.NewScheme()
+			require.NoError(t, v1alpha1.AddToScheme(s))
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(append(c.existingResources, rule.DeepCopy())...).Build()
+
+			testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+			testClient.TestServer.WaitForServiceIntentions(t)
+			consulClient := testClient.APIClient
+			if c.consulEntry != nil {
+				_, _, err := consulClient.ConfigEntries().Set(c.consulEntry, nil)
+				require.NoError(t, err)
+			}
+
+			r := &ServiceIntentionController{
+				Client: fakeClient,
+				Log:    logrtest.TestLogger{T: t},
+				Scheme: s,
+				ConfigEntryController: &ConfigEntryController{
+					ConsulClientConfig:  testClient.Cfg,
+					ConsulServerConnMgr: testClient.Watcher,
+					DatacenterName:      datacenterName,
+					ClusterID:           c.clusterID,
+				},
+			}
+			resp, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}})
+			require.NoError(t, err)
+			require.Equal(t, ownershipConflictRequeue, resp.RequeueAfter)
+
+			var actual v1alpha1.ServiceIntention
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rule), &actual))
+			status, reason, message := actual.SyncedCondition()
+			require.Equal(t, corev1.ConditionFalse, status)
+			require.Equal(t, c.expReason, reason)
+			require.Equal(t, c.expMessage, message)
+
+			// The config entry in Consul is left unchanged.
+			entry, _, err := consulClient.ConfigEntries().Get(capi.ServiceIntentions, "db", nil)
+			if c.consulEntry == nil {
+				require.True(t, isNotFoundErr(err))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "api", entry.(*capi.ServiceIntentionsConfigEntry).Sources[0].Name)
+			}
+		})
+	}
+}
+
+func TestResolveIntentionSources(t *testing.T) {
+	rule := func(name, source string, created int64) *v1alpha1.ServiceIntention {
+		return &v1alpha1.ServiceIntention{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.Unix(created, 0)},
+			Spec:       v1alpha1.ServiceIntentionSpec{Source: v1alpha1.SourceIntention{Name: source}},
+		}
+	}
+	a := rule("a", "web", 2)
+	b := rule("b", "web", 1)
+	c := rule("c", "web", 1)
+	d := rule("d", "api", 3)
+
+	included, conflicts := resolveIntentionSources([]*v1alpha1.ServiceIntention{a, b, c, d})
+	require.Equal(t, []*v1alpha1.ServiceIntention{b, d}, included)
+	require.Equal(t, []intentionSourceConflict{{rule: c, winner: b}, {rule: a, winner: b}}, conflicts)
+}
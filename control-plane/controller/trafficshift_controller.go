@@ -0,0 +1,359 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	consulv1alpha1 "github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
+	capi "github.com/hashicorp/consul/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ServiceSplitterError is the reason used when the ServiceSplitter of a
+	// TrafficShift resource can't be read or updated.
+	ServiceSplitterError = "ServiceSplitterError"
+
+	// prometheusQueryTimeout is how long a Prometheus query may take.
+	prometheusQueryTimeout = 10 * time.Second
+)
+
+// TrafficShiftController reconciles TrafficShift resources by updating the
+// weights of their ServiceSplitter. The ServiceSplitter controller then
+// writes the new weights to Consul.
+//
+// Each step's weight is kept for its duration while the canary subset is
+// checked every analysis interval. Once a step's duration has passed the
+// next step starts. If more checks fail than the failure limit allows, all
+// traffic is shifted back to the stable subset and the traffic shift stops
+// until its spec changes.
+type TrafficShiftController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// ConsulClientConfig is the config for the Consul API client.
+	ConsulClientConfig *consul.Config
+	// ConsulServerConnMgr is the watcher for the Consul server addresses.
+	ConsulServerConnMgr consul.ServerConnectionManager
+
+	// EnableConsulNamespaces indicates that a user is running Consul Enterprise
+	// with version 1.7+ which supports namespaces.
+	EnableConsulNamespaces bool
+	// ConsulDestinationNamespace is the namespace the ServiceSplitter is
+	// written to if mirroring is disabled.
+	ConsulDestinationNamespace string
+	// EnableNSMirroring causes config entries to be written to the Consul
+	// namespace that matches their k8s namespace.
+	EnableNSMirroring bool
+	// NSMirroringPrefix is an optional prefix that can be added to the Consul
+	// namespaces created while mirroring.
+	NSMirroringPrefix string
+
+	// HTTPClient is used to query Prometheus. http.DefaultClient is used if
+	// it is nil.
+	HTTPClient *http.Client
+}
+
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=trafficshifts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=consul.hashicorp.com,resources=trafficshifts/status,verbs=get;update;patch
+
+func (r *TrafficShiftController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("request", req.NamespacedName)
+
+	var shift consulv1alpha1.TrafficShift
+	err := r.Get(ctx, req.NamespacedName, &shift)
+	if k8serr.IsNotFound(err) {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if err != nil {
+		logger.Error(err, "retrieving resource")
+		return ctrl.Result{}, err
+	}
+	if !shift.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+	before := shift.Status.DeepCopy()
+
+	now := time.Now()
+	if shift.Status.ObservedGeneration != shift.Generation {
+		logger.Info("starting traffic shift")
+		startTime := metav1.NewTime(now)
+		shift.Status.ObservedGeneration = shift.Generation
+		shift.Status.Phase = consulv1alpha1.TrafficShiftProgressing
+		shift.Status.CurrentStep = 0
+		shift.Status.StepStartTime = &startTime
+		shift.Status.LastAnalysisTime = nil
+		shift.Status.Failures = 0
+		shift.Status.Message = ""
+	}
+	if shift.Status.Phase != consulv1alpha1.TrafficShiftProgressing {
+		return ctrl.Result{}, nil
+	}
+
+	var splitter consulv1alpha1.ServiceSplitter
+	splitterName := types.NamespacedName{Namespace: shift.Namespace, Name: shift.Spec.ServiceSplitter}
+	if err := r.Get(ctx, splitterName, &splitter); err != nil {
+		return resourceSyncFailed(ctx, logger, r.Status(), &shift, ServiceSplitterError,
+			fmt.Errorf("reading ServiceSplitter %q: %w", shift.Spec.ServiceSplitter, err))
+	}
+
+	// Check the canary subset at most once per interval so that reconciles
+	// caused by updating the status don't count as additional checks.
+	requeueAfter := shift.AnalysisInterval()
+	if shift.HasChecks() {
+		if last := shift.Status.LastAnalysisTime; last == nil || now.Sub(last.Time) >= shift.AnalysisInterval() {
+			analysisTime := metav1.NewTime(now)
+			shift.Status.LastAnalysisTime = &analysisTime
+			if msg := r.analyze(ctx, &shift, &splitter); msg != "" {
+				shift.Status.Failures++
+				shift.Status.Message = msg
+				logger.Info("canary check failed", "failures", shift.Status.Failures, "message", msg)
+				if shift.Status.Failures > shift.Spec.Analysis.FailureLimit {
+					logger.Info("rolling back traffic shift")
+					shift.Status.Phase = consulv1alpha1.TrafficShiftRolledBack
+					return r.setWeight(ctx, logger, before, &shift, &splitter, 0, 0)
+				}
+			}
+		} else {
+			requeueAfter = shift.AnalysisInterval() - now.Sub(last.Time)
+		}
+	}
+
+	step := shift.Spec.Steps[shift.Status.CurrentStep]
+	if remaining := step.Duration.Duration - now.Sub(shift.Status.StepStartTime.Time); remaining > 0 {
+		if remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+		return r.setWeight(ctx, logger, before, &shift, &splitter, step.Weight, requeueAfter)
+	}
+	if int(shift.Status.CurrentStep) == len(shift.Spec.Steps)-1 {
+		logger.Info("traffic shift succeeded")
+		shift.Status.Phase = consulv1alpha1.TrafficShiftSucceeded
+		return r.setWeight(ctx, logger, before, &shift, &splitter, step.Weight, 0)
+	}
+
+	shift.Status.CurrentStep++
+	startTime := metav1.NewTime(now)
+	shift.Status.StepStartTime = &startTime
+	step = shift.Spec.Steps[shift.Status.CurrentStep]
+	logger.Info("starting step", "step", shift.Status.CurrentStep, "weight", step.Weight)
+	if step.Duration.Duration < requeueAfter {
+		requeueAfter = step.Duration.Duration
+	}
+	return r.setWeight(ctx, logger, before, &shift, &splitter, step.Weight, requeueAfter)
+}
+
+func (r *TrafficShiftController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&consulv1alpha1.TrafficShift{}).
+		WithOptions(controllerOptions()).
+		Complete(r)
+}
+
+// setWeight sends weight percent of the splitter's traffic to the canary
+// subset and records it in the status of the traffic shift. The status is
+// only updated if it changed since before, so that updating it doesn't
+// trigger another reconcile. The request is requeued after requeueAfter, or
+// not at all if it is zero.
+func (r *TrafficShiftController) setWeight(ctx context.Context, logger logr.Logger, before *consulv1alpha1.TrafficShiftStatus, shift *consulv1alpha1.TrafficShift, splitter *consulv1alpha1.ServiceSplitter, weight float32, requeueAfter time.Duration) (ctrl.Result, error) {
+	if setSplitWeights(splitter, shift.Spec.StableSubset, shift.Spec.CanarySubset, weight) {
+		logger.Info("updating ServiceSplitter", "canary-weight", weight)
+		if err := r.Update(ctx, splitter); err != nil {
+			return resourceSyncFailed(ctx, logger, r.Status(), shift, ServiceSplitterError,
+				fmt.Errorf("updating ServiceSplitter %q: %w", splitter.Name, err))
+		}
+	}
+	shift.Status.CanaryWeight = weight
+	if shift.SyncedConditionStatus() == corev1.ConditionTrue && reflect.DeepEqual(*before, shift.Status) {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	if _, err := resourceSyncSuccessful(ctx, r.Status(), shift); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// setSplitWeights replaces the splits of splitter with a split for the stable
+// subset and one for the canary subset that gets weight percent of the
+// traffic. Existing splits for the subsets are kept so that their header
+// modifiers still apply. It returns true if the splits changed.
+func setSplitWeights(splitter *consulv1alpha1.ServiceSplitter, stable, canary string, weight float32) bool {
+	stableSplit := consulv1alpha1.ServiceSplit{ServiceSubset: stable}
+	canarySplit := consulv1alpha1.ServiceSplit{ServiceSubset: canary}
+	for _, split := range splitter.Spec.Splits {
+		if split.Service != "" && split.Service != splitter.Name {
+			continue
+		}
+		switch split.ServiceSubset {
+		case stable:
+			stableSplit = split
+		case canary:
+			canarySplit = split
+		}
+	}
+	stableSplit.Weight = 100 - weight
+	canarySplit.Weight = weight
+
+	splits := consulv1alpha1.ServiceSplits{stableSplit, canarySplit}
+	changed := len(splits) != len(splitter.Spec.Splits)
+	for i := 0; !changed && i < len(splits); i++ {
+		changed = splits[i] != splitter.Spec.Splits[i]
+	}
+	splitter.Spec.Splits = splits
+	return changed
+}
+
+// analyze runs the checks of the traffic shift against the canary subset. It
+// returns a message describing the first failed check, or an empty string if
+// all checks pass.
+func (r *TrafficShiftController) analyze(ctx context.Context, shift *consulv1alpha1.TrafficShift, splitter *consulv1alpha1.ServiceSplitter) string {
+	if shift.Spec.Analysis.ConsulHealth {
+		if err := r.checkConsulHealth(ctx, shift, splitter); err != nil {
+			return fmt.Sprintf("Consul health check failed: %s", err)
+		}
+	}
+	for _, check := range shift.Spec.Analysis.Prometheus {
+		value, err := r.queryPrometheus(ctx, check.Address, check.Query)
+		if err != nil {
+			return fmt.Sprintf("Prometheus check %q failed: %s", check.Name, err)
+		}
+		if check.Min != nil && value < float64(*check.Min) {
+			return fmt.Sprintf("Prometheus check %q failed: %v is less than %v", check.Name, value, *check.Min)
+		}
+		if check.Max != nil && value > float64(*check.Max) {
+			return fmt.Sprintf("Prometheus check %q failed: %v is greater than %v", check.Name, value, *check.Max)
+		}
+	}
+	return ""
+}
+
+// checkConsulHealth returns an error unless the canary subset has instances
+// and all of their health checks are passing. The instances of the subset
+// are selected with the filter of the subset in the ServiceResolver of the
+// splitter's service.
+func (r *TrafficShiftController) checkConsulHealth(ctx context.Context, shift *consulv1alpha1.TrafficShift, splitter *consulv1alpha1.ServiceSplitter) error {
+	var resolver consulv1alpha1.ServiceResolver
+	if err := r.Get(ctx, types.NamespacedName{Namespace: splitter.Namespace, Name: splitter.Name}, &resolver); err != nil {
+		return fmt.Errorf("reading ServiceResolver %q: %w", splitter.Name, err)
+	}
+	subset, ok := resolver.Spec.Subsets[shift.Spec.CanarySubset]
+	if !ok {
+		return fmt.Errorf("subset %q is not defined by ServiceResolver %q", shift.Spec.CanarySubset, resolver.Name)
+	}
+
+	consulClient, err := consulClientFromConnMgr(r.ConsulClientConfig, r.ConsulServerConnMgr)
+	if err != nil {
+		return err
+	}
+	consulNS := namespaces.ConsulNamespace(splitter.Namespace, r.EnableConsulNamespaces,
+		r.ConsulDestinationNamespace, r.EnableNSMirroring, r.NSMirroringPrefix)
+	entries, _, err := consulClient.Health().Service(splitter.ConsulName(), "", false, &capi.QueryOptions{
+		Namespace: consulNS,
+		Filter:    subset.Filter,
+	})
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("subset %q has no instances", shift.Spec.CanarySubset)
+	}
+	for _, entry := range entries {
+		if status := entry.Checks.AggregatedStatus(); status != capi.HealthPassing {
+			return fmt.Errorf("instance %q is %s", entry.Service.ID, status)
+		}
+	}
+	return nil
+}
+
+// prometheusResponse is the response of the Prometheus instant query API.
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// queryPrometheus runs an instant query against the Prometheus server at
+// address and returns its result, which must be a single value.
+func (r *TrafficShiftController) queryPrometheus(ctx context.Context, address, query string) (float64, error) {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	ctx, cancel := context.WithTimeout(ctx, prometheusQueryTimeout)
+	defer cancel()
+	queryURL := strings.TrimSuffix(address, "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var promResp prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&promResp); err != nil {
+		return 0, fmt.Errorf("decoding response: %w", err)
+	}
+	if promResp.Status != "success" {
+		return 0, fmt.Errorf("query failed: %s", promResp.Error)
+	}
+
+	// A sample is a [timestamp, "value"] pair.
+	var sample []interface{}
+	switch promResp.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(promResp.Data.Result, &sample); err != nil {
+			return 0, fmt.Errorf("decoding result: %w", err)
+		}
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(promResp.Data.Result, &vector); err != nil {
+			return 0, fmt.Errorf("decoding result: %w", err)
+		}
+		if len(vector) != 1 {
+			return 0, fmt.Errorf("query returned %d values but must return one", len(vector))
+		}
+		sample = vector[0].Value
+	default:
+		return 0, fmt.Errorf("query returned a %s but must return a scalar or a vector", promResp.Data.ResultType)
+	}
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("unexpected sample %v", sample)
+	}
+	str, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample value %v", sample[1])
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(value) {
+		return 0, fmt.Errorf("query returned NaN")
+	}
+	return value, nil
+}
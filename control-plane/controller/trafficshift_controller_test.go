@@ -0,0 +1,366 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	logrtest "github.com/go-logr/logr/testing"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/helper/test"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTrafficShiftController_progressesThroughSteps(t *testing.T) {
+	t.Parallel()
+
+	shift := trafficShift([]v1alpha1.TrafficShiftStep{
+		{Weight: 10, Duration: metav1.Duration{Duration: time.Hour}},
+		{Weight: 50, Duration: metav1.Duration{Duration: time.Hour}},
+		{Weight: 100},
+	})
+	r := trafficShiftTestController(t, shift, trafficShiftSplitter())
+
+	// The first step starts right away.
+	result := reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, v1alpha1.TrafficShiftProgressing, shift.Status.Phase)
+	require.Equal(t, int32(0), shift.Status.CurrentStep)
+	require.Equal(t, float32(10), shift.Status.CanaryWeight)
+	require.Equal(t, float32(10), requireSplitWeight(t, r, "v2"))
+	require.Equal(t, float32(90), requireSplitWeight(t, r, "v1"))
+	require.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= time.Hour)
+
+	// Reconciling again before the step is complete keeps the weight.
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, int32(0), shift.Status.CurrentStep)
+
+	expireTrafficShiftStep(t, r, shift)
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, int32(1), shift.Status.CurrentStep)
+	require.Equal(t, float32(50), requireSplitWeight(t, r, "v2"))
+
+	expireTrafficShiftStep(t, r, shift)
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, int32(2), shift.Status.CurrentStep)
+	require.Equal(t, float32(100), requireSplitWeight(t, r, "v2"))
+	require.Equal(t, float32(0), requireSplitWeight(t, r, "v1"))
+
+	// The last step has no duration so the traffic shift succeeds.
+	result = reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, v1alpha1.TrafficShiftSucceeded, shift.Status.Phase)
+	require.Equal(t, ctrl.Result{}, result)
+
+	// Changing the spec starts the traffic shift again.
+	shift.Generation++
+	require.NoError(t, r.Update(context.Background(), shift))
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, v1alpha1.TrafficShiftProgressing, shift.Status.Phase)
+	require.Equal(t, int32(0), shift.Status.CurrentStep)
+	require.Equal(t, float32(10), requireSplitWeight(t, r, "v2"))
+}
+
+func TestTrafficShiftController_prometheusAnalysis(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		max          float32
+		failureLimit int32
+		expPhase     string
+		expWeight    float32
+		expFailures  int32
+		expMessage   string
+	}{
+		"passing": {
+			max:       1,
+			expPhase:  v1alpha1.TrafficShiftProgressing,
+			expWeight: 10,
+		},
+		"failing within the failure limit": {
+			max:          0.1,
+			failureLimit: 1,
+			expPhase:     v1alpha1.TrafficShiftProgressing,
+			expWeight:    10,
+			expFailures:  1,
+			expMessage:   `Prometheus check "errors" failed: 0.5 is greater than 0.1`,
+		},
+		"failing rolls back": {
+			max:         0.1,
+			expPhase:    v1alpha1.TrafficShiftRolledBack,
+			expWeight:   0,
+			expFailures: 1,
+			expMessage:  `Prometheus check "errors" failed: 0.5 is greater than 0.1`,
+		},
+	}
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				require.Equal(t, "/api/v1/query", req.URL.Path)
+				require.Equal(t, `rate(errors{subset="v2"}[1m])`, req.URL.Query().Get("query"))
+				fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1,"0.5"]}]}}`)
+			}))
+			defer prometheus.Close()
+
+			shift := trafficShift([]v1alpha1.TrafficShiftStep{
+				{Weight: 10, Duration: metav1.Duration{Duration: time.Hour}},
+				{Weight: 100},
+			})
+			shift.Spec.Analysis = v1alpha1.TrafficShiftAnalysis{
+				FailureLimit: c.failureLimit,
+				Prometheus: []v1alpha1.TrafficShiftPrometheusCheck{{
+					Name:    "errors",
+					Address: prometheus.URL,
+					Query:   `rate(errors{subset="v2"}[1m])`,
+					Max:     &c.max,
+				}},
+			}
+			r := trafficShiftTestController(t, shift, trafficShiftSplitter())
+
+			reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+			require.Equal(t, c.expPhase, shift.Status.Phase)
+			require.Equal(t, c.expWeight, shift.Status.CanaryWeight)
+			require.Equal(t, c.expWeight, requireSplitWeight(t, r, "v2"))
+			require.Equal(t, c.expFailures, shift.Status.Failures)
+			require.Equal(t, c.expMessage, shift.Status.Message)
+			require.NotNil(t, shift.Status.LastAnalysisTime)
+		})
+	}
+}
+
+func TestTrafficShiftController_rollsBackAfterFailureLimit(t *testing.T) {
+	t.Parallel()
+
+	value := "0"
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"scalar","result":[1,%q]}}`, value)
+	}))
+	defer prometheus.Close()
+
+	max := float32(0.1)
+	shift := trafficShift([]v1alpha1.TrafficShiftStep{
+		{Weight: 10, Duration: metav1.Duration{Duration: time.Minute}},
+		{Weight: 50, Duration: metav1.Duration{Duration: time.Hour}},
+	})
+	shift.Spec.Analysis = v1alpha1.TrafficShiftAnalysis{
+		Interval:     metav1.Duration{Duration: time.Second},
+		FailureLimit: 1,
+		Prometheus: []v1alpha1.TrafficShiftPrometheusCheck{{
+			Name:    "errors",
+			Address: prometheus.URL,
+			Query:   "errors",
+			Max:     &max,
+		}},
+	}
+	r := trafficShiftTestController(t, shift, trafficShiftSplitter())
+
+	// The checks aren't run again until the interval has passed.
+	result := reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= time.Second)
+	value = "1"
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, int32(0), shift.Status.Failures)
+
+	expireTrafficShiftAnalysis(t, r, shift)
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, v1alpha1.TrafficShiftProgressing, shift.Status.Phase)
+	require.Equal(t, int32(1), shift.Status.Failures)
+
+	expireTrafficShiftAnalysis(t, r, shift)
+	result = reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, v1alpha1.TrafficShiftRolledBack, shift.Status.Phase)
+	require.Equal(t, int32(2), shift.Status.Failures)
+	require.Equal(t, float32(0), shift.Status.CanaryWeight)
+	require.Equal(t, float32(0), requireSplitWeight(t, r, "v2"))
+	require.Equal(t, float32(100), requireSplitWeight(t, r, "v1"))
+	require.Equal(t, ctrl.Result{}, result)
+
+	// A rolled back traffic shift stays rolled back.
+	value = "0"
+	expireTrafficShiftStep(t, r, shift)
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, v1alpha1.TrafficShiftRolledBack, shift.Status.Phase)
+	require.Equal(t, float32(0), requireSplitWeight(t, r, "v2"))
+}
+
+func TestTrafficShiftController_consulHealthAnalysis(t *testing.T) {
+	t.Parallel()
+
+	shift := trafficShift([]v1alpha1.TrafficShiftStep{{Weight: 10, Duration: metav1.Duration{Duration: time.Hour}}})
+	shift.Spec.Analysis = v1alpha1.TrafficShiftAnalysis{
+		Interval:     metav1.Duration{Duration: time.Second},
+		FailureLimit: 5,
+		ConsulHealth: true,
+	}
+	resolver := &v1alpha1.ServiceResolver{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1alpha1.ServiceResolverSpec{
+			Subsets: v1alpha1.ServiceResolverSubsetMap{
+				"v1": {Filter: "Service.Meta.version == v1"},
+				"v2": {Filter: "Service.Meta.version == v2"},
+			},
+		},
+	}
+	r := trafficShiftTestController(t, shift, trafficShiftSplitter(), resolver)
+	testClient := test.TestServerWithMockConnMgrWatcher(t, nil)
+	testClient.TestServer.WaitForLeader(t)
+	r.ConsulClientConfig = testClient.Cfg
+	r.ConsulServerConnMgr = testClient.Watcher
+	consulClient := testClient.APIClient
+
+	// Only instances of the stable subset are registered.
+	require.NoError(t, consulClient.Agent().ServiceRegister(&capi.AgentServiceRegistration{
+		ID: "web-v1", Name: "web", Meta: map[string]string{"version": "v1"},
+	}))
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, int32(1), shift.Status.Failures)
+	require.Equal(t, `Consul health check failed: subset "v2" has no instances`, shift.Status.Message)
+
+	// An instance of the canary subset with a failing check.
+	require.NoError(t, consulClient.Agent().ServiceRegister(&capi.AgentServiceRegistration{
+		ID: "web-v2", Name: "web", Meta: map[string]string{"version": "v2"},
+		Check: &capi.AgentServiceCheck{CheckID: "web-v2-ttl", TTL: "1h", Status: capi.HealthCritical},
+	}))
+	expireTrafficShiftAnalysis(t, r, shift)
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, int32(2), shift.Status.Failures)
+	require.Equal(t, `Consul health check failed: instance "web-v2" is critical`, shift.Status.Message)
+
+	// Once the check passes so does the analysis.
+	require.NoError(t, consulClient.Agent().UpdateTTL("web-v2-ttl", "", capi.HealthPassing))
+	expireTrafficShiftAnalysis(t, r, shift)
+	reconcileTrafficShift(t, r, shift, corev1.ConditionTrue, "")
+	require.Equal(t, int32(2), shift.Status.Failures)
+	require.Equal(t, v1alpha1.TrafficShiftProgressing, shift.Status.Phase)
+}
+
+func TestTrafficShiftController_missingSplitter(t *testing.T) {
+	t.Parallel()
+
+	shift := trafficShift([]v1alpha1.TrafficShiftStep{{Weight: 10}})
+	r := trafficShiftTestController(t, shift)
+
+	reconcileTrafficShift(t, r, shift, corev1.ConditionFalse, ServiceSplitterError)
+	require.Contains(t, shift.Status.Conditions[0].Message, `reading ServiceSplitter "web"`)
+}
+
+func TestSetSplitWeights(t *testing.T) {
+	splitter := &v1alpha1.ServiceSplitter{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: v1alpha1.ServiceSplitterSpec{
+			Splits: v1alpha1.ServiceSplits{
+				{Weight: 50, Service: "other"},
+				{
+					Weight:         50,
+					ServiceSubset:  "v2",
+					RequestHeaders: &v1alpha1.HTTPHeaderModifiers{Set: map[string]string{"x-canary": "true"}},
+				},
+			},
+		},
+	}
+
+	require.True(t, setSplitWeights(splitter, "v1", "v2", 25))
+	require.Equal(t, v1alpha1.ServiceSplits{
+		{Weight: 75, ServiceSubset: "v1"},
+		{
+			Weight:         25,
+			ServiceSubset:  "v2",
+			RequestHeaders: &v1alpha1.HTTPHeaderModifiers{Set: map[string]string{"x-canary": "true"}},
+		},
+	}, splitter.Spec.Splits)
+
+	require.False(t, setSplitWeights(splitter, "v1", "v2", 25))
+	require.True(t, setSplitWeights(splitter, "v1", "v2", 0))
+	require.Equal(t, float32(100), splitter.Spec.Splits[0].Weight)
+}
+
+func trafficShift(steps []v1alpha1.TrafficShiftStep) *v1alpha1.TrafficShift {
+	return &v1alpha1.TrafficShift{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: 1},
+		Spec: v1alpha1.TrafficShiftSpec{
+			ServiceSplitter: "web",
+			StableSubset:    "v1",
+			CanarySubset:    "v2",
+			Steps:           steps,
+		},
+	}
+}
+
+func trafficShiftSplitter() *v1alpha1.ServiceSplitter {
+	return &v1alpha1.ServiceSplitter{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1alpha1.ServiceSplitterSpec{
+			Splits: v1alpha1.ServiceSplits{{Weight: 100, ServiceSubset: "v1"}},
+		},
+	}
+}
+
+func trafficShiftTestController(t *testing.T, objs ...runtime.Object) *TrafficShiftController {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	return &TrafficShiftController{
+		Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build(),
+		Log:    logrtest.TestLogger{T: t},
+	}
+}
+
+// reconcileTrafficShift reconciles shift and refreshes it from Kubernetes. It
+// checks the synced condition has the expected status and reason.
+func reconcileTrafficShift(t *testing.T, r *TrafficShiftController, shift *v1alpha1.TrafficShift, expStatus corev1.ConditionStatus, expReason string) ctrl.Result {
+	t.Helper()
+	namespacedName := types.NamespacedName{Name: shift.Name, Namespace: shift.Namespace}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: namespacedName})
+	if expStatus == corev1.ConditionTrue {
+		require.NoError(t, err)
+	} else {
+		require.Error(t, err)
+	}
+	require.NoError(t, r.Get(context.Background(), namespacedName, shift))
+	require.Equal(t, expStatus, shift.SyncedConditionStatus())
+	require.Equal(t, expReason, shift.Status.Conditions[0].Reason)
+	return result
+}
+
+// expireTrafficShiftStep moves the start of the current step into the past so
+// that the next reconcile completes it.
+func expireTrafficShiftStep(t *testing.T, r *TrafficShiftController, shift *v1alpha1.TrafficShift) {
+	t.Helper()
+	past := metav1.NewTime(time.Now().Add(-24 * time.Hour))
+	shift.Status.StepStartTime = &past
+	require.NoError(t, r.Status().Update(context.Background(), shift))
+}
+
+// expireTrafficShiftAnalysis moves the last analysis into the past so that
+// the next reconcile runs the checks.
+func expireTrafficShiftAnalysis(t *testing.T, r *TrafficShiftController, shift *v1alpha1.TrafficShift) {
+	t.Helper()
+	past := metav1.NewTime(time.Now().Add(-24 * time.Hour))
+	shift.Status.LastAnalysisTime = &past
+	require.NoError(t, r.Status().Update(context.Background(), shift))
+}
+
+// requireSplitWeight returns the weight of the split for subset in the
+// ServiceSplitter.
+func requireSplitWeight(t *testing.T, r *TrafficShiftController, subset string) float32 {
+	t.Helper()
+	var splitter v1alpha1.ServiceSplitter
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "web", Namespace: "default"}, &splitter))
+	for _, split := range splitter.Spec.Splits {
+		if split.ServiceSubset == subset {
+			return split.Weight
+		}
+	}
+	require.Failf(t, "split not found", "subset %q", subset)
+	return 0
+}
@@ -0,0 +1,508 @@
+package configimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/consul"
+	"github.com/hashicorp/consul-k8s/control-plane/subcommand"
+	subcommon "github.com/hashicorp/consul-k8s/control-plane/subcommand/common"
+	"github.com/hashicorp/consul-k8s/control-plane/subcommand/flags"
+	"github.com/hashicorp/consul-server-connection-manager/discovery"
+	capi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// kubeKinds maps the Consul config entry kinds that can be imported to the
+// kind of the custom resource they are imported as.
+var kubeKinds = map[string]string{
+	capi.APIGateway:         "APIGateway",
+	capi.ExportedServices:   "ExportedServices",
+	capi.HTTPRoute:          "HTTPRoute",
+	capi.IngressGateway:     "IngressGateway",
+	capi.InlineCertificate:  "InlineCertificate",
+	capi.JWTProvider:        "JWTProvider",
+	capi.MeshConfig:         "Mesh",
+	capi.ProxyDefaults:      "ProxyDefaults",
+	capi.SamenessGroup:      "SamenessGroup",
+	capi.ServiceDefaults:    "ServiceDefaults",
+	capi.ServiceIntentions:  "ServiceIntentions",
+	capi.ServiceResolver:    "ServiceResolver",
+	capi.ServiceRouter:      "ServiceRouter",
+	capi.ServiceSplitter:    "ServiceSplitter",
+	capi.TCPRoute:           "TCPRoute",
+	capi.TerminatingGateway: "TerminatingGateway",
+}
+
+// invalidNameChars matches the characters that can't be used in the name of
+// a Kubernetes resource.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]`)
+
+type Command struct {
+	UI cli.Ui
+
+	flags  *flag.FlagSet
+	consul *flags.ConsulFlags
+	k8s    *flags.K8SFlags
+
+	flagKinds                      []string
+	flagK8sNamespace               string
+	flagEnableNamespaces           bool
+	flagConsulDestinationNamespace string
+	flagEnableNSMirroring          bool
+	flagNSMirroringPrefix          string
+	flagOutputDir                  string
+	flagApply                      bool
+	flagTimeout                    time.Duration
+	flagLogLevel                   string
+	flagLogJSON                    bool
+
+	// consulClient and k8sClient might be set in tests.
+	consulClient *capi.Client
+	k8sClient    client.Client
+
+	scheme *runtime.Scheme
+	log    hclog.Logger
+
+	once sync.Once
+	help string
+}
+
+func (c *Command) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.Var((*flags.AppendSliceValue)(&c.flagKinds), "kind",
+		"Kind of config entry to import, e.g. service-defaults. May be specified multiple times. "+
+			"All kinds that have a custom resource are imported if not set.")
+	c.flags.StringVar(&c.flagK8sNamespace, "k8s-namespace", "default",
+		"Kubernetes namespace the resources are created in. If namespace mirroring is enabled, "+
+			"this is only used for global config entries such as proxy-defaults.")
+	c.flags.BoolVar(&c.flagEnableNamespaces, "enable-namespaces", false,
+		"[Enterprise Only] Enables Consul Enterprise namespaces.")
+	c.flags.StringVar(&c.flagConsulDestinationNamespace, "consul-destination-namespace", "default",
+		"[Enterprise Only] Consul namespace to import config entries from. Ignored if namespace mirroring is enabled.")
+	c.flags.BoolVar(&c.flagEnableNSMirroring, "enable-k8s-namespace-mirroring", false,
+		"[Enterprise Only] Import config entries from all Consul namespaces into the "+
+			"Kubernetes namespace with the same name.")
+	c.flags.StringVar(&c.flagNSMirroringPrefix, "k8s-namespace-mirroring-prefix", "",
+		"[Enterprise Only] Prefix that is removed from Consul namespaces to get the "+
+			"Kubernetes namespace when namespace mirroring is enabled.")
+	c.flags.StringVar(&c.flagOutputDir, "output-dir", "",
+		"Directory to write one manifest per config entry to. Manifests are written to "+
+			"standard output if not set.")
+	c.flags.BoolVar(&c.flagApply, "apply", false,
+		"Create the resources in Kubernetes. Resources that already exist are left unchanged.")
+	c.flags.DurationVar(&c.flagTimeout, "timeout", 5*time.Minute,
+		"How long to wait for the import to complete before timing out, e.g. 1ms, 2s, 3m")
+	c.flags.StringVar(&c.flagLogLevel, "log-level", "info",
+		"Log verbosity level. Supported values (in order of detail) are \"trace\", "+
+			"\"debug\", \"info\", \"warn\", and \"error\".")
+	c.flags.BoolVar(&c.flagLogJSON, "log-json", false,
+		"Enable or disable JSON output format for logging.")
+
+	c.consul = &flags.ConsulFlags{}
+	c.k8s = &flags.K8SFlags{}
+	flags.Merge(c.flags, c.consul.Flags())
+	flags.Merge(c.flags, c.k8s.Flags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *Command) Synopsis() string { return synopsis }
+
+func (c *Command) Help() string {
+	c.once.Do(c.init)
+	return c.help
+}
+
+// Run generates custom resources for the config entries in Consul and writes
+// them out or applies them to Kubernetes.
+func (c *Command) Run(args []string) int {
+	c.once.Do(c.init)
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+	if len(c.flags.Args()) > 0 {
+		c.UI.Error("Should have no non-flag arguments.")
+		return 1
+	}
+	if err := c.validateFlags(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	var err error
+	c.log, err = subcommon.Logger(c.flagLogLevel, c.flagLogJSON)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.flagTimeout)
+	defer cancel()
+
+	c.scheme = runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(c.scheme); err != nil {
+		c.UI.Error(fmt.Sprintf("Error building scheme: %s", err))
+		return 1
+	}
+
+	if c.consulClient == nil {
+		serverConnMgrCfg, err := c.consul.ConsulServerConnMgrConfig()
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("unable to create config for consul-server-connection-manager: %s", err))
+			return 1
+		}
+		serverConnMgrCfg.ServerWatchDisabled = true
+		watcher, err := discovery.NewWatcher(ctx, serverConnMgrCfg, c.log.Named("consul-server-connection-manager"))
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("unable to create Consul server watcher: %s", err))
+			return 1
+		}
+		go watcher.Run()
+		defer watcher.Stop()
+
+		state, err := watcher.State()
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("unable to get Consul server addresses from watcher: %s", err))
+			return 1
+		}
+		c.consulClient, err = consul.NewClientFromConnMgrState(c.consul.ConsulClientConfig(), state)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("unable to create Consul client: %s", err))
+			return 1
+		}
+	}
+
+	if c.flagApply && c.k8sClient == nil {
+		config, err := subcommand.K8SConfig(c.k8s.KubeConfig())
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error retrieving Kubernetes auth: %s", err))
+			return 1
+		}
+		c.k8sClient, err = client.New(config, client.Options{Scheme: c.scheme})
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error initializing Kubernetes client: %s", err))
+			return 1
+		}
+	}
+
+	resources, failed := c.importConfigEntries(ctx)
+
+	var manifests []string
+	for _, resource := range resources {
+		manifest, err := c.manifest(resource)
+		if err != nil {
+			c.log.Error("unable to generate manifest", "kind", resource.ConsulKind(), "name", resource.ConsulName(), "error", err)
+			failed = true
+			continue
+		}
+
+		if c.flagOutputDir != "" {
+			path := filepath.Join(c.flagOutputDir, manifestFileName(resource))
+			if err := os.WriteFile(path, manifest, 0644); err != nil {
+				c.UI.Error(fmt.Sprintf("Error writing manifest %q: %s", path, err))
+				return 1
+			}
+			c.log.Info("wrote manifest", "path", path)
+		} else {
+			manifests = append(manifests, string(manifest))
+		}
+
+		if c.flagApply {
+			err := c.k8sClient.Create(ctx, resource)
+			switch {
+			case k8serrors.IsAlreadyExists(err):
+				c.log.Info("resource already exists, leaving it unchanged", "kind", resource.KubeKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+			case err != nil:
+				c.log.Error("unable to create resource", "kind", resource.KubeKind(), "namespace", resource.GetNamespace(), "name", resource.GetName(), "error", err)
+				failed = true
+			default:
+				c.log.Info("created resource", "kind", resource.KubeKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+			}
+		}
+	}
+	if len(manifests) > 0 {
+		c.UI.Output(strings.Join(manifests, "---\n"))
+	}
+
+	if failed {
+		c.UI.Error("Some config entries could not be imported, see the logs for details.")
+		return 1
+	}
+	return 0
+}
+
+// importConfigEntries lists the config entries of each kind from Consul and
+// converts them to custom resources. It returns true if any of them couldn't
+// be converted.
+func (c *Command) importConfigEntries(ctx context.Context) ([]common.ConfigEntryResource, bool) {
+	kinds := c.flagKinds
+	if len(kinds) == 0 {
+		for kind := range kubeKinds {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+	}
+
+	var resources []common.ConfigEntryResource
+	seen := make(map[string]string)
+	failed := false
+	for _, kind := range kinds {
+		entries, _, err := c.consulClient.ConfigEntries().List(kind, c.queryOptions(ctx))
+		// Older Consul servers don't support every kind, which only matters
+		// if the kind was asked for explicitly.
+		if err != nil && len(c.flagKinds) == 0 && strings.Contains(err.Error(), "invalid config entry kind") {
+			c.log.Warn("config entry kind is not supported by Consul, skipping", "kind", kind)
+			continue
+		}
+		if err != nil {
+			c.log.Error("unable to list config entries", "kind", kind, "error", err)
+			failed = true
+			continue
+		}
+		for _, entry := range entries {
+			resource, err := c.fromConsul(entry)
+			if err != nil {
+				c.log.Error("unable to import config entry", "kind", kind, "namespace", entry.GetNamespace(), "name", entry.GetName(), "error", err)
+				failed = true
+				continue
+			}
+			if resource == nil {
+				c.log.Debug("skipping config entry in unmirrored namespace", "kind", kind, "namespace", entry.GetNamespace(), "name", entry.GetName())
+				continue
+			}
+
+			// Config entries in different Consul namespaces or with names
+			// that differ only in characters that are invalid in Kubernetes
+			// can end up with the same resource name.
+			key := fmt.Sprintf("%s/%s/%s", resource.KubeKind(), resource.GetNamespace(), resource.GetName())
+			if other, ok := seen[key]; ok {
+				c.log.Error("config entry would be imported as the same resource as another config entry",
+					"kind", kind, "name", entry.GetName(), "other", other, "resource", key)
+				failed = true
+				continue
+			}
+			seen[key] = entry.GetName()
+			resources = append(resources, resource)
+		}
+	}
+	return resources, failed
+}
+
+// queryOptions returns the options used to list config entries from the
+// Consul namespaces and partition the controller manages.
+func (c *Command) queryOptions(ctx context.Context) *capi.QueryOptions {
+	opts := &capi.QueryOptions{Partition: c.consul.Partition}
+	if c.flagEnableNamespaces {
+		opts.Namespace = c.flagConsulDestinationNamespace
+		if c.flagEnableNSMirroring {
+			opts.Namespace = common.WildcardNamespace
+		}
+	}
+	return opts.WithContext(ctx)
+}
+
+// fromConsul converts a config entry to the custom resource that generates
+// it. It returns nil if the config entry is in a Consul namespace that isn't
+// mirrored to a Kubernetes namespace.
+func (c *Command) fromConsul(entry capi.ConfigEntry) (common.ConfigEntryResource, error) {
+	kubeKind, ok := kubeKinds[entry.GetKind()]
+	if !ok {
+		return nil, fmt.Errorf("config entries of kind %q can't be imported", entry.GetKind())
+	}
+	obj, err := c.scheme.New(v1alpha1.GroupVersion.WithKind(kubeKind))
+	if err != nil {
+		return nil, err
+	}
+	resource, ok := obj.(common.ConfigEntryResource)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a config entry resource", kubeKind)
+	}
+
+	namespace, ok := c.k8sNamespace(entry, resource.ConsulGlobalResource())
+	if !ok {
+		return nil, nil
+	}
+
+	// The custom resource specs use the same fields as the Consul API
+	// with lower camel case names, which encoding/json matches case
+	// insensitively. Fields that don't line up are caught by the
+	// MatchesConsul check below.
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, err
+	}
+	// The status of gateways and routes is computed by Consul.
+	for _, field := range []string{"Kind", "Name", "Namespace", "Partition", "Meta", "Status", "CreateIndex", "ModifyIndex"} {
+		delete(spec, field)
+	}
+
+	name := entry.GetName()
+	if entry.GetKind() == capi.ServiceIntentions {
+		destination := map[string]interface{}{"name": entry.GetName()}
+		if c.flagEnableNamespaces {
+			destination["namespace"] = entry.GetNamespace()
+		}
+		spec["destination"] = destination
+		if name == common.WildcardNamespace {
+			name = "wildcard"
+		}
+		name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	}
+
+	raw, err = json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"annotations": map[string]string{
+				common.MigrateEntryKey: common.MigrateEntryTrue,
+			},
+		},
+		"spec": spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, resource); err != nil {
+		return nil, err
+	}
+	resource.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(kubeKind))
+
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return nil, fmt.Errorf("%q is not a valid Kubernetes resource name: %s", name, strings.Join(errs, ", "))
+	}
+	if !resource.MatchesConsul(entry) {
+		return nil, errors.New("config entry uses fields that are not supported by the custom resource")
+	}
+	return resource, nil
+}
+
+// k8sNamespace returns the Kubernetes namespace of the resource for entry,
+// or false if the Consul namespace isn't mirrored.
+func (c *Command) k8sNamespace(entry capi.ConfigEntry, globalResource bool) (string, bool) {
+	if !c.flagEnableNamespaces || !c.flagEnableNSMirroring || globalResource {
+		return c.flagK8sNamespace, true
+	}
+	namespace := entry.GetNamespace()
+	if namespace == "" {
+		namespace = "default"
+	}
+	if !strings.HasPrefix(namespace, c.flagNSMirroringPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(namespace, c.flagNSMirroringPrefix), true
+}
+
+// manifest returns the YAML manifest of resource without its status and the
+// metadata set by Kubernetes.
+func (c *Command) manifest(resource common.ConfigEntryResource) ([]byte, error) {
+	raw, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+	delete(obj, "status")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+	pruneEmpty(obj)
+	return yaml.Marshal(obj)
+}
+
+// pruneEmpty removes the empty objects that struct fields without omitempty
+// leave in obj, e.g. `expose: {}`.
+func pruneEmpty(obj map[string]interface{}) {
+	for k, v := range obj {
+		switch value := v.(type) {
+		case map[string]interface{}:
+			pruneEmpty(value)
+			if len(value) == 0 {
+				delete(obj, k)
+			}
+		case []interface{}:
+			for _, item := range value {
+				if m, ok := item.(map[string]interface{}); ok {
+					pruneEmpty(m)
+				}
+			}
+		}
+	}
+}
+
+// manifestFileName returns the name of the file the manifest of resource is
+// written to.
+func manifestFileName(resource common.ConfigEntryResource) string {
+	return fmt.Sprintf("%s-%s-%s.yaml", resource.GetNamespace(), resource.KubeKind(), resource.GetName())
+}
+
+func (c *Command) validateFlags() error {
+	if len(c.consul.Addresses) == 0 {
+		return errors.New("-addresses must be set")
+	}
+	for _, kind := range c.flagKinds {
+		if _, ok := kubeKinds[kind]; !ok {
+			return fmt.Errorf("-kind %q is not a config entry kind that can be imported", kind)
+		}
+	}
+	if c.flagK8sNamespace == "" {
+		return errors.New("-k8s-namespace must be set")
+	}
+	if c.flagEnableNSMirroring && !c.flagEnableNamespaces {
+		return errors.New("-enable-k8s-namespace-mirroring requires -enable-namespaces")
+	}
+	if c.flagOutputDir != "" {
+		info, err := os.Stat(c.flagOutputDir)
+		if err != nil {
+			return fmt.Errorf("-output-dir %q: %s", c.flagOutputDir, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("-output-dir %q is not a directory", c.flagOutputDir)
+		}
+	}
+	return nil
+}
+
+const synopsis = "Import config entries from Consul as custom resources."
+const help = `
+Usage: consul-k8s-control-plane config import [options]
+
+  Lists the config entries in Consul and generates the custom resources
+  that manage them, annotated with consul.hashicorp.com/migrate-entry so
+  that the controller takes over the existing config entries. The manifests
+  are written to standard output or -output-dir and can be created in
+  Kubernetes directly with -apply.
+
+  Config entries that use fields the custom resources don't support are
+  reported and skipped.
+
+  The command ships with consul-k8s-control-plane rather than the consul-k8s
+  CLI because it converts config entries with the custom resource types,
+  which depend on the controller-runtime and Kubernetes client versions of
+  this module. Run it with kubectl exec in the controller pod or with the
+  control plane image.
+
+`
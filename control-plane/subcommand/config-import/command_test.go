@@ -0,0 +1,293 @@
+package configimport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-k8s/control-plane/api/common"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRun_FlagValidation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		flags  []string
+		expErr string
+	}{
+		{
+			flags:  nil,
+			expErr: "-addresses must be set",
+		},
+		{
+			flags:  []string{"-addresses", "foo", "-kind", "service-default"},
+			expErr: `-kind "service-default" is not a config entry kind that can be imported`,
+		},
+		{
+			flags:  []string{"-addresses", "foo", "-k8s-namespace", ""},
+			expErr: "-k8s-namespace must be set",
+		},
+		{
+			flags:  []string{"-addresses", "foo", "-enable-k8s-namespace-mirroring"},
+			expErr: "-enable-k8s-namespace-mirroring requires -enable-namespaces",
+		},
+		{
+			flags:  []string{"-addresses", "foo", "-output-dir", "/does/not/exist"},
+			expErr: `-output-dir "/does/not/exist"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expErr, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := Command{UI: ui}
+			exitCode := cmd.Run(c.flags)
+			require.Equal(t, 1, exitCode, ui.ErrorWriter.String())
+			require.Contains(t, ui.ErrorWriter.String(), c.expErr)
+		})
+	}
+}
+
+func TestRun_WritesManifests(t *testing.T) {
+	t.Parallel()
+	consulClient, args := testServer(t)
+	writeConfigEntries(t, consulClient)
+
+	ui := cli.NewMockUi()
+	cmd := Command{UI: ui}
+	exitCode := cmd.Run(args)
+	require.Equal(t, 0, exitCode, ui.ErrorWriter.String())
+
+	var kinds []string
+	for _, manifest := range strings.Split(ui.OutputWriter.String(), "---\n") {
+		var obj struct {
+			metav1.TypeMeta   `json:",inline"`
+			metav1.ObjectMeta `json:"metadata"`
+		}
+		require.NoError(t, yaml.Unmarshal([]byte(manifest), &obj))
+		require.Equal(t, v1alpha1.GroupVersion.String(), obj.APIVersion)
+		require.Equal(t, "default", obj.Namespace)
+		require.Equal(t, common.MigrateEntryTrue, obj.Annotations[common.MigrateEntryKey])
+		kinds = append(kinds, obj.Kind+"/"+obj.Name)
+	}
+	require.Equal(t, []string{
+		"ProxyDefaults/global",
+		"ServiceDefaults/foo",
+		"ServiceIntentions/wildcard",
+		"ServiceRouter/foo",
+	}, kinds)
+}
+
+func TestRun_Kind(t *testing.T) {
+	t.Parallel()
+	consulClient, args := testServer(t)
+	writeConfigEntries(t, consulClient)
+
+	ui := cli.NewMockUi()
+	cmd := Command{UI: ui}
+	exitCode := cmd.Run(append(args, "-kind", api.ServiceDefaults))
+	require.Equal(t, 0, exitCode, ui.ErrorWriter.String())
+
+	var svcDefaults v1alpha1.ServiceDefaults
+	require.NoError(t, yaml.Unmarshal(ui.OutputWriter.Bytes(), &svcDefaults))
+	require.Equal(t, "foo", svcDefaults.Name)
+	require.Equal(t, "http", svcDefaults.Spec.Protocol)
+	require.Equal(t, v1alpha1.MeshGateway{Mode: "local"}, svcDefaults.Spec.MeshGateway)
+}
+
+func TestRun_Apply(t *testing.T) {
+	t.Parallel()
+	consulClient, args := testServer(t)
+	writeConfigEntries(t, consulClient)
+
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	// The service router already exists so it must be left unchanged.
+	router := &v1alpha1.ServiceRouter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "default",
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(s).WithObjects(router).Build()
+
+	outputDir := t.TempDir()
+	ui := cli.NewMockUi()
+	cmd := Command{UI: ui, k8sClient: k8sClient}
+	exitCode := cmd.Run(append(args, "-apply", "-output-dir", outputDir))
+	require.Equal(t, 0, exitCode, ui.ErrorWriter.String())
+	require.Empty(t, ui.OutputWriter.String())
+
+	files, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	require.ElementsMatch(t, []string{
+		"default-proxydefaults-global.yaml",
+		"default-servicedefaults-foo.yaml",
+		"default-serviceintentions-wildcard.yaml",
+		"default-servicerouter-foo.yaml",
+	}, names)
+
+	manifest, err := os.ReadFile(filepath.Join(outputDir, "default-serviceintentions-wildcard.yaml"))
+	require.NoError(t, err)
+	var fromFile v1alpha1.ServiceIntentions
+	require.NoError(t, yaml.Unmarshal(manifest, &fromFile))
+
+	var intentions v1alpha1.ServiceIntentions
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "wildcard"}, &intentions))
+	require.Equal(t, "*", intentions.Spec.Destination.Name)
+	require.Equal(t, fromFile.Spec, intentions.Spec)
+	require.Len(t, intentions.Spec.Sources, 1)
+	require.Equal(t, "bar", intentions.Spec.Sources[0].Name)
+	require.Equal(t, v1alpha1.IntentionAction("allow"), intentions.Spec.Sources[0].Action)
+
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "foo"}, router))
+	require.Empty(t, router.Spec.Routes)
+}
+
+// Test that the gateway and route config entries, which the test server is
+// too old to store, are converted to the resources that generate them and
+// that the status computed by Consul is dropped.
+func TestFromConsul_GatewayKinds(t *testing.T) {
+	t.Parallel()
+
+	status := api.ConfigEntryStatus{
+		Conditions: []api.Condition{{Type: "Accepted", Status: "True"}},
+	}
+	cases := map[string]api.ConfigEntry{
+		"APIGateway": &api.APIGatewayConfigEntry{
+			Kind: api.APIGateway,
+			Name: "gateway",
+			Listeners: []api.APIGatewayListener{{
+				Name:     "https",
+				Port:     8443,
+				Protocol: "http",
+				TLS: api.APIGatewayTLSConfiguration{
+					Certificates: []api.ResourceReference{{Kind: api.InlineCertificate, Name: "cert"}},
+				},
+			}},
+			Status: status,
+		},
+		"HTTPRoute": &api.HTTPRouteConfigEntry{
+			Kind:    api.HTTPRoute,
+			Name:    "web",
+			Parents: []api.ResourceReference{{Kind: api.APIGateway, Name: "gateway", SectionName: "https"}},
+			Rules: []api.HTTPRouteRule{{
+				Matches:  []api.HTTPMatch{{Path: api.HTTPPathMatch{Match: api.HTTPPathMatchPrefix, Value: "/web"}}},
+				Services: []api.HTTPService{{Name: "web", Weight: 1}},
+			}},
+			Hostnames: []string{"web.example.com"},
+			Status:    status,
+		},
+		"TCPRoute": &api.TCPRouteConfigEntry{
+			Kind:     api.TCPRoute,
+			Name:     "db",
+			Parents:  []api.ResourceReference{{Kind: api.APIGateway, Name: "gateway"}},
+			Services: []api.TCPService{{Name: "db"}},
+			Status:   status,
+		},
+		"InlineCertificate": &api.InlineCertificateConfigEntry{
+			Kind:        api.InlineCertificate,
+			Name:        "cert",
+			Certificate: "certificate",
+			PrivateKey:  "private-key",
+		},
+	}
+	for kind, entry := range cases {
+		entry := entry
+		t.Run(kind, func(t *testing.T) {
+			s := runtime.NewScheme()
+			require.NoError(t, v1alpha1.AddToScheme(s))
+			cmd := Command{UI: cli.NewMockUi(), flagK8sNamespace: "default", scheme: s}
+
+			resource, err := cmd.fromConsul(entry)
+			require.NoError(t, err)
+			require.Equal(t, kind, resource.GetObjectKind().GroupVersionKind().Kind)
+			require.Equal(t, entry.GetName(), resource.GetName())
+			require.True(t, resource.MatchesConsul(entry))
+		})
+	}
+}
+
+// testServer starts a Consul server and returns a client for it and the
+// flags the command needs to connect to it.
+func testServer(t *testing.T) (*api.Client, []string) {
+	server, err := testutil.NewTestServerConfigT(t, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = server.Stop()
+	})
+	server.WaitForLeader(t)
+
+	consulClient, err := api.NewClient(&api.Config{Address: server.HTTPAddr})
+	require.NoError(t, err)
+
+	return consulClient, []string{
+		"-addresses", "127.0.0.1",
+		"-http-port", strings.Split(server.HTTPAddr, ":")[1],
+		"-grpc-port", strings.Split(server.GRPCAddr, ":")[1],
+	}
+}
+
+// writeConfigEntries writes config entries to Consul the way a user would
+// with `consul config write`.
+func writeConfigEntries(t *testing.T, consulClient *api.Client) {
+	entries := []api.ConfigEntry{
+		&api.ProxyConfigEntry{
+			Kind: api.ProxyDefaults,
+			Name: api.ProxyConfigGlobal,
+			Config: map[string]interface{}{
+				"protocol": "http",
+			},
+		},
+		&api.ServiceConfigEntry{
+			Kind:     api.ServiceDefaults,
+			Name:     "foo",
+			Protocol: "http",
+			MeshGateway: api.MeshGatewayConfig{
+				Mode: api.MeshGatewayModeLocal,
+			},
+		},
+		&api.ServiceRouterConfigEntry{
+			Kind: api.ServiceRouter,
+			Name: "foo",
+			Routes: []api.ServiceRoute{
+				{
+					Match: &api.ServiceRouteMatch{
+						HTTP: &api.ServiceRouteHTTPMatch{PathPrefix: "/admin"},
+					},
+					Destination: &api.ServiceRouteDestination{Service: "admin"},
+				},
+			},
+		},
+		&api.ServiceIntentionsConfigEntry{
+			Kind: api.ServiceIntentions,
+			Name: "*",
+			Sources: []*api.SourceIntention{
+				{
+					Name:   "bar",
+					Action: api.IntentionActionAllow,
+				},
+			},
+		},
+	}
+	for _, entry := range entries {
+		_, _, err := consulClient.ConfigEntries().Set(entry, nil)
+		require.NoError(t, err)
+	}
+}
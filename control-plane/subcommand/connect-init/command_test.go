@@ -272,6 +272,22 @@ func TestRun_Gateways(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:        "api-gateway",
+			gatewayKind: "api-gateway",
+			agentService: api.AgentService{
+				ID:      "api-gateway",
+				Service: "api-gateway",
+				Kind:    api.ServiceKindAPIGateway,
+				Port:    21000,
+				Address: "127.0.0.1",
+				Meta: map[string]string{
+					"component":    "api-gateway",
+					metaKeyPodName: testGatewayName,
+					metaKeyKubeNS:  "default-ns",
+				},
+			},
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
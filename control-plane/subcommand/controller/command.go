@@ -9,9 +9,11 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/hashicorp/consul-k8s/control-plane/api/common"
 	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
+	"github.com/hashicorp/consul-k8s/control-plane/api/v1beta1"
 	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/controllers/endpoints"
 	connectInjectWebhook "github.com/hashicorp/consul-k8s/control-plane/connect-inject/webhook"
 	"github.com/hashicorp/consul-k8s/control-plane/controller"
@@ -21,7 +23,9 @@ import (
 	"github.com/hashicorp/consul-server-connection-manager/discovery"
 	"github.com/mitchellh/cli"
 	"go.uber.org/zap/zapcore"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -29,6 +33,7 @@ import (
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 )
 
 const WebhookCAFilename = "ca.crt"
@@ -47,6 +52,29 @@ type Command struct {
 	flagResourcePrefix        string
 	flagEnableWebhookCAUpdate bool
 
+	// Flags to configure drift detection.
+	flagResyncPeriod time.Duration
+	flagDriftPolicy  string
+
+	// Identifies this cluster as the owner of the config entries it manages.
+	flagClusterID string
+
+	// Whether webhooks check config entries against the resources they reference.
+	flagCrossResourceValidation string
+
+	// Whether to run the ConsulKV controller, which reads Secrets.
+	flagEnableConsulKV bool
+	// Whether to run the Registration controller, which can register any node.
+	flagEnableRegistrations bool
+	// Whether to run the ExternalService controller, which registers nodes.
+	flagEnableExternalServices bool
+
+	// Flags to deploy the gateways of IngressGateway resources.
+	flagEnableIngressGatewayDeployments bool
+	flagConsulDataplaneImage            string
+	flagConsulK8sImage                  string
+	flagIngressGatewayAuthMethod        string
+
 	// Flags to support Consul Enterprise namespaces.
 	flagEnableNamespaces           bool
 	flagConsulDestinationNamespace string
@@ -66,6 +94,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -99,6 +128,34 @@ func (c *Command) init() {
 			"%q, %q, %q, and %q.", zapcore.DebugLevel.String(), zapcore.InfoLevel.String(), zapcore.WarnLevel.String(), zapcore.ErrorLevel.String()))
 	c.flagSet.BoolVar(&c.flagLogJSON, "log-json", false,
 		"Enable or disable JSON output format for logging.")
+	c.flagSet.DurationVar(&c.flagResyncPeriod, "resync-period", 0,
+		"How often config entries, ACL resources, prepared queries and conflicting ConsulKV keys are re-read from Consul to detect changes made outside of Kubernetes. "+
+			"Drift detection is disabled if set to 0.")
+	c.flagSet.StringVar(&c.flagDriftPolicy, "drift-policy", controller.DriftPolicyCorrect,
+		fmt.Sprintf("What to do with config entries that have drifted from their custom resource. "+
+			"Either %q to overwrite them in Consul or %q to only report them.", controller.DriftPolicyCorrect, controller.DriftPolicyReport))
+	c.flagSet.StringVar(&c.flagClusterID, "cluster-id", "",
+		"Identifies this Kubernetes cluster when multiple clusters manage config entries in the same Consul datacenter. "+
+			"If set, config entries record the cluster and resource that manage them and are not overwritten by other clusters.")
+	c.flagSet.StringVar(&c.flagCrossResourceValidation, "cross-resource-validation", common.CrossResourceValidationDisabled,
+		fmt.Sprintf("Whether webhooks check config entries against the resources they reference, e.g. that routers target services "+
+			"with an HTTP protocol. One of %q, %q to admit invalid resources with warnings, or %q to reject them.",
+			common.CrossResourceValidationDisabled, common.CrossResourceValidationWarn, common.CrossResourceValidationDeny))
+
+	c.flagSet.BoolVar(&c.flagEnableConsulKV, "enable-consul-kv", false,
+		"Sync ConsulKV resources to the Consul KV store. Requires read access to the ConfigMaps and Secrets they reference.")
+	c.flagSet.BoolVar(&c.flagEnableRegistrations, "enable-registrations", false,
+		"Register the services of Registration resources in the Consul catalog. Requires node write access.")
+	c.flagSet.BoolVar(&c.flagEnableExternalServices, "enable-external-services", false,
+		"Register the services of ExternalService resources in the Consul catalog and link them to terminating gateways. Requires node write access.")
+	c.flagSet.BoolVar(&c.flagEnableIngressGatewayDeployments, "enable-ingress-gateway-deployments", false,
+		"Deploy the gateway pods and Service of IngressGateway resources that have a deployment section. Requires Kubernetes 1.21 or later.")
+	c.flagSet.StringVar(&c.flagConsulDataplaneImage, "consul-dataplane-image", "",
+		"Docker image for Consul Dataplane. Required if -enable-ingress-gateway-deployments is set.")
+	c.flagSet.StringVar(&c.flagConsulK8sImage, "consul-k8s-image", "",
+		"Docker image for consul-k8s. Required if -enable-ingress-gateway-deployments is set.")
+	c.flagSet.StringVar(&c.flagIngressGatewayAuthMethod, "ingress-gateway-auth-method", "",
+		"Name of the Kubernetes auth method the pods of deployed ingress gateways log in with when ACLs are enabled.")
 
 	c.consulFlags = &flags.ConsulFlags{}
 	flags.Merge(c.flagSet, c.consulFlags.Flags())
@@ -176,6 +233,8 @@ func (c *Command) Run(args []string) int {
 		DestinationNamespace: c.flagConsulDestinationNamespace,
 		Mirroring:            c.flagEnableNSMirroring,
 		Prefix:               c.flagNSMirroringPrefix,
+
+		CrossResourceValidation: c.flagCrossResourceValidation,
 	}
 
 	configEntryReconciler := &controller.ConfigEntryController{
@@ -187,6 +246,9 @@ func (c *Command) Run(args []string) int {
 		EnableNSMirroring:          c.flagEnableNSMirroring,
 		NSMirroringPrefix:          c.flagNSMirroringPrefix,
 		CrossNSACLPolicy:           c.flagCrossNSACLPolicy,
+		ResyncPeriod:               c.flagResyncPeriod,
+		DriftPolicy:                c.flagDriftPolicy,
+		ClusterID:                  c.flagClusterID,
 	}
 	if err = (&controller.ServiceDefaultsController{
 		ConfigEntryController: configEntryReconciler,
@@ -260,11 +322,56 @@ func (c *Command) Run(args []string) int {
 		setupLog.Error(err, "unable to create controller", "controller", common.ServiceIntentions)
 		return 1
 	}
+	if err = (&controller.ServiceIntentionController{
+		ConfigEntryController: configEntryReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.ServiceIntention),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.ServiceIntention)
+		return 1
+	}
+	var ingressGatewayDeployment *controller.IngressGatewayDeploymentConfig
+	if c.flagEnableIngressGatewayDeployments {
+		// The gateways' PodDisruptionBudgets are created with policy/v1,
+		// which Kubernetes serves from 1.21.
+		pdbKind := schema.GroupKind{Group: policyv1.GroupName, Kind: "PodDisruptionBudget"}
+		if _, err := mgr.GetRESTMapper().RESTMapping(pdbKind, policyv1.SchemeGroupVersion.Version); err != nil {
+			setupLog.Error(err, "-enable-ingress-gateway-deployments requires Kubernetes 1.21 or later")
+			return 1
+		}
+		caCertPem := c.consulFlags.CACertPEM
+		if c.consulFlags.CACertFile != "" {
+			pem, err := os.ReadFile(c.consulFlags.CACertFile)
+			if err != nil {
+				setupLog.Error(err, "unable to read Consul's CA cert file", "file", c.consulFlags.CACertFile)
+				return 1
+			}
+			caCertPem = string(pem)
+		}
+		ingressGatewayDeployment = &controller.IngressGatewayDeploymentConfig{
+			ImageConsulDataplane: c.flagConsulDataplaneImage,
+			ImageConsulK8S:       c.flagConsulK8sImage,
+			ConsulAddress:        c.consulFlags.Addresses,
+			ConsulGRPCPort:       c.consulFlags.GRPCPort,
+			ConsulHTTPPort:       c.consulFlags.HTTPPort,
+			ConsulAPITimeout:     c.consulFlags.APITimeout.String(),
+			ConsulCACert:         caCertPem,
+			ConsulTLSServerName:  c.consulFlags.TLSServerName,
+			TLSEnabled:           c.consulFlags.UseTLS,
+			SkipServerWatch:      c.consulFlags.SkipServerWatch,
+			ConsulPartition:      c.consulFlags.Partition,
+			AuthMethod:           c.flagIngressGatewayAuthMethod,
+			LogLevel:             c.flagLogLevel,
+			LogJSON:              c.flagLogJSON,
+		}
+	}
 	if err = (&controller.IngressGatewayController{
 		ConfigEntryController: configEntryReconciler,
 		Client:                mgr.GetClient(),
 		Log:                   ctrl.Log.WithName("controller").WithName(common.IngressGateway),
 		Scheme:                mgr.GetScheme(),
+		Deployment:            ingressGatewayDeployment,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", common.IngressGateway)
 		return 1
@@ -278,6 +385,208 @@ func (c *Command) Run(args []string) int {
 		setupLog.Error(err, "unable to create controller", "controller", common.TerminatingGateway)
 		return 1
 	}
+	if err = (&controller.APIGatewayController{
+		ConfigEntryController: configEntryReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.APIGateway),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.APIGateway)
+		return 1
+	}
+	if err = (&controller.HTTPRouteController{
+		ConfigEntryController: configEntryReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.HTTPRoute),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.HTTPRoute)
+		return 1
+	}
+	if err = (&controller.TCPRouteController{
+		ConfigEntryController: configEntryReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.TCPRoute),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.TCPRoute)
+		return 1
+	}
+	if err = (&controller.InlineCertificateController{
+		ConfigEntryController: configEntryReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.InlineCertificate),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.InlineCertificate)
+		return 1
+	}
+	if err = (&controller.JWTProviderController{
+		ConfigEntryController: configEntryReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.JWTProvider),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.JWTProvider)
+		return 1
+	}
+	if err = (&controller.SamenessGroupController{
+		ConfigEntryController: configEntryReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.SamenessGroup),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.SamenessGroup)
+		return 1
+	}
+	aclResourceReconciler := &controller.ACLResourceController{
+		ConsulClientConfig:         c.consulFlags.ConsulClientConfig(),
+		ConsulServerConnMgr:        watcher,
+		EnableConsulNamespaces:     c.flagEnableNamespaces,
+		ConsulDestinationNamespace: c.flagConsulDestinationNamespace,
+		EnableNSMirroring:          c.flagEnableNSMirroring,
+		NSMirroringPrefix:          c.flagNSMirroringPrefix,
+		CrossNSACLPolicy:           c.flagCrossNSACLPolicy,
+		ResyncPeriod:               c.flagResyncPeriod,
+	}
+	if err = (&controller.ACLPolicyController{
+		ACLResourceController: aclResourceReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.ACLPolicy),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.ACLPolicy)
+		return 1
+	}
+	if err = (&controller.ACLRoleController{
+		ACLResourceController: aclResourceReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.ACLRole),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.ACLRole)
+		return 1
+	}
+	if err = (&controller.ACLBindingRuleController{
+		ACLResourceController: aclResourceReconciler,
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controller").WithName(common.ACLBindingRule),
+		Scheme:                mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.ACLBindingRule)
+		return 1
+	}
+	if c.flagEnableConsulKV {
+		if err = (&controller.ConsulKVController{
+			Client:                     mgr.GetClient(),
+			APIReader:                  mgr.GetAPIReader(),
+			Log:                        ctrl.Log.WithName("controller").WithName(common.ConsulKV),
+			Scheme:                     mgr.GetScheme(),
+			ConsulClientConfig:         c.consulFlags.ConsulClientConfig(),
+			ConsulServerConnMgr:        watcher,
+			EnableConsulNamespaces:     c.flagEnableNamespaces,
+			ConsulDestinationNamespace: c.flagConsulDestinationNamespace,
+			EnableNSMirroring:          c.flagEnableNSMirroring,
+			NSMirroringPrefix:          c.flagNSMirroringPrefix,
+			CrossNSACLPolicy:           c.flagCrossNSACLPolicy,
+			DatacenterName:             c.consulFlags.Datacenter,
+			ClusterID:                  c.flagClusterID,
+			ResyncPeriod:               c.flagResyncPeriod,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", common.ConsulKV)
+			return 1
+		}
+	}
+	if err = (&controller.PreparedQueryController{
+		Client:                     mgr.GetClient(),
+		Log:                        ctrl.Log.WithName("controller").WithName(common.PreparedQuery),
+		Scheme:                     mgr.GetScheme(),
+		ConsulClientConfig:         c.consulFlags.ConsulClientConfig(),
+		ConsulServerConnMgr:        watcher,
+		EnableConsulNamespaces:     c.flagEnableNamespaces,
+		ConsulDestinationNamespace: c.flagConsulDestinationNamespace,
+		EnableNSMirroring:          c.flagEnableNSMirroring,
+		NSMirroringPrefix:          c.flagNSMirroringPrefix,
+		ResyncPeriod:               c.flagResyncPeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.PreparedQuery)
+		return 1
+	}
+	if c.flagEnableExternalServices {
+		if err = (&controller.ExternalServiceController{
+			Client:                     mgr.GetClient(),
+			Log:                        ctrl.Log.WithName("controller").WithName(common.ExternalService),
+			Scheme:                     mgr.GetScheme(),
+			ConsulClientConfig:         c.consulFlags.ConsulClientConfig(),
+			ConsulServerConnMgr:        watcher,
+			EnableConsulNamespaces:     c.flagEnableNamespaces,
+			ConsulDestinationNamespace: c.flagConsulDestinationNamespace,
+			EnableNSMirroring:          c.flagEnableNSMirroring,
+			NSMirroringPrefix:          c.flagNSMirroringPrefix,
+			CrossNSACLPolicy:           c.flagCrossNSACLPolicy,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", common.ExternalService)
+			return 1
+		}
+	}
+	if c.flagEnableRegistrations {
+		if err = (&controller.RegistrationController{
+			Client:                     mgr.GetClient(),
+			Log:                        ctrl.Log.WithName("controller").WithName(common.Registration),
+			Scheme:                     mgr.GetScheme(),
+			ConsulClientConfig:         c.consulFlags.ConsulClientConfig(),
+			ConsulServerConnMgr:        watcher,
+			EnableConsulNamespaces:     c.flagEnableNamespaces,
+			ConsulDestinationNamespace: c.flagConsulDestinationNamespace,
+			EnableNSMirroring:          c.flagEnableNSMirroring,
+			NSMirroringPrefix:          c.flagNSMirroringPrefix,
+			CrossNSACLPolicy:           c.flagCrossNSACLPolicy,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", common.Registration)
+			return 1
+		}
+	}
+	if err = (&controller.TrafficShiftController{
+		Client:                     mgr.GetClient(),
+		Log:                        ctrl.Log.WithName("controller").WithName(common.TrafficShift),
+		Scheme:                     mgr.GetScheme(),
+		ConsulClientConfig:         c.consulFlags.ConsulClientConfig(),
+		ConsulServerConnMgr:        watcher,
+		EnableConsulNamespaces:     c.flagEnableNamespaces,
+		ConsulDestinationNamespace: c.flagConsulDestinationNamespace,
+		EnableNSMirroring:          c.flagEnableNSMirroring,
+		NSMirroringPrefix:          c.flagNSMirroringPrefix,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", common.TrafficShift)
+		return 1
+	}
+	if c.flagEnableNamespaces {
+		if err = (&controller.ConsulNamespaceController{
+			Client:              mgr.GetClient(),
+			Log:                 ctrl.Log.WithName("controller").WithName(common.ConsulNamespace),
+			Scheme:              mgr.GetScheme(),
+			ConsulClientConfig:  c.consulFlags.ConsulClientConfig(),
+			ConsulServerConnMgr: watcher,
+			ConsulPartition:     c.consulFlags.Partition,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", common.ConsulNamespace)
+			return 1
+		}
+	}
+	// Admin partitions can only be managed with operator = "write", which
+	// tokens only get in the default partition.
+	if partitionsEnabled && c.consulFlags.Partition == common.DefaultConsulPartition {
+		if err = (&controller.ConsulPartitionController{
+			Client:              mgr.GetClient(),
+			Log:                 ctrl.Log.WithName("controller").WithName(common.ConsulPartition),
+			Scheme:              mgr.GetScheme(),
+			ConsulClientConfig:  c.consulFlags.ConsulClientConfig(),
+			ConsulServerConnMgr: watcher,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", common.ConsulPartition)
+			return 1
+		}
+	}
 
 	if c.flagEnableWebhooks {
 		// This webhook server sets up a Cert Watcher on the CertDir. This watches for file changes and updates the webhook certificates
@@ -334,6 +643,12 @@ func (c *Command) Run(args []string) int {
 				Logger:     ctrl.Log.WithName("webhooks").WithName(common.ServiceIntentions),
 				ConsulMeta: consulMeta,
 			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-serviceintention",
+			&webhook.Admission{Handler: &v1alpha1.ServiceIntentionWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.ServiceIntention),
+				ConsulMeta: consulMeta,
+			}})
 		mgr.GetWebhookServer().Register("/mutate-v1alpha1-ingressgateway",
 			&webhook.Admission{Handler: &v1alpha1.IngressGatewayWebhook{
 				Client:     mgr.GetClient(),
@@ -346,6 +661,120 @@ func (c *Command) Run(args []string) int {
 				Logger:     ctrl.Log.WithName("webhooks").WithName(common.TerminatingGateway),
 				ConsulMeta: consulMeta,
 			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-apigateway",
+			&webhook.Admission{Handler: &v1alpha1.APIGatewayWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.APIGateway),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-httproute",
+			&webhook.Admission{Handler: &v1alpha1.HTTPRouteWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.HTTPRoute),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-tcproute",
+			&webhook.Admission{Handler: &v1alpha1.TCPRouteWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.TCPRoute),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-inlinecertificate",
+			&webhook.Admission{Handler: &v1alpha1.InlineCertificateWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.InlineCertificate),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-jwtprovider",
+			&webhook.Admission{Handler: &v1alpha1.JWTProviderWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.JWTProvider),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-samenessgroup",
+			&webhook.Admission{Handler: &v1alpha1.SamenessGroupWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.SamenessGroup),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-consulnamespace",
+			&webhook.Admission{Handler: &v1alpha1.ConsulNamespaceWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.ConsulNamespace),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-consulpartition",
+			&webhook.Admission{Handler: &v1alpha1.ConsulPartitionWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.ConsulPartition),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-aclpolicy",
+			&webhook.Admission{Handler: &v1alpha1.ACLPolicyWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.ACLPolicy),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-aclrole",
+			&webhook.Admission{Handler: &v1alpha1.ACLRoleWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.ACLRole),
+				ConsulMeta: consulMeta,
+			}})
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-aclbindingrule",
+			&webhook.Admission{Handler: &v1alpha1.ACLBindingRuleWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.ACLBindingRule),
+				ConsulMeta: consulMeta,
+			}})
+		if c.flagEnableConsulKV {
+			mgr.GetWebhookServer().Register("/mutate-v1alpha1-consulkv",
+				&webhook.Admission{Handler: &v1alpha1.ConsulKVWebhook{
+					Client:     mgr.GetClient(),
+					Logger:     ctrl.Log.WithName("webhooks").WithName(common.ConsulKV),
+					ConsulMeta: consulMeta,
+				}})
+		}
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-preparedquery",
+			&webhook.Admission{Handler: &v1alpha1.PreparedQueryWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.PreparedQuery),
+				ConsulMeta: consulMeta,
+			}})
+		if c.flagEnableExternalServices {
+			mgr.GetWebhookServer().Register("/mutate-v1alpha1-externalservice",
+				&webhook.Admission{Handler: &v1alpha1.ExternalServiceWebhook{
+					Client:     mgr.GetClient(),
+					Logger:     ctrl.Log.WithName("webhooks").WithName(common.ExternalService),
+					ConsulMeta: consulMeta,
+				}})
+		}
+		if c.flagEnableRegistrations {
+			mgr.GetWebhookServer().Register("/mutate-v1alpha1-registration",
+				&webhook.Admission{Handler: &v1alpha1.RegistrationWebhook{
+					Client:     mgr.GetClient(),
+					Logger:     ctrl.Log.WithName("webhooks").WithName(common.Registration),
+					ConsulMeta: consulMeta,
+				}})
+		}
+		mgr.GetWebhookServer().Register("/mutate-v1alpha1-trafficshift",
+			&webhook.Admission{Handler: &v1alpha1.TrafficShiftWebhook{
+				Client:     mgr.GetClient(),
+				Logger:     ctrl.Log.WithName("webhooks").WithName(common.TrafficShift),
+				ConsulMeta: consulMeta,
+			}})
+
+		// The conversion webhook converts resources between the versions of
+		// the CRDs that serve more than one version, e.g. v1alpha1 and v1beta1.
+		mgr.GetWebhookServer().Register("/convert", &conversion.Webhook{})
+		if err = (&controller.ConversionWebhookController{
+			Client:            mgr.GetClient(),
+			Log:               ctrl.Log.WithName("controller").WithName("conversion-webhook"),
+			WebhookConfigName: fmt.Sprintf("%s-controller", c.flagResourcePrefix),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "conversion-webhook")
+			return 1
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
@@ -409,6 +838,26 @@ func (c *Command) validateFlags() error {
 	if c.consulFlags.APITimeout <= 0 {
 		return errors.New("-consul-api-timeout must be set to a value greater than 0")
 	}
+	if c.flagResyncPeriod < 0 {
+		return errors.New("-resync-period must not be negative")
+	}
+	if c.flagDriftPolicy != controller.DriftPolicyCorrect && c.flagDriftPolicy != controller.DriftPolicyReport {
+		return fmt.Errorf("-drift-policy must be one of %q or %q", controller.DriftPolicyCorrect, controller.DriftPolicyReport)
+	}
+	switch c.flagCrossResourceValidation {
+	case common.CrossResourceValidationDisabled, common.CrossResourceValidationWarn, common.CrossResourceValidationDeny:
+	default:
+		return fmt.Errorf("-cross-resource-validation must be one of %q, %q or %q",
+			common.CrossResourceValidationDisabled, common.CrossResourceValidationWarn, common.CrossResourceValidationDeny)
+	}
+	if c.flagEnableIngressGatewayDeployments {
+		if c.flagConsulDataplaneImage == "" {
+			return errors.New("-consul-dataplane-image must be set if -enable-ingress-gateway-deployments is set")
+		}
+		if c.flagConsulK8sImage == "" {
+			return errors.New("-consul-k8s-image must be set if -enable-ingress-gateway-deployments is set")
+		}
+	}
 
 	return nil
 }
@@ -35,6 +35,27 @@ func TestRun_FlagValidation(t *testing.T) {
 				"-log-level", "invalid"},
 			expErr: `unknown log level "invalid": unrecognized level: "invalid"`,
 		},
+		{
+			flags:  []string{"-webhook-tls-cert-dir", "/foo", "-datacenter", "foo", "-resync-period=-1s"},
+			expErr: "-resync-period must not be negative",
+		},
+		{
+			flags:  []string{"-webhook-tls-cert-dir", "/foo", "-datacenter", "foo", "-drift-policy", "ignore"},
+			expErr: `-drift-policy must be one of "correct" or "report"`,
+		},
+		{
+			flags:  []string{"-webhook-tls-cert-dir", "/foo", "-datacenter", "foo", "-cross-resource-validation", "strict"},
+			expErr: `-cross-resource-validation must be one of "disabled", "warn" or "deny"`,
+		},
+		{
+			flags:  []string{"-webhook-tls-cert-dir", "/foo", "-datacenter", "foo", "-enable-ingress-gateway-deployments"},
+			expErr: "-consul-dataplane-image must be set if -enable-ingress-gateway-deployments is set",
+		},
+		{
+			flags: []string{"-webhook-tls-cert-dir", "/foo", "-datacenter", "foo", "-enable-ingress-gateway-deployments",
+				"-consul-dataplane-image", "hashicorp/consul-dataplane"},
+			expErr: "-consul-k8s-image must be set if -enable-ingress-gateway-deployments is set",
+		},
 	}
 
 	for _, c := range cases {
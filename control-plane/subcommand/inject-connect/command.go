@@ -14,6 +14,7 @@ import (
 
 	"github.com/hashicorp/consul-k8s/control-plane/api/v1alpha1"
 	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/controllers/endpoints"
+	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/controllers/gatewayapi"
 	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/controllers/peering"
 	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/metrics"
 	"github.com/hashicorp/consul-k8s/control-plane/connect-inject/webhook"
@@ -26,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -105,6 +107,11 @@ type Command struct {
 	// Peering flags.
 	flagEnablePeering bool
 
+	// Gateway API flags.
+	flagEnableGatewayAPI      bool
+	flagGatewayAPIServiceType string
+	flagGatewayAPIReplicas    int
+
 	// WAN Federation flags.
 	flagEnableFederation bool
 
@@ -149,6 +156,12 @@ func (c *Command) init() {
 		"Docker image for consul-k8s. Used for the connect sidecar.")
 	c.flagSet.BoolVar(&c.flagEnablePeering, "enable-peering", false, "Enable cluster peering controllers.")
 	c.flagSet.BoolVar(&c.flagEnableFederation, "enable-federation", false, "Enable Consul WAN Federation.")
+	c.flagSet.BoolVar(&c.flagEnableGatewayAPI, "enable-gateway-api", false,
+		"Enable the controllers that deploy Kubernetes Gateway API Gateways as Consul API gateways.")
+	c.flagSet.StringVar(&c.flagGatewayAPIServiceType, "gateway-api-service-type", string(corev1.ServiceTypeLoadBalancer),
+		"Type of the Service created for each Gateway API Gateway.")
+	c.flagSet.IntVar(&c.flagGatewayAPIReplicas, "gateway-api-replicas", 1,
+		"Number of gateway pods deployed for each Gateway API Gateway.")
 	c.flagSet.StringVar(&c.flagEnvoyExtraArgs, "envoy-extra-args", "",
 		"Extra envoy command line args to be set when starting envoy (e.g \"--log-level debug --disable-hot-restart\").")
 	c.flagSet.StringVar(&c.flagACLAuthMethod, "acl-auth-method", "",
@@ -485,6 +498,74 @@ func (c *Command) Run(args []string) int {
 			}})
 	}
 
+	if c.flagEnableGatewayAPI {
+		gatewayConfig := gatewayapi.Config{
+			ConsulClientConfig:         consulConfig,
+			ConsulServerConnMgr:        watcher,
+			ConsulPartition:            c.consul.Partition,
+			EnableConsulNamespaces:     c.flagEnableNamespaces,
+			ConsulDestinationNamespace: c.flagConsulDestinationNamespace,
+			EnableNSMirroring:          c.flagEnableK8SNSMirroring,
+			NSMirroringPrefix:          c.flagK8SNSMirroringPrefix,
+			CrossNSACLPolicy:           c.flagCrossNamespaceACLPolicy,
+			Deployment: gatewayapi.DeploymentConfig{
+				ImageConsulDataplane: c.flagConsulDataplaneImage,
+				ImageConsulK8S:       c.flagConsulK8sImage,
+				ConsulAddress:        c.consul.Addresses,
+				ConsulGRPCPort:       c.consul.GRPCPort,
+				ConsulHTTPPort:       c.consul.HTTPPort,
+				ConsulAPITimeout:     c.consul.APITimeout.String(),
+				ConsulCACert:         string(caCertPem),
+				ConsulTLSServerName:  c.consul.TLSServerName,
+				TLSEnabled:           c.consul.UseTLS,
+				SkipServerWatch:      c.consul.SkipServerWatch,
+				AuthMethod:           c.flagACLAuthMethod,
+				ServiceType:          corev1.ServiceType(c.flagGatewayAPIServiceType),
+				Replicas:             int32(c.flagGatewayAPIReplicas),
+				LogLevel:             c.flagLogLevel,
+				LogJSON:              c.flagLogJSON,
+			},
+		}
+
+		// TCPRoute is only part of the experimental channel of the Gateway
+		// API, so it is only watched when its CRD is installed.
+		routeGVKs := []schema.GroupVersionKind{gatewayapi.HTTPRouteGVK}
+		if _, err := mgr.GetRESTMapper().RESTMapping(gatewayapi.TCPRouteGVK.GroupKind(), gatewayapi.TCPRouteGVK.Version); err == nil {
+			routeGVKs = append(routeGVKs, gatewayapi.TCPRouteGVK)
+		} else {
+			setupLog.Info("TCPRoute CRD is not installed, TCPRoutes will not be reconciled")
+		}
+
+		if err = (&gatewayapi.GatewayClassController{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("controller").WithName("gatewayclass"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "gatewayclass")
+			return 1
+		}
+		if err = (&gatewayapi.GatewayController{
+			Client:    mgr.GetClient(),
+			Config:    gatewayConfig,
+			Log:       ctrl.Log.WithName("controller").WithName("gateway"),
+			Scheme:    mgr.GetScheme(),
+			RouteGVKs: routeGVKs,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "gateway")
+			return 1
+		}
+		for _, gvk := range routeGVKs {
+			if err = (&gatewayapi.RouteController{
+				Client: mgr.GetClient(),
+				Config: gatewayConfig,
+				Log:    ctrl.Log.WithName("controller").WithName(strings.ToLower(gvk.Kind)),
+				GVK:    gvk,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", strings.ToLower(gvk.Kind))
+				return 1
+			}
+		}
+	}
+
 	mgr.GetWebhookServer().CertDir = c.flagCertDir
 
 	mgr.GetWebhookServer().Register("/mutate",
@@ -582,6 +663,17 @@ func (c *Command) validateFlags() error {
 		return errors.New("-default-envoy-proxy-concurrency must be >= 0 if set")
 	}
 
+	if c.flagEnableGatewayAPI {
+		switch corev1.ServiceType(c.flagGatewayAPIServiceType) {
+		case corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+		default:
+			return fmt.Errorf("-gateway-api-service-type must be one of %s, %s, or %s", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer)
+		}
+		if c.flagGatewayAPIReplicas < 1 {
+			return errors.New("-gateway-api-replicas must be >= 1")
+		}
+	}
+
 	return nil
 }
 
@@ -129,6 +129,18 @@ func TestRun_FlagValidation(t *testing.T) {
 			},
 			expErr: "-default-envoy-proxy-concurrency must be >= 0 if set",
 		},
+		{
+			flags: []string{"-consul-k8s-image", "foo", "-consul-image", "foo", "-consul-dataplane-image", "consul-dataplane:1.14.0",
+				"-enable-gateway-api", "-gateway-api-service-type", "ExternalName",
+			},
+			expErr: "-gateway-api-service-type must be one of ClusterIP, NodePort, or LoadBalancer",
+		},
+		{
+			flags: []string{"-consul-k8s-image", "foo", "-consul-image", "foo", "-consul-dataplane-image", "consul-dataplane:1.14.0",
+				"-enable-gateway-api", "-gateway-api-replicas=0",
+			},
+			expErr: "-gateway-api-replicas must be >= 1",
+		},
 	}
 
 	for _, c := range cases {
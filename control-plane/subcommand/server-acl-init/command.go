@@ -47,14 +47,18 @@ type Command struct {
 
 	flagClient bool
 
-	flagSyncCatalog        bool
-	flagSyncConsulNodeName string
+	flagSyncCatalog          bool
+	flagSyncConsulNodeName   string
+	flagSyncImportedServices bool
 
 	flagConnectInject       bool
 	flagAuthMethodHost      string
 	flagBindingRuleSelector string
 
-	flagController bool
+	flagController                 bool
+	flagControllerConsulKV         bool
+	flagControllerRegistrations    bool
+	flagControllerExternalServices bool
 
 	flagCreateEntLicenseToken bool
 
@@ -136,6 +140,9 @@ func (c *Command) init() {
 	c.flags.StringVar(&c.flagSyncConsulNodeName, "sync-consul-node-name", "k8s-sync",
 		"The Consul node name to register for catalog sync. Defaults to k8s-sync. To be discoverable "+
 			"via DNS, the name should only contain alpha-numerics and dashes.")
+	c.flags.BoolVar(&c.flagSyncImportedServices, "sync-imported-services", false,
+		"Toggle for allowing catalog sync to route services imported from peers and partitions "+
+			"through an ingress gateway.")
 
 	c.flags.BoolVar(&c.flagConnectInject, "connect-inject", false,
 		"Toggle for configuring ACL login for Connect inject.")
@@ -147,6 +154,12 @@ func (c *Command) init() {
 
 	c.flags.BoolVar(&c.flagController, "controller", false,
 		"Toggle for configuring ACL login for the controller.")
+	c.flags.BoolVar(&c.flagControllerConsulKV, "controller-consul-kv", false,
+		"Toggle for allowing the controller to write to the KV store for ConsulKV resources.")
+	c.flags.BoolVar(&c.flagControllerRegistrations, "controller-registrations", false,
+		"Toggle for allowing the controller to register nodes in the catalog for Registration resources.")
+	c.flags.BoolVar(&c.flagControllerExternalServices, "controller-external-services", false,
+		"Toggle for allowing the controller to register nodes in the catalog for ExternalService resources.")
 
 	c.flags.BoolVar(&c.flagCreateEntLicenseToken, "create-enterprise-license-token", false,
 		"Toggle for creating a token for the enterprise license job.")
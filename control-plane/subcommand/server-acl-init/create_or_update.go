@@ -2,8 +2,8 @@ package serveraclinit
 
 import (
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/consul-k8s/control-plane/acls"
 	"github.com/hashicorp/consul-k8s/control-plane/subcommand/common"
 	"github.com/hashicorp/consul/api"
 	apiv1 "k8s.io/api/core/v1"
@@ -89,30 +89,18 @@ func (c *Command) addRoleAndBindingRule(client *api.Client, componentName, servi
 // updateOrCreateACLRole will query to see if existing role is in place and update them
 // or create them if they do not yet exist.
 func (c *Command) updateOrCreateACLRole(client *api.Client, role *api.ACLRole) error {
-	err := c.untilSucceeds(fmt.Sprintf("update or create acl role for %s", role.Name),
+	return c.untilSucceeds(fmt.Sprintf("update or create acl role for %s", role.Name),
 		func() error {
-			var err error
-			aclRole, _, err := client.ACL().RoleReadByName(role.Name, &api.QueryOptions{})
-			if err != nil {
-				c.log.Error("unable to read ACL Roles", err)
-				return err
-			}
-			if aclRole != nil {
-				_, _, err := client.ACL().RoleUpdate(aclRole, &api.WriteOptions{})
-				if err != nil {
-					c.log.Error("unable to update role", err)
-					return err
-				}
-				return nil
-			}
-			_, _, err = client.ACL().RoleCreate(role, &api.WriteOptions{})
+			// Existing roles are left as they are so that we keep any policies
+			// users attached to them.
+			_, err := acls.CreateOrUpdateRole(client, *role, func(*api.ACLRole) (bool, error) {
+				return false, nil
+			}, &api.WriteOptions{})
 			if err != nil {
-				c.log.Error("unable to create role", err)
-				return err
+				c.log.Error("unable to update or create role", err)
 			}
 			return err
 		})
-	return err
 }
 
 // createConnectBindingRule will query to see if existing binding rules are in place and update them
@@ -302,60 +290,29 @@ func (c *Command) createACL(name, rules string, localToken bool, dc string, isPr
 }
 
 func (c *Command) createOrUpdateACLPolicy(policy api.ACLPolicy, consulClient *api.Client) error {
-	// Attempt to create the ACL policy.
-	_, _, err := consulClient.ACL().PolicyCreate(&policy, &api.WriteOptions{})
-
 	// With the introduction of Consul namespaces, if someone upgrades into a
 	// Consul version with namespace support or changes any of their namespace
 	// settings, the policies associated with their ACL tokens will need to be
 	// updated to be namespace aware.
 	// Allowing the Consul node name to be configurable also requires any sync
 	// policy to be updated in case the node name has changed.
-	if isPolicyExistsErr(err, policy.Name) {
-		if c.flagEnableNamespaces || c.flagSyncCatalog {
-			c.log.Info(fmt.Sprintf("Policy %q already exists, updating", policy.Name))
-
-			// The policy ID is required in any PolicyUpdate call, so first we need to
-			// get the existing policy to extract its ID.
-			existingPolicies, _, err := consulClient.ACL().PolicyList(&api.QueryOptions{})
-			if err != nil {
-				return err
-			}
-
-			// Find the policy that matches our name and description
-			// and that's the ID we need
-			for _, existingPolicy := range existingPolicies {
-				if existingPolicy.Name == policy.Name && existingPolicy.Description == policy.Description {
-					policy.ID = existingPolicy.ID
-				}
-			}
-
-			// This shouldn't happen, because we're looking for a policy
-			// only after we've hit a `Policy already exists` error.
-			// The only time it might happen is if a user has manually created a policy
-			// with this name but used a different description. In this case,
-			// we don't want to overwrite the policy so we just error.
-			if policy.ID == "" {
-				return fmt.Errorf("policy found with name %q but not with expected description %q; "+
-					"if this policy was created manually it must be renamed to something else because this name is reserved by consul-k8s",
-					policy.Name, policy.Description)
-			}
-
-			// Update the policy now that we've found its ID
-			_, _, err = consulClient.ACL().PolicyUpdate(&policy, &api.WriteOptions{})
-			return err
-		} else {
+	_, err := acls.CreateOrUpdatePolicy(consulClient, policy, func(existing *api.ACLPolicy) (bool, error) {
+		if !c.flagEnableNamespaces && !c.flagSyncCatalog {
 			c.log.Info(fmt.Sprintf("Policy %q already exists, skipping update", policy.Name))
-			return nil
+			return false, nil
 		}
-	}
-	return err
-}
 
-// isPolicyExistsErr returns true if err is due to trying to call the
-// policy create API when the policy already exists.
-func isPolicyExistsErr(err error, policyName string) bool {
-	return err != nil &&
-		strings.Contains(err.Error(), "Unexpected response code: 500") &&
-		strings.Contains(err.Error(), fmt.Sprintf("Invalid Policy: A Policy with Name %q already exists", policyName))
+		// The only time the description might not match is if a user has
+		// manually created a policy with this name but used a different
+		// description. In this case, we don't want to overwrite the policy
+		// so we just error.
+		if existing.Description != policy.Description {
+			return false, fmt.Errorf("policy found with name %q but not with expected description %q; "+
+				"if this policy was created manually it must be renamed to something else because this name is reserved by consul-k8s",
+				policy.Name, policy.Description)
+		}
+		c.log.Info(fmt.Sprintf("Policy %q already exists, updating", policy.Name))
+		return true, nil
+	}, &api.WriteOptions{})
+	return err
 }
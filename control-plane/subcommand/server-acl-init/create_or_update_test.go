@@ -66,3 +66,52 @@ func TestCreateOrUpdateACLPolicy_ErrorsIfDescriptionDoesNotMatch(t *testing.T) {
 	require.NoError(err)
 	require.Equal(policyDescription, rereadPolicy.Description)
 }
+
+// Test that existing ACL roles are not updated so that policies users
+// attached to them are kept.
+func TestUpdateOrCreateACLRole_KeepsExistingRole(t *testing.T) {
+	require := require.New(t)
+	cmd := Command{
+		UI:  cli.NewMockUi(),
+		log: hclog.NewNullLogger(),
+	}
+
+	// Start Consul.
+	bootToken := "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"
+	svr, err := testutil.NewTestServerConfigT(t, func(c *testutil.TestServerConfig) {
+		c.ACL.Enabled = true
+		c.ACL.Tokens.InitialManagement = bootToken
+	})
+	require.NoError(err)
+	svr.WaitForLeader(t)
+
+	// Get a Consul client.
+	consul, err := api.NewClient(&api.Config{
+		Address: svr.HTTPAddr,
+		Token:   bootToken,
+	})
+	require.NoError(err)
+
+	// Create the role with a policy attached by a user.
+	for _, name := range []string{"component-policy", "user-policy"} {
+		_, _, err = consul.ACL().PolicyCreate(&api.ACLPolicy{Name: name}, nil)
+		require.NoError(err)
+	}
+	role, _, err := consul.ACL().RoleCreate(&api.ACLRole{
+		Name:     "component-role",
+		Policies: []*api.ACLRolePolicyLink{{Name: "component-policy"}, {Name: "user-policy"}},
+	}, nil)
+	require.NoError(err)
+
+	// Now run the function.
+	err = cmd.updateOrCreateACLRole(consul, &api.ACLRole{
+		Name:     "component-role",
+		Policies: []*api.ACLRolePolicyLink{{Name: "component-policy"}},
+	})
+	require.NoError(err)
+
+	// Check that the role wasn't modified.
+	rereadRole, _, err := consul.ACL().RoleRead(role.ID, nil)
+	require.NoError(err)
+	require.Len(rereadRole.Policies, 2)
+}
@@ -18,6 +18,10 @@ type rulesData struct {
 	InjectEnableNSMirroring bool
 	InjectNSMirroringPrefix string
 	SyncConsulNodeName      string
+	SyncImportedServices    bool
+	ControllerConsulKV      bool
+	ControllerRegistrations bool
+	ControllerExtServices   bool
 }
 
 type gatewayRulesData struct {
@@ -272,10 +276,21 @@ partition "{{ .PartitionName }}" {
 // Attaching a default ACL policy to a namespace requires acl = "write" in the
 // namespace that the policy is defined in, which in our case is "default".
 func (c *Command) syncRules() (string, error) {
+	// Services imported from cluster peers are found by listing the peerings,
+	// and services exported from other partitions by reading the
+	// exported-services config entries and catalogs of those partitions.
+	// They're routed through an ingress gateway whose config entry, and the
+	// service resolvers it routes to, live in the sync namespace.
 	syncRulesTpl := `
   node "{{ .SyncConsulNodeName }}" {
     policy = "write"
   }
+{{- if .EnablePeering }}
+  peering = "read"
+{{- end }}
+{{- if and .SyncImportedServices (not .EnableNamespaces) }}
+  mesh = "write"
+{{- end }}
 {{- if .EnableNamespaces }}
 {{- if .EnablePartitions }}
 partition "{{ .PartitionName }}" {
@@ -303,8 +318,26 @@ partition "{{ .PartitionName }}" {
 {{- if .EnableNamespaces }}
   }
 {{- end }}
+{{- if and .SyncImportedServices .EnableNamespaces (not .EnablePartitions) (not (and .SyncEnableNSMirroring (eq .SyncNSMirroringPrefix ""))) }}
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }
+{{- end }}
 {{- if .EnablePartitions }}
 }
+partition_prefix "" {
+  mesh = "read"
+  node_prefix "" {
+    policy = "read"
+  }
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }
+}
 {{- end }}
 `
 
@@ -391,15 +424,35 @@ partition "default" {
 // acl = "write" is required when creating namespace with a default policy.
 // Attaching a default ACL policy to a namespace requires acl = "write" in the
 // namespace that the policy is defined in, which in our case is "default".
+// key_prefix "" write is required to manage ConsulKV resources, so it's only
+// granted if they're enabled.
+// node_prefix "" write is required to register the nodes of Registration and
+// ExternalService resources in the catalog, so it's only granted if either
+// is enabled.
+// query_prefix "" write is required to manage PreparedQuery resources.
 func (c *Command) controllerRules() (string, error) {
+	// The controller manages admin partitions from the default partition,
+	// which requires operator = "write". Non-default partitions don't
+	// support operator rules.
 	controllerRules := `
 {{- if .EnablePartitions }}
+{{- if eq .PartitionName "default" }}
+operator = "write"
+{{- end }}
 partition "{{ .PartitionName }}" {
   mesh = "write"
   acl = "write"
 {{- else }}
   operator = "write"
   acl = "write"
+{{- end }}
+  query_prefix "" {
+    policy = "write"
+  }
+{{- if or .ControllerRegistrations .ControllerExtServices }}
+  node_prefix "" {
+    policy = "write"
+  }
 {{- end }}
 {{- if .EnableNamespaces }}
 {{- if .InjectEnableNSMirroring }}
@@ -415,6 +468,11 @@ partition "{{ .PartitionName }}" {
       policy = "write"
       intentions = "write"
     }
+{{- if .ControllerConsulKV }}
+    key_prefix "" {
+      policy = "write"
+    }
+{{- end }}
 {{- if .EnableNamespaces }}
   }
 {{- end }}
@@ -438,6 +496,10 @@ func (c *Command) rulesData() rulesData {
 		InjectEnableNSMirroring: c.flagEnableInjectK8SNSMirroring,
 		InjectNSMirroringPrefix: c.flagInjectK8SNSMirroringPrefix,
 		SyncConsulNodeName:      c.flagSyncConsulNodeName,
+		SyncImportedServices:    c.flagSyncImportedServices,
+		ControllerConsulKV:      c.flagControllerConsulKV,
+		ControllerRegistrations: c.flagControllerRegistrations,
+		ControllerExtServices:   c.flagControllerExternalServices,
 	}
 }
 
@@ -525,6 +525,8 @@ func TestSyncRules(t *testing.T) {
 		EnableSyncK8SNSMirroring       bool
 		SyncK8SNSMirroringPrefix       string
 		SyncConsulNodeName             string
+		EnablePeering                  bool
+		SyncImportedServices           bool
 		Expected                       string
 	}{
 		{
@@ -539,6 +541,21 @@ func TestSyncRules(t *testing.T) {
 			Expected: `node "k8s-sync" {
     policy = "write"
   }
+    node_prefix "" {
+      policy = "read"
+    }
+    service_prefix "" {
+      policy = "write"
+    }`,
+		},
+		{
+			Name:               "Namespaces are disabled, peering enabled",
+			SyncConsulNodeName: "k8s-sync",
+			EnablePeering:      true,
+			Expected: `node "k8s-sync" {
+    policy = "write"
+  }
+  peering = "read"
     node_prefix "" {
       policy = "read"
     }
@@ -727,6 +744,17 @@ partition "foo" {
       policy = "write"
     }
   }
+}
+partition_prefix "" {
+  mesh = "read"
+  node_prefix "" {
+    policy = "read"
+  }
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }
 }`,
 		},
 		{
@@ -753,6 +781,17 @@ partition "foo" {
       policy = "write"
     }
   }
+}
+partition_prefix "" {
+  mesh = "read"
+  node_prefix "" {
+    policy = "read"
+  }
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }
 }`,
 		},
 		{
@@ -779,6 +818,17 @@ partition "foo" {
       policy = "write"
     }
   }
+}
+partition_prefix "" {
+  mesh = "read"
+  node_prefix "" {
+    policy = "read"
+  }
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }
 }`,
 		},
 		{
@@ -805,6 +855,17 @@ partition "foo" {
       policy = "write"
     }
   }
+}
+partition_prefix "" {
+  mesh = "read"
+  node_prefix "" {
+    policy = "read"
+  }
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }
 }`,
 		},
 		{
@@ -831,6 +892,17 @@ partition "foo" {
       policy = "write"
     }
   }
+}
+partition_prefix "" {
+  mesh = "read"
+  node_prefix "" {
+    policy = "read"
+  }
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }
 }`,
 		},
 		{
@@ -857,8 +929,81 @@ partition "foo" {
       policy = "write"
     }
   }
+}
+partition_prefix "" {
+  mesh = "read"
+  node_prefix "" {
+    policy = "read"
+  }
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }
 }`,
 		},
+		{
+			Name:                 "Namespaces are disabled, imported services enabled",
+			SyncConsulNodeName:   "k8s-sync",
+			EnablePeering:        true,
+			SyncImportedServices: true,
+			Expected: `node "k8s-sync" {
+    policy = "write"
+  }
+  peering = "read"
+  mesh = "write"
+    node_prefix "" {
+      policy = "read"
+    }
+    service_prefix "" {
+      policy = "write"
+    }`,
+		},
+		{
+			Name:                           "Namespaces are enabled, mirroring disabled, imported services enabled",
+			EnableNamespaces:               true,
+			ConsulSyncDestinationNamespace: "sync-namespace",
+			SyncConsulNodeName:             "k8s-sync",
+			SyncImportedServices:           true,
+			Expected: `node "k8s-sync" {
+    policy = "write"
+  }
+  operator = "write"
+  acl = "write"
+  namespace "sync-namespace" {
+    node_prefix "" {
+      policy = "read"
+    }
+    service_prefix "" {
+      policy = "write"
+    }
+  }
+  namespace_prefix "" {
+    service_prefix "" {
+      policy = "read"
+    }
+  }`,
+		},
+		{
+			Name:                     "Namespaces are enabled, mirroring enabled, prefix empty, imported services enabled",
+			EnableNamespaces:         true,
+			EnableSyncK8SNSMirroring: true,
+			SyncConsulNodeName:       "k8s-sync",
+			SyncImportedServices:     true,
+			Expected: `node "k8s-sync" {
+    policy = "write"
+  }
+  operator = "write"
+  acl = "write"
+  namespace_prefix "" {
+    node_prefix "" {
+      policy = "read"
+    }
+    service_prefix "" {
+      policy = "write"
+    }
+  }`,
+		},
 	}
 
 	for _, tt := range cases {
@@ -870,6 +1015,8 @@ partition "foo" {
 				flagEnableSyncK8SNSMirroring:       tt.EnableSyncK8SNSMirroring,
 				flagSyncK8SNSMirroringPrefix:       tt.SyncK8SNSMirroringPrefix,
 				flagSyncConsulNodeName:             tt.SyncConsulNodeName,
+				flagEnablePeering:                  tt.EnablePeering,
+				flagSyncImportedServices:           tt.SyncImportedServices,
 			}
 
 			syncRules, err := cmd.syncRules()
@@ -1084,63 +1231,168 @@ func TestControllerRules(t *testing.T) {
 		DestConsulNS     string
 		Mirroring        bool
 		MirroringPrefix  string
+		ConsulKV         bool
+		Registrations    bool
+		ExtServices      bool
 		Expected         string
 	}{
 		{
-			Name: "namespaces=disabled, partitions=disabled",
+			Name: "namespaces=disabled, partitions=disabled, consulKV=disabled, registrations=disabled, externalServices=disabled",
 			Expected: `
   operator = "write"
   acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
     service_prefix "" {
       policy = "write"
       intentions = "write"
+    }`,
+		},
+		{
+			Name:        "namespaces=disabled, partitions=disabled, externalServices=enabled",
+			ExtServices: true,
+			Expected: `
+  operator = "write"
+  acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
+    service_prefix "" {
+      policy = "write"
+      intentions = "write"
+    }`,
+		},
+		{
+			Name:          "namespaces=disabled, partitions=disabled",
+			ConsulKV:      true,
+			Registrations: true,
+			Expected: `
+  operator = "write"
+  acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
+    service_prefix "" {
+      policy = "write"
+      intentions = "write"
+    }
+    key_prefix "" {
+      policy = "write"
     }`,
 		},
 		{
 			Name:             "namespaces=enabled, consulDestNS=consul, partitions=disabled",
+			ConsulKV:         true,
+			Registrations:    true,
 			EnableNamespaces: true,
 			DestConsulNS:     "consul",
 			Expected: `
   operator = "write"
   acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
   namespace "consul" {
     service_prefix "" {
       policy = "write"
       intentions = "write"
     }
+    key_prefix "" {
+      policy = "write"
+    }
   }`,
 		},
 		{
 			Name:             "namespaces=enabled, mirroring=true, partitions=disabled",
+			ConsulKV:         true,
+			Registrations:    true,
 			EnableNamespaces: true,
 			Mirroring:        true,
 			Expected: `
   operator = "write"
   acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
   namespace_prefix "" {
     service_prefix "" {
       policy = "write"
       intentions = "write"
     }
+    key_prefix "" {
+      policy = "write"
+    }
   }`,
 		},
 		{
 			Name:             "namespaces=enabled, mirroring=true, mirroringPrefix=prefix-, partitions=disabled",
+			ConsulKV:         true,
+			Registrations:    true,
 			EnableNamespaces: true,
 			Mirroring:        true,
 			MirroringPrefix:  "prefix-",
 			Expected: `
   operator = "write"
   acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
   namespace_prefix "prefix-" {
     service_prefix "" {
       policy = "write"
       intentions = "write"
     }
+    key_prefix "" {
+      policy = "write"
+    }
   }`,
+		},
+		{
+			Name:             "namespaces=disabled, partitions=enabled",
+			ConsulKV:         true,
+			Registrations:    true,
+			EnablePartitions: true,
+			PartitionName:    "part-1",
+			Expected: `
+partition "part-1" {
+  mesh = "write"
+  acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
+    policy = "write"
+    service_prefix "" {
+      policy = "write"
+      intentions = "write"
+    }
+    key_prefix "" {
+      policy = "write"
+    }
+}`,
 		},
 		{
 			Name:             "namespaces=enabled, consulDestNS=consul, partitions=enabled",
+			ConsulKV:         true,
+			Registrations:    true,
 			EnablePartitions: true,
 			PartitionName:    "part-1",
 			EnableNamespaces: true,
@@ -1149,17 +1401,59 @@ func TestControllerRules(t *testing.T) {
 partition "part-1" {
   mesh = "write"
   acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
   namespace "consul" {
     policy = "write"
     service_prefix "" {
       policy = "write"
       intentions = "write"
     }
+    key_prefix "" {
+      policy = "write"
+    }
+  }
+}`,
+		},
+		{
+			Name:             "namespaces=enabled, consulDestNS=consul, partitions=enabled, partition=default",
+			ConsulKV:         true,
+			Registrations:    true,
+			EnablePartitions: true,
+			PartitionName:    "default",
+			EnableNamespaces: true,
+			DestConsulNS:     "consul",
+			Expected: `
+operator = "write"
+partition "default" {
+  mesh = "write"
+  acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
+  namespace "consul" {
+    policy = "write"
+    service_prefix "" {
+      policy = "write"
+      intentions = "write"
+    }
+    key_prefix "" {
+      policy = "write"
+    }
   }
 }`,
 		},
 		{
 			Name:             "namespaces=enabled, mirroring=true, partitions=enabled",
+			ConsulKV:         true,
+			Registrations:    true,
 			EnablePartitions: true,
 			PartitionName:    "part-1",
 			EnableNamespaces: true,
@@ -1168,17 +1462,28 @@ partition "part-1" {
 partition "part-1" {
   mesh = "write"
   acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
   namespace_prefix "" {
     policy = "write"
     service_prefix "" {
       policy = "write"
       intentions = "write"
     }
+    key_prefix "" {
+      policy = "write"
+    }
   }
 }`,
 		},
 		{
 			Name:             "namespaces=enabled, mirroring=true, mirroringPrefix=prefix-, partitions=enabled",
+			ConsulKV:         true,
+			Registrations:    true,
 			EnablePartitions: true,
 			PartitionName:    "part-1",
 			EnableNamespaces: true,
@@ -1188,12 +1493,21 @@ partition "part-1" {
 partition "part-1" {
   mesh = "write"
   acl = "write"
+  query_prefix "" {
+    policy = "write"
+  }
+  node_prefix "" {
+    policy = "write"
+  }
   namespace_prefix "prefix-" {
     policy = "write"
     service_prefix "" {
       policy = "write"
       intentions = "write"
     }
+    key_prefix "" {
+      policy = "write"
+    }
   }
 }`,
 		},
@@ -1207,6 +1521,9 @@ partition "part-1" {
 				flagEnableInjectK8SNSMirroring:       tt.Mirroring,
 				flagInjectK8SNSMirroringPrefix:       tt.MirroringPrefix,
 				consulFlags:                          &flags.ConsulFlags{Partition: tt.PartitionName},
+				flagControllerConsulKV:               tt.ConsulKV,
+				flagControllerRegistrations:          tt.Registrations,
+				flagControllerExternalServices:       tt.ExtServices,
 			}
 
 			rules, err := cmd.controllerRules()
@@ -2,6 +2,7 @@ package synccatalog
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
@@ -17,6 +18,7 @@ import (
 	catalogtok8s "github.com/hashicorp/consul-k8s/control-plane/catalog/to-k8s"
 	"github.com/hashicorp/consul-k8s/control-plane/consul"
 	"github.com/hashicorp/consul-k8s/control-plane/helper/controller"
+	"github.com/hashicorp/consul-k8s/control-plane/namespaces"
 	"github.com/hashicorp/consul-k8s/control-plane/subcommand"
 	"github.com/hashicorp/consul-k8s/control-plane/subcommand/common"
 	"github.com/hashicorp/consul-k8s/control-plane/subcommand/flags"
@@ -47,6 +49,12 @@ type Command struct {
 	flagConsulServicePrefix   string
 	flagK8SSourceNamespace    string
 	flagK8SWriteNamespace     string
+	flagSyncPeerServices      bool
+	flagSyncPartitionServices bool
+	flagK8SImportedNamespace  string
+	flagImportedPollInterval  time.Duration
+	flagImportedGateway       string
+	flagImportedGatewayPort   int
 	flagConsulWritePeriod     time.Duration
 	flagSyncClusterIPServices bool
 	flagSyncLBEndpoints       bool
@@ -100,6 +108,25 @@ func (c *Command) init() {
 	c.flags.StringVar(&c.flagK8SWriteNamespace, "k8s-write-namespace", metav1.NamespaceDefault,
 		"The Kubernetes namespace to write to for services from Consul. "+
 			"If this is not set then it will default to the default namespace.")
+	c.flags.BoolVar(&c.flagSyncPeerServices, "sync-peer-services", false,
+		"If true, services imported from cluster peers will be synced to Kubernetes as ClusterIP services "+
+			"routed through the ingress gateway set with -imported-services-gateway. Requires -to-k8s.")
+	c.flags.BoolVar(&c.flagSyncPartitionServices, "sync-partition-services", false,
+		"[Enterprise Only] If true, services exported to this admin partition from other admin partitions "+
+			"will be synced to Kubernetes as ClusterIP services routed through the ingress gateway set with "+
+			"-imported-services-gateway. Requires -to-k8s.")
+	c.flags.StringVar(&c.flagK8SImportedNamespace, "k8s-imported-services-namespace", "",
+		"The Kubernetes namespace to write services imported from peers and partitions to. "+
+			"Defaults to -k8s-write-namespace.")
+	c.flags.DurationVar(&c.flagImportedPollInterval, "imported-services-poll-interval", 30*time.Second,
+		"The interval to poll Consul for services imported from peers and partitions.")
+	c.flags.StringVar(&c.flagImportedGateway, "imported-services-gateway", "",
+		"The name of the ingress gateway that routes to services imported from peers and partitions. "+
+			"Its config entry is managed by sync so it must be dedicated to imported services. It must be "+
+			"registered in the Consul namespace that -k8s-imported-services-namespace maps to.")
+	c.flags.IntVar(&c.flagImportedGatewayPort, "imported-services-gateway-base-port", catalogtok8s.DefaultGatewayBasePort,
+		"The first port of the ingress gateway listeners for services imported from peers and partitions. "+
+			"Each imported service gets its own listener port.")
 	c.flags.StringVar(&c.flagConsulDomain, "consul-domain", "consul",
 		"The domain for Consul services to use when writing services to "+
 			"Kubernetes. Defaults to consul.")
@@ -305,10 +332,11 @@ func (c *Command) Run(args []string) int {
 	var toK8SCh chan struct{}
 	if c.flagToK8S {
 		sink := &catalogtok8s.K8SSink{
-			Client:    c.clientset,
-			Namespace: c.flagK8SWriteNamespace,
-			Log:       c.logger.Named("to-k8s/sink"),
-			Ctx:       ctx,
+			Client:            c.clientset,
+			Namespace:         c.flagK8SWriteNamespace,
+			ImportedNamespace: c.flagK8SImportedNamespace,
+			Log:               c.logger.Named("to-k8s/sink"),
+			Ctx:               ctx,
 		}
 
 		source := &catalogtok8s.Source{
@@ -322,6 +350,26 @@ func (c *Command) Run(args []string) int {
 		}
 		go source.Run(ctx)
 
+		if c.flagSyncPeerServices || c.flagSyncPartitionServices {
+			importedSource := &catalogtok8s.ImportedSource{
+				ConsulClientConfig:  consulConfig,
+				ConsulServerConnMgr: c.connMgr,
+				Domain:              c.flagConsulDomain,
+				Sink:                sink,
+				Prefix:              c.flagK8SServicePrefix,
+				Log:                 c.logger.Named("to-k8s/imported-source"),
+				SyncPeers:           c.flagSyncPeerServices,
+				SyncPartitions:      c.flagSyncPartitionServices,
+				Partition:           c.consul.Partition,
+				EnableNamespaces:    c.flagEnableNamespaces,
+				Gateway:             c.flagImportedGateway,
+				GatewayNamespace:    c.importedGatewayNamespace(),
+				GatewayBasePort:     int32(c.flagImportedGatewayPort),
+				PollPeriod:          c.flagImportedPollInterval,
+			}
+			go importedSource.Run(ctx)
+		}
+
 		// Build the controller and start it
 		ctl := &controller.Controller{
 			Log:      c.logger.Named("to-k8s/controller"),
@@ -423,9 +471,28 @@ func (c *Command) validateFlags() error {
 		)
 	}
 
+	if (c.flagSyncPeerServices || c.flagSyncPartitionServices) && !c.flagToK8S {
+		return errors.New("-sync-peer-services and -sync-partition-services require -to-k8s")
+	}
+	if (c.flagSyncPeerServices || c.flagSyncPartitionServices) && c.flagImportedGateway == "" {
+		return errors.New("-sync-peer-services and -sync-partition-services require -imported-services-gateway")
+	}
+
 	return nil
 }
 
+// importedGatewayNamespace returns the Consul namespace of the ingress gateway
+// for imported services: the namespace the Kubernetes namespace imported
+// services are written to is synced to.
+func (c *Command) importedGatewayNamespace() string {
+	k8sNS := c.flagK8SImportedNamespace
+	if k8sNS == "" {
+		k8sNS = c.flagK8SWriteNamespace
+	}
+	return namespaces.ConsulNamespace(k8sNS, c.flagEnableNamespaces, c.flagConsulDestinationNamespace,
+		c.flagEnableK8SNSMirroring, c.flagK8SNSMirroringPrefix)
+}
+
 const synopsis = "Sync Kubernetes services and Consul services."
 const help = `
 Usage: consul-k8s-control-plane sync-catalog [options]
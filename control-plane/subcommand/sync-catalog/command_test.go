@@ -36,6 +36,14 @@ func TestRun_FlagValidation(t *testing.T) {
 			ExpErr: "-consul-node-name=5r9OPGfSRXUdGzNjBdAwmhCBrzHDNYs4XjZVR4wp7lSLIzqwS0ta51nBLIN0TMPV-too-long is invalid: node name will not be discoverable " +
 				"via DNS due to it being too long. Valid lengths are between 1 and 63 bytes",
 		},
+		{
+			Flags:  []string{"-to-k8s=false", "-sync-peer-services"},
+			ExpErr: "-sync-peer-services and -sync-partition-services require -to-k8s",
+		},
+		{
+			Flags:  []string{"-sync-partition-services"},
+			ExpErr: "-sync-peer-services and -sync-partition-services require -imported-services-gateway",
+		},
 	}
 
 	for _, c := range cases {
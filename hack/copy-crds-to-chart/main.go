@@ -60,6 +60,26 @@ func realMain(helmPath string) error {
 		withLabels := append(splitOnNewlines[0:9], append(labelLines, splitOnNewlines[9:]...)...)
 		contents = strings.Join(withLabels, "\n")
 
+		// CRDs that serve more than one version are converted between
+		// versions by the controller's conversion webhook. Its CA bundle is
+		// set by the controller.
+		if strings.Contains(contents, "storage: false") {
+			conversionLines := []string{
+				`spec:`,
+				`  conversion:`,
+				`    strategy: Webhook`,
+				`    webhook:`,
+				`      clientConfig:`,
+				`        service:`,
+				`          name: {{ template "consul.fullname" . }}-controller-webhook`,
+				`          namespace: {{ .Release.Namespace }}`,
+				`          path: /convert`,
+				`      conversionReviewVersions:`,
+				`      - v1`,
+			}
+			contents = strings.Replace(contents, "\nspec:\n", "\n"+strings.Join(conversionLines, "\n")+"\n", 1)
+		}
+
 		// Construct the destination filename.
 		filenameSplit := strings.Split(info.Name(), "_")
 		crdName := filenameSplit[1]